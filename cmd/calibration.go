@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"aibot/internal/strategy"
+)
+
+// runCalibration merges the signal outcomes of session reports into one calibration report
+func runCalibration(args []string) int {
+	flags := flag.NewFlagSet("calibration", flag.ExitOnError)
+	bins := flags.Int("bins", strategy.DefaultCalibrationBins, "Number of confidence bins")
+	output := flags.String("output", "", "Write the merged report as JSON to this file")
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		matches, err := filepath.Glob(filepath.Join("./data/sessions", "session-*.json"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list session reports: %v\n", err)
+			return 1
+		}
+		paths = matches
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "No session reports found; pass report files as arguments")
+		return 1
+	}
+
+	var outcomes []strategy.SignalOutcome
+	pending := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", path, err)
+			return 1
+		}
+		var report struct {
+			Calibration *strategy.CalibrationReport `json:"calibration"`
+		}
+		if err := json.Unmarshal(data, &report); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse %s: %v\n", path, err)
+			return 1
+		}
+		if report.Calibration != nil {
+			outcomes = append(outcomes, report.Calibration.Outcomes...)
+			pending += report.Calibration.Pending
+		}
+	}
+
+	merged := strategy.BuildCalibrationReport(outcomes, *bins)
+	merged.Pending = pending
+
+	fmt.Printf("Signal calibration (%d reports, %d closed trades)\n", len(paths), len(outcomes))
+	fmt.Println("==================================================")
+	fmt.Print(merged.FormatText())
+
+	if *output != "" {
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to marshal report: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(*output, data, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *output, err)
+			return 1
+		}
+		fmt.Printf("\nReport written to %s\n", *output)
+	}
+	return 0
+}
@@ -0,0 +1,555 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aibot/internal/bot"
+	"aibot/internal/config"
+	"aibot/internal/data"
+	"aibot/internal/ledger"
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+)
+
+// clientCommands maps CLI subcommands to control protocol commands
+var clientCommands = map[string]string{
+	"status":       bot.ControlStatus,
+	"pause":        bot.ControlPause,
+	"resume":       bot.ControlResume,
+	"close-all":    bot.ControlCloseAll,
+	"log-level":    bot.ControlSetLogLevel,
+	"health":       bot.ControlHealth,
+	"update-grid":  bot.ControlUpdateGrid,
+	"candles":      bot.ControlCandles,
+	"subscribe":    bot.ControlSubscribe,
+	"unsubscribe":  bot.ControlUnsubscribe,
+	"place-order":  bot.ControlPlaceOrder,
+	"modify-order": bot.ControlModifyOrder,
+	"cancel-order": bot.ControlCancelOrder,
+	"grid-levels":  bot.ControlGridLevels,
+	"ledger":       bot.ControlLedger,
+	"lock-mode":    bot.ControlLockMode,
+	"unlock-mode":  bot.ControlUnlockMode,
+}
+
+// runClient sends a command to the control server of a running bot and prints its state
+func runClient(command string, args []string) int {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file of the running bot, used for the control address")
+	socket := flags.String("socket", "", "Control socket path (overrides the configuration)")
+	address := flags.String("addr", "", "Loopback control address (overrides the configuration)")
+	timeout := flags.Duration("timeout", 10*time.Second, "Request timeout")
+	var gridUpdate bot.GridUpdate
+	if command == "update-grid" {
+		flags.Float64Var(&gridUpdate.Spacing, "spacing", 0, "Distance between grid levels as a fraction of price, e.g. 0.005")
+		flags.IntVar(&gridUpdate.Levels, "levels", 0, "Number of grid levels")
+		flags.Float64Var(&gridUpdate.UpperBound, "upper", 0, "Upper grid bound (with -lower)")
+		flags.Float64Var(&gridUpdate.LowerBound, "lower", 0, "Lower grid bound (with -upper)")
+		flags.BoolVar(&gridUpdate.Reset, "reset", false, "Drop all overrides and return to the calculated grid")
+	}
+	var query data.CandleQuery
+	var since time.Duration
+	var timeframe, format, output string
+	if command == "candles" {
+		flags.StringVar(&query.Symbol, "symbol", "", "Symbol (default: the active symbol)")
+		flags.StringVar(&timeframe, "timeframe", string(data.Timeframe3s), "Candle timeframe, e.g. 1s, 3s or 15s")
+		flags.DurationVar(&since, "since", 0, "Only candles opened within this period (default: all kept in memory)")
+		flags.IntVar(&query.Limit, "limit", 0, "Most recent candles only (0: all)")
+		flags.BoolVar(&query.IncludeCurrent, "current", false, "Include the candle still being built")
+		flags.StringVar(&format, "format", data.ExportFormatCSV, "Output format: csv or json")
+		flags.StringVar(&output, "output", "", "Write the candles to this file instead of stdout")
+	}
+	var order bot.PlaceOrderParams
+	var modify bot.ModifyOrderParams
+	var side, orderType, positionType string
+	if command == "place-order" {
+		flags.StringVar(&order.Symbol, "symbol", "", "Symbol")
+		flags.StringVar(&side, "side", "", "Order side: buy or sell")
+		flags.StringVar(&orderType, "type", "", "Order type: market or limit (default: limit with -price, market otherwise)")
+		flags.Float64Var(&order.Quantity, "qty", 0, "Order quantity")
+		flags.Float64Var(&order.Price, "price", 0, "Limit price")
+		flags.StringVar(&positionType, "position", "", "Position side in hedge mode: long or short (default: derived from -side and -reduce-only)")
+		flags.BoolVar(&order.ReduceOnly, "reduce-only", false, "Only reduce an open position")
+		flags.BoolVar(&order.PostOnly, "post-only", false, "Reject the limit order instead of taking liquidity")
+	}
+	if command == "modify-order" || command == "cancel-order" {
+		flags.StringVar(&modify.OrderID, "id", "", "Exchange order ID")
+	}
+	if command == "modify-order" {
+		flags.Float64Var(&modify.Quantity, "qty", 0, "New quantity (default: the remaining quantity)")
+		flags.Float64Var(&modify.Price, "price", 0, "New limit price (default: unchanged)")
+	}
+	var ledgerSymbol string
+	var ledgerLimit int
+	if command == "ledger" {
+		flags.StringVar(&ledgerSymbol, "symbol", "", "Only postings of this symbol (default: all)")
+		flags.IntVar(&ledgerLimit, "limit", 20, "Most recent postings to list")
+	}
+	flags.Parse(args)
+
+	network, target, err := controlTarget(*configFile, *socket, *address)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	request := bot.ControlRequest{Command: clientCommands[command]}
+	if command == "update-grid" {
+		if err := gridUpdate.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid grid update: %v\n", err)
+			return 1
+		}
+		request.Grid = &gridUpdate
+	}
+	if command == "candles" {
+		query.Timeframe = data.CandleTimeframe(timeframe)
+		if since > 0 {
+			query.Start = time.Now().Add(-since)
+		}
+		if format != data.ExportFormatCSV && format != data.ExportFormatJSON {
+			fmt.Fprintf(os.Stderr, "Unsupported format %q: use csv or json\n", format)
+			return 1
+		}
+		request.Query = &query
+	}
+	if command == "place-order" {
+		order.Side = types.OrderSide(strings.ToLower(side))
+		order.Type = types.OrderType(strings.ToLower(orderType))
+		order.PositionType = types.PositionType(strings.ToLower(positionType))
+		if err := order.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid order: %v\n", err)
+			return 1
+		}
+		request.Order = &order
+	}
+	if command == "modify-order" {
+		if err := modify.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid order change: %v\n", err)
+			return 1
+		}
+		request.Modify = &modify
+	}
+	if command == "cancel-order" {
+		if err := (bot.CancelOrderParams{OrderID: modify.OrderID}).Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid cancellation: %v\n", err)
+			return 1
+		}
+		request.OrderID = modify.OrderID
+	}
+	if command == "ledger" {
+		request.Symbol = ledgerSymbol
+		request.Limit = ledgerLimit
+	}
+	if command == "subscribe" || command == "unsubscribe" {
+		// subscribe <symbol>...; symbols are streamed and analyzed but only the active symbol is traded
+		if len(flags.Args()) == 0 {
+			fmt.Fprintf(os.Stderr, "Usage: %s <symbol>...\n", command)
+			return 1
+		}
+		request.Symbols = flags.Args()
+	}
+	if command == "lock-mode" {
+		// lock-mode <mode> [reason...]
+		positional := flags.Args()
+		if len(positional) == 0 {
+			fmt.Fprintln(os.Stderr, "Usage: lock-mode <mode> [reason...]")
+			return 1
+		}
+		request.Mode = bot.TradingMode(strings.ToLower(positional[0]))
+		request.Reason = strings.Join(positional[1:], " ")
+		if err := (bot.LockModeParams{Mode: request.Mode, Reason: request.Reason}).Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid mode lock: %v\n", err)
+			return 1
+		}
+	}
+	if command == "log-level" {
+		// log-level [component] <level>; without arguments the levels in force are listed
+		switch positional := flags.Args(); len(positional) {
+		case 0:
+			request.Command = bot.ControlLogLevels
+		case 1:
+			request.Level = positional[0]
+		case 2:
+			request.Component, request.Level = positional[0], positional[1]
+		default:
+			fmt.Fprintln(os.Stderr, "Usage: log-level [component] <level>")
+			return 1
+		}
+	}
+
+	response, err := bot.SendControlRequest(network, target, request, *timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Is the bot running? %v\n", err)
+		return 1
+	}
+	if !response.OK {
+		fmt.Fprintf(os.Stderr, "%s failed: %s\n", command, response.Error)
+		if response.Order != nil {
+			printOrder(response.Order)
+		}
+		return 1
+	}
+
+	if command == "candles" {
+		return writeCandles(response.Candles, format, output)
+	}
+	if command == "grid-levels" {
+		printGridLevels(response.GridLevels)
+		return 0
+	}
+	if command == "ledger" {
+		printLedger(response.Ledger, response.Postings)
+		return 0
+	}
+	if response.Order != nil {
+		fmt.Printf("✅ %s accepted\n\n", command)
+		printOrder(response.Order)
+		return 0
+	}
+	if command != "status" && command != "health" && request.Command != bot.ControlLogLevels {
+		fmt.Printf("✅ %s accepted\n\n", command)
+	}
+	if response.LogLevels != nil {
+		printLogLevels(response.LogLevels)
+		return 0
+	}
+	if response.Health != nil {
+		printHealth(response.Health)
+		if response.Health.Status != bot.HealthHealthy {
+			return 2
+		}
+		return 0
+	}
+	printStatus(response.State, response.Performance, response.Grid, response.Account, response.Violations)
+	return 0
+}
+
+// controlTarget returns the network and address of the control server: the -socket or -addr override, or
+// the socket, else the address, of the configuration
+func controlTarget(configFile, socket, address string) (string, string, error) {
+	if socket != "" {
+		return "unix", socket, nil
+	}
+	if address != "" {
+		return "tcp", address, nil
+	}
+
+	control := config.DefaultConfig().Control
+	if _, err := os.Stat(configFile); err == nil {
+		loaded, err := config.LoadConfig(configFile)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to load configuration: %w", err)
+		}
+		control = loaded.Control
+	}
+	// The socket is preferred so no network port is needed
+	if control.SocketPath != "" {
+		return "unix", control.SocketPath, nil
+	}
+	if control.Address != "" {
+		return "tcp", control.Address, nil
+	}
+	return "", "", fmt.Errorf("control server is disabled in the configuration; pass -socket or -addr to override")
+}
+
+// printOrder prints an order returned by an order command
+func printOrder(order *types.Order) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Order:\t%s (%s)\n", order.ID, order.ClientOrderID)
+	fmt.Fprintf(w, "Symbol:\t%s\n", order.Symbol)
+	fmt.Fprintf(w, "Side:\t%s %s %s\n", order.Type, order.Side, order.PositionType)
+	fmt.Fprintf(w, "Quantity:\t%.6f (filled %.6f)\n", order.Quantity, order.FilledQty)
+	if order.Price > 0 {
+		fmt.Fprintf(w, "Price:\t%.2f\n", order.Price)
+	}
+	if order.AvgFillPrice > 0 {
+		fmt.Fprintf(w, "Average fill:\t%.2f\n", order.AvgFillPrice)
+	}
+	fmt.Fprintf(w, "Status:\t%s\n", order.Status)
+	w.Flush()
+}
+
+// writeCandles exports candles to a file, or to stdout if no file is given
+func writeCandles(candles []types.OHLCV, format, output string) int {
+	if output == "" {
+		if err := data.ExportCandles(os.Stdout, candles, format); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", output, err)
+		return 1
+	}
+	if err := data.ExportCandles(file, candles, format); err != nil {
+		file.Close()
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	if err := file.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", output, err)
+		return 1
+	}
+	fmt.Printf("%d candles written to %s\n", len(candles), output)
+	return 0
+}
+
+// printLogLevels prints the log level in force for each component
+func printLogLevels(levels map[string]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tLEVEL")
+	for _, component := range logging.SortedComponents(levels) {
+		fmt.Fprintf(w, "  %s\t%s\n", component, levels[component])
+	}
+	w.Flush()
+}
+
+// printHealth prints the resource watchdog report
+func printHealth(report *bot.HealthReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	sample := report.Sample
+
+	fmt.Fprintln(w, "HEALTH\t")
+	fmt.Fprintf(w, "  Status\t%s\n", strings.ToUpper(report.Status))
+	if report.Degraded {
+		fmt.Fprintln(w, "  Degraded\tswitched to idle, resume once healthy")
+	}
+	fmt.Fprintf(w, "  Goroutines\t%d\n", sample.Goroutines)
+	fmt.Fprintf(w, "  Memory\t%.1f MB heap (%.1f MB from OS, %d GCs)\n", sample.HeapMB, sample.SysMB, sample.GCCount)
+	fmt.Fprintf(w, "  Data backlog\t%d/%d\n", sample.DataBacklog, sample.DataCapacity)
+	fmt.Fprintf(w, "  Signal backlog\t%d/%d\n", sample.SignalBacklog, sample.SignalCapacity)
+	if sample.LastTick.IsZero() {
+		fmt.Fprintln(w, "  Stream lag\tno ticks yet")
+	} else {
+		fmt.Fprintf(w, "  Stream lag\t%s since last tick (event lag %s)\n",
+			sample.StreamLag.Round(time.Millisecond), sample.EventLag.Round(time.Millisecond))
+	}
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "  Issue\t%s (since %s)\n", issue.Message, formatClientTime(issue.Since))
+	}
+	fmt.Fprintf(w, "  Checked\t%s\n", formatClientTime(report.CheckedAt))
+	w.Flush()
+}
+
+// printGridLevels prints the fill statistics of each grid level offset, lowest level first
+func printGridLevels(levels []strategy.GridLevelStats) {
+	if len(levels) == 0 {
+		fmt.Println("No grid has been laid out this session")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OFFSET\tPRICE\tALLOCATION\tFILLS\tBUY\tSELL\tVOLUME\tPROFIT\tRETURN\tAVG REFILL\tLAST FILL")
+	for _, level := range levels {
+		refill := "-"
+		if level.Refills > 0 {
+			refill = level.AvgRefill.Round(time.Second).String()
+		}
+		fmt.Fprintf(w, "%+d\t%.4f\t%.2f\t%d\t%d\t%d\t%.2f\t%.4f\t%.2f%%\t%s\t%s\n",
+			level.Offset, level.Price, level.Allocation, level.Fills, level.BuyFills, level.SellFills,
+			level.Volume, level.Profit, level.ReturnOnAllocation()*100, refill, formatClientTime(level.LastFill))
+	}
+	w.Flush()
+}
+
+// printLedger prints the ledger balance, category totals per symbol and the most recent postings
+func printLedger(summary *ledger.Summary, postings []ledger.Entry) {
+	if summary == nil {
+		fmt.Println("No ledger available")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Opening balance\t%.2f\n", summary.OpeningBalance)
+	fmt.Fprintf(w, "Balance\t%.2f\n", summary.Balance)
+	if summary.Swept != 0 {
+		fmt.Fprintf(w, "Swept\t%.2f\n", summary.Swept)
+	}
+	fmt.Fprintf(w, "Postings\t%d\n\n", summary.Entries)
+
+	symbols := make([]string, 0, len(summary.BySymbol))
+	for symbol := range summary.BySymbol {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	fmt.Fprintln(w, "SYMBOL\tREALIZED PNL\tCOMMISSION\tFUNDING\tSLIPPAGE\tNET")
+	for _, symbol := range symbols {
+		totals := summary.BySymbol[symbol]
+		fmt.Fprintf(w, "%s\t%.4f\t%.4f\t%.4f\t%.4f\t%.4f\n", symbol, totals.RealizedPnL, totals.Commission, totals.Funding, totals.Slippage, totals.Net)
+	}
+	totals := summary.Totals
+	fmt.Fprintf(w, "TOTAL\t%.4f\t%.4f\t%.4f\t%.4f\t%.4f\n", totals.RealizedPnL, totals.Commission, totals.Funding, totals.Slippage, totals.Net)
+	w.Flush()
+
+	if len(postings) == 0 {
+		return
+	}
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tSYMBOL\tCATEGORY\tDEBIT\tCREDIT\tAMOUNT\tREFERENCE")
+	for _, posting := range postings {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%.4f\t%s\n", posting.ID, formatClientTime(posting.Timestamp), posting.Symbol,
+			posting.Category, posting.Debit, posting.Credit, posting.Amount, posting.Reference)
+	}
+	w.Flush()
+}
+
+// printStatus prints bot state and performance as aligned tables
+func printStatus(state *bot.BotState, performance *bot.PerformanceMetrics, grid *bot.GridUpdate, account *bot.AccountSnapshot,
+	violations []strategy.PolicyViolation) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	if state != nil {
+		fmt.Fprintln(w, "STATE\t")
+		fmt.Fprintf(w, "  Mode\t%s\n", strings.ToUpper(string(state.Mode)))
+		if lock := state.ModeLock; lock != nil {
+			fmt.Fprintf(w, "  Mode lock\t%s since %s (%d transitions suppressed) %s\n",
+				strings.ToUpper(string(lock.Mode)), formatClientTime(lock.LockedAt), lock.Suppressed, lock.Reason)
+		}
+		fmt.Fprintf(w, "  Active\t%v\n", state.IsActive)
+		fmt.Fprintf(w, "  Symbol\t%s\n", state.CurrentSymbol)
+		if len(state.Symbols) > 1 {
+			fmt.Fprintf(w, "  Streaming\t%s\n", strings.Join(state.Symbols, ", "))
+		}
+		if state.GridBounds.UpperBound > 0 {
+			fmt.Fprintf(w, "  Grid\t%.4f - %.4f\n", state.GridBounds.LowerBound, state.GridBounds.UpperBound)
+		}
+		if grid != nil {
+			fmt.Fprintf(w, "  Grid override\t%s\n", formatGridUpdate(grid))
+		}
+		if state.BreakoutInfo != nil {
+			fmt.Fprintf(w, "  Breakout\t%s @ %.4f (confirmed: %v)\n",
+				state.BreakoutInfo.BreakoutType, state.BreakoutInfo.EntryPrice, state.BreakoutInfo.IsConfirmed)
+		}
+		// The account section lists the same estimates with each position
+		liquidations := state.Liquidations
+		if account != nil {
+			liquidations = nil
+		}
+		for _, liquidation := range liquidations {
+			if liquidation.LiquidationPrice <= 0 {
+				fmt.Fprintf(w, "  Liquidation\t%s %s: none\n", liquidation.Symbol, liquidation.PositionType)
+				continue
+			}
+			warning := ""
+			if liquidation.NearLiquidation {
+				warning = " (near liquidation)"
+			}
+			fmt.Fprintf(w, "  Liquidation\t%s %s @ %.4f (%.2f%% away)%s\n", liquidation.Symbol, liquidation.PositionType,
+				liquidation.LiquidationPrice, liquidation.Distance*100, warning)
+		}
+		fmt.Fprintf(w, "  Session start\t%s\n", formatClientTime(state.SessionStart))
+		fmt.Fprintf(w, "  Last update\t%s\n", formatClientTime(state.LastUpdateTime))
+		fmt.Fprintln(w, "\t")
+	}
+
+	if performance != nil {
+		fmt.Fprintln(w, "PERFORMANCE\t")
+		fmt.Fprintf(w, "  Trades\t%d (%d won / %d lost)\n",
+			performance.TotalTrades, performance.WinningTrades, performance.LosingTrades)
+		fmt.Fprintf(w, "  Win rate\t%.2f%%\n", performance.WinRate*100)
+		fmt.Fprintf(w, "  Total PnL\t%.2f\n", performance.TotalPnL)
+		fmt.Fprintf(w, "  Profit factor\t%.2f\n", performance.ProfitFactor)
+		fmt.Fprintf(w, "  Sharpe ratio\t%.2f\n", performance.SharpeRatio)
+		fmt.Fprintf(w, "  Drawdown\t%.2f%% (max %.2f%%)\n", performance.CurrentDrawdown*100, performance.MaxDrawdown*100)
+		fmt.Fprintf(w, "  Last trade\t%s\n", formatClientTime(performance.LastTradeTime))
+		fmt.Fprintln(w, "\t")
+	}
+
+	if account != nil {
+		printAccount(w, account)
+	}
+
+	if len(violations) > 0 {
+		printPolicyViolations(w, violations)
+	}
+
+	w.Flush()
+}
+
+// statusViolations is the number of recent risk policy violations listed in the status table
+const statusViolations = 5
+
+// printPolicyViolations prints the most recent risk policy violations, newest first
+func printPolicyViolations(w *tabwriter.Writer, violations []strategy.PolicyViolation) {
+	fmt.Fprintf(w, "RISK POLICY\t%d recent violations\n", len(violations))
+	for i := len(violations) - 1; i >= 0 && i >= len(violations)-statusViolations; i-- {
+		violation := violations[i]
+		fmt.Fprintf(w, "  %s\t%s %s: %s\n", formatClientTime(violation.Time), violation.Symbol, violation.Rule, violation.Message)
+	}
+	fmt.Fprintln(w, "\t")
+}
+
+// printAccount prints the account section of the status table
+func printAccount(w *tabwriter.Writer, account *bot.AccountSnapshot) {
+	fmt.Fprintln(w, "ACCOUNT\t")
+	fmt.Fprintf(w, "  Balance\t%.2f (available %.2f)\n", account.Balance, account.AvailableBalance)
+	if account.SweptProfit > 0 {
+		fmt.Fprintf(w, "  Equity\t%.2f (%.2f swept)\n", account.Equity, account.SweptProfit)
+	} else {
+		fmt.Fprintf(w, "  Equity\t%.2f\n", account.Equity)
+	}
+	if account.Margin != nil {
+		fmt.Fprintf(w, "  Margin\t%.2f used, %.2f free (level %.2f)\n",
+			account.Margin.UsedMargin, account.Margin.FreeMargin, account.Margin.MarginLevel)
+	}
+	fmt.Fprintf(w, "  Today\t%.2f (realized %.2f, unrealized %.2f)\n",
+		account.DailyPnL.Total(), account.DailyPnL.Realized, account.DailyPnL.Unrealized)
+	if account.DailyPnL.Halted {
+		fmt.Fprintln(w, "  Daily loss\thalted until the next day")
+	}
+
+	exposure := account.Exposure
+	fmt.Fprintf(w, "  Exposure\t%.2f (margin %.2f)\n", exposure.TotalExposure, exposure.UsedMargin)
+	fmt.Fprintf(w, "  Risk\t%s (level %.2f, de-risk %s)\n", exposure.PortfolioHealth, exposure.OverallRiskLevel, exposure.DeRiskLevel)
+	fmt.Fprintf(w, "  Sizing\t%.2fx size, %.1fx leverage\n", exposure.SizeMultiplier, exposure.EffectiveLeverage)
+
+	if len(account.Positions) == 0 {
+		fmt.Fprintln(w, "  Positions\tnone")
+	}
+	for _, position := range account.Positions {
+		liquidation := "no liquidation"
+		if position.Liquidation.LiquidationPrice > 0 {
+			liquidation = fmt.Sprintf("liq %.4f (%.2f%% away)", position.Liquidation.LiquidationPrice, position.Liquidation.Distance*100)
+			if position.Liquidation.NearLiquidation {
+				liquidation += " near liquidation"
+			}
+		}
+		fmt.Fprintf(w, "  Position\t%s %s %.6f @ %.4f, mark %.4f, PnL %.2f, %s\n", position.Symbol, position.Type,
+			position.Size, position.EntryPrice, position.MarkPrice, position.UnrealizedPnL, liquidation)
+	}
+	for _, err := range account.Errors {
+		fmt.Fprintf(w, "  Unavailable\t%s\n", err)
+	}
+}
+
+// formatGridUpdate describes the grid overrides set by the operator
+func formatGridUpdate(update *bot.GridUpdate) string {
+	parts := make([]string, 0, 3)
+	if update.Spacing > 0 {
+		parts = append(parts, fmt.Sprintf("spacing %.2f%%", update.Spacing*100))
+	}
+	if update.Levels > 0 {
+		parts = append(parts, fmt.Sprintf("%d levels", update.Levels))
+	}
+	if update.UpperBound > 0 {
+		parts = append(parts, fmt.Sprintf("bounds %.4f - %.4f", update.LowerBound, update.UpperBound))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatClientTime formats a timestamp for the status table
+func formatClientTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
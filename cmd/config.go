@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"aibot/internal/bot"
+	"aibot/internal/config"
+	"aibot/internal/data"
+	"aibot/internal/strategy"
+	"aibot/pkg/app"
+)
+
+// maxExchangeLeverage is the highest leverage futures exchanges offer on any symbol; per-symbol limits are
+// lower and checked against the exchange by validate
+const maxExchangeLeverage = 125.0
+
+// runConfig checks configuration files
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, "Usage: config validate [-config file] [-strict]")
+		return 1
+	}
+
+	flags := flag.NewFlagSet("config validate", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file to check")
+	strict := flags.Bool("strict", false, "Fail on warnings as well as errors")
+	flags.Parse(args[1:])
+
+	checklist := &validationChecklist{}
+	if cfg := lintConfigFile(*configFile, checklist); cfg != nil {
+		lintConfig(cfg, checklist)
+	}
+	checklist.print("Configuration check: " + *configFile)
+
+	if !checklist.passed() || (*strict && checklist.warned()) {
+		return 1
+	}
+	return 0
+}
+
+// lintConfigFile parses and validates a configuration file without creating it, returning nil if it is
+// unusable
+func lintConfigFile(path string, checklist *validationChecklist) *config.Config {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		checklist.add("Config file", CheckFail, "%v; the bot would create a default configuration here", err)
+		return nil
+	}
+
+	cfg := &config.Config{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		if !strings.HasPrefix(err.Error(), "json: unknown field") {
+			checklist.add("Config file", CheckFail, "%s", describeJSONError(content, err))
+			return nil
+		}
+		// Unknown fields are ignored by the bot, so they are most likely typos
+		checklist.add("Config file", CheckWarn, "%s is ignored; check its spelling and section", strings.TrimPrefix(err.Error(), "json: "))
+		cfg = &config.Config{}
+		if err := json.Unmarshal(content, cfg); err != nil {
+			checklist.add("Config file", CheckFail, "%s", describeJSONError(content, err))
+			return nil
+		}
+	} else {
+		checklist.add("Config file", CheckPass, "parsed")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		checklist.add("Validation", CheckFail, "%v", err)
+		return nil
+	}
+	checklist.add("Validation", CheckPass, "all fields within their ranges")
+	return cfg
+}
+
+// describeJSONError locates syntax and type errors by line
+func describeJSONError(content []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("line %d: %v", lineOf(content, syntaxErr.Offset), syntaxErr)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Sprintf("line %d: %s must be a %s, not a %s", lineOf(content, typeErr.Offset), typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	return err.Error()
+}
+
+// lineOf returns the line of a byte offset
+func lineOf(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+// lintConfig runs the semantic checks on the settings the bot would actually run with
+func lintConfig(cfg *config.Config, checklist *validationChecklist) {
+	botConfig, err := app.NewBotConfig(cfg)
+	if err != nil {
+		checklist.add("Strategy profile", CheckFail, "%v", err)
+		return
+	}
+	profile := cfg.Strategy.Profile
+	if profile == "" {
+		profile = strategy.DefaultProfile
+	}
+	checklist.add("Strategy profile", CheckPass, "%s", profile)
+
+	maker, taker := startingFees(cfg.Trading)
+	lintGridFees(botConfig.GridSetupConfig, profile, maker, taker, checklist)
+	lintGridSpacing(botConfig.GridSetupConfig, maker, checklist)
+	lintLeverage(cfg.Trading, botConfig.RiskManagerConfig, profile, checklist)
+	lintStopLoss(cfg, botConfig.PositionManagerConfig, taker, checklist)
+	lintTimeframes(cfg, botConfig, checklist)
+	validateRiskPolicy(cfg, checklist)
+}
+
+// startingFees returns the maker and taker fees of a new account: the lowest-volume tier of the fee schedule,
+// or the flat fees without one
+func startingFees(cfg config.TradingConfig) (float64, float64) {
+	maker, taker := cfg.MakerFee, cfg.TakerFee
+	for i, tier := range cfg.FeeSchedule.Tiers {
+		if i == 0 || tier.MinVolume < cfg.FeeSchedule.Tiers[i-1].MinVolume {
+			maker, taker = tier.MakerFee, tier.TakerFee
+		}
+	}
+	return maker, taker
+}
+
+// lintGridFees checks the profile does not plan the grid with lower fees than the account pays
+func lintGridFees(grid strategy.GridSetupConfig, profile string, maker, taker float64, checklist *validationChecklist) {
+	if grid.MakerFee < maker || grid.TakerFee < taker {
+		checklist.add("Grid fees", CheckWarn, "profile %s plans the grid with maker/taker %.4f%%/%.4f%% but the account pays %.4f%%/%.4f%%; "+
+			"save a profile with grid_setup fees to match (profile save)", profile, grid.MakerFee*100, grid.TakerFee*100, maker*100, taker*100)
+		return
+	}
+	checklist.add("Grid fees", CheckPass, "profile maker/taker %.4f%%/%.4f%% cover the account's %.4f%%/%.4f%%",
+		grid.MakerFee*100, grid.TakerFee*100, maker*100, taker*100)
+}
+
+// lintGridSpacing checks the narrowest grid the profile can lay out earns more per level than the round-trip
+// maker fees of a buy and a sell
+func lintGridSpacing(grid strategy.GridSetupConfig, maker float64, checklist *validationChecklist) {
+	if grid.MaxGridLevels <= 0 || grid.MinPriceRange <= 0 {
+		checklist.add("Grid spacing", CheckSkip, "profile sets no price range or levels")
+		return
+	}
+
+	spacing := grid.MinPriceRange / float64(grid.MaxGridLevels)
+	roundTrip := 2 * maker
+	net := spacing - roundTrip
+	switch {
+	case net <= 0:
+		checklist.add("Grid spacing", CheckFail, "narrowest grid spacing %.4f%% (min_price_range / max_grid_levels) does not cover %.4f%% round-trip fees; "+
+			"raise min_price_range or lower max_grid_levels", spacing*100, roundTrip*100)
+	case net < roundTrip:
+		checklist.add("Grid spacing", CheckWarn, "narrowest grid spacing %.4f%% nets %.4f%% per level, less than the %.4f%% paid in fees; "+
+			"raise min_price_range or lower max_grid_levels", spacing*100, net*100, roundTrip*100)
+	default:
+		checklist.add("Grid spacing", CheckPass, "narrowest spacing %.4f%% nets %.4f%% per level after %.4f%% round-trip fees",
+			spacing*100, net*100, roundTrip*100)
+	}
+}
+
+// lintLeverage checks leverage settings against what exchanges offer and each other
+func lintLeverage(cfg config.TradingConfig, risk strategy.RiskManagerConfig, profile string, checklist *validationChecklist) {
+	switch {
+	case cfg.MarketType == "spot" && cfg.DefaultLeverage > 1:
+		checklist.add("Leverage", CheckWarn, "default_leverage %.0fx is ignored on spot markets; set it to 1", cfg.DefaultLeverage)
+	case cfg.MaxLeverage > maxExchangeLeverage:
+		checklist.add("Leverage", CheckFail, "max_leverage %.0fx exceeds the %.0fx exchanges offer; lower trading.max_leverage",
+			cfg.MaxLeverage, maxExchangeLeverage)
+	case risk.MaxLeverage > maxExchangeLeverage:
+		checklist.add("Leverage", CheckFail, "profile %s max leverage %.0fx exceeds the %.0fx exchanges offer", profile, risk.MaxLeverage, maxExchangeLeverage)
+	case risk.MaxLeverage > cfg.MaxLeverage:
+		checklist.add("Leverage", CheckWarn, "profile %s sizes positions at up to %.0fx, above trading.max_leverage %.0fx; "+
+			"use a profile with a lower max leverage", profile, risk.MaxLeverage, cfg.MaxLeverage)
+	default:
+		checklist.add("Leverage", CheckPass, "default %.0fx, max %.0fx (exchange max %.0fx; run validate for per-symbol limits)",
+			cfg.DefaultLeverage, cfg.MaxLeverage, maxExchangeLeverage)
+	}
+}
+
+// lintStopLoss checks the breakout stop loss is wider than the spread orders may be placed at, with room
+// for the costs of a stopped-out trade
+func lintStopLoss(cfg *config.Config, positions strategy.PositionManagerConfig, taker float64, checklist *validationChecklist) {
+	stopLoss := strategy.NewPositionManager(positions).StopLossPercent
+	spread := cfg.Risk.Liquidity.MaxSpread
+	costs := spread + 2*taker + cfg.Trading.Slippage // Market entry and exit across the spread
+
+	switch {
+	case spread == 0:
+		checklist.add("Stop loss", CheckWarn, "%.2f%% stop, but orders are placed at any spread; set risk.liquidity.max_spread", stopLoss*100)
+	case stopLoss <= spread:
+		checklist.add("Stop loss", CheckFail, "%.2f%% stop is inside the %.2f%% max spread and can trigger on the spread alone; "+
+			"lower risk.liquidity.max_spread", stopLoss*100, spread*100)
+	case stopLoss < 3*costs:
+		checklist.add("Stop loss", CheckWarn, "a stopped-out trade pays %.2f%% in spread, fees and slippage, over a third of the %.2f%% stop; "+
+			"lower risk.liquidity.max_spread or trading.slippage", costs*100, stopLoss*100)
+	default:
+		checklist.add("Stop loss", CheckPass, "%.2f%% stop vs %.2f%% max spread and %.2f%% round-trip costs", stopLoss*100, spread*100, costs*100)
+	}
+}
+
+// lintTimeframes checks every strategy timeframe is one candles are aggregated in
+func lintTimeframes(cfg *config.Config, botConfig *bot.BotConfig, checklist *validationChecklist) {
+	built := make(map[data.CandleTimeframe]bool, len(bot.CandleTimeframes))
+	names := make([]string, len(bot.CandleTimeframes))
+	for i, timeframe := range bot.CandleTimeframes {
+		built[timeframe] = true
+		names[i] = string(timeframe)
+	}
+
+	unsupported := make([]string, 0)
+	check := func(field string, timeframe data.CandleTimeframe) {
+		if timeframe != "" && !built[timeframe] {
+			unsupported = append(unsupported, fmt.Sprintf("%s %q", field, timeframe))
+		}
+	}
+	for _, timeframe := range cfg.Strategy.Technical.AnalysisTimeframes {
+		check("strategy.technical.analysis_timeframes", data.CandleTimeframe(timeframe))
+	}
+	check("profile grid_setup.analysis_timeframe", botConfig.GridSetupConfig.AnalysisTimeframe)
+	check("strategy.breakout.confirmation_timeframe", botConfig.BreakoutConfig.ConfirmationTimeframe)
+
+	if len(unsupported) > 0 {
+		checklist.add("Timeframes", CheckFail, "%s not aggregated; use one of %s",
+			strings.Join(unsupported, ", "), strings.Join(names, ", "))
+		return
+	}
+	if cfg.Backtest.IntraCandlePath != "" {
+		if _, err := time.ParseDuration(cfg.Backtest.Timeframe); err != nil {
+			checklist.add("Timeframes", CheckWarn, "backtest.timeframe %q is not a duration, so intra-candle paths are not spread over the candle; use e.g. \"1m\" or \"1h\"",
+				cfg.Backtest.Timeframe)
+			return
+		}
+	}
+	checklist.add("Timeframes", CheckPass, "all within %s", strings.Join(names, ", "))
+}
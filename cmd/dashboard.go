@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"aibot/internal/bot"
+	"aibot/internal/config"
+)
+
+// runDashboard generates the Grafana dashboard that overlays the bot's annotations
+func runDashboard(args []string) int {
+	flags := flag.NewFlagSet("dashboard", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file with the Grafana annotation tags")
+	title := flags.String("title", AppName+" events", "Dashboard title")
+	output := flags.String("output", "", "Write the dashboard to this file instead of stdout")
+	flags.Parse(args)
+
+	tags := config.DefaultConfig().Grafana.Tags
+	if _, err := os.Stat(*configFile); err == nil {
+		cfg, err := config.LoadConfig(*configFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+			return 1
+		}
+		tags = cfg.Grafana.Tags
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bot.GrafanaDashboard(*title, tags)); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode dashboard: %v\n", err)
+		return 1
+	}
+	data := buf.Bytes()
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := os.MkdirAll(filepath.Dir(*output), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create dashboard directory: %v\n", err)
+		return 1
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write dashboard: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Dashboard written to %s (import it in Grafana, uid %s)\n", *output, bot.GrafanaDashboardUID)
+	return 0
+}
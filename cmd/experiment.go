@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"aibot/internal/config"
+	"aibot/internal/journal"
+	"aibot/internal/logging"
+	"aibot/pkg/app"
+)
+
+// runExperiment trades two parameter profiles side by side in simulation and reports which one wins
+func runExperiment(args []string) int {
+	flags := flag.NewFlagSet("experiment", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file with the experiment settings")
+	name := flags.String("name", "", "Experiment name (overrides experiment.name)")
+	profileA := flags.String("a", "", "Profile of the first variant (overrides its experiment profile)")
+	profileB := flags.String("b", "", "Profile of the second variant (overrides its experiment profile)")
+	duration := flags.Duration("duration", 0, "How long the variants trade (overrides experiment.duration)")
+	flags.Parse(args)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if len(cfg.Experiment.Variants) != 2 {
+		fmt.Fprintln(os.Stderr, "Configure two experiment.variants to run an experiment")
+		return 1
+	}
+	if *name != "" {
+		cfg.Experiment.Name = *name
+	}
+	if *profileA != "" {
+		cfg.Experiment.Variants[0].Profile = *profileA
+	}
+	if *profileB != "" {
+		cfg.Experiment.Variants[1].Profile = *profileB
+	}
+	if *duration > 0 {
+		cfg.Experiment.Duration = *duration
+	}
+	cfg.Trading.ExecutionType = "simulation"
+
+	logging.RegisterSecrets(cfg.Secrets()...)
+	logger := logging.NewLogger(cfg.Logging)
+	defer logger.Close()
+	experiment, err := app.NewExperiment(cfg, app.Dependencies{Logger: logger})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create experiment: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	setupSignalHandling(cancel, logger)
+
+	report, err := experiment.Run(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Experiment stopped early: %v\n", err)
+	}
+	if report == nil {
+		return 1
+	}
+
+	printExperimentReport(report)
+	path, saveErr := journal.SaveExperimentReport(experiment.Directory(), *report)
+	if saveErr != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", saveErr)
+		return 1
+	}
+	fmt.Printf("\nReport written to %s\n", path)
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// printExperimentReport prints the variants side by side with the test result
+func printExperimentReport(report *journal.ExperimentReport) {
+	fmt.Printf("Experiment %s on %s (%s to %s)\n", report.Name, report.Symbol,
+		formatClientTime(report.Start), formatClientTime(report.End))
+	fmt.Println("==================================================")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VARIANT\tPROFILE\tCONFIG\tCAPITAL\tNET PNL\tRETURN\tMAX DD\tTRADES\tWIN RATE\tMEAN TRADE\tSHARPE")
+	for _, variant := range report.Variants {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%.2f\t%.2f%%\t%.2f%%\t%d\t%.0f%%\t%.4f%%\t%.2f\n",
+			variant.Name, variant.Profile, variant.ConfigHash, variant.InitialCapital, variant.NetPnL,
+			variant.Return*100, variant.MaxDrawdown*100, variant.Trades, variant.WinRate*100,
+			variant.MeanTradeReturn*100, variant.Sharpe)
+	}
+	w.Flush()
+	fmt.Printf("\nWelch's t-test: t = %.3f, df = %.1f, p = %.4f\n", report.TStatistic, report.DegreesOfFreedom, report.PValue)
+	fmt.Println(report.Conclusion)
+}
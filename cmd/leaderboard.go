@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"aibot/internal/journal"
+)
+
+// runLeaderboard ranks the configurations recorded in the performance database
+func runLeaderboard(args []string) int {
+	flags := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	path := flags.String("db", "./data/performance.jsonl", "Performance database file")
+	metric := flags.String("metric", journal.MetricSharpe, "Ranking metric: sharpe, return, drawdown or calmar")
+	source := flags.String("source", "", "Only sessions from this source: live, simulation or backtest (default: all)")
+	symbol := flags.String("symbol", "", "Only sessions of this symbol (default: all)")
+	minSessions := flags.Int("min-sessions", 1, "Leave out configurations with fewer sessions")
+	top := flags.Int("top", 10, "Number of configurations listed (0: all)")
+	show := flags.String("show", "", "Print the parameters of the configuration with this hash instead of the ranking")
+	asJSON := flags.Bool("json", false, "Print the ranking as JSON")
+	flags.Parse(args)
+
+	results, err := journal.OpenPerformanceDB(*path).Results()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	if *show != "" {
+		// The latest session of the configuration carries its parameters
+		for i := len(results) - 1; i >= 0; i-- {
+			if strings.HasPrefix(results[i].Hash, *show) {
+				var parameters interface{}
+				if err := json.Unmarshal(results[i].Parameters, &parameters); err != nil {
+					fmt.Fprintf(os.Stderr, "Configuration %s has no readable parameters: %v\n", results[i].Hash, err)
+					return 1
+				}
+				data, _ := json.MarshalIndent(parameters, "", "  ")
+				fmt.Printf("Configuration %s (profile %s)\n%s\n", results[i].Hash, results[i].Profile, data)
+				return 0
+			}
+		}
+		fmt.Fprintf(os.Stderr, "No session with configuration %s in %s\n", *show, *path)
+		return 1
+	}
+
+	rankings, err := journal.Leaderboard(results, journal.LeaderboardQuery{
+		Source:      *source,
+		Symbol:      *symbol,
+		Metric:      *metric,
+		MinSessions: *minSessions,
+		Limit:       *top,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		for i := range rankings {
+			rankings[i].Parameters = nil
+		}
+		data, err := json.MarshalIndent(rankings, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to marshal leaderboard: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(data))
+		return 0
+	}
+
+	fmt.Printf("Configuration leaderboard by %s (%d sessions in %s)\n", *metric, len(results), *path)
+	fmt.Println("==================================================")
+	if len(rankings) == 0 {
+		fmt.Println("No configuration matches; lower -min-sessions or drop the filters")
+		return 0
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tCONFIG\tPROFILE\tSESSIONS\tPROFITABLE\tMEAN RETURN\tWORST RETURN\tMEAN DD\tWORST DD\tSHARPE\tTRADES\tLAST SEEN")
+	for _, ranking := range rankings {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%.0f%%\t%.2f%%\t%.2f%%\t%.2f%%\t%.2f%%\t%.2f\t%d\t%s\n",
+			ranking.Rank, ranking.ConfigHash, ranking.Profile, ranking.Sessions, ranking.ProfitableRate*100,
+			ranking.MeanReturn*100, ranking.WorstReturn*100, ranking.MeanDrawdown*100, ranking.WorstDrawdown*100,
+			ranking.MeanSharpe, ranking.Trades, formatClientTime(ranking.LastSeen))
+	}
+	w.Flush()
+	fmt.Printf("\nShow parameters: %s leaderboard -show <config>\n", os.Args[0])
+	return 0
+}
@@ -11,12 +11,9 @@ import (
 	"syscall"
 	"time"
 
-	"aibot/internal/bot"
 	"aibot/internal/config"
 	"aibot/internal/logging"
-	"aibot/internal/strategy"
-	"aibot/pkg/stream"
-	"aibot/pkg/trading"
+	"aibot/pkg/app"
 
 	"github.com/sirupsen/logrus"
 )
@@ -32,24 +29,11 @@ var (
 	// Command line flags
 	configPath = flag.String("config", DefaultConfigPath, "Path to configuration file")
 	debugMode  = flag.Bool("debug", false, "Enable debug mode")
+	profile    = flag.String("profile", "", "Strategy parameter profile (overrides strategy.profile)")
 	version    = flag.Bool("version", false, "Show version information")
 	help       = flag.Bool("help", false, "Show help information")
-
-	// Global variables
-	cfg        *config.Config
-	logger     *logging.Logger
-	orchestrator *bot.Orchestrator
-	streamProvider stream.StreamProvider
-	tradingExecutor trading.TradingExecutor
 )
 
-// Application represents the main application
-type Application struct {
-	ctx        context.Context
-	cancel     context.CancelFunc
-	shutdownCh chan struct{}
-}
-
 func init() {
 	// Set up command line parsing
 	flag.Usage = printUsage
@@ -59,6 +43,45 @@ func init() {
 }
 
 func main() {
+	// Handle subcommands before regular flag parsing
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		flag.CommandLine.Parse(os.Args[2:])
+		os.Exit(runValidate())
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "calibration" {
+		os.Exit(runCalibration(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "stress" {
+		os.Exit(runStress(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "screen" {
+		os.Exit(runScreen(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "profile" {
+		os.Exit(runProfile(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		os.Exit(runReconcile(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "dashboard" {
+		os.Exit(runDashboard(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "leaderboard" {
+		os.Exit(runLeaderboard(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "experiment" {
+		os.Exit(runExperiment(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "tui" {
+		os.Exit(runTUI(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && clientCommands[os.Args[1]] != "" {
+		os.Exit(runClient(os.Args[1], os.Args[2:]))
+	}
+
 	// Parse command line flags
 	flag.Parse()
 
@@ -74,36 +97,40 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Load configuration and set up logging
+	cfg, logger, err := loadConfiguration()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize application: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize application
-	app, err := initializeApplication()
+	application, err := app.New(cfg, app.Dependencies{Logger: logger})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize application: %v\n", err)
 		os.Exit(1)
 	}
+	logger.Info("Application initialized successfully")
 
-	// Run application
-	if err := app.run(); err != nil {
+	// Run application until a shutdown signal arrives
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	setupSignalHandling(cancel, logger)
+
+	if err := application.Run(ctx); err != nil {
 		logger.Fatalf("Application failed: %v", err)
 	}
 
 	logger.Info("Application shutdown completed")
+	logger.Close()
 }
 
-// initializeApplication initializes the application
-func initializeApplication() (*Application, error) {
-	// Create application context
-	ctx, cancel := context.WithCancel(context.Background())
-	app := &Application{
-		ctx:        ctx,
-		cancel:     cancel,
-		shutdownCh: make(chan struct{}),
-	}
-
+// loadConfiguration loads and validates the configuration file and initializes logging
+func loadConfiguration() (*config.Config, *logging.Logger, error) {
 	// Load configuration
-	var err error
-	cfg, err = config.LoadConfig(*configPath)
+	cfg, err := config.LoadConfig(*configPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %w", err)
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	// Override debug mode if specified
@@ -112,13 +139,19 @@ func initializeApplication() (*Application, error) {
 		cfg.Logging.Level = "debug"
 	}
 
+	// Override the strategy profile if specified
+	if *profile != "" {
+		cfg.Strategy.Profile = *profile
+	}
+
 	// Ensure required directories exist
 	if err := ensureDirectories(); err != nil {
-		return nil, fmt.Errorf("failed to create directories: %w", err)
+		return nil, nil, fmt.Errorf("failed to create directories: %w", err)
 	}
 
-	// Initialize logging
-	logger = logging.NewLogger(cfg.Logging)
+	// Initialize logging; credentials are masked wherever they appear in log output
+	logging.RegisterSecrets(cfg.Secrets()...)
+	logger := logging.NewLogger(cfg.Logging)
 	logging.InitGlobalLogger(cfg.Logging)
 
 	// Log application startup
@@ -127,206 +160,20 @@ func initializeApplication() (*Application, error) {
 		"environment": cfg.App.Environment,
 		"config_path": *configPath,
 		"debug_mode":  cfg.App.Debug,
+		"profile":     cfg.Strategy.Profile,
 	}).Info("Starting AI Trading Bot")
+	logger.WithField("config", cfg.Redacted()).Debug("Effective configuration")
 
 	// Validate configuration
 	if err := validateConfiguration(cfg); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
-	}
-
-	// Initialize components
-	if err := app.initializeComponents(cfg); err != nil {
-		return nil, fmt.Errorf("failed to initialize components: %w", err)
-	}
-
-	// Set up signal handling
-	app.setupSignalHandling()
-
-	return app, nil
-}
-
-// run runs the main application loop
-func (app *Application) run() error {
-	logger.Info("Application initialized successfully")
-
-	// Run trading mode (now handles all modes through streaming config)
-	logger.Info("Starting trading bot")
-	return app.runLiveTrading()
-}
-
-// runLiveTrading runs the live trading mode
-func (app *Application) runLiveTrading() error {
-	// Create bot configuration for orchestrator
-	botConfig := convertToBotConfig(cfg)
-
-	// Create orchestrator
-	var err error
-	orchestrator, err = bot.NewOrchestrator(botConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create orchestrator: %w", err)
+		return nil, nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	// Start orchestrator
-	if err := orchestrator.Start(streamProvider, tradingExecutor); err != nil {
-		return fmt.Errorf("failed to start orchestrator: %w", err)
-	}
-
-	logger.Info("Trading bot started successfully")
-
-	// Wait for shutdown signal
-	select {
-	case <-app.shutdownCh:
-		logger.Info("Shutdown signal received")
-	case <-app.ctx.Done():
-		logger.Info("Context cancelled")
-	}
-
-	// Graceful shutdown
-	return app.shutdown()
-}
-
-
-// initializeComponents initializes all application components
-func (app *Application) initializeComponents(cfg *config.Config) error {
-	logger.Info("Initializing application components")
-
-	// Initialize stream provider
-	var err error
-	streamProvider, err = createStreamProvider(cfg.Stream)
-	if err != nil {
-		return fmt.Errorf("failed to create stream provider: %w", err)
-	}
-
-	// Initialize trading executor
-	tradingExecutor, err = createTradingExecutor(cfg.Trading)
-	if err != nil {
-		return fmt.Errorf("failed to create trading executor: %w", err)
-	}
-
-	logger.Info("Components initialized successfully")
-	return nil
+	return cfg, logger, nil
 }
 
-// createStreamProvider creates the appropriate stream provider
-func createStreamProvider(cfg config.StreamConfig) (stream.StreamProvider, error) {
-	factory := stream.NewStreamProviderFactory()
-
-	// Create live config
-	liveConfig := stream.RealStreamConfig{
-		StreamConfig: stream.StreamConfig{
-			ProviderType: "live",
-			Symbols:      []string{"BTCUSDT"},
-		},
-		WSSURL:          "wss://api.binance.com/ws/btcusdt@ticker", // Default example
-		PingInterval:     20 * time.Second,
-		Timeout:          30 * time.Second,
-		Compression:      true,
-		RateLimitPerSec:  10,
-	}
-
-	return factory.CreateStreamProvider(liveConfig)
-}
-
-// createTradingExecutor creates the appropriate trading executor
-func createTradingExecutor(cfg config.TradingConfig) (trading.TradingExecutor, error) {
-	factory := trading.NewTradingExecutorFactory()
-
-	// Create live config
-	liveConfig := trading.LiveConfig{
-		ExecutionConfig: trading.ExecutionConfig{
-			ProviderType:    "live",
-			InitialBalance:  cfg.InitialBalance,
-			DefaultLeverage: cfg.DefaultLeverage,
-			Commission:      cfg.MakerFee + cfg.TakerFee,
-		},
-		WSSURL:          "wss://api.binance.com/ws/btcusdt@trade",
-		RESTURL:         "https://api.binance.com/api/v3",
-		Timeout:         30 * time.Second,
-		RateLimitPerSec: 10,
-		UseTestNet:      true,
-		EnableHedging:   false,
-	}
-
-	return factory.CreateTradingExecutor(liveConfig)
-}
-
-// convertToBotConfig converts app config to bot orchestrator config
-func convertToBotConfig(cfg *config.Config) *bot.BotConfig {
-	return &bot.BotConfig{
-		InitialBalance:    cfg.Trading.InitialBalance,
-		MaxSymbols:        1, // Simplified
-		DefaultSymbol:     cfg.Trading.DefaultSymbol,
-		GridSetupConfig: strategy.GridSetupConfig{
-			MinHistoryCandles: 100,
-			AnalysisTimeframe: "3s",
-			DefaultGridLevels: 20,
-			MinGridLevels:     10,
-			MaxGridLevels:     30,
-			MinPriceRange:     0.03, // 3%
-			MaxPriceRange:     0.10, // 10%
-			RangeMultiplier:   1.2,
-			ATRMultiplier:     2.0,
-			MakerFee:          0.0002, // 0.02%
-			TakerFee:          0.0006, // 0.06%
-			MinProfitPerLevel: 0.0015, // 0.15%
-		},
-		BreakoutConfig: strategy.BreakoutConfig{
-			ConfirmationPeriod: 3,
-			MinBreakoutStrength: 0.5,
-			VolumeMultiplier:    1.2,
-			RSIOverbought:       70,
-			RSIOversold:         30,
-			ATRMultiple:         1.5,
-			MomentumThreshold:   0.3,
-		},
-		FalseBreakoutConfig: strategy.FalseBreakoutConfig{
-			PriceReversionThreshold: 0.005, // 0.5%
-			StrongReversalThreshold: 0.01,  // 1.0%
-			ConfirmationCandles:     3,
-			VolumeDeclineThreshold:  0.5,   // 50%
-			MomentumReversalMs:      900,
-			StdDevMultiplier:        2.0,
-		},
-		StabilityConfig: strategy.StabilityConfig{
-			AnalysisWindow:       10,
-			VolatilityThreshold:  0.005, // 0.5%
-			MomentumThreshold:    0.002, // 0.2%
-			PriceConformity:      0.8,   // 80%
-			RangeContraction:     0.7,   // 70%
-			MinStabilityPeriods:  3,
-			PrimaryTimeframe:     "3s",
-			SecondaryTimeframe:   "15s",
-		},
-		RiskManagerConfig: strategy.RiskManagerConfig{
-			MaxPortfolioRisk:     0.05,  // 5%
-			MaxPositionRisk:      0.02,  // 2%
-			MaxCorrelation:       0.7,
-			MaxDrawdown:          0.10,  // 10%
-			MinRiskRewardRatio:   1.5,
-			DefaultLeverage:      5.0,
-			MaxLeverage:          10.0,
-			ConcentrationLimit:   0.3,   // 30%
-			VolatilityMultiplier: 1.5,
-		},
-		StreamConfig: stream.StreamConfig{
-			ProviderType: "live",
-			Symbols:      []string{cfg.Trading.DefaultSymbol},
-		},
-		TradingConfig: trading.ExecutionConfig{
-			ProviderType:    "live",
-			InitialBalance:  cfg.Trading.InitialBalance,
-			DefaultLeverage: cfg.Trading.DefaultLeverage,
-			Commission:      cfg.Trading.MakerFee + cfg.Trading.TakerFee,
-		},
-		UpdateInterval:      1 * time.Second,
-		HealthCheckInterval: 30 * time.Second,
-		MaxDailyLoss:        cfg.Trading.MaxDailyLoss,
-		MaxConsecutiveLosses: cfg.Trading.MaxConsecutiveLosses,
-	}
-}
-
-// setupSignalHandling sets up signal handling for graceful shutdown
-func (app *Application) setupSignalHandling() {
+// setupSignalHandling cancels the application context on an interrupt or termination signal
+func setupSignalHandling(cancel context.CancelFunc, logger *logging.Logger) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
@@ -335,64 +182,10 @@ func (app *Application) setupSignalHandling() {
 		logger.WithField("signal", sig.String()).Info("Signal received, initiating shutdown")
 
 		// Cancel context to trigger shutdown
-		app.cancel()
+		cancel()
 	}()
 }
 
-// shutdown performs graceful shutdown
-func (app *Application) shutdown() error {
-	logger.Info("Starting graceful shutdown")
-
-	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	shutdownErrors := make(chan error, 1)
-
-	// Perform shutdown in goroutine
-	go func() {
-		defer close(shutdownErrors)
-
-		// Stop orchestrator
-		if orchestrator != nil {
-			logger.Info("Stopping orchestrator")
-			if err := orchestrator.Stop(); err != nil {
-				shutdownErrors <- fmt.Errorf("failed to stop orchestrator: %w", err)
-				return
-			}
-		}
-
-		// Stop stream provider
-		if streamProvider != nil {
-			logger.Info("Stopping stream provider")
-			if err := streamProvider.Stop(); err != nil {
-				shutdownErrors <- fmt.Errorf("failed to stop stream provider: %w", err)
-				return
-			}
-		}
-
-		// Disconnect trading executor
-		if tradingExecutor != nil {
-			logger.Info("Disconnecting trading executor")
-			if err := tradingExecutor.Disconnect(); err != nil {
-				shutdownErrors <- fmt.Errorf("failed to disconnect trading executor: %w", err)
-				return
-			}
-		}
-
-		logger.Info("Shutdown completed successfully")
-	}()
-
-	// Wait for shutdown or timeout
-	select {
-	case err := <-shutdownErrors:
-		return err
-	case <-shutdownCtx.Done():
-		logger.Warn("Shutdown timeout reached")
-		return fmt.Errorf("shutdown timeout")
-	}
-}
-
 // ensureDirectories ensures required directories exist
 func ensureDirectories() error {
 	directories := []string{
@@ -444,7 +237,37 @@ func validateConfiguration(cfg *config.Config) error {
 func printUsage() {
 	fmt.Printf(`%s - %s
 
-Usage: %s [options]
+Usage: %s [command] [options]
+
+Commands:
+  validate    Check exchange connectivity, permissions, symbols and balance without trading
+  config      Check a configuration file offline: grid spacing vs fees, leverage, stop loss, timeframes (validate [-strict])
+  calibration Compare signal confidence with realized win rate across session reports
+  stress      Run price, volatility and correlation shocks against the journaled open positions
+  profile     List, show or save strategy parameter profiles (list | show <name> | save <name>)
+  screen      Rank symbols by grid suitability (volatility, ADX, volume, spread) from exchange data
+  reconcile   Import the account's trade history and flag differences with the trade journal (exit code 2 if any)
+  dashboard   Generate the Grafana dashboard for mode transition, breakout and risk alert annotations
+  leaderboard Rank configurations by the results of every recorded session (-metric, -source, -min-sessions)
+  experiment  Trade two profiles side by side in simulation and test which wins (-a, -b, -duration)
+  status      Show state and performance of a running bot
+  pause       Pause a running bot (cancels grid orders, keeps positions)
+  resume      Resume grid trading on a paused bot
+  close-all   Cancel grid orders and close all positions of a running bot
+  log-level   Show or change log levels of a running bot ([component] <level>)
+  health      Show the resource watchdog report of a running bot (exit code 2 unless healthy)
+  update-grid Change spacing, levels or bounds of the live grid (-spacing, -levels, -upper/-lower, -reset)
+  candles     Export the in-memory candles of a running bot as CSV or JSON (-symbol, -timeframe, -since, -format)
+  subscribe   Stream more symbols on a running bot without reconnecting (<symbol>...); only the active one trades
+  unsubscribe Stop streaming watched symbols on a running bot (<symbol>...)
+  place-order Place an order through a running bot's risk checks (-symbol, -side, -qty, -price, -reduce-only)
+  modify-order Replace a resting manual limit order with a new quantity or price (-id, -qty, -price)
+  cancel-order Cancel a resting order of a running bot (-id)
+  grid-levels Show fills, profit and time to refill per grid level of a running bot
+  ledger      Show the balance, PnL, fee and funding totals and recent postings of a running bot (-symbol, -limit)
+  lock-mode   Lock a running bot in a mode until unlock-mode, suppressing all other transitions (<mode> [reason])
+  unlock-mode Lift the mode lock so automatic mode transitions resume
+  tui         Live candlestick chart of a running bot with grid levels, positions, mode and PnL (-timeframe, -refresh)
 
 Options:
 `, AppName, AppVersion, os.Args[0])
@@ -454,8 +277,30 @@ Examples:
   %s                                    # Run with default config
   %s -config ./myconfig.json            # Run with custom config
   %s -debug                            # Run in debug mode
+  %s -profile conservative             # Run with the conservative parameter profile
   %s -version                          # Show version
   %s -help                             # Show this help
+  %s validate -config ./myconfig.json   # Pre-flight check before live trading
+  %s config validate -config ./myconfig.json  # Lint a configuration before any run
+  %s calibration -bins 5                # Calibration curve of ./data/sessions reports
+  %s stress -prices BTCUSDT=60000       # Stress test open positions at a given mark price
+  %s profile save mine -from scalping   # Copy a profile to ./config/profiles/mine.json to edit
+  %s screen -top 2                      # Rank the screener universe and select the best two
+  %s reconcile -since 72h              # Reconcile the last three days of trades against the journal
+  %s dashboard -output dash.json        # Write the Grafana dashboard for import
+  %s leaderboard -min-sessions 3        # Configurations with at least three sessions, best Sharpe first
+  %s experiment -a balanced -b scalping -duration 48h  # A/B test two profiles for two days
+  %s status -socket ./data/aibot.sock   # Query a running bot
+  %s health                             # Check goroutines, memory, backlogs and stream lag
+  %s update-grid -spacing 0.004         # Re-lay the live grid with 0.4%% spacing
+  %s log-level stream debug             # Debug-log market data of a running bot
+  %s candles -timeframe 15s -since 10m  # Dump the 15s candles the bot saw in the last ten minutes as CSV
+  %s subscribe ETHUSDT SOLUSDT          # Warm up candles and indicators of two candidate symbols
+  %s place-order -symbol BTCUSDT -side buy -qty 0.01 -price 60000  # Rest a manual limit buy
+  %s grid-levels                        # Check whether the outer grid levels earn their capital
+  %s ledger -symbol BTCUSDT -limit 50    # Audit the last fifty BTCUSDT postings against the exchange
+  %s lock-mode idle FOMC                # Stay idle through a news event until unlock-mode
+  %s tui -timeframe 1m                  # Watch the bot over SSH without a web dashboard
 
 Environment Variables:
   TRADING_BOT_CONFIG_PATH    Path to configuration file (overrides -config flag)
@@ -468,7 +313,7 @@ Configuration:
   The default configuration file location is: %s
 
 For more information, see the documentation.
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], DefaultConfigPath)
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], DefaultConfigPath)
 }
 
 // printVersion prints version information
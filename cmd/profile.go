@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"aibot/internal/config"
+	"aibot/internal/strategy"
+)
+
+// runProfile lists, shows and saves strategy parameter profiles
+func runProfile(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: profile list | show [name] | save <name> [-from profile] [-description text]")
+		return 1
+	}
+	action, rest := args[0], args[1:]
+
+	// Allow the profile name before the flags
+	name := ""
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		name, rest = rest[0], rest[1:]
+	}
+
+	flags := flag.NewFlagSet("profile "+action, flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file with the selected profile and profile directory")
+	from := flags.String("from", "", "Profile to copy when saving (default: the configured profile)")
+	description := flags.String("description", "", "Description of the saved profile")
+	flags.Parse(rest)
+	if name == "" && flags.NArg() > 0 {
+		name = flags.Arg(0)
+	}
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	profileDir := cfg.Strategy.ProfileDir
+
+	switch action {
+	case "list":
+		profiles, err := strategy.ListProfiles(profileDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list profiles: %v\n", err)
+			return 1
+		}
+		printProfiles(profiles, cfg.Strategy.Profile)
+		return 0
+
+	case "show":
+		if name == "" {
+			name = cfg.Strategy.Profile
+		}
+		profile, err := strategy.LoadProfile(name, profileDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		data, err := json.MarshalIndent(profile, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to marshal profile: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(data))
+		return 0
+
+	case "save":
+		if name == "" {
+			fmt.Fprintln(os.Stderr, "Usage: profile save <name> [-from profile] [-description text]")
+			return 1
+		}
+		if *from == "" {
+			*from = cfg.Strategy.Profile
+		}
+		profile, err := strategy.LoadProfile(*from, profileDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		profile.Name = name
+		if *description != "" {
+			profile.Description = *description
+		} else {
+			profile.Description = fmt.Sprintf("Custom profile based on %s", *from)
+		}
+		path, err := strategy.SaveProfile(profile, profileDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save profile: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Profile %s saved to %s\n", name, path)
+		fmt.Printf("Edit it and select it with -profile %s or strategy.profile in %s\n", name, *configFile)
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown profile command: %s (expected list, show or save)\n", action)
+		return 1
+	}
+}
+
+// printProfiles prints the available profiles with their key parameters, marking the selected one
+func printProfiles(profiles []*strategy.StrategyProfile, selected string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tPROFILE\tSOURCE\tGRID LEVELS\tLEVERAGE\tMAX DRAWDOWN\tDESCRIPTION")
+	for _, profile := range profiles {
+		marker := ""
+		if profile.Name == selected {
+			marker = "*"
+		}
+		source := "custom"
+		if profile.Builtin {
+			source = "built-in"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d (%d-%d)\t%.0fx (max %.0fx)\t%.0f%%\t%s\n", marker, profile.Name, source,
+			profile.GridSetup.DefaultGridLevels, profile.GridSetup.MinGridLevels, profile.GridSetup.MaxGridLevels,
+			profile.Risk.DefaultLeverage, profile.Risk.MaxLeverage, profile.Risk.MaxDrawdown*100, profile.Description)
+	}
+	w.Flush()
+}
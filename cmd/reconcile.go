@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"aibot/internal/config"
+	"aibot/internal/journal"
+	"aibot/internal/reconcile"
+	"aibot/pkg/app"
+)
+
+// runReconcile imports the account's trade history from the exchange and reconciles it against the trade journal
+func runReconcile(args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file with the exchange credentials and reconcile settings")
+	symbol := flags.String("symbol", "", "Symbol to reconcile (default: trading.default_symbol)")
+	since := flags.Duration("since", 0, "Period reconciled up to now (overrides reconcile.lookback)")
+	journalFile := flags.String("journal", "./data/journal/trades.jsonl", "Trade journal the exchange trades are compared with")
+	output := flags.String("output", "", "Write the report as JSON to this file (default: reconcile.directory)")
+	timeout := flags.Duration("timeout", 2*time.Minute, "Time limit for importing the trade history")
+	flags.Parse(args)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if *symbol == "" {
+		*symbol = cfg.Trading.DefaultSymbol
+	}
+	*symbol = strings.ToUpper(*symbol)
+	lookback := cfg.Reconcile.Lookback
+	if *since > 0 {
+		lookback = *since
+	}
+	if lookback <= 0 {
+		fmt.Fprintln(os.Stderr, "Reconcile lookback must be positive; pass -since")
+		return 1
+	}
+
+	entries, err := journal.ReadJournal(*journalFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	end := time.Now()
+	start := end.Add(-lookback)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	history, reconciler := app.NewReconciler(cfg)
+	trades, err := history.GetTrades(ctx, *symbol, start, end)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Trade history import failed: %v\n", err)
+		return 1
+	}
+	report := reconciler.Reconcile(*symbol, start, end, trades, entries)
+
+	fmt.Printf("Trade reconciliation %s (%d exchange trades, %d journaled fills)\n", *symbol, report.ExchangeTrades, report.JournalFills)
+	fmt.Println("==================================================")
+	fmt.Print(report.FormatText())
+
+	path := *output
+	if path == "" && cfg.Reconcile.Directory != "" {
+		path = filepath.Join(cfg.Reconcile.Directory, fmt.Sprintf("reconcile-%s-%s.json", *symbol, end.UTC().Format("20060102-150405")))
+	}
+	if path != "" {
+		if err := reconcile.SaveReport(report, path); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		fmt.Printf("\nReport written to %s\n", path)
+	}
+
+	if !report.Clean() {
+		return 2
+	}
+	return 0
+}
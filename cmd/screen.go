@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aibot/internal/config"
+	"aibot/internal/screener"
+	"aibot/pkg/app"
+)
+
+// runScreen ranks the screener universe by grid suitability using exchange REST data
+func runScreen(args []string) int {
+	flags := flag.NewFlagSet("screen", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file with the screener settings")
+	symbols := flags.String("symbols", "", "Comma-separated universe (overrides screener.symbols)")
+	top := flags.Int("top", 0, "Symbols to select (overrides screener.top_n)")
+	output := flags.String("output", "", "Write the report as JSON to this file")
+	timeout := flags.Duration("timeout", 2*time.Minute, "Time limit for the whole screen")
+	flags.Parse(args)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if *symbols != "" {
+		cfg.Screener.Symbols = strings.Split(strings.ToUpper(strings.ReplaceAll(*symbols, " ", "")), ",")
+	}
+	if *top > 0 {
+		cfg.Screener.TopN = *top
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	report, err := app.NewScreener(cfg).Screen(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Screen failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Grid suitability screen (%d symbols, %d x %s klines)\n", len(report.Results), report.Lookback, report.Interval)
+	fmt.Println("==================================================")
+	printScreenReport(report)
+
+	if *output != "" {
+		if err := screener.SaveReport(report, *output); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		fmt.Printf("\nReport written to %s\n", *output)
+	}
+	return 0
+}
+
+// printScreenReport prints the ranking with the metrics behind each score
+func printScreenReport(report *screener.Report) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RANK\tSYMBOL\tSCORE\tDAILY VOL\tADX\t24H VOLUME\tSPREAD\tSTATUS")
+	for i, result := range report.Results {
+		if result.Error != "" {
+			fmt.Fprintf(w, "%d\t%s\t-\t-\t-\t-\t-\terror: %s\n", i+1, result.Symbol, result.Error)
+			continue
+		}
+		status := "eligible"
+		if !result.Eligible {
+			status = strings.Join(result.Reasons, "; ")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%.3f\t%.2f%%\t%.1f\t%.0f\t%.3f%%\t%s\n", i+1, result.Symbol, result.Score,
+			result.Volatility*100, result.ADX, result.QuoteVolume, result.Spread*100, status)
+	}
+	w.Flush()
+
+	if len(report.Selected) == 0 {
+		fmt.Println("\nNo symbol passed every limit")
+		return
+	}
+	fmt.Printf("\nSelected: %s\n", strings.Join(report.Selected, ", "))
+}
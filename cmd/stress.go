@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"aibot/internal/config"
+	"aibot/internal/journal"
+	"aibot/internal/strategy"
+	"aibot/pkg/app"
+)
+
+// runStress rebuilds the open positions from the trade journal and runs shock scenarios against them
+func runStress(args []string) int {
+	flags := flag.NewFlagSet("stress", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file with risk and contract settings")
+	journalFile := flags.String("journal", "./data/journal/trades.jsonl", "Trade journal the open positions are rebuilt from")
+	equity := flags.Float64("equity", 0, "Account equity (default: initial balance plus journaled PnL net of fees)")
+	prices := flags.String("prices", "", "Mark prices as SYMBOL=PRICE,... (default: last fill price)")
+	volatility := flags.Float64("volatility", strategy.DefaultStressVolatility, "Adverse move per unit of volatility shock")
+	scenarioFile := flags.String("scenarios", "", "JSON file with a list of scenarios (default: -10%, -20%, 3x vol, 3x vol correlated)")
+	output := flags.String("output", "", "Write the results as JSON to this file")
+	flags.Parse(args)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	botConfig, err := app.NewBotConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	scenarios := strategy.DefaultStressScenarios()
+	if *scenarioFile != "" {
+		data, err := os.ReadFile(*scenarioFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", *scenarioFile, err)
+			return 1
+		}
+		if err := json.Unmarshal(data, &scenarios); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse %s: %v\n", *scenarioFile, err)
+			return 1
+		}
+	}
+
+	entries, err := journal.ReadJournal(*journalFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load positions: %v\n", err)
+		return 1
+	}
+	positions := journal.OpenPositions(entries, botConfig.PositionManagerConfig.Contracts, cfg.Trading.DefaultLeverage)
+
+	marks, err := parsePrices(*prices)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -prices: %v\n", err)
+		return 1
+	}
+	for _, position := range positions {
+		if mark, ok := marks[position.Symbol]; ok {
+			position.UpdateMarkPrice(mark)
+		}
+	}
+
+	if *equity == 0 {
+		*equity = cfg.Trading.InitialBalance
+		for _, entry := range entries {
+			*equity += entry.RealizedPnL - entry.Fee
+		}
+		for _, position := range positions {
+			*equity += position.UnrealizedPnL
+		}
+	}
+
+	volatilities := make(map[string]float64, len(positions))
+	for _, position := range positions {
+		volatilities[position.Symbol] = *volatility
+	}
+	riskManager := strategy.NewRiskManager(botConfig.RiskManagerConfig, *equity)
+	results := riskManager.StressTest(positions, *equity, volatilities, scenarios)
+
+	fmt.Printf("Portfolio stress test (%d open positions, equity %.2f)\n", len(positions), *equity)
+	fmt.Println("==================================================")
+	printStressResults(results)
+
+	if *output != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to marshal results: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(*output, data, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *output, err)
+			return 1
+		}
+		fmt.Printf("\nResults written to %s\n", *output)
+	}
+
+	for _, result := range results {
+		if result.MarginCall {
+			return 2
+		}
+	}
+	return 0
+}
+
+// parsePrices parses SYMBOL=PRICE pairs separated by commas
+func parsePrices(value string) (map[string]float64, error) {
+	prices := make(map[string]float64)
+	if value == "" {
+		return prices, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		symbol, price, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return nil, fmt.Errorf("expected SYMBOL=PRICE, got %q", pair)
+		}
+		parsed, err := strconv.ParseFloat(price, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid price for %s: %q", symbol, price)
+		}
+		prices[strings.ToUpper(symbol)] = parsed
+	}
+	return prices, nil
+}
+
+// printStressResults prints the portfolio effect of each scenario and the positions it hits
+func printStressResults(results []strategy.StressResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCENARIO\tPNL\tLOSS\tEQUITY AFTER\tMARGIN BEFORE\tMARGIN AFTER\tMARGIN RATIO\t")
+	for _, result := range results {
+		status := ""
+		if result.MarginCall {
+			status = "MARGIN CALL"
+		}
+		fmt.Fprintf(w, "  %s\t%.2f\t%.2f%%\t%.2f\t%.2f\t%.2f\t%.2f%%\t%s\n", result.Scenario.Name, result.PnL,
+			result.LossPercent*100, result.EquityAfter, result.MarginBefore, result.MarginAfter, result.MarginRatio*100, status)
+	}
+	w.Flush()
+
+	for _, result := range results {
+		for _, position := range result.Positions {
+			if position.Liquidated {
+				fmt.Printf("  ⚠️ %s: %s %s liquidated at %.4f (liquidation %.4f)\n", result.Scenario.Name,
+					position.Symbol, position.PositionType, position.ShockedPrice, position.LiquidationPrice)
+			}
+		}
+	}
+}
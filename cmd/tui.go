@@ -0,0 +1,371 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"aibot/internal/bot"
+	"aibot/internal/data"
+	"aibot/internal/types"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences of the terminal UI
+const (
+	ansiReset      = "\x1b[0m"
+	ansiBold       = "\x1b[1m"
+	ansiDim        = "\x1b[2m"
+	ansiRed        = "\x1b[31m"
+	ansiGreen      = "\x1b[32m"
+	ansiYellow     = "\x1b[33m"
+	ansiCyan       = "\x1b[36m"
+	ansiClear      = "\x1b[H\x1b[2J"
+	ansiAltScreen  = "\x1b[?1049h\x1b[?25l" // Alternate screen, cursor hidden
+	ansiMainScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+// tuiAxisWidth is the width of the price axis right of the chart
+const tuiAxisWidth = 14
+
+// tuiSnapshot is the bot state drawn in one frame
+type tuiSnapshot struct {
+	status  *bot.ControlResponse
+	candles []types.OHLCV
+	err     error
+	at      time.Time
+}
+
+// tuiCell is one character of the chart with its color
+type tuiCell struct {
+	char  rune
+	color string
+}
+
+// runTUI draws a live candlestick chart of a running bot with its grid levels, positions, mode and PnL
+func runTUI(args []string) int {
+	flags := flag.NewFlagSet("tui", flag.ExitOnError)
+	configFile := flags.String("config", DefaultConfigPath, "Configuration file of the running bot, used for the control address")
+	socket := flags.String("socket", "", "Control socket path (overrides the configuration)")
+	address := flags.String("addr", "", "Loopback control address (overrides the configuration)")
+	timeout := flags.Duration("timeout", 5*time.Second, "Request timeout")
+	timeframe := flags.String("timeframe", string(data.Timeframe15s), "Candle timeframe: 1s, 3s, 15s, 30s or 1m")
+	refresh := flags.Duration("refresh", time.Second, "Redraw interval")
+	width := flags.Int("width", 0, "Screen width in columns (default: terminal width)")
+	height := flags.Int("height", 0, "Screen height in rows (default: terminal height)")
+	noColor := flags.Bool("no-color", false, "Draw without colors")
+	flags.Parse(args)
+
+	network, target, err := controlTarget(*configFile, *socket, *address)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *refresh <= 0 {
+		fmt.Fprintln(os.Stderr, "Refresh interval must be positive")
+		return 1
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	fmt.Print(ansiAltScreen)
+	defer fmt.Print(ansiMainScreen)
+
+	ticker := time.NewTicker(*refresh)
+	defer ticker.Stop()
+	for {
+		columns, rows := terminalSize(*width, *height)
+		candleCount := (columns - tuiAxisWidth) / 2
+		snapshot := fetchTUISnapshot(network, target, data.CandleTimeframe(*timeframe), candleCount, *timeout)
+		fmt.Print(ansiClear + renderTUI(snapshot, *timeframe, columns, rows, !*noColor))
+
+		select {
+		case <-sigCh:
+			return 0
+		case <-ticker.C:
+		}
+	}
+}
+
+// terminalSize returns the screen size: the given dimensions, else the size of the terminal on stdout, else
+// $COLUMNS and $LINES, else 120x40
+func terminalSize(width, height int) (int, int) {
+	if width > 0 && height > 0 {
+		return width, height
+	}
+	columns, rows := 120, 40
+	if value, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && value > 0 {
+		columns = value
+	}
+	if value, err := strconv.Atoi(os.Getenv("LINES")); err == nil && value > 0 {
+		rows = value
+	}
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 && h > 0 {
+		columns, rows = w, h
+	}
+	if width > 0 {
+		columns = width
+	}
+	if height > 0 {
+		rows = height
+	}
+	return columns, rows
+}
+
+// fetchTUISnapshot reads the status and the latest candles of the active symbol from the control server
+func fetchTUISnapshot(network, target string, timeframe data.CandleTimeframe, limit int, timeout time.Duration) tuiSnapshot {
+	snapshot := tuiSnapshot{at: time.Now()}
+
+	status, err := bot.SendControlRequest(network, target, bot.ControlRequest{Command: bot.ControlStatus}, timeout)
+	if err == nil && !status.OK {
+		err = fmt.Errorf("status failed: %s", status.Error)
+	}
+	if err != nil {
+		snapshot.err = err
+		return snapshot
+	}
+	snapshot.status = status
+
+	query := &data.CandleQuery{Symbol: status.State.CurrentSymbol, Timeframe: timeframe, Limit: limit, IncludeCurrent: true}
+	candles, err := bot.SendControlRequest(network, target, bot.ControlRequest{Command: bot.ControlCandles, Query: query}, timeout)
+	if err == nil && !candles.OK {
+		err = fmt.Errorf("candles failed: %s", candles.Error)
+	}
+	if err != nil {
+		snapshot.err = err
+		return snapshot
+	}
+	snapshot.candles = candles.Candles
+	return snapshot
+}
+
+// renderTUI draws a frame: a header with mode and PnL, the candlestick chart with grid levels and position
+// entries, and the open positions
+func renderTUI(snapshot tuiSnapshot, timeframe string, columns, rows int, color bool) string {
+	paint := func(code, text string) string {
+		if !color || code == "" {
+			return text
+		}
+		return code + text + ansiReset
+	}
+	var b strings.Builder
+
+	footer := fmt.Sprintf("%s candles · %s · Ctrl+C to quit", timeframe, snapshot.at.Format("15:04:05"))
+	if snapshot.status == nil {
+		fmt.Fprintf(&b, "%s\n\n", paint(ansiBold, AppName))
+		fmt.Fprintf(&b, "%s\n\n", paint(ansiRed, fmt.Sprintf("Is the bot running? %v", snapshot.err)))
+		b.WriteString(paint(ansiDim, footer))
+		return b.String()
+	}
+
+	state, performance, account := snapshot.status.State, snapshot.status.Performance, snapshot.status.Account
+	price := 0.0
+	if len(snapshot.candles) > 0 {
+		price = snapshot.candles[len(snapshot.candles)-1].Close
+	}
+
+	// Header
+	activity := paint(ansiGreen, "ACTIVE")
+	if !state.IsActive {
+		activity = paint(ansiYellow, "INACTIVE")
+	}
+	if state.ModeLock != nil {
+		activity += " " + paint(ansiYellow, "LOCKED")
+	}
+	pnlColor := ansiGreen
+	if performance.TotalPnL < 0 {
+		pnlColor = ansiRed
+	}
+	fmt.Fprintf(&b, "%s  %s  %s for %s  %s  price %s  PnL %s  drawdown %.2f%% (max %.2f%%)  trades %d (%.0f%% won)\n",
+		paint(ansiBold, AppName), paint(ansiBold, state.CurrentSymbol),
+		paint(ansiCyan, strings.ToUpper(string(state.Mode))), time.Since(state.ModeSince).Round(time.Second), activity,
+		paint(ansiBold, strconv.FormatFloat(price, 'f', -1, 64)), paint(pnlColor, fmt.Sprintf("%+.2f", performance.TotalPnL)),
+		performance.CurrentDrawdown*100, performance.MaxDrawdown*100, performance.TotalTrades, performance.WinRate*100)
+
+	resting := 0
+	for _, level := range snapshot.status.GridLayout {
+		if level.Active {
+			resting++
+		}
+	}
+	var details []string
+	if account != nil {
+		details = append(details, fmt.Sprintf("equity %.2f", account.Equity),
+			fmt.Sprintf("balance %.2f (available %.2f)", account.Balance, account.AvailableBalance),
+			fmt.Sprintf("unrealized %+.2f", account.UnrealizedPnL),
+			fmt.Sprintf("today %+.2f", account.DailyPnL.Total()))
+	}
+	if state.GridBounds.UpperBound > 0 {
+		details = append(details, fmt.Sprintf("grid %g - %g (%d levels, %d resting)",
+			state.GridBounds.LowerBound, state.GridBounds.UpperBound, len(snapshot.status.GridLayout), resting))
+	}
+	if state.BreakoutInfo != nil {
+		details = append(details, fmt.Sprintf("breakout %s @ %g (confirmed: %v)",
+			state.BreakoutInfo.BreakoutType, state.BreakoutInfo.EntryPrice, state.BreakoutInfo.IsConfirmed))
+	}
+	fmt.Fprintf(&b, "%s\n\n", strings.Join(details, "  "))
+
+	// Positions below the chart
+	var positions []string
+	if account != nil {
+		for _, position := range account.Positions {
+			line := fmt.Sprintf("  %-5s %s %g @ %g  mark %g  unrealized %+.2f", strings.ToUpper(string(position.Type)),
+				position.Symbol, math.Abs(position.Size), position.EntryPrice, position.MarkPrice, position.UnrealizedPnL)
+			if position.Liquidation.LiquidationPrice > 0 {
+				line += fmt.Sprintf("  liquidation %g (%.1f%% away)", position.Liquidation.LiquidationPrice, position.Liquidation.Distance*100)
+			}
+			positions = append(positions, line)
+		}
+	}
+	if len(positions) == 0 {
+		positions = []string{"  none"}
+	}
+	chartRows := rows - 3 - 2 - len(positions) - 1 // Header, positions with their title and a blank line, footer
+	if chartRows < 5 {
+		chartRows = 5
+	}
+
+	b.WriteString(renderChart(snapshot, chartRows, columns, paint))
+	fmt.Fprintf(&b, "\n%s\n", paint(ansiBold, "POSITIONS"))
+	for _, line := range positions {
+		fmt.Fprintln(&b, line)
+	}
+
+	if snapshot.err != nil {
+		footer = paint(ansiRed, snapshot.err.Error()) + " · " + footer
+	}
+	b.WriteString(paint(ansiDim, footer))
+	return b.String()
+}
+
+// renderChart draws candles two columns apart with grid levels and position entries as horizontal lines
+// and their prices on the axis
+func renderChart(snapshot tuiSnapshot, rows, columns int, paint func(code, text string) string) string {
+	candles := snapshot.candles
+	if len(candles) == 0 {
+		return strings.Repeat("\n", rows/2) + "  Waiting for candles..." + strings.Repeat("\n", rows-rows/2)
+	}
+	width := columns - tuiAxisWidth
+	if len(candles)*2 > width {
+		candles = candles[len(candles)-width/2:]
+	}
+
+	// The price range covers the candles, the grid and the entry prices
+	high, low := candles[0].High, candles[0].Low
+	include := func(price float64) {
+		if price > 0 {
+			high, low = math.Max(high, price), math.Min(low, price)
+		}
+	}
+	for _, candle := range candles {
+		include(candle.High)
+		include(candle.Low)
+	}
+	for _, level := range snapshot.status.GridLayout {
+		include(level.Price)
+	}
+	var entries []types.Position
+	if snapshot.status.Account != nil {
+		for _, position := range snapshot.status.Account.Positions {
+			include(position.EntryPrice)
+			entries = append(entries, position.Position)
+		}
+	}
+	if high == low {
+		high, low = high*1.001, low*0.999
+	}
+	step := (high - low) / float64(rows-1)
+	rowOf := func(price float64) int {
+		return int(math.Round((high - price) / step))
+	}
+	decimals := 0
+	if step > 0 && step < 1 {
+		decimals = int(math.Ceil(-math.Log10(step))) + 1
+	}
+	if decimals > 8 {
+		decimals = 8
+	}
+
+	grid := make([][]tuiCell, rows)
+	labels := make([]string, rows)
+	for row := range grid {
+		grid[row] = make([]tuiCell, width)
+		for column := range grid[row] {
+			grid[row][column] = tuiCell{char: ' '}
+		}
+		if row%5 == 0 {
+			labels[row] = paint(ansiDim, strconv.FormatFloat(high-float64(row)*step, 'f', decimals, 64))
+		}
+	}
+	line := func(row int, char rune, color string) {
+		for column := range grid[row] {
+			grid[row][column] = tuiCell{char: char, color: color}
+		}
+	}
+
+	// Resting grid orders are bright, empty levels dim
+	for _, level := range snapshot.status.GridLayout {
+		row := rowOf(level.Price)
+		color, side := ansiGreen, "buy "
+		if level.Side == types.OrderSideSell {
+			color, side = ansiRed, "sell"
+		}
+		if !level.Active {
+			color = ansiDim
+		}
+		line(row, '┄', color)
+		labels[row] = paint(color, side+" "+strconv.FormatFloat(level.Price, 'f', decimals, 64))
+	}
+	for _, position := range entries {
+		row := rowOf(position.EntryPrice)
+		line(row, '═', ansiYellow)
+		labels[row] = paint(ansiYellow, fmt.Sprintf("%-4s %s", position.Type, strconv.FormatFloat(position.EntryPrice, 'f', decimals, 64)))
+	}
+
+	for i, candle := range candles {
+		column := i * 2
+		color := ansiGreen
+		if candle.Close < candle.Open {
+			color = ansiRed
+		}
+		bodyTop, bodyBottom := rowOf(math.Max(candle.Open, candle.Close)), rowOf(math.Min(candle.Open, candle.Close))
+		for row := rowOf(candle.High); row <= rowOf(candle.Low); row++ {
+			char := '│'
+			if row >= bodyTop && row <= bodyBottom {
+				char = '█'
+			}
+			grid[row][column] = tuiCell{char: char, color: color}
+		}
+	}
+	last := candles[len(candles)-1].Close
+	labels[rowOf(last)] = paint(ansiBold, "◀ "+strconv.FormatFloat(last, 'f', decimals, 64))
+
+	var b strings.Builder
+	for row := range grid {
+		color := ""
+		var run strings.Builder
+		flush := func() {
+			b.WriteString(paint(color, run.String()))
+			run.Reset()
+		}
+		for _, cell := range grid[row] {
+			if cell.color != color {
+				flush()
+				color = cell.color
+			}
+			run.WriteRune(cell.char)
+		}
+		flush()
+		fmt.Fprintf(&b, " %s\n", labels[row])
+	}
+	return b.String()
+}
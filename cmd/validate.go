@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"aibot/internal/config"
+	"aibot/internal/strategy"
+	"aibot/pkg/app"
+	"aibot/pkg/trading"
+)
+
+// CheckStatus represents the outcome of a single validation check
+type CheckStatus string
+
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+	CheckSkip CheckStatus = "skip"
+)
+
+// CheckResult is one line of the go/no-go checklist
+type CheckResult struct {
+	Name    string
+	Status  CheckStatus
+	Message string
+}
+
+// validationChecklist collects check results in order
+type validationChecklist struct {
+	results []CheckResult
+}
+
+func (c *validationChecklist) add(name string, status CheckStatus, format string, args ...interface{}) {
+	c.results = append(c.results, CheckResult{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// passed returns true if no check failed
+func (c *validationChecklist) passed() bool {
+	for _, result := range c.results {
+		if result.Status == CheckFail {
+			return false
+		}
+	}
+	return true
+}
+
+// warned returns true if any check warned
+func (c *validationChecklist) warned() bool {
+	for _, result := range c.results {
+		if result.Status == CheckWarn {
+			return true
+		}
+	}
+	return false
+}
+
+// print writes the checklist under a title and the final verdict to stdout
+func (c *validationChecklist) print(title string) {
+	icons := map[CheckStatus]string{
+		CheckPass: "✅",
+		CheckWarn: "⚠️ ",
+		CheckFail: "❌",
+		CheckSkip: "⏭️ ",
+	}
+
+	fmt.Println(title)
+	fmt.Println(strings.Repeat("=", len(title)))
+	for _, result := range c.results {
+		fmt.Printf("%s %-24s %s\n", icons[result.Status], result.Name, result.Message)
+	}
+	fmt.Println()
+	if c.passed() {
+		fmt.Println("Result: GO")
+	} else {
+		fmt.Println("Result: NO-GO")
+	}
+}
+
+// runValidate connects to the configured exchange and checks it is ready for trading without placing orders
+func runValidate() int {
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Printf("❌ Failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	checklist := &validationChecklist{}
+	validateRiskPolicy(cfg, checklist)
+	validateExchange(cfg, checklist)
+	checklist.print("Pre-flight checklist")
+
+	if !checklist.passed() {
+		return 1
+	}
+	return 0
+}
+
+// validateRiskPolicy checks that the configured risk policy file loads
+func validateRiskPolicy(cfg *config.Config, checklist *validationChecklist) {
+	if cfg.Risk.PolicyFile == "" {
+		checklist.add("Risk policy", CheckSkip, "no policy file configured")
+		return
+	}
+	policy, err := strategy.LoadRiskPolicy(cfg.Risk.PolicyFile)
+	if err != nil {
+		checklist.add("Risk policy", CheckFail, "%v", err)
+		return
+	}
+	checklist.add("Risk policy", CheckPass, "%d banned symbols, %d trading windows",
+		len(policy.BannedSymbols), len(policy.TradingWindows))
+}
+
+// validateExchange runs all exchange checks, stopping early when the executor is unusable
+func validateExchange(cfg *config.Config, checklist *validationChecklist) {
+	executor, err := app.NewTradingExecutor(cfg.Trading)
+	if err != nil {
+		checklist.add("Executor", CheckFail, "%s executor unavailable: %v", cfg.Trading.ExecutionType, err)
+		return
+	}
+	checklist.add("Executor", CheckPass, "%s executor created", cfg.Trading.ExecutionType)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := executor.Connect(ctx); err != nil || !executor.IsConnected() {
+		checklist.add("Connection", CheckFail, "failed to connect: %v", err)
+		return
+	}
+	defer executor.Disconnect()
+	checklist.add("Connection", CheckPass, "connected")
+
+	accountInfo, hasAccountInfo := executor.(trading.AccountInfoProvider)
+	validatePermissions(accountInfo, hasAccountInfo, cfg.Trading.MarketType == "spot", checklist)
+
+	for _, symbol := range cfg.Trading.SupportedSymbols {
+		validateSymbol(executor, accountInfo, hasAccountInfo, cfg.Trading, symbol, checklist)
+	}
+
+	validateFees(executor, cfg.Trading, checklist)
+	validateBalance(executor, cfg.Trading, checklist)
+}
+
+// validatePermissions checks the API key can trade (futures unless on spot) and cannot withdraw
+func validatePermissions(accountInfo trading.AccountInfoProvider, supported, spot bool, checklist *validationChecklist) {
+	if !supported {
+		checklist.add("API permissions", CheckSkip, "executor does not report permissions")
+		return
+	}
+
+	permissions, err := accountInfo.GetAccountPermissions()
+	if err != nil {
+		checklist.add("API permissions", CheckFail, "failed to query permissions: %v", err)
+		return
+	}
+
+	switch {
+	case !permissions.CanTrade:
+		checklist.add("API permissions", CheckFail, "trading is not enabled for this API key")
+	case !spot && !permissions.CanFutures:
+		checklist.add("API permissions", CheckFail, "futures trading is not enabled for this API key")
+	case permissions.CanWithdraw:
+		checklist.add("API permissions", CheckWarn, "withdrawals are enabled; use a trade-only key")
+	default:
+		checklist.add("API permissions", CheckPass, "trading enabled, withdrawals disabled")
+	}
+}
+
+// validateSymbol checks symbol availability, configured sizes against exchange filters and leverage
+func validateSymbol(executor trading.TradingExecutor, accountInfo trading.AccountInfoProvider, supported bool,
+	cfg config.TradingConfig, symbol string, checklist *validationChecklist) {
+
+	price := 0.0
+	if ticker, err := executor.GetTicker(symbol); err == nil && ticker.Price > 0 {
+		price = ticker.Price
+	}
+
+	if !supported {
+		if price > 0 {
+			checklist.add("Symbol "+symbol, CheckPass, "price %.2f", price)
+		} else {
+			checklist.add("Symbol "+symbol, CheckWarn, "no market data; trading rules not reported")
+		}
+		return
+	}
+
+	rules, err := accountInfo.GetSymbolRules(symbol)
+	if err != nil {
+		checklist.add("Symbol "+symbol, CheckFail, "not available: %v", err)
+		return
+	}
+	if rules.Status != "TRADING" {
+		checklist.add("Symbol "+symbol, CheckFail, "status is %s", rules.Status)
+		return
+	}
+	if price > 0 {
+		checklist.add("Symbol "+symbol, CheckPass, "trading, price %.2f", price)
+	} else {
+		checklist.add("Symbol "+symbol, CheckWarn, "trading, but no price available")
+	}
+
+	// Configured sizes vs exchange filters
+	sizeCheck := "Sizes " + symbol
+	switch {
+	case cfg.MinPositionSize < rules.MinQty:
+		checklist.add(sizeCheck, CheckFail, "min position size %.6f below exchange min qty %.6f",
+			cfg.MinPositionSize, rules.MinQty)
+	case rules.MaxQty > 0 && cfg.MaxPositionSize > rules.MaxQty:
+		checklist.add(sizeCheck, CheckFail, "max position size %.6f above exchange max qty %.6f",
+			cfg.MaxPositionSize, rules.MaxQty)
+	case rules.MinNotional > 0 && price <= 0:
+		checklist.add(sizeCheck, CheckWarn, "cannot check min notional %.2f without a price", rules.MinNotional)
+	case rules.MinNotional > 0 && cfg.MinPositionSize*price < rules.MinNotional:
+		checklist.add(sizeCheck, CheckFail, "min position notional %.2f below exchange min notional %.2f",
+			cfg.MinPositionSize*price, rules.MinNotional)
+	case rules.StepSize > 0 && !isMultipleOf(cfg.MinPositionSize, rules.StepSize):
+		checklist.add(sizeCheck, CheckWarn, "min position size %.6f is not a multiple of step size %.6f",
+			cfg.MinPositionSize, rules.StepSize)
+	default:
+		checklist.add(sizeCheck, CheckPass, "min %.6f / max %.6f within exchange filters",
+			cfg.MinPositionSize, cfg.MaxPositionSize)
+	}
+
+	// Leverage settings
+	leverageCheck := "Leverage " + symbol
+	if cfg.MarketType == "spot" {
+		checklist.add(leverageCheck, CheckPass, "spot market, no leverage")
+		return
+	}
+	if rules.MaxLeverage > 0 && cfg.DefaultLeverage > rules.MaxLeverage {
+		checklist.add(leverageCheck, CheckFail, "default leverage %.0fx exceeds exchange max %.0fx",
+			cfg.DefaultLeverage, rules.MaxLeverage)
+		return
+	}
+	current, err := executor.GetLeverage(symbol)
+	switch {
+	case err != nil:
+		checklist.add(leverageCheck, CheckWarn, "failed to read account leverage: %v", err)
+	case current != cfg.DefaultLeverage:
+		checklist.add(leverageCheck, CheckWarn, "account leverage %.0fx differs from configured %.0fx",
+			current, cfg.DefaultLeverage)
+	default:
+		checklist.add(leverageCheck, CheckPass, "%.0fx", current)
+	}
+}
+
+// validateFees compares exchange fee rates with the configured ones
+func validateFees(executor trading.TradingExecutor, cfg config.TradingConfig, checklist *validationChecklist) {
+	fees, err := executor.GetFeeRates()
+	if err != nil {
+		checklist.add("Fee rates", CheckWarn, "failed to query fee rates: %v", err)
+		return
+	}
+
+	if fees.MakerFee > cfg.MakerFee || fees.TakerFee > cfg.TakerFee {
+		checklist.add("Fee rates", CheckWarn, "exchange maker/taker %.4f%%/%.4f%% above configured %.4f%%/%.4f%%",
+			fees.MakerFee*100, fees.TakerFee*100, cfg.MakerFee*100, cfg.TakerFee*100)
+		return
+	}
+	checklist.add("Fee rates", CheckPass, "maker %.4f%%, taker %.4f%%", fees.MakerFee*100, fees.TakerFee*100)
+}
+
+// validateBalance checks the account balance covers the configured initial balance
+func validateBalance(executor trading.TradingExecutor, cfg config.TradingConfig, checklist *validationChecklist) {
+	balance, err := executor.GetBalance()
+	if err != nil {
+		checklist.add("Balance", CheckFail, "failed to query balance: %v", err)
+		return
+	}
+
+	if balance < cfg.InitialBalance {
+		checklist.add("Balance", CheckFail, "balance %.2f below configured initial balance %.2f",
+			balance, cfg.InitialBalance)
+		return
+	}
+	checklist.add("Balance", CheckPass, "%.2f available (initial balance %.2f)", balance, cfg.InitialBalance)
+}
+
+// isMultipleOf returns true if value is an integer multiple of step within float tolerance
+func isMultipleOf(value, step float64) bool {
+	ratio := value / step
+	return math.Abs(ratio-math.Round(ratio)) < 1e-9
+}
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"aibot/pkg/trading"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AccountSnapshot merges the executor's view of the account with the risk manager's view of its exposure
+type AccountSnapshot struct {
+	Timestamp        time.Time           `json:"timestamp"`
+	Symbol           string              `json:"symbol"`
+	Mode             TradingMode         `json:"mode"`
+	Balance          float64             `json:"balance"`
+	AvailableBalance float64             `json:"available_balance"`
+	Margin           *trading.MarginInfo `json:"margin,omitempty"`
+	SweptProfit      float64             `json:"swept_profit"` // Profit moved out of the trading account
+	Equity           float64             `json:"equity"`       // Total balance plus swept profit, as drawdown counts it
+	Positions        []AccountPosition   `json:"positions"`
+	UnrealizedPnL    float64             `json:"unrealized_pnl"`
+	Exposure         AccountExposure     `json:"exposure"`
+	DailyPnL         strategy.DailyPnL   `json:"daily_pnl"`
+	Errors           []string            `json:"errors,omitempty"` // Parts the executor failed to report
+}
+
+// AccountPosition is an open position marked to the latest price, with its liquidation estimate
+type AccountPosition struct {
+	types.Position
+	Liquidation strategy.LiquidationEstimate `json:"liquidation"`
+}
+
+// AccountExposure is the risk manager's view of the account
+type AccountExposure struct {
+	TotalExposure      float64              `json:"total_exposure"`
+	UsedMargin         float64              `json:"used_margin"`
+	CurrentDrawdown    float64              `json:"current_drawdown"`
+	MaxDrawdownReached float64              `json:"max_drawdown_reached"`
+	DeRiskLevel        strategy.DeRiskLevel `json:"derisk_level"`
+	PortfolioHealth    string               `json:"portfolio_health"`
+	OverallRiskLevel   float64              `json:"overall_risk_level"`
+	SizeMultiplier     float64              `json:"size_multiplier"`
+	EffectiveLeverage  float64              `json:"effective_leverage"`
+}
+
+// GetAccountSnapshot returns balances, margin, open positions with liquidation estimates, exposure and the
+// day's PnL in one view. Executor failures are listed in Errors and leave their part empty.
+func (o *Orchestrator) GetAccountSnapshot() AccountSnapshot {
+	o.mu.RLock()
+	snapshot := AccountSnapshot{
+		Timestamp: time.Now(),
+		Symbol:    o.activeSymbol,
+		Mode:      o.state.Mode,
+	}
+	o.mu.RUnlock()
+
+	if balance, err := o.tradingExecutor.GetBalance(); err != nil {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("balance: %v", err))
+	} else {
+		snapshot.Balance = balance
+	}
+	if available, err := o.tradingExecutor.GetAvailableBalance(); err != nil {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("available balance: %v", err))
+	} else {
+		snapshot.AvailableBalance = available
+	}
+
+	// Cross margin liquidation is estimated against the wallet, as the risk worker does
+	collateral := snapshot.Balance
+	if margin, err := o.tradingExecutor.GetMarginInfo(); err != nil {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("margin: %v", err))
+	} else {
+		snapshot.Margin = margin
+		collateral = margin.TotalBalance
+	}
+	snapshot.SweptProfit = o.profitSweeper.TotalSwept()
+	snapshot.Equity = collateral + snapshot.SweptProfit
+
+	snapshot.Positions = make([]AccountPosition, 0)
+	if positions, err := o.tradingExecutor.GetAllPositions(); err != nil {
+		snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("positions: %v", err))
+	} else {
+		for _, position := range positions {
+			if position == nil || position.IsFlat() || position.Status == "closed" {
+				continue
+			}
+			marked := *position
+			if price := o.candleAggregator.GetLatestPrice(marked.Symbol); price > 0 {
+				marked.UpdateMarkPrice(price)
+			}
+			snapshot.UnrealizedPnL += marked.UnrealizedPnL
+			snapshot.Positions = append(snapshot.Positions, AccountPosition{
+				Position:    marked,
+				Liquidation: o.riskManager.EstimateLiquidation(&marked, collateral),
+			})
+		}
+		sort.Slice(snapshot.Positions, func(i, j int) bool {
+			return types.PositionKey(snapshot.Positions[i].Symbol, snapshot.Positions[i].Type) <
+				types.PositionKey(snapshot.Positions[j].Symbol, snapshot.Positions[j].Type)
+		})
+	}
+
+	assessment := o.riskManager.AssessRisk()
+	snapshot.Exposure = AccountExposure{
+		TotalExposure:      o.riskManager.TotalExposure,
+		UsedMargin:         o.riskManager.UsedMargin,
+		CurrentDrawdown:    o.riskManager.CurrentDrawdown,
+		MaxDrawdownReached: o.riskManager.MaxDrawdownReached,
+		DeRiskLevel:        o.riskManager.GetDeRiskLevel(),
+		PortfolioHealth:    assessment.PortfolioHealth,
+		OverallRiskLevel:   assessment.OverallRiskLevel,
+		SizeMultiplier:     o.riskManager.GetSizeMultiplier(),
+		EffectiveLeverage:  o.riskManager.GetEffectiveLeverage(),
+	}
+	snapshot.DailyPnL = o.dailyLoss.Snapshot()
+	return snapshot
+}
+
+// journalAccountSnapshot appends an account snapshot to the snapshot log once per snapshot interval
+func (o *Orchestrator) journalAccountSnapshot(now time.Time) {
+	if o.accountSnapshots == nil || now.Sub(o.lastAccountSnapshot) < o.config.AccountSnapshotInterval {
+		return
+	}
+	o.lastAccountSnapshot = now
+	if err := o.accountSnapshots.Append(o.GetAccountSnapshot()); err != nil {
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ Failed to journal account snapshot: %v", err)
+	}
+}
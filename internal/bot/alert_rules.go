@@ -0,0 +1,394 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Alert rule metrics evaluated by the rules engine; metrics without a value (e.g. grid bounds while no
+// grid is set) leave the rules that use them unevaluated
+const (
+	AlertMetricPrice         = "price"          // Latest price of the traded symbol
+	AlertMetricMode          = "mode"           // Trading mode: idle, grid, breakout, stability, recovery
+	AlertMetricUnrealizedPnL = "unrealized_pnl" // Unrealized PnL of open positions
+	AlertMetricRealizedPnL   = "realized_pnl"   // Session realized PnL
+	AlertMetricDailyPnL      = "daily_pnl"      // Realized and unrealized PnL of the accounting day
+	AlertMetricBalance       = "balance"        // Ledger wallet balance
+	AlertMetricEquity        = "equity"         // Balance plus unrealized PnL
+	AlertMetricDrawdown      = "drawdown"       // Current drawdown from the equity peak, fraction
+	AlertMetricMaxDrawdown   = "max_drawdown"   // Session maximum drawdown, fraction
+	AlertMetricPositions     = "positions"      // Open positions
+	AlertMetricOpenOrders    = "open_orders"    // Resting orders
+	AlertMetricTrades        = "trades"         // Session trades
+	AlertMetricWinRate       = "win_rate"       // Session win rate, fraction
+	AlertMetricGridUpper     = "grid_upper"     // Grid upper bound
+	AlertMetricGridLower     = "grid_lower"     // Grid lower bound
+	AlertMetricGridCenter    = "grid_center"    // Grid center
+)
+
+// knownAlertMetrics lists the known metrics; mode is the only text metric
+var knownAlertMetrics = map[string]bool{
+	AlertMetricPrice: true, AlertMetricMode: true, AlertMetricUnrealizedPnL: true, AlertMetricRealizedPnL: true,
+	AlertMetricDailyPnL: true, AlertMetricBalance: true, AlertMetricEquity: true, AlertMetricDrawdown: true,
+	AlertMetricMaxDrawdown: true, AlertMetricPositions: true, AlertMetricOpenOrders: true, AlertMetricTrades: true,
+	AlertMetricWinRate: true, AlertMetricGridUpper: true, AlertMetricGridLower: true, AlertMetricGridCenter: true,
+}
+
+// AlertRule is a user-defined alert condition. Conditions compare a metric with a number, another metric
+// or, for mode, a word:
+//
+//	unrealized_pnl < -100
+//	mode == recovery for > 10m
+//	price crosses above grid_upper
+//
+// A comparison fires when it becomes true, or once it has held for the "for" duration, and fires again
+// only after it was false in between. "crosses", "crosses above" and "crosses below" fire when the metric
+// moves through the other side between two checks.
+type AlertRule struct {
+	Name      string        `json:"name"`
+	Condition string        `json:"condition"`
+	Level     string        `json:"level"`    // "info", "warning" or "critical" (warning)
+	Message   string        `json:"message"`  // Alert text ("" describes the condition)
+	Cooldown  time.Duration `json:"cooldown"` // Least time between two firings of the rule (5m)
+}
+
+// AlertRulesConfig holds configuration for the alert rules engine
+type AlertRulesConfig struct {
+	CheckInterval time.Duration `json:"check_interval"` // 5s
+	Email         bool          `json:"email"`          // Also email firings through the email settings
+	Rules         []AlertRule   `json:"rules"`          // No rules disables the engine
+}
+
+// AlertMetrics is a snapshot of the metrics rules are evaluated against; values are float64, mode a string
+type AlertMetrics map[string]interface{}
+
+// AlertRuleFiring is a rule whose condition was met
+type AlertRuleFiring struct {
+	Rule      string    `json:"rule"`
+	Condition string    `json:"condition"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	Value     float64   `json:"value,omitempty"`     // Metric value when it is numeric
+	Threshold float64   `json:"threshold,omitempty"` // Compared value when it is numeric
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// alertOperand is a side of a condition: a metric, a number or a word
+type alertOperand struct {
+	metric string
+	number float64
+	word   string
+}
+
+// alertCondition is a parsed rule condition
+type alertCondition struct {
+	left      alertOperand
+	operator  string // "<", "<=", ">", ">=", "==", "!=" or "crosses"
+	direction string // Crossing direction: "above", "below" or "" for both
+	right     alertOperand
+	hold      time.Duration // Time a comparison must hold before firing
+}
+
+// alertRuleState is the evaluation state of one rule
+type alertRuleState struct {
+	rule      AlertRule
+	condition alertCondition
+
+	trueSince time.Time // Start of the current run of checks the comparison held, zero while false
+	fired     bool      // Fired during the current run; rearmed once the comparison is false
+	lastSide  int       // Sign of left minus right at the previous check, 0 before the first
+	lastFired time.Time
+	firings   int64
+}
+
+// AlertRuleEngine evaluates user-defined alert rules against periodic metric snapshots
+type AlertRuleEngine struct {
+	config AlertRulesConfig
+	rules  []*alertRuleState
+
+	// Statistics
+	checks  int64
+	firings int64
+
+	mu sync.Mutex
+}
+
+// NewAlertRuleEngine creates an alert rules engine, rejecting rules whose conditions do not parse
+func NewAlertRuleEngine(config AlertRulesConfig) (*AlertRuleEngine, error) {
+	if config.CheckInterval == 0 {
+		config.CheckInterval = 5 * time.Second // default
+	}
+
+	engine := &AlertRuleEngine{config: config}
+	names := make(map[string]bool, len(config.Rules))
+	for _, rule := range config.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("alert rule %q has no name", rule.Condition)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate alert rule name: %s", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Level == "" {
+			rule.Level = "warning" // default
+		}
+		if _, ok := alertLevelRank[rule.Level]; !ok {
+			return nil, fmt.Errorf("invalid level of alert rule %s: %s", rule.Name, rule.Level)
+		}
+		if rule.Cooldown == 0 {
+			rule.Cooldown = 5 * time.Minute // default
+		}
+		condition, err := parseAlertCondition(rule.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %s: %w", rule.Name, err)
+		}
+		engine.rules = append(engine.rules, &alertRuleState{rule: rule, condition: condition})
+	}
+	return engine, nil
+}
+
+// parseAlertCondition parses "<metric> <op> <operand> [for > <duration>]" or
+// "<metric> crosses [above|below] <operand>"
+func parseAlertCondition(text string) (alertCondition, error) {
+	var condition alertCondition
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) < 3 {
+		return condition, fmt.Errorf("condition %q must read \"<metric> <operator> <value>\"", text)
+	}
+
+	if !knownAlertMetrics[fields[0]] {
+		return condition, fmt.Errorf("unknown metric %q in condition %q", fields[0], text)
+	}
+	condition.left = alertOperand{metric: fields[0]}
+	condition.operator = fields[1]
+	rest := fields[2:]
+
+	switch condition.operator {
+	case "<", "<=", ">", ">=", "==", "!=":
+	case "crosses":
+		if rest[0] == "above" || rest[0] == "below" {
+			condition.direction, rest = rest[0], rest[1:]
+		}
+		if len(rest) != 1 {
+			return condition, fmt.Errorf("condition %q must read \"<metric> crosses [above|below] <value>\"", text)
+		}
+	default:
+		return condition, fmt.Errorf("unknown operator %q in condition %q", condition.operator, text)
+	}
+	if len(rest) == 0 {
+		return condition, fmt.Errorf("condition %q has no value to compare with", text)
+	}
+
+	right, err := parseAlertOperand(rest[0], condition.operator == "==" || condition.operator == "!=")
+	if err != nil {
+		return condition, fmt.Errorf("%w in condition %q", err, text)
+	}
+	condition.right = right
+	textual := condition.left.metric == AlertMetricMode || condition.right.metric == AlertMetricMode || right.word != ""
+	if textual && condition.operator != "==" && condition.operator != "!=" {
+		return condition, fmt.Errorf("mode can only be compared with == or != in condition %q", text)
+	}
+	if textual && condition.left.metric != AlertMetricMode && condition.right.metric != AlertMetricMode {
+		return condition, fmt.Errorf("only mode can be compared with a word in condition %q", text)
+	}
+
+	rest = rest[1:]
+	if len(rest) == 0 {
+		return condition, nil
+	}
+	// for > 10m, for >= 10m or for 10m
+	if rest[0] != "for" || len(rest) < 2 || len(rest) > 3 || (len(rest) == 3 && rest[1] != ">" && rest[1] != ">=") {
+		return condition, fmt.Errorf("condition %q must end with \"for > <duration>\"", text)
+	}
+	hold, err := time.ParseDuration(rest[len(rest)-1])
+	if err != nil || hold <= 0 {
+		return condition, fmt.Errorf("invalid duration %q in condition %q", rest[len(rest)-1], text)
+	}
+	condition.hold = hold
+	return condition, nil
+}
+
+// parseAlertOperand parses a number, a metric or, where allowed, a word
+func parseAlertOperand(field string, allowWord bool) (alertOperand, error) {
+	if number, err := strconv.ParseFloat(field, 64); err == nil {
+		return alertOperand{number: number}, nil
+	}
+	if knownAlertMetrics[field] {
+		return alertOperand{metric: field}, nil
+	}
+	if allowWord {
+		return alertOperand{word: field}, nil
+	}
+	return alertOperand{}, fmt.Errorf("%q is neither a number nor a known metric", field)
+}
+
+// value resolves an operand against a metric snapshot; ok is false when a metric has no value
+func (op alertOperand) value(metrics AlertMetrics) (interface{}, bool) {
+	switch {
+	case op.metric != "":
+		value, ok := metrics[op.metric]
+		return value, ok
+	case op.word != "":
+		return op.word, true
+	default:
+		return op.number, true
+	}
+}
+
+// String returns the operand as written in the condition
+func (op alertOperand) String() string {
+	switch {
+	case op.metric != "":
+		return op.metric
+	case op.word != "":
+		return op.word
+	default:
+		return strconv.FormatFloat(op.number, 'f', -1, 64)
+	}
+}
+
+// Evaluate checks every rule against a metric snapshot and returns the rules that fire
+func (e *AlertRuleEngine) Evaluate(metrics AlertMetrics, now time.Time) []AlertRuleFiring {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.checks++
+	var firings []AlertRuleFiring
+	for _, state := range e.rules {
+		firing := state.evaluate(metrics, now)
+		if firing == nil {
+			continue
+		}
+		if !state.lastFired.IsZero() && now.Sub(state.lastFired) < state.rule.Cooldown {
+			continue
+		}
+		state.lastFired = now
+		state.firings++
+		e.firings++
+		firings = append(firings, *firing)
+	}
+	return firings
+}
+
+// evaluate advances a rule's state by one check and returns a firing when its condition is met
+func (s *alertRuleState) evaluate(metrics AlertMetrics, now time.Time) *AlertRuleFiring {
+	condition := s.condition
+	left, okLeft := condition.left.value(metrics)
+	right, okRight := condition.right.value(metrics)
+	if !okLeft || !okRight {
+		// A metric without a value interrupts holds and crossings
+		s.trueSince, s.fired, s.lastSide = time.Time{}, false, 0
+		return nil
+	}
+	leftNumber, leftNumeric := left.(float64)
+	rightNumber, rightNumeric := right.(float64)
+
+	if condition.operator == "crosses" {
+		if !leftNumeric || !rightNumeric {
+			return nil
+		}
+		side := 0
+		if leftNumber > rightNumber {
+			side = 1
+		} else if leftNumber < rightNumber {
+			side = -1
+		}
+		previous := s.lastSide
+		if side != 0 {
+			s.lastSide = side
+		}
+		crossed := previous != 0 && side != 0 && side != previous
+		if !crossed || (condition.direction == "above" && side < 0) || (condition.direction == "below" && side > 0) {
+			return nil
+		}
+		return s.firing(leftNumber, rightNumber, now)
+	}
+
+	var holds bool
+	if leftNumeric && rightNumeric {
+		holds = compareAlertValues(leftNumber, rightNumber, condition.operator)
+	} else {
+		equal := fmt.Sprint(left) == fmt.Sprint(right)
+		holds = equal == (condition.operator == "==")
+	}
+	if !holds {
+		s.trueSince, s.fired = time.Time{}, false
+		return nil
+	}
+	if s.trueSince.IsZero() {
+		s.trueSince = now
+	}
+	if s.fired || now.Sub(s.trueSince) < condition.hold {
+		return nil
+	}
+	s.fired = true
+	if !leftNumeric || !rightNumeric {
+		return s.firing(0, 0, now)
+	}
+	return s.firing(leftNumber, rightNumber, now)
+}
+
+// compareAlertValues applies a comparison operator
+func compareAlertValues(left, right float64, operator string) bool {
+	switch operator {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "==":
+		return left == right
+	default:
+		return left != right
+	}
+}
+
+// firing describes a met condition
+func (s *alertRuleState) firing(value, threshold float64, now time.Time) *AlertRuleFiring {
+	message := s.rule.Message
+	if message == "" {
+		message = fmt.Sprintf("Alert rule %s: %s", s.rule.Name, s.rule.Condition)
+		if s.condition.left.metric != AlertMetricMode {
+			message += fmt.Sprintf(" (%s = %s)", s.condition.left.metric, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+	return &AlertRuleFiring{
+		Rule:      s.rule.Name,
+		Condition: s.rule.Condition,
+		Level:     s.rule.Level,
+		Message:   message,
+		Value:     value,
+		Threshold: threshold,
+		Timestamp: now,
+	}
+}
+
+// GetAlertRuleStats returns alert rule statistics
+func (e *AlertRuleEngine) GetAlertRuleStats() map[string]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rules := make([]map[string]interface{}, 0, len(e.rules))
+	for _, state := range e.rules {
+		rules = append(rules, map[string]interface{}{
+			"name":       state.rule.Name,
+			"condition":  state.rule.Condition,
+			"level":      state.rule.Level,
+			"active":     !state.trueSince.IsZero(),
+			"firings":    state.firings,
+			"last_fired": state.lastFired,
+		})
+	}
+	return map[string]interface{}{
+		"check_interval": e.config.CheckInterval.String(),
+		"checks":         e.checks,
+		"firings":        e.firings,
+		"rules":          rules,
+	}
+}
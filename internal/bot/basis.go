@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"fmt"
+	"time"
+)
+
+// basisWorker polls the spot price of the active symbol and evaluates its basis against the perpetual,
+// alerting when the basis turns extreme or a price goes stale and resuming grid entries once it normalizes
+func (o *Orchestrator) basisWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.spotPriceFeed.config.PollInterval)
+	defer ticker.Stop()
+
+	poll := func() {
+		o.mu.RLock()
+		symbol := o.activeSymbol
+		o.mu.RUnlock()
+
+		// The spot endpoint shares the rate limit of the banned IP
+		if !o.restPaused() {
+			price, err := o.spotPriceFeed.Fetch(o.ctx, symbol)
+			if err != nil {
+				o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⚠️ Failed to fetch spot price: %v", err)
+			} else {
+				o.basisMonitor.UpdateSpot(symbol, price, time.Now())
+			}
+		}
+
+		reading, changed := o.basisMonitor.Evaluate(symbol, time.Now())
+		if changed {
+			o.reportBasis(reading)
+		}
+	}
+	poll()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+// reportBasis reports a basis reading that changed between normal, extreme and stale
+func (o *Orchestrator) reportBasis(reading strategy.BasisReading) {
+	switch {
+	case reading.Extreme:
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "📐 Basis extreme, pausing grid entries: %s", reading.Reason)
+		o.publishRiskAlert(RiskAlert{
+			Level:     "warning",
+			Type:      "basis",
+			Message:   fmt.Sprintf("%s (spot %.8g, mark %.8g)", reading.Reason, reading.Spot, reading.Mark),
+			Symbol:    reading.Symbol,
+			Value:     reading.Basis,
+			Threshold: o.config.BasisConfig.MaxBasis,
+			Timestamp: reading.Timestamp,
+		})
+
+	case reading.Stale:
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "📐 Basis unavailable: %s", reading.Reason)
+		o.publishRiskAlert(RiskAlert{
+			Level:     "warning",
+			Type:      "basis_stale",
+			Message:   fmt.Sprintf("Basis cannot be measured: %s", reading.Reason),
+			Symbol:    reading.Symbol,
+			Timestamp: reading.Timestamp,
+		})
+
+	default:
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "📐 Basis of %s back to %.4f%%", reading.Symbol, reading.Basis*100)
+		o.publishSignal(TradingSignal{
+			Type:      "basis_normal",
+			Symbol:    reading.Symbol,
+			Action:    "resume_entries",
+			Reason:    fmt.Sprintf("basis back to %.4f%%", reading.Basis*100),
+			Data:      reading,
+			Timestamp: reading.Timestamp,
+		})
+	}
+}
+
+// handleBasisNormal places the grid levels held back while the basis was extreme
+func (o *Orchestrator) handleBasisNormal(signal TradingSignal) {
+	o.mu.RLock()
+	mode := o.state.Mode
+	o.mu.RUnlock()
+	if mode == ModeGrid {
+		o.placeGridOrders(signal.Context)
+	}
+}
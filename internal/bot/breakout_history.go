@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"aibot/internal/journal"
+	"aibot/internal/logging"
+	"time"
+)
+
+// recordBreakoutOutcome counts how a breakout resolved in its symbol's success rate and persists it, so
+// breakout confidence keeps learning across restarts. Each breakout is recorded once; the caller holds o.mu.
+func (o *Orchestrator) recordBreakoutOutcome(info *BreakoutInfo, outcome string) {
+	if info == nil || info.OutcomeRecorded || info.ID == "" {
+		return
+	}
+	info.OutcomeRecorded = true
+
+	wasReal := outcome == journal.BreakoutConfirmed
+	o.breakoutDetector.RecordOutcome(o.activeSymbol, wasReal)
+	o.logf(logging.ComponentOrchestrator, logging.DebugLevel, "📚 Breakout %s of %s resolved as %s (success rate %.0f%%)",
+		info.ID, o.activeSymbol, outcome, o.breakoutDetector.SuccessRate(o.activeSymbol)*100)
+
+	if o.breakoutHistory == nil {
+		return
+	}
+	record := journal.BreakoutRecord{
+		ID:         info.ID,
+		Symbol:     o.activeSymbol,
+		Type:       string(info.BreakoutType),
+		Confidence: info.Confidence,
+		EntryPrice: info.EntryPrice,
+		ExitPrice:  o.candleAggregator.GetLatestPrice(o.activeSymbol),
+		Start:      info.BreakoutTime,
+		End:        time.Now(),
+		Outcome:    outcome,
+		WasReal:    wasReal,
+	}
+	if err := o.breakoutHistory.Record(record); err != nil {
+		o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⚠️ Failed to record breakout outcome: %v", err)
+	}
+}
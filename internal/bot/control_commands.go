@@ -0,0 +1,301 @@
+package bot
+
+import (
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Control protocol commands with typed parameters
+const (
+	ControlSetSymbol       = "set_symbol"
+	ControlSubscribe       = "subscribe"
+	ControlUnsubscribe     = "unsubscribe"
+	ControlUpdateRiskLimit = "update_risk_limit"
+	ControlClosePosition   = "close_position"
+	ControlPlaceOrder      = "place_order"
+	ControlModifyOrder     = "modify_order"
+	ControlCancelOrder     = "cancel_order"
+	ControlBatch           = "batch"
+)
+
+// maxBatchCommands caps the number of commands in one batch
+const maxBatchCommands = 50
+
+// symbolPattern matches exchange symbols such as BTCUSDT
+var symbolPattern = regexp.MustCompile(`^[A-Z0-9]{2,20}$`)
+
+// ControlPayload is the typed, validated payload of a control command
+type ControlPayload interface {
+	Validate() error
+}
+
+// SwitchModeParams are the parameters of switch_mode
+type SwitchModeParams struct {
+	Mode TradingMode `json:"mode"`
+}
+
+// Validate checks that the mode is known
+func (p SwitchModeParams) Validate() error {
+	switch p.Mode {
+	case ModeGrid, ModeBreakout, ModeRecovery, ModeStability, ModeIdle:
+		return nil
+	case "":
+		return fmt.Errorf("switch_mode requires a mode")
+	default:
+		return fmt.Errorf("unknown trading mode: %s", p.Mode)
+	}
+}
+
+// SetSymbolParams are the parameters of set_symbol
+type SetSymbolParams struct {
+	Symbol string `json:"symbol"`
+}
+
+// Validate checks that the symbol looks like an exchange symbol
+func (p SetSymbolParams) Validate() error {
+	if !symbolPattern.MatchString(p.Symbol) {
+		return fmt.Errorf("invalid symbol %q: use the exchange symbol, e.g. BTCUSDT", p.Symbol)
+	}
+	return nil
+}
+
+// SubscriptionParams are the parameters of subscribe and unsubscribe
+type SubscriptionParams struct {
+	Symbols []string `json:"symbols"`
+}
+
+// Validate checks that at least one symbol is given and each looks like an exchange symbol
+func (p SubscriptionParams) Validate() error {
+	if len(p.Symbols) == 0 {
+		return fmt.Errorf("subscription requires at least one symbol")
+	}
+	for _, symbol := range p.Symbols {
+		if err := (SetSymbolParams{Symbol: symbol}).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RiskLimitParams are the parameters of update_risk_limit; omitted limits keep their current value
+type RiskLimitParams struct {
+	MaxDailyLoss    *float64 `json:"max_daily_loss,omitempty"` // Quote asset amount, 0 disables the limit
+	MaxDrawdown     float64  `json:"max_drawdown,omitempty"`
+	MaxPositionRisk float64  `json:"max_position_risk,omitempty"`
+	MaxPositionSize float64  `json:"max_position_size,omitempty"`
+}
+
+// Validate checks that at least one limit is set and all are within range
+func (p RiskLimitParams) Validate() error {
+	if p.MaxDailyLoss == nil && p.RiskLimits() == (strategy.RiskLimits{}) {
+		return fmt.Errorf("update_risk_limit requires at least one limit")
+	}
+	if p.MaxDailyLoss != nil && *p.MaxDailyLoss < 0 {
+		return fmt.Errorf("max daily loss cannot be negative, got %.2f", *p.MaxDailyLoss)
+	}
+	return p.RiskLimits().Validate()
+}
+
+// RiskLimits returns the limits enforced by the risk manager
+func (p RiskLimitParams) RiskLimits() strategy.RiskLimits {
+	return strategy.RiskLimits{
+		MaxDrawdown:     p.MaxDrawdown,
+		MaxPositionRisk: p.MaxPositionRisk,
+		MaxPositionSize: p.MaxPositionSize,
+	}
+}
+
+// ClosePositionParams are the parameters of close_position
+type ClosePositionParams struct {
+	Symbol       string             `json:"symbol"`
+	PositionType types.PositionType `json:"position_type,omitempty"` // "long" or "short" ("" closes both)
+}
+
+// Validate checks the symbol and position type
+func (p ClosePositionParams) Validate() error {
+	if err := (SetSymbolParams{Symbol: p.Symbol}).Validate(); err != nil {
+		return err
+	}
+	switch p.PositionType {
+	case "", types.PositionTypeLong, types.PositionTypeShort:
+		return nil
+	default:
+		return fmt.Errorf("position type must be long or short, got %s", p.PositionType)
+	}
+}
+
+// PlaceOrderParams are the parameters of place_order
+type PlaceOrderParams struct {
+	Symbol       string             `json:"symbol"`
+	Side         types.OrderSide    `json:"side"`
+	Type         types.OrderType    `json:"type,omitempty"` // "market" or "limit" (limit if a price is given, market otherwise)
+	Quantity     float64            `json:"quantity"`
+	Price        float64            `json:"price,omitempty"`         // Limit price
+	PositionType types.PositionType `json:"position_type,omitempty"` // Position side in hedge mode (derived from side and reduce_only)
+	ReduceOnly   bool               `json:"reduce_only,omitempty"`
+	PostOnly     bool               `json:"post_only,omitempty"` // Limit orders only; rejected instead of taking liquidity
+}
+
+// OrderType returns the order type, derived from the price if not given
+func (p PlaceOrderParams) OrderType() types.OrderType {
+	if p.Type != "" {
+		return p.Type
+	}
+	if p.Price > 0 {
+		return types.OrderTypeLimit
+	}
+	return types.OrderTypeMarket
+}
+
+// Validate checks the symbol, side, type, quantity and price
+func (p PlaceOrderParams) Validate() error {
+	if err := (SetSymbolParams{Symbol: p.Symbol}).Validate(); err != nil {
+		return err
+	}
+	if p.Side != types.OrderSideBuy && p.Side != types.OrderSideSell {
+		return fmt.Errorf("order side must be buy or sell, got %q", p.Side)
+	}
+	if p.Quantity <= 0 {
+		return fmt.Errorf("order quantity must be positive, got %g", p.Quantity)
+	}
+	switch p.OrderType() {
+	case types.OrderTypeMarket:
+		if p.Price != 0 || p.PostOnly {
+			return fmt.Errorf("market orders take neither a price nor post_only")
+		}
+	case types.OrderTypeLimit:
+		if p.Price <= 0 {
+			return fmt.Errorf("limit orders require a positive price, got %g", p.Price)
+		}
+	default:
+		return fmt.Errorf("order type must be market or limit, got %s", p.Type)
+	}
+	switch p.PositionType {
+	case "", types.PositionTypeLong, types.PositionTypeShort:
+		return nil
+	default:
+		return fmt.Errorf("position type must be long or short, got %s", p.PositionType)
+	}
+}
+
+// ModifyOrderParams are the parameters of modify_order; an omitted quantity or price keeps the order's own
+type ModifyOrderParams struct {
+	OrderID  string  `json:"order_id"`
+	Quantity float64 `json:"quantity,omitempty"` // New total quantity of the replacement (remaining quantity if 0)
+	Price    float64 `json:"price,omitempty"`
+}
+
+// Validate checks that an order and at least one change are given
+func (p ModifyOrderParams) Validate() error {
+	if p.OrderID == "" {
+		return fmt.Errorf("modify_order requires an order ID")
+	}
+	if p.Quantity < 0 || p.Price < 0 {
+		return fmt.Errorf("quantity and price cannot be negative")
+	}
+	if p.Quantity == 0 && p.Price == 0 {
+		return fmt.Errorf("modify_order requires a new quantity or price")
+	}
+	return nil
+}
+
+// CancelOrderParams are the parameters of cancel_order
+type CancelOrderParams struct {
+	OrderID string `json:"order_id"`
+}
+
+// Validate checks that an order is given
+func (p CancelOrderParams) Validate() error {
+	if p.OrderID == "" {
+		return fmt.Errorf("cancel_order requires an order ID")
+	}
+	return nil
+}
+
+// ControlResult is the outcome of one command of a batch
+type ControlResult struct {
+	Index   int          `json:"index"`
+	Command string       `json:"command"`
+	OK      bool         `json:"ok"`
+	Error   string       `json:"error,omitempty"`
+	Skipped bool         `json:"skipped,omitempty"` // Not run because an earlier command failed
+	Order   *types.Order `json:"order,omitempty"`   // Order placed, replaced or cancelled by an order command
+}
+
+// Payload returns the typed payload of a trading command, validated; commands without parameters
+// return nil
+func (r ControlRequest) Payload() (ControlPayload, error) {
+	var payload ControlPayload
+	switch r.Command {
+	case ControlPause, ControlResume, ControlCloseAll, ControlUnlockMode:
+		return nil, nil
+	case ControlSwitchMode:
+		payload = SwitchModeParams{Mode: r.Mode}
+	case ControlLockMode:
+		payload = LockModeParams{Mode: r.Mode, Reason: r.Reason}
+	case ControlSetSymbol:
+		payload = SetSymbolParams{Symbol: strings.ToUpper(r.Symbol)}
+	case ControlSubscribe, ControlUnsubscribe:
+		symbols := make([]string, 0, len(r.Symbols)+1)
+		if r.Symbol != "" {
+			symbols = append(symbols, strings.ToUpper(r.Symbol))
+		}
+		for _, symbol := range r.Symbols {
+			symbols = append(symbols, strings.ToUpper(symbol))
+		}
+		payload = SubscriptionParams{Symbols: symbols}
+	case ControlClosePosition:
+		payload = ClosePositionParams{Symbol: strings.ToUpper(r.Symbol), PositionType: r.PositionType}
+	case ControlUpdateRiskLimit:
+		if r.Risk == nil {
+			return nil, fmt.Errorf("update_risk_limit requires risk limits")
+		}
+		payload = *r.Risk
+	case ControlUpdateGrid:
+		if r.Grid == nil {
+			return nil, fmt.Errorf("update_grid requires a grid update")
+		}
+		payload = *r.Grid
+	case ControlPlaceOrder:
+		if r.Order == nil {
+			return nil, fmt.Errorf("place_order requires an order")
+		}
+		params := *r.Order
+		params.Symbol = strings.ToUpper(params.Symbol)
+		payload = params
+	case ControlModifyOrder:
+		if r.Modify == nil {
+			return nil, fmt.Errorf("modify_order requires an order change")
+		}
+		payload = *r.Modify
+	case ControlCancelOrder:
+		payload = CancelOrderParams{OrderID: r.OrderID}
+	default:
+		return nil, fmt.Errorf("%s cannot be sent to the control worker", r.Command)
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// validateBatch checks every command of a batch before any of them runs
+func validateBatch(requests []ControlRequest) ([]ControlPayload, []ControlResult, bool) {
+	payloads := make([]ControlPayload, len(requests))
+	results := make([]ControlResult, len(requests))
+	valid := true
+	for i, request := range requests {
+		results[i] = ControlResult{Index: i, Command: request.Command}
+		payload, err := request.Payload()
+		if err != nil {
+			results[i].Error = err.Error()
+			valid = false
+			continue
+		}
+		payloads[i] = payload
+	}
+	return payloads, results, valid
+}
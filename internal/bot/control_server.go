@@ -0,0 +1,450 @@
+package bot
+
+import (
+	"aibot/internal/data"
+	"aibot/internal/ledger"
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Control protocol commands
+const (
+	ControlStatus      = "status"
+	ControlPause       = "pause"
+	ControlResume      = "resume"
+	ControlCloseAll    = "close_all"
+	ControlSwitchMode  = "switch_mode"
+	ControlStop        = "stop"
+	ControlLogLevels   = "log_levels"
+	ControlSetLogLevel = "set_log_level"
+	ControlHealth      = "health"
+	ControlUpdateGrid  = "update_grid"
+	ControlCandles     = "candles"
+	ControlGridLevels  = "grid_levels"
+	ControlLedger      = "ledger"
+)
+
+// defaultLedgerEntries is the number of recent postings returned by ledger without a limit
+const defaultLedgerEntries = 20
+
+// ControlServerConfig holds configuration for the local control server
+type ControlServerConfig struct {
+	Address        string        `json:"address"`         // Loopback TCP address ("" disables TCP)
+	SocketPath     string        `json:"socket_path"`     // Unix domain socket path ("" disables the socket)
+	SocketMode     os.FileMode   `json:"socket_mode"`     // Permissions of the socket file; access is granted through them (0600)
+	CommandTimeout time.Duration `json:"command_timeout"` // Wait for the control worker to process a command (5s)
+}
+
+// ControlRequest is one line of JSON sent by a control client, e.g. {"command":"switch_mode","mode":"grid"}.
+// A line holding a JSON array of requests is run as a batch.
+type ControlRequest struct {
+	Command      string             `json:"command"`
+	Mode         TradingMode        `json:"mode,omitempty"`          // Target mode for switch_mode and lock_mode
+	Reason       string             `json:"reason,omitempty"`        // Reason shown with a lock_mode lock
+	Symbol       string             `json:"symbol,omitempty"`        // Symbol for set_symbol, close_position, subscribe, unsubscribe and ledger
+	Symbols      []string           `json:"symbols,omitempty"`       // Symbols for subscribe and unsubscribe
+	PositionType types.PositionType `json:"position_type,omitempty"` // Side for close_position ("" closes both)
+	Risk         *RiskLimitParams   `json:"risk,omitempty"`          // New limits for update_risk_limit
+	Component    string             `json:"component,omitempty"`     // Component for set_log_level ("" or "default" for all)
+	Level        string             `json:"level,omitempty"`         // Level for set_log_level; "default" removes a component override
+	Grid         *GridUpdate        `json:"grid,omitempty"`          // New grid layout for update_grid
+	Query        *data.CandleQuery  `json:"query,omitempty"`         // Candle window for candles ("" symbol: active symbol)
+	Order        *PlaceOrderParams  `json:"order,omitempty"`         // New order for place_order
+	Modify       *ModifyOrderParams `json:"modify,omitempty"`        // Order change for modify_order
+	OrderID      string             `json:"order_id,omitempty"`      // Order for cancel_order
+	Limit        int                `json:"limit,omitempty"`         // Most recent postings returned by ledger (20)
+
+	// Batch execution: every command is validated before the first one runs, then they run in order
+	Commands        []ControlRequest `json:"commands,omitempty"`          // Commands of a batch
+	ContinueOnError bool             `json:"continue_on_error,omitempty"` // Run the rest of a batch after a command fails
+}
+
+// ControlResponse is the line of JSON returned for each request; state, performance and grid overrides
+// are included after every successful trading command, the order after order commands, log levels after
+// log level commands and the watchdog report after health commands
+type ControlResponse struct {
+	OK          bool                       `json:"ok"`
+	Error       string                     `json:"error,omitempty"`
+	State       *BotState                  `json:"state,omitempty"`
+	Performance *PerformanceMetrics        `json:"performance,omitempty"`
+	Grid        *GridUpdate                `json:"grid,omitempty"` // Operator overrides of the grid layout in effect
+	LogLevels   map[string]string          `json:"log_levels,omitempty"`
+	Health      *HealthReport              `json:"health,omitempty"`
+	Results     []ControlResult            `json:"results,omitempty"`           // Outcome of each command of a batch
+	Candles     []types.OHLCV              `json:"candles,omitempty"`           // In-memory candles returned by candles
+	Order       *types.Order               `json:"order,omitempty"`             // Order placed, replaced or cancelled by an order command
+	GridLevels  []strategy.GridLevelStats  `json:"grid_levels,omitempty"`       // Per-level fill statistics returned by grid_levels
+	Account     *AccountSnapshot           `json:"account,omitempty"`           // Balances, positions and exposure returned by status
+	GridLayout  []types.GridLevel          `json:"grid_layout,omitempty"`       // Current grid levels returned by status
+	Ledger      *ledger.Summary            `json:"ledger,omitempty"`            // Balance, category totals and trial balance returned by ledger
+	Postings    []ledger.Entry             `json:"postings,omitempty"`          // Most recent ledger postings, filtered by symbol
+	Violations  []strategy.PolicyViolation `json:"policy_violations,omitempty"` // Most recent orders refused by the risk policy, returned by status
+}
+
+// ControlServer accepts newline-delimited JSON requests from local clients such as the CLI
+type ControlServer struct {
+	config       ControlServerConfig
+	orchestrator *Orchestrator
+	listeners    []net.Listener
+	conns        map[net.Conn]bool
+
+	// Statistics
+	requests int64
+	failures int64
+
+	closed bool
+	mu     sync.Mutex
+}
+
+// NewControlServer creates a control server for an orchestrator
+func NewControlServer(config ControlServerConfig, orchestrator *Orchestrator) *ControlServer {
+	if config.SocketMode == 0 {
+		config.SocketMode = 0600 // default
+	}
+	if config.CommandTimeout == 0 {
+		config.CommandTimeout = 5 * time.Second // default
+	}
+
+	return &ControlServer{
+		config:       config,
+		orchestrator: orchestrator,
+		conns:        make(map[net.Conn]bool),
+	}
+}
+
+// Start listens on the configured unix socket and loopback address
+func (cs *ControlServer) Start() error {
+	if cs.config.SocketPath != "" {
+		listener, err := cs.listenSocket()
+		if err != nil {
+			return err
+		}
+		cs.listeners = append(cs.listeners, listener)
+	}
+
+	if cs.config.Address != "" {
+		listener, err := cs.listenTCP()
+		if err != nil {
+			cs.Close()
+			return err
+		}
+		cs.listeners = append(cs.listeners, listener)
+	}
+
+	for _, listener := range cs.listeners {
+		log.Printf("🎛️ Control server listening on %s %s", listener.Addr().Network(), listener.Addr())
+		go cs.serve(listener)
+	}
+	return nil
+}
+
+// listenSocket creates the unix socket, replacing a stale socket left by a previous run
+func (cs *ControlServer) listenSocket() (net.Listener, error) {
+	path := cs.config.SocketPath
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("control socket path exists and is not a socket: %s", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("control socket %s is in use by another instance", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale control socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, cs.config.SocketMode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict control socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// listenTCP listens on the configured address; only loopback addresses are accepted
+func (cs *ControlServer) listenTCP() (net.Listener, error) {
+	host, _, err := net.SplitHostPort(cs.config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control address %s: %w", cs.config.Address, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("control address must be a loopback address: %s", cs.config.Address)
+	}
+
+	listener, err := net.Listen("tcp", cs.config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", cs.config.Address, err)
+	}
+	return listener, nil
+}
+
+// Close stops accepting connections and closes open ones; it does not wait for in-flight requests
+// because they may be blocked on the orchestrator that is shutting down
+func (cs *ControlServer) Close() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.closed {
+		return
+	}
+	cs.closed = true
+	for _, listener := range cs.listeners {
+		listener.Close() // Also removes the socket file
+	}
+	for conn := range cs.conns {
+		conn.Close()
+	}
+}
+
+// serve accepts connections until the listener is closed
+func (cs *ControlServer) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			cs.mu.Lock()
+			closed := cs.closed
+			cs.mu.Unlock()
+			if !closed {
+				log.Printf("❌ Control server stopped: %v", err)
+			}
+			return
+		}
+
+		cs.mu.Lock()
+		if cs.closed {
+			cs.mu.Unlock()
+			conn.Close()
+			return
+		}
+		cs.conns[conn] = true
+		cs.mu.Unlock()
+
+		go cs.handleConn(conn)
+	}
+}
+
+// handleConn answers requests on one connection until the client disconnects
+func (cs *ControlServer) handleConn(conn net.Conn) {
+	defer func() {
+		cs.mu.Lock()
+		delete(cs.conns, conn)
+		cs.mu.Unlock()
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var request ControlRequest
+		response := ControlResponse{}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if bytes.HasPrefix(line, []byte("[")) {
+			request.Command = ControlBatch
+			if err := json.Unmarshal(line, &request.Commands); err != nil {
+				response.Error = fmt.Sprintf("invalid batch: %v", err)
+			} else {
+				response = cs.handle(request)
+			}
+		} else if err := json.Unmarshal(line, &request); err != nil {
+			response.Error = fmt.Sprintf("invalid request: %v", err)
+		} else {
+			response = cs.handle(request)
+		}
+
+		cs.mu.Lock()
+		cs.requests++
+		if !response.OK {
+			cs.failures++
+		}
+		cs.mu.Unlock()
+
+		if err := encoder.Encode(response); err != nil {
+			return
+		}
+	}
+}
+
+// handle executes one request
+func (cs *ControlServer) handle(request ControlRequest) ControlResponse {
+	switch request.Command {
+	case ControlStatus:
+		state := cs.orchestrator.GetState()
+		performance := cs.orchestrator.GetPerformance()
+		account := cs.orchestrator.GetAccountSnapshot()
+		return ControlResponse{OK: true, State: &state, Performance: &performance, Grid: cs.orchestrator.GetGridOverride(), Account: &account,
+			GridLayout: cs.orchestrator.GetGridLevels(), Violations: cs.orchestrator.GetPolicyViolations()}
+	case ControlPause, ControlResume, ControlCloseAll, ControlSwitchMode, ControlSetSymbol, ControlUpdateRiskLimit,
+		ControlClosePosition, ControlUpdateGrid, ControlSubscribe, ControlUnsubscribe, ControlLockMode, ControlUnlockMode:
+		payload, err := request.Payload()
+		if err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		if _, err := cs.execute(request.Command, payload); err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+	case ControlPlaceOrder, ControlModifyOrder, ControlCancelOrder:
+		payload, err := request.Payload()
+		if err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		order, err := cs.execute(request.Command, payload)
+		if err != nil {
+			return ControlResponse{Error: err.Error(), Order: order}
+		}
+		return ControlResponse{OK: true, Order: order}
+	case ControlBatch:
+		response := cs.handleBatch(request)
+		if response.Error != "" {
+			return response
+		}
+		state := cs.orchestrator.GetState()
+		performance := cs.orchestrator.GetPerformance()
+		response.State, response.Performance, response.Grid = &state, &performance, cs.orchestrator.GetGridOverride()
+		return response
+	case ControlStop:
+		// Stopping closes this connection, so the command is queued and answered right away
+		log.Printf("🎛️ Control command received: %s", request.Command)
+		state := cs.orchestrator.GetState()
+		if !state.IsActive {
+			return ControlResponse{Error: "orchestrator is not active"}
+		}
+		performance := cs.orchestrator.GetPerformance()
+		cs.orchestrator.SendControlCommand(ControlCommand{Type: ControlStop})
+		return ControlResponse{OK: true, State: &state, Performance: &performance}
+	case ControlHealth:
+		health := cs.orchestrator.GetHealth()
+		return ControlResponse{OK: true, Health: &health}
+	case ControlCandles:
+		if request.Query == nil {
+			return ControlResponse{Error: "candles requires a query"}
+		}
+		candles, err := cs.orchestrator.GetCandles(*request.Query)
+		if err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		return ControlResponse{OK: true, Candles: candles}
+	case ControlGridLevels:
+		return ControlResponse{OK: true, GridLevels: cs.orchestrator.GetGridLevelStats()}
+	case ControlLedger:
+		limit := request.Limit
+		if limit <= 0 {
+			limit = defaultLedgerEntries
+		}
+		accounts := cs.orchestrator.GetLedger()
+		return ControlResponse{OK: true, Ledger: accounts.GetSummary(),
+			Postings: accounts.GetEntries(request.Symbol, time.Time{}, time.Time{}, limit)}
+	case ControlLogLevels:
+		return ControlResponse{OK: true, LogLevels: cs.orchestrator.logLevels.Get()}
+	case ControlSetLogLevel:
+		if err := cs.orchestrator.logLevels.Set(request.Component, request.Level); err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		component := request.Component
+		if component == "" {
+			component = logging.DefaultComponent
+		}
+		log.Printf("🎛️ Log level of %s set to %s", component, request.Level)
+		return ControlResponse{OK: true, LogLevels: cs.orchestrator.logLevels.Get()}
+	default:
+		return ControlResponse{Error: fmt.Sprintf("unknown command: %s", request.Command)}
+	}
+
+	state := cs.orchestrator.GetState()
+	performance := cs.orchestrator.GetPerformance()
+	return ControlResponse{OK: true, State: &state, Performance: &performance, Grid: cs.orchestrator.GetGridOverride()}
+}
+
+// execute runs one validated trading command on the control worker; order commands return their order
+func (cs *ControlServer) execute(command string, payload ControlPayload) (*types.Order, error) {
+	log.Printf("🎛️ Control command received: %s", command)
+	return cs.orchestrator.ExecuteOrderCommand(ControlCommand{Type: command, Payload: payload}, cs.config.CommandTimeout)
+}
+
+// handleBatch validates every command of a batch and, only if all are valid, runs them in order. A failed
+// command skips the rest unless the batch continues on error; commands that already ran are not undone.
+func (cs *ControlServer) handleBatch(request ControlRequest) ControlResponse {
+	if len(request.Commands) == 0 {
+		return ControlResponse{Error: "batch requires at least one command"}
+	}
+	if len(request.Commands) > maxBatchCommands {
+		return ControlResponse{Error: fmt.Sprintf("batch holds %d commands, at most %d are allowed", len(request.Commands), maxBatchCommands)}
+	}
+
+	payloads, results, valid := validateBatch(request.Commands)
+	if !valid {
+		for i := range results {
+			results[i].Skipped = results[i].Error == ""
+		}
+		return ControlResponse{Error: "batch rejected: invalid commands", Results: results}
+	}
+
+	failed := false
+	for i, command := range request.Commands {
+		if failed && !request.ContinueOnError {
+			results[i].Skipped = true
+			continue
+		}
+		order, err := cs.execute(command.Command, payloads[i])
+		results[i].Order = order
+		if err != nil {
+			results[i].Error = err.Error()
+			failed = true
+			continue
+		}
+		results[i].OK = true
+	}
+
+	response := ControlResponse{OK: !failed, Results: results}
+	if failed {
+		response.Error = "batch failed: see results"
+	}
+	return response
+}
+
+// SendControlRequest sends one request to a control server and returns its response
+func SendControlRequest(network, address string, request ControlRequest, timeout time.Duration) (*ControlResponse, error) {
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if err := json.NewEncoder(conn).Encode(request); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var response ControlResponse
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &response, nil
+}
+
+// GetControlStats returns control server statistics
+func (cs *ControlServer) GetControlStats() map[string]interface{} {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	return map[string]interface{}{
+		"address":     cs.config.Address,
+		"socket_path": cs.config.SocketPath,
+		"connections": len(cs.conns),
+		"requests":    cs.requests,
+		"failures":    cs.failures,
+	}
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"sort"
+)
+
+// syncRiskPortfolio hands the risk manager the account equity and the open positions with their notional
+// values in the accounting currency, so portfolio risk adds up symbols of different quote assets
+func (o *Orchestrator) syncRiskPortfolio(equity float64) {
+	o.positionMu.Lock()
+	states := o.positionManager.GetAllPositions()
+	keys := make([]string, 0, len(states))
+	open := make(map[string]strategy.PositionState, len(states))
+	for key, state := range states {
+		if !state.Position.IsFlat() && state.Position.Status != "closed" {
+			keys = append(keys, key)
+			copied := *state
+			position := *state.Position
+			copied.Position = &position
+			open[key] = copied
+		}
+	}
+	o.positionMu.Unlock()
+	sort.Strings(keys)
+
+	positions := make([]strategy.RiskPosition, 0, len(keys))
+	for _, key := range keys {
+		state := open[key]
+		position := state.Position
+		price := o.candleAggregator.GetLatestPrice(position.Symbol)
+		if price <= 0 {
+			price = position.EntryPrice
+		}
+		size := position.Size
+		if position.Type == types.PositionTypeShort {
+			size = -size
+		}
+		positions = append(positions, strategy.RiskPosition{
+			Symbol:        position.Symbol,
+			PositionSize:  size,
+			NotionalValue: o.currency.ToAccounting(position.Symbol, position.Contract().Notional(size, price)),
+			MarginUsed:    o.currency.ToAccounting(position.Symbol, position.Margin),
+			OpenTime:      state.EntryTime,
+			StopLoss:      state.StopLoss,
+			TakeProfit:    state.TakeProfit,
+		})
+	}
+	o.riskManager.SyncPortfolio(equity, positions)
+}
@@ -0,0 +1,314 @@
+package bot
+
+import (
+	"aibot/internal/journal"
+	"aibot/internal/ledger"
+	"aibot/internal/strategy"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DailyReportConfig holds configuration for the scheduled end-of-day report
+type DailyReportConfig struct {
+	Time       string         `json:"time"`        // Time of day the report is sent, "HH:MM" (00:00); it covers the day before
+	AttachCSV  bool           `json:"attach_csv"`  // Attach the window's fills as CSV
+	AlertLevel string         `json:"alert_level"` // Lowest risk alert level listed: "info", "warning" or "critical" (warning)
+	Location   *time.Location `json:"-"`           // Accounting timezone Time is read in (UTC)
+}
+
+// DailyReport summarizes trading over a report window
+type DailyReport struct {
+	Symbol          string                 `json:"symbol"`
+	From            time.Time              `json:"from"`
+	To              time.Time              `json:"to"`
+	Fills           []journal.JournalEntry `json:"fills"`
+	WinningFills    int                    `json:"winning_fills"`
+	LosingFills     int                    `json:"losing_fills"`
+	Volume          float64                `json:"volume"`
+	RealizedPnL     float64                `json:"realized_pnl"`
+	Fees            float64                `json:"fees"`
+	NetPnL          float64                `json:"net_pnl"`
+	DayDrawdown     float64                `json:"day_drawdown"`     // Largest peak-to-trough drop of the window's cumulative net PnL
+	CurrentDrawdown float64                `json:"current_drawdown"` // From the equity high-water mark, at report time
+	MaxDrawdown     float64                `json:"max_drawdown"`     // Session maximum
+	Balance         float64                `json:"balance"`
+	Ledger          []ledger.DailyTotals   `json:"ledger"` // Ledger totals of the accounting days the window touches
+	Alerts          []RiskAlert            `json:"alerts"`
+	Location        *time.Location         `json:"-"` // Accounting timezone times are shown in
+}
+
+// alertLevelRank orders risk alert levels for the report's alert filter
+var alertLevelRank = map[string]int{"info": 0, "warning": 1, "critical": 2}
+
+// DailyReporter sends the end-of-day report through the email notifier
+type DailyReporter struct {
+	config   DailyReportConfig
+	notifier *EmailNotifier
+	hour     int
+	minute   int
+
+	// Statistics
+	sent       int64
+	failed     int64
+	lastReport time.Time
+	lastError  string
+
+	mu sync.Mutex
+}
+
+// NewDailyReporter creates an end-of-day reporter
+func NewDailyReporter(config DailyReportConfig, notifier *EmailNotifier) (*DailyReporter, error) {
+	if config.Time == "" {
+		config.Time = "00:00" // default
+	}
+	if config.AlertLevel == "" {
+		config.AlertLevel = "warning" // default
+	}
+	if config.Location == nil {
+		config.Location = time.UTC // default
+	}
+	if _, ok := alertLevelRank[config.AlertLevel]; !ok {
+		return nil, fmt.Errorf("invalid daily report alert level: %s", config.AlertLevel)
+	}
+	at, err := time.Parse("15:04", config.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid daily report time %q: %w", config.Time, err)
+	}
+
+	return &DailyReporter{
+		config:   config,
+		notifier: notifier,
+		hour:     at.Hour(),
+		minute:   at.Minute(),
+	}, nil
+}
+
+// NextRun returns the first scheduled report time after now. The time of day is taken on the calendar of
+// the accounting timezone, so it stays put across daylight saving changes.
+func (r *DailyReporter) NextRun(now time.Time) time.Time {
+	now = now.In(r.config.Location)
+	next := strategy.LocalTime(now.Year(), now.Month(), now.Day(), r.hour, r.minute, r.config.Location)
+	if !next.After(now) {
+		next = strategy.LocalTime(now.Year(), now.Month(), now.Day()+1, r.hour, r.minute, r.config.Location)
+	}
+	return next
+}
+
+// PreviousRun returns the report time one calendar day before a run, the start of the run's report
+// window; the window is 23 or 25 hours long across daylight saving changes
+func (r *DailyReporter) PreviousRun(run time.Time) time.Time {
+	run = run.In(r.config.Location)
+	return strategy.LocalTime(run.Year(), run.Month(), run.Day()-1, r.hour, r.minute, r.config.Location)
+}
+
+// includesAlert returns true if an alert's level is at or above the configured level
+func (r *DailyReporter) includesAlert(alert RiskAlert) bool {
+	rank, ok := alertLevelRank[alert.Level]
+	if !ok {
+		rank = alertLevelRank["warning"] // Health levels such as "degraded" count as warnings
+	}
+	return rank >= alertLevelRank[r.config.AlertLevel]
+}
+
+// Send renders the report and emails it
+func (r *DailyReporter) Send(report *DailyReport) error {
+	html, err := report.FormatHTML()
+	if err != nil {
+		return err
+	}
+
+	message := EmailMessage{
+		Subject: fmt.Sprintf("aibot %s daily report %s: net PnL %.2f", report.Symbol, report.From.In(report.Location).Format("2006-01-02"), report.NetPnL),
+		HTML:    html,
+	}
+	if r.config.AttachCSV {
+		data, err := report.FillsCSV()
+		if err != nil {
+			return err
+		}
+		message.Attachments = append(message.Attachments, EmailAttachment{
+			Name:        fmt.Sprintf("fills-%s-%s.csv", report.Symbol, report.From.In(report.Location).Format("20060102")),
+			ContentType: "text/csv",
+			Data:        data,
+		})
+	}
+
+	err = r.notifier.Send(message)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.failed++
+		r.lastError = err.Error()
+		return err
+	}
+	r.sent++
+	r.lastReport = report.To
+	r.lastError = ""
+	return nil
+}
+
+// dailyReportTemplate renders the report email
+var dailyReportTemplate = template.Must(template.New("daily_report").Funcs(template.FuncMap{
+	"money": func(value float64) string { return strconv.FormatFloat(value, 'f', 2, 64) },
+	"pct":   func(value float64) string { return strconv.FormatFloat(value*100, 'f', 2, 64) + "%" },
+	"clock": func(t time.Time, location *time.Location) string { return t.In(location).Format("15:04:05") },
+	"stamp": func(t time.Time, location *time.Location) string {
+		return t.In(location).Format("2006-01-02 15:04 MST")
+	},
+}).Parse(`<html><body style="font-family: sans-serif">
+<h2>{{.Symbol}} daily report</h2>
+<p>{{stamp .From .Location}} to {{stamp .To .Location}} ({{.Location}})</p>
+<table cellpadding="4">
+<tr><td>Fills</td><td>{{len .Fills}} (won {{.WinningFills}}, lost {{.LosingFills}})</td></tr>
+<tr><td>Volume</td><td>{{money .Volume}}</td></tr>
+<tr><td>Realized PnL</td><td>{{money .RealizedPnL}}</td></tr>
+<tr><td>Fees</td><td>{{money .Fees}}</td></tr>
+<tr><td><b>Net PnL</b></td><td><b>{{money .NetPnL}}</b></td></tr>
+<tr><td>Intraday drawdown</td><td>{{money .DayDrawdown}}</td></tr>
+<tr><td>Drawdown (current / session max)</td><td>{{pct .CurrentDrawdown}} / {{pct .MaxDrawdown}}</td></tr>
+<tr><td>Balance</td><td>{{money .Balance}}</td></tr>
+</table>
+{{if .Ledger}}<h3>Ledger</h3>
+<table border="1" cellpadding="4" style="border-collapse: collapse">
+<tr><th>Day</th><th>Symbol</th><th>PnL</th><th>Commission</th><th>Funding</th><th>Slippage</th><th>Net</th></tr>
+{{range .Ledger}}<tr><td>{{.Day}}</td><td>{{.Symbol}}</td><td>{{money .RealizedPnL}}</td><td>{{money .Commission}}</td><td>{{money .Funding}}</td><td>{{money .Slippage}}</td><td>{{money .Net}}</td></tr>
+{{end}}</table>{{end}}
+<h3>Alerts ({{len .Alerts}})</h3>
+{{if .Alerts}}<ul>
+{{range .Alerts}}<li>{{clock .Timestamp $.Location}} [{{.Level}}] {{.Type}}: {{.Message}}</li>
+{{end}}</ul>{{else}}<p>None</p>{{end}}
+<h3>Fills ({{len .Fills}})</h3>
+{{if .Fills}}<table border="1" cellpadding="4" style="border-collapse: collapse">
+<tr><th>Time</th><th>Side</th><th>Quantity</th><th>Price</th><th>Fee</th><th>PnL</th><th>Mode</th></tr>
+{{range .Fills}}<tr><td>{{clock .Timestamp $.Location}}</td><td>{{.Side}}</td><td>{{.Quantity}}</td><td>{{.Price}}</td><td>{{money .Fee}}</td><td>{{money .RealizedPnL}}</td><td>{{.Mode}}</td></tr>
+{{end}}</table>{{else}}<p>None</p>{{end}}
+</body></html>
+`))
+
+// FormatHTML renders the report as an HTML email body
+func (r *DailyReport) FormatHTML() (string, error) {
+	var b bytes.Buffer
+	if err := dailyReportTemplate.Execute(&b, r); err != nil {
+		return "", fmt.Errorf("failed to render daily report: %w", err)
+	}
+	return b.String(), nil
+}
+
+// FillsCSV returns the report's fills as CSV
+func (r *DailyReport) FillsCSV() ([]byte, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+	if err := writer.Write([]string{"timestamp", "order_id", "symbol", "event", "side", "position_type", "quantity", "price", "fee", "realized_pnl", "mode"}); err != nil {
+		return nil, fmt.Errorf("failed to write fills: %w", err)
+	}
+
+	format := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	for _, fill := range r.Fills {
+		record := []string{
+			fill.Timestamp.UTC().Format(time.RFC3339Nano),
+			fill.OrderID,
+			fill.Symbol,
+			fill.EventType,
+			string(fill.Side),
+			string(fill.PositionType),
+			format(fill.Quantity),
+			format(fill.Price),
+			format(fill.Fee),
+			format(fill.RealizedPnL),
+			fill.Mode,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write fills: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write fills: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// buildDailyReport assembles the report for [from, to) from the journal, ledger and session alerts
+func (o *Orchestrator) buildDailyReport(from, to time.Time) *DailyReport {
+	report := &DailyReport{
+		Symbol:   o.activeSymbol,
+		From:     from,
+		To:       to,
+		Fills:    make([]journal.JournalEntry, 0),
+		Alerts:   make([]RiskAlert, 0),
+		Balance:  o.ledger.GetBalance(),
+		Location: o.dailyReporter.config.Location,
+	}
+
+	cumulative, peak := 0.0, 0.0
+	for _, entry := range o.tradeJournal.GetEntries(0) {
+		if entry.Timestamp.Before(from) || !entry.Timestamp.Before(to) {
+			continue
+		}
+		if entry.EventType != "fill" && entry.EventType != "partial_fill" {
+			continue
+		}
+		report.Fills = append(report.Fills, entry)
+		report.Volume += entry.Quantity * entry.Price
+		report.RealizedPnL += entry.RealizedPnL
+		report.Fees += entry.Fee
+		if entry.RealizedPnL > 0 {
+			report.WinningFills++
+		} else if entry.RealizedPnL < 0 {
+			report.LosingFills++
+		}
+
+		cumulative += entry.RealizedPnL - entry.Fee
+		if cumulative > peak {
+			peak = cumulative
+		}
+		if drawdown := peak - cumulative; drawdown > report.DayDrawdown {
+			report.DayDrawdown = drawdown
+		}
+	}
+	report.NetPnL = report.RealizedPnL - report.Fees
+
+	location := report.Location
+	firstDay, lastDay := from.In(location).Format("2006-01-02"), to.Add(-time.Nanosecond).In(location).Format("2006-01-02")
+	for _, totals := range o.ledger.GetDailyTotals() {
+		if totals.Day == firstDay || totals.Day == lastDay {
+			report.Ledger = append(report.Ledger, totals)
+		}
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	report.CurrentDrawdown = o.performance.CurrentDrawdown
+	report.MaxDrawdown = o.performance.MaxDrawdown
+	for _, alert := range o.riskAlerts {
+		if alert.Timestamp.Before(from) || !alert.Timestamp.Before(to) || !o.dailyReporter.includesAlert(alert) {
+			continue
+		}
+		report.Alerts = append(report.Alerts, alert)
+	}
+	return report
+}
+
+// GetDailyReportStats returns end-of-day report statistics
+func (r *DailyReporter) GetDailyReportStats(now time.Time) map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return map[string]interface{}{
+		"time":        r.config.Time,
+		"timezone":    r.config.Location.String(),
+		"next_run":    r.NextRun(now),
+		"sent":        r.sent,
+		"failed":      r.failed,
+		"last_report": r.lastReport,
+		"last_error":  r.lastError,
+	}
+}
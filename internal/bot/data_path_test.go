@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"aibot/internal/data"
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// benchmarkSymbols is the number of symbols the data path benchmarks rotate ticks across
+const benchmarkSymbols = 10
+
+// BenchmarkDataPathPooled measures queueing a tick, popping it and adding it to the candle aggregator with
+// pooled market data
+func BenchmarkDataPathPooled(b *testing.B) {
+	benchmarkDataPath(b, func(ticker types.Ticker) *types.Ticker {
+		return data.AcquireTicker(ticker)
+	}, releaseDataUpdate)
+}
+
+// BenchmarkDataPathHeap measures the same path allocating every update on the heap
+func BenchmarkDataPathHeap(b *testing.B) {
+	benchmarkDataPath(b, func(ticker types.Ticker) *types.Ticker {
+		return &ticker
+	}, func(DataUpdate) {})
+}
+
+// benchmarkDataPath runs ticks through a data queue into an aggregator, wrapping each with wrap and
+// handing it to release once processed
+func benchmarkDataPath(b *testing.B, wrap func(types.Ticker) *types.Ticker, release func(DataUpdate)) {
+	ticks := syntheticTicks(benchmarkSymbols, 1000)
+	ctx := context.Background()
+	queue := NewDataQueue(DataQueueConfig{Policy: BackpressureDropOldest})
+	aggregator := data.NewCandleAggregator(data.AggregatorConfig{})
+
+	// Warm up candle history so the measured ticks run at steady state
+	for _, ticker := range ticks {
+		aggregator.AddTick(ticker)
+	}
+	last := ticks[len(ticks)-1].Timestamp
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ticker := ticks[i%len(ticks)]
+		ticker.Timestamp = last.Add(time.Duration(i/benchmarkSymbols+1) * data.DefaultBaseInterval)
+		queue.Push(ctx, DataUpdate{Symbol: ticker.Symbol, Ticker: wrap(ticker), Time: ticker.Timestamp})
+
+		update, _ := queue.Pop(ctx)
+		aggregator.AddTick(*update.Ticker)
+		release(update)
+	}
+}
+
+// syntheticTicks generates count ticks per symbol at the base interval, rotating across the symbols
+func syntheticTicks(symbols, count int) []types.Ticker {
+	names := make([]string, symbols)
+	for i := range names {
+		names[i] = fmt.Sprintf("BENCH%dUSDT", i)
+	}
+
+	ticks := make([]types.Ticker, 0, symbols*count)
+	start := time.Unix(0, 0).UTC()
+	for i := 0; i < count; i++ {
+		for j, symbol := range names {
+			ticks = append(ticks, types.Ticker{
+				Symbol:    symbol,
+				Timestamp: start.Add(time.Duration(i) * data.DefaultBaseInterval),
+				Price:     100 + float64(j) + float64(i%50)*0.01,
+				Volume:    1,
+			})
+		}
+	}
+	return ticks
+}
@@ -0,0 +1,211 @@
+package bot
+
+import (
+	"aibot/internal/data"
+	"context"
+	"sync"
+)
+
+// Backpressure policies applied when market data arrives faster than it is processed
+const (
+	BackpressureCoalesce   = "coalesce"    // Merge consecutive ticks of a symbol into the latest; drop the oldest update when full
+	BackpressureDropOldest = "drop_oldest" // Queue every update; drop the oldest update when full
+	BackpressureBlock      = "block"       // Stall the stream receiver until there is room
+)
+
+// DataQueueConfig holds configuration for the queue between the stream receiver and data processing
+type DataQueueConfig struct {
+	Policy   string `json:"policy"`   // "coalesce", "drop_oldest" or "block" (coalesce)
+	Capacity int    `json:"capacity"` // Updates held before the policy applies (100)
+}
+
+// queuedUpdate is a data update with its position in the queue
+type queuedUpdate struct {
+	seq    int64
+	update DataUpdate
+}
+
+// DataQueue is a bounded FIFO of market data updates whose overflow behavior is set by a backpressure policy
+type DataQueue struct {
+	config  DataQueueConfig
+	items   []queuedUpdate
+	buffer  []queuedUpdate   // Backing array of items, reused as updates are popped off the front
+	headSeq int64            // Sequence number of items[0]
+	nextSeq int64            // Sequence number of the next pushed update
+	tails   map[string]int64 // Symbol -> sequence number of its newest queued update, for coalescing
+	ready   chan struct{}    // Signalled when an update is pushed
+	space   chan struct{}    // Signalled when an update is popped, for the block policy
+
+	// Statistics
+	received  int64
+	processed int64
+	coalesced int64
+	dropped   int64
+	maxDepth  int
+
+	mu sync.Mutex
+}
+
+// NewDataQueue creates a data queue
+func NewDataQueue(config DataQueueConfig) *DataQueue {
+	if config.Policy == "" {
+		config.Policy = BackpressureCoalesce // default
+	}
+	if config.Capacity == 0 {
+		config.Capacity = 100 // default
+	}
+
+	buffer := make([]queuedUpdate, 0, config.Capacity)
+	return &DataQueue{
+		config: config,
+		items:  buffer,
+		buffer: buffer,
+		tails:  make(map[string]int64),
+		ready:  make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+	}
+}
+
+// Push adds an update according to the backpressure policy; it returns the number of updates
+// dropped to make room, and only blocks under the block policy. The queue owns the update's market
+// data from then on: merged and dropped updates are released to the data pools.
+func (q *DataQueue) Push(ctx context.Context, update DataUpdate) int {
+	q.mu.Lock()
+	q.received++
+
+	if q.config.Policy == BackpressureCoalesce && q.coalesce(update) {
+		q.mu.Unlock()
+		return 0
+	}
+	for q.config.Policy == BackpressureBlock && len(q.items) >= q.config.Capacity {
+		q.mu.Unlock()
+		select {
+		case <-q.space:
+		case <-ctx.Done():
+			return 0
+		}
+		q.mu.Lock()
+	}
+
+	dropped := 0
+	if len(q.items) >= q.config.Capacity {
+		releaseDataUpdate(q.popLocked())
+		q.dropped++
+		dropped++
+	}
+	if len(q.items) == cap(q.items) {
+		// Popping walked items to the end of the buffer; move them back to its start
+		n := copy(q.buffer[:len(q.items)], q.items)
+		q.items = q.buffer[:n]
+	}
+	q.tails[update.Symbol] = q.nextSeq
+	q.items = append(q.items, queuedUpdate{seq: q.nextSeq, update: update})
+	q.nextSeq++
+	if len(q.items) > q.maxDepth {
+		q.maxDepth = len(q.items)
+	}
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// coalesce merges a tick into the newest queued update of its symbol if that is a tick too, adding up
+// traded volume so candles stay complete; intermediate prices are skipped. Caller must hold mu.
+func (q *DataQueue) coalesce(update DataUpdate) bool {
+	if update.Ticker == nil {
+		return false
+	}
+	seq, exists := q.tails[update.Symbol]
+	if !exists {
+		return false
+	}
+	pending := &q.items[seq-q.headSeq].update
+	if pending.Ticker == nil {
+		return false // Keep ticks behind a queued candle in order
+	}
+
+	volume := pending.Ticker.Volume
+	*pending.Ticker = *update.Ticker
+	pending.Ticker.Volume += volume
+	pending.Time = update.Time
+	data.ReleaseTicker(update.Ticker)
+	q.coalesced++
+	return true
+}
+
+// Pop waits for the oldest update; it returns false once the context is cancelled
+func (q *DataQueue) Pop(ctx context.Context) (DataUpdate, bool) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			update := q.popLocked()
+			q.processed++
+			q.mu.Unlock()
+
+			select {
+			case q.space <- struct{}{}:
+			default:
+			}
+			return update, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return DataUpdate{}, false
+		}
+	}
+}
+
+// popLocked removes the oldest update; caller must hold mu
+func (q *DataQueue) popLocked() DataUpdate {
+	item := q.items[0]
+	q.items[0] = queuedUpdate{}
+	q.items = q.items[1:]
+	q.headSeq++
+	if seq, exists := q.tails[item.update.Symbol]; exists && seq == item.seq {
+		delete(q.tails, item.update.Symbol)
+	}
+	return item.update
+}
+
+// releaseDataUpdate returns an update's market data to the data pools; the update must not be used afterwards
+func releaseDataUpdate(update DataUpdate) {
+	data.ReleaseTicker(update.Ticker)
+	data.ReleaseCandle(update.OHLCV)
+	data.ReleaseAggTrade(update.AggTrade)
+}
+
+// Len returns the number of queued updates
+func (q *DataQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Cap returns the number of updates held before the backpressure policy applies
+func (q *DataQueue) Cap() int {
+	return q.config.Capacity
+}
+
+// GetQueueStats returns data queue statistics
+func (q *DataQueue) GetQueueStats() map[string]interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return map[string]interface{}{
+		"policy":    q.config.Policy,
+		"capacity":  q.config.Capacity,
+		"depth":     len(q.items),
+		"max_depth": q.maxDepth,
+		"received":  q.received,
+		"processed": q.processed,
+		"coalesced": q.coalesced,
+		"dropped":   q.dropped,
+	}
+}
@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Topic names a stream of orchestrator events; every event on a topic carries the same payload type
+type Topic string
+
+const (
+	TopicMarketData  Topic = "market_data"  // DataUpdate, after the orchestrator processed it
+	TopicSignal      Topic = "signal"       // TradingSignal
+	TopicRiskAlert   Topic = "risk_alert"   // RiskAlert
+	TopicModeChange  Topic = "mode_change"  // ModeTransition
+	TopicOrderUpdate Topic = "order_update" // types.OrderUpdate, after it was journaled and booked
+)
+
+// Event is a payload published on a topic
+type Event struct {
+	Topic   Topic
+	Payload interface{}
+	Time    time.Time
+}
+
+// SubscribeOptions controls how events are delivered to a subscription
+type SubscribeOptions struct {
+	Buffer   int  // Events buffered for the subscriber (64)
+	Blocking bool // Publishers wait for buffer space instead of dropping events, so a slow subscriber stalls them
+}
+
+// Subscription receives the events of its topics
+type Subscription struct {
+	name     string
+	topics   []Topic
+	blocking bool
+	events   chan Event
+	done     chan struct{} // Closed on Unsubscribe so blocked publishers give up
+	bus      *EventBus
+	once     sync.Once
+
+	delivered atomic.Int64
+	dropped   atomic.Int64
+}
+
+// Events returns the channel the subscription's events are delivered on
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Len returns the number of undelivered events
+func (s *Subscription) Len() int {
+	return len(s.events)
+}
+
+// Cap returns the subscription's buffer size
+func (s *Subscription) Cap() int {
+	return cap(s.events)
+}
+
+// Unsubscribe stops delivery; events already buffered stay readable
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.bus.remove(s)
+		close(s.done)
+	})
+}
+
+// EventBus is an in-memory publish/subscribe bus, so components can follow orchestrator events without
+// the workers that produce them knowing about every consumer
+type EventBus struct {
+	subscribers map[Topic][]*Subscription
+	published   map[Topic]int64
+	mu          sync.RWMutex
+}
+
+// NewEventBus creates an event bus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[Topic][]*Subscription),
+		published:   make(map[Topic]int64),
+	}
+}
+
+// Subscribe creates a subscription to the given topics
+func (b *EventBus) Subscribe(name string, options SubscribeOptions, topics ...Topic) *Subscription {
+	if options.Buffer == 0 {
+		options.Buffer = 64 // default
+	}
+
+	sub := &Subscription{
+		name:     name,
+		topics:   topics,
+		blocking: options.Blocking,
+		events:   make(chan Event, options.Buffer),
+		done:     make(chan struct{}),
+		bus:      b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, topic := range topics {
+		b.subscribers[topic] = append(b.subscribers[topic], sub)
+	}
+	return sub
+}
+
+// remove detaches a subscription from its topics
+func (b *EventBus) remove(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range sub.topics {
+		subs := b.subscribers[topic]
+		for i, candidate := range subs {
+			if candidate == sub {
+				b.subscribers[topic] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish delivers a payload to every subscriber of the topic and returns how many received it. Blocking
+// subscribers are waited for until ctx is done; others drop the event when their buffer is full.
+func (b *EventBus) Publish(ctx context.Context, topic Topic, payload interface{}) int {
+	b.mu.Lock()
+	b.published[topic]++
+	subs := b.subscribers[topic]
+	b.mu.Unlock()
+
+	delivered := 0
+	event := Event{Topic: topic, Payload: payload, Time: time.Now()}
+	for _, sub := range subs {
+		if sub.blocking {
+			select {
+			case sub.events <- event:
+				sub.delivered.Add(1)
+				delivered++
+			case <-sub.done:
+			case <-ctx.Done():
+				sub.dropped.Add(1)
+			}
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+			sub.delivered.Add(1)
+			delivered++
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+	return delivered
+}
+
+// GetEventBusStats returns published events per topic and delivery statistics per subscriber
+func (b *EventBus) GetEventBusStats() map[string]interface{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	published := make(map[string]int64, len(b.published))
+	for topic, count := range b.published {
+		published[string(topic)] = count
+	}
+
+	subscribers := make(map[string]interface{})
+	for _, subs := range b.subscribers {
+		for _, sub := range subs {
+			if _, seen := subscribers[sub.name]; seen {
+				continue
+			}
+			topics := make([]string, len(sub.topics))
+			for i, topic := range sub.topics {
+				topics[i] = string(topic)
+			}
+			subscribers[sub.name] = map[string]interface{}{
+				"topics":    topics,
+				"blocking":  sub.blocking,
+				"delivered": sub.delivered.Load(),
+				"dropped":   sub.dropped.Load(),
+				"backlog":   sub.Len(),
+				"capacity":  sub.Cap(),
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"published":   published,
+		"subscribers": subscribers,
+	}
+}
@@ -0,0 +1,232 @@
+package bot
+
+import (
+	"aibot/internal/types"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// EventSchema names the machine-readable event stream; EventSchemaVersion changes whenever a field of
+// EventRecord or of an event payload is renamed, removed or changes meaning. New fields do not bump it.
+const (
+	EventSchema        = "aibot.event"
+	EventSchemaVersion = 1
+)
+
+// Event record types
+const (
+	EventTypeTrade       = "trade"        // types.OrderUpdate that executed quantity
+	EventTypeOrderUpdate = "order_update" // types.OrderUpdate without a fill (accepted, cancelled, rejected)
+	EventTypeSignal      = "signal"       // TradingSignal
+	EventTypeRiskAlert   = "risk_alert"   // RiskAlert
+	EventTypeModeChange  = "mode_change"  // ModeTransition
+)
+
+// EventLogConfig holds configuration for the machine-readable event stream
+type EventLogConfig struct {
+	Output     string   `json:"output"`      // JSON lines file, or "stdout" ("" disables the stream)
+	Topics     []string `json:"topics"`      // Topics to record (signal, risk_alert, mode_change, order_update)
+	BufferSize int      `json:"buffer_size"` // Events buffered for the writer before they are dropped (1024)
+}
+
+// EventRecord is one line of the event stream. The envelope is the same for every type; Data holds the
+// payload, whose shape is fixed per type and version.
+type EventRecord struct {
+	Schema   string      `json:"schema"`
+	Version  int         `json:"version"`
+	Sequence int64       `json:"seq"` // Counts every event taken for the stream, so gaps reveal dropped or unencodable ones
+	Time     time.Time   `json:"time"`
+	Type     string      `json:"type"`
+	Symbol   string      `json:"symbol,omitempty"`
+	Mode     string      `json:"mode,omitempty"` // Trading mode when the event was recorded
+	Data     interface{} `json:"data"`
+}
+
+// EventLog writes orchestrator events as JSON lines for downstream pipelines, so bot activity can be
+// ingested without parsing human-readable logs. Events are taken from the event bus without blocking
+// the publishers; if the writer falls behind, events are dropped and counted.
+type EventLog struct {
+	config EventLogConfig
+	sub    *Subscription
+	mode   func() TradingMode
+	writer *bufio.Writer
+	closer io.Closer // nil for stdout
+	done   chan struct{}
+
+	// Statistics
+	sequence int64 // Records written
+	failed   int64
+
+	mu sync.Mutex
+}
+
+// NewEventLog opens the output and starts recording the configured topics of bus; mode reports the
+// trading mode stamped on each record
+func NewEventLog(config EventLogConfig, bus *EventBus, mode func() TradingMode) (*EventLog, error) {
+	if len(config.Topics) == 0 {
+		config.Topics = []string{string(TopicSignal), string(TopicRiskAlert), string(TopicModeChange), string(TopicOrderUpdate)} // default
+	}
+	if config.BufferSize == 0 {
+		config.BufferSize = 1024 // default
+	}
+
+	topics := make([]Topic, 0, len(config.Topics))
+	for _, topic := range config.Topics {
+		switch Topic(topic) {
+		case TopicSignal, TopicRiskAlert, TopicModeChange, TopicOrderUpdate:
+			topics = append(topics, Topic(topic))
+		default:
+			return nil, fmt.Errorf("event log cannot record topic %q", topic)
+		}
+	}
+
+	el := &EventLog{
+		config: config,
+		mode:   mode,
+		done:   make(chan struct{}),
+	}
+	if config.Output == "stdout" {
+		el.writer = bufio.NewWriter(os.Stdout)
+	} else {
+		if dir := filepath.Dir(config.Output); dir != "" {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create event log directory: %w", err)
+			}
+		}
+		file, err := os.OpenFile(config.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open event log: %w", err)
+		}
+		el.writer = bufio.NewWriter(file)
+		el.closer = file
+	}
+
+	el.sub = bus.Subscribe("event_log", SubscribeOptions{Buffer: config.BufferSize}, topics...)
+	go el.run()
+	return el, nil
+}
+
+// run writes events until the subscription is closed, flushing whenever it has caught up
+func (el *EventLog) run() {
+	defer close(el.done)
+
+	for {
+		select {
+		case event := <-el.sub.Events():
+			el.write(event)
+			if el.sub.Len() == 0 {
+				el.flush()
+			}
+		case <-el.sub.done:
+			for el.sub.Len() > 0 {
+				el.write(<-el.sub.Events())
+			}
+			el.flush()
+			return
+		}
+	}
+}
+
+// write encodes one event as a JSON line
+func (el *EventLog) write(event Event) {
+	record, ok := el.record(event)
+	if !ok {
+		return
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	record.Sequence = el.sequence + el.failed + el.sub.dropped.Load() + 1
+	line, err := json.Marshal(record)
+	if err != nil {
+		el.failed++
+		log.Printf("⚠️ Failed to encode %s event: %v", record.Type, err)
+		return
+	}
+	el.sequence++
+	el.writer.Write(line)
+	el.writer.WriteByte('\n')
+}
+
+// record wraps an event payload in the record envelope
+func (el *EventLog) record(event Event) (EventRecord, bool) {
+	record := EventRecord{
+		Schema:  EventSchema,
+		Version: EventSchemaVersion,
+		Time:    event.Time.UTC(),
+		Data:    event.Payload,
+	}
+	if el.mode != nil {
+		record.Mode = string(el.mode())
+	}
+
+	switch payload := event.Payload.(type) {
+	case types.OrderUpdate:
+		record.Type = EventTypeOrderUpdate
+		if payload.IsFill() {
+			record.Type = EventTypeTrade
+		}
+		record.Symbol = payload.Symbol
+	case TradingSignal:
+		record.Type = EventTypeSignal
+		record.Symbol = payload.Symbol
+	case RiskAlert:
+		record.Type = EventTypeRiskAlert
+		record.Symbol = payload.Symbol
+	case ModeTransition:
+		record.Type = EventTypeModeChange
+	default:
+		return EventRecord{}, false
+	}
+	return record, true
+}
+
+// flush writes buffered records to the output
+func (el *EventLog) flush() {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if err := el.writer.Flush(); err != nil {
+		el.failed++
+		log.Printf("⚠️ Failed to write event log: %v", err)
+	}
+}
+
+// Close stops recording and waits up to timeout for buffered events to be written
+func (el *EventLog) Close(timeout time.Duration) {
+	el.sub.Unsubscribe()
+
+	select {
+	case <-el.done:
+	case <-time.After(timeout):
+		log.Printf("⚠️ Event log timeout reached, %d events unwritten", el.sub.Len())
+	}
+	if el.closer != nil {
+		el.mu.Lock()
+		el.closer.Close()
+		el.mu.Unlock()
+	}
+}
+
+// GetEventLogStats returns event stream statistics
+func (el *EventLog) GetEventLogStats() map[string]interface{} {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	return map[string]interface{}{
+		"output":         el.config.Output,
+		"schema_version": EventSchemaVersion,
+		"written":        el.sequence,
+		"failed":         el.failed,
+		"dropped":        el.sub.dropped.Load(),
+		"backlog":        el.sub.Len(),
+	}
+}
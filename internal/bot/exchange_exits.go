@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"aibot/pkg/trading"
+	"context"
+)
+
+// exchangeExit is a stop loss or take profit of a tracked position resting on the exchange
+type exchangeExit struct {
+	orderID string
+	trigger strategy.TriggerType
+}
+
+// placeExchangeExits rests the stop loss and take profit of a position on the exchange where the executor
+// executes them natively, replacing exits placed for an earlier size or level. Triggers whose order is not
+// accepted stay with the bot.
+func (o *Orchestrator) placeExchangeExits(ctx context.Context, key string) {
+	provider, ok := o.tradingExecutor.(trading.ConditionalOrderProvider)
+	if !ok {
+		return
+	}
+	o.cancelExchangeExits(key)
+
+	o.positionMu.Lock()
+	orders := o.positionManager.ExchangeExitOrders(key, provider.SupportsOrderType)
+	o.positionMu.Unlock()
+
+	for _, triggerType := range []strategy.TriggerType{strategy.TriggerStopLoss, strategy.TriggerTakeProfit} {
+		order := orders[triggerType]
+		if order == nil {
+			continue
+		}
+		if err := o.checkRateLimit(true); err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Exchange exits of %s left to the bot: %v", key, err)
+			return
+		}
+		if err := o.checkRiskPolicy(ctx, order); err != nil {
+			continue
+		}
+
+		result, err := o.tradingExecutor.PlaceOrder(order)
+		if err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to place exchange %s for %s, left to the bot: %v", triggerType, key, err)
+			if o.noteRateLimit(err) {
+				return
+			}
+			continue
+		}
+		if result.Status == string(types.OrderStatusFilled) {
+			// The level was already crossed; the fill is booked like any other
+			continue
+		}
+
+		o.positionMu.Lock()
+		_, open := o.positionManager.GetPosition(key)
+		if open {
+			o.positionManager.DelegateTrigger(key, triggerType, result.OrderID)
+			o.exchangeExits[key] = append(o.exchangeExits[key], exchangeExit{orderID: result.OrderID, trigger: triggerType})
+		}
+		o.positionMu.Unlock()
+		if !open {
+			// The position closed while the exit was placed
+			o.cancelExitOrders([]string{result.OrderID})
+			return
+		}
+		o.logf(logging.ComponentExecutor, logging.InfoLevel, "🛡️ Exchange %s for %s: %s %.4f @ %.2f (%s)",
+			triggerType, key, order.Side, order.Quantity, exitLevel(order), order.Type)
+	}
+}
+
+// exitLevel returns the price an exit order executes at or triggers on
+func exitLevel(order *types.Order) float64 {
+	if order.IsConditional() {
+		return order.StopPrice
+	}
+	return order.Price
+}
+
+// cancelExchangeExits cancels the exchange exits of a position and hands its triggers back to the bot
+func (o *Orchestrator) cancelExchangeExits(key string) {
+	o.positionMu.Lock()
+	exits := o.exchangeExits[key]
+	delete(o.exchangeExits, key)
+	orderIDs := make([]string, 0, len(exits))
+	for _, exit := range exits {
+		o.positionManager.DelegateTrigger(key, exit.trigger, "")
+		orderIDs = append(orderIDs, exit.orderID)
+	}
+	o.positionMu.Unlock()
+
+	o.cancelExitOrders(orderIDs)
+}
+
+// cancelExitOrders cancels exit orders, deferring the cancels while REST calls are paused
+func (o *Orchestrator) cancelExitOrders(orderIDs []string) {
+	if len(orderIDs) == 0 {
+		return
+	}
+	if o.restPaused() {
+		o.deferCancels(orderIDs)
+		return
+	}
+	for _, orderID := range orderIDs {
+		if err := o.tradingExecutor.CancelOrder(orderID); err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to cancel exchange exit %s: %v", orderID, err)
+			if o.noteRateLimit(err) {
+				o.deferCancels([]string{orderID})
+			}
+		}
+	}
+}
+
+// applyExchangeExitUpdate forgets exchange exits that are done; an exit cancelled or rejected by the
+// exchange hands its trigger back to the bot. Caller must hold positionMu.
+func (o *Orchestrator) applyExchangeExitUpdate(update types.OrderUpdate) {
+	if update.Status == types.OrderStatusNew || update.Status == types.OrderStatusPending || update.Status == types.OrderStatusPartial {
+		return
+	}
+	for key, exits := range o.exchangeExits {
+		for i, exit := range exits {
+			if exit.orderID != update.OrderID {
+				continue
+			}
+			if update.Status != types.OrderStatusFilled {
+				o.positionManager.DelegateTrigger(key, exit.trigger, "")
+			}
+			o.exchangeExits[key] = append(exits[:i:i], exits[i+1:]...)
+			if len(o.exchangeExits[key]) == 0 {
+				delete(o.exchangeExits, key)
+			}
+			return
+		}
+	}
+}
+
+// syncExchangeExits cancels the exchange exits left behind by closed positions, such as the take profit of
+// a position its stop loss closed
+func (o *Orchestrator) syncExchangeExits() {
+	o.positionMu.Lock()
+	var closed []string
+	for key := range o.exchangeExits {
+		if _, open := o.positionManager.GetPosition(key); !open {
+			closed = append(closed, key)
+		}
+	}
+	o.positionMu.Unlock()
+
+	for _, key := range closed {
+		o.cancelExchangeExits(key)
+	}
+}
@@ -0,0 +1,224 @@
+package bot
+
+import (
+	"aibot/internal/types"
+	"aibot/pkg/trading"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SymbolStatusTrading is the exchange status of a symbol open for continuous trading; any other status,
+// e.g. "HALT", "BREAK" or "AUCTION_MATCH", pauses order placement
+const SymbolStatusTrading = "TRADING"
+
+// ExchangeStatusConfig holds configuration for pausing trading during exchange maintenance and symbol halts
+type ExchangeStatusConfig struct {
+	URL          string           `json:"url"`           // Binance-compatible REST API ("" disables the monitor)
+	MarketType   types.MarketType `json:"market_type"`   // Selects the spot or futures endpoints (futures)
+	PollInterval time.Duration    `json:"poll_interval"` // Status refresh interval (30s)
+	Timeout      time.Duration    `json:"timeout"`       // Request timeout (10s)
+}
+
+// ExchangeStatus is the last known system and symbol status
+type ExchangeStatus struct {
+	Symbol        string    `json:"symbol"`
+	Maintenance   bool      `json:"maintenance"`    // The exchange announced system maintenance
+	SystemMessage string    `json:"system_message"` // Message of the system status endpoint, e.g. "normal"
+	SymbolStatus  string    `json:"symbol_status"`  // Trading status of the symbol, e.g. "TRADING" or "HALT"
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+// Halted returns true while maintenance or a symbol status other than TRADING blocks order placement
+func (s ExchangeStatus) Halted() bool {
+	return s.Maintenance || (s.SymbolStatus != "" && s.SymbolStatus != SymbolStatusTrading)
+}
+
+// Reason describes why trading is halted
+func (s ExchangeStatus) Reason() string {
+	switch {
+	case s.Maintenance:
+		return fmt.Sprintf("exchange maintenance (%s)", s.SystemMessage)
+	case s.Halted():
+		return fmt.Sprintf("%s status %s", s.Symbol, s.SymbolStatus)
+	default:
+		return ""
+	}
+}
+
+// ExchangeStatusMonitor polls the exchange system status and the trading status of a symbol. A failed
+// poll keeps the last known status, so an unreachable status endpoint neither halts nor resumes trading.
+type ExchangeStatusMonitor struct {
+	config ExchangeStatusConfig
+	client *http.Client
+	status ExchangeStatus
+
+	// Statistics
+	checks      int64
+	checkErrors int64
+	halts       int64
+	lastError   string
+
+	mu sync.RWMutex
+}
+
+// NewExchangeStatusMonitor creates an exchange status monitor
+func NewExchangeStatusMonitor(config ExchangeStatusConfig) *ExchangeStatusMonitor {
+	if config.MarketType == "" {
+		config.MarketType = types.MarketTypeFutures // default
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = 30 * time.Second // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &ExchangeStatusMonitor{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Check refreshes the system status and the status of symbol and returns the result
+func (m *ExchangeStatusMonitor) Check(ctx context.Context, symbol string) (ExchangeStatus, error) {
+	status := ExchangeStatus{Symbol: symbol, CheckedAt: time.Now()}
+	err := m.fetchSystemStatus(ctx, &status)
+	if err == nil {
+		err = m.fetchSymbolStatus(ctx, &status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checks++
+	if err != nil {
+		m.checkErrors++
+		m.lastError = err.Error()
+		return m.status, err
+	}
+	m.lastError = ""
+	if status.Halted() && !m.status.Halted() {
+		m.halts++
+	}
+	m.status = status
+	return status, nil
+}
+
+// fetchSystemStatus reads the spot system status; futures have no such endpoint and announce maintenance
+// through the symbol status instead
+func (m *ExchangeStatusMonitor) fetchSystemStatus(ctx context.Context, status *ExchangeStatus) error {
+	if !m.config.MarketType.IsSpot() {
+		return nil
+	}
+
+	var system struct {
+		Status int    `json:"status"` // 0: normal, 1: system maintenance
+		Msg    string `json:"msg"`
+	}
+	if err := m.get(ctx, "/sapi/v1/system/status", nil, &system); err != nil {
+		return err
+	}
+	status.Maintenance = system.Status != 0
+	status.SystemMessage = system.Msg
+	return nil
+}
+
+// fetchSymbolStatus reads the trading status of the symbol from the exchange info
+func (m *ExchangeStatusMonitor) fetchSymbolStatus(ctx context.Context, status *ExchangeStatus) error {
+	path := "/fapi/v1/exchangeInfo"
+	query := url.Values{}
+	if m.config.MarketType.IsSpot() {
+		path = "/api/v3/exchangeInfo"
+		query.Set("symbol", status.Symbol)
+	}
+
+	var info struct {
+		Symbols []struct {
+			Symbol string `json:"symbol"`
+			Status string `json:"status"`
+		} `json:"symbols"`
+	}
+	if err := m.get(ctx, path, query, &info); err != nil {
+		return err
+	}
+	for _, symbol := range info.Symbols {
+		if strings.EqualFold(symbol.Symbol, status.Symbol) {
+			status.SymbolStatus = strings.ToUpper(symbol.Status)
+			return nil
+		}
+	}
+	return fmt.Errorf("symbol %s not found in exchange info", status.Symbol)
+}
+
+// get requests a status endpoint and decodes its JSON response
+func (m *ExchangeStatusMonitor) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	target := m.config.URL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create status request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if rateLimit := trading.ParseRateLimitResponse(resp.StatusCode, resp.Header, time.Now(), trading.DefaultBanFallback); rateLimit != nil {
+		return fmt.Errorf("%s: %w", path, rateLimit)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// Status returns the last known exchange status
+func (m *ExchangeStatusMonitor) Status() ExchangeStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// IsHalted returns true while the last known status blocks order placement
+func (m *ExchangeStatusMonitor) IsHalted() bool {
+	if m == nil {
+		return false
+	}
+	return m.Status().Halted()
+}
+
+// GetExchangeStatusStats returns exchange status monitor statistics
+func (m *ExchangeStatusMonitor) GetExchangeStatusStats() map[string]interface{} {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return map[string]interface{}{
+		"url":            m.config.URL,
+		"market_type":    string(m.config.MarketType),
+		"maintenance":    m.status.Maintenance,
+		"system_message": m.status.SystemMessage,
+		"symbol_status":  m.status.SymbolStatus,
+		"halted":         m.status.Halted(),
+		"reason":         m.status.Reason(),
+		"checked_at":     m.status.CheckedAt,
+		"checks":         m.checks,
+		"check_errors":   m.checkErrors,
+		"halts":          m.halts,
+		"last_error":     m.lastError,
+	}
+}
@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"aibot/pkg/trading"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FundingFeedConfig holds configuration for polling funding rates from a futures exchange
+type FundingFeedConfig struct {
+	URL          string        `json:"url"`           // Binance-compatible futures REST API ("" disables the feed)
+	PollInterval time.Duration `json:"poll_interval"` // Funding refresh interval (1m)
+	Timeout      time.Duration `json:"timeout"`       // Request timeout (10s)
+}
+
+// FundingInfo is the funding rate and next funding time reported for a symbol
+type FundingInfo struct {
+	Symbol      string    `json:"symbol"`
+	Rate        float64   `json:"rate"`
+	NextFunding time.Time `json:"next_funding"`
+	MarkPrice   float64   `json:"mark_price"`
+}
+
+// FundingFeed polls the premium index of a futures exchange for funding rates and funding times
+type FundingFeed struct {
+	config FundingFeedConfig
+	client *http.Client
+
+	// Statistics
+	polls      int64
+	pollErrors int64
+	lastError  string
+
+	mu sync.Mutex
+}
+
+// NewFundingFeed creates a funding feed
+func NewFundingFeed(config FundingFeedConfig) *FundingFeed {
+	if config.PollInterval == 0 {
+		config.PollInterval = time.Minute // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &FundingFeed{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Fetch reads the current funding rate and next funding time of symbol
+func (f *FundingFeed) Fetch(ctx context.Context, symbol string) (FundingInfo, error) {
+	info, err := f.fetch(ctx, symbol)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.polls++
+	if err != nil {
+		f.pollErrors++
+		f.lastError = err.Error()
+		return FundingInfo{}, err
+	}
+	f.lastError = ""
+	return info, nil
+}
+
+// fetch requests the premium index of symbol
+func (f *FundingFeed) fetch(ctx context.Context, symbol string) (FundingInfo, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.config.URL+"/fapi/v1/premiumIndex?"+query.Encode(), nil)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to create funding request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to fetch funding rate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if rateLimit := trading.ParseRateLimitResponse(resp.StatusCode, resp.Header, time.Now(), trading.DefaultBanFallback); rateLimit != nil {
+		return FundingInfo{}, fmt.Errorf("premium index: %w", rateLimit)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return FundingInfo{}, fmt.Errorf("premium index returned status %d", resp.StatusCode)
+	}
+
+	var index struct {
+		Symbol          string `json:"symbol"`
+		MarkPrice       string `json:"markPrice"`
+		LastFundingRate string `json:"lastFundingRate"`
+		NextFundingTime int64  `json:"nextFundingTime"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return FundingInfo{}, fmt.Errorf("failed to decode premium index: %w", err)
+	}
+
+	rate, err := strconv.ParseFloat(index.LastFundingRate, 64)
+	if err != nil {
+		return FundingInfo{}, fmt.Errorf("invalid funding rate %q: %w", index.LastFundingRate, err)
+	}
+	markPrice, _ := strconv.ParseFloat(index.MarkPrice, 64)
+	info := FundingInfo{Symbol: symbol, Rate: rate, MarkPrice: markPrice}
+	if index.NextFundingTime > 0 {
+		info.NextFunding = time.UnixMilli(index.NextFundingTime)
+	}
+	return info, nil
+}
+
+// GetFundingFeedStats returns funding feed statistics
+func (f *FundingFeed) GetFundingFeedStats() map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return map[string]interface{}{
+		"url":         f.config.URL,
+		"polls":       f.polls,
+		"poll_errors": f.pollErrors,
+		"last_error":  f.lastError,
+	}
+}
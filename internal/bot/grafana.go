@@ -0,0 +1,295 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Grafana annotation kinds, used as tags so dashboards can overlay each kind separately
+const (
+	AnnotationModeTransition = "mode_transition"
+	AnnotationBreakout       = "breakout"
+	AnnotationRiskAlert      = "risk_alert"
+)
+
+// GrafanaDashboardUID is the UID of the generated dashboard
+const GrafanaDashboardUID = "aibot-events"
+
+// GrafanaConfig holds configuration for pushing annotations to Grafana
+type GrafanaConfig struct {
+	URL          string        `json:"url"`           // Grafana base URL ("" disables annotations)
+	APIKey       string        `json:"api_key"`       // Service account token sent as a bearer token
+	DashboardUID string        `json:"dashboard_uid"` // Dashboard the annotations belong to ("" for organization-wide)
+	Tags         []string      `json:"tags"`          // Tags added to every annotation (["aibot"])
+	Events       []string      `json:"events"`        // Annotation kinds to push (all if empty)
+	Timeout      time.Duration `json:"timeout"`       // Per-request timeout (5s)
+	QueueSize    int           `json:"queue_size"`    // Annotations buffered for delivery (100)
+}
+
+// GrafanaAnnotation is the payload of Grafana's annotation API
+type GrafanaAnnotation struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time"` // Unix milliseconds
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+}
+
+// GrafanaAnnotator pushes events to Grafana's annotation API asynchronously so callers never block on the network
+type GrafanaAnnotator struct {
+	config GrafanaConfig
+	client *http.Client
+	events map[string]bool
+	queue  chan GrafanaAnnotation
+	done   chan struct{}
+
+	// Statistics
+	sent      int64
+	delivered int64
+	failed    int64
+	dropped   int64
+
+	closed bool
+	mu     sync.Mutex
+}
+
+// NewGrafanaAnnotator creates an annotator and starts its delivery worker
+func NewGrafanaAnnotator(config GrafanaConfig) *GrafanaAnnotator {
+	if len(config.Tags) == 0 {
+		config.Tags = []string{"aibot"} // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 5 * time.Second // default
+	}
+	if config.QueueSize == 0 {
+		config.QueueSize = 100 // default
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	events := make(map[string]bool)
+	for _, event := range config.Events {
+		events[event] = true
+	}
+
+	ga := &GrafanaAnnotator{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		events: events,
+		queue:  make(chan GrafanaAnnotation, config.QueueSize),
+		done:   make(chan struct{}),
+	}
+	go ga.run()
+	return ga
+}
+
+// Annotate queues an annotation of the given kind; it never blocks and drops the annotation if the queue is full
+func (ga *GrafanaAnnotator) Annotate(kind, symbol, text string) {
+	if len(ga.events) > 0 && !ga.events[kind] {
+		return
+	}
+
+	tags := append(append(make([]string, 0, len(ga.config.Tags)+2), ga.config.Tags...), kind)
+	if symbol != "" {
+		tags = append(tags, symbol)
+	}
+	annotation := GrafanaAnnotation{
+		DashboardUID: ga.config.DashboardUID,
+		Time:         time.Now().UnixMilli(),
+		Tags:         tags,
+		Text:         text,
+	}
+
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+
+	if ga.closed {
+		return
+	}
+	ga.sent++
+	select {
+	case ga.queue <- annotation:
+	default:
+		ga.dropped++
+		log.Printf("⚠️ Grafana annotation queue full, dropped %s annotation", kind)
+	}
+}
+
+// Close stops accepting annotations and waits up to timeout for queued ones to be delivered
+func (ga *GrafanaAnnotator) Close(timeout time.Duration) {
+	ga.mu.Lock()
+	if !ga.closed {
+		ga.closed = true
+		close(ga.queue)
+	}
+	ga.mu.Unlock()
+
+	select {
+	case <-ga.done:
+	case <-time.After(timeout):
+		log.Printf("⚠️ Grafana delivery timeout reached, %d annotations undelivered", len(ga.queue))
+	}
+}
+
+// run delivers queued annotations until the queue is closed
+func (ga *GrafanaAnnotator) run() {
+	defer close(ga.done)
+
+	for annotation := range ga.queue {
+		err := ga.post(annotation)
+
+		ga.mu.Lock()
+		if err != nil {
+			ga.failed++
+		} else {
+			ga.delivered++
+		}
+		ga.mu.Unlock()
+
+		if err != nil {
+			log.Printf("❌ Grafana annotation failed: %v", err)
+		}
+	}
+}
+
+// post sends one annotation
+func (ga *GrafanaAnnotator) post(annotation GrafanaAnnotation) error {
+	body, err := json.Marshal(annotation)
+	if err != nil {
+		return fmt.Errorf("failed to encode annotation: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ga.config.URL+"/api/annotations", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if ga.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+ga.config.APIKey)
+	}
+
+	resp, err := ga.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("grafana returned %s", resp.Status)
+	}
+	return nil
+}
+
+// GetGrafanaStats returns annotation delivery statistics
+func (ga *GrafanaAnnotator) GetGrafanaStats() map[string]interface{} {
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+
+	return map[string]interface{}{
+		"url":       ga.config.URL,
+		"sent":      ga.sent,
+		"delivered": ga.delivered,
+		"failed":    ga.failed,
+		"dropped":   ga.dropped,
+		"queued":    len(ga.queue),
+	}
+}
+
+// GrafanaDashboard builds a dashboard that overlays the bot's annotations by kind and lists recent events;
+// tags must match the annotator's tags so the annotation queries find its events
+func GrafanaDashboard(title string, tags []string) map[string]interface{} {
+	if len(tags) == 0 {
+		tags = []string{"aibot"}
+	}
+
+	kinds := []struct {
+		kind  string
+		name  string
+		color string
+	}{
+		{AnnotationModeTransition, "Mode transitions", "blue"},
+		{AnnotationBreakout, "Breakouts", "orange"},
+		{AnnotationRiskAlert, "Risk alerts", "red"},
+	}
+
+	annotations := []interface{}{
+		map[string]interface{}{
+			"builtIn":    1,
+			"datasource": grafanaDatasource(),
+			"enable":     true,
+			"hide":       true,
+			"iconColor":  "rgba(0, 211, 255, 1)",
+			"name":       "Annotations & Alerts",
+			"type":       "dashboard",
+		},
+	}
+	panels := make([]interface{}, 0, len(kinds)+1)
+	panels = append(panels, map[string]interface{}{
+		"id":      1,
+		"type":    "annolist",
+		"title":   "Recent events",
+		"gridPos": map[string]int{"x": 0, "y": 0, "w": 24, "h": 10},
+		"options": grafanaAnnotationList(tags, 50),
+	})
+	for i, kind := range kinds {
+		kindTags := append(append([]string{}, tags...), kind.kind)
+		annotations = append(annotations, map[string]interface{}{
+			"datasource": grafanaDatasource(),
+			"enable":     true,
+			"iconColor":  kind.color,
+			"name":       kind.name,
+			"target": map[string]interface{}{
+				"type":     "tags",
+				"tags":     kindTags,
+				"matchAny": false,
+				"limit":    500,
+			},
+		})
+		panels = append(panels, map[string]interface{}{
+			"id":      i + 2,
+			"type":    "annolist",
+			"title":   kind.name,
+			"gridPos": map[string]int{"x": i * 8, "y": 10, "w": 8, "h": 12},
+			"options": grafanaAnnotationList(kindTags, 20),
+		})
+	}
+
+	return map[string]interface{}{
+		"uid":           GrafanaDashboardUID,
+		"title":         title,
+		"tags":          tags,
+		"timezone":      "browser",
+		"schemaVersion": 39,
+		"version":       1,
+		"editable":      true,
+		"refresh":       "30s",
+		"time":          map[string]string{"from": "now-24h", "to": "now"},
+		"annotations":   map[string]interface{}{"list": annotations},
+		"panels":        panels,
+	}
+}
+
+// grafanaDatasource refers to Grafana's built-in annotation store
+func grafanaDatasource() map[string]string {
+	return map[string]string{"type": "grafana", "uid": "-- Grafana --"}
+}
+
+// grafanaAnnotationList returns the options of an annotation list panel filtered by tags
+func grafanaAnnotationList(tags []string, limit int) map[string]interface{} {
+	return map[string]interface{}{
+		"onlyFromThisDashboard": false,
+		"onlyInTimeRange":       true,
+		"tags":                  tags,
+		"limit":                 limit,
+		"showUser":              false,
+		"showTime":              true,
+		"showTags":              true,
+		"navigateToPanel":       false,
+		"navigateBefore":        "10m",
+		"navigateAfter":         "10m",
+	}
+}
@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"aibot/internal/strategy"
+	"fmt"
+	"math"
+)
+
+// maxGridUpdateLevels caps the number of levels an operator can request
+const maxGridUpdateLevels = 200
+
+// GridUpdate holds operator overrides of the calculated grid layout; zero fields keep the calculated value.
+// Bounds are set together and take precedence over spacing, which then only derives the level count.
+type GridUpdate struct {
+	Spacing    float64 `json:"spacing,omitempty"`     // Distance between levels as a fraction of price, e.g. 0.005
+	Levels     int     `json:"levels,omitempty"`      // Number of spaces between the bounds
+	UpperBound float64 `json:"upper_bound,omitempty"` // Highest level price
+	LowerBound float64 `json:"lower_bound,omitempty"` // Lowest level price
+	Reset      bool    `json:"reset,omitempty"`       // Drop all overrides and return to the calculated layout
+}
+
+// Validate checks that the update is self-consistent; bounds are checked against price when applied
+func (u GridUpdate) Validate() error {
+	if u.Reset {
+		if u.Spacing != 0 || u.Levels != 0 || u.UpperBound != 0 || u.LowerBound != 0 {
+			return fmt.Errorf("a grid reset cannot carry new grid parameters")
+		}
+		return nil
+	}
+	if u.IsEmpty() {
+		return fmt.Errorf("grid update requires spacing, levels or bounds")
+	}
+	if u.Spacing < 0 || u.Spacing >= 0.5 {
+		return fmt.Errorf("grid spacing must be between 0 and 0.5, got %.4f", u.Spacing)
+	}
+	if u.Levels < 0 || u.Levels > maxGridUpdateLevels {
+		return fmt.Errorf("grid levels must be between 1 and %d, got %d", maxGridUpdateLevels, u.Levels)
+	}
+	if (u.UpperBound == 0) != (u.LowerBound == 0) {
+		return fmt.Errorf("upper and lower grid bounds must be set together")
+	}
+	if u.LowerBound < 0 || u.UpperBound < u.LowerBound || (u.UpperBound > 0 && u.UpperBound == u.LowerBound) {
+		return fmt.Errorf("grid bounds must satisfy 0 < lower < upper, got %.4f-%.4f", u.LowerBound, u.UpperBound)
+	}
+	return nil
+}
+
+// IsEmpty returns true if the update overrides nothing
+func (u GridUpdate) IsEmpty() bool {
+	return u.Spacing == 0 && u.Levels == 0 && u.UpperBound == 0 && u.LowerBound == 0
+}
+
+// Merge returns the overrides in effect after applying the update on top of the current ones (nil if none)
+func (u GridUpdate) Merge(current *GridUpdate) *GridUpdate {
+	if u.Reset {
+		return nil
+	}
+	merged := u
+	if current != nil {
+		merged = *current
+		if u.Spacing > 0 {
+			merged.Spacing = u.Spacing
+		}
+		if u.Levels > 0 {
+			merged.Levels = u.Levels
+		}
+		if u.UpperBound > 0 {
+			merged.UpperBound, merged.LowerBound = u.UpperBound, u.LowerBound
+		}
+	}
+	return &merged
+}
+
+// gridPlan is a grid layout computed from market analysis and operator overrides
+type gridPlan struct {
+	price      float64
+	result     *strategy.GridCalculationResult
+	volatility float64
+	category   string
+}
+
+// bounds returns the grid bounds of the planned layout
+func (p *gridPlan) bounds() strategy.GridBounds {
+	return strategy.GridBounds{
+		UpperBound: p.result.UpperBound,
+		LowerBound: p.result.LowerBound,
+		Center:     p.price,
+		Range:      p.result.TotalRange,
+	}
+}
+
+// apply overrides the calculated layout around the current price; the per-level size is scaled so the
+// grid keeps the calculated total size
+func (u GridUpdate) apply(result *strategy.GridCalculationResult, price float64) error {
+	calculatedLevels := result.GridLevels
+	levels := calculatedLevels
+	if u.Levels > 0 {
+		levels = u.Levels
+	}
+
+	mode := result.SpacingMode
+	if u.UpperBound > 0 {
+		if u.Levels == 0 && u.Spacing > 0 {
+			steps := (u.UpperBound - u.LowerBound) / (u.Spacing * price)
+			if mode.IsGeometric() && u.LowerBound > 0 {
+				steps = math.Log(u.UpperBound/u.LowerBound) / math.Log(1+u.Spacing)
+			}
+			levels = int(math.Max(1, math.Round(steps)))
+		}
+		result.UpperBound, result.LowerBound = u.UpperBound, u.LowerBound
+	} else {
+		spacing := result.GridSpacing
+		if u.Spacing > 0 {
+			spacing = u.Spacing
+		}
+		result.UpperBound, result.LowerBound = strategy.GridBoundsAround(price, spacing, levels, mode)
+	}
+
+	if levels > maxGridUpdateLevels {
+		return fmt.Errorf("grid update needs %d levels, more than the maximum of %d", levels, maxGridUpdateLevels)
+	}
+	if result.LowerBound <= 0 {
+		return fmt.Errorf("grid update puts the lower bound at %.4f, below zero", result.LowerBound)
+	}
+
+	result.GridLevels = levels
+	result.TotalRange = (result.UpperBound - result.LowerBound) / price
+	result.GridSpacing = strategy.GridSpacingOf(result.LowerBound, result.UpperBound, price, levels, mode)
+	if calculatedLevels > 0 {
+		result.PositionSize *= float64(calculatedLevels) / float64(levels)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package bot
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Health statuses, from best to worst
+const (
+	HealthHealthy  = "healthy"
+	HealthDegraded = "degraded" // A threshold is exceeded but not yet for longer than the grace period
+	HealthCritical = "critical" // A threshold has been exceeded for longer than the grace period
+)
+
+// HealthConfig holds the resource watchdog thresholds
+type HealthConfig struct {
+	CheckInterval time.Duration `json:"check_interval"`  // Sampling interval (5s)
+	MaxGoroutines int           `json:"max_goroutines"`  // 0 disables the check (1000)
+	MaxMemoryMB   float64       `json:"max_memory_mb"`   // Heap in use; 0 disables the check (1024)
+	MaxBacklog    float64       `json:"max_backlog"`     // Channel fill fraction counted as backed up (0.9)
+	MaxStreamLag  time.Duration `json:"max_stream_lag"`  // Age of the last tick; 0 disables the check (30s)
+	GracePeriod   time.Duration `json:"grace_period"`    // How long a threshold may stay exceeded before acting (10s)
+	DegradeToIdle bool          `json:"degrade_to_idle"` // Switch to idle when a threshold stays exceeded
+}
+
+// HealthSample is one measurement of the bot's resources
+type HealthSample struct {
+	Goroutines     int           `json:"goroutines"`
+	HeapMB         float64       `json:"heap_mb"`
+	SysMB          float64       `json:"sys_mb"`
+	GCCount        uint32        `json:"gc_count"`
+	DataBacklog    int           `json:"data_backlog"`
+	DataCapacity   int           `json:"data_capacity"`
+	SignalBacklog  int           `json:"signal_backlog"`
+	SignalCapacity int           `json:"signal_capacity"`
+	LastTick       time.Time     `json:"last_tick"`
+	StreamLag      time.Duration `json:"stream_lag"` // Time since the last tick was received
+	EventLag       time.Duration `json:"event_lag"`  // Receive time minus event time of the last tick
+}
+
+// HealthIssue is a threshold currently exceeded
+type HealthIssue struct {
+	Check   string    `json:"check"` // "goroutines", "memory", "data_backlog", "signal_backlog", "stream_lag"
+	Message string    `json:"message"`
+	Since   time.Time `json:"since"`
+}
+
+// HealthReport is the latest watchdog result
+type HealthReport struct {
+	Status    string        `json:"status"`
+	Sample    HealthSample  `json:"sample"`
+	Issues    []HealthIssue `json:"issues,omitempty"`
+	Degraded  bool          `json:"degraded"` // The watchdog switched the bot to idle and has not seen it healthy since
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// HealthMonitor evaluates resource samples against thresholds and decides when the bot must degrade
+type HealthMonitor struct {
+	config   HealthConfig
+	since    map[string]time.Time // Check -> when its threshold was first exceeded
+	degraded bool
+	report   HealthReport
+
+	// Statistics
+	checks      int64
+	degradeRuns int64
+
+	mu sync.RWMutex
+}
+
+// NewHealthMonitor creates a health monitor
+func NewHealthMonitor(config HealthConfig) *HealthMonitor {
+	if config.CheckInterval == 0 {
+		config.CheckInterval = 5 * time.Second // default
+	}
+	if config.MaxBacklog == 0 {
+		config.MaxBacklog = 0.9 // default
+	}
+	if config.GracePeriod == 0 {
+		config.GracePeriod = 10 * time.Second // default
+	}
+
+	return &HealthMonitor{
+		config: config,
+		since:  make(map[string]time.Time),
+		report: HealthReport{Status: HealthHealthy},
+	}
+}
+
+// ReadRuntime fills the goroutine and memory fields of a sample
+func (s *HealthSample) ReadRuntime() {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	s.Goroutines = runtime.NumGoroutine()
+	s.HeapMB = float64(mem.HeapInuse) / (1024 * 1024)
+	s.SysMB = float64(mem.Sys) / (1024 * 1024)
+	s.GCCount = mem.NumGC
+}
+
+// Evaluate checks a sample and returns the report; degrade is true once when a threshold has stayed
+// exceeded for longer than the grace period and the bot should switch to idle
+func (hm *HealthMonitor) Evaluate(sample HealthSample, now time.Time) (HealthReport, bool) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.checks++
+	breaches := hm.breaches(sample)
+	for check := range hm.since {
+		if _, exceeded := breaches[check]; !exceeded {
+			delete(hm.since, check)
+		}
+	}
+
+	report := HealthReport{Status: HealthHealthy, Sample: sample, CheckedAt: now}
+	checks := make([]string, 0, len(breaches))
+	for check := range breaches {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+
+	for _, check := range checks {
+		since, exists := hm.since[check]
+		if !exists {
+			since = now
+			hm.since[check] = now
+		}
+		report.Issues = append(report.Issues, HealthIssue{Check: check, Message: breaches[check], Since: since})
+
+		status := HealthDegraded
+		if now.Sub(since) >= hm.config.GracePeriod {
+			status = HealthCritical
+		}
+		if status == HealthCritical || report.Status == HealthHealthy {
+			report.Status = status
+		}
+	}
+
+	degrade := false
+	switch {
+	case report.Status == HealthHealthy:
+		hm.degraded = false
+	case report.Status == HealthCritical && hm.config.DegradeToIdle && !hm.degraded:
+		hm.degraded = true
+		hm.degradeRuns++
+		degrade = true
+	}
+	report.Degraded = hm.degraded
+	hm.report = report
+	return report, degrade
+}
+
+// breaches returns the exceeded thresholds of a sample with a description of each
+func (hm *HealthMonitor) breaches(sample HealthSample) map[string]string {
+	breaches := make(map[string]string)
+
+	if hm.config.MaxGoroutines > 0 && sample.Goroutines > hm.config.MaxGoroutines {
+		breaches["goroutines"] = fmt.Sprintf("%d goroutines (max %d)", sample.Goroutines, hm.config.MaxGoroutines)
+	}
+	if hm.config.MaxMemoryMB > 0 && sample.HeapMB > hm.config.MaxMemoryMB {
+		breaches["memory"] = fmt.Sprintf("%.1f MB heap in use (max %.0f MB)", sample.HeapMB, hm.config.MaxMemoryMB)
+	}
+	if backedUp(sample.DataBacklog, sample.DataCapacity, hm.config.MaxBacklog) {
+		breaches["data_backlog"] = fmt.Sprintf("data queue %d/%d full", sample.DataBacklog, sample.DataCapacity)
+	}
+	if backedUp(sample.SignalBacklog, sample.SignalCapacity, hm.config.MaxBacklog) {
+		breaches["signal_backlog"] = fmt.Sprintf("signal channel %d/%d full", sample.SignalBacklog, sample.SignalCapacity)
+	}
+	if hm.config.MaxStreamLag > 0 && !sample.LastTick.IsZero() && sample.StreamLag > hm.config.MaxStreamLag {
+		breaches["stream_lag"] = fmt.Sprintf("no tick for %s (max %s)", sample.StreamLag.Round(time.Second), hm.config.MaxStreamLag)
+	}
+	return breaches
+}
+
+// backedUp returns true if a channel holds at least the given fraction of its capacity
+func backedUp(backlog, capacity int, maxFill float64) bool {
+	return capacity > 0 && float64(backlog) >= float64(capacity)*maxFill
+}
+
+// GetReport returns the latest health report
+func (hm *HealthMonitor) GetReport() HealthReport {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.report
+}
+
+// GetHealthStats returns watchdog statistics
+func (hm *HealthMonitor) GetHealthStats() map[string]interface{} {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	return map[string]interface{}{
+		"status":       hm.report.Status,
+		"issues":       len(hm.report.Issues),
+		"degraded":     hm.degraded,
+		"checks":       hm.checks,
+		"degrade_runs": hm.degradeRuns,
+		"checked_at":   hm.report.CheckedAt,
+	}
+}
@@ -0,0 +1,234 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Probe response statuses
+const (
+	ProbeOK          = "ok"
+	ProbeUnavailable = "unavailable"
+)
+
+// HealthServerConfig holds configuration for the HTTP health probes
+type HealthServerConfig struct {
+	Address    string        `json:"address"`      // Listen address such as ":8081" ("" disables the probes)
+	MaxTickAge time.Duration `json:"max_tick_age"` // Age of the last tick beyond which the bot is not ready (30s)
+}
+
+// ProbeCheck is one condition of a probe
+type ProbeCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ProbeResponse is the JSON body of /healthz and /readyz
+type ProbeResponse struct {
+	Status    string       `json:"status"` // "ok" or "unavailable"
+	Checks    []ProbeCheck `json:"checks"`
+	LastTick  *time.Time   `json:"last_tick,omitempty"`
+	TickAge   string       `json:"tick_age,omitempty"`
+	CheckedAt time.Time    `json:"checked_at"`
+}
+
+// HealthServer answers container orchestrator probes over HTTP. /healthz fails only when the bot should be
+// restarted: the resource watchdog is critical. /readyz also fails while the bot cannot trade: before it is
+// active, while the stream or executor is disconnected, or when ticks have stopped arriving.
+type HealthServer struct {
+	config       HealthServerConfig
+	orchestrator *Orchestrator
+	server       *http.Server
+	listener     net.Listener
+
+	// Statistics
+	requests int64
+	failures int64
+
+	mu sync.Mutex
+}
+
+// NewHealthServer creates a health probe server for an orchestrator
+func NewHealthServer(config HealthServerConfig, orchestrator *Orchestrator) *HealthServer {
+	if config.MaxTickAge == 0 {
+		config.MaxTickAge = 30 * time.Second // default
+	}
+
+	hs := &HealthServer{
+		config:       config,
+		orchestrator: orchestrator,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		hs.respond(w, r, orchestrator.liveness(time.Now()))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		hs.respond(w, r, orchestrator.readiness(time.Now(), config.MaxTickAge))
+	})
+	hs.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return hs
+}
+
+// Start listens on the configured address
+func (hs *HealthServer) Start() error {
+	listener, err := net.Listen("tcp", hs.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", hs.config.Address, err)
+	}
+	hs.listener = listener
+
+	log.Printf("🩺 Health probes listening on http://%s/healthz and /readyz", listener.Addr())
+	go func() {
+		if err := hs.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Health probe server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the probes listen on
+func (hs *HealthServer) Addr() net.Addr {
+	return hs.listener.Addr()
+}
+
+// Close stops the server, letting probes in flight finish
+func (hs *HealthServer) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := hs.server.Shutdown(ctx); err != nil {
+		hs.server.Close()
+	}
+}
+
+// respond writes a probe result: 200 when every check passes, 503 otherwise
+func (hs *HealthServer) respond(w http.ResponseWriter, r *http.Request, response ProbeResponse) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := http.StatusOK
+	if response.Status != ProbeOK {
+		status = http.StatusServiceUnavailable
+	}
+	hs.mu.Lock()
+	hs.requests++
+	if status != http.StatusOK {
+		hs.failures++
+	}
+	hs.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// GetHealthServerStats returns health probe server statistics
+func (hs *HealthServer) GetHealthServerStats() map[string]interface{} {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	return map[string]interface{}{
+		"address":      hs.config.Address,
+		"max_tick_age": hs.config.MaxTickAge.String(),
+		"requests":     hs.requests,
+		"failures":     hs.failures,
+	}
+}
+
+// liveness reports whether the bot should keep running. It reads no state guarded by o.mu, which Start
+// and Stop hold for their whole run, so probes answer while the bot starts up or shuts down.
+func (o *Orchestrator) liveness(now time.Time) ProbeResponse {
+	response := ProbeResponse{CheckedAt: now}
+	response.add(o.watchdogCheck())
+	o.addTickAge(&response, now)
+	return response.finish()
+}
+
+// readiness reports whether the bot is trading: active, connected and receiving ticks
+func (o *Orchestrator) readiness(now time.Time, maxTickAge time.Duration) ProbeResponse {
+	response := ProbeResponse{CheckedAt: now}
+
+	if !o.active.Load() {
+		response.add(ProbeCheck{Name: "orchestrator", Detail: "inactive"})
+	} else {
+		response.add(ProbeCheck{Name: "orchestrator", OK: true, Detail: "active"})
+		response.add(connectionCheck("stream", o.streamProvider.IsConnected()))
+		response.add(connectionCheck("executor", o.tradingExecutor.IsConnected()))
+	}
+
+	tick := ProbeCheck{Name: "last_tick", Detail: "no tick received"}
+	if age, ok := o.addTickAge(&response, now); ok {
+		tick.OK = age <= maxTickAge
+		tick.Detail = fmt.Sprintf("%s ago (limit %s)", age, maxTickAge)
+	}
+	response.add(tick)
+	response.add(o.watchdogCheck())
+	return response.finish()
+}
+
+// watchdogCheck fails when a resource threshold has stayed exceeded past the watchdog's grace period
+func (o *Orchestrator) watchdogCheck() ProbeCheck {
+	report := o.health.GetReport()
+	check := ProbeCheck{Name: "watchdog", OK: report.Status != HealthCritical, Detail: report.Status}
+	if len(report.Issues) > 0 {
+		issues := make([]string, len(report.Issues))
+		for i, issue := range report.Issues {
+			issues[i] = issue.Check
+		}
+		check.Detail += ": " + strings.Join(issues, ", ")
+	}
+	return check
+}
+
+// addTickAge records when the last tick arrived; ok is false before the first tick
+func (o *Orchestrator) addTickAge(response *ProbeResponse, now time.Time) (time.Duration, bool) {
+	lastTick := o.lastTickAt.Load()
+	if lastTick == 0 {
+		return 0, false
+	}
+	at := time.Unix(0, lastTick)
+	age := now.Sub(at).Truncate(time.Millisecond)
+	response.LastTick = &at
+	response.TickAge = age.String()
+	return age, true
+}
+
+// connectionCheck reports a connection state
+func connectionCheck(name string, connected bool) ProbeCheck {
+	if connected {
+		return ProbeCheck{Name: name, OK: true, Detail: "connected"}
+	}
+	return ProbeCheck{Name: name, Detail: "disconnected"}
+}
+
+// add appends a check
+func (r *ProbeResponse) add(check ProbeCheck) {
+	r.Checks = append(r.Checks, check)
+}
+
+// finish sets the status from the checks
+func (r ProbeResponse) finish() ProbeResponse {
+	r.Status = ProbeOK
+	for _, check := range r.Checks {
+		if !check.OK {
+			r.Status = ProbeUnavailable
+		}
+	}
+	return r
+}
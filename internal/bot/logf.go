@@ -0,0 +1,14 @@
+package bot
+
+import (
+	"log"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logf prints a log line if the component's runtime log level allows it
+func (o *Orchestrator) logf(component string, level logrus.Level, format string, args ...interface{}) {
+	if o.logLevels.Enabled(component, level) {
+		log.Printf(format, args...)
+	}
+}
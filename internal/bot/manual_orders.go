@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/types"
+	"aibot/pkg/trading"
+	"fmt"
+)
+
+// manualOrderStrategy is the strategy name embedded in client order IDs of operator orders
+const manualOrderStrategy = "manual"
+
+// placeManualOrder places an operator order through the executor. It passes the same risk checks as
+// strategy orders and is journaled as an order intent; its fills are booked like any other fill, so
+// positions, the ledger and reconciliation stay in step with the exchange.
+func (o *Orchestrator) placeManualOrder(params PlaceOrderParams) (*types.Order, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	positionType := params.PositionType
+	if positionType == "" {
+		// Buying opens longs and closes shorts; selling the reverse
+		positionType = types.PositionTypeLong
+		if (params.Side == types.OrderSideSell) != params.ReduceOnly {
+			positionType = types.PositionTypeShort
+		}
+	}
+	order := types.NewOrder("", params.Symbol, params.Side, params.OrderType(), params.Quantity, params.Price, positionType)
+	order.SetReduceOnly(params.ReduceOnly)
+	if params.PostOnly {
+		order.TimeInForce = types.TimeInForcePostOnly
+	}
+
+	if err := o.checkManualOrder(order, true); err != nil {
+		return nil, err
+	}
+	return o.submitManualOrder(order)
+}
+
+// modifyManualOrder replaces a resting manual limit order with one at the new quantity or price. The
+// executor has no amend, so the order is cancelled first; if the replacement then fails, the error says so.
+func (o *Orchestrator) modifyManualOrder(params ModifyOrderParams) (*types.Order, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	original, err := o.activeOrder(params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if strategy := types.StrategyFromClientOrderID(original.ClientOrderID); strategy != manualOrderStrategy {
+		if strategy == "" {
+			strategy = "another client"
+		}
+		return nil, fmt.Errorf("order %s was placed by %s; only manual orders can be modified", original.ID, strategy)
+	}
+	if original.Type != types.OrderTypeLimit {
+		return nil, fmt.Errorf("only limit orders can be modified, order %s is a %s order", original.ID, original.Type)
+	}
+
+	quantity := params.Quantity
+	if quantity == 0 {
+		quantity = original.GetRemainingQty()
+	}
+	price := params.Price
+	if price == 0 {
+		price = original.Price
+	}
+	replacement := types.NewLimitOrder("", original.Symbol, original.Side, quantity, price, original.PositionType)
+	replacement.SetReduceOnly(original.ReduceOnly)
+	replacement.TimeInForce = original.TimeInForce
+
+	// The cancelled order frees its slot, so the open order budget is not checked again
+	if err := o.checkManualOrder(replacement, false); err != nil {
+		return nil, err
+	}
+	if err := o.tradingExecutor.CancelOrder(original.ID); err != nil {
+		return nil, fmt.Errorf("failed to cancel order %s: %w", original.ID, err)
+	}
+	placed, err := o.submitManualOrder(replacement)
+	if err != nil {
+		return nil, fmt.Errorf("order %s was cancelled but its replacement failed: %w", original.ID, err)
+	}
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "🖐️ Manual order %s modified: %.6f @ %.2f -> %.6f @ %.2f (now %s)",
+		original.ID, original.GetRemainingQty(), original.Price, quantity, price, placed.ID)
+	return placed, nil
+}
+
+// cancelManualOrder cancels a resting order of any origin; a cancelled grid order deactivates its level
+// once the executor reports the cancellation
+func (o *Orchestrator) cancelManualOrder(params CancelOrderParams) (*types.Order, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	order, err := o.activeOrder(params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.tradingExecutor.CancelOrder(order.ID); err != nil {
+		return nil, fmt.Errorf("failed to cancel order %s: %w", order.ID, err)
+	}
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "🖐️ Operator cancelled %s %s order %s: %.6f @ %.2f",
+		order.Symbol, order.Side, order.ID, order.GetRemainingQty(), order.Price)
+
+	if cancelled, err := o.tradingExecutor.GetOrder(order.ID); err == nil {
+		return cancelled, nil
+	}
+	order.Status = types.OrderStatusCancelled
+	return order, nil
+}
+
+// activeOrder returns an order that is still open on the exchange
+func (o *Orchestrator) activeOrder(orderID string) (*types.Order, error) {
+	order, err := o.tradingExecutor.GetOrder(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("order %s not found: %w", orderID, err)
+	}
+	if !order.IsActive() {
+		return nil, fmt.Errorf("order %s is already %s", orderID, order.Status)
+	}
+	return order, nil
+}
+
+// checkManualOrder applies the risk checks of strategy orders: new exposure is refused while the daily
+// loss, drawdown or equity curve guards halt entries or the exchange is halted, the risk policy applies,
+// and resting orders must fit the open order budget
+func (o *Orchestrator) checkManualOrder(order *types.Order, budget bool) error {
+	if !order.ReduceOnly {
+		switch {
+		case o.dailyLoss.IsHalted():
+			return fmt.Errorf("new exposure is halted: daily loss limit reached")
+		case o.riskManager.GetSizeMultiplier() <= 0:
+			return fmt.Errorf("new exposure is halted by the drawdown policy")
+		case !o.equityFilter.IsTradingEnabled():
+			return fmt.Errorf("new exposure is halted by the equity curve filter")
+		case o.exchangeStatus.IsHalted():
+			return fmt.Errorf("new exposure is halted while the exchange is halted")
+		}
+	}
+	if err := o.checkRiskPolicy(o.ctx, order); err != nil {
+		return err
+	}
+
+	if !budget || order.Type == types.OrderTypeMarket || !o.orderBudget.Enabled() {
+		return nil
+	}
+	open, err := o.tradingExecutor.GetOpenOrders("")
+	if err != nil {
+		return fmt.Errorf("failed to count open orders: %w", err)
+	}
+	if available, limited := o.orderBudget.Available(order.Symbol, open); limited && available == 0 {
+		o.orderBudget.RecordDeferred(1)
+		return fmt.Errorf("open order budget for %s is used up", order.Symbol)
+	}
+	return nil
+}
+
+// submitManualOrder journals and places an operator order and returns it as the executor reports it
+func (o *Orchestrator) submitManualOrder(order *types.Order) (*types.Order, error) {
+	if err := o.checkRateLimit(order.ReduceOnly); err != nil {
+		return nil, err
+	}
+
+	expectedPrice := order.Price
+	if expectedPrice <= 0 {
+		expectedPrice = o.candleAggregator.GetLatestPrice(order.Symbol)
+	}
+
+	o.positionMu.Lock()
+	o.orderSeq++
+	order.ClientOrderID = types.NewClientOrderID(manualOrderStrategy, 0, order.Side, o.orderSeq)
+	order.Tag = types.NewOrderTag(manualOrderStrategy, string(order.Type))
+	if expectedPrice > 0 {
+		o.expectedPrices[order.ClientOrderID] = expectedPrice
+	}
+	o.positionMu.Unlock()
+
+	// An order that cannot be persisted is not submitted, so a crash never loses an accepted order
+	intent, err := o.intents.Enqueue(order, manualOrderStrategy)
+	var result *types.OrderResult
+	if err == nil {
+		result, err = trading.PlaceOrderWithRetry(o.tradingExecutor, order, o.config.OrderRetryAttempts, o.config.OrderRetryDelay)
+		if err != nil {
+			if failErr := o.intents.Fail(intent.ID, err); failErr != nil {
+				o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to record failed order intent %s: %v", intent.ID, failErr)
+			}
+		} else if ackErr := o.intents.Ack(intent.ID, result.OrderID); ackErr != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to acknowledge order intent %s: %v", intent.ID, ackErr)
+		}
+	}
+	if err != nil {
+		o.positionMu.Lock()
+		delete(o.expectedPrices, order.ClientOrderID)
+		o.positionMu.Unlock()
+		o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Manual %s %s order for %.6f %s failed: %v", order.Type, order.Side, order.Quantity, order.Symbol, err)
+		return nil, err
+	}
+
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "🖐️ Manual %s %s order %s: %.6f %s @ %.2f (%s)",
+		order.Type, order.Side, result.OrderID, order.Quantity, order.Symbol, expectedPrice, result.Status)
+
+	if placed, err := o.tradingExecutor.GetOrder(result.OrderID); err == nil {
+		return placed, nil
+	}
+	order.ID = result.OrderID
+	order.Status = types.OrderStatus(result.Status)
+	order.FilledQty = result.FilledQty
+	order.AvgFillPrice = result.FilledPrice
+	return order, nil
+}
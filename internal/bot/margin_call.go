@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/types"
+	"aibot/pkg/trading"
+	"fmt"
+	"time"
+)
+
+// checkMarginCall raises a critical margin_call alert, once per margin call, when the executor reports the
+// account's maintenance margin at its margin call ratio of equity
+func (o *Orchestrator) checkMarginCall(margin *trading.MarginInfo) {
+	if !margin.MarginCall {
+		if o.marginCall {
+			o.logf(logging.ComponentRisk, logging.InfoLevel, "✅ Margin call cleared: margin ratio %.1f%%", margin.MarginRatio*100)
+		}
+		o.marginCall = false
+		return
+	}
+	if o.marginCall {
+		return
+	}
+	o.marginCall = true
+
+	o.publishRiskAlert(RiskAlert{
+		Level: "critical",
+		Type:  "margin_call",
+		Message: fmt.Sprintf("Margin call: maintenance margin %.2f is %.1f%% of equity %.2f",
+			margin.MaintenanceMargin, margin.MarginRatio*100, margin.TotalBalance),
+		Symbol:    o.activeSymbol,
+		Value:     margin.MarginRatio,
+		Threshold: 1, // Positions are liquidated when maintenance margin reaches equity
+		Timestamp: time.Now(),
+	})
+}
+
+// handleLiquidationFill raises a critical alert for a position the executor closed by forced liquidation
+func (o *Orchestrator) handleLiquidationFill(update types.OrderUpdate) {
+	o.publishRiskAlert(RiskAlert{
+		Level: "critical",
+		Type:  "forced_liquidation",
+		Message: fmt.Sprintf("%s %s position of %.6f liquidated at %.2f (pnl: %.2f, fee: %.4f)",
+			update.Symbol, update.PositionType, update.LastFillQty, update.LastFillPrice, update.RealizedPnL, update.Fee),
+		Symbol:    update.Symbol,
+		Value:     update.RealizedPnL,
+		Timestamp: update.Timestamp,
+	})
+}
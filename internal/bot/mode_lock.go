@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Control protocol commands of the mode lock
+const (
+	ControlLockMode   = "lock_mode"
+	ControlUnlockMode = "unlock_mode"
+)
+
+// ErrModeLocked is returned for transitions away from the mode an operator locked the bot in
+var ErrModeLocked = errors.New("mode is locked")
+
+// ModeLock pins the trading mode until an operator unlocks it; every other transition, automatic or
+// requested, is suppressed meanwhile
+type ModeLock struct {
+	Mode           TradingMode `json:"mode"`
+	Reason         string      `json:"reason,omitempty"`
+	LockedAt       time.Time   `json:"locked_at"`
+	Suppressed     int64       `json:"suppressed"`                // Transitions refused while locked
+	LastSuppressed TradingMode `json:"last_suppressed,omitempty"` // Target of the last refused transition
+}
+
+// LockModeParams are the parameters of lock_mode
+type LockModeParams struct {
+	Mode   TradingMode `json:"mode"`
+	Reason string      `json:"reason,omitempty"` // Shown with the lock, e.g. "FOMC"
+}
+
+// Validate checks that the mode is known
+func (p LockModeParams) Validate() error {
+	if p.Mode == "" {
+		return fmt.Errorf("lock_mode requires a mode")
+	}
+	return SwitchModeParams{Mode: p.Mode}.Validate()
+}
+
+// lockMode switches to a mode, if not in it already, and keeps the bot there until unlockMode. A lock in
+// another mode is replaced.
+func (o *Orchestrator) lockMode(params LockModeParams) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.state.Mode != params.Mode {
+		if err := o.transitionMode(params.Mode); err != nil {
+			return err
+		}
+	}
+	o.state.ModeLock = &ModeLock{
+		Mode:     params.Mode,
+		Reason:   params.Reason,
+		LockedAt: time.Now(),
+	}
+	o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "🔒 Mode locked to %s until unlocked: %s", params.Mode, params.Reason)
+	return nil
+}
+
+// unlockMode lifts the mode lock so automatic transitions resume
+func (o *Orchestrator) unlockMode() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	lock := o.state.ModeLock
+	if lock == nil {
+		return fmt.Errorf("mode is not locked")
+	}
+	o.state.ModeLock = nil
+	o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "🔓 Mode lock on %s lifted after %s (%d transitions suppressed)",
+		lock.Mode, time.Since(lock.LockedAt).Round(time.Second), lock.Suppressed)
+	return nil
+}
+
+// refuseLockedTransition returns true, recording the suppression, if the mode lock refuses a transition to
+// newMode; callers check it before acting on a transition they are about to make
+func (o *Orchestrator) refuseLockedTransition(newMode TradingMode) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if lock := o.state.ModeLock; lock != nil && newMode != lock.Mode {
+		o.suppressTransition(newMode)
+		return true
+	}
+	return false
+}
+
+// suppressTransition records and logs a transition refused by the mode lock; caller must hold o.mu
+func (o *Orchestrator) suppressTransition(newMode TradingMode) error {
+	lock := o.state.ModeLock
+	lock.Suppressed++
+	lock.LastSuppressed = newMode
+	o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "🔒 Mode locked to %s, suppressed transition %s -> %s",
+		lock.Mode, o.state.Mode, newMode)
+	return fmt.Errorf("%w to %s: transition to %s suppressed", ErrModeLocked, lock.Mode, newMode)
+}
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limits a mode watchdog enforces
+const (
+	ModeLimitMaxDuration = "max_duration"
+	ModeLimitInactivity  = "inactivity"
+)
+
+// ModeTimeout limits how long the bot may stay in one mode
+type ModeTimeout struct {
+	MaxDuration time.Duration `json:"max_duration"` // Longest stay in the mode (0 = unlimited)
+	Inactivity  time.Duration `json:"inactivity"`   // Longest time without fills or mode changes (0 = unlimited)
+	Fallback    TradingMode   `json:"fallback"`     // Mode switched to when a limit is exceeded ("" only signals)
+}
+
+// ModeWatchdogConfig holds the per-mode limits of the mode watchdog
+type ModeWatchdogConfig struct {
+	CheckInterval time.Duration               `json:"check_interval"` // 5s
+	Modes         map[TradingMode]ModeTimeout `json:"modes"`          // Modes without an entry are not limited
+}
+
+// DefaultModeTimeouts returns limits that leave room for long breakouts but recover from a stuck mode;
+// recovery mode is time-boxed by the recovery escalation instead
+func DefaultModeTimeouts() map[TradingMode]ModeTimeout {
+	return map[TradingMode]ModeTimeout{
+		ModeBreakout:  {MaxDuration: 4 * time.Hour, Inactivity: 30 * time.Minute, Fallback: ModeGrid},
+		ModeStability: {MaxDuration: 30 * time.Minute, Inactivity: 10 * time.Minute, Fallback: ModeGrid},
+	}
+}
+
+// Validate checks that limits are non-negative and fallbacks are known modes other than the mode itself
+func (c ModeWatchdogConfig) Validate() error {
+	for mode, timeout := range c.Modes {
+		if err := (SwitchModeParams{Mode: mode}).Validate(); err != nil {
+			return fmt.Errorf("mode watchdog: %w", err)
+		}
+		if timeout.MaxDuration < 0 || timeout.Inactivity < 0 {
+			return fmt.Errorf("mode watchdog limits of %s cannot be negative", mode)
+		}
+		if timeout.Fallback == "" {
+			continue
+		}
+		if err := (SwitchModeParams{Mode: timeout.Fallback}).Validate(); err != nil {
+			return fmt.Errorf("mode watchdog fallback of %s: %w", mode, err)
+		}
+		if timeout.Fallback == mode {
+			return fmt.Errorf("mode watchdog fallback of %s cannot be the mode itself", mode)
+		}
+	}
+	return nil
+}
+
+// ModeTimeoutEvent is a mode exceeding one of its limits
+type ModeTimeoutEvent struct {
+	Mode      TradingMode   `json:"mode"`
+	Limit     string        `json:"limit"` // "max_duration" or "inactivity"
+	Elapsed   time.Duration `json:"elapsed"`
+	Threshold time.Duration `json:"threshold"`
+	Fallback  TradingMode   `json:"fallback,omitempty"`
+	EnteredAt time.Time     `json:"entered_at"`
+}
+
+// Reason describes the exceeded limit
+func (e ModeTimeoutEvent) Reason() string {
+	if e.Limit == ModeLimitInactivity {
+		return fmt.Sprintf("%s mode inactive for %v (limit %v)", e.Mode, e.Elapsed.Round(time.Second), e.Threshold)
+	}
+	return fmt.Sprintf("%s mode active for %v (limit %v)", e.Mode, e.Elapsed.Round(time.Second), e.Threshold)
+}
+
+// ModeWatchdog detects modes held longer than their configured limits. Each stay in a mode fires at most
+// one event, so a fallback that is refused or disabled is not re-signalled every check.
+type ModeWatchdog struct {
+	config ModeWatchdogConfig
+
+	firedFor time.Time // Entry time of the stay that already fired
+	timeouts int64
+
+	mu sync.Mutex
+}
+
+// NewModeWatchdog creates a mode watchdog
+func NewModeWatchdog(config ModeWatchdogConfig) *ModeWatchdog {
+	if config.CheckInterval == 0 {
+		config.CheckInterval = 5 * time.Second // default
+	}
+	if config.Modes == nil {
+		config.Modes = DefaultModeTimeouts() // default
+	}
+	return &ModeWatchdog{config: config}
+}
+
+// Check returns the exceeded limit of a mode entered at enteredAt and last active at lastActivity, or nil
+func (w *ModeWatchdog) Check(mode TradingMode, enteredAt, lastActivity, now time.Time) *ModeTimeoutEvent {
+	timeout, limited := w.config.Modes[mode]
+	if !limited {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.firedFor.Equal(enteredAt) {
+		return nil
+	}
+
+	event := &ModeTimeoutEvent{Mode: mode, Fallback: timeout.Fallback, EnteredAt: enteredAt}
+	switch {
+	case timeout.MaxDuration > 0 && now.Sub(enteredAt) > timeout.MaxDuration:
+		event.Limit, event.Elapsed, event.Threshold = ModeLimitMaxDuration, now.Sub(enteredAt), timeout.MaxDuration
+	case timeout.Inactivity > 0 && now.Sub(lastActivity) > timeout.Inactivity:
+		event.Limit, event.Elapsed, event.Threshold = ModeLimitInactivity, now.Sub(lastActivity), timeout.Inactivity
+	default:
+		return nil
+	}
+
+	w.firedFor = enteredAt
+	w.timeouts++
+	return event
+}
+
+// GetModeWatchdogStats returns mode watchdog statistics
+func (w *ModeWatchdog) GetModeWatchdogStats() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	modes := make(map[string]interface{}, len(w.config.Modes))
+	for mode, timeout := range w.config.Modes {
+		modes[string(mode)] = timeout
+	}
+	return map[string]interface{}{
+		"check_interval": w.config.CheckInterval.String(),
+		"modes":          modes,
+		"timeouts":       w.timeouts,
+	}
+}
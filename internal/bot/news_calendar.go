@@ -0,0 +1,228 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Calendar event impact levels, lowest first
+var calendarImpacts = map[string]int{
+	"low":    1,
+	"medium": 2,
+	"high":   3,
+}
+
+// NewsCalendarConfig holds configuration for pausing grid entries around scheduled events
+type NewsCalendarConfig struct {
+	URL          string        `json:"url"`           // JSON event calendar ("" disables the integration)
+	PollInterval time.Duration `json:"poll_interval"` // Calendar refresh interval (15m)
+	Timeout      time.Duration `json:"timeout"`       // Request timeout (10s)
+	PauseBefore  time.Duration `json:"pause_before"`  // Entries stop this long before an event (15m)
+	ResumeAfter  time.Duration `json:"resume_after"`  // Entries resume this long after an event (15m)
+	MinImpact    string        `json:"min_impact"`    // Lowest impact that pauses entries: "low", "medium", "high" (high)
+	Currencies   []string      `json:"currencies"`    // Currencies or countries to watch (all if empty)
+}
+
+// CalendarEvent is one scheduled event; "date" and "country" are accepted as aliases of "time" and "currency"
+// so common calendar exports can be used directly
+type CalendarEvent struct {
+	Title    string    `json:"title"`
+	Time     time.Time `json:"time"`
+	Impact   string    `json:"impact"`
+	Currency string    `json:"currency"`
+}
+
+// UnmarshalJSON accepts the field aliases used by calendar exports
+func (e *CalendarEvent) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Title    string     `json:"title"`
+		Time     *time.Time `json:"time"`
+		Date     *time.Time `json:"date"`
+		Impact   string     `json:"impact"`
+		Currency string     `json:"currency"`
+		Country  string     `json:"country"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	e.Title = raw.Title
+	e.Impact = strings.ToLower(raw.Impact)
+	e.Currency = raw.Currency
+	if e.Currency == "" {
+		e.Currency = raw.Country
+	}
+	switch {
+	case raw.Time != nil:
+		e.Time = *raw.Time
+	case raw.Date != nil:
+		e.Time = *raw.Date
+	default:
+		return fmt.Errorf("calendar event %q has no time", raw.Title)
+	}
+	return nil
+}
+
+// NewsCalendar polls an event calendar and reports the blackout windows around relevant events
+type NewsCalendar struct {
+	config     NewsCalendarConfig
+	client     *http.Client
+	currencies map[string]bool
+	events     []CalendarEvent // Relevant events, ordered by time
+
+	// Statistics
+	fetches     int64
+	fetchErrors int64
+	lastFetch   time.Time
+	lastError   string
+
+	mu sync.RWMutex
+}
+
+// NewNewsCalendar creates a news calendar
+func NewNewsCalendar(config NewsCalendarConfig) *NewsCalendar {
+	if config.PollInterval == 0 {
+		config.PollInterval = 15 * time.Minute // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default
+	}
+	if config.PauseBefore == 0 {
+		config.PauseBefore = 15 * time.Minute // default
+	}
+	if config.ResumeAfter == 0 {
+		config.ResumeAfter = 15 * time.Minute // default
+	}
+	if config.MinImpact == "" {
+		config.MinImpact = "high" // default
+	}
+
+	currencies := make(map[string]bool)
+	for _, currency := range config.Currencies {
+		currencies[strings.ToUpper(currency)] = true
+	}
+
+	return &NewsCalendar{
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout},
+		currencies: currencies,
+	}
+}
+
+// Fetch downloads the calendar and keeps the events that can pause trading
+func (nc *NewsCalendar) Fetch(ctx context.Context) error {
+	events, err := nc.download(ctx)
+
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	nc.fetches++
+	nc.lastFetch = time.Now()
+	if err != nil {
+		nc.fetchErrors++
+		nc.lastError = err.Error()
+		return err
+	}
+	nc.lastError = ""
+
+	minImpact := calendarImpacts[strings.ToLower(nc.config.MinImpact)]
+	relevant := make([]CalendarEvent, 0, len(events))
+	for _, event := range events {
+		if calendarImpacts[event.Impact] < minImpact {
+			continue
+		}
+		if len(nc.currencies) > 0 && !nc.currencies[strings.ToUpper(event.Currency)] {
+			continue
+		}
+		relevant = append(relevant, event)
+	}
+	sort.Slice(relevant, func(i, j int) bool { return relevant[i].Time.Before(relevant[j].Time) })
+	nc.events = relevant
+	return nil
+}
+
+// download requests and decodes the calendar
+func (nc *NewsCalendar) download(ctx context.Context) ([]CalendarEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nc.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create calendar request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := nc.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("calendar returned status %d", resp.StatusCode)
+	}
+
+	var events []CalendarEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to decode calendar: %w", err)
+	}
+	return events, nil
+}
+
+// ActiveEvent returns the event whose blackout window contains now
+func (nc *NewsCalendar) ActiveEvent(now time.Time) (CalendarEvent, bool) {
+	if nc == nil {
+		return CalendarEvent{}, false
+	}
+	nc.mu.RLock()
+	defer nc.mu.RUnlock()
+
+	for _, event := range nc.events {
+		if !now.Before(event.Time.Add(-nc.config.PauseBefore)) && now.Before(event.Time.Add(nc.config.ResumeAfter)) {
+			return event, true
+		}
+	}
+	return CalendarEvent{}, false
+}
+
+// UpcomingEvents returns relevant events that have not yet ended their blackout
+func (nc *NewsCalendar) UpcomingEvents(now time.Time) []CalendarEvent {
+	nc.mu.RLock()
+	defer nc.mu.RUnlock()
+
+	var upcoming []CalendarEvent
+	for _, event := range nc.events {
+		if now.Before(event.Time.Add(nc.config.ResumeAfter)) {
+			upcoming = append(upcoming, event)
+		}
+	}
+	return upcoming
+}
+
+// GetCalendarStats returns news calendar statistics
+func (nc *NewsCalendar) GetCalendarStats() map[string]interface{} {
+	now := time.Now()
+	active, blackout := nc.ActiveEvent(now)
+	upcoming := nc.UpcomingEvents(now)
+
+	nc.mu.RLock()
+	defer nc.mu.RUnlock()
+
+	stats := map[string]interface{}{
+		"url":             nc.config.URL,
+		"events":          len(nc.events),
+		"upcoming_events": len(upcoming),
+		"blackout":        blackout,
+		"fetches":         nc.fetches,
+		"fetch_errors":    nc.fetchErrors,
+		"last_fetch":      nc.lastFetch,
+		"last_error":      nc.lastError,
+	}
+	if blackout {
+		stats["active_event"] = active
+	}
+	return stats
+}
@@ -0,0 +1,227 @@
+package bot
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmailConfig holds configuration for sending notifications over SMTP
+type EmailConfig struct {
+	SMTPHost string        `json:"smtp_host"` // Mail server ("" disables email)
+	SMTPPort int           `json:"smtp_port"` // 587 (STARTTLS) or 465 (implicit TLS)
+	Username string        `json:"username"`  // "" sends without authentication
+	Password string        `json:"-"`
+	From     string        `json:"from"`
+	To       []string      `json:"to"`
+	Timeout  time.Duration `json:"timeout"` // Connection and delivery timeout (30s)
+}
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// EmailMessage is an HTML email with an optional plain-text alternative and attachments
+type EmailMessage struct {
+	Subject     string
+	HTML        string
+	Text        string // Plain-text alternative ("" sends HTML only)
+	Attachments []EmailAttachment
+}
+
+// EmailNotifier sends notification emails through an SMTP server
+type EmailNotifier struct {
+	config EmailConfig
+
+	// Statistics
+	sent      int64
+	failed    int64
+	lastError string
+
+	mu sync.Mutex
+}
+
+// NewEmailNotifier creates an email notifier
+func NewEmailNotifier(config EmailConfig) (*EmailNotifier, error) {
+	if config.SMTPHost == "" || config.From == "" || len(config.To) == 0 {
+		return nil, fmt.Errorf("email requires an SMTP host, a sender and at least one recipient")
+	}
+	if config.SMTPPort == 0 {
+		config.SMTPPort = 587 // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second // default
+	}
+	return &EmailNotifier{config: config}, nil
+}
+
+// Send delivers a message to the configured recipients
+func (n *EmailNotifier) Send(message EmailMessage) error {
+	err := n.send(message)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err != nil {
+		n.failed++
+		n.lastError = err.Error()
+		return err
+	}
+	n.sent++
+	n.lastError = ""
+	return nil
+}
+
+// send performs the SMTP exchange
+func (n *EmailNotifier) send(message EmailMessage) error {
+	body, err := n.compose(message, time.Now())
+	if err != nil {
+		return err
+	}
+
+	address := net.JoinHostPort(n.config.SMTPHost, strconv.Itoa(n.config.SMTPPort))
+	dialer := &net.Dialer{Timeout: n.config.Timeout}
+	tlsConfig := &tls.Config{ServerName: n.config.SMTPHost}
+
+	var conn net.Conn
+	if n.config.SMTPPort == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	conn.SetDeadline(time.Now().Add(n.config.Timeout))
+
+	client, err := smtp.NewClient(conn, n.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && n.config.SMTPPort != 465 {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.SMTPHost)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.config.From); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	for _, recipient := range n.config.To {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := writer.Write(body); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// compose renders the message as a MIME multipart/mixed email
+func (n *EmailNotifier) compose(message EmailMessage, now time.Time) ([]byte, error) {
+	mixed, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+	alternative, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mixed)
+
+	fmt.Fprintf(&b, "--%s\r\n", mixed)
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", alternative)
+	if message.Text != "" {
+		writeMIMEPart(&b, alternative, "text/plain; charset=utf-8", "", []byte(message.Text))
+	}
+	writeMIMEPart(&b, alternative, "text/html; charset=utf-8", "", []byte(message.HTML))
+	fmt.Fprintf(&b, "--%s--\r\n", alternative)
+
+	for _, attachment := range message.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name})
+		writeMIMEPart(&b, mixed, contentType, disposition, attachment.Data)
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", mixed)
+	return b.Bytes(), nil
+}
+
+// writeMIMEPart writes one base64-encoded part
+func writeMIMEPart(b *bytes.Buffer, boundary, contentType, disposition string, data []byte) {
+	fmt.Fprintf(b, "--%s\r\n", boundary)
+	fmt.Fprintf(b, "Content-Type: %s\r\n", contentType)
+	if disposition != "" {
+		fmt.Fprintf(b, "Content-Disposition: %s\r\n", disposition)
+	}
+	fmt.Fprintf(b, "Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+}
+
+// newBoundary returns a random MIME boundary
+func newBoundary() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to create MIME boundary: %w", err)
+	}
+	return "aibot-" + hex.EncodeToString(buf), nil
+}
+
+// GetNotifierStats returns email delivery statistics
+func (n *EmailNotifier) GetNotifierStats() map[string]interface{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return map[string]interface{}{
+		"smtp_host":  n.config.SMTPHost,
+		"recipients": len(n.config.To),
+		"sent":       n.sent,
+		"failed":     n.failed,
+		"last_error": n.lastError,
+	}
+}
@@ -2,16 +2,25 @@ package bot
 
 import (
 	"aibot/internal/data"
+	"aibot/internal/execution"
 	"aibot/internal/indicators"
+	"aibot/internal/journal"
+	"aibot/internal/ledger"
+	"aibot/internal/logging"
 	"aibot/internal/strategy"
+	"aibot/internal/tracing"
 	"aibot/internal/types"
 	"aibot/pkg/stream"
 	"aibot/pkg/trading"
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"math"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,25 +35,37 @@ const (
 	ModeIdle      TradingMode = "idle"       // Idle/waiting mode
 )
 
+// CandleTimeframes are the timeframes candles are aggregated in; strategy timeframes must be one of them
+var CandleTimeframes = []data.CandleTimeframe{data.Timeframe1s, data.Timeframe3s, data.Timeframe15s}
+
+// droppedFillCheckInterval is how often the fill worker checks the executor for dropped order updates
+const droppedFillCheckInterval = time.Second
+
 // BotState represents the current state of the trading bot
 type BotState struct {
 	Mode               TradingMode    `json:"mode"`
 	IsActive           bool           `json:"is_active"`
 	CurrentSymbol      string         `json:"current_symbol"`
+	Symbols            []string       `json:"symbols"`     // Streamed symbols; only the current symbol is traded
 	GridBounds         strategy.GridBounds `json:"grid_bounds,omitempty"`
 	BreakoutInfo       *BreakoutInfo `json:"breakout_info,omitempty"`
 	LastUpdateTime     time.Time      `json:"last_update_time"`
+	ModeSince          time.Time      `json:"mode_since"`  // When the current mode was entered
 	SessionStart       time.Time      `json:"session_start"`
 	TradeCount         int            `json:"trade_count"`
 	SuccessfulTrades   int            `json:"successful_trades"`
 	TotalPnL           float64        `json:"total_pnl"`
 	MaxDrawdown        float64        `json:"max_drawdown"`
 	CurrentDrawdown    float64        `json:"current_drawdown"`
+	Liquidations       []strategy.LiquidationEstimate `json:"liquidations,omitempty"` // Estimated liquidation price per open position
+	ModeLock           *ModeLock      `json:"mode_lock,omitempty"` // Operator lock suppressing mode transitions
 }
 
 // BreakoutInfo contains information about current breakout handling
 type BreakoutInfo struct {
+	ID                 string                  `json:"id"`
 	BreakoutType       strategy.BreakoutType    `json:"breakout_type"`
+	Confidence         float64                 `json:"confidence"`
 	BreakoutTime       time.Time               `json:"breakout_time"`
 	EntryPrice         float64                 `json:"entry_price"`
 	ConfirmationCandles int                    `json:"confirmation_candles"`
@@ -52,6 +73,18 @@ type BreakoutInfo struct {
 	FalseBreakoutDetected bool                 `json:"false_breakout_detected"`
 	RecoveryAction     string                  `json:"recovery_action"`
 	StabilityWaitStart *time.Time              `json:"stability_wait_start,omitempty"`
+
+	Concurrent         bool                    `json:"concurrent,omitempty"` // Opened alongside a running grid
+
+	// Position lifecycle
+	PositionType       types.PositionType      `json:"position_type,omitempty"`
+	TargetQuantity     float64                 `json:"target_quantity"`      // Full size across both entry tiers
+	EntryTiers         int                     `json:"entry_tiers"`          // Entry tiers filled (0-2)
+	EntryDeferred      bool                    `json:"entry_deferred,omitempty"` // Second tier waits for liquidity
+	StopLoss           float64                 `json:"stop_loss"`
+	TakeProfit         float64                 `json:"take_profit"`
+	LastConfirmationCandle time.Time           `json:"last_confirmation_candle"`
+	OutcomeRecorded    bool                    `json:"outcome_recorded,omitempty"` // Counted in the breakout history
 }
 
 // Orchestrator manages the entire trading bot coordination
@@ -65,15 +98,83 @@ type Orchestrator struct {
 	// Strategy components
 	gridSetup        *strategy.GridSetup
 	gridCalculator   *strategy.GridCalculator
+	gridEngine       *strategy.GridEngine
+	gridMu           sync.Mutex // Serializes grid rebuilds with grid order placement; guards gridOverride
+	gridOverride     *GridUpdate // Operator overrides of the calculated grid layout, nil when none
 	breakoutDetector *strategy.BreakoutDetector
 	falseBreakoutDetector *strategy.FalseBreakoutDetector
 	positionManager  *strategy.PositionManager
 	stabilityDetector *strategy.PriceStabilityDetector
 	riskManager      *strategy.RiskManager
+	equityFilter     *strategy.EquityCurveFilter
+	feeGovernor      *strategy.FeeGovernor // Throttles grid turnover while fees run ahead of the budget
+	liquidityGuard   *strategy.LiquidityGuard // Defers orders while the book is too thin
+	volatilityBreaker *strategy.VolatilityBreaker // Suspends orders and widens the grid during 1s volatility spikes
+	orderBudget      *strategy.OrderBudget    // Caps simultaneously open orders per symbol and overall
+	signalDedup      *SignalDeduplicator      // Drops signals repeated within their cooldown
+	dailyLoss        *strategy.DailyLossGuard // Enforces MaxDailyLoss per accounting day
+	pnlCheckpoints   *journal.CheckpointStore // nil when daily PnL checkpoints are disabled
+	lastPnLCheckpoint time.Time // Only touched by the risk worker
+	hwmCheckpoints   *journal.CheckpointStore // nil when the equity high-water mark is not persisted
+	hwmAccounts      map[string]strategy.EquityHighWaterMark // Checkpointed high-water marks by account
+	savedHWM         strategy.EquityHighWaterMark // Last checkpointed mark of this account; only touched by the risk worker
+	dailyLossHalted  bool      // Halt seen by the last daily loss check; only touched by the risk worker
+	tradeJournal     *journal.TradeJournal
+	intents          *journal.IntentQueue // Persists managed orders until the exchange acknowledges them
+	performanceDB    *journal.PerformanceDB // nil when session results are not recorded
+	breakoutHistory  *journal.BreakoutHistory // nil when breakout outcomes are not persisted
+	accountSnapshots *journal.SnapshotLog // nil when account snapshots are not journaled
+	lastAccountSnapshot time.Time // Only touched by the risk worker
+	execution        *execution.Engine // Works closes with the algorithm configured for their intent; set on start
+	ledger           *ledger.Ledger
+	webhooks         *WebhookDispatcher // nil when no webhook URL is configured
+	annotator        *GrafanaAnnotator // nil when no Grafana URL is configured
+	eventLog         *EventLog // nil when no event output is configured
+	newsCalendar     *NewsCalendar // nil when no calendar URL is configured
+	exchangeStatus   *ExchangeStatusMonitor // nil when no exchange status URL is configured
+	rateLimits       *trading.RateLimitGuard // Ban window of the last 418/429 answer; REST calls pause while it is open
+	rateLimitResume  *time.Timer // Queues rate_limit_resume when the ban ends; guarded by rateLimitMu
+	rateLimitMu      sync.Mutex
+	fundingCalendar  *strategy.FundingCalendar // nil on spot markets
+	fundingFeed      *FundingFeed // nil on spot markets or when no funding URL is configured
+	basisMonitor     *strategy.BasisMonitor // nil on spot markets or when no spot price URL is configured
+	spotPriceFeed    *SpotPriceFeed // Spot prices the basis is measured against; set with basisMonitor
+	profitSweeper    *ProfitSweeper // nil when profit sweeping is disabled; set on start
+	dailyReporter    *DailyReporter // nil when the end-of-day report is disabled
+	alertRules       *AlertRuleEngine // nil when no alert rules are configured
+	alertEmail       *EmailNotifier // nil unless alert rule firings are emailed
+	modeWatchdog     *ModeWatchdog
+	recoveryEscalation *RecoveryEscalation // Time-boxes recovery mode
+	benchmark        *journal.BenchmarkTracker // Session equity against buy-and-hold and DCA of the starting symbol
+	controlServer    *ControlServer // nil when no control address is configured
+	healthServer     *HealthServer // nil when no health probe address is configured
+	active           atomic.Bool // Mirrors state.IsActive for health probes, which must not wait on mu
+	health           *HealthMonitor
+	lastTickAt       atomic.Int64 // Unix nanoseconds when the last tick was received
+	lastTickLag      atomic.Int64 // Receive time minus event time of the last tick
+	staleFilter      *stream.StaleDataFilter
+	candlePath       *data.CandlePath // nil when streamed candles do not drive the simulated executor
+	tickSampler      *logging.Sampler // Thins out per-tick debug logs
+	logLevels        *logging.Levels // nil logs every component at info
+	tracer           *tracing.Tracer // nil when tracing is disabled
+	dataGap          bool // Last observed gap state of the active symbol; only touched by the data worker
+	liquidationLevels map[string]string // Position key -> alert level last raised near liquidation; only touched by the risk worker
+	marginCall       bool // Margin call state last reported by the executor; only touched by the risk worker
+	capitalAllocator *strategy.CapitalAllocator // nil when capital is not split between strategies
+	currency         *strategy.CurrencyConverter // Converts quote and settlement assets into the accounting currency
+	positionMu       sync.Mutex // Guards positionManager, managedOrders, expectedPrices and exchangeExits
+	managedOrders    map[string]bool // Client order IDs already booked in positionManager
+	exchangeExits    map[string][]exchangeExit // Position key -> stop loss and take profit resting on the exchange
+	expectedPrices   map[string]float64 // Client order ID -> market price when a market order was sent, for slippage
+	resyncedDrops    int64 // Dropped order updates already resynced; only touched by the fill worker
+	restored         bool  // A snapshot was restored; the executor's account is adopted on start
+	deferredCancels  []string // Grid orders and exchange exits to cancel once the rate limit ban ends; guarded by rateLimitMu
+	deferredRecovery atomic.Bool // Order intent recovery waits for the rate limit ban to end
+	orderSeq         int64
 
 	// Configuration
 	config           *BotConfig
-	symbols          []string
+	subscriptions    *stream.SubscriptionSet // Streamed symbols: the active symbol and watched ones
 	activeSymbol     string
 
 	// State management
@@ -81,14 +182,25 @@ type Orchestrator struct {
 	mu               sync.RWMutex
 	modeTransitions  map[TradingMode][]TradingMode // Allowed mode transitions
 
-	// Event channels
-	dataChan         chan DataUpdate
-	signalChan       chan TradingSignal
-	riskChan         chan RiskAlert
+	// Events
+	dataQueue        *DataQueue    // Market data waiting for the data processing worker
+	events           *EventBus     // Signals, risk alerts, mode changes, order updates and processed data
+	signalEvents     *Subscription // Signal worker's subscription
+	riskEvents       *Subscription // Risk worker's subscription
 	controlChan      chan ControlCommand
 
 	// Performance tracking
 	performance      PerformanceMetrics
+	modePnL          map[TradingMode]ModePnL
+	modeHistory      []ModeTransition
+	signalHistory    []SignalEvent
+	riskAlerts       []RiskAlert
+	calibration      *strategy.CalibrationTracker // Joins breakout confidences with trade results
+	realizedEquity   float64 // Initial balance plus realized PnL net of fees
+	equityPeak       float64
+	tradeReturns     int64   // Fills that realized PnL, for the per-trade Sharpe ratio
+	tradeReturnSum   float64 // Sum of their net returns on the initial balance
+	tradeReturnSumSq float64
 
 	// Context and shutdown
 	ctx              context.Context
@@ -101,12 +213,13 @@ type DataUpdate struct {
 	Symbol   string
 	Ticker   *types.Ticker
 	OHLCV    *types.OHLCV
+	AggTrade *types.AggTrade
 	Time     time.Time
 }
 
 // TradingSignal represents a trading signal from any strategy component
 type TradingSignal struct {
-	Type         string      `json:"type"`         // "grid_setup", "breakout", "false_breakout", "stability"
+	Type         string      `json:"type"`         // "grid_setup", "breakout", "false_breakout", "stability", "news_pause", "news_resume", "mode_timeout"
 	Symbol       string      `json:"symbol"`
 	Action       string      `json:"action"`       // "buy", "sell", "close", "setup_grid"
 	Price        float64     `json:"price"`
@@ -115,23 +228,28 @@ type TradingSignal struct {
 	Reason       string      `json:"reason"`
 	Data         interface{} `json:"data,omitempty"`
 	Timestamp    time.Time   `json:"timestamp"`
+
+	Context      context.Context `json:"-"` // Carries the trace of the tick that raised the signal
 }
 
 // RiskAlert represents a risk management alert
 type RiskAlert struct {
 	Level       string    `json:"level"`       // "info", "warning", "critical"
-	Type        string    `json:"type"`        // "margin", "drawdown", "concentration", "correlation"
+	Type        string    `json:"type"`        // "margin", "drawdown", "concentration", "correlation", "liquidation"
 	Message     string    `json:"message"`
 	Symbol      string    `json:"symbol,omitempty"`
 	Value       float64   `json:"value,omitempty"`
 	Threshold   float64   `json:"threshold,omitempty"`
+	Action      string    `json:"action,omitempty"` // De-risking step to execute: "reduce_size", "halt_entries", "flatten", "normal"
 	Timestamp   time.Time `json:"timestamp"`
 }
 
 // ControlCommand represents a control command to the orchestrator
 type ControlCommand struct {
-	Type    string      `json:"type"`    // "start", "stop", "pause", "resume", "switch_mode"
-	Payload interface{} `json:"payload,omitempty"`
+	Type    string         `json:"type"`    // "stop", "pause", "resume", "switch_mode", "close_all", "update_grid", "set_symbol", "subscribe", "unsubscribe", "update_risk_limit", "close_position", "place_order", "modify_order", "cancel_order"
+	Payload ControlPayload `json:"payload,omitempty"` // Typed parameters, e.g. SwitchModeParams for switch_mode
+	Reply   chan error  `json:"-"` // Receives the result once processed (optional)
+	Order   chan *types.Order `json:"-"` // Receives the order of an order command before Reply (optional, buffered)
 }
 
 // PerformanceMetrics tracks bot performance
@@ -156,91 +274,453 @@ type BotConfig struct {
 	InitialBalance      float64  `json:"initial_balance"`
 	MaxSymbols          int      `json:"max_symbols"`
 	DefaultSymbol       string   `json:"default_symbol"`
+	MarketType          types.MarketType `json:"market_type"` // "futures", "spot"
+
+	// Multi-strategy execution
+	ConcurrentStrategies bool               `json:"concurrent_strategies"` // Run breakout trend-follow alongside the grid
+	CapitalAllocation    map[string]float64 `json:"capital_allocation"`    // Bucket ("grid", "breakout") -> fraction of capital
 
 	// Strategy parameters
+	NamedIndicators     []string                   `json:"named_indicators"` // Extra indicator instances, e.g. "rsi_7", "ema_50"
+	MinHistoryCandles   int                        `json:"min_history_candles"` // Candles before strategies are evaluated, on top of indicator warm-ups (50)
 	GridSetupConfig     strategy.GridSetupConfig   `json:"grid_setup_config"`
+	GridEngineConfig    strategy.GridEngineConfig  `json:"grid_engine_config"`
 	BreakoutConfig      strategy.BreakoutConfig    `json:"breakout_config"`
 	FalseBreakoutConfig strategy.FalseBreakoutConfig `json:"false_breakout_config"`
+	FalseBreakoutVolumeLookback int              `json:"false_breakout_volume_lookback"` // 1s candles averaged for volume
+	DivergenceConfig    indicators.DivergenceConfig `json:"divergence_config"` // Price/OBV and price/RSI divergence fed to false breakout detection
+	GapConfig           data.GapConfig             `json:"gap_config"` // Feed stall handling in the candle aggregator
+	EventTimeConfig     data.EventTimeConfig       `json:"event_time_config"` // Candles bucketed by exchange timestamp with allowed lateness
 	StabilityConfig     strategy.StabilityConfig   `json:"stability_config"`
 	RiskManagerConfig   strategy.RiskManagerConfig `json:"risk_manager_config"`
+	EquityCurveConfig   strategy.EquityCurveConfig `json:"equity_curve_config"`
+	FeeGovernorConfig   strategy.FeeGovernorConfig `json:"fee_governor_config"`
+	LiquidityGuardConfig strategy.LiquidityGuardConfig `json:"liquidity_guard_config"` // Spread and depth checked before placing orders
+	VolatilityBreakerConfig strategy.VolatilityBreakerConfig `json:"volatility_breaker_config"` // 1s volatility circuit breaker
+	OrderBudgetConfig   strategy.OrderBudgetConfig `json:"order_budget_config"`   // Open order caps, mirroring the exchange's
+	PositionManagerConfig strategy.PositionManagerConfig `json:"position_manager_config"`
+	JournalConfig       journal.JournalConfig      `json:"journal_config"`
+	IntentQueueConfig   journal.IntentQueueConfig  `json:"intent_queue_config"`
+	ExecutionConfig     execution.Config           `json:"execution_config"`
+	SessionReportDir    string                     `json:"session_report_dir"` // "" disables session reports
+	PerformanceDBConfig journal.PerformanceDBConfig `json:"performance_db_config"` // Session results for the configuration leaderboard
+	BenchmarkConfig     journal.BenchmarkConfig    `json:"benchmark_config"`       // Buy-and-hold and DCA comparison in the session report
+	BreakoutHistoryConfig journal.BreakoutHistoryConfig `json:"breakout_history_config"` // Resolved breakouts the success rates learn from
+	SessionConfig       journal.ConfigSummary      `json:"session_config"`         // Configuration recorded with the session result
+	AccountSnapshotConfig journal.SnapshotLogConfig `json:"account_snapshot_config"` // Periodic account snapshots ("" directory disables them)
+	AccountSnapshotInterval time.Duration       `json:"account_snapshot_interval"` // 5m
+	WebhookConfig       WebhookConfig              `json:"webhook_config"`
+	GrafanaConfig       GrafanaConfig              `json:"grafana_config"`
+	EventLogConfig      EventLogConfig             `json:"event_log_config"` // Machine-readable JSON lines event stream ("" output disables)
+	NewsCalendarConfig  NewsCalendarConfig         `json:"news_calendar_config"`
+	ExchangeStatusConfig ExchangeStatusConfig      `json:"exchange_status_config"`
+	FundingConfig       strategy.FundingConfig     `json:"funding_config"`      // Funding and settlement calendar of futures
+	FundingFeedConfig   FundingFeedConfig          `json:"funding_feed_config"` // Funding rates polled from the exchange
+	BasisConfig         strategy.BasisConfig       `json:"basis_config"`        // Spot-futures basis thresholds
+	SpotPriceFeedConfig SpotPriceFeedConfig        `json:"spot_price_feed_config"` // Spot prices polled for the basis
+	ProfitSweepEnabled  bool                       `json:"profit_sweep_enabled"`
+	ProfitSweepConfig   ProfitSweepConfig          `json:"profit_sweep_config"` // Transfers of profit above the working capital
+	DailyReportEnabled  bool                       `json:"daily_report_enabled"`
+	DailyReportConfig   DailyReportConfig          `json:"daily_report_config"` // End-of-day performance email
+	AlertRulesConfig    AlertRulesConfig           `json:"alert_rules_config"`  // User-defined alert conditions
+	EmailConfig         EmailConfig                `json:"email_config"`
+	ModeWatchdogConfig  ModeWatchdogConfig         `json:"mode_watchdog_config"` // Per-mode time limits and fallbacks
+	RecoveryEscalationConfig RecoveryEscalationConfig `json:"recovery_escalation_config"` // Steps of a recovery that runs too long
+	SignalDedupConfig   SignalDedupConfig          `json:"signal_dedup_config"`  // Cooldowns of repeated signals
+	HealthConfig        HealthConfig               `json:"health_config"`
+	DataQueueConfig     DataQueueConfig            `json:"data_queue_config"` // Backpressure between stream receiver and processing
+	CandlePathConfig    data.CandlePathConfig      `json:"candle_path_config"` // Intra-candle path of replayed candles ("" model disables)
+	TradingStart        time.Time                  `json:"trading_start"`      // Replayed data before it only warms up candles and indicators (zero: no warm-up)
+	ControlConfig       ControlServerConfig        `json:"control_config"`
+	HealthServerConfig  HealthServerConfig         `json:"health_server_config"` // HTTP liveness and readiness probes ("" address disables)
+	TracingConfig       tracing.TracerConfig       `json:"tracing_config"`
 
 	// Stream and trading config
 	StreamConfig        stream.StreamConfig        `json:"stream_config"`
 	TradingConfig       trading.ExecutionConfig    `json:"trading_config"`
 
 	// Operational parameters
+	BaseInterval        time.Duration `json:"base_interval"` // Feed update interval candles are built from (300ms)
 	UpdateInterval      time.Duration `json:"update_interval"`
 	HealthCheckInterval time.Duration `json:"health_check_interval"`
 
 	// Safety parameters
 	MaxDailyLoss        float64 `json:"max_daily_loss"`
+	PnLCheckpointPath   string        `json:"pnl_checkpoint_path"`     // Daily PnL checkpoint file ("" keeps it in memory only)
+	PnLCheckpointInterval time.Duration `json:"pnl_checkpoint_interval"` // 1m
+	AccountingLocation  *time.Location `json:"-"`                       // Timezone daily loss, daily reports and ledger days roll over in (UTC)
+	CurrencyConfig      strategy.CurrencyConfig `json:"currency_config"`   // Accounting currency PnL and portfolio risk are aggregated in
+	RateSymbols         []string      `json:"rate_symbols"`            // Streamed for live conversion rates, e.g. BTCUSDT for BTC-quoted pairs
+	AccountID           string        `json:"account_id"`              // Account the equity high-water mark is kept for (default)
+	HighWaterMarkPath   string        `json:"high_water_mark_path"`    // Equity high-water mark checkpoint file ("" resets the peak every run)
 	MaxConsecutiveLosses int     `json:"max_consecutive_losses"`
+
+	// Order placement retries (retried orders keep their client order ID)
+	OrderRetryAttempts  int           `json:"order_retry_attempts"`
+	OrderRetryDelay     time.Duration `json:"order_retry_delay"`
+
+	// Logging
+	TickLogSampleRate   int           `json:"tick_log_sample_rate"` // Log one in N ticks at stream debug level
+	LogLevels           *logging.Levels `json:"-"`                  // Runtime log levels of the instance (nil logs everything at info)
 }
 
 // NewOrchestrator creates a new trading bot orchestrator
 func NewOrchestrator(config *BotConfig) (*Orchestrator, error) {
+	indicatorNames := append([]string{
+		config.BreakoutConfig.RSIIndicator,
+		config.BreakoutConfig.ATRIndicator,
+		config.BreakoutConfig.VolumeIndicator,
+		config.StabilityConfig.VolatilityIndicator,
+	}, config.NamedIndicators...)
+	for _, name := range indicatorNames {
+		if name == "" {
+			continue
+		}
+		if _, err := indicators.ParseIndicatorName(name); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.ModeWatchdogConfig.Validate(); err != nil {
+		return nil, err
+	}
+	if err := config.RecoveryEscalationConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
+	if config.FalseBreakoutVolumeLookback == 0 {
+		config.FalseBreakoutVolumeLookback = 5 // default
+	}
+	if config.MinHistoryCandles == 0 {
+		config.MinHistoryCandles = 50 // default
+	}
+	if config.MarketType == "" {
+		config.MarketType = types.MarketTypeFutures // default
+	}
+	if config.OrderRetryAttempts == 0 {
+		config.OrderRetryAttempts = 1 // default: no retries
+	}
+	if config.TickLogSampleRate == 0 {
+		config.TickLogSampleRate = 100 // default
+	}
+	if config.BaseInterval == 0 {
+		config.BaseInterval = data.DefaultBaseInterval // default
+	}
+	if config.PnLCheckpointInterval == 0 {
+		config.PnLCheckpointInterval = time.Minute // default
+	}
+	if config.AccountSnapshotInterval == 0 {
+		config.AccountSnapshotInterval = 5 * time.Minute // default
+	}
+	if config.AccountingLocation == nil {
+		config.AccountingLocation = time.UTC // default
+	}
+	config.DailyReportConfig.Location = config.AccountingLocation
+	if config.AccountID == "" {
+		config.AccountID = "default" // default
+	}
+
 	// Create core components
 	candleAggregator := data.NewCandleAggregator(data.AggregatorConfig{
-		BaseInterval: 300 * time.Millisecond,
+		BaseInterval: config.BaseInterval,
 		MaxHistory:   100,
-		Timeframes:   []data.CandleTimeframe{data.Timeframe1s, data.Timeframe3s, data.Timeframe15s},
+		Timeframes:   CandleTimeframes,
 		Symbols:      []string{config.DefaultSymbol},
+		Gaps:         config.GapConfig,
+		EventTime:    config.EventTimeConfig,
 	})
+	// Out-of-order ticks the candles still accept must get past the stale data filter
+	if eventTime := candleAggregator.EventTime(); eventTime.Enabled && config.StreamConfig.StaleFilter.Tolerance < eventTime.AllowedLateness {
+		config.StreamConfig.StaleFilter.Tolerance = eventTime.AllowedLateness
+	}
 
 	technicalAnalyzer := indicators.NewTechnicalAnalyzer(indicators.AnalyzerConfig{
 		MaxHistoryCandles: 100,
+		NamedIndicators:   config.NamedIndicators,
+		MinCandles:        config.MinHistoryCandles,
+		Divergence:        config.DivergenceConfig,
 	})
 
 	// Create strategy components
 	gridSetup := strategy.NewGridSetup(candleAggregator, technicalAnalyzer, config.GridSetupConfig)
 	gridCalculator := strategy.NewGridCalculator()
+	if config.GridEngineConfig.SpacingMode != "" {
+		gridCalculator.SpacingMode = config.GridEngineConfig.SpacingMode
+	}
+	config.GridEngineConfig.MarketType = config.MarketType
+	gridEngine := strategy.NewGridEngine(config.GridEngineConfig)
 	breakoutDetector := strategy.NewBreakoutDetector(
 		config.BreakoutConfig,
 		candleAggregator,
 		technicalAnalyzer,
 	)
 	falseBreakoutDetector := strategy.NewFalseBreakoutDetector(config.FalseBreakoutConfig)
+	falseBreakoutDetector.SetDivergenceSource(technicalAnalyzer)
 	stabilityDetector := strategy.NewPriceStabilityDetector(
 		config.StabilityConfig,
 		technicalAnalyzer,
 		candleAggregator,
 	)
 	riskManager := strategy.NewRiskManager(config.RiskManagerConfig, config.InitialBalance)
+	if config.RiskManagerConfig.PolicyFile != "" {
+		if err := riskManager.LoadPolicy(config.RiskManagerConfig.PolicyFile); err != nil {
+			cancel()
+			return nil, err
+		}
+		log.Printf("📜 Risk policy loaded from %s", config.RiskManagerConfig.PolicyFile)
+	}
+	equityFilter := strategy.NewEquityCurveFilter(config.EquityCurveConfig)
+	positionManager := strategy.NewPositionManager(config.PositionManagerConfig)
+
+	if config.FalseBreakoutConfig.Scorer.Type != "" {
+		scorer, err := strategy.NewFalseBreakoutScorer(config.FalseBreakoutConfig.Scorer)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to create false breakout scorer: %w", err)
+		}
+		falseBreakoutDetector.SetScorer(scorer, config.FalseBreakoutConfig.Scorer.BlendWeight)
+	}
+
+	tradeJournal, err := journal.NewTradeJournal(config.JournalConfig)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create trade journal: %w", err)
+	}
+
+	intents, err := journal.NewIntentQueue(config.IntentQueueConfig)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create order intent queue: %w", err)
+	}
+
+	var candlePath *data.CandlePath
+	if config.CandlePathConfig.Model != "" {
+		candlePath = data.NewCandlePath(config.CandlePathConfig)
+	}
+
+	var performanceDB *journal.PerformanceDB
+	if config.PerformanceDBConfig.Directory != "" {
+		performanceDB, err = journal.NewPerformanceDB(config.PerformanceDBConfig)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	// Breakout success rates continue from every breakout resolved in earlier runs
+	var breakoutHistory *journal.BreakoutHistory
+	if config.BreakoutHistoryConfig.Directory != "" {
+		breakoutHistory, err = journal.NewBreakoutHistory(config.BreakoutHistoryConfig)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if records, err := breakoutHistory.Records(); err != nil {
+			log.Printf("⚠️ Ignoring breakout history: %v", err)
+		} else if len(records) > 0 {
+			for _, record := range records {
+				breakoutDetector.RecordOutcome(record.Symbol, record.WasReal)
+			}
+			log.Printf("📚 Restored %d breakout outcomes", len(records))
+		}
+	}
+
+	var accountSnapshots *journal.SnapshotLog
+	if config.AccountSnapshotConfig.Directory != "" {
+		accountSnapshots, err = journal.NewSnapshotLog(config.AccountSnapshotConfig)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+
+	tracer, err := tracing.NewTracer(config.TracingConfig)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// A restart within the same accounting day continues from the last checkpoint so the daily loss limit holds
+	dailyLoss := strategy.NewDailyLossGuard(config.MaxDailyLoss, config.AccountingLocation)
+	var pnlCheckpoints *journal.CheckpointStore
+	if config.PnLCheckpointPath != "" {
+		pnlCheckpoints, err = journal.NewCheckpointStore(config.PnLCheckpointPath)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		var checkpoint strategy.DailyPnL
+		if found, err := pnlCheckpoints.Load(&checkpoint); err != nil {
+			log.Printf("⚠️ Ignoring daily PnL checkpoint: %v", err)
+		} else if found && dailyLoss.Restore(checkpoint, time.Now()) {
+			log.Printf("📅 Restored daily PnL checkpoint for %s: realized %.2f (halted: %v)", checkpoint.Day, checkpoint.Realized, checkpoint.Halted)
+		}
+	}
+
+	// Drawdown is measured from the account's all-time equity peak, not the peak of this run
+	hwmAccounts := make(map[string]strategy.EquityHighWaterMark)
+	var hwmCheckpoints *journal.CheckpointStore
+	var savedHWM strategy.EquityHighWaterMark
+	if config.HighWaterMarkPath != "" {
+		hwmCheckpoints, err = journal.NewCheckpointStore(config.HighWaterMarkPath)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if _, err := hwmCheckpoints.Load(&hwmAccounts); err != nil {
+			log.Printf("⚠️ Ignoring equity high-water mark checkpoint: %v", err)
+			hwmAccounts = make(map[string]strategy.EquityHighWaterMark)
+		} else if hwm, found := hwmAccounts[config.AccountID]; found {
+			savedHWM = hwm
+			if riskManager.RestoreHighWaterMark(hwm) {
+				log.Printf("📈 Restored equity high-water mark of %s: %.2f (%s)", config.AccountID, hwm.Peak, hwm.PeakAt.Format(time.RFC3339))
+			}
+		}
+	}
 
 	orchestrator := &Orchestrator{
 		candleAggregator:        candleAggregator,
 		technicalAnalyzer:      technicalAnalyzer,
 		gridSetup:              gridSetup,
 		gridCalculator:         gridCalculator,
+		gridEngine:             gridEngine,
 		breakoutDetector:       breakoutDetector,
 		falseBreakoutDetector:  falseBreakoutDetector,
+		positionManager:        positionManager,
 		stabilityDetector:      stabilityDetector,
 		riskManager:            riskManager,
+		equityFilter:           equityFilter,
+		feeGovernor:            strategy.NewFeeGovernor(config.FeeGovernorConfig),
+		liquidityGuard:         strategy.NewLiquidityGuard(config.LiquidityGuardConfig),
+		volatilityBreaker:      strategy.NewVolatilityBreaker(config.VolatilityBreakerConfig),
+		orderBudget:            strategy.NewOrderBudget(config.OrderBudgetConfig),
+		signalDedup:            NewSignalDeduplicator(config.SignalDedupConfig),
+		rateLimits:             trading.NewRateLimitGuard(),
+		dailyLoss:              dailyLoss,
+		pnlCheckpoints:         pnlCheckpoints,
+		hwmCheckpoints:         hwmCheckpoints,
+		hwmAccounts:            hwmAccounts,
+		savedHWM:               savedHWM,
+		tradeJournal:           tradeJournal,
+		intents:                intents,
+		performanceDB:          performanceDB,
+		breakoutHistory:        breakoutHistory,
+		accountSnapshots:       accountSnapshots,
+		ledger:                 ledger.NewLedger(ledger.LedgerConfig{InitialBalance: config.InitialBalance, Location: config.AccountingLocation}),
+		staleFilter:            stream.NewStaleDataFilter(config.StreamConfig.StaleFilter),
+		candlePath:             candlePath,
+		tickSampler:            logging.NewSampler(config.TickLogSampleRate),
+		logLevels:              config.LogLevels,
+		tracer:                 tracer,
+		managedOrders:          make(map[string]bool),
+		exchangeExits:          make(map[string][]exchangeExit),
+		expectedPrices:         make(map[string]float64),
 		config:                 config,
-		symbols:                []string{config.DefaultSymbol},
+		subscriptions:          stream.NewSubscriptionSet([]string{config.DefaultSymbol}),
 		activeSymbol:           config.DefaultSymbol,
 		state: BotState{
 			Mode:         ModeIdle,
 			IsActive:     false,
+			ModeSince:    time.Now(),
 			SessionStart: time.Now(),
 		},
 		modeTransitions: map[TradingMode][]TradingMode{
 			ModeIdle:      {ModeGrid},
-			ModeGrid:      {ModeBreakout, ModeRecovery},
-			ModeBreakout:  {ModeStability, ModeRecovery, ModeGrid},
-			ModeStability: {ModeGrid, ModeBreakout},
-			ModeRecovery:  {ModeGrid},
+			ModeGrid:      {ModeBreakout, ModeRecovery, ModeIdle},
+			ModeBreakout:  {ModeStability, ModeRecovery, ModeGrid, ModeIdle},
+			ModeStability: {ModeGrid, ModeBreakout, ModeIdle},
+			ModeRecovery:  {ModeGrid, ModeIdle},
 		},
-		dataChan:    make(chan DataUpdate, 100),
-		signalChan:  make(chan TradingSignal, 50),
-		riskChan:    make(chan RiskAlert, 50),
+		modePnL:        make(map[TradingMode]ModePnL),
+		calibration:    strategy.NewCalibrationTracker(),
+		realizedEquity: config.InitialBalance,
+		equityPeak:     config.InitialBalance,
+		dataQueue:   NewDataQueue(config.DataQueueConfig),
+		events:      NewEventBus(),
 		controlChan: make(chan ControlCommand, 10),
+		health:      NewHealthMonitor(config.HealthConfig),
 		ctx:         ctx,
 		cancel:      cancel,
 	}
 
+	// The workers must see every signal and alert, so publishers wait for them like on a channel
+	orchestrator.signalEvents = orchestrator.events.Subscribe("signal_worker", SubscribeOptions{Buffer: 50, Blocking: true}, TopicSignal)
+	orchestrator.riskEvents = orchestrator.events.Subscribe("risk_worker", SubscribeOptions{Buffer: 50, Blocking: true}, TopicRiskAlert)
+
+	if config.WebhookConfig.URL != "" {
+		orchestrator.webhooks = NewWebhookDispatcher(config.WebhookConfig)
+	}
+	if config.DailyReportEnabled {
+		notifier, err := NewEmailNotifier(config.EmailConfig)
+		if err != nil {
+			orchestrator.closeOnError()
+			return nil, fmt.Errorf("failed to create daily report: %w", err)
+		}
+		orchestrator.dailyReporter, err = NewDailyReporter(config.DailyReportConfig, notifier)
+		if err != nil {
+			orchestrator.closeOnError()
+			return nil, fmt.Errorf("failed to create daily report: %w", err)
+		}
+	}
+	if len(config.AlertRulesConfig.Rules) > 0 {
+		orchestrator.alertRules, err = NewAlertRuleEngine(config.AlertRulesConfig)
+		if err != nil {
+			orchestrator.closeOnError()
+			return nil, err
+		}
+		if config.AlertRulesConfig.Email {
+			orchestrator.alertEmail, err = NewEmailNotifier(config.EmailConfig)
+			if err != nil {
+				orchestrator.closeOnError()
+				return nil, fmt.Errorf("failed to create alert rule email: %w", err)
+			}
+		}
+	}
+	if config.GrafanaConfig.URL != "" {
+		orchestrator.annotator = NewGrafanaAnnotator(config.GrafanaConfig)
+	}
+	if config.EventLogConfig.Output != "" {
+		eventLog, err := NewEventLog(config.EventLogConfig, orchestrator.events, orchestrator.currentMode)
+		if err != nil {
+			orchestrator.closeOnError()
+			return nil, err
+		}
+		orchestrator.eventLog = eventLog
+	}
+	if config.NewsCalendarConfig.URL != "" {
+		orchestrator.newsCalendar = NewNewsCalendar(config.NewsCalendarConfig)
+	}
+	orchestrator.modeWatchdog = NewModeWatchdog(config.ModeWatchdogConfig)
+	orchestrator.recoveryEscalation = NewRecoveryEscalation(config.RecoveryEscalationConfig)
+	orchestrator.benchmark = journal.NewBenchmarkTracker(config.BenchmarkConfig, config.DefaultSymbol, config.InitialBalance)
+	if config.ExchangeStatusConfig.URL != "" {
+		if config.ExchangeStatusConfig.MarketType == "" {
+			config.ExchangeStatusConfig.MarketType = config.MarketType
+		}
+		orchestrator.exchangeStatus = NewExchangeStatusMonitor(config.ExchangeStatusConfig)
+	}
+	if !config.MarketType.IsSpot() {
+		orchestrator.fundingCalendar = strategy.NewFundingCalendar(config.FundingConfig, config.PositionManagerConfig.Contracts)
+		if config.FundingFeedConfig.URL != "" {
+			orchestrator.fundingFeed = NewFundingFeed(config.FundingFeedConfig)
+		}
+		if config.SpotPriceFeedConfig.URL != "" {
+			orchestrator.basisMonitor = strategy.NewBasisMonitor(config.BasisConfig)
+			orchestrator.spotPriceFeed = NewSpotPriceFeed(config.SpotPriceFeedConfig)
+		}
+	}
+	orchestrator.currency = strategy.NewCurrencyConverter(config.CurrencyConfig, candleAggregator.GetLatestPrice)
+	for _, symbol := range orchestrator.subscriptions.Add(config.RateSymbols) {
+		candleAggregator.AddSymbol(symbol, nil)
+	}
+	if len(config.CapitalAllocation) > 0 {
+		orchestrator.capitalAllocator = strategy.NewCapitalAllocator(strategy.CapitalAllocatorConfig{
+			TotalCapital: config.InitialBalance,
+			Allocations:  config.CapitalAllocation,
+		})
+	}
+
 	return orchestrator, nil
 }
 
@@ -255,19 +735,73 @@ func (o *Orchestrator) Start(streamProvider stream.StreamProvider, tradingExecut
 
 	o.streamProvider = streamProvider
 	o.tradingExecutor = tradingExecutor
+	if o.config.ConcurrentStrategies {
+		// Hedge-mode accounts keep each side separate, so only one-way accounts need netting
+		if hedged, ok := tradingExecutor.(trading.HedgePositionProvider); !ok || !hedged.IsHedgeMode() {
+			o.tradingExecutor = trading.NewNettingExecutor(tradingExecutor)
+			log.Printf("🔀 Concurrent strategies enabled: orders are netted per strategy")
+		}
+	}
+	o.execution = execution.NewEngine(o.config.ExecutionConfig, o.tradingExecutor)
+	o.loadSymbolPrecision(o.activeSymbol)
+	if o.config.ProfitSweepEnabled {
+		sweepConfig := o.config.ProfitSweepConfig
+		if sweepConfig.WorkingCapital == 0 {
+			sweepConfig.WorkingCapital = o.config.InitialBalance
+		}
+		if sweepConfig.AccountID == "" {
+			sweepConfig.AccountID = o.config.AccountID
+		}
+		if sweeper, err := NewProfitSweeper(sweepConfig, tradingExecutor); err != nil {
+			log.Printf("⚠️ Profit sweep disabled: %v", err)
+		} else {
+			o.profitSweeper = sweeper
+		}
+	}
+
+	// Probes answer from here on, so liveness holds while positions are recovered and readiness waits for trading
+	if o.config.HealthServerConfig.Address != "" {
+		healthServer := NewHealthServer(o.config.HealthServerConfig, o)
+		if err := healthServer.Start(); err != nil {
+			log.Printf("⚠️ Health probes disabled: %v", err)
+		} else {
+			o.healthServer = healthServer
+		}
+	}
+
+	// Resolve orders left pending by a crash before new signals are processed
+	o.recoverOrderIntents()
+	if o.restored {
+		o.adoptRestoredAccount()
+	}
 
 	// Start data streaming
 	if err := o.startDataStreaming(); err != nil {
+		if o.healthServer != nil {
+			o.healthServer.Close()
+			o.healthServer = nil
+		}
 		return fmt.Errorf("failed to start data streaming: %w", err)
 	}
 
 	// Start orchestrator workers
 	o.startWorkers()
 
+	if o.config.ControlConfig.Address != "" || o.config.ControlConfig.SocketPath != "" {
+		controlServer := NewControlServer(o.config.ControlConfig, o)
+		if err := controlServer.Start(); err != nil {
+			log.Printf("⚠️ Control server disabled: %v", err)
+		} else {
+			o.controlServer = controlServer
+		}
+	}
+
 	// Start in idle mode - will switch to grid after receiving first price data
 	o.state.Mode = ModeIdle
 	o.state.IsActive = true
+	o.active.Store(true)
 	o.state.SessionStart = time.Now()
+	o.state.ModeSince = o.state.SessionStart
 	o.performance.SessionStart = time.Now()
 
 	log.Printf("🚀 Trading bot orchestrator started for symbol: %s (waiting for price data)", o.activeSymbol)
@@ -292,9 +826,16 @@ func (o *Orchestrator) Stop() error {
 
 	// Cancel context first to signal all goroutines to stop
 	o.cancel()
+	o.active.Store(false)
+	o.stopRateLimitResume()
 
-	// Close all positions
-	if err := o.closeAllPositions(); err != nil {
+	if o.controlServer != nil {
+		o.controlServer.Close()
+	}
+
+	// Pull resting grid orders and close all positions
+	o.cancelGridOrders()
+	if err := o.closeAllPositions(types.NewOrderTag("shutdown", "flatten")); err != nil {
 		log.Printf("Error closing positions: %v", err)
 	}
 
@@ -317,22 +858,84 @@ func (o *Orchestrator) Stop() error {
 		log.Println("⚠️ Worker shutdown timeout reached, exiting immediately")
 	}
 
+	if o.webhooks != nil {
+		o.webhooks.Close(3 * time.Second)
+	}
+	if o.annotator != nil {
+		o.annotator.Close(3 * time.Second)
+	}
+	if o.eventLog != nil {
+		o.eventLog.Close(3 * time.Second)
+	}
+	o.savePnLCheckpoint()
+	o.saveHighWaterMark()
+
+	if err := o.tradeJournal.Close(); err != nil {
+		log.Printf("Error closing trade journal: %v", err)
+	}
+
+	if err := o.intents.Close(); err != nil {
+		log.Printf("Error closing order intent log: %v", err)
+	}
+
+	if err := o.tracer.Close(); err != nil {
+		log.Printf("Error closing trace file: %v", err)
+	}
+
+	if o.config.SessionReportDir != "" || o.performanceDB != nil {
+		report := o.buildSessionReport(time.Now())
+		if o.config.SessionReportDir != "" {
+			if path, err := WriteSessionReport(report, o.config.SessionReportDir); err != nil {
+				log.Printf("Error writing session report: %v", err)
+			} else {
+				log.Printf("📝 Session report written to %s", path)
+			}
+		}
+		if o.performanceDB != nil {
+			if err := o.performanceDB.Record(o.sessionResult(report)); err != nil {
+				log.Printf("Error recording session result: %v", err)
+			} else {
+				log.Printf("📝 Session result recorded in %s", o.performanceDB.Path())
+			}
+		}
+	}
+
 	o.state.IsActive = false
+	if o.healthServer != nil {
+		o.healthServer.Close()
+	}
 	log.Println("🛑 Trading bot orchestrator stopped")
 
 	return nil
 }
 
+// closeOnError releases what NewOrchestrator opened before a later component failed to be created: the
+// context, the trade journal, the order intent log and the trace file
+func (o *Orchestrator) closeOnError() {
+	o.cancel()
+	if err := o.tradeJournal.Close(); err != nil {
+		log.Printf("Error closing trade journal: %v", err)
+	}
+	if err := o.intents.Close(); err != nil {
+		log.Printf("Error closing order intent log: %v", err)
+	}
+	if err := o.tracer.Close(); err != nil {
+		log.Printf("Error closing trace file: %v", err)
+	}
+}
+
 // startDataStreaming starts the data streaming and processing
 func (o *Orchestrator) startDataStreaming() error {
 	// Start streaming for symbols
-	if err := o.streamProvider.Start(o.ctx, o.symbols); err != nil {
+	if err := o.streamProvider.Start(o.ctx, o.subscriptions.Symbols()); err != nil {
 		return err
 	}
 
-	// Start data processing worker
+	// Start data receiving and processing workers
 	o.wg.Add(1)
 	go o.dataStreamingWorker()
+	o.wg.Add(1)
+	go o.dataProcessingWorker()
 
 	return nil
 }
@@ -358,14 +961,84 @@ func (o *Orchestrator) startWorkers() {
 	// Control command worker
 	o.wg.Add(1)
 	go o.controlWorker()
+
+	// Order fill worker
+	o.wg.Add(1)
+	go o.fillProcessingWorker()
+
+	// Economic calendar worker
+	if o.newsCalendar != nil {
+		o.wg.Add(1)
+		go o.newsWorker()
+	}
+
+	// Exchange maintenance and symbol halt worker
+	if o.exchangeStatus != nil {
+		o.wg.Add(1)
+		go o.exchangeStatusWorker()
+	}
+
+	// Funding rate worker
+	if o.fundingFeed != nil {
+		o.wg.Add(1)
+		go o.fundingWorker()
+	}
+
+	// Spot-futures basis worker
+	if o.basisMonitor != nil {
+		o.wg.Add(1)
+		go o.basisWorker()
+	}
+
+	// Profit sweep worker
+	if o.profitSweeper != nil {
+		o.wg.Add(1)
+		go o.profitSweepWorker()
+	}
+
+	// End-of-day report worker
+	if o.dailyReporter != nil {
+		o.wg.Add(1)
+		go o.dailyReportWorker()
+	}
+
+	// User-defined alert rules
+	if o.alertRules != nil {
+		o.wg.Add(1)
+		go o.alertRuleWorker()
+	}
+
+	// Redundant feed switch reporting
+	if failover, ok := o.streamProvider.(*stream.FailoverProvider); ok {
+		o.wg.Add(1)
+		go o.feedFailoverWorker(failover)
+	}
+
+	// Resource watchdog
+	o.wg.Add(1)
+	go o.healthWorker()
 }
 
-// dataStreamingWorker processes incoming data from stream provider
+// dataStreamingWorker receives data from the stream provider and queues it for processing, so a slow
+// processing step is absorbed by the backpressure policy instead of stalling the feed
 func (o *Orchestrator) dataStreamingWorker() {
 	defer o.wg.Done()
 
 	tickerChan := o.streamProvider.GetTickerChannel()
 	ohlcvChan := o.streamProvider.GetOHLCVChannel()
+	var aggTradeChan <-chan types.AggTrade // nil blocks, so providers without trades only deliver ticks
+	if provider, ok := o.streamProvider.(stream.AggTradeProvider); ok && o.config.StreamConfig.AggTrades {
+		aggTradeChan = provider.GetAggTradeChannel()
+	}
+	var lastDropWarning time.Time
+	queue := func(update DataUpdate) {
+		if dropped := o.dataQueue.Push(o.ctx, update); dropped > 0 && time.Since(lastDropWarning) >= time.Minute {
+			lastDropWarning = time.Now()
+			stats := o.dataQueue.GetQueueStats()
+			o.logf(logging.ComponentStream, logging.WarnLevel, "⚠️ Data processing is falling behind: %d updates dropped so far (%s policy, capacity %d)",
+				stats["dropped"], stats["policy"], stats["capacity"])
+		}
+	}
 
 	for {
 		select {
@@ -377,8 +1050,16 @@ func (o *Orchestrator) dataStreamingWorker() {
 				// Channel closed
 				return
 			}
-			if ticker.Symbol == o.activeSymbol {
-				o.processTicker(&ticker)
+			now := time.Now()
+			o.lastTickAt.Store(now.UnixNano())
+			o.lastTickLag.Store(int64(now.Sub(ticker.Timestamp)))
+			if o.staleFilter.CheckTicker(ticker) != stream.VerdictAccept {
+				o.reportStaleData()
+				continue
+			}
+			if o.subscriptions.Contains(ticker.Symbol) {
+				o.basisMonitor.UpdateMark(ticker.Symbol, ticker.GetTriggerPrice(types.TriggerPriceMark), now)
+				queue(DataUpdate{Symbol: ticker.Symbol, Ticker: data.AcquireTicker(ticker), Time: ticker.Timestamp})
 			}
 
 		case ohlcv, ok := <-ohlcvChan:
@@ -386,8 +1067,103 @@ func (o *Orchestrator) dataStreamingWorker() {
 				// Channel closed
 				return
 			}
-			if ohlcv.Symbol == o.activeSymbol {
-				o.processOHLCV(&ohlcv)
+			if o.staleFilter.CheckCandle(ohlcv) != stream.VerdictAccept {
+				o.reportStaleData()
+				continue
+			}
+			if o.subscriptions.Contains(ohlcv.Symbol) {
+				queue(DataUpdate{Symbol: ohlcv.Symbol, OHLCV: data.AcquireCandle(ohlcv), Time: ohlcv.Timestamp})
+			}
+
+		case trade, ok := <-aggTradeChan:
+			if !ok {
+				// Trades stopped; ticks keep the candles going
+				aggTradeChan = nil
+				continue
+			}
+			now := time.Now()
+			o.lastTickAt.Store(now.UnixNano())
+			o.lastTickLag.Store(int64(now.Sub(trade.Timestamp)))
+			if o.subscriptions.Contains(trade.Symbol) {
+				queue(DataUpdate{Symbol: trade.Symbol, AggTrade: data.AcquireAggTrade(trade), Time: trade.Timestamp})
+			}
+		}
+	}
+}
+
+// dataProcessingWorker processes queued market data in arrival order; updates no subscriber received go
+// back to the data pools
+func (o *Orchestrator) dataProcessingWorker() {
+	defer o.wg.Done()
+
+	for {
+		update, ok := o.dataQueue.Pop(o.ctx)
+		if !ok {
+			return
+		}
+		switch {
+		case update.Ticker != nil:
+			o.processTicker(update.Ticker)
+		case update.OHLCV != nil:
+			o.processOHLCV(update.OHLCV)
+		case update.AggTrade != nil:
+			o.processAggTrade(update.AggTrade)
+		}
+		if o.events.Publish(o.ctx, TopicMarketData, update) == 0 {
+			releaseDataUpdate(update)
+		}
+	}
+}
+
+// reportStaleData raises a throttled warning when the feed delivers stale or duplicated data
+func (o *Orchestrator) reportStaleData() {
+	summary, ok := o.staleFilter.TakeAlert(time.Now())
+	if !ok {
+		return
+	}
+
+	alert := RiskAlert{
+		Level:     "warning",
+		Type:      "stale_data",
+		Symbol:    o.activeSymbol,
+		Message:   fmt.Sprintf("Feed delivered %d stale and %d duplicate events (max lag %s), dropped before processing",
+			summary.Stale, summary.Duplicates, summary.MaxLag),
+		Value:     summary.MaxLag.Seconds(),
+		Timestamp: time.Now(),
+	}
+	o.publishRiskAlert(alert)
+}
+
+// feedFailoverWorker raises an alert whenever the redundant stream switches between its feeds
+func (o *Orchestrator) feedFailoverWorker(failover *stream.FailoverProvider) {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var seen int64
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			var switches []stream.FailoverSwitch
+			switches, seen = failover.SwitchesSince(seen)
+			for _, change := range switches {
+				level := "warning"
+				if change.To == stream.FeedPrimary {
+					level = "info"
+				}
+				o.logf(logging.ComponentStream, logging.WarnLevel, "🔀 Market data switched from %s to %s feed: %s", change.From, change.To, change.Reason)
+				alert := RiskAlert{
+					Level:     level,
+					Type:      "feed_failover",
+					Symbol:    o.activeSymbol,
+					Message:   fmt.Sprintf("Market data switched from %s to %s feed: %s", change.From, change.To, change.Reason),
+					Timestamp: change.Time,
+				}
+				o.publishRiskAlert(alert)
 			}
 		}
 	}
@@ -395,10 +1171,36 @@ func (o *Orchestrator) dataStreamingWorker() {
 
 // processTicker processes incoming ticker data
 func (o *Orchestrator) processTicker(ticker *types.Ticker) {
+	ctx, span := o.tracer.StartTrace(o.ctx, "tick")
+	defer span.End()
+	span.SetAttribute("symbol", ticker.Symbol)
+	span.SetAttribute("price", ticker.Price)
+	span.SetAttribute("event_time", ticker.Timestamp)
+
 	// Update candle aggregator
+	_, aggregatorSpan := o.tracer.Start(ctx, "aggregator.add_tick")
 	o.candleAggregator.AddTick(*ticker)
+	aggregatorSpan.End()
+	warmup := ticker.Timestamp.Before(o.config.TradingStart)
+	if ticker.Symbol == o.activeSymbol {
+		o.checkDataGap(ctx)
+		o.checkVolatility(ticker)
+		if !warmup {
+			o.benchmark.Sample(ticker.Symbol, ticker.Timestamp, o.currency.PriceInAccounting(ticker.Symbol, ticker.Price))
+		}
+	}
+	if o.logLevels.Enabled(logging.ComponentStream, logging.DebugLevel) && o.tickSampler.Allow() {
+		log.Printf("📡 Tick %s %.4f (volume: %.4f, %d ticks skipped so far)",
+			ticker.Symbol, ticker.Price, ticker.Volume, o.tickSampler.Suppressed())
+	}
+
+	// Let simulated executors match resting orders against the new price
+	if receiver, ok := o.tradingExecutor.(trading.MarketDataReceiver); ok {
+		receiver.UpdateTicker(*ticker)
+	}
 
 	// Update technical analyzer with new ticker data
+	_, indicatorSpan := o.tracer.Start(ctx, "indicators.update")
 	o.technicalAnalyzer.AddCandle(types.OHLCV{
 		Symbol:    ticker.Symbol,
 		Timestamp: ticker.Timestamp,
@@ -408,21 +1210,49 @@ func (o *Orchestrator) processTicker(ticker *types.Ticker) {
 		Close:     ticker.Price,
 		Volume:    ticker.Volume,
 	})
+	indicatorSpan.End()
 
-	// Send data update to processing pipeline with non-blocking send
-	select {
-	case o.dataChan <- DataUpdate{
-		Symbol: ticker.Symbol,
-		Ticker: ticker,
-		Time:   ticker.Timestamp,
-	}:
-	case <-o.ctx.Done():
-		// Context cancelled, don't block
+	// Watched symbols only build candles and indicators; the strategy trades the active symbol once the
+	// warm-up is over
+	if ticker.Symbol != o.activeSymbol || warmup {
 		return
 	}
 
 	// Process based on current mode
-	o.processDataInMode(ticker.Price, ticker.Timestamp)
+	o.processDataInMode(ctx, ticker.Price, ticker.Timestamp)
+}
+
+// checkDataGap logs data gap transitions and re-places grid orders once the feed has recovered
+func (o *Orchestrator) checkDataGap(ctx context.Context) {
+	gap := o.candleAggregator.HasDataGap(o.activeSymbol, time.Now())
+	if gap == o.dataGap {
+		return
+	}
+	o.dataGap = gap
+
+	if gap {
+		if state, ok := o.candleAggregator.GetGapState(o.activeSymbol); ok {
+			o.logf(logging.ComponentStream, logging.WarnLevel, "⚠️ Market data gap for %s: no data from %s to %s, entries paused",
+				o.activeSymbol, state.Since.Format("15:04:05"), state.ResumedAt.Format("15:04:05"))
+		}
+		return
+	}
+
+	o.logf(logging.ComponentStream, logging.InfoLevel, "✅ Market data for %s recovered, entries resumed", o.activeSymbol)
+	o.mu.RLock()
+	mode := o.state.Mode
+	o.mu.RUnlock()
+	if mode == ModeGrid {
+		o.placeGridOrders(ctx)
+	}
+}
+
+// processAggTrade adds an aggregated trade to the candles, giving them taker buy and sell volume; ticks
+// still drive the strategies
+func (o *Orchestrator) processAggTrade(trade *types.AggTrade) {
+	if !o.candleAggregator.AddAggTrade(*trade) && o.logLevels.Enabled(logging.ComponentStream, logging.DebugLevel) {
+		log.Printf("📡 Dropped duplicate trade %d for %s", trade.AggTradeID, trade.Symbol)
+	}
 }
 
 // processOHLCV processes incoming OHLCV candle data
@@ -430,41 +1260,74 @@ func (o *Orchestrator) processOHLCV(ohlcv *types.OHLCV) {
 	// Add candle to technical analyzer
 	o.technicalAnalyzer.AddCandle(*ohlcv)
 
-	// Send data update to processing pipeline with non-blocking send
-	select {
-	case o.dataChan <- DataUpdate{
-		Symbol: ohlcv.Symbol,
-		OHLCV:  ohlcv,
-		Time:   ohlcv.Timestamp,
-	}:
-	case <-o.ctx.Done():
-		// Context cancelled, don't block
+	// Walk simulated executors through the candle, so resting orders fill where the candle traded through
+	// them and stops and take-profits trigger in the order the path reaches them, not all at the close
+	if o.candlePath == nil {
+		return
+	}
+	receiver, ok := o.tradingExecutor.(trading.MarketDataReceiver)
+	if !ok {
+		return
+	}
+	ticks, err := o.candlePath.Ticks(*ohlcv)
+	if err != nil {
+		o.logf(logging.ComponentStream, logging.WarnLevel, "⚠️ Intra-candle path unavailable, using the close: %v", err)
+		receiver.UpdateTicker(types.Ticker{Symbol: ohlcv.Symbol, Timestamp: ohlcv.Timestamp, Price: ohlcv.Close, Volume: ohlcv.Volume})
 		return
 	}
+	for _, tick := range ticks {
+		receiver.UpdateTicker(tick)
+	}
 }
 
 // processDataInMode processes data based on current trading mode
-func (o *Orchestrator) processDataInMode(price float64, timestamp time.Time) {
+func (o *Orchestrator) processDataInMode(ctx context.Context, price float64, timestamp time.Time) {
 	o.mu.Lock()
 	currentMode := o.state.Mode
 	o.mu.Unlock()
 
+	// Stability thresholds calibrate on all market data, not only while a mode checks stability
+	o.stabilityDetector.Observe(o.activeSymbol, timestamp)
+
+	ctx, span := o.tracer.Start(ctx, "signal.detect")
+	defer span.End()
+	span.SetAttribute("mode", string(currentMode))
+
 	switch currentMode {
 	case ModeGrid:
-		o.processGridMode(price, timestamp)
+		o.processGridMode(ctx, price, timestamp)
 	case ModeBreakout:
-		o.processBreakoutMode(price, timestamp)
+		o.processBreakoutMode(ctx, price, timestamp)
 	case ModeRecovery:
-		o.processRecoveryMode(price, timestamp)
+		o.processRecoveryMode(ctx, price, timestamp)
 	case ModeStability:
-		o.processStabilityMode(price, timestamp)
+		o.processStabilityMode(ctx, price, timestamp)
 	case ModeIdle:
 		// No processing in idle mode
 	}
 }
 
 // processGridMode processes data in grid trading mode
-func (o *Orchestrator) processGridMode(price float64, timestamp time.Time) {
+func (o *Orchestrator) processGridMode(ctx context.Context, price float64, timestamp time.Time) {
+	// A trend-follow position running alongside the grid is managed here until it closes
+	o.mu.RLock()
+	concurrentBreakout := o.state.BreakoutInfo != nil && o.state.BreakoutInfo.Concurrent
+	o.mu.RUnlock()
+	if concurrentBreakout {
+		o.processConcurrentBreakout(ctx, price)
+		return
+	}
+
+	// Grid orders deferred by thin liquidity are placed once the book recovers
+	if o.liquidityGuard.RetryDue(o.activeSymbol, time.Now()) {
+		o.placeGridOrders(ctx)
+	}
+
+	// Signals from indicators that are still warming up are not trusted
+	if !o.technicalAnalyzer.IsReady(o.activeSymbol) {
+		return
+	}
+
 	// Check for breakout conditions
 	breakoutSignal := o.breakoutDetector.DetectBreakout(
 		o.activeSymbol,
@@ -474,7 +1337,9 @@ func (o *Orchestrator) processGridMode(price float64, timestamp time.Time) {
 
 	if breakoutSignal != nil {
 		// Breakout detected - switch to breakout mode
-		o.signalChan <- TradingSignal{
+		o.annotate(AnnotationBreakout, o.activeSymbol, fmt.Sprintf("%s breakout @ %.4f (confidence %.2f)",
+			breakoutSignal.Type, breakoutSignal.Price, breakoutSignal.Confidence))
+		o.publishSignal(TradingSignal{
 			Type:       "breakout",
 			Symbol:     o.activeSymbol,
 			Action:     "switch_mode",
@@ -482,24 +1347,26 @@ func (o *Orchestrator) processGridMode(price float64, timestamp time.Time) {
 			Reason:     fmt.Sprintf("Breakout detected: %s", breakoutSignal.Type),
 			Data:       breakoutSignal,
 			Timestamp:  timestamp,
-		}
+			Context:    ctx,
+		})
 		return
 	}
 
 	// Check for false breakouts within grid bounds
 	if o.state.BreakoutInfo != nil && o.state.BreakoutInfo.FalseBreakoutDetected {
+		atr, averageVolume := o.getFalseBreakoutInputs()
 		falseBreakoutSignal := o.falseBreakoutDetector.DetectFalseBreakout(
 			o.activeSymbol,
 			o.state.BreakoutInfo.EntryPrice,
 			price,
 			strategy.BreakoutType(o.state.BreakoutInfo.BreakoutType),
-			0, // ATR - would get from technical analyzer
-			0, // Average volume - would get from data
+			atr,
+			averageVolume,
 			timestamp.Sub(o.state.BreakoutInfo.BreakoutTime),
 		)
 
 		if falseBreakoutSignal != nil {
-			o.signalChan <- TradingSignal{
+			o.publishSignal(TradingSignal{
 				Type:       "false_breakout",
 				Symbol:     o.activeSymbol,
 				Action:     "recovery",
@@ -507,31 +1374,47 @@ func (o *Orchestrator) processGridMode(price float64, timestamp time.Time) {
 				Reason:     falseBreakoutSignal.RecoveryAction,
 				Data:       falseBreakoutSignal,
 				Timestamp:  timestamp,
-			}
+				Context:    ctx,
+			})
 		}
 	}
 }
 
 // processBreakoutMode processes data in breakout mode
-func (o *Orchestrator) processBreakoutMode(price float64, timestamp time.Time) {
-	if o.state.BreakoutInfo == nil {
+func (o *Orchestrator) processBreakoutMode(ctx context.Context, price float64, timestamp time.Time) {
+	o.mu.RLock()
+	breakoutInfo := o.state.BreakoutInfo
+	var info BreakoutInfo
+	if breakoutInfo != nil {
+		info = *breakoutInfo
+	}
+	o.mu.RUnlock()
+
+	if breakoutInfo == nil {
 		o.switchMode(ModeGrid)
 		return
 	}
 
+	// Count confirmation candles and complete the tiered entry once confirmed
+	o.updateBreakoutConfirmation(ctx, price)
+
+	// Execute stop loss / take profit on the breakout position
+	o.processBreakoutExits(ctx, price)
+
 	// Check for false breakout
+	atr, averageVolume := o.getFalseBreakoutInputs()
 	falseBreakoutSignal := o.falseBreakoutDetector.DetectFalseBreakout(
 		o.activeSymbol,
-		o.state.BreakoutInfo.EntryPrice,
+		info.EntryPrice,
 		price,
-		strategy.BreakoutType(o.state.BreakoutInfo.BreakoutType),
-		0, // ATR
-		0, // Average volume
-		timestamp.Sub(o.state.BreakoutInfo.BreakoutTime),
+		strategy.BreakoutType(info.BreakoutType),
+		atr,
+		averageVolume,
+		timestamp.Sub(info.BreakoutTime),
 	)
 
 	if falseBreakoutSignal != nil {
-		o.signalChan <- TradingSignal{
+		o.publishSignal(TradingSignal{
 			Type:       "false_breakout",
 			Symbol:     o.activeSymbol,
 			Action:     "switch_mode",
@@ -539,7 +1422,8 @@ func (o *Orchestrator) processBreakoutMode(price float64, timestamp time.Time) {
 			Reason:     "False breakout detected",
 			Data:       falseBreakoutSignal,
 			Timestamp:  timestamp,
-		}
+			Context:    ctx,
+		})
 		return
 	}
 
@@ -547,26 +1431,86 @@ func (o *Orchestrator) processBreakoutMode(price float64, timestamp time.Time) {
 	stabilitySignal := o.stabilityDetector.AnalyzeStability(o.activeSymbol, price)
 
 	if stabilitySignal.IsStable && stabilitySignal.RecommendedAction == "Return to grid trading" {
-		o.signalChan <- TradingSignal{
+		o.publishSignal(TradingSignal{
 			Type:       "stability",
 			Symbol:     o.activeSymbol,
 			Action:     "switch_mode",
+			Price:      price,
 			Confidence: stabilitySignal.Confidence,
 			Reason:     "Price stability detected, returning to grid",
 			Data:       stabilitySignal,
 			Timestamp:  timestamp,
-		}
+			Context:    ctx,
+		})
 	}
 }
 
-// processRecoveryMode processes data in recovery mode
-func (o *Orchestrator) processRecoveryMode(price float64, timestamp time.Time) {
-	// In recovery mode, focus on minimizing losses and resetting
+// processConcurrentBreakout manages a breakout position opened alongside the grid and clears it once closed
+func (o *Orchestrator) processConcurrentBreakout(ctx context.Context, price float64) {
+	o.updateBreakoutConfirmation(ctx, price)
+	o.processBreakoutExits(ctx, price)
+
+	o.mu.RLock()
+	info := o.state.BreakoutInfo
+	opened := info != nil && info.EntryTiers > 0
+	var positionType types.PositionType
+	if info != nil {
+		positionType = info.PositionType
+	}
+	insideGrid := price >= o.state.GridBounds.LowerBound && price <= o.state.GridBounds.UpperBound
+	o.mu.RUnlock()
+
+	if !opened {
+		// No entry (yet); hold off new signals until price is back inside the grid
+		if !insideGrid {
+			return
+		}
+	} else {
+		o.positionMu.Lock()
+		_, hasPosition := o.positionManager.GetPosition(o.positionManager.PositionKey(o.activeSymbol, positionType))
+		o.positionMu.Unlock()
+		if hasPosition {
+			return
+		}
+	}
+
+	o.mu.Lock()
+	o.recordBreakoutOutcome(o.state.BreakoutInfo, journal.BreakoutUnconfirmed)
+	o.state.BreakoutInfo = nil
+	o.mu.Unlock()
+	if opened {
+		log.Printf("🏁 Trend-follow position closed, watching for the next breakout")
+	}
+}
+
+// getFalseBreakoutInputs returns the current ATR and recent average volume for false breakout detection
+func (o *Orchestrator) getFalseBreakoutInputs() (float64, float64) {
+	atr := 0.0
+	if values := o.technicalAnalyzer.GetIndicatorValues(o.activeSymbol); values != nil && !math.IsNaN(values.ATR) {
+		atr = values.ATR
+	}
+
+	averageVolume := 0.0
+	candles := o.candleAggregator.GetCandles(o.activeSymbol, data.Timeframe1s, o.config.FalseBreakoutVolumeLookback)
+	if len(candles) > 0 {
+		totalVolume := 0.0
+		for _, candle := range candles {
+			totalVolume += candle.Volume
+		}
+		averageVolume = totalVolume / float64(len(candles))
+	}
+
+	return atr, averageVolume
+}
+
+// processRecoveryMode processes data in recovery mode
+func (o *Orchestrator) processRecoveryMode(ctx context.Context, price float64, timestamp time.Time) {
+	// In recovery mode, focus on minimizing losses and resetting
 	// Check if conditions are suitable to return to grid trading
 	stabilitySignal := o.stabilityDetector.AnalyzeStability(o.activeSymbol, price)
 
 	if stabilitySignal.IsStable {
-		o.signalChan <- TradingSignal{
+		o.publishSignal(TradingSignal{
 			Type:       "recovery_complete",
 			Symbol:     o.activeSymbol,
 			Action:     "switch_mode",
@@ -574,28 +1518,36 @@ func (o *Orchestrator) processRecoveryMode(price float64, timestamp time.Time) {
 			Reason:     "Recovery complete, returning to grid",
 			Data:       stabilitySignal,
 			Timestamp:  timestamp,
-		}
+			Context:    ctx,
+		})
 	}
 }
 
 // processStabilityMode processes data in stability detection mode
-func (o *Orchestrator) processStabilityMode(price float64, timestamp time.Time) {
+func (o *Orchestrator) processStabilityMode(ctx context.Context, price float64, timestamp time.Time) {
+	// Wait for the indicators to warm up before deciding on the next mode
+	if !o.technicalAnalyzer.IsReady(o.activeSymbol) {
+		return
+	}
+
 	// Monitor stability and decide on next action
 	stabilitySignal := o.stabilityDetector.AnalyzeStability(o.activeSymbol, price)
 
 	if stabilitySignal.IsStable && stabilitySignal.RecommendedAction == "Return to grid trading" {
-		o.signalChan <- TradingSignal{
+		o.publishSignal(TradingSignal{
 			Type:       "stability_confirmed",
 			Symbol:     o.activeSymbol,
 			Action:     "switch_mode",
+			Price:      price,
 			Confidence: stabilitySignal.Confidence,
 			Reason:     "Stability confirmed, returning to grid",
 			Data:       stabilitySignal,
 			Timestamp:  timestamp,
-		}
+			Context:    ctx,
+		})
 	} else if !stabilitySignal.IsStable {
 		// If stability is lost, might need to go back to breakout mode
-		o.signalChan <- TradingSignal{
+		o.publishSignal(TradingSignal{
 			Type:       "stability_lost",
 			Symbol:     o.activeSymbol,
 			Action:     "switch_mode",
@@ -603,7 +1555,8 @@ func (o *Orchestrator) processStabilityMode(price float64, timestamp time.Time)
 			Reason:     "Stability lost, returning to breakout management",
 			Data:       stabilitySignal,
 			Timestamp:  timestamp,
-		}
+			Context:    ctx,
+		})
 	}
 }
 
@@ -616,14 +1569,45 @@ func (o *Orchestrator) signalProcessingWorker() {
 		case <-o.ctx.Done():
 			return
 
-		case signal := <-o.signalChan:
-			o.processTradingSignal(signal)
+		case event := <-o.signalEvents.Events():
+			o.processTradingSignal(event.Payload.(TradingSignal))
 		}
 	}
 }
 
+// publishSignal publishes a trading signal unless it repeats a recent one, waiting for the signal worker to have room
+func (o *Orchestrator) publishSignal(signal TradingSignal) {
+	// Cooldowns run on event time, so replayed data is deduplicated like live data
+	now := signal.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	allowed, suppressed := o.signalDedup.Allow(signal, now)
+	if !allowed {
+		return
+	}
+	if suppressed > 0 {
+		o.logf(logging.ComponentOrchestrator, logging.DebugLevel, "🔁 Suppressed %d repeated %s signals for %s", suppressed, signal.Type, signal.Symbol)
+	}
+	o.events.Publish(o.ctx, TopicSignal, signal)
+}
+
+// publishRiskAlert publishes a risk alert, waiting for the risk worker to have room
+func (o *Orchestrator) publishRiskAlert(alert RiskAlert) {
+	o.events.Publish(o.ctx, TopicRiskAlert, alert)
+}
+
 // processTradingSignal processes a trading signal
 func (o *Orchestrator) processTradingSignal(signal TradingSignal) {
+	if signal.Context == nil {
+		signal.Context = o.ctx
+	}
+	ctx, span := o.tracer.Start(signal.Context, "signal.handle")
+	defer span.End()
+	span.SetAttribute("signal", signal.Type)
+	signal.Context = ctx
+	o.recordSignal(signal)
+
 	switch signal.Type {
 	case "breakout":
 		o.handleBreakoutSignal(signal)
@@ -633,6 +1617,46 @@ func (o *Orchestrator) processTradingSignal(signal TradingSignal) {
 		o.handleStabilitySignal(signal)
 	case "grid_setup":
 		o.handleGridSetupSignal(signal)
+	case "news_pause", "news_resume":
+		o.handleNewsSignal(signal)
+	case "exchange_halt", "exchange_resume":
+		o.handleExchangeStatusSignal(signal)
+	case "rate_limit_resume":
+		o.handleRateLimitResume(signal)
+	case "basis_normal":
+		o.handleBasisNormal(signal)
+	case "volatility_halt", "volatility_recovering", "volatility_clear":
+		o.handleVolatilitySignal(signal)
+	case "mode_timeout":
+		o.handleModeTimeoutSignal(signal)
+	case "recovery_escalation":
+		o.handleRecoveryEscalation(signal)
+	}
+}
+
+// recordSignal keeps a handled signal for the session report
+func (o *Orchestrator) recordSignal(signal TradingSignal) {
+	event := SignalEvent{
+		Type:       signal.Type,
+		Action:     signal.Action,
+		Price:      signal.Price,
+		Confidence: signal.Confidence,
+		Reason:     signal.Reason,
+		Timestamp:  signal.Timestamp,
+	}
+	if event.Price == 0 {
+		event.Price = o.candleAggregator.GetLatestPrice(o.activeSymbol)
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	event.Mode = o.state.Mode
+	o.signalHistory = append(o.signalHistory, event)
+	if len(o.signalHistory) > maxSessionEvents {
+		o.signalHistory = o.signalHistory[1:]
 	}
 }
 
@@ -640,164 +1664,887 @@ func (o *Orchestrator) processTradingSignal(signal TradingSignal) {
 func (o *Orchestrator) handleBreakoutSignal(signal TradingSignal) {
 	breakoutData := signal.Data.(*strategy.BreakoutSignal)
 
-	// Update breakout info
+	// Only the first breakout signal out of grid mode starts a new breakout
 	o.mu.Lock()
+	if o.state.Mode != ModeGrid || (o.state.BreakoutInfo != nil && o.state.BreakoutInfo.Concurrent) {
+		o.mu.Unlock()
+		return
+	}
+	// A grid mode lock overrides breakout detection; concurrent breakouts leave the grid mode alone
+	if o.state.ModeLock != nil && !o.config.ConcurrentStrategies {
+		o.suppressTransition(ModeBreakout)
+		o.mu.Unlock()
+		return
+	}
+
+	// Only candles that close after the breakout count towards confirmation
+	var lastCandleTime time.Time
+	if candles := o.candleAggregator.GetCandles(o.activeSymbol, data.Timeframe1s, 1); len(candles) > 0 {
+		lastCandleTime = candles[0].Timestamp
+	}
+
+	// Update breakout info
 	o.state.BreakoutInfo = &BreakoutInfo{
+		ID:                  fmt.Sprintf("%s-%d", breakoutData.Symbol, breakoutData.Timestamp.UnixNano()),
 		BreakoutType:        breakoutData.Type,
+		Confidence:          breakoutData.Confidence,
 		BreakoutTime:        breakoutData.Timestamp,
 		EntryPrice:          breakoutData.Price,
 		ConfirmationCandles: 0,
 		IsConfirmed:         false,
 		FalseBreakoutDetected: false,
+		LastConfirmationCandle: lastCandleTime,
+		Concurrent:          o.config.ConcurrentStrategies,
 	}
 	o.mu.Unlock()
 
+	if o.config.ConcurrentStrategies {
+		// The grid keeps running; the breakout trades from its own capital bucket
+		log.Printf("🔥 Breakout detected: %s at %.2f (confidence: %.2f), trend-following alongside the grid",
+			breakoutData.Type, breakoutData.Price, breakoutData.Confidence)
+		o.openBreakoutPosition(signal.Context, breakoutData)
+		return
+	}
+
 	// Switch to breakout mode
-	o.switchMode(ModeBreakout)
+	if err := o.switchMode(ModeBreakout); err != nil {
+		log.Printf("❌ Failed to switch to breakout mode: %v", err)
+		return
+	}
 
 	log.Printf("🔥 Breakout detected: %s at %.2f (confidence: %.2f)",
 		breakoutData.Type, breakoutData.Price, breakoutData.Confidence)
+
+	// Open the first entry tier
+	o.openBreakoutPosition(signal.Context, breakoutData)
 }
 
-// handleFalseBreakoutSignal handles false breakout signals
-func (o *Orchestrator) handleFalseBreakoutSignal(signal TradingSignal) {
-	falseBreakoutData := signal.Data.(*strategy.FalseBreakoutSignal)
+// openBreakoutPosition sizes a breakout trade and opens its first (50%) entry tier
+func (o *Orchestrator) openBreakoutPosition(ctx context.Context, breakoutData *strategy.BreakoutSignal) {
+	o.positionMu.Lock()
+	positionType := o.positionManager.GetPositionTypeForBreakout(breakoutData.Type)
+	positionKey := o.positionManager.PositionKey(o.activeSymbol, positionType)
+	_, hasPosition := o.positionManager.GetPosition(positionKey)
+	stopLoss, takeProfit := o.positionManager.GetStopAndTarget(positionType, breakoutData.Price)
+	o.positionMu.Unlock()
+
+	// Target the grid center when it lies beyond the default take profit
+	center := breakoutData.GridBounds.Center
+	if (positionType == types.PositionTypeShort && center > 0 && center < takeProfit) ||
+		(positionType == types.PositionTypeLong && center > takeProfit) {
+		takeProfit = center
+	}
+
+	if hasPosition {
+		log.Printf("⚠️ Skipping breakout entry: position already open for %s", o.activeSymbol)
+		return
+	}
+	if !o.equityFilter.IsTradingEnabled() {
+		log.Printf("⚠️ Skipping breakout entry: equity is below its moving average")
+		return
+	}
+	if o.dailyLoss.IsHalted() {
+		log.Printf("⚠️ Skipping breakout entry: daily loss limit reached")
+		return
+	}
+	if o.candleAggregator.HasDataGap(o.activeSymbol, time.Now()) {
+		log.Printf("⚠️ Skipping breakout entry: market data for %s has a gap", o.activeSymbol)
+		return
+	}
+	if o.exchangeStatus.IsHalted() {
+		log.Printf("⚠️ Skipping breakout entry: %s", o.exchangeStatus.Status().Reason())
+		return
+	}
+	if o.restPaused() {
+		log.Printf("⚠️ Skipping breakout entry: REST calls are paused by an exchange rate limit")
+		return
+	}
+	if o.volatilityBreaker.IsTripped(o.activeSymbol) {
+		log.Printf("⚠️ Skipping breakout entry: volatility circuit breaker tripped for %s", o.activeSymbol)
+		return
+	}
+	if o.config.MarketType.IsSpot() && positionType == types.PositionTypeShort {
+		log.Printf("⚠️ Skipping breakout entry: short positions are not available on spot markets")
+		return
+	}
+	if reason := o.fundingCalendar.SettlementBlackout(o.activeSymbol, time.Now()); reason != "" {
+		log.Printf("⚠️ Skipping breakout entry: %s", reason)
+		return
+	}
+	if reason := o.fundingCalendar.CheckCarry(o.activeSymbol, positionType, breakoutData.Price, takeProfit, time.Now()); reason != "" {
+		log.Printf("⚠️ Skipping breakout entry: %s", reason)
+		return
+	}
+	if reason := o.basisMonitor.CheckDirection(o.activeSymbol, positionType); reason != "" {
+		log.Printf("⚠️ Skipping breakout entry: %s", reason)
+		return
+	}
+	if !o.checkLiquidity("breakout entry") {
+		log.Printf("⚠️ Skipping breakout entry: %s liquidity is too thin", o.activeSymbol)
+		return
+	}
+
+	volatility := float64(0)
+	if values := o.technicalAnalyzer.GetIndicatorValues(o.activeSymbol); values != nil && breakoutData.Price > 0 {
+		volatility = values.ATR / breakoutData.Price
+	}
+
+	sizing := o.riskManager.CalculatePositionSize(strategy.PositionSizingRequest{
+		Symbol:     o.activeSymbol,
+		EntryPrice: breakoutData.Price,
+		StopLoss:   stopLoss,
+		TakeProfit: takeProfit,
+		Confidence: breakoutData.Confidence,
+		Volatility: volatility,
+	})
+	if o.capitalAllocator != nil {
+		sizing.RecommendedSize *= o.capitalAllocator.SizeFraction(strategy.BucketBreakout)
+	}
+	if !sizing.AcceptableRisk || sizing.RecommendedSize <= 0 {
+		log.Printf("⚠️ Skipping breakout entry: %s", sizing.Reason)
+		return
+	}
+
+	// Tiered entry: 50% immediately, the rest after confirmation
+	firstTier := sizing.RecommendedSize * 0.5
+	result, err := o.submitManagedOrder(ctx, positionType, firstTier, false, "breakout-entry", types.NewOrderTag("breakout", "entry-tier-1"))
+	if err != nil {
+		log.Printf("❌ Failed to open breakout position: %v", err)
+		return
+	}
+	fillPrice := result.FilledPrice
+	if fillPrice == 0 {
+		fillPrice = breakoutData.Price
+	}
+
+	o.positionMu.Lock()
+	_, err = o.positionManager.OpenBreakoutPosition(o.activeSymbol, breakoutData.Type, sizing.RecommendedSize, fillPrice, breakoutData.Confidence)
+	if err == nil {
+		err = o.positionManager.SetStopAndTarget(positionKey, stopLoss, takeProfit)
+	}
+	state, _ := o.positionManager.GetPosition(positionKey)
+	o.positionMu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Breakout position filled but not tracked: %v", err)
+		return
+	}
+	o.calibration.RecordSignal(state.Position.ID, "breakout_"+string(breakoutData.Type), breakoutData.Confidence, breakoutData.Timestamp)
+	o.placeExchangeExits(ctx, positionKey)
 
-	// Update breakout info
 	o.mu.Lock()
 	if o.state.BreakoutInfo != nil {
-		o.state.BreakoutInfo.FalseBreakoutDetected = true
-		o.state.BreakoutInfo.RecoveryAction = falseBreakoutData.RecoveryAction
+		o.state.BreakoutInfo.PositionType = positionType
+		o.state.BreakoutInfo.TargetQuantity = sizing.RecommendedSize
+		o.state.BreakoutInfo.EntryTiers = 1
+		o.state.BreakoutInfo.StopLoss = state.StopLoss
+		o.state.BreakoutInfo.TakeProfit = state.TakeProfit
 	}
 	o.mu.Unlock()
 
-	// Execute recovery action if specified
-	o.executeRecoveryAction(falseBreakoutData.RecoveryAction, falseBreakoutData)
+	log.Printf("📈 Breakout entry 1/2: %s %.4f @ %.2f (stop: %.2f, target: %.2f)",
+		positionType, firstTier, fillPrice, state.StopLoss, state.TakeProfit)
+}
 
-	// Switch to recovery mode
-	o.switchMode(ModeRecovery)
+// updateBreakoutConfirmation counts closed candles beyond the grid bound and completes the entry once confirmed
+func (o *Orchestrator) updateBreakoutConfirmation(ctx context.Context, price float64) {
+	candles := o.candleAggregator.GetCandles(o.activeSymbol, data.Timeframe1s, 1)
+	if len(candles) == 0 {
+		return
+	}
+	lastCandle := candles[0]
 
-	log.Printf("⚠️ False breakout detected: %s (confidence: %.2f)",
-		falseBreakoutData.RecoveryAction, falseBreakoutData.Confidence)
-}
+	o.mu.Lock()
+	info := o.state.BreakoutInfo
+	if info != nil && info.EntryDeferred {
+		retry := info.EntryTiers == 1 && o.liquidityGuard.RetryDue(o.activeSymbol, time.Now())
+		o.mu.Unlock()
+		if retry {
+			o.completeBreakoutPosition(ctx, price)
+		}
+		return
+	}
+	if info == nil || info.IsConfirmed || !lastCandle.Timestamp.After(info.LastConfirmationCandle) {
+		o.mu.Unlock()
+		return
+	}
+	info.LastConfirmationCandle = lastCandle.Timestamp
+
+	beyondBound := (info.BreakoutType == strategy.BreakoutTypeUp && lastCandle.Close > o.state.GridBounds.UpperBound) ||
+		(info.BreakoutType == strategy.BreakoutTypeDown && lastCandle.Close < o.state.GridBounds.LowerBound)
+	if beyondBound {
+		info.ConfirmationCandles++
+	} else {
+		info.ConfirmationCandles = 0
+	}
 
-// handleStabilitySignal handles price stability signals
-func (o *Orchestrator) handleStabilitySignal(signal TradingSignal) {
-	// Switch to grid mode
-	o.switchMode(ModeGrid)
+	confirmed := info.ConfirmationCandles >= o.breakoutDetector.ConfirmationCandles
+	if confirmed {
+		info.IsConfirmed = true
+		o.recordBreakoutOutcome(info, journal.BreakoutConfirmed)
+	}
+	needsSecondTier := confirmed && info.EntryTiers == 1
+	confirmationCandles := info.ConfirmationCandles
+	o.mu.Unlock()
 
-	log.Printf("✅ Price stability confirmed: %s", signal.Reason)
+	if confirmed {
+		log.Printf("✅ Breakout confirmed after %d closed candles", confirmationCandles)
+	}
+	if needsSecondTier {
+		o.completeBreakoutPosition(ctx, price)
+	}
 }
 
-// handleGridSetupSignal handles grid setup signals
-func (o *Orchestrator) handleGridSetupSignal(signal TradingSignal) {
-	// Grid setup is handled during initialization
-	log.Printf("🔧 Grid setup completed: %s", signal.Reason)
-}
+// completeBreakoutPosition opens the second entry tier of a confirmed breakout
+func (o *Orchestrator) completeBreakoutPosition(ctx context.Context, price float64) {
+	o.mu.RLock()
+	info := *o.state.BreakoutInfo
+	o.mu.RUnlock()
 
-// executeRecoveryAction executes recovery actions for false breakouts
-func (o *Orchestrator) executeRecoveryAction(action string, data interface{}) {
-	// Get current position
-	position, err := o.tradingExecutor.GetPosition(o.activeSymbol)
+	o.positionMu.Lock()
+	positionKey := o.positionManager.PositionKey(o.activeSymbol, info.PositionType)
+	_, hasPosition := o.positionManager.GetPosition(positionKey)
+	o.positionMu.Unlock()
+	if !hasPosition {
+		log.Printf("⚠️ Breakout position already closed, skipping second entry")
+		return
+	}
+	allowed := o.checkLiquidity("breakout entry")
+	o.mu.Lock()
+	if o.state.BreakoutInfo != nil {
+		o.state.BreakoutInfo.EntryDeferred = !allowed
+	}
+	o.mu.Unlock()
+	if !allowed {
+		if !info.EntryDeferred {
+			log.Printf("⏳ Deferring second breakout entry: %s liquidity is too thin", o.activeSymbol)
+		}
+		return
+	}
+
+	remaining := info.TargetQuantity * 0.5
+	result, err := o.submitManagedOrder(ctx, info.PositionType, remaining, false, "breakout-entry", types.NewOrderTag("breakout", "entry-tier-2"))
 	if err != nil {
-		log.Printf("Error getting position for recovery: %v", err)
+		log.Printf("❌ Failed to complete breakout position: %v", err)
 		return
 	}
+	fillPrice := result.FilledPrice
+	if fillPrice == 0 {
+		fillPrice = price
+	}
 
-	if position == nil {
-		return // No position to recover
+	o.positionMu.Lock()
+	_, err = o.positionManager.CompleteBreakoutPosition(positionKey, remaining, fillPrice)
+	if err == nil {
+		// Keep the original stop and target rather than the averaged defaults
+		err = o.positionManager.SetStopAndTarget(positionKey, info.StopLoss, info.TakeProfit)
+	}
+	state, _ := o.positionManager.GetPosition(positionKey)
+	o.positionMu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Breakout position filled but not tracked: %v", err)
+		return
 	}
+	o.placeExchangeExits(ctx, positionKey)
 
-	switch action {
-	case "Close position and take profit":
-		var err error
-		if position.Size > 0 {
-			_, err = o.tradingExecutor.CloseLong(o.activeSymbol, position.Size, 0)
-		} else {
-			_, err = o.tradingExecutor.CloseShort(o.activeSymbol, -position.Size, 0)
-		}
-		if err != nil {
-			log.Printf("Error closing position for profit: %v", err)
+	o.mu.Lock()
+	if o.state.BreakoutInfo != nil {
+		o.state.BreakoutInfo.EntryTiers = 2
+		o.state.BreakoutInfo.StopLoss = state.StopLoss
+		o.state.BreakoutInfo.TakeProfit = state.TakeProfit
+	}
+	o.mu.Unlock()
+
+	log.Printf("📈 Breakout entry 2/2: %s %.4f @ %.2f (stop: %.2f, target: %.2f)",
+		info.PositionType, remaining, fillPrice, state.StopLoss, state.TakeProfit)
+}
+
+// processBreakoutExits fires stop loss / take profit triggers and aged position unwinds and mirrors the
+// closes to the executor. Closes are booked before their exit orders are sent, then settled at the fills the
+// executor reports; a position is put back if its exit orders are not accepted, so the closes fire again on
+// a later price.
+func (o *Orchestrator) processBreakoutExits(ctx context.Context, price float64) {
+	o.positionMu.Lock()
+	states := o.activePositionStates()
+	snapshots := o.positionManager.SnapshotPositions(o.activeSymbol)
+	results, err := o.positionManager.ProcessCloseTriggers(o.activeSymbol, price)
+	var unwinds []strategy.AgedUnwind
+	if err == nil {
+		unwinds, err = o.positionManager.ProcessAgedPositions(o.activeSymbol, price, time.Now())
+	}
+	o.positionManager.MarkBooked(snapshots)
+	o.positionMu.Unlock()
+	defer func() {
+		o.positionMu.Lock()
+		o.recordClosedTrades(states)
+		o.positionMu.Unlock()
+		o.syncExchangeExits()
+	}()
+	if err != nil {
+		log.Printf("Error processing close triggers: %v", err)
+		for _, snapshot := range snapshots {
+			o.restorePosition(snapshot)
 		}
+		return
+	}
 
-	case "Close position to minimize loss":
-		var err error
-		if position.Size > 0 {
-			_, err = o.tradingExecutor.CloseLong(o.activeSymbol, position.Size, 0)
-		} else {
-			_, err = o.tradingExecutor.CloseShort(o.activeSymbol, -position.Size, 0)
+	exited := make(map[string]bool)
+	failed := make(map[string]bool)
+	settle := make(map[string]bool)
+	fills := make(map[string][]strategy.ExitFill)
+	for _, result := range results {
+		positionType := types.PositionType(result.PositionType)
+		key := o.positionManager.PositionKey(o.activeSymbol, positionType)
+		if failed[key] {
+			// Not sent after a failed exit; nothing is booked for it
+			fills[key] = append(fills[key], strategy.ExitFill{})
+			continue
 		}
+		order, err := o.submitManagedOrder(ctx, positionType, result.Quantity, true, "breakout-exit", types.NewOrderTag("breakout", "exit-trigger"))
 		if err != nil {
-			log.Printf("Error closing position for loss: %v", err)
+			log.Printf("❌ Failed to execute breakout exit: %v", err)
+			failed[key], settle[key] = true, true
+			fills[key] = append(fills[key], strategy.ExitFill{})
+			continue
 		}
-
-	case "Consider taking opposite position":
-		// Close current position first
-		var err error
-		if position.Size > 0 {
-			_, err = o.tradingExecutor.CloseLong(o.activeSymbol, position.Size, 0)
-		} else {
-			_, err = o.tradingExecutor.CloseShort(o.activeSymbol, -position.Size, 0)
+		fill := exitFill(order, result.Quantity, price)
+		fills[key] = append(fills[key], fill)
+		exited[key] = true
+		if fill.Quantity != result.Quantity || fill.Price != price {
+			settle[key] = true
 		}
-		if err != nil {
-			log.Printf("Error closing position before reversal: %v", err)
-			return
+		log.Printf("📉 Breakout exit: closed %s %.4f @ %.2f", positionType, fill.Quantity, fill.Price)
+	}
+	for key := range settle {
+		if !exited[key] {
+			o.restorePosition(snapshots[key])
+			continue
 		}
+		o.settleExits(snapshots[key], fills[key])
+	}
 
-		// Calculate opposite position size
-		oppositeSize := position.Size * 0.8 // 80% of original size as opposite position
-
-		if position.Size > 0 {
-			// Was long, now go short
-			_, err = o.tradingExecutor.OpenShort(o.activeSymbol, oppositeSize, 0)
-			if err != nil {
-				log.Printf("Error opening short position: %v", err)
-			}
-		} else {
-			// Was short, now go long
-			_, err = o.tradingExecutor.OpenLong(o.activeSymbol, oppositeSize, 0)
-			if err != nil {
-				log.Printf("Error opening long position: %v", err)
-			}
+	for _, unwind := range unwinds {
+		switch {
+		case failed[unwind.Key] && !exited[unwind.Key]:
+			// The restored position includes the unwind step
+		case failed[unwind.Key] || exited[unwind.Key]:
+			o.executeAgedUnwind(ctx, unwind, nil)
+		default:
+			o.executeAgedUnwind(ctx, unwind, snapshots[unwind.Key])
 		}
 	}
 }
 
-// switchMode switches the trading mode with validation
-func (o *Orchestrator) switchMode(newMode TradingMode) error {
-	o.mu.Lock()
-	defer o.mu.Unlock()
+// restorePosition puts back a position whose booked closes never reached the executor
+func (o *Orchestrator) restorePosition(snapshot *strategy.PositionSnapshot) {
+	if snapshot == nil {
+		return
+	}
+	o.positionMu.Lock()
+	err := o.positionManager.RestorePosition(snapshot)
+	o.positionMu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Position is out of sync with the executor: %v", err)
+		return
+	}
+	log.Printf("↩️ Restored %s position after its exit order was not accepted", snapshot.Key())
+}
 
-	currentMode := o.state.Mode
+// settleExits books the closes of a position at the fills of its exit orders instead of the trigger price;
+// closes whose exit was not sent or not filled in full leave the rest of the position open
+func (o *Orchestrator) settleExits(snapshot *strategy.PositionSnapshot, fills []strategy.ExitFill) {
+	if snapshot == nil {
+		return
+	}
+	o.positionMu.Lock()
+	err := o.positionManager.SettleCloses(snapshot, fills)
+	o.positionMu.Unlock()
+	if err != nil {
+		log.Printf("⚠️ Position is out of sync with the executor: %v", err)
+	}
+}
 
-	// Validate transition
-	allowedModes, exists := o.modeTransitions[currentMode]
-	if !exists {
-		return fmt.Errorf("no transitions defined for mode %s", currentMode)
+// exitFill returns the quantity and price an accepted exit order closed from the fill the executor reported;
+// an order accepted before it filled is taken to close the requested quantity at price
+func exitFill(result *types.OrderResult, quantity, price float64) strategy.ExitFill {
+	fill := strategy.ExitFill{Quantity: quantity, Price: price}
+	if result.FilledQty > 0 && result.FilledQty < quantity {
+		fill.Quantity = result.FilledQty
 	}
+	if result.FilledPrice > 0 {
+		fill.Price = result.FilledPrice
+	}
+	return fill
+}
 
-	allowed := false
-	for _, mode := range allowedModes {
-		if mode == newMode {
-			allowed = true
-			break
+// executeAgedUnwind mirrors an aged position unwind step to the executor, putting the position back from
+// snapshot if the order is not accepted. Steps worked by an execution algorithm, such as passive limit
+// orders over the unwind window, run in the background so price processing is not held up.
+func (o *Orchestrator) executeAgedUnwind(ctx context.Context, unwind strategy.AgedUnwind, snapshot *strategy.PositionSnapshot) {
+	positionType := types.PositionType(unwind.Result.PositionType)
+	quantity := unwind.Result.Quantity
+	submit := func(ctx context.Context) {
+		if _, err := o.submitManagedOrder(ctx, positionType, quantity, true, "aged-unwind", types.NewOrderTag("aged_unwind", fmt.Sprintf("step-%d", unwind.Step))); err != nil {
+			log.Printf("❌ Failed to unwind aged %s position: %v", positionType, err)
+			o.restorePosition(snapshot)
+			return
 		}
+		o.syncExchangeExits()
+		log.Printf("⌛ Aged %s position unwound %d/%d: %.4f after %s (%s policy)",
+			positionType, unwind.Step, unwind.Steps, quantity, unwind.Age.Round(time.Minute), unwind.Policy)
 	}
 
-	if !allowed {
-		return fmt.Errorf("transition from %s to %s is not allowed", currentMode, newMode)
+	if o.execution.AlgoFor(execution.IntentAgedUnwind) == execution.AlgoMarket {
+		submit(ctx)
+		return
 	}
+	log.Printf("⌛ Working aged %s position out via %s: %.4f (step %d/%d)",
+		positionType, o.execution.AlgoFor(execution.IntentAgedUnwind), quantity, unwind.Step, unwind.Steps)
+	o.wg.Add(1)
+	go func() {
+		defer o.wg.Done()
+		submit(o.ctx)
+	}()
+}
 
-	// Perform mode transition
-	oldMode := o.state.Mode
-	o.state.Mode = newMode
-	o.state.LastUpdateTime = time.Now()
-
-	log.Printf("🔄 Mode transition: %s -> %s", oldMode, newMode)
+// closeBreakoutPosition flattens any tracked breakout position; the close is booked at the executor's fill
+// once the exit order is accepted, against the position as it is by then
+func (o *Orchestrator) closeBreakoutPosition(ctx context.Context, price float64, reason string) {
+	o.positionMu.Lock()
+	state, exists := o.positionManager.GetPosition(o.activeSymbol)
+	if !exists {
+		o.positionMu.Unlock()
+		return
+	}
+	positionType := state.Position.Type
+	size := state.Position.Size
+	o.positionMu.Unlock()
 
-	// Perform mode-specific setup
-	switch newMode {
-	case ModeGrid:
+	result, err := o.submitManagedOrder(ctx, positionType, size, true, "breakout-exit", types.NewOrderTag("breakout", "close"))
+	if err != nil {
+		log.Printf("❌ Failed to close breakout position: %v", err)
+		return
+	}
+	fill := exitFill(result, size, price)
+
+	// Exchange exits may have closed part or all of the position while the exit was sent
+	o.positionMu.Lock()
+	if current, open := o.positionManager.GetPosition(o.activeSymbol); !open || current != state {
+		o.positionMu.Unlock()
+		o.syncExchangeExits()
+		log.Printf("📉 Breakout position was closed on the exchange before its exit filled (%s)", reason)
+		return
+	}
+	_, err = o.positionManager.ClosePosition(o.activeSymbol, fill.Quantity, fill.Price, reason, strategy.TriggerStability)
+	o.recordClosedTrades([]*strategy.PositionState{state})
+	o.positionMu.Unlock()
+	o.syncExchangeExits()
+	if err != nil {
+		log.Printf("Error closing breakout position: %v", err)
+		return
+	}
+	log.Printf("📉 Breakout position closed: %s %.4f @ %.2f (%s)", positionType, fill.Quantity, fill.Price, reason)
+}
+
+// activePositionStates returns the tracked positions of the active symbol; caller must hold positionMu
+func (o *Orchestrator) activePositionStates() []*strategy.PositionState {
+	var states []*strategy.PositionState
+	for _, positionType := range []types.PositionType{types.PositionTypeLong, types.PositionTypeShort} {
+		state, exists := o.positionManager.GetPosition(o.positionManager.PositionKey(o.activeSymbol, positionType))
+		if exists && (len(states) == 0 || states[0] != state) {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// recordClosedTrades reports fully closed positions to the calibration tracker; caller must hold positionMu
+func (o *Orchestrator) recordClosedTrades(states []*strategy.PositionState) {
+	for _, state := range states {
+		if state.Position.Status != "closed" {
+			continue
+		}
+		closedTime := time.Now()
+		if state.Position.ExitTime != nil {
+			closedTime = *state.Position.ExitTime
+		}
+		o.calibration.RecordOutcome(state.Position.ID, state.Position.RealizedPnL, closedTime)
+	}
+}
+
+// managedOrderIntents maps managed order tags to execution intents
+var managedOrderIntents = map[string]execution.Intent{
+	"breakout-entry": execution.IntentBreakoutEntry,
+	"breakout-exit":  execution.IntentBreakoutExit,
+	"aged-unwind":    execution.IntentAgedUnwind,
+}
+
+// submitManagedOrder places a market order whose position effect is booked by the caller; large orders
+// may be split by the execution engine
+func (o *Orchestrator) submitManagedOrder(ctx context.Context, positionType types.PositionType, quantity float64, reduceOnly bool, tag, orderTag string) (*types.OrderResult, error) {
+	side := types.OrderSideBuy
+	if (positionType == types.PositionTypeLong) == reduceOnly {
+		side = types.OrderSideSell
+	}
+
+	ctx, span := o.tracer.Start(ctx, "order.submit")
+	defer span.End()
+	span.SetAttribute("side", string(side))
+	span.SetAttribute("quantity", quantity)
+	span.SetAttribute("reduce_only", reduceOnly)
+
+	order := types.NewMarketOrder("", o.activeSymbol, side, quantity, positionType)
+	order.SetReduceOnly(reduceOnly)
+	order.Tag = orderTag
+	if err := o.checkRiskPolicy(ctx, order); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	if err := o.checkRateLimit(reduceOnly); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Register before placing so the fill worker never books the fill twice
+	o.positionMu.Lock()
+	o.orderSeq++
+	order.ClientOrderID = types.NewClientOrderID(tag, 0, side, o.orderSeq)
+	o.managedOrders[order.ClientOrderID] = true
+	if price := o.candleAggregator.GetLatestPrice(o.activeSymbol); price > 0 {
+		o.expectedPrices[order.ClientOrderID] = price
+	}
+	o.positionMu.Unlock()
+
+	span.SetAttribute("client_order_id", order.ClientOrderID)
+	if traceID := tracing.TraceIDFromContext(ctx); traceID != "" {
+		o.logf(logging.ComponentExecutor, logging.DebugLevel, "🧵 Order %s submitted in trace %s", order.ClientOrderID, traceID)
+	}
+
+	// An order that cannot be persisted is not submitted, so a crash never loses an accepted order
+	intent, err := o.intents.Enqueue(order, tag)
+	if err == nil {
+		var result *types.OrderResult
+		execIntent := managedOrderIntents[tag]
+		if algo := o.execution.Route(execIntent, order); algo != execution.AlgoMarket {
+			result, err = o.executeManagedOrder(ctx, execIntent, algo, order)
+		} else {
+			result, err = trading.PlaceOrderWithRetry(o.tradingExecutor, order, o.config.OrderRetryAttempts, o.config.OrderRetryDelay)
+		}
+		if err == nil {
+			if ackErr := o.intents.Ack(intent.ID, result.OrderID); ackErr != nil {
+				o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to acknowledge order intent %s: %v", intent.ID, ackErr)
+			}
+			return result, nil
+		}
+		o.noteRateLimit(err)
+		if failErr := o.intents.Fail(intent.ID, err); failErr != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to record failed order intent %s: %v", intent.ID, failErr)
+		}
+	}
+
+	o.positionMu.Lock()
+	delete(o.managedOrders, order.ClientOrderID)
+	delete(o.expectedPrices, order.ClientOrderID)
+	o.positionMu.Unlock()
+	span.RecordError(err)
+	return nil, err
+}
+
+// executeManagedOrder works a managed order with an execution algorithm. Child orders are registered as
+// managed before they are placed so the fill worker does not book their fills a second time.
+func (o *Orchestrator) executeManagedOrder(ctx context.Context, intent execution.Intent, algo execution.Algo, order *types.Order) (*types.OrderResult, error) {
+	ctx, span := o.tracer.Start(ctx, "order.execute")
+	defer span.End()
+	span.SetAttribute("algo", string(algo))
+
+	defer func() {
+		// The parent is never placed itself, so no update clears it
+		o.positionMu.Lock()
+		delete(o.managedOrders, order.ClientOrderID)
+		delete(o.expectedPrices, order.ClientOrderID)
+		o.positionMu.Unlock()
+	}()
+
+	result, err := o.execution.Execute(ctx, intent, algo, order, func(child *types.Order) {
+		o.positionMu.Lock()
+		defer o.positionMu.Unlock()
+		o.managedOrders[child.ClientOrderID] = true
+		if price := o.candleAggregator.GetLatestPrice(o.activeSymbol); price > 0 && child.Type == types.OrderTypeMarket {
+			o.expectedPrices[child.ClientOrderID] = price
+		}
+	})
+	o.logExecution(intent, algo, result)
+	if result == nil || result.FilledQty <= 0 {
+		if err == nil {
+			err = fmt.Errorf("%s execution filled nothing", algo)
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+	if err != nil {
+		// Part of the order is on the books, so the caller still records the position
+		o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ %s execution of %s partially filled: %v", algo, order.ClientOrderID, err)
+	}
+
+	status := types.OrderStatusFilled
+	if result.FilledQty < result.Quantity {
+		status = types.OrderStatusPartial
+	}
+	now := time.Now()
+	return &types.OrderResult{
+		OrderID:      order.ClientOrderID,
+		Symbol:       order.Symbol,
+		Side:         string(order.Side),
+		PositionType: string(order.PositionType),
+		Quantity:     order.Quantity,
+		FilledQty:    result.FilledQty,
+		FilledPrice:  result.AvgPrice,
+		Fee:          result.Fee,
+		Commission:   result.Fee,
+		Timestamp:    now.Add(-result.Duration),
+		Status:       string(status),
+		ExecutedTime: now,
+	}, nil
+}
+
+// recoverOrderIntents resolves intents a previous run left pending: orders the exchange already has are
+// acknowledged, recent ones are resubmitted and the rest expire. Their fills are booked by the fill worker.
+func (o *Orchestrator) recoverOrderIntents() {
+	pending := o.intents.Pending()
+	if len(pending) == 0 {
+		return
+	}
+	if o.restPaused() {
+		// Lookups and resubmissions would extend the ban; the intents stay pending until it ends
+		o.deferredRecovery.Store(true)
+		log.Printf("⏸️ REST calls paused, recovering %d pending order intents when the ban ends", len(pending))
+		return
+	}
+	o.deferredRecovery.Store(false)
+	log.Printf("📥 Recovering %d pending order intents", len(pending))
+
+	for _, intent := range pending {
+		if orderID, found := o.findSubmittedOrder(intent); found {
+			log.Printf("✅ Order intent %s (%s) was already submitted as %s", intent.ID, intent.Order.ClientOrderID, orderID)
+			if err := o.intents.Ack(intent.ID, orderID); err != nil {
+				log.Printf("⚠️ Failed to acknowledge order intent %s: %v", intent.ID, err)
+			}
+			continue
+		}
+
+		if age := time.Since(intent.CreatedAt); age > o.intents.MaxAge() {
+			log.Printf("⚠️ Order intent %s (%s %.6f %s) expired after %v, not resubmitting",
+				intent.ID, intent.Order.Side, intent.Order.Quantity, intent.Order.Symbol, age.Round(time.Second))
+			if err := o.intents.Expire(intent.ID); err != nil {
+				log.Printf("⚠️ Failed to expire order intent %s: %v", intent.ID, err)
+			}
+			continue
+		}
+
+		if err := o.intents.Retry(intent.ID); err != nil {
+			log.Printf("⚠️ Failed to record retry of order intent %s: %v", intent.ID, err)
+			continue
+		}
+		order := intent.Order
+		result, err := trading.PlaceOrderWithRetry(o.tradingExecutor, &order, o.config.OrderRetryAttempts, o.config.OrderRetryDelay)
+		if err != nil {
+			log.Printf("❌ Failed to resubmit order intent %s: %v", intent.ID, err)
+			if failErr := o.intents.Fail(intent.ID, err); failErr != nil {
+				log.Printf("⚠️ Failed to record failed order intent %s: %v", intent.ID, failErr)
+			}
+			continue
+		}
+		log.Printf("🔁 Resubmitted order intent %s: %s %.6f %s as %s",
+			intent.ID, order.Side, order.Quantity, order.Symbol, result.OrderID)
+		if err := o.intents.Ack(intent.ID, result.OrderID); err != nil {
+			log.Printf("⚠️ Failed to acknowledge order intent %s: %v", intent.ID, err)
+		}
+	}
+}
+
+// findSubmittedOrder looks up the exchange order placed for an intent by its client order ID, or the first
+// child order of a split execution. Orders created well before the intent belong to an earlier session
+// that reused the ID.
+func (o *Orchestrator) findSubmittedOrder(intent journal.OrderIntent) (string, bool) {
+	notBefore := intent.CreatedAt.Add(-5 * time.Second)
+	childPrefix := intent.Order.ClientOrderID + "-"
+	matches := func(orders []*types.Order) (string, bool) {
+		for _, order := range orders {
+			sameOrder := order.ClientOrderID == intent.Order.ClientOrderID || strings.HasPrefix(order.ClientOrderID, childPrefix)
+			if sameOrder && !order.CreateTime.Before(notBefore) {
+				return order.ID, true
+			}
+		}
+		return "", false
+	}
+
+	if orders, err := o.tradingExecutor.GetOpenOrders(intent.Order.Symbol); err == nil {
+		if orderID, found := matches(orders); found {
+			return orderID, true
+		}
+	}
+	if orders, err := o.tradingExecutor.GetOrderHistory(intent.Order.Symbol, 100); err == nil {
+		return matches(orders)
+	}
+	return "", false
+}
+
+// handleFalseBreakoutSignal handles false breakout signals
+func (o *Orchestrator) handleFalseBreakoutSignal(signal TradingSignal) {
+	falseBreakoutData := signal.Data.(*strategy.FalseBreakoutSignal)
+
+	// Update breakout info
+	o.mu.Lock()
+	if o.state.BreakoutInfo != nil {
+		o.state.BreakoutInfo.FalseBreakoutDetected = true
+		o.state.BreakoutInfo.RecoveryAction = falseBreakoutData.RecoveryAction
+		o.recordBreakoutOutcome(o.state.BreakoutInfo, journal.BreakoutFalse)
+	}
+	o.mu.Unlock()
+
+	// Execute recovery action if specified
+	o.executeRecoveryAction(signal.Context, falseBreakoutData.RecoveryAction, falseBreakoutData)
+
+	// Switch to recovery mode
+	o.switchMode(ModeRecovery)
+
+	log.Printf("⚠️ False breakout detected: %s (confidence: %.2f)",
+		falseBreakoutData.RecoveryAction, falseBreakoutData.Confidence)
+}
+
+// handleStabilitySignal handles price stability signals
+func (o *Orchestrator) handleStabilitySignal(signal TradingSignal) {
+	// Exit the breakout trade before grid trading resumes
+	price := signal.Price
+	if price == 0 {
+		price = o.candleAggregator.GetLatestPrice(o.activeSymbol)
+	}
+	o.closeBreakoutPosition(signal.Context, price, "Price stabilized")
+
+	// Switch to grid mode
+	o.switchMode(ModeGrid)
+
+	log.Printf("✅ Price stability confirmed: %s", signal.Reason)
+}
+
+// handleGridSetupSignal handles grid setup signals
+func (o *Orchestrator) handleGridSetupSignal(signal TradingSignal) {
+	// Grid setup is handled during initialization
+	log.Printf("🔧 Grid setup completed: %s", signal.Reason)
+}
+
+// executeRecoveryAction executes recovery actions for false breakouts
+func (o *Orchestrator) executeRecoveryAction(ctx context.Context, action string, data interface{}) {
+	// Get current position
+	position, err := o.tradingExecutor.GetPosition(o.activeSymbol)
+	if err != nil {
+		log.Printf("Error getting position for recovery: %v", err)
+		return
+	}
+
+	if position == nil {
+		return // No position to recover
+	}
+
+	switch action {
+	case "Close position and take profit":
+		if err := o.closeExecutorPosition(ctx, position, execution.IntentRecoveryClose, types.NewOrderTag("recovery", "take-profit")); err != nil {
+			log.Printf("Error closing position for profit: %v", err)
+		}
+
+	case "Close position to minimize loss":
+		if err := o.closeExecutorPosition(ctx, position, execution.IntentRecoveryClose, types.NewOrderTag("recovery", "cut-loss")); err != nil {
+			log.Printf("Error closing position for loss: %v", err)
+		}
+
+	case "Consider taking opposite position":
+		// Close current position first
+		if err := o.closeExecutorPosition(ctx, position, execution.IntentRecoveryClose, types.NewOrderTag("recovery", "reverse")); err != nil {
+			log.Printf("Error closing position before reversal: %v", err)
+			return
+		}
+
+		// Spot accounts cannot reverse a long into a short
+		if o.config.MarketType.IsSpot() && position.Type == types.PositionTypeLong {
+			return
+		}
+
+		// Calculate opposite position size
+		oppositeSize := math.Abs(position.Size) * 0.8 // 80% of original size as opposite position
+
+		reversal := types.NewMarketOrder("", o.activeSymbol, types.OrderSideBuy, oppositeSize, types.PositionTypeLong)
+		if position.Type == types.PositionTypeLong {
+			reversal = types.NewMarketOrder("", o.activeSymbol, types.OrderSideSell, oppositeSize, types.PositionTypeShort)
+		}
+		if err := o.checkRiskPolicy(ctx, reversal); err != nil {
+			return
+		}
+
+		// Was long, now go short; or was short, now go long
+		reversal.Tag = types.NewOrderTag("recovery", "reverse")
+		if _, err := o.tradingExecutor.PlaceOrder(reversal); err != nil {
+			log.Printf("Error opening %s position: %v", reversal.PositionType, err)
+		}
+	}
+}
+
+// switchMode switches the trading mode with validation
+func (o *Orchestrator) switchMode(newMode TradingMode) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if lock := o.state.ModeLock; lock != nil && newMode != lock.Mode {
+		return o.suppressTransition(newMode)
+	}
+	return o.transitionMode(newMode)
+}
+
+// transitionMode performs a validated mode transition and the new mode's setup; caller must hold o.mu
+func (o *Orchestrator) transitionMode(newMode TradingMode) error {
+	currentMode := o.state.Mode
+
+	// Validate transition
+	allowedModes, exists := o.modeTransitions[currentMode]
+	if !exists {
+		return fmt.Errorf("no transitions defined for mode %s", currentMode)
+	}
+
+	allowed := false
+	for _, mode := range allowedModes {
+		if mode == newMode {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return fmt.Errorf("transition from %s to %s is not allowed", currentMode, newMode)
+	}
+
+	// Perform mode transition
+	oldMode := o.state.Mode
+	o.state.Mode = newMode
+	o.state.LastUpdateTime = time.Now()
+	o.state.ModeSince = o.state.LastUpdateTime
+
+	o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "🔄 Mode transition: %s -> %s", oldMode, newMode)
+
+	transition := ModeTransition{From: oldMode, To: newMode, Timestamp: o.state.LastUpdateTime}
+	o.modeHistory = append(o.modeHistory, transition)
+	if len(o.modeHistory) > maxSessionEvents {
+		o.modeHistory = o.modeHistory[1:]
+	}
+	o.events.Publish(o.ctx, TopicModeChange, transition)
+	o.sendWebhook(WebhookEventModeTransition, o.activeSymbol, newMode, transition)
+	o.annotate(AnnotationModeTransition, o.activeSymbol, fmt.Sprintf("Mode %s -> %s", oldMode, newMode))
+
+	// Grid orders only rest on the book while in grid mode
+	if oldMode == ModeGrid && newMode != ModeGrid {
+		o.cancelGridOrders()
+	}
+
+	// Perform mode-specific setup
+	switch newMode {
+	case ModeGrid:
 		return o.setupGridMode()
 	case ModeBreakout:
 		return o.setupBreakoutMode()
@@ -839,16 +2586,16 @@ func (o *Orchestrator) waitForPriceAndInitializeGrid() {
 				currentPrice := o.candleAggregator.GetLatestPrice(o.activeSymbol)
 				if currentPrice > 0 {
 					havePrice = true
-					log.Printf("📊 Received initial price data: %.2f, waiting for sufficient historical data...", currentPrice)
+					o.logf(logging.ComponentStream, logging.InfoLevel, "📊 Received initial price data: %.2f, waiting for sufficient historical data...", currentPrice)
 				}
 			}
 		case <-ticker.C:
-			// Every 3 seconds, check if we have sufficient data for grid setup
-			historicalCandles := o.candleAggregator.GetCandles(o.activeSymbol, data.Timeframe3s, 50)
+			// Every 3 seconds, check if the indicators have warmed up for grid setup
+			warmup := o.technicalAnalyzer.GetWarmup(o.activeSymbol)
 
-			if len(historicalCandles) >= 50 {
+			if warmup.Ready {
 				currentPrice := o.candleAggregator.GetLatestPrice(o.activeSymbol)
-				log.Printf("📈 Sufficient data collected: %d candles, current price: %.2f", len(historicalCandles), currentPrice)
+				o.logf(logging.ComponentStream, logging.InfoLevel, "📈 Indicators warmed up: %d candles, current price: %.2f", warmup.Have, currentPrice)
 
 				// Check market conditions
 				suitable, reason := o.gridSetup.ShouldSetupGrid(o.activeSymbol)
@@ -873,8 +2620,8 @@ func (o *Orchestrator) waitForPriceAndInitializeGrid() {
 				return
 			} else {
 				if havePrice {
-					log.Printf("⏳ Collecting data: %d/50 candles needed (%.1f%% complete)",
-						len(historicalCandles), float64(len(historicalCandles))*100/50)
+					o.logf(logging.ComponentStream, logging.InfoLevel, "⏳ Warming up indicators: %d/%d candles (%.1f%% complete), waiting on %s",
+						warmup.Have, warmup.Required, warmup.Progress()*100, strings.Join(warmup.Pending(), ", "))
 				}
 			}
 		}
@@ -883,11 +2630,17 @@ func (o *Orchestrator) waitForPriceAndInitializeGrid() {
 
 // setupGridMode sets up grid trading mode
 func (o *Orchestrator) setupGridMode() error {
-	// Reset breakout info
+	// Reset breakout info; a breakout ending here never confirmed nor reversed
+	o.recordBreakoutOutcome(o.state.BreakoutInfo, journal.BreakoutUnconfirmed)
 	o.state.BreakoutInfo = nil
 
 	// Initialize or reinitialize grid
-	return o.initializeGridTrading()
+	if err := o.initializeGridTrading(); err != nil {
+		return err
+	}
+
+	o.placeGridOrders(o.ctx)
+	return nil
 }
 
 // setupBreakoutMode sets up breakout mode
@@ -929,28 +2682,44 @@ func (o *Orchestrator) setupIdleMode() error {
 
 // initializeGridTrading initializes grid trading setup
 func (o *Orchestrator) initializeGridTrading() error {
+	plan, err := o.planGrid()
+	if err != nil {
+		return err
+	}
+	if err := o.rebuildGrid(plan); err != nil {
+		return err
+	}
+	o.state.GridBounds = plan.bounds()
+	return nil
+}
+
+// planGrid analyzes the market and calculates the grid layout without touching resting orders
+func (o *Orchestrator) planGrid() (*gridPlan, error) {
 	// Get current price
 	currentPrice := o.candleAggregator.GetLatestPrice(o.activeSymbol)
 	if currentPrice == 0 {
-		return fmt.Errorf("no current price available for grid setup")
+		return nil, fmt.Errorf("no current price available for grid setup")
 	}
 
-	// Require sufficient historical data for proper analysis
-	historicalCandles := o.candleAggregator.GetCandles(o.activeSymbol, data.Timeframe3s, 50)
-	if len(historicalCandles) < 50 {
-		return fmt.Errorf("insufficient historical data for grid setup: need 50 candles, have %d", len(historicalCandles))
+	// Require warmed-up indicators for proper analysis
+	if warmup := o.technicalAnalyzer.GetWarmup(o.activeSymbol); !warmup.Ready {
+		return nil, fmt.Errorf("indicators not warmed up for grid setup: have %d of %d candles", warmup.Have, warmup.Required)
 	}
 
 	// Check if market conditions are suitable for grid trading
 	suitable, reason := o.gridSetup.ShouldSetupGrid(o.activeSymbol)
 	if !suitable {
-		return fmt.Errorf("market conditions not suitable for grid trading: %s", reason)
+		return nil, fmt.Errorf("market conditions not suitable for grid trading: %s", reason)
 	}
 
 	// Perform comprehensive market analysis for grid setup
-	gridParams, err := o.gridSetup.AnalyzeAndSetup(o.activeSymbol, o.config.InitialBalance)
+	gridCapital := o.config.InitialBalance
+	if o.capitalAllocator != nil {
+		gridCapital = o.capitalAllocator.Capital(strategy.BucketGrid)
+	}
+	gridParams, err := o.gridSetup.AnalyzeAndSetup(o.activeSymbol, gridCapital)
 	if err != nil {
-		return fmt.Errorf("failed to analyze market for grid setup: %w", err)
+		return nil, fmt.Errorf("failed to analyze market for grid setup: %w", err)
 	}
 
 	// Calculate optimal grid parameters based on actual market analysis
@@ -964,115 +2733,1967 @@ func (o *Orchestrator) initializeGridTrading() error {
 	gridCalcResult := o.gridCalculator.CalculateOptimalGrid(
 		currentPrice,
 		gridParams.Volatility,
-		o.config.InitialBalance,
+		gridCapital,
 		volatilityCategory,
 	)
 
-	// Set grid bounds based on analysis
-	o.state.GridBounds = strategy.GridBounds{
-		UpperBound: gridCalcResult.UpperBound,
-		LowerBound: gridCalcResult.LowerBound,
-		Center:     currentPrice,
-		Range:      gridCalcResult.TotalRange,
+	// Apply operator overrides on top of the calculated layout, then the fee budget and volatility throttles
+	o.gridMu.Lock()
+	override := o.gridOverride
+	o.gridMu.Unlock()
+	if override != nil {
+		if err := override.apply(gridCalcResult, currentPrice); err != nil {
+			return nil, err
+		}
 	}
-
-	log.Printf("✅ Grid trading initialized: Center=%.2f, Upper=%.2f, Lower=%.2f, Range=%.2f%%, Levels=%d, Spacing=%.2f%%, Volatility=%.3f (%s)",
-		currentPrice, gridCalcResult.UpperBound, gridCalcResult.LowerBound,
-		gridCalcResult.TotalRange*100, gridCalcResult.GridLevels, gridCalcResult.GridSpacing*100,
-		gridParams.Volatility, volatilityCategory)
-
-	return nil
+	o.feeGovernor.Throttle().Apply(gridCalcResult, currentPrice)
+	o.volatilityBreaker.Throttle(o.activeSymbol).Apply(gridCalcResult, currentPrice)
+
+	return &gridPlan{
+		price:      currentPrice,
+		result:     gridCalcResult,
+		volatility: gridParams.Volatility,
+		category:   volatilityCategory,
+	}, nil
 }
 
-// riskManagementWorker handles risk management
-func (o *Orchestrator) riskManagementWorker() {
-	defer o.wg.Done()
+// rebuildGrid cancels the resting grid orders and lays out the planned levels. The new levels are only
+// built once no grid order is left on the exchange, so a failed cancel cannot leave an orphaned order.
+// The caller records the plan's bounds in the bot state.
+func (o *Orchestrator) rebuildGrid(plan *gridPlan) error {
+	o.gridMu.Lock()
+	defer o.gridMu.Unlock()
 
-	ticker := time.NewTicker(o.config.HealthCheckInterval)
-	defer ticker.Stop()
+	o.cancelGridOrders()
+	if err := o.sweepGridOrders(); err != nil {
+		return err
+	}
 
-	for {
-		select {
-		case <-o.ctx.Done():
-			return
+	// Lay out grid levels; spot sell levels need base inventory to back them
+	inventory := float64(0)
+	if o.config.MarketType.IsSpot() {
+		if position, err := o.tradingExecutor.GetPosition(o.activeSymbol); err == nil && position != nil {
+			inventory = position.Size
+		}
+	}
+	result := plan.result
+	if err := o.gridEngine.BuildGrid(o.activeSymbol, result.UpperBound, result.LowerBound,
+		result.GridLevels, result.PositionSize*o.riskManager.GetSizeMultiplier(), plan.price, inventory); err != nil {
+		return fmt.Errorf("failed to build grid levels: %w", err)
+	}
 
-		case <-ticker.C:
-			// Perform risk assessment
-			riskAssessment := o.riskManager.AssessRisk()
+	log.Printf("✅ Grid trading initialized: Center=%.2f, Upper=%.2f, Lower=%.2f, Range=%.2f%%, Levels=%d, Spacing=%.2f%% %s, Volatility=%.3f (%s)",
+		plan.price, result.UpperBound, result.LowerBound,
+		result.TotalRange*100, result.GridLevels, result.GridSpacing*100, result.SpacingMode,
+		plan.volatility, plan.category)
 
-			// Send risk alerts if needed
-			if riskAssessment.PortfolioHealth != "healthy" {
-				o.riskChan <- RiskAlert{
-					Level:     riskAssessment.PortfolioHealth,
-					Type:      "portfolio_health",
-					Message:   fmt.Sprintf("Portfolio health: %s (risk level: %.2f)",
-						riskAssessment.PortfolioHealth, riskAssessment.OverallRiskLevel),
-					Timestamp: time.Now(),
-				}
-			}
+	return nil
+}
 
-			// Check for critical risk conditions
-			if riskAssessment.MarginCallRisk {
-				o.handleCriticalRisk("margin_call", riskAssessment)
-			}
+// sweepGridOrders cancels grid orders still open on the exchange for the active symbol, such as an
+// order whose cancel failed or that was placed while the grid was being reset
+func (o *Orchestrator) sweepGridOrders() error {
+	if o.tradingExecutor == nil {
+		return nil
+	}
 
-		case alert := <-o.riskChan:
-			o.handleRiskAlert(alert)
+	orders, err := o.tradingExecutor.GetOpenOrders(o.activeSymbol)
+	if err != nil {
+		return fmt.Errorf("failed to check for leftover grid orders: %w", err)
+	}
+	remaining := 0
+	for _, order := range orders {
+		if !strategy.IsGridOrder(order.ClientOrderID) {
+			continue
+		}
+		if err := o.tradingExecutor.CancelOrder(order.ID); err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to cancel leftover grid order %s: %v", order.ID, err)
+			remaining++
+			continue
 		}
+		o.logf(logging.ComponentExecutor, logging.InfoLevel, "🧹 Cancelled leftover grid order %s", order.ID)
 	}
+	if remaining > 0 {
+		return fmt.Errorf("%d grid orders are still open on the exchange; grid not rebuilt", remaining)
+	}
+	return nil
 }
 
-// handleCriticalRisk handles critical risk conditions
-func (o *Orchestrator) handleCriticalRisk(riskType string, assessment *strategy.RiskAssessment) {
-	log.Printf("🚨 CRITICAL RISK DETECTED: %s", riskType)
+// updateGrid applies operator overrides to the grid layout. In grid mode the grid is re-planned first,
+// so an update that cannot be applied leaves the resting orders untouched; otherwise the overrides are
+// kept for the next grid setup. Only the plan is computed under o.mu: cancelling and placing orders and
+// the risk alerts they raise would otherwise stall every reader of the bot state.
+func (o *Orchestrator) updateGrid(update GridUpdate) error {
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	o.gridMu.Lock()
+	previous := o.gridOverride
+	o.gridOverride = update.Merge(previous)
+	o.gridMu.Unlock()
+
+	if o.state.Mode != ModeGrid {
+		o.mu.Unlock()
+		log.Printf("🎛️ Grid update stored; it applies when grid trading starts")
+		return nil
+	}
+
+	plan, err := o.planGrid()
+	if err != nil {
+		o.gridMu.Lock()
+		o.gridOverride = previous
+		o.gridMu.Unlock()
+		o.mu.Unlock()
+		return fmt.Errorf("grid update rejected: %w", err)
+	}
+	o.mu.Unlock()
+
+	if err := o.rebuildGrid(plan); err != nil {
+		return fmt.Errorf("grid update failed: %w", err)
+	}
+
+	o.mu.Lock()
+	if o.state.Mode != ModeGrid {
+		// The mode changed while the grid was rebuilt; its levels must not be placed
+		o.mu.Unlock()
+		o.gridMu.Lock()
+		o.cancelGridOrders()
+		o.gridMu.Unlock()
+		return fmt.Errorf("grid update abandoned: mode changed to %s", o.currentMode())
+	}
+	o.state.GridBounds = plan.bounds()
+	o.mu.Unlock()
+
+	o.placeGridOrders(o.ctx)
+	return nil
+}
+
+// GetGridOverride returns the operator overrides of the grid layout, or nil if there are none
+func (o *Orchestrator) GetGridOverride() *GridUpdate {
+	o.gridMu.Lock()
+	defer o.gridMu.Unlock()
+
+	if o.gridOverride == nil {
+		return nil
+	}
+	override := *o.gridOverride
+	return &override
+}
+
+// placeGridOrders places limit orders for grid levels that are not on the book yet; ctx carries the trace
+// of the tick or signal that caused the placement
+func (o *Orchestrator) placeGridOrders(ctx context.Context) {
+	if o.tradingExecutor == nil || o.restPaused() {
+		return
+	}
+	ctx, span := o.tracer.Start(ctx, "grid.place")
+	defer span.End()
+	o.gridMu.Lock()
+	defer o.gridMu.Unlock()
+
+	// Unloading reduces exposure, so it is not gated on entries being allowed
+	if order := o.gridEngine.NextUnloadOrder(); order != nil {
+		result, err := trading.PlaceOrderWithRetry(o.tradingExecutor, order, o.config.OrderRetryAttempts, o.config.OrderRetryDelay)
+		if err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to place grid inventory unload %s %.6f at %.2f: %v", order.Side, order.Quantity, order.Price, err)
+			o.gridEngine.ReleaseOrder(order.ClientOrderID)
+			if o.noteRateLimit(err) {
+				return
+			}
+		} else {
+			o.gridEngine.ConfirmOrder(order.ClientOrderID, result.OrderID)
+			o.logf(logging.ComponentExecutor, logging.InfoLevel, "⚖️ Grid inventory unload: %s %.6f %s at %.2f", order.Side, order.Quantity, order.Symbol, order.Price)
+		}
+	}
+
+	if !o.entriesAllowed() {
+		return
+	}
+	levels := o.gridEngine.GetUnplacedLevels()
+	if len(levels) == 0 || !o.checkLiquidity("grid orders") {
+		return
+	}
+	levels = o.applyOrderBudget(levels)
+	span.SetAttribute("levels", len(levels))
+
+	for _, level := range levels {
+		clientOrderID, err := o.gridEngine.AssignOrder(level.ID)
+		if err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to assign grid level %s: %v", level.ID, err)
+			continue
+		}
+
+		order := types.NewLimitOrder("", level.Symbol, level.Side, level.Quantity, level.Price, "")
+		order.ClientOrderID = clientOrderID
+		order.Tag = o.gridEngine.OrderTag(clientOrderID)
+		if err := o.checkRiskPolicy(ctx, order); err != nil {
+			o.gridEngine.ReleaseOrder(clientOrderID)
+			continue
+		}
+
+		result, err := trading.PlaceOrderWithRetry(o.tradingExecutor, order, o.config.OrderRetryAttempts, o.config.OrderRetryDelay)
+		if err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to place grid %s order at %.2f: %v", level.Side, level.Price, err)
+			o.gridEngine.ReleaseOrder(clientOrderID)
+			if o.noteRateLimit(err) {
+				return
+			}
+			continue
+		}
+		o.gridEngine.ConfirmOrder(clientOrderID, result.OrderID)
+	}
+}
+
+// applyOrderBudget trims grid levels to the room left under the open order caps, keeping those nearest
+// to price; the rest are placed as fills and cancellations free up room
+func (o *Orchestrator) applyOrderBudget(levels []*types.GridLevel) []*types.GridLevel {
+	if !o.orderBudget.Enabled() {
+		return levels
+	}
+
+	open, err := o.tradingExecutor.GetOpenOrders("")
+	if err != nil {
+		// The exchange still rejects orders beyond its own cap
+		o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to count open orders for the order budget: %v", err)
+		o.noteRateLimit(err)
+		return levels
+	}
+	available, limited := o.orderBudget.Available(o.activeSymbol, open)
+	if !limited || available >= len(levels) {
+		return levels
+	}
+
+	price := o.candleAggregator.GetLatestPrice(o.activeSymbol)
+	o.orderBudget.RecordDeferred(len(levels) - available)
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "📋 Order budget: placing %d of %d grid levels nearest to %.2f (%d orders open)",
+		available, len(levels), price, len(open))
+	return o.gridEngine.PrioritizeLevels(levels, price, available)
+}
+
+// cancelGridOrders cancels all resting grid orders and clears the grid levels
+func (o *Orchestrator) cancelGridOrders() {
+	orderIDs := o.gridEngine.Reset()
+	if o.tradingExecutor == nil {
+		return
+	}
+	if o.restPaused() {
+		o.deferCancels(orderIDs)
+		return
+	}
+
+	for _, orderID := range orderIDs {
+		if err := o.tradingExecutor.CancelOrder(orderID); err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to cancel grid order %s: %v", orderID, err)
+			o.noteRateLimit(err)
+		}
+	}
+	if len(orderIDs) > 0 {
+		o.logf(logging.ComponentExecutor, logging.InfoLevel, "🧹 Cancelled %d grid orders", len(orderIDs))
+	}
+}
+
+// riskManagementWorker handles risk management
+func (o *Orchestrator) riskManagementWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			// Risk checks read the account over REST, so they wait out a rate limit ban
+			if o.restPaused() {
+				continue
+			}
+			if marginInfo, err := o.tradingExecutor.GetMarginInfo(); err != nil {
+				o.noteRateLimit(err)
+			} else {
+				// Swept profit was earned, so drawdown and the equity curve still count it
+				equity := o.currency.Convert(marginInfo.Currency, marginInfo.TotalBalance) + o.profitSweeper.TotalSwept()
+				o.syncRiskPortfolio(equity)
+				o.checkDrawdownPolicy(equity)
+				o.checkDailyLoss()
+				o.checkEquityCurve(equity)
+				o.checkFeeBudget()
+				o.checkLeverage()
+				o.checkLiquidation(marginInfo.TotalBalance)
+				o.checkMarginCall(marginInfo)
+			}
+			o.journalAccountSnapshot(time.Now())
+
+			// Perform risk assessment
+			riskAssessment := o.riskManager.AssessRisk()
+
+			// Send risk alerts if needed
+			if riskAssessment.PortfolioHealth != "healthy" {
+				o.publishRiskAlert(RiskAlert{
+					Level:     riskAssessment.PortfolioHealth,
+					Type:      "portfolio_health",
+					Message:   fmt.Sprintf("Portfolio health: %s (risk level: %.2f)",
+						riskAssessment.PortfolioHealth, riskAssessment.OverallRiskLevel),
+					Timestamp: time.Now(),
+				})
+			}
+
+			// Check for critical risk conditions
+			if riskAssessment.MarginCallRisk {
+				o.handleCriticalRisk("margin_call", riskAssessment)
+			}
+
+		case event := <-o.riskEvents.Events():
+			o.handleRiskAlert(event.Payload.(RiskAlert))
+		}
+	}
+}
+
+// handleCriticalRisk handles critical risk conditions
+func (o *Orchestrator) handleCriticalRisk(riskType string, assessment *strategy.RiskAssessment) {
+	o.logf(logging.ComponentRisk, logging.ErrorLevel, "🚨 CRITICAL RISK DETECTED: %s", riskType)
+
+	// Alerts are already sent by handleRiskAlert; only direct assessments are sent here
+	if assessment != nil {
+		o.mu.RLock()
+		mode := o.state.Mode
+		o.mu.RUnlock()
+		o.sendWebhook(WebhookEventCriticalRisk, o.activeSymbol, mode, RiskAlert{
+			Level:     "critical",
+			Type:      riskType,
+			Message:   fmt.Sprintf("Critical risk: %s (risk level: %.2f)", riskType, assessment.OverallRiskLevel),
+			Value:     assessment.OverallRiskLevel,
+			Timestamp: time.Now(),
+		})
+	}
 
 	// Emergency actions
 	switch riskType {
-	case "margin_call":
+	case "margin_call", "forced_liquidation":
 		// Close all positions immediately
-		if err := o.closeAllPositions(); err != nil {
+		if err := o.closeAllPositions(types.NewOrderTag("risk", riskType)); err != nil {
 			log.Printf("Error closing positions in emergency: %v", err)
 		}
 		// Switch to idle mode
 		o.switchMode(ModeIdle)
+	case "health":
+		// Stop placing orders until an operator resumes; open positions keep their protection
+		o.mu.RLock()
+		mode := o.state.Mode
+		o.mu.RUnlock()
+		if mode != ModeIdle {
+			o.switchMode(ModeIdle)
+		}
+	}
+}
+
+// handleRiskAlert handles risk alerts
+func (o *Orchestrator) handleRiskAlert(alert RiskAlert) {
+	o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ Risk Alert [%s]: %s", alert.Level, alert.Message)
+
+	o.mu.Lock()
+	o.riskAlerts = append(o.riskAlerts, alert)
+	if len(o.riskAlerts) > maxSessionEvents {
+		o.riskAlerts = o.riskAlerts[1:]
+	}
+	mode := o.state.Mode
+	o.mu.Unlock()
+
+	if alert.Level == "critical" {
+		o.sendWebhook(WebhookEventCriticalRisk, alert.Symbol, mode, alert)
+	}
+	o.annotate(AnnotationRiskAlert, alert.Symbol, fmt.Sprintf("[%s] %s", alert.Level, alert.Message))
+
+	// Take action based on alert level
+	if alert.Action != "" {
+		o.executeDeRiskAction(strategy.DeRiskLevel(alert.Action))
+	} else if alert.Level == "critical" {
+		o.handleCriticalRisk(alert.Type, nil)
+	}
+}
+
+// checkDrawdownPolicy feeds account equity to the risk manager and emits any de-risking step as an alert
+func (o *Orchestrator) checkDrawdownPolicy(equity float64) {
+	action := o.riskManager.UpdateEquity(equity)
+	o.saveHighWaterMark()
+	if action == nil {
+		return
+	}
+
+	alert := RiskAlert{
+		Level:     "warning",
+		Type:      "drawdown",
+		Symbol:    o.activeSymbol,
+		Value:     action.Drawdown,
+		Threshold: action.Threshold,
+		Action:    string(action.Level),
+		Timestamp: time.Now(),
+	}
+	switch action.Level {
+	case strategy.DeRiskReduce:
+		alert.Message = fmt.Sprintf("Drawdown %.2f%%: position sizes cut by policy", action.Drawdown*100)
+	case strategy.DeRiskHalt:
+		alert.Message = fmt.Sprintf("Drawdown %.2f%%: new entries halted", action.Drawdown*100)
+	case strategy.DeRiskFlatten:
+		alert.Level = "critical"
+		alert.Message = fmt.Sprintf("Drawdown %.2f%%: flattening and idling until %s",
+			action.Drawdown*100, action.CooldownUntil.Format("15:04:05"))
+	default:
+		alert.Level = "info"
+		alert.Message = fmt.Sprintf("Drawdown %.2f%%: normal trading restored", action.Drawdown*100)
+	}
+	o.publishRiskAlert(alert)
+}
+
+// checkDailyLoss updates the day's PnL with the unrealized PnL of open positions, flattens and halts
+// entries for the rest of the accounting day when the daily loss limit is hit, and checkpoints the day's PnL
+func (o *Orchestrator) checkDailyLoss() {
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		return
+	}
+	unrealized := 0.0
+	for _, position := range positions {
+		unrealized += position.UnrealizedPnL
+	}
+
+	now := time.Now()
+	if breach := o.dailyLoss.Update(unrealized, now); breach != nil {
+		o.savePnLCheckpoint()
+		o.publishRiskAlert(RiskAlert{
+			Level:     "critical",
+			Type:      "daily_loss",
+			Symbol:    o.activeSymbol,
+			Message:   fmt.Sprintf("Daily loss %.2f reached limit %.2f: flattening and halting entries until the next accounting day", -breach.PnL, breach.Limit),
+			Value:     -breach.PnL,
+			Threshold: breach.Limit,
+			Action:    string(strategy.DeRiskFlatten),
+			Timestamp: now,
+		})
+	}
+
+	// A new accounting day lifts the halt
+	halted := o.dailyLoss.IsHalted()
+	if o.dailyLossHalted && !halted {
+		o.publishRiskAlert(RiskAlert{
+			Level:     "info",
+			Type:      "daily_loss",
+			Symbol:    o.activeSymbol,
+			Message:   "New accounting day: daily loss limit reset, entries resumed",
+			Action:    string(strategy.DeRiskNormal),
+			Timestamp: now,
+		})
+	}
+	o.dailyLossHalted = halted
+
+	if now.Sub(o.lastPnLCheckpoint) >= o.config.PnLCheckpointInterval {
+		o.lastPnLCheckpoint = now
+		o.savePnLCheckpoint()
+	}
+}
+
+// savePnLCheckpoint persists the day's PnL
+func (o *Orchestrator) savePnLCheckpoint() {
+	if o.pnlCheckpoints == nil {
+		return
+	}
+	if err := o.pnlCheckpoints.Save(o.dailyLoss.Snapshot()); err != nil {
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ Failed to save daily PnL checkpoint: %v", err)
+	}
+}
+
+// saveHighWaterMark persists the equity high-water mark of the account when it has moved
+func (o *Orchestrator) saveHighWaterMark() {
+	if o.hwmCheckpoints == nil {
+		return
+	}
+	hwm := o.riskManager.HighWaterMark()
+	if hwm.Peak == o.savedHWM.Peak && hwm.MaxDrawdownReached == o.savedHWM.MaxDrawdownReached {
+		return
+	}
+	hwm.Account = o.config.AccountID
+	o.hwmAccounts[o.config.AccountID] = hwm
+	if err := o.hwmCheckpoints.Save(o.hwmAccounts); err != nil {
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ Failed to save equity high-water mark: %v", err)
+		return
+	}
+	o.savedHWM = hwm
+}
+
+// checkLiquidation refreshes the liquidation estimates in the bot state and raises an alert when a
+// position moves inside the liquidation buffer, escalating to critical within half of it
+func (o *Orchestrator) checkLiquidation(equity float64) {
+	o.positionMu.Lock()
+	positions := make(map[string]types.Position)
+	for key, state := range o.positionManager.GetAllPositions() {
+		if !state.Position.IsFlat() && state.Position.Status != "closed" {
+			positions[key] = *state.Position
+		}
+	}
+	o.positionMu.Unlock()
+
+	keys := make([]string, 0, len(positions))
+	for key := range positions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	estimates := make([]strategy.LiquidationEstimate, 0, len(positions))
+	levels := make(map[string]string)
+	for _, key := range keys {
+		position := positions[key]
+		if price := o.candleAggregator.GetLatestPrice(position.Symbol); price > 0 {
+			position.UpdateMarkPrice(price)
+		}
+		estimate := o.riskManager.EstimateLiquidation(&position, equity)
+		estimates = append(estimates, estimate)
+		if !estimate.NearLiquidation {
+			continue
+		}
+
+		level := "warning"
+		if estimate.Distance <= o.riskManager.LiquidationBuffer/2 {
+			level = "critical"
+		}
+		levels[key] = level
+		if o.liquidationLevels[key] == level || (level == "warning" && o.liquidationLevels[key] == "critical") {
+			continue
+		}
+		o.publishRiskAlert(RiskAlert{
+			Level:     level,
+			Type:      "liquidation",
+			Symbol:    position.Symbol,
+			Message:   fmt.Sprintf("%s %s at %.2f is %.2f%% from its estimated liquidation price %.2f",
+				position.Symbol, position.Type, estimate.MarkPrice, estimate.Distance*100, estimate.LiquidationPrice),
+			Value:     estimate.Distance,
+			Threshold: o.riskManager.LiquidationBuffer,
+			Timestamp: time.Now(),
+		})
+	}
+	o.liquidationLevels = levels
+
+	o.mu.Lock()
+	o.state.Liquidations = estimates
+	o.mu.Unlock()
+}
+
+// checkEquityCurve samples equity into the equity curve filter and pauses or resumes the grid when it flips
+func (o *Orchestrator) checkEquityCurve(equity float64) {
+	if !o.equityFilter.Update(equity) {
+		return
+	}
+
+	average := o.equityFilter.GetMovingAverage()
+	if !o.equityFilter.IsTradingEnabled() {
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "📉 Equity %.2f fell below its moving average %.2f, pausing new entries", equity, average)
+		o.cancelGridOrders()
+		return
+	}
+
+	o.logf(logging.ComponentRisk, logging.InfoLevel, "📈 Equity %.2f regained its moving average %.2f, resuming trading", equity, average)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.state.Mode == ModeGrid {
+		if err := o.setupGridMode(); err != nil {
+			log.Printf("⚠️ Failed to rebuild grid after equity recovery: %v", err)
+		}
+	}
+}
+
+// checkLeverage measures rolling realized volatility and applies the leverage the risk manager allows for it
+func (o *Orchestrator) checkLeverage() {
+	if o.config.MarketType.IsSpot() {
+		return
+	}
+
+	candles := o.candleAggregator.GetCandles(o.activeSymbol, data.Timeframe15s, o.riskManager.GetVolatilityWindow()+1)
+	if len(candles) < 3 {
+		return
+	}
+	volatility := indicators.RealizedVolatility(candles, float64(24*time.Hour/(15*time.Second)))
+	change := o.riskManager.UpdateVolatility(volatility)
+	if change == nil {
+		return
+	}
+
+	if err := o.tradingExecutor.SetLeverage(o.activeSymbol, change.To); err != nil {
+		o.riskManager.RevertLeverage(change)
+		o.logf(logging.ComponentRisk, logging.ErrorLevel, "❌ Failed to set %s leverage to %.1fx (%s): %v", o.activeSymbol, change.To, change.Reason, err)
+		return
+	}
+	o.logf(logging.ComponentRisk, logging.WarnLevel, "⚖️ %s leverage %.1fx -> %.1fx: %s", o.activeSymbol, change.From, change.To, change.Reason)
+}
+
+// checkFeeBudget re-evaluates the fee budget and re-lays the grid when the turnover throttle changes
+func (o *Orchestrator) checkFeeBudget() {
+	if !o.feeGovernor.Evaluate(time.Now()) {
+		return
+	}
+
+	throttle := o.feeGovernor.Throttle()
+	if reason := o.feeGovernor.GetReason(); reason != "" {
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "💸 %s, throttling grid turnover to level %d (spacing x%.2f, levels x%.2f)",
+			reason, throttle.Level, throttle.SpacingMultiplier, throttle.LevelMultiplier)
+	} else {
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "💸 Fees back within budget, easing grid throttle to level %d", throttle.Level)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.state.Mode == ModeGrid {
+		if err := o.setupGridMode(); err != nil {
+			log.Printf("⚠️ Failed to rebuild grid after fee throttle change: %v", err)
+		}
+	}
+}
+
+// entriesAllowed returns false while the drawdown policy, the equity curve filter, the daily loss limit,
+// a market data gap, a scheduled news event, an exchange halt, a rate limit ban, an extreme basis or a
+// volatility spike blocks new entries
+func (o *Orchestrator) entriesAllowed() bool {
+	now := time.Now()
+	_, newsBlackout := o.newsCalendar.ActiveEvent(now)
+	return o.riskManager.GetSizeMultiplier() > 0 && o.equityFilter.IsTradingEnabled() && !o.dailyLoss.IsHalted() &&
+		!o.candleAggregator.HasDataGap(o.activeSymbol, now) && !newsBlackout && !o.exchangeStatus.IsHalted() &&
+		!o.restPaused() && o.fundingCalendar.EntryBlackout(o.activeSymbol, now) == "" &&
+		o.basisMonitor.EntryBlock(o.activeSymbol) == "" && !o.volatilityBreaker.IsTripped(o.activeSymbol)
+}
+
+// checkLiquidity checks the spread and top-of-book depth of the active symbol before orders are placed
+// and reports changes between thin and normal liquidity as a liquidity_guard event. Without an order
+// book the guard cannot judge the market and lets placement through.
+func (o *Orchestrator) checkLiquidity(purpose string) bool {
+	if !o.liquidityGuard.Enabled() || o.tradingExecutor == nil {
+		return true
+	}
+	book, err := o.tradingExecutor.GetOrderBook(o.activeSymbol, 1)
+	if err != nil || len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return true
+	}
+
+	bid, ask := book.Bids[0], book.Asks[0]
+	check, changed := o.liquidityGuard.Evaluate(o.activeSymbol, bid.Price, ask.Price, bid.Quantity, ask.Quantity, time.Now())
+	if !changed {
+		return check.Allowed
+	}
+
+	if check.Allowed {
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "💧 liquidity_guard: %s liquidity recovered (spread %.4f%%), placing %s",
+			o.activeSymbol, check.Spread*100, purpose)
+		return true
+	}
+	o.logf(logging.ComponentRisk, logging.WarnLevel, "💧 liquidity_guard: %s too thin, holding %s: %s", o.activeSymbol, purpose, check.Reason)
+	o.publishRiskAlert(RiskAlert{
+		Level:     "warning",
+		Type:      "liquidity_guard",
+		Message:   fmt.Sprintf("%s liquidity too thin for %s: %s", o.activeSymbol, purpose, check.Reason),
+		Symbol:    o.activeSymbol,
+		Value:     check.Spread,
+		Threshold: o.config.LiquidityGuardConfig.MaxSpread,
+		Timestamp: check.Timestamp,
+	})
+	return false
+}
+
+// executeDeRiskAction applies a drawdown policy step; sizing limits are enforced by the risk manager itself
+func (o *Orchestrator) executeDeRiskAction(level strategy.DeRiskLevel) {
+	o.mu.RLock()
+	currentMode := o.state.Mode
+	isActive := o.state.IsActive
+	o.mu.RUnlock()
+
+	switch level {
+	case strategy.DeRiskFlatten:
+		o.closeBreakoutPosition(o.ctx, o.candleAggregator.GetLatestPrice(o.activeSymbol), "Drawdown limit reached")
+		if err := o.closeAllPositions(types.NewOrderTag("risk", "drawdown")); err != nil {
+			log.Printf("Error flattening positions: %v", err)
+		}
+		if currentMode != ModeIdle {
+			if err := o.switchMode(ModeIdle); err != nil {
+				log.Printf("Error switching to idle after flattening: %v", err)
+			}
+		}
+
+	case strategy.DeRiskNormal:
+		// Cooldown finished; resume grid trading if the policy left the bot idle
+		if currentMode == ModeIdle && isActive {
+			if err := o.switchMode(ModeGrid); err != nil {
+				log.Printf("⚠️ Failed to resume grid trading after cooldown: %v", err)
+			}
+		}
+	}
+}
+
+// checkRiskPolicy evaluates an order against the risk policy and logs violations; orders with a
+// violation must not be placed
+func (o *Orchestrator) checkRiskPolicy(ctx context.Context, order *types.Order) error {
+	_, span := o.tracer.Start(ctx, "risk.check")
+	defer span.End()
+
+	price := order.Price
+	if price <= 0 {
+		price = o.candleAggregator.GetLatestPrice(order.Symbol)
+	}
+	leverage := o.riskManager.GetEffectiveLeverage()
+	if o.config.MarketType.IsSpot() || leverage <= 0 {
+		leverage = 1
+	}
+
+	violations := o.riskManager.EvaluateOrder(strategy.OrderIntent{
+		Symbol:     order.Symbol,
+		Side:       order.Side,
+		Quantity:   order.Quantity,
+		Price:      price,
+		Leverage:   leverage,
+		ReduceOnly: order.ReduceOnly,
+	})
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = fmt.Sprintf("%s: %s", violation.Rule, violation.Message)
+	}
+	o.logf(logging.ComponentRisk, logging.WarnLevel, "🚫 Risk policy blocked %s %.6f %s: %s", order.Side, order.Quantity, order.Symbol, strings.Join(messages, "; "))
+	err := fmt.Errorf("risk policy violation: %s", strings.Join(messages, "; "))
+	span.RecordError(err)
+	return err
+}
+
+// closeAllPositions closes all open positions, including both sides in hedge mode, with orders tagged tag
+func (o *Orchestrator) closeAllPositions(tag string) error {
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		return err
+	}
+
+	for _, position := range positions {
+		if position.Symbol != o.activeSymbol || math.Abs(position.Size) == 0 {
+			continue
+		}
+		if err := o.closeExecutorPosition(o.ctx, position, execution.IntentEmergencyClose, tag); err != nil {
+			return err
+		}
+		log.Printf("📉 Emergency position close: %s %.4f @ %.2f", position.Type, position.Size, position.EntryPrice)
+	}
+
+	return nil
+}
+
+// closeExecutorPosition closes an executor position based on its direction, at market unless the intent
+// or the position size calls for an execution algorithm. Closes with a cancelled context, as on shutdown,
+// always go at market.
+func (o *Orchestrator) closeExecutorPosition(ctx context.Context, position *types.Position, intent execution.Intent, tag string) error {
+	if err := o.checkRateLimit(true); err != nil {
+		return err
+	}
+	size := math.Abs(position.Size)
+	short := position.Type == types.PositionTypeShort || (position.Type == "" && position.Size < 0)
+	order := types.NewMarketOrder("", position.Symbol, types.OrderSideSell, size, types.PositionTypeLong)
+	if short {
+		order = types.NewMarketOrder("", position.Symbol, types.OrderSideBuy, size, types.PositionTypeShort)
+	}
+	order.SetReduceOnly(true)
+	order.Tag = tag
+	if algo := o.execution.Route(intent, order); algo != execution.AlgoMarket && ctx.Err() == nil {
+		result, err := o.execution.Execute(ctx, intent, algo, order, nil)
+		o.logExecution(intent, algo, result)
+		return err
+	}
+	_, err := o.tradingExecutor.PlaceOrder(order)
+	return err
+}
+
+// logExecution logs the outcome of an algorithmic execution
+func (o *Orchestrator) logExecution(intent execution.Intent, algo execution.Algo, result *execution.Result) {
+	if result == nil {
+		return
+	}
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "🎯 %s via %s: %.6f/%.6f filled @ %.2f in %d orders (maker %.6f, market %.6f, %d reprices, %v)",
+		intent, algo, result.FilledQty, result.Quantity, result.AvgPrice, result.Orders, result.MakerQty, result.MarketQty, result.Reprices, result.Duration.Round(time.Millisecond))
+}
+
+// modeManagementWorker manages mode transitions and state
+func (o *Orchestrator) modeManagementWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.modeWatchdog.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			// Periodic mode health checks
+			o.checkModeHealth()
+			o.checkRecoveryEscalation()
+		}
+	}
+}
+
+// checkModeHealth raises a mode_timeout signal when the current mode exceeds its configured time limits
+func (o *Orchestrator) checkModeHealth() {
+	o.mu.RLock()
+	currentMode := o.state.Mode
+	modeSince := o.state.ModeSince
+	lastUpdate := o.state.LastUpdateTime
+	o.mu.RUnlock()
+
+	event := o.modeWatchdog.Check(currentMode, modeSince, lastUpdate, time.Now())
+	if event == nil {
+		return
+	}
+	o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⚠️ %s", event.Reason())
+
+	signal := TradingSignal{
+		Type:      "mode_timeout",
+		Symbol:    o.activeSymbol,
+		Action:    "switch_mode",
+		Reason:    event.Reason(),
+		Data:      *event,
+		Timestamp: time.Now(),
+	}
+	if event.Fallback == "" {
+		signal.Action = "none"
+	}
+	o.publishSignal(signal)
+}
+
+// handleModeTimeoutSignal switches a timed-out mode to its fallback; a breakout position is closed first
+// when the fallback does not manage it
+func (o *Orchestrator) handleModeTimeoutSignal(signal TradingSignal) {
+	event := signal.Data.(ModeTimeoutEvent)
+	if event.Fallback == "" {
+		return
+	}
+
+	o.mu.RLock()
+	currentMode := o.state.Mode
+	modeSince := o.state.ModeSince
+	o.mu.RUnlock()
+	if currentMode != event.Mode || !modeSince.Equal(event.EnteredAt) {
+		return // The mode changed since the timeout was raised
+	}
+	if o.refuseLockedTransition(event.Fallback) {
+		return // Keep the breakout position the locked mode manages
+	}
+
+	o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "🔄 Switching %s -> %s after %s timeout", event.Mode, event.Fallback, event.Limit)
+	if event.Fallback == ModeGrid || event.Fallback == ModeIdle {
+		o.closeBreakoutPosition(o.ctx, o.candleAggregator.GetLatestPrice(o.activeSymbol), "Mode "+event.Limit+" timeout")
+	}
+	if err := o.switchMode(event.Fallback); err != nil {
+		o.logf(logging.ComponentOrchestrator, logging.ErrorLevel, "❌ Mode timeout fallback refused: %v", err)
+	}
+}
+
+// newsWorker refreshes the economic calendar and raises signals when a news blackout starts or ends
+func (o *Orchestrator) newsWorker() {
+	defer o.wg.Done()
+
+	if err := o.newsCalendar.Fetch(o.ctx); err != nil {
+		log.Printf("⚠️ Failed to fetch news calendar: %v", err)
+	}
+
+	pollTicker := time.NewTicker(o.newsCalendar.config.PollInterval)
+	defer pollTicker.Stop()
+	checkTicker := time.NewTicker(10 * time.Second)
+	defer checkTicker.Stop()
+
+	var active *CalendarEvent
+	check := func() {
+		event, blackout := o.newsCalendar.ActiveEvent(time.Now())
+		switch {
+		case blackout && active == nil:
+			active = &event
+			o.sendNewsSignal("news_pause", event)
+		case !blackout && active != nil:
+			o.sendNewsSignal("news_resume", *active)
+			active = nil
+		}
+	}
+	check()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-pollTicker.C:
+			if err := o.newsCalendar.Fetch(o.ctx); err != nil {
+				log.Printf("⚠️ Failed to refresh news calendar: %v", err)
+			}
+			check()
+
+		case <-checkTicker.C:
+			check()
+		}
+	}
+}
+
+// exchangeStatusWorker polls the exchange and symbol status and raises signals when a halt starts or ends
+func (o *Orchestrator) exchangeStatusWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.exchangeStatus.config.PollInterval)
+	defer ticker.Stop()
+
+	halted := false
+	check := func() {
+		// The status endpoints share the rate limit of the banned IP
+		if o.restPaused() {
+			return
+		}
+		status, err := o.exchangeStatus.Check(o.ctx, o.activeSymbol)
+		if err != nil {
+			o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⚠️ Failed to check exchange status: %v", err)
+			o.noteRateLimit(err)
+			return
+		}
+		switch {
+		case status.Halted() && !halted:
+			halted = true
+			o.sendExchangeStatusSignal("exchange_halt", status)
+		case !status.Halted() && halted:
+			halted = false
+			o.sendExchangeStatusSignal("exchange_resume", status)
+		}
+	}
+	check()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// healthWorker samples resource usage and degrades the bot to idle when a threshold stays exceeded
+func (o *Orchestrator) healthWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.health.config.CheckInterval)
+	defer ticker.Stop()
+
+	lastStatus := HealthHealthy
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			now := time.Now()
+			report, degrade := o.health.Evaluate(o.sampleHealth(now), now)
+
+			if report.Status != lastStatus {
+				if report.Status == HealthHealthy {
+					o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "🩺 Health restored")
+				} else {
+					for _, issue := range report.Issues {
+						o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "🩺 Health %s: %s", report.Status, issue.Message)
+					}
+				}
+				lastStatus = report.Status
+			}
+
+			if degrade {
+				messages := make([]string, 0, len(report.Issues))
+				for _, issue := range report.Issues {
+					messages = append(messages, issue.Message)
+				}
+				alert := RiskAlert{
+					Level:     "critical",
+					Type:      "health",
+					Symbol:    o.activeSymbol,
+					Message:   fmt.Sprintf("Health thresholds exceeded for over %s (%s), degrading to idle",
+						o.health.config.GracePeriod, strings.Join(messages, "; ")),
+					Value:     float64(len(report.Issues)),
+					Timestamp: now,
+				}
+				o.publishRiskAlert(alert)
+			}
+		}
+	}
+}
+
+// sampleHealth measures goroutines, memory, channel backlogs and stream lag
+func (o *Orchestrator) sampleHealth(now time.Time) HealthSample {
+	sample := HealthSample{
+		DataBacklog:    o.dataQueue.Len(),
+		DataCapacity:   o.dataQueue.Cap(),
+		SignalBacklog:  o.signalEvents.Len(),
+		SignalCapacity: o.signalEvents.Cap(),
+		EventLag:       time.Duration(o.lastTickLag.Load()),
+	}
+	sample.ReadRuntime()
+	if lastTick := o.lastTickAt.Load(); lastTick != 0 {
+		sample.LastTick = time.Unix(0, lastTick)
+		sample.StreamLag = now.Sub(sample.LastTick)
+	}
+	return sample
+}
+
+// sendNewsSignal queues a news blackout signal for the signal worker
+func (o *Orchestrator) sendNewsSignal(signalType string, event CalendarEvent) {
+	signal := TradingSignal{
+		Type:      signalType,
+		Symbol:    o.activeSymbol,
+		Action:    "pause_entries",
+		Reason:    event.Title,
+		Data:      event,
+		Timestamp: time.Now(),
+	}
+	if signalType == "news_resume" {
+		signal.Action = "resume_entries"
+	}
+
+	o.publishSignal(signal)
+}
+
+// handleNewsSignal cancels resting grid orders when a news blackout starts and rebuilds the grid when it ends
+func (o *Orchestrator) handleNewsSignal(signal TradingSignal) {
+	event := signal.Data.(CalendarEvent)
+
+	if signal.Type == "news_pause" {
+		log.Printf("📰 %s %s event \"%s\" at %s, pausing new grid entries",
+			event.Currency, event.Impact, event.Title, event.Time.Format("15:04"))
+		o.cancelGridOrders()
+		return
+	}
+
+	log.Printf("📰 News blackout for \"%s\" ended, resuming grid entries", event.Title)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.state.Mode == ModeGrid {
+		if err := o.setupGridMode(); err != nil {
+			log.Printf("⚠️ Failed to rebuild grid after news blackout: %v", err)
+		}
+	}
+}
+
+// fundingWorker polls the funding rate and next funding time of the active symbol into the funding calendar
+func (o *Orchestrator) fundingWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.fundingFeed.config.PollInterval)
+	defer ticker.Stop()
+
+	poll := func() {
+		if o.restPaused() {
+			return
+		}
+		o.mu.RLock()
+		symbol := o.activeSymbol
+		o.mu.RUnlock()
+
+		info, err := o.fundingFeed.Fetch(o.ctx, symbol)
+		if err != nil {
+			o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⚠️ Failed to fetch funding rate: %v", err)
+			o.noteRateLimit(err)
+			return
+		}
+		if payment, due := o.fundingCalendar.DueFunding(symbol, time.Now()); due {
+			o.bookFunding(payment)
+		}
+		o.fundingCalendar.UpdateFunding(symbol, info.Rate, info.NextFunding)
+	}
+	poll()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+// bookFunding books the funding each open position of a symbol paid or received at a funding time in the
+// ledger; longs pay shorts when the rate is positive
+func (o *Orchestrator) bookFunding(payment strategy.FundingPayment) {
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⚠️ Failed to read positions for %s funding: %v", payment.Symbol, err)
+		o.noteRateLimit(err)
+		return
+	}
+
+	contract := o.positionManager.Contracts[payment.Symbol]
+	for _, position := range positions {
+		if position.Symbol != payment.Symbol || position.Size == 0 {
+			continue
+		}
+		mark := o.candleAggregator.GetLatestPrice(payment.Symbol)
+		if mark <= 0 {
+			mark = position.MarkPrice
+		}
+		amount := payment.Rate * contract.Notional(math.Abs(position.Size), mark)
+		if position.Type == types.PositionTypeLong {
+			amount = -amount
+		}
+		amount = o.currency.ToAccounting(payment.Symbol, amount)
+
+		reference := fmt.Sprintf("funding:%s:%s:%d", payment.Symbol, position.Type, payment.Time.Unix())
+		o.ledger.RecordFunding(payment.Symbol, amount, payment.Time, reference)
+		o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "💸 Funding %s %s at %.4f%%: %.4f",
+			payment.Symbol, position.Type, payment.Rate*100, amount)
+	}
+}
+
+// profitSweepWorker transfers realized profit above the working capital out of the trading wallet and
+// books every sweep in the ledger
+func (o *Orchestrator) profitSweepWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.profitSweeper.config.CheckInterval)
+	defer ticker.Stop()
+
+	check := func() {
+		if o.restPaused() {
+			return
+		}
+		balance, err := o.tradingExecutor.GetBalance()
+		if err != nil {
+			o.noteRateLimit(err)
+			return
+		}
+		available, err := o.tradingExecutor.GetAvailableBalance()
+		if err != nil {
+			o.noteRateLimit(err)
+			return
+		}
+		amount := o.profitSweeper.Plan(balance, available)
+		if amount <= 0 {
+			return
+		}
+
+		sweep, err := o.profitSweeper.Sweep(o.ctx, amount, balance)
+		if sweep.TransferID == "" {
+			o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ Profit sweep of %.2f %s failed: %v", amount, o.profitSweeper.config.Asset, err)
+			o.noteRateLimit(err)
+			return
+		}
+		o.ledger.RecordSweep(sweep.Amount, sweep.Timestamp, sweep.TransferID,
+			fmt.Sprintf("%s %s, balance %.2f above working capital %.2f", sweep.TransferType, sweep.Asset, sweep.BalanceBefore, sweep.WorkingCapital))
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "💸 Swept %.2f %s of profit (%s, transfer %s)", sweep.Amount, sweep.Asset, sweep.TransferType, sweep.TransferID)
+		if err != nil {
+			o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ %v", err)
+		}
+	}
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// dailyReportWorker emails the performance of the past accounting day at the configured time of day
+func (o *Orchestrator) dailyReportWorker() {
+	defer o.wg.Done()
+
+	for {
+		next := o.dailyReporter.NextRun(time.Now())
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-o.ctx.Done():
+			timer.Stop()
+			return
+
+		case <-timer.C:
+			report := o.buildDailyReport(o.dailyReporter.PreviousRun(next), next)
+			if err := o.dailyReporter.Send(report); err != nil {
+				log.Printf("⚠️ Failed to send daily report: %v", err)
+				continue
+			}
+			log.Printf("📧 Daily report sent: %d fills, net PnL %.2f", len(report.Fills), report.NetPnL)
+		}
+	}
+}
+
+// alertRuleWorker evaluates the alert rules at the check interval and raises their firings as risk alerts
+func (o *Orchestrator) alertRuleWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.alertRules.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			now := time.Now()
+			for _, firing := range o.alertRules.Evaluate(o.collectAlertMetrics(), now) {
+				o.fireAlertRule(firing)
+			}
+		}
+	}
+}
+
+// collectAlertMetrics snapshots the metrics alert rules are evaluated against
+func (o *Orchestrator) collectAlertMetrics() AlertMetrics {
+	metrics := AlertMetrics{
+		AlertMetricBalance:  o.ledger.GetBalance(),
+		AlertMetricDailyPnL: o.dailyLoss.Snapshot().Total(),
+	}
+	if price := o.candleAggregator.GetLatestPrice(o.activeSymbol); price > 0 {
+		metrics[AlertMetricPrice] = price
+	}
+	if positions, err := o.tradingExecutor.GetAllPositions(); err == nil {
+		unrealized := 0.0
+		for _, position := range positions {
+			unrealized += position.UnrealizedPnL
+		}
+		metrics[AlertMetricPositions] = float64(len(positions))
+		metrics[AlertMetricUnrealizedPnL] = unrealized
+		metrics[AlertMetricEquity] = metrics[AlertMetricBalance].(float64) + unrealized
+	}
+	if orders, err := o.tradingExecutor.GetOpenOrders(""); err == nil {
+		metrics[AlertMetricOpenOrders] = float64(len(orders))
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	metrics[AlertMetricMode] = string(o.state.Mode)
+	metrics[AlertMetricRealizedPnL] = o.performance.TotalPnL
+	metrics[AlertMetricDrawdown] = o.performance.CurrentDrawdown
+	metrics[AlertMetricMaxDrawdown] = o.performance.MaxDrawdown
+	metrics[AlertMetricTrades] = float64(o.performance.TotalTrades)
+	metrics[AlertMetricWinRate] = o.performance.WinRate
+	if bounds := o.state.GridBounds; bounds.UpperBound > 0 {
+		metrics[AlertMetricGridUpper] = bounds.UpperBound
+		metrics[AlertMetricGridLower] = bounds.LowerBound
+		metrics[AlertMetricGridCenter] = bounds.Center
+	}
+	return metrics
+}
+
+// fireAlertRule raises a rule firing as a risk alert, which logs, annotates and lists it in reports, and
+// sends it to the webhook and, if configured, by email
+func (o *Orchestrator) fireAlertRule(firing AlertRuleFiring) {
+	o.publishRiskAlert(RiskAlert{
+		Level:     firing.Level,
+		Type:      "alert_rule",
+		Message:   firing.Message,
+		Symbol:    o.activeSymbol,
+		Value:     firing.Value,
+		Threshold: firing.Threshold,
+		Timestamp: firing.Timestamp,
+	})
+
+	o.mu.RLock()
+	mode := o.state.Mode
+	o.mu.RUnlock()
+	o.sendWebhook(WebhookEventAlertRule, o.activeSymbol, mode, firing)
+
+	if o.alertEmail == nil {
+		return
+	}
+	err := o.alertEmail.Send(EmailMessage{
+		Subject: fmt.Sprintf("aibot %s alert [%s] %s", o.activeSymbol, firing.Level, firing.Rule),
+		HTML:    fmt.Sprintf("<p>%s</p><p>Condition: <code>%s</code><br>Time: %s</p>", html.EscapeString(firing.Message), html.EscapeString(firing.Condition), firing.Timestamp.Format(time.RFC3339)),
+		Text:    fmt.Sprintf("%s\nCondition: %s\nTime: %s\n", firing.Message, firing.Condition, firing.Timestamp.Format(time.RFC3339)),
+	})
+	if err != nil {
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "⚠️ Failed to email alert rule %s: %v", firing.Rule, err)
+	}
+}
+
+// sendExchangeStatusSignal queues an exchange halt signal for the signal worker
+func (o *Orchestrator) sendExchangeStatusSignal(signalType string, status ExchangeStatus) {
+	signal := TradingSignal{
+		Type:      signalType,
+		Symbol:    status.Symbol,
+		Action:    "pause_entries",
+		Reason:    status.Reason(),
+		Data:      status,
+		Timestamp: status.CheckedAt,
+	}
+	if signalType == "exchange_resume" {
+		signal.Action = "resume_entries"
+	}
+
+	o.publishSignal(signal)
+}
+
+// handleExchangeStatusSignal cancels resting grid orders when the exchange halts trading and rebuilds the
+// grid once it resumes
+func (o *Orchestrator) handleExchangeStatusSignal(signal TradingSignal) {
+	status := signal.Data.(ExchangeStatus)
+
+	if signal.Type == "exchange_halt" {
+		o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "🚧 Trading halted by %s, pausing order placement", status.Reason())
+		o.cancelGridOrders()
+		return
+	}
+
+	o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "✅ %s is trading again, resuming order placement", status.Symbol)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.state.Mode == ModeGrid {
+		if err := o.setupGridMode(); err != nil {
+			log.Printf("⚠️ Failed to rebuild grid after exchange halt: %v", err)
+		}
+	}
+}
+
+// performanceWorker tracks performance metrics
+func (o *Orchestrator) performanceWorker() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case <-ticker.C:
+			o.updatePerformanceMetrics()
+		}
+	}
+}
+
+// updatePerformanceMetrics updates performance tracking
+func (o *Orchestrator) updatePerformanceMetrics() {
+	if o.restPaused() {
+		return
+	}
+
+	// Get current position and balance
+	position, err := o.tradingExecutor.GetPosition(o.activeSymbol)
+	if err != nil {
+		o.noteRateLimit(err)
+		return
+	}
+
+	balance, err := o.tradingExecutor.GetAvailableBalance()
+	if err != nil {
+		o.noteRateLimit(err)
+		return
+	}
+
+	// Update performance metrics
+	o.mu.Lock()
+
+	if position != nil {
+		o.performance.TotalPnL = position.UnrealizedPnL
+	}
+
+	// Calculate drawdown
+	peak := o.config.InitialBalance
+	if balance > peak {
+		peak = balance
+	}
+	drawdown := (peak - balance) / peak
+	o.performance.CurrentDrawdown = drawdown
+
+	if drawdown > o.performance.MaxDrawdown {
+		o.performance.MaxDrawdown = drawdown
+	}
+
+	o.state.TotalPnL = o.performance.TotalPnL
+	o.state.CurrentDrawdown = o.performance.CurrentDrawdown
+	o.state.MaxDrawdown = o.performance.MaxDrawdown
+
+	o.mu.Unlock()
+}
+
+// fillProcessingWorker routes asynchronous order updates from the executor
+func (o *Orchestrator) fillProcessingWorker() {
+	defer o.wg.Done()
+
+	fillChan := o.tradingExecutor.GetFillChannel()
+	dropCheck := time.NewTicker(droppedFillCheckInterval)
+	defer dropCheck.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+
+		case update, ok := <-fillChan:
+			if !ok {
+				// Executor disconnected
+				return
+			}
+			o.processOrderUpdate(update)
+
+		case <-dropCheck.C:
+			// Updates still buffered would be booked twice after a resync
+			if len(fillChan) == 0 {
+				o.checkDroppedFills()
+			}
+		}
+	}
+}
+
+// checkDroppedFills resyncs tracked positions with the executor once order updates have been dropped on a full
+// fill channel, for every symbol holding a position or open orders since the dropped updates may belong to any
+// of them. Each resync is journaled, and an alert is raised since grid levels may also be stale. The drops
+// count as resynced only once every symbol is.
+func (o *Orchestrator) checkDroppedFills() {
+	reporter, ok := o.tradingExecutor.(trading.FillDropReporter)
+	if !ok {
+		return
+	}
+	dropped := reporter.DroppedFills()
+	if dropped <= o.resyncedDrops {
+		return
+	}
+	missed := dropped - o.resyncedDrops
+
+	symbols, err := o.resyncSymbols()
+	if err != nil {
+		o.logf(logging.ComponentExecutor, logging.ErrorLevel, "❌ Failed to list positions to resync after dropped order updates: %v", err)
+		return
+	}
+	reason := fmt.Sprintf("%d order updates dropped", missed)
+	var failed []string
+	for _, symbol := range symbols {
+		price := o.resyncPrice(symbol)
+		if price <= 0 {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ No price to resync %s positions at, retrying", symbol)
+			failed = append(failed, symbol)
+			continue
+		}
+		results, err := o.resyncPositions(symbol, price)
+		if err != nil {
+			o.logf(logging.ComponentExecutor, logging.ErrorLevel, "❌ Failed to resync %s positions after dropped order updates: %v", symbol, err)
+			failed = append(failed, symbol)
+			continue
+		}
+		o.journalResync(symbol, price, results, reason)
+	}
+	o.syncExchangeExits()
+	if len(failed) > 0 {
+		return
+	}
+
+	o.resyncedDrops = dropped
+	o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ %s on a full fill channel: %s positions resynced with the executor", reason, strings.Join(symbols, ", "))
+	o.publishRiskAlert(RiskAlert{
+		Level:     "critical",
+		Type:      "fills_dropped",
+		Symbol:    o.activeSymbol,
+		Message:   fmt.Sprintf("%s: %s positions resynced with the executor, grid levels may be stale", reason, strings.Join(symbols, ", ")),
+		Value:     float64(missed),
+		Timestamp: time.Now(),
+	})
+}
+
+// resyncSymbols returns the active symbol and every symbol with an executor position, a tracked position or
+// open orders
+func (o *Orchestrator) resyncSymbols() ([]string, error) {
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		return nil, err
+	}
+	orders, err := o.tradingExecutor.GetOpenOrders("")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{o.activeSymbol: true}
+	for _, position := range positions {
+		if !position.IsFlat() {
+			seen[position.Symbol] = true
+		}
+	}
+	for _, order := range orders {
+		seen[order.Symbol] = true
+	}
+	o.positionMu.Lock()
+	for _, state := range o.positionManager.GetAllPositions() {
+		seen[state.Position.Symbol] = true
+	}
+	o.positionMu.Unlock()
+
+	symbols := make([]string, 0, len(seen))
+	for symbol := range seen {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+// resyncPrice returns the price a symbol's positions are resynced at: the latest streamed price, else the
+// executor's ticker
+func (o *Orchestrator) resyncPrice(symbol string) float64 {
+	if price := o.candleAggregator.GetLatestPrice(symbol); price > 0 {
+		return price
+	}
+	if ticker, err := o.tradingExecutor.GetTicker(symbol); err == nil && ticker != nil {
+		return ticker.Price
+	}
+	return 0
+}
+
+// journalResync records the sizes booked by a resync in the trade journal; a symbol already in sync is
+// journaled once with no quantity, so every resync leaves a record
+func (o *Orchestrator) journalResync(symbol string, price float64, results []*types.OrderResult, reason string) {
+	entries := make([]journal.JournalEntry, 0, len(results))
+	for _, result := range results {
+		entries = append(entries, journal.JournalEntry{
+			Symbol:       symbol,
+			EventType:    journal.EventResync,
+			Side:         types.OrderSide(result.Side),
+			PositionType: types.PositionType(result.PositionType),
+			Quantity:     result.Quantity,
+			Price:        price,
+			Mode:         string(o.currentMode()),
+			Reason:       reason,
+		})
+	}
+	if len(entries) == 0 {
+		entries = append(entries, journal.JournalEntry{
+			Symbol:    symbol,
+			EventType: journal.EventResync,
+			Price:     price,
+			Mode:      string(o.currentMode()),
+			Reason:    reason + ", already in sync",
+		})
+	}
+	for _, entry := range entries {
+		if err := o.tradeJournal.Record(entry); err != nil {
+			log.Printf("Error writing trade journal: %v", err)
+		}
+	}
+}
+
+// resyncPositions books the difference between the executor's positions of a symbol and the tracked ones at
+// price and returns what was booked. Grid inventory of the active symbol is held in the same executor position
+// but tracked by the grid engine, so it is taken out first; in hedge mode it is taken from the side it leans to.
+func (o *Orchestrator) resyncPositions(symbol string, price float64) ([]*types.OrderResult, error) {
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	long, short := 0.0, 0.0
+	for _, position := range positions {
+		if position.Symbol != symbol || position.IsFlat() {
+			continue
+		}
+		if position.Type == types.PositionTypeShort || (position.Type == "" && position.Size < 0) {
+			short += math.Abs(position.Size)
+		} else {
+			long += math.Abs(position.Size)
+		}
+	}
+	inventory := 0.0
+	if symbol == o.activeSymbol {
+		inventory = o.gridEngine.GetInventory()
+	}
+
+	o.positionMu.Lock()
+	defer o.positionMu.Unlock()
+
+	var results []*types.OrderResult
+	book := func(positionType types.PositionType, size float64) error {
+		result, err := o.positionManager.SyncPosition(symbol, positionType, size, price)
+		if result != nil {
+			results = append(results, result)
+		}
+		return err
+	}
+
+	if !o.positionManager.HedgeMode {
+		net := long - short - inventory
+		positionType := types.PositionTypeLong
+		if net < 0 {
+			positionType = types.PositionTypeShort
+		}
+		return results, book(positionType, math.Abs(net))
+	}
+
+	if inventory > 0 {
+		long = math.Max(0, long-inventory)
+	} else {
+		short = math.Max(0, short+inventory)
+	}
+	if err := book(types.PositionTypeLong, long); err != nil {
+		return results, err
+	}
+	return results, book(types.PositionTypeShort, short)
+}
+
+// processOrderUpdate applies an order update to position tracking, the journal and performance metrics
+func (o *Orchestrator) processOrderUpdate(update types.OrderUpdate) {
+	o.mu.RLock()
+	mode := o.state.Mode
+	o.mu.RUnlock()
+
+	if err := o.tradeJournal.RecordOrderUpdate(update, string(mode)); err != nil {
+		log.Printf("Error writing trade journal: %v", err)
+	}
+
+	o.positionMu.Lock()
+	expectedPrice := o.expectedPrices[update.ClientOrderID]
+	if update.Status != types.OrderStatusPartial {
+		delete(o.expectedPrices, update.ClientOrderID)
+	}
+	o.positionMu.Unlock()
+
+	// The journal keeps the raw fill; aggregates across symbols are kept in the accounting currency
+	converted, expectedPrice := o.currency.ConvertFill(update, expectedPrice)
+	o.ledger.RecordFill(converted, expectedPrice)
+	o.dailyLoss.RecordFill(converted)
+	o.benchmark.RecordFill(converted)
+	if o.capitalAllocator != nil {
+		o.capitalAllocator.RecordFill(converted)
+	}
+
+	if strategy.IsGridOrder(update.ClientOrderID) {
+		// Grid inventory is tracked by the grid engine rather than the position manager
+		o.feeGovernor.RecordFill(update)
+		o.applyGridOrderUpdate(update, mode)
+	} else {
+		o.positionMu.Lock()
+		var err error
+		if o.managedOrders[update.ClientOrderID] {
+			// Already booked by the strategy that placed the order
+			if update.Status != types.OrderStatusPartial {
+				delete(o.managedOrders, update.ClientOrderID)
+			}
+		} else {
+			_, err = o.positionManager.ApplyFill(update)
+		}
+		o.applyExchangeExitUpdate(update)
+		o.positionMu.Unlock()
+		if err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Position tracking out of sync for %s: %v", update.Symbol, err)
+		}
+		o.syncExchangeExits()
+	}
+	o.events.Publish(o.ctx, TopicOrderUpdate, update)
+
+	if !update.IsFill() {
+		if update.Status == types.OrderStatusRejected {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "❌ Order %s rejected: %s", update.OrderID, update.Reason)
+		}
+		return
+	}
+
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "✅ Order %s %s: %s %.4f %s @ %.2f (fee: %.4f, pnl: %.2f)",
+		update.OrderID, update.Status, update.Side, update.LastFillQty, update.Symbol,
+		update.LastFillPrice, update.Fee, update.RealizedPnL)
+	if update.Reason == trading.OrderReasonLiquidation {
+		o.handleLiquidationFill(update)
+	}
+
+	o.recordFillPerformance(converted, mode)
+}
+
+// recordFillPerformance updates trade counts, per-mode PnL, trade returns and drawdown with a fill whose
+// PnL and fees are in the accounting currency
+func (o *Orchestrator) recordFillPerformance(update types.OrderUpdate, mode TradingMode) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.state.TradeCount++
+	o.state.LastUpdateTime = time.Now()
+	o.performance.TotalTrades++
+	o.performance.LastTradeTime = update.Timestamp
+
+	// Only fills that realize PnL count as completed round trips
+	if update.RealizedPnL > 0 {
+		o.state.SuccessfulTrades++
+		o.performance.WinningTrades++
+	} else if update.RealizedPnL < 0 {
+		o.performance.LosingTrades++
+	}
+	if closed := o.performance.WinningTrades + o.performance.LosingTrades; closed > 0 {
+		o.performance.WinRate = float64(o.performance.WinningTrades) / float64(closed)
+	}
+
+	// Attribute the fill to the mode active when it arrived
+	modePnL := o.modePnL[mode]
+	modePnL.Fills++
+	modePnL.Volume += update.GetFillNotional()
+	modePnL.RealizedPnL += update.RealizedPnL
+	modePnL.Fees += update.Fee
+	o.modePnL[mode] = modePnL
+
+	// Per-trade returns of closed round trips feed the Sharpe ratio
+	if update.RealizedPnL != 0 && o.config.InitialBalance > 0 {
+		tradeReturn := (update.RealizedPnL - update.Fee) / o.config.InitialBalance
+		o.tradeReturns++
+		o.tradeReturnSum += tradeReturn
+		o.tradeReturnSumSq += tradeReturn * tradeReturn
+		o.performance.SharpeRatio = journal.TradeSharpe(o.tradeReturns, o.tradeReturnSum, o.tradeReturnSumSq)
+	}
+
+	// Track drawdown on realized equity between the periodic balance checks
+	o.realizedEquity += update.RealizedPnL - update.Fee
+	if o.realizedEquity > o.equityPeak {
+		o.equityPeak = o.realizedEquity
+	}
+	if o.equityPeak > 0 {
+		drawdown := (o.equityPeak - o.realizedEquity) / o.equityPeak
+		if drawdown > o.performance.MaxDrawdown {
+			o.performance.MaxDrawdown = drawdown
+			o.state.MaxDrawdown = drawdown
+		}
+	}
+}
+
+// applyGridOrderUpdate updates the grid engine and re-arms the counter level after a fill
+func (o *Orchestrator) applyGridOrderUpdate(update types.OrderUpdate, mode TradingMode) {
+	switch {
+	case update.IsFill():
+		if o.gridEngine.HandleFill(update) && mode == ModeGrid {
+			o.placeGridOrders(o.ctx)
+		}
+	case update.Status == types.OrderStatusRejected || update.Status == types.OrderStatusCancelled:
+		o.gridEngine.ReleaseOrder(update.ClientOrderID)
+	}
+}
+
+// GetTradeJournal returns the trade journal
+func (o *Orchestrator) GetTradeJournal() *journal.TradeJournal {
+	return o.tradeJournal
+}
+
+// sendWebhook queues a webhook event if webhooks are configured
+func (o *Orchestrator) sendWebhook(eventType, symbol string, mode TradingMode, data interface{}) {
+	if o.webhooks == nil {
+		return
+	}
+	o.webhooks.Send(eventType, symbol, mode, data)
+}
+
+// annotate queues a Grafana annotation if annotations are configured
+func (o *Orchestrator) annotate(kind, symbol, text string) {
+	if o.annotator == nil {
+		return
+	}
+	o.annotator.Annotate(kind, symbol, text)
+}
+
+// GetEventLog returns the machine-readable event stream, or nil if it is disabled
+func (o *Orchestrator) GetEventLog() *EventLog {
+	return o.eventLog
+}
+
+// currentMode returns the active trading mode
+func (o *Orchestrator) currentMode() TradingMode {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.state.Mode
+}
+
+// GetAnnotator returns the Grafana annotator, or nil if annotations are disabled
+func (o *Orchestrator) GetAnnotator() *GrafanaAnnotator {
+	return o.annotator
+}
+
+// GetWebhooks returns the webhook dispatcher, or nil if webhooks are disabled
+func (o *Orchestrator) GetWebhooks() *WebhookDispatcher {
+	return o.webhooks
+}
+
+// GetExchangeStatusMonitor returns the exchange status monitor, or nil if it is disabled
+func (o *Orchestrator) GetExchangeStatusMonitor() *ExchangeStatusMonitor {
+	return o.exchangeStatus
+}
+
+// GetFundingCalendar returns the funding and settlement calendar, or nil on spot markets
+func (o *Orchestrator) GetFundingCalendar() *strategy.FundingCalendar {
+	return o.fundingCalendar
+}
+
+// GetGridLevelStats returns the session's fill statistics per grid level offset
+func (o *Orchestrator) GetGridLevelStats() []strategy.GridLevelStats {
+	return o.gridEngine.GetLevelStats()
+}
+
+// GetGridLevels returns the levels of the current grid
+func (o *Orchestrator) GetGridLevels() []types.GridLevel {
+	return o.gridEngine.GetLevels()
+}
+
+// GetAlertRules returns the alert rules engine, or nil if no rules are configured
+func (o *Orchestrator) GetAlertRules() *AlertRuleEngine {
+	return o.alertRules
+}
+
+// GetModeWatchdog returns the per-mode timeout watchdog
+func (o *Orchestrator) GetModeWatchdog() *ModeWatchdog {
+	return o.modeWatchdog
+}
+
+// GetRecoveryEscalation returns the recovery mode escalation
+func (o *Orchestrator) GetRecoveryEscalation() *RecoveryEscalation {
+	return o.recoveryEscalation
+}
+
+// GetNewsCalendar returns the economic calendar, or nil if the news integration is disabled
+func (o *Orchestrator) GetNewsCalendar() *NewsCalendar {
+	return o.newsCalendar
+}
+
+// GetHealth returns the latest resource watchdog report
+func (o *Orchestrator) GetHealth() HealthReport {
+	return o.health.GetReport()
+}
+
+// GetExecutionAlgoStats returns execution algorithm statistics
+func (o *Orchestrator) GetExecutionAlgoStats() map[string]interface{} {
+	if o.execution == nil {
+		return map[string]interface{}{}
 	}
+	return o.execution.GetAlgoStats()
 }
 
-// handleRiskAlert handles risk alerts
-func (o *Orchestrator) handleRiskAlert(alert RiskAlert) {
-	log.Printf("⚠️ Risk Alert [%s]: %s", alert.Level, alert.Message)
+// GetIndicatorWarmup returns the warm-up progress of the active symbol's indicators
+func (o *Orchestrator) GetIndicatorWarmup() indicators.WarmupStatus {
+	return o.technicalAnalyzer.GetWarmup(o.activeSymbol)
+}
 
-	// Take action based on alert level
-	if alert.Level == "critical" {
-		o.handleCriticalRisk(alert.Type, nil)
+// GetCandles returns the aggregator's in-memory candles for a query, i.e. exactly what the strategies saw;
+// an empty symbol selects the active symbol
+func (o *Orchestrator) GetCandles(query data.CandleQuery) ([]types.OHLCV, error) {
+	if query.Symbol == "" {
+		query.Symbol = o.activeSymbol
 	}
+	query.Symbol = strings.ToUpper(query.Symbol)
+	return o.candleAggregator.QueryCandles(query)
 }
 
-// closeAllPositions closes all open positions
-func (o *Orchestrator) closeAllPositions() error {
-	position, err := o.tradingExecutor.GetPosition(o.activeSymbol)
-	if err != nil {
-		return err
-	}
+// GetDailyLossStats returns the day's PnL against the daily loss limit
+func (o *Orchestrator) GetDailyLossStats() map[string]interface{} {
+	return o.dailyLoss.GetDailyLossStats()
+}
 
-	if position != nil && math.Abs(position.Size) > 0 {
-		var err error
-		if position.Size > 0 {
-			_, err = o.tradingExecutor.CloseLong(o.activeSymbol, position.Size, 0)
-		} else {
-			_, err = o.tradingExecutor.CloseShort(o.activeSymbol, -position.Size, 0)
-		}
-		if err != nil {
-			return err
-		}
-		log.Printf("📉 Emergency position close: %.4f @ %.2f", position.Size, position.EntryPrice)
+// GetFeeGovernorStats returns fee budget and grid throttle statistics
+func (o *Orchestrator) GetFeeGovernorStats() map[string]interface{} {
+	return o.feeGovernor.GetFeeGovernorStats()
+}
+
+// GetBasisMonitor returns the spot-futures basis monitor, or nil when basis monitoring is off
+func (o *Orchestrator) GetBasisMonitor() *strategy.BasisMonitor {
+	return o.basisMonitor
+}
+
+// GetVolatilityBreakerStats returns volatility circuit breaker statistics
+func (o *Orchestrator) GetVolatilityBreakerStats() map[string]interface{} {
+	return o.volatilityBreaker.GetVolatilityBreakerStats()
+}
+
+// GetLiquidityGuardStats returns spread and depth check statistics
+func (o *Orchestrator) GetLiquidityGuardStats() map[string]interface{} {
+	return o.liquidityGuard.GetLiquidityGuardStats()
+}
+
+// GetCurrencyStats returns accounting currency conversion statistics
+func (o *Orchestrator) GetCurrencyStats() map[string]interface{} {
+	return o.currency.GetCurrencyStats()
+}
+
+// GetRateLimitStats returns exchange rate limit ban statistics
+func (o *Orchestrator) GetRateLimitStats() map[string]interface{} {
+	return o.rateLimits.GetRateLimitStats()
+}
+
+// GetSignalDedupStats returns repeated signal suppression statistics
+func (o *Orchestrator) GetSignalDedupStats() map[string]interface{} {
+	return o.signalDedup.GetSignalDedupStats()
+}
+
+// GetOrderBudgetStats returns open order budget statistics
+func (o *Orchestrator) GetOrderBudgetStats() map[string]interface{} {
+	return o.orderBudget.GetOrderBudgetStats()
+}
+
+// GetDataQueueStats returns backpressure statistics of the market data queue and reuse of its pooled data
+func (o *Orchestrator) GetDataQueueStats() map[string]interface{} {
+	stats := o.dataQueue.GetQueueStats()
+	stats["pools"] = data.GetPoolStats()
+	return stats
+}
+
+// GetStaleFilterStats returns stale and duplicate stream data statistics
+func (o *Orchestrator) GetStaleFilterStats() map[string]interface{} {
+	return o.staleFilter.GetFilterStats()
+}
+
+// GetEventBus returns the event bus components subscribe to for orchestrator events
+func (o *Orchestrator) GetEventBus() *EventBus {
+	return o.events
+}
+
+// GetFailoverStats returns redundant feed statistics, or nil without a backup feed
+func (o *Orchestrator) GetFailoverStats() map[string]interface{} {
+	failover, ok := o.streamProvider.(*stream.FailoverProvider)
+	if !ok {
+		return nil
 	}
+	return failover.GetFailoverStats()
+}
 
-	return nil
+// GetCapitalAllocator returns the capital allocator, or nil if capital is not split between strategies
+func (o *Orchestrator) GetCapitalAllocator() *strategy.CapitalAllocator {
+	return o.capitalAllocator
 }
 
-// modeManagementWorker manages mode transitions and state
-func (o *Orchestrator) modeManagementWorker() {
+// GetProfitSweeper returns the profit sweeper, or nil if sweeping is disabled
+func (o *Orchestrator) GetProfitSweeper() *ProfitSweeper {
+	return o.profitSweeper
+}
+
+// GetDailyReporter returns the end-of-day reporter, or nil if the report is disabled
+func (o *Orchestrator) GetDailyReporter() *DailyReporter {
+	return o.dailyReporter
+}
+
+// GetLedger returns the balance and PnL ledger
+func (o *Orchestrator) GetLedger() *ledger.Ledger {
+	return o.ledger
+}
+
+// GetCalibrationReport returns the signal confidence calibration of closed breakout trades
+func (o *Orchestrator) GetCalibrationReport() *strategy.CalibrationReport {
+	return o.calibration.Report(strategy.DefaultCalibrationBins)
+}
+
+// GetPolicyViolations returns the most recent orders refused by the risk policy, oldest first
+func (o *Orchestrator) GetPolicyViolations() []strategy.PolicyViolation {
+	return o.riskManager.GetPolicyViolations()
+}
+
+// GetIntentStats returns order intent queue statistics
+func (o *Orchestrator) GetIntentStats() map[string]interface{} {
+	return o.intents.GetIntentStats()
+}
+
+// GetTracingStats returns latency statistics of the traced pipeline stages
+func (o *Orchestrator) GetTracingStats() map[string]interface{} {
+	return o.tracer.GetTracingStats()
+}
+
+// controlWorker processes control commands
+func (o *Orchestrator) controlWorker() {
 	defer o.wg.Done()
 
 	for {
@@ -1080,126 +4701,332 @@ func (o *Orchestrator) modeManagementWorker() {
 		case <-o.ctx.Done():
 			return
 
-		default:
-			// Periodic mode health checks
-			time.Sleep(5 * time.Second)
-			o.checkModeHealth()
+		case cmd := <-o.controlChan:
+			err := o.processControlCommand(cmd)
+			if cmd.Reply != nil {
+				cmd.Reply <- err
+			}
 		}
 	}
 }
 
-// checkModeHealth performs health checks on current mode
-func (o *Orchestrator) checkModeHealth() {
-	o.mu.RLock()
-	currentMode := o.state.Mode
-	lastUpdate := o.state.LastUpdateTime
-	o.mu.RUnlock()
-
-	// Check if mode has been inactive for too long
-	if time.Since(lastUpdate) > 30*time.Second {
-		log.Printf("⚠️ Mode %s inactive for %v", currentMode, time.Since(lastUpdate))
-
-		// Auto-switch to grid if stuck in other modes
-		if currentMode != ModeGrid && currentMode != ModeIdle {
-			log.Printf("🔄 Auto-switching to grid mode due to inactivity")
-			_ = o.switchMode(ModeGrid)
+// processControlCommand processes control commands
+func (o *Orchestrator) processControlCommand(cmd ControlCommand) error {
+	switch cmd.Type {
+	case "stop":
+		return o.Stop()
+	case "pause":
+		return o.switchMode(ModeIdle)
+	case "resume":
+		return o.switchMode(ModeGrid)
+	case "switch_mode":
+		if params, ok := cmd.Payload.(SwitchModeParams); ok {
+			return o.switchMode(params.Mode)
+		}
+		return fmt.Errorf("switch_mode requires a trading mode payload")
+	case "lock_mode":
+		if params, ok := cmd.Payload.(LockModeParams); ok {
+			return o.lockMode(params)
 		}
+		return fmt.Errorf("lock_mode requires a trading mode payload")
+	case "unlock_mode":
+		return o.unlockMode()
+	case "update_grid":
+		if update, ok := cmd.Payload.(GridUpdate); ok {
+			return o.updateGrid(update)
+		}
+		return fmt.Errorf("update_grid requires a grid update payload")
+	case "set_symbol":
+		if params, ok := cmd.Payload.(SetSymbolParams); ok {
+			return o.setActiveSymbol(params.Symbol)
+		}
+		return fmt.Errorf("set_symbol requires a symbol payload")
+	case "subscribe":
+		if params, ok := cmd.Payload.(SubscriptionParams); ok {
+			return o.subscribeSymbols(params)
+		}
+		return fmt.Errorf("subscribe requires a symbols payload")
+	case "unsubscribe":
+		if params, ok := cmd.Payload.(SubscriptionParams); ok {
+			return o.unsubscribeSymbols(params)
+		}
+		return fmt.Errorf("unsubscribe requires a symbols payload")
+	case "update_risk_limit":
+		if params, ok := cmd.Payload.(RiskLimitParams); ok {
+			return o.updateRiskLimits(params)
+		}
+		return fmt.Errorf("update_risk_limit requires a risk limit payload")
+	case "close_position":
+		if params, ok := cmd.Payload.(ClosePositionParams); ok {
+			return o.closePosition(params)
+		}
+		return fmt.Errorf("close_position requires a position payload")
+	case "place_order":
+		if params, ok := cmd.Payload.(PlaceOrderParams); ok {
+			order, err := o.placeManualOrder(params)
+			cmd.replyOrder(order)
+			return err
+		}
+		return fmt.Errorf("place_order requires an order payload")
+	case "modify_order":
+		if params, ok := cmd.Payload.(ModifyOrderParams); ok {
+			order, err := o.modifyManualOrder(params)
+			cmd.replyOrder(order)
+			return err
+		}
+		return fmt.Errorf("modify_order requires an order change payload")
+	case "cancel_order":
+		if params, ok := cmd.Payload.(CancelOrderParams); ok {
+			order, err := o.cancelManualOrder(params)
+			cmd.replyOrder(order)
+			return err
+		}
+		return fmt.Errorf("cancel_order requires an order ID payload")
+	case "close_all":
+		// Leaving the active mode cancels resting grid orders before positions are flattened
+		o.mu.RLock()
+		mode := o.state.Mode
+		o.mu.RUnlock()
+		if mode != ModeIdle {
+			if err := o.switchMode(ModeIdle); err != nil {
+				return err
+			}
+		}
+		return o.closeAllPositions(types.NewOrderTag("operator", "close_all"))
 	}
+	return fmt.Errorf("unknown control command: %s", cmd.Type)
 }
 
-// performanceWorker tracks performance metrics
-func (o *Orchestrator) performanceWorker() {
-	defer o.wg.Done()
+// setActiveSymbol moves trading to another symbol. The bot must be idle and flat on the current symbol, so
+// no grid orders or positions are left behind; indicators warm up afresh unless the symbol was watched.
+func (o *Orchestrator) setActiveSymbol(symbol string) error {
+	if err := (SetSymbolParams{Symbol: symbol}).Validate(); err != nil {
+		return err
+	}
+	if symbol == o.activeSymbol {
+		return nil
+	}
 
-	ticker := time.NewTicker(1 * time.Minute)
-	defer ticker.Stop()
+	o.mu.Lock()
+	defer o.mu.Unlock()
 
-	for {
-		select {
-		case <-o.ctx.Done():
-			return
+	if o.state.Mode != ModeIdle {
+		return fmt.Errorf("set_symbol requires idle mode, current mode is %s", o.state.Mode)
+	}
+	o.positionMu.Lock()
+	states := o.activePositionStates()
+	o.positionMu.Unlock()
+	if len(states) > 0 {
+		return fmt.Errorf("close the open %s position before changing symbol", o.activeSymbol)
+	}
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		return fmt.Errorf("failed to check open positions: %w", err)
+	}
+	for _, position := range positions {
+		if position.Symbol == o.activeSymbol && !position.IsFlat() {
+			return fmt.Errorf("close the open %s position before changing symbol", o.activeSymbol)
+		}
+	}
 
-		case <-ticker.C:
-			o.updatePerformanceMetrics()
+	// A watched symbol keeps its warm candles and indicators; the previous symbol stops streaming
+	if !o.subscriptions.Contains(symbol) {
+		if err := o.addSubscriptions([]string{symbol}); err != nil {
+			return err
 		}
 	}
+	previous := o.activeSymbol
+	o.removeSubscriptions([]string{previous})
+
+	o.activeSymbol = symbol
+	o.state.CurrentSymbol = symbol
+	o.dataGap = false
+	o.loadSymbolPrecision(symbol)
+	o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "🔀 Active symbol changed: %s -> %s", previous, symbol)
+	return nil
 }
 
-// updatePerformanceMetrics updates performance tracking
-func (o *Orchestrator) updatePerformanceMetrics() {
-	// Get current position and balance
-	position, err := o.tradingExecutor.GetPosition(o.activeSymbol)
-	if err != nil {
+// loadSymbolPrecision takes the quantity step and price tick of a symbol from the exchange's trading
+// rules, so positions are compared at the symbol's precision; configured contract values are kept when
+// the exchange reports none
+func (o *Orchestrator) loadSymbolPrecision(symbol string) {
+	accountInfo, ok := o.tradingExecutor.(trading.AccountInfoProvider)
+	if !ok {
 		return
 	}
-
-	balance, err := o.tradingExecutor.GetAvailableBalance()
+	rules, err := accountInfo.GetSymbolRules(symbol)
 	if err != nil {
+		o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to load %s trading rules, using configured precision: %v", symbol, err)
+		return
+	}
+	if rules.StepSize <= 0 && rules.TickSize <= 0 {
 		return
 	}
 
-	// Update performance metrics
+	o.positionMu.Lock()
+	defer o.positionMu.Unlock()
+	precision := o.positionManager.Contracts[symbol].Precision()
+	if rules.StepSize > 0 {
+		precision.StepSize = rules.StepSize
+	}
+	if rules.TickSize > 0 {
+		precision.TickSize = rules.TickSize
+	}
+	o.positionManager.SetPrecision(symbol, precision)
+	o.logf(logging.ComponentExecutor, logging.DebugLevel, "📏 %s precision: step %g, tick %g", symbol, precision.StepSize, precision.TickSize)
+}
+
+// subscribeSymbols starts streaming symbols in addition to the active symbol, so their candles and
+// indicators are warm when trading moves to them; the screener or an operator can watch candidates this way
+func (o *Orchestrator) subscribeSymbols(params SubscriptionParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
 	o.mu.Lock()
+	defer o.mu.Unlock()
 
-	if position != nil {
-		o.performance.TotalPnL = position.UnrealizedPnL
+	pending := make([]string, 0, len(params.Symbols))
+	for _, symbol := range params.Symbols {
+		if !o.subscriptions.Contains(symbol) {
+			pending = append(pending, symbol)
+		}
 	}
+	if max := o.config.MaxSymbols; max > 0 && o.subscriptions.Len()+len(pending) > max {
+		return fmt.Errorf("subscribing %d more symbols exceeds max symbols %d", len(pending), max)
+	}
+	return o.addSubscriptions(pending)
+}
 
-	// Calculate drawdown
-	peak := o.config.InitialBalance
-	if balance > peak {
-		peak = balance
+// unsubscribeSymbols stops streaming watched symbols; the active symbol cannot be unsubscribed
+func (o *Orchestrator) unsubscribeSymbols(params SubscriptionParams) error {
+	if err := params.Validate(); err != nil {
+		return err
 	}
-	drawdown := (peak - balance) / peak
-	o.performance.CurrentDrawdown = drawdown
 
-	if drawdown > o.performance.MaxDrawdown {
-		o.performance.MaxDrawdown = drawdown
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, symbol := range params.Symbols {
+		if symbol == o.activeSymbol {
+			return fmt.Errorf("cannot unsubscribe the active symbol %s; use set_symbol first", symbol)
+		}
 	}
+	o.removeSubscriptions(params.Symbols)
+	return nil
+}
 
-	o.state.TotalPnL = o.performance.TotalPnL
-	o.state.CurrentDrawdown = o.performance.CurrentDrawdown
-	o.state.MaxDrawdown = o.performance.MaxDrawdown
+// addSubscriptions subscribes the stream to symbols and prepares their candles; caller must hold o.mu
+func (o *Orchestrator) addSubscriptions(symbols []string) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+	if o.streamProvider != nil {
+		if err := o.streamProvider.Subscribe(symbols); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", strings.Join(symbols, ", "), err)
+		}
+	}
+	for _, symbol := range o.subscriptions.Add(symbols) {
+		o.candleAggregator.AddSymbol(symbol, nil)
+	}
+	o.logf(logging.ComponentStream, logging.InfoLevel, "📡 Subscribed to %s", strings.Join(symbols, ", "))
+	return nil
+}
 
-	o.mu.Unlock()
+// removeSubscriptions unsubscribes the stream from symbols and drops their candles and indicators; a
+// failed unsubscribe only costs bandwidth, so it is logged and the symbols are dropped anyway. Caller must
+// hold o.mu.
+func (o *Orchestrator) removeSubscriptions(symbols []string) {
+	removed := o.subscriptions.Remove(symbols)
+	if len(removed) == 0 {
+		return
+	}
+	if o.streamProvider != nil {
+		if err := o.streamProvider.Unsubscribe(removed); err != nil {
+			o.logf(logging.ComponentStream, logging.WarnLevel, "⚠️ Failed to unsubscribe from %s: %v", strings.Join(removed, ", "), err)
+		}
+	}
+	for _, symbol := range removed {
+		o.candleAggregator.RemoveSymbol(symbol)
+		o.technicalAnalyzer.Clear(symbol)
+	}
+	o.logf(logging.ComponentStream, logging.InfoLevel, "📡 Unsubscribed from %s", strings.Join(removed, ", "))
 }
 
-// controlWorker processes control commands
-func (o *Orchestrator) controlWorker() {
-	defer o.wg.Done()
+// updateRiskLimits applies operator changes to the daily loss limit and the risk manager's limits
+func (o *Orchestrator) updateRiskLimits(params RiskLimitParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	if err := o.riskManager.UpdateLimits(params.RiskLimits()); err != nil {
+		return err
+	}
+	if params.MaxDailyLoss != nil {
+		o.dailyLoss.SetMaxLoss(*params.MaxDailyLoss)
+		o.config.MaxDailyLoss = *params.MaxDailyLoss
+	}
+	o.logf(logging.ComponentRisk, logging.InfoLevel, "🛡️ Risk limits updated by operator: %+v", params.RiskLimits())
+	return nil
+}
 
-	for {
-		select {
-		case <-o.ctx.Done():
-			return
+// closePosition closes the executor positions of a symbol at market, one side or both. A tracked breakout
+// position on the active symbol is closed through the position manager first so its trade is recorded.
+func (o *Orchestrator) closePosition(params ClosePositionParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
 
-		case cmd := <-o.controlChan:
-			o.processControlCommand(cmd)
+	var trackedType types.PositionType
+	if params.Symbol == o.activeSymbol {
+		o.positionMu.Lock()
+		state, exists := o.positionManager.GetPosition(o.activeSymbol)
+		if exists && (params.PositionType == "" || state.Position.Type == params.PositionType) {
+			trackedType = state.Position.Type
+		}
+		o.positionMu.Unlock()
+		if trackedType != "" {
+			o.closeBreakoutPosition(o.ctx, o.candleAggregator.GetLatestPrice(o.activeSymbol), "Operator close")
 		}
 	}
-}
 
-// processControlCommand processes control commands
-func (o *Orchestrator) processControlCommand(cmd ControlCommand) {
-	switch cmd.Type {
-	case "stop":
-		_ = o.Stop()
-	case "pause":
-		o.switchMode(ModeIdle)
-	case "resume":
-		_ = o.switchMode(ModeGrid)
-	case "switch_mode":
-		if mode, ok := cmd.Payload.(TradingMode); ok {
-			_ = o.switchMode(mode)
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		return err
+	}
+	closed := 0
+	for _, position := range positions {
+		if position.Symbol != params.Symbol || position.IsFlat() {
+			continue
+		}
+		if params.PositionType != "" && position.Type != params.PositionType {
+			continue
+		}
+		if position.Type == trackedType {
+			continue // Already closed with the breakout position
+		}
+		if err := o.closeExecutorPosition(o.ctx, position, execution.IntentEmergencyClose, types.NewOrderTag("operator", "close_position")); err != nil {
+			return err
 		}
+		closed++
+		log.Printf("📉 Operator position close: %s %s %.4f", position.Symbol, position.Type, position.Size)
 	}
+	if closed == 0 && trackedType == "" {
+		o.logf(logging.ComponentOrchestrator, logging.InfoLevel, "ℹ️ No open %s position to close", params.Symbol)
+	}
+	return nil
 }
 
 // GetState returns current bot state
 func (o *Orchestrator) GetState() BotState {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
-	return o.state
+	state := o.state
+	state.Symbols = o.subscriptions.Symbols()
+	if state.ModeLock != nil {
+		lock := *state.ModeLock
+		state.ModeLock = &lock
+	}
+	return state
 }
 
 // GetPerformance returns performance metrics
@@ -1209,6 +5036,13 @@ func (o *Orchestrator) GetPerformance() PerformanceMetrics {
 	return o.performance
 }
 
+// GetTradeReturns returns the per-trade net returns on the initial balance the Sharpe ratio is built from
+func (o *Orchestrator) GetTradeReturns() journal.ReturnSample {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return journal.ReturnSample{Count: o.tradeReturns, Sum: o.tradeReturnSum, SumSq: o.tradeReturnSumSq}
+}
+
 // SendControlCommand sends a control command to the orchestrator
 func (o *Orchestrator) SendControlCommand(cmd ControlCommand) {
 	select {
@@ -1218,6 +5052,83 @@ func (o *Orchestrator) SendControlCommand(cmd ControlCommand) {
 	}
 }
 
+// ExecuteControlCommand sends a control command and waits for the control worker to process it
+func (o *Orchestrator) ExecuteControlCommand(cmd ControlCommand, timeout time.Duration) error {
+	if !o.GetState().IsActive {
+		return fmt.Errorf("orchestrator is not active")
+	}
+
+	cmd.Reply = make(chan error, 1)
+	select {
+	case o.controlChan <- cmd:
+	default:
+		return fmt.Errorf("control channel full, dropping command: %s", cmd.Type)
+	}
+
+	select {
+	case err := <-cmd.Reply:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out waiting for %s to be processed", cmd.Type)
+	}
+}
+
+// ExecuteOrderCommand runs a control command like ExecuteControlCommand and returns the order an order
+// command placed, modified or cancelled; other commands return a nil order
+func (o *Orchestrator) ExecuteOrderCommand(cmd ControlCommand, timeout time.Duration) (*types.Order, error) {
+	cmd.Order = make(chan *types.Order, 1)
+	err := o.ExecuteControlCommand(cmd, timeout)
+	select {
+	case order := <-cmd.Order:
+		return order, err
+	default:
+		return nil, err
+	}
+}
+
+// replyOrder hands the order of an order command to the waiting caller, if any
+func (cmd ControlCommand) replyOrder(order *types.Order) {
+	if cmd.Order == nil {
+		return
+	}
+	select {
+	case cmd.Order <- order:
+	default:
+	}
+}
+
+// Done returns a channel that is closed once the orchestrator is stopping, including via a stop command
+func (o *Orchestrator) Done() <-chan struct{} {
+	return o.ctx.Done()
+}
+
+// WaitDataProcessed blocks until the market data delivered by the stream has been taken for processing,
+// or ctx is done, so a finished replay plays out before shutdown
+func (o *Orchestrator) WaitDataProcessed(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for len(o.streamProvider.GetTickerChannel()) > 0 || len(o.streamProvider.GetOHLCVChannel()) > 0 || o.dataQueue.Len() > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetControlServer returns the control server, or nil if it is disabled
+func (o *Orchestrator) GetControlServer() *ControlServer {
+	return o.controlServer
+}
+
+// GetHealthServer returns the health probe server, or nil if it is disabled
+func (o *Orchestrator) GetHealthServer() *HealthServer {
+	return o.healthServer
+}
+
 // Helper function
 func abs(x float64) float64 {
 	if x < 0 {
@@ -0,0 +1,267 @@
+package bot
+
+import (
+	"aibot/internal/journal"
+	"aibot/pkg/trading"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Binance universal transfer types moving balance out of the USDT-M futures wallet
+const (
+	TransferFuturesToSpot    = "UMFUTURE_MAIN"
+	TransferFuturesToFunding = "UMFUTURE_FUNDING"
+)
+
+// ProfitSweepConfig holds configuration for moving realized profit above the working capital to another wallet
+type ProfitSweepConfig struct {
+	WorkingCapital float64       `json:"working_capital"` // Balance kept in the trading wallet (initial balance)
+	Threshold      float64       `json:"threshold"`       // Profit above the working capital that triggers a sweep (must be set)
+	Asset          string        `json:"asset"`           // Asset transferred (USDT)
+	TransferType   string        `json:"transfer_type"`   // Universal transfer type (UMFUTURE_MAIN)
+	CheckInterval  time.Duration `json:"check_interval"`  // Balance check interval (5m)
+	URL            string        `json:"url"`             // Binance-compatible wallet API (https://api.binance.com)
+	APIKey         string        `json:"-"`               // Without credentials the executor transfers, e.g. in simulation
+	APISecret      string        `json:"-"`
+	Timeout        time.Duration `json:"timeout"`     // Request timeout (10s)
+	RecvWindow     time.Duration `json:"recv_window"` // Validity of a signed request (5s)
+	StatePath      string        `json:"state_path"`  // Checkpoint of the total swept per account ("" keeps it in memory)
+	AccountID      string        `json:"account_id"`
+}
+
+// ProfitSweep is one transfer of profit out of the trading wallet
+type ProfitSweep struct {
+	TransferID     string    `json:"transfer_id"`
+	Asset          string    `json:"asset"`
+	Amount         float64   `json:"amount"`
+	TransferType   string    `json:"transfer_type"`
+	BalanceBefore  float64   `json:"balance_before"`
+	WorkingCapital float64   `json:"working_capital"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// sweepState is the checkpointed sweep history of an account
+type sweepState struct {
+	TotalSwept float64     `json:"total_swept"`
+	Sweeps     int64       `json:"sweeps"`
+	LastSweep  ProfitSweep `json:"last_sweep"`
+}
+
+// ProfitSweeper transfers realized profit above the working capital out of the trading wallet through the
+// exchange's wallet transfer API, or the executor when no credentials are configured. The total swept is
+// checkpointed so drawdown and equity filters can keep counting swept profit as earned.
+type ProfitSweeper struct {
+	config      ProfitSweepConfig
+	client      *http.Client
+	executor    trading.WalletTransferProvider // nil when the executor cannot transfer
+	checkpoints *journal.CheckpointStore       // nil when StatePath is empty
+	accounts    map[string]sweepState
+
+	// Statistics
+	failures  int64
+	lastError string
+
+	mu sync.Mutex
+}
+
+// NewProfitSweeper creates a profit sweeper and restores the account's sweep history
+func NewProfitSweeper(config ProfitSweepConfig, executor trading.TradingExecutor) (*ProfitSweeper, error) {
+	if config.Threshold <= 0 {
+		return nil, fmt.Errorf("profit sweep threshold must be positive")
+	}
+	if config.Asset == "" {
+		config.Asset = "USDT" // default
+	}
+	if config.TransferType == "" {
+		config.TransferType = TransferFuturesToSpot // default
+	}
+	if config.CheckInterval == 0 {
+		config.CheckInterval = 5 * time.Minute // default
+	}
+	if config.URL == "" {
+		config.URL = "https://api.binance.com" // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default
+	}
+	if config.RecvWindow == 0 {
+		config.RecvWindow = 5 * time.Second // default
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	sweeper := &ProfitSweeper{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		accounts: make(map[string]sweepState),
+	}
+	if provider, ok := executor.(trading.WalletTransferProvider); ok {
+		sweeper.executor = provider
+	}
+	if sweeper.executor == nil && (config.APIKey == "" || config.APISecret == "") {
+		return nil, fmt.Errorf("profit sweep requires API credentials or an executor that supports transfers")
+	}
+
+	if config.StatePath != "" {
+		checkpoints, err := journal.NewCheckpointStore(config.StatePath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := checkpoints.Load(&sweeper.accounts); err != nil {
+			return nil, fmt.Errorf("failed to load profit sweep state: %w", err)
+		}
+		if sweeper.accounts == nil {
+			sweeper.accounts = make(map[string]sweepState)
+		}
+		sweeper.checkpoints = checkpoints
+	}
+	return sweeper, nil
+}
+
+// Plan returns the amount to sweep for a wallet balance, limited to the transferable balance, or 0 while
+// profit above the working capital is below the threshold
+func (s *ProfitSweeper) Plan(balance, transferable float64) float64 {
+	excess := balance - s.config.WorkingCapital
+	if excess < s.config.Threshold {
+		return 0
+	}
+	amount := math.Floor(math.Min(excess, transferable)*100) / 100
+	if amount <= 0 {
+		return 0
+	}
+	return amount
+}
+
+// Sweep transfers amount out of the trading wallet and checkpoints the account's total swept
+func (s *ProfitSweeper) Sweep(ctx context.Context, amount, balance float64) (ProfitSweep, error) {
+	var transferID string
+	var err error
+	if s.config.APIKey != "" && s.config.APISecret != "" {
+		transferID, err = s.transfer(ctx, amount)
+	} else {
+		transferID, err = s.executor.TransferOut(s.config.Asset, amount)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.failures++
+		s.lastError = err.Error()
+		return ProfitSweep{}, err
+	}
+	s.lastError = ""
+
+	sweep := ProfitSweep{
+		TransferID:     transferID,
+		Asset:          s.config.Asset,
+		Amount:         amount,
+		TransferType:   s.config.TransferType,
+		BalanceBefore:  balance,
+		WorkingCapital: s.config.WorkingCapital,
+		Timestamp:      time.Now(),
+	}
+	state := s.accounts[s.config.AccountID]
+	state.TotalSwept += amount
+	state.Sweeps++
+	state.LastSweep = sweep
+	s.accounts[s.config.AccountID] = state
+
+	if s.checkpoints != nil {
+		if err := s.checkpoints.Save(s.accounts); err != nil {
+			return sweep, fmt.Errorf("profit swept but state not saved: %w", err)
+		}
+	}
+	return sweep, nil
+}
+
+// transfer requests a universal transfer from the exchange's wallet API and returns its transaction ID
+func (s *ProfitSweeper) transfer(ctx context.Context, amount float64) (string, error) {
+	query := url.Values{}
+	query.Set("type", s.config.TransferType)
+	query.Set("asset", s.config.Asset)
+	query.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	query.Set("recvWindow", strconv.FormatInt(s.config.RecvWindow.Milliseconds(), 10))
+	query.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	payload := query.Encode()
+	mac := hmac.New(sha256.New, []byte(s.config.APISecret))
+	mac.Write([]byte(payload))
+	body := payload + "&signature=" + hex.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL+"/sapi/v1/asset/transfer", strings.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create transfer request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-MBX-APIKEY", s.config.APIKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request transfer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		TranID int64  `json:"tranId"`
+		Code   int    `json:"code"`
+		Msg    string `json:"msg"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+	if rateLimit := trading.ParseRateLimitResponse(resp.StatusCode, resp.Header, time.Now(), trading.DefaultBanFallback); rateLimit != nil {
+		rateLimit.Message = result.Msg
+		return "", fmt.Errorf("transfer: %w", rateLimit)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if decodeErr == nil && result.Msg != "" {
+			return "", fmt.Errorf("transfer returned status %d: %s (code %d)", resp.StatusCode, result.Msg, result.Code)
+		}
+		return "", fmt.Errorf("transfer returned status %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("failed to decode transfer response: %w", decodeErr)
+	}
+	return strconv.FormatInt(result.TranID, 10), nil
+}
+
+// TotalSwept returns the profit swept out of the account so far; 0 for a nil sweeper
+func (s *ProfitSweeper) TotalSwept() float64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accounts[s.config.AccountID].TotalSwept
+}
+
+// GetProfitSweepStats returns profit sweep statistics
+func (s *ProfitSweeper) GetProfitSweepStats() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.accounts[s.config.AccountID]
+	stats := map[string]interface{}{
+		"working_capital": s.config.WorkingCapital,
+		"threshold":       s.config.Threshold,
+		"asset":           s.config.Asset,
+		"transfer_type":   s.config.TransferType,
+		"total_swept":     state.TotalSwept,
+		"sweeps":          state.Sweeps,
+		"failures":        s.failures,
+		"last_error":      s.lastError,
+	}
+	if state.Sweeps > 0 {
+		stats["last_sweep"] = state.LastSweep
+	}
+	return stats
+}
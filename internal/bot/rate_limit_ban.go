@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"fmt"
+	"time"
+)
+
+// noteRateLimit enters the protective mode when err carries an exchange rate limit or ban: REST calls stop
+// (exits still go out under a plain rate limit), the bot runs on stream data alone and an alert is raised,
+// critical for an IP ban, until the ban window ends. Returns true if err was a rate limit.
+func (o *Orchestrator) noteRateLimit(err error) bool {
+	ban, extended := o.rateLimits.Record(err)
+	if ban == nil {
+		return false
+	}
+	if !extended {
+		return true
+	}
+
+	// An IP ban refuses every request until it ends, while a plain rate limit still lets exits through
+	level, consequence := "warning", "entries and grid orders paused"
+	if ban.IsBan() {
+		level, consequence = "critical", "IP banned"
+	}
+	o.logf(logging.ComponentExecutor, logging.ErrorLevel, "🛑 %v, pausing REST calls for %s", ban, ban.RetryAfter)
+	o.publishRiskAlert(RiskAlert{
+		Level:     level,
+		Type:      "rate_limit_ban",
+		Symbol:    o.activeSymbol,
+		Message:   fmt.Sprintf("Exchange answered HTTP %d (%s): REST calls paused until %s, trading on stream data only", ban.StatusCode, consequence, ban.Until.Format(time.RFC3339)),
+		Value:     ban.RetryAfter.Seconds(),
+		Timestamp: time.Now(),
+	})
+	o.scheduleRateLimitResume(ban.Until)
+	return true
+}
+
+// restPaused returns true while a rate limit ban pauses REST calls
+func (o *Orchestrator) restPaused() bool {
+	_, banned := o.rateLimits.Active(time.Now())
+	return banned
+}
+
+// checkRateLimit returns the active ban if it refuses an order: an IP ban refuses every request, while a
+// plain rate limit still lets exits through, since a position left open is a bigger risk than an extended
+// rate limit
+func (o *Orchestrator) checkRateLimit(exit bool) error {
+	ban, banned := o.rateLimits.Active(time.Now())
+	if !banned || (exit && !ban.IsBan()) {
+		return nil
+	}
+	return ban
+}
+
+// deferCancels keeps orders pulled during a ban for cancellation once it ends, since cancelling now
+// would extend it
+func (o *Orchestrator) deferCancels(orderIDs []string) {
+	if len(orderIDs) == 0 {
+		return
+	}
+	o.rateLimitMu.Lock()
+	o.deferredCancels = append(o.deferredCancels, orderIDs...)
+	o.rateLimitMu.Unlock()
+	o.logf(logging.ComponentExecutor, logging.WarnLevel, "⏸️ REST calls paused, cancelling %d orders when the ban ends", len(orderIDs))
+}
+
+// cancelDeferred cancels the orders pulled during the ban
+func (o *Orchestrator) cancelDeferred() {
+	o.rateLimitMu.Lock()
+	orderIDs := o.deferredCancels
+	o.deferredCancels = nil
+	o.rateLimitMu.Unlock()
+
+	for _, orderID := range orderIDs {
+		if err := o.tradingExecutor.CancelOrder(orderID); err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to cancel order %s: %v", orderID, err)
+			if o.noteRateLimit(err) {
+				o.deferCancels([]string{orderID})
+			}
+		}
+	}
+	if len(orderIDs) > 0 {
+		o.logf(logging.ComponentExecutor, logging.InfoLevel, "🧹 Cancelled %d orders pulled during the ban", len(orderIDs))
+	}
+}
+
+// scheduleRateLimitResume queues a rate_limit_resume signal for when the ban window ends; an extended
+// ban reschedules it
+func (o *Orchestrator) scheduleRateLimitResume(until time.Time) {
+	o.rateLimitMu.Lock()
+	defer o.rateLimitMu.Unlock()
+
+	if o.rateLimitResume != nil {
+		o.rateLimitResume.Stop()
+	}
+	o.rateLimitResume = time.AfterFunc(time.Until(until), func() {
+		if o.ctx.Err() != nil {
+			return
+		}
+		o.publishSignal(TradingSignal{
+			Type:      "rate_limit_resume",
+			Symbol:    o.activeSymbol,
+			Action:    "resume_entries",
+			Reason:    "rate limit ban ended",
+			Timestamp: time.Now(),
+		})
+	})
+}
+
+// stopRateLimitResume cancels a pending rate_limit_resume signal
+func (o *Orchestrator) stopRateLimitResume() {
+	o.rateLimitMu.Lock()
+	defer o.rateLimitMu.Unlock()
+
+	if o.rateLimitResume != nil {
+		o.rateLimitResume.Stop()
+		o.rateLimitResume = nil
+	}
+}
+
+// handleRateLimitResume resumes REST calls once the ban is over: grid orders pulled and order intents left
+// pending during it are resolved, and the grid levels skipped during it are placed
+func (o *Orchestrator) handleRateLimitResume(signal TradingSignal) {
+	if o.restPaused() {
+		return
+	}
+	o.logf(logging.ComponentExecutor, logging.InfoLevel, "✅ Rate limit ban over, resuming REST calls")
+	o.cancelDeferred()
+	if o.deferredRecovery.Load() {
+		o.recoverOrderIntents()
+	}
+
+	o.mu.RLock()
+	mode := o.state.Mode
+	o.mu.RUnlock()
+	if mode == ModeGrid {
+		o.placeGridOrders(signal.Context)
+	}
+}
@@ -0,0 +1,244 @@
+package bot
+
+import (
+	"aibot/internal/execution"
+	"aibot/internal/logging"
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Recovery escalation actions
+const (
+	RecoveryActionReduce  = "reduce"  // Close a fraction of the open position
+	RecoveryActionFlatten = "flatten" // Close the open position
+	RecoveryActionIdle    = "idle"    // Switch to idle and raise a critical alert
+)
+
+// RecoveryStep is one escalation step of a recovery that has not completed in time
+type RecoveryStep struct {
+	After    time.Duration `json:"after"`              // Time in recovery mode before the step runs
+	Action   string        `json:"action"`             // "reduce", "flatten" or "idle"
+	Fraction float64       `json:"fraction,omitempty"` // Reduce: share of the open position closed (0.5)
+}
+
+// RecoveryEscalationConfig holds the escalation steps of recovery mode
+type RecoveryEscalationConfig struct {
+	Steps []RecoveryStep `json:"steps"` // Run in order of After; nil uses the defaults, empty disables escalation
+}
+
+// DefaultRecoverySteps halves exposure after 15 minutes, flattens after 30 and gives up after an hour
+func DefaultRecoverySteps() []RecoveryStep {
+	return []RecoveryStep{
+		{After: 15 * time.Minute, Action: RecoveryActionReduce, Fraction: 0.5},
+		{After: 30 * time.Minute, Action: RecoveryActionFlatten},
+		{After: time.Hour, Action: RecoveryActionIdle},
+	}
+}
+
+// Validate checks that steps have a known action, a positive delay and a reduce fraction of at most 1
+func (c RecoveryEscalationConfig) Validate() error {
+	for i, step := range c.Steps {
+		switch step.Action {
+		case RecoveryActionReduce:
+			if step.Fraction < 0 || step.Fraction > 1 {
+				return fmt.Errorf("recovery step %d: reduce fraction must be between 0 and 1", i)
+			}
+		case RecoveryActionFlatten, RecoveryActionIdle:
+		default:
+			return fmt.Errorf("recovery step %d: unknown action %q", i, step.Action)
+		}
+		if step.After <= 0 {
+			return fmt.Errorf("recovery step %d: after must be positive", i)
+		}
+	}
+	return nil
+}
+
+// RecoveryEscalationEvent is an escalation step that came due
+type RecoveryEscalationEvent struct {
+	Step      RecoveryStep  `json:"step"`
+	Index     int           `json:"index"` // Position of the step, from 0
+	Steps     int           `json:"steps"` // Number of steps
+	Elapsed   time.Duration `json:"elapsed"`
+	EnteredAt time.Time     `json:"entered_at"`
+}
+
+// Reason describes the step and why it runs
+func (e RecoveryEscalationEvent) Reason() string {
+	action := e.Step.Action
+	if action == RecoveryActionReduce {
+		action = fmt.Sprintf("reduce exposure by %.0f%%", e.Step.Fraction*100)
+	}
+	return fmt.Sprintf("recovery not complete after %v, step %d/%d: %s", e.Elapsed.Round(time.Second), e.Index+1, e.Steps, action)
+}
+
+// RecoveryEscalation time-boxes recovery mode: each stay in recovery runs the configured steps in turn as
+// they come due, so a recovery that never finds stability cannot hold capital indefinitely
+type RecoveryEscalation struct {
+	config RecoveryEscalationConfig
+
+	enteredAt time.Time // Entry time of the recovery the steps are counted for
+	next      int       // Index of the next step of that recovery
+
+	// Statistics
+	escalations map[string]int64 // Action -> steps run
+
+	mu sync.Mutex
+}
+
+// NewRecoveryEscalation creates a recovery escalation
+func NewRecoveryEscalation(config RecoveryEscalationConfig) *RecoveryEscalation {
+	if config.Steps == nil {
+		config.Steps = DefaultRecoverySteps() // default
+	}
+	steps := make([]RecoveryStep, len(config.Steps))
+	for i, step := range config.Steps {
+		if step.Action == RecoveryActionReduce && step.Fraction == 0 {
+			step.Fraction = 0.5 // default
+		}
+		steps[i] = step
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].After < steps[j].After })
+	config.Steps = steps
+
+	return &RecoveryEscalation{
+		config:      config,
+		escalations: make(map[string]int64),
+	}
+}
+
+// Check returns the next step that came due for a stay in mode entered at enteredAt, or nil. Each step
+// runs once per stay in recovery mode.
+func (e *RecoveryEscalation) Check(mode TradingMode, enteredAt, now time.Time) *RecoveryEscalationEvent {
+	if mode != ModeRecovery || len(e.config.Steps) == 0 {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.enteredAt.Equal(enteredAt) {
+		e.enteredAt, e.next = enteredAt, 0
+	}
+	if e.next >= len(e.config.Steps) || now.Sub(enteredAt) < e.config.Steps[e.next].After {
+		return nil
+	}
+
+	event := &RecoveryEscalationEvent{
+		Step:      e.config.Steps[e.next],
+		Index:     e.next,
+		Steps:     len(e.config.Steps),
+		Elapsed:   now.Sub(enteredAt),
+		EnteredAt: enteredAt,
+	}
+	e.next++
+	e.escalations[event.Step.Action]++
+	return event
+}
+
+// GetRecoveryEscalationStats returns recovery escalation statistics
+func (e *RecoveryEscalation) GetRecoveryEscalationStats() map[string]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	escalations := make(map[string]int64, len(e.escalations))
+	for action, count := range e.escalations {
+		escalations[action] = count
+	}
+	return map[string]interface{}{
+		"steps":       e.config.Steps,
+		"escalations": escalations,
+	}
+}
+
+// checkRecoveryEscalation raises a recovery_escalation signal when the next step of the current recovery
+// comes due
+func (o *Orchestrator) checkRecoveryEscalation() {
+	o.mu.RLock()
+	currentMode := o.state.Mode
+	modeSince := o.state.ModeSince
+	o.mu.RUnlock()
+
+	event := o.recoveryEscalation.Check(currentMode, modeSince, time.Now())
+	if event == nil {
+		return
+	}
+	o.logf(logging.ComponentOrchestrator, logging.WarnLevel, "⏰ %s", event.Reason())
+
+	o.publishSignal(TradingSignal{
+		Type:      "recovery_escalation",
+		Symbol:    o.activeSymbol,
+		Action:    event.Step.Action,
+		Reason:    event.Reason(),
+		Data:      *event,
+		Timestamp: time.Now(),
+	})
+}
+
+// handleRecoveryEscalation runs an escalation step of a recovery that is still in progress and alerts on it
+func (o *Orchestrator) handleRecoveryEscalation(signal TradingSignal) {
+	event := signal.Data.(RecoveryEscalationEvent)
+
+	o.mu.RLock()
+	currentMode := o.state.Mode
+	modeSince := o.state.ModeSince
+	o.mu.RUnlock()
+	if currentMode != ModeRecovery || !modeSince.Equal(event.EnteredAt) {
+		return // Recovery completed or was left since the step came due
+	}
+
+	alert := RiskAlert{
+		Level:     "warning",
+		Type:      "recovery_escalation",
+		Message:   fmt.Sprintf("%s %s", o.activeSymbol, event.Reason()),
+		Symbol:    o.activeSymbol,
+		Value:     event.Elapsed.Minutes(),
+		Threshold: event.Step.After.Minutes(),
+		Timestamp: signal.Timestamp,
+	}
+
+	switch event.Step.Action {
+	case RecoveryActionReduce:
+		o.reduceRecoveryExposure(event.Step.Fraction)
+
+	case RecoveryActionFlatten:
+		o.closeBreakoutPosition(o.ctx, o.candleAggregator.GetLatestPrice(o.activeSymbol), "Recovery timed out")
+		if err := o.closeAllPositions(types.NewOrderTag("recovery", "flatten")); err != nil {
+			o.logf(logging.ComponentRisk, logging.ErrorLevel, "❌ Failed to flatten timed-out recovery: %v", err)
+		}
+
+	case RecoveryActionIdle:
+		alert.Level = "critical"
+		if err := o.switchMode(ModeIdle); err != nil {
+			o.logf(logging.ComponentOrchestrator, logging.ErrorLevel, "❌ Failed to idle after recovery timeout: %v", err)
+		}
+	}
+	o.publishRiskAlert(alert)
+}
+
+// reduceRecoveryExposure closes a fraction of the open positions of the active symbol
+func (o *Orchestrator) reduceRecoveryExposure(fraction float64) {
+	positions, err := o.tradingExecutor.GetAllPositions()
+	if err != nil {
+		o.logf(logging.ComponentRisk, logging.ErrorLevel, "❌ Failed to read positions to reduce: %v", err)
+		return
+	}
+
+	for _, position := range positions {
+		if position.Symbol != o.activeSymbol || math.Abs(position.Size) == 0 {
+			continue
+		}
+		reduced := *position
+		reduced.Size = position.Size * fraction
+		if err := o.closeExecutorPosition(o.ctx, &reduced, execution.IntentRecoveryClose, types.NewOrderTag("recovery", "reduce")); err != nil {
+			o.logf(logging.ComponentRisk, logging.ErrorLevel, "❌ Failed to reduce %s %s position: %v", position.Symbol, position.Type, err)
+			continue
+		}
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "📉 Reduced %s %s position by %.0f%%: %.6f of %.6f",
+			position.Symbol, position.Type, fraction*100, math.Abs(reduced.Size), math.Abs(position.Size))
+	}
+}
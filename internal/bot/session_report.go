@@ -0,0 +1,278 @@
+package bot
+
+import (
+	"aibot/internal/journal"
+	"aibot/internal/ledger"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSessionEvents limits the mode transitions, signals and risk alerts kept for the session report
+const maxSessionEvents = 1000
+
+// ModeTransition records a single mode change during the session
+type ModeTransition struct {
+	From      TradingMode `json:"from"`
+	To        TradingMode `json:"to"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// SignalEvent records a strategy signal handled during the session, so the report shows why modes changed
+type SignalEvent struct {
+	Type       string      `json:"type"`
+	Action     string      `json:"action,omitempty"`
+	Price      float64     `json:"price"` // Latest price when the signal was handled, unless the signal carried one
+	Confidence float64     `json:"confidence,omitempty"`
+	Reason     string      `json:"reason,omitempty"`
+	Mode       TradingMode `json:"mode"` // Mode when the signal was handled
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// ModePnL aggregates fill activity attributed to a trading mode
+type ModePnL struct {
+	Fills       int64   `json:"fills"`
+	Volume      float64 `json:"volume"`
+	RealizedPnL float64 `json:"realized_pnl"`
+	Fees        float64 `json:"fees"`
+}
+
+// SessionReport summarizes a bot session from start to shutdown
+type SessionReport struct {
+	Symbol          string                  `json:"symbol"`
+	SessionStart    time.Time               `json:"session_start"`
+	SessionEnd      time.Time               `json:"session_end"`
+	Duration        string                  `json:"duration"`
+	FinalMode       TradingMode             `json:"final_mode"`
+	InitialBalance  float64                 `json:"initial_balance"`
+	TotalTrades     int64                   `json:"total_trades"`
+	WinningTrades   int64                   `json:"winning_trades"`
+	LosingTrades    int64                   `json:"losing_trades"`
+	WinRate         float64                 `json:"win_rate"`
+	RealizedPnL     float64                 `json:"realized_pnl"`
+	TotalFees       float64                 `json:"total_fees"`
+	NetPnL          float64                 `json:"net_pnl"`
+	MaxDrawdown     float64                 `json:"max_drawdown"`
+	PnLByMode       map[TradingMode]ModePnL `json:"pnl_by_mode"`
+	RiskAlerts      []RiskAlert             `json:"risk_alerts"`
+	ModeTransitions []ModeTransition        `json:"mode_transitions"`
+	Signals         []SignalEvent           `json:"signals"`
+	Ledger          *ledger.Summary         `json:"ledger"`
+	CapitalBuckets  []strategy.CapitalBucket `json:"capital_buckets,omitempty"` // Per-strategy performance when capital is allocated
+	Calibration     *strategy.CalibrationReport `json:"calibration"` // Signal confidence versus realized win rate
+	GridLevels      []strategy.GridLevelStats `json:"grid_levels,omitempty"` // Fills, profit and refill time per grid level
+	Benchmark       *journal.BenchmarkReport `json:"benchmark"` // Equity curve against buy-and-hold and DCA of the symbol
+	Attribution     journal.AttributionReport `json:"attribution"` // Fills per component and order tag that created them
+}
+
+// buildSessionReport assembles the session report; caller must hold o.mu
+func (o *Orchestrator) buildSessionReport(end time.Time) *SessionReport {
+	report := &SessionReport{
+		Symbol:          o.activeSymbol,
+		SessionStart:    o.state.SessionStart,
+		SessionEnd:      end,
+		Duration:        end.Sub(o.state.SessionStart).Round(time.Second).String(),
+		FinalMode:       o.state.Mode,
+		InitialBalance:  o.config.InitialBalance,
+		TotalTrades:     o.performance.TotalTrades,
+		WinningTrades:   o.performance.WinningTrades,
+		LosingTrades:    o.performance.LosingTrades,
+		WinRate:         o.performance.WinRate,
+		MaxDrawdown:     o.performance.MaxDrawdown,
+		PnLByMode:       make(map[TradingMode]ModePnL, len(o.modePnL)),
+		RiskAlerts:      append([]RiskAlert(nil), o.riskAlerts...),
+		ModeTransitions: append([]ModeTransition(nil), o.modeHistory...),
+		Signals:         append([]SignalEvent(nil), o.signalHistory...),
+		Ledger:          o.ledger.GetSummary(),
+		Calibration:     o.calibration.Report(strategy.DefaultCalibrationBins),
+		GridLevels:      o.gridEngine.GetLevelStats(),
+		Benchmark:       o.benchmark.Report(),
+		Attribution:     o.tradeJournal.GetAttribution(),
+	}
+	if o.capitalAllocator != nil {
+		report.CapitalBuckets = o.capitalAllocator.GetBuckets()
+	}
+
+	for mode, pnl := range o.modePnL {
+		report.PnLByMode[mode] = pnl
+		report.RealizedPnL += pnl.RealizedPnL
+		report.TotalFees += pnl.Fees
+	}
+	report.NetPnL = report.RealizedPnL - report.TotalFees
+
+	return report
+}
+
+// sessionResult condenses the session report into the performance database record; caller must hold o.mu
+func (o *Orchestrator) sessionResult(report *SessionReport) journal.SessionResult {
+	result := journal.SessionResult{
+		ConfigSummary:  o.config.SessionConfig,
+		Symbol:         report.Symbol,
+		Start:          report.SessionStart,
+		End:            report.SessionEnd,
+		InitialBalance: report.InitialBalance,
+		NetPnL:         report.NetPnL,
+		MaxDrawdown:    report.MaxDrawdown,
+		Sharpe:         o.performance.SharpeRatio,
+		Trades:         report.TotalTrades,
+		WinRate:        report.WinRate,
+	}
+	if report.InitialBalance > 0 {
+		result.Return = report.NetPnL / report.InitialBalance
+	}
+	return result
+}
+
+// WriteSessionReport writes the report as JSON and text files into dir, returning the JSON path
+func WriteSessionReport(report *SessionReport, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create session report directory: %w", err)
+	}
+
+	baseName := "session-" + report.SessionEnd.Format("20060102-150405")
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal session report: %w", err)
+	}
+	jsonPath := filepath.Join(dir, baseName+".json")
+	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write session report: %w", err)
+	}
+
+	textPath := filepath.Join(dir, baseName+".txt")
+	if err := os.WriteFile(textPath, []byte(report.FormatText()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write session summary: %w", err)
+	}
+
+	return jsonPath, nil
+}
+
+// formatRefill returns a level's average time to refill, or "-" if it never refilled
+func formatRefill(level strategy.GridLevelStats) string {
+	if level.Refills == 0 {
+		return "-"
+	}
+	return level.AvgRefill.Round(time.Second).String()
+}
+
+// FormatText renders the report as a human-readable summary
+func (r *SessionReport) FormatText() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Session Report - %s\n", r.Symbol)
+	fmt.Fprintf(&b, "========================================\n")
+	fmt.Fprintf(&b, "Start:           %s\n", r.SessionStart.Format(time.RFC3339))
+	fmt.Fprintf(&b, "End:             %s\n", r.SessionEnd.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Duration:        %s\n", r.Duration)
+	fmt.Fprintf(&b, "Final mode:      %s\n", r.FinalMode)
+	fmt.Fprintf(&b, "\nTrading\n")
+	fmt.Fprintf(&b, "  Trades:        %d (won %d, lost %d, win rate %.1f%%)\n",
+		r.TotalTrades, r.WinningTrades, r.LosingTrades, r.WinRate*100)
+	fmt.Fprintf(&b, "  Realized PnL:  %.2f\n", r.RealizedPnL)
+	fmt.Fprintf(&b, "  Fees:          %.2f\n", r.TotalFees)
+	fmt.Fprintf(&b, "  Net PnL:       %.2f\n", r.NetPnL)
+	fmt.Fprintf(&b, "  Max drawdown:  %.2f%%\n", r.MaxDrawdown*100)
+
+	fmt.Fprintf(&b, "\nPnL by mode\n")
+	if len(r.PnLByMode) == 0 {
+		fmt.Fprintf(&b, "  (no fills)\n")
+	}
+	modes := make([]string, 0, len(r.PnLByMode))
+	for mode := range r.PnLByMode {
+		modes = append(modes, string(mode))
+	}
+	sort.Strings(modes)
+	for _, mode := range modes {
+		pnl := r.PnLByMode[TradingMode(mode)]
+		fmt.Fprintf(&b, "  %-10s fills=%d volume=%.2f pnl=%.2f fees=%.2f\n",
+			mode, pnl.Fills, pnl.Volume, pnl.RealizedPnL, pnl.Fees)
+	}
+
+	if len(r.Attribution.Components) > 0 {
+		fmt.Fprintf(&b, "\nPnL by component\n")
+		for _, component := range r.Attribution.Components {
+			fmt.Fprintf(&b, "  %-22s fills=%d volume=%.2f pnl=%.2f fees=%.2f net=%.2f (won %d, lost %d)\n",
+				component.Tag, component.Fills, component.Volume, component.RealizedPnL, component.Fees,
+				component.NetPnL, component.Wins, component.Losses)
+			for _, tag := range r.Attribution.Tags {
+				if tag.Tag == component.Tag || types.ComponentFromTag(tag.Tag) != component.Tag {
+					continue
+				}
+				fmt.Fprintf(&b, "    %-20s fills=%d volume=%.2f pnl=%.2f fees=%.2f net=%.2f\n",
+					tag.Tag, tag.Fills, tag.Volume, tag.RealizedPnL, tag.Fees, tag.NetPnL)
+			}
+		}
+	}
+
+	if len(r.CapitalBuckets) > 0 {
+		fmt.Fprintf(&b, "\nCapital buckets\n")
+		for _, bucket := range r.CapitalBuckets {
+			fmt.Fprintf(&b, "  %-12s alloc=%.0f%% capital=%.2f fills=%d pnl=%.2f fees=%.2f\n",
+				bucket.Name, bucket.Allocation*100, bucket.Capital(), bucket.Fills, bucket.RealizedPnL, bucket.Fees)
+		}
+	}
+
+	if len(r.GridLevels) > 0 {
+		fmt.Fprintf(&b, "\nGrid levels (offset from center)\n")
+		for _, level := range r.GridLevels {
+			fmt.Fprintf(&b, "  %+3d @ %-12.4f fills=%d (buy %d, sell %d) profit=%.4f return=%.2f%% avg refill=%s\n",
+				level.Offset, level.Price, level.Fills, level.BuyFills, level.SellFills, level.Profit,
+				level.ReturnOnAllocation()*100, formatRefill(level))
+		}
+	}
+
+	if r.Benchmark != nil && r.Benchmark.Samples > 0 {
+		fmt.Fprintf(&b, "\nBenchmarks (%d samples every %s)\n", r.Benchmark.Samples, r.Benchmark.Interval)
+		fmt.Fprintf(&b, "  %-13s return=%.2f%% max drawdown=%.2f%%\n", "strategy", r.Benchmark.Return*100, r.Benchmark.MaxDrawdown*100)
+		for _, benchmark := range r.Benchmark.Benchmarks {
+			fmt.Fprintf(&b, "  %-13s return=%.2f%% max drawdown=%.2f%% excess=%.2f%% alpha=%.2f%% beta=%.2f correlation=%.2f\n",
+				benchmark.Name, benchmark.Return*100, benchmark.MaxDrawdown*100, benchmark.ExcessReturn*100,
+				benchmark.Alpha*100, benchmark.Beta, benchmark.Correlation)
+		}
+	}
+
+	if r.Ledger != nil {
+		fmt.Fprintf(&b, "\nLedger\n")
+		fmt.Fprintf(&b, "  Balance:       %.2f (opening %.2f)\n", r.Ledger.Balance, r.Ledger.OpeningBalance)
+		fmt.Fprintf(&b, "  Realized PnL:  %.4f\n", r.Ledger.Totals.RealizedPnL)
+		fmt.Fprintf(&b, "  Commission:    %.4f\n", r.Ledger.Totals.Commission)
+		fmt.Fprintf(&b, "  Funding:       %.4f\n", r.Ledger.Totals.Funding)
+		fmt.Fprintf(&b, "  Slippage:      %.4f\n", r.Ledger.Totals.Slippage)
+		for _, daily := range r.Ledger.Daily {
+			fmt.Fprintf(&b, "  %s %-10s pnl=%.4f commission=%.4f funding=%.4f slippage=%.4f net=%.4f\n",
+				daily.Day, daily.Symbol, daily.RealizedPnL, daily.Commission, daily.Funding, daily.Slippage, daily.Net)
+		}
+	}
+
+	if r.Calibration != nil {
+		fmt.Fprintf(&b, "\nSignal calibration\n")
+		b.WriteString(r.Calibration.FormatText())
+	}
+
+	fmt.Fprintf(&b, "\nRisk alerts (%d)\n", len(r.RiskAlerts))
+	for _, alert := range r.RiskAlerts {
+		fmt.Fprintf(&b, "  %s [%s] %s: %s\n",
+			alert.Timestamp.Format("15:04:05"), alert.Level, alert.Type, alert.Message)
+	}
+
+	fmt.Fprintf(&b, "\nMode timeline (%d transitions)\n", len(r.ModeTransitions))
+	for _, transition := range r.ModeTransitions {
+		fmt.Fprintf(&b, "  %s %s -> %s\n",
+			transition.Timestamp.Format("15:04:05"), transition.From, transition.To)
+	}
+
+	fmt.Fprintf(&b, "\nSignals (%d)\n", len(r.Signals))
+	for _, signal := range r.Signals {
+		fmt.Fprintf(&b, "  %s [%s] %s @ %.4f confidence=%.2f %s\n",
+			signal.Timestamp.Format("15:04:05"), signal.Mode, signal.Type, signal.Price, signal.Confidence, signal.Reason)
+	}
+
+	return b.String()
+}
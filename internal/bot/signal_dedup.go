@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"aibot/internal/strategy"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SignalDedupConfig holds the cooldowns of repeated trading signals
+type SignalDedupConfig struct {
+	Cooldowns map[string]time.Duration `json:"cooldowns"` // Signal type -> least time between equal signals; types not listed are never suppressed
+}
+
+// defaultSignalCooldowns covers the signals re-evaluated on every tick
+var defaultSignalCooldowns = map[string]time.Duration{
+	"breakout":            30 * time.Second,
+	"false_breakout":      30 * time.Second,
+	"stability":           30 * time.Second,
+	"stability_confirmed": 30 * time.Second,
+	"stability_lost":      30 * time.Second,
+	"recovery_complete":   30 * time.Second,
+}
+
+// signalKey identifies signals that repeat each other
+type signalKey struct {
+	signalType string
+	symbol     string
+	direction  string
+}
+
+// signalBurst tracks the signals of one key
+type signalBurst struct {
+	lastPassed time.Time
+	suppressed int64 // Suppressed since the last signal passed
+}
+
+// SignalBurst reports the largest run of suppressed duplicates of a signal
+type SignalBurst struct {
+	Type      string `json:"type"`
+	Symbol    string `json:"symbol"`
+	Direction string `json:"direction,omitempty"`
+	Size      int64  `json:"size"`
+}
+
+// SignalDeduplicator drops a signal that repeats one of the same type, symbol and direction within the
+// type's cooldown, so conditions that hold for many ticks raise one signal instead of one per tick
+type SignalDeduplicator struct {
+	config SignalDedupConfig
+	bursts map[signalKey]*signalBurst
+
+	// Statistics
+	passed       map[string]int64 // Signal type -> signals let through
+	suppressed   map[string]int64 // Signal type -> signals dropped
+	largestBurst SignalBurst
+
+	mu sync.Mutex
+}
+
+// NewSignalDeduplicator creates a signal deduplicator
+func NewSignalDeduplicator(config SignalDedupConfig) *SignalDeduplicator {
+	if config.Cooldowns == nil {
+		config.Cooldowns = defaultSignalCooldowns // default
+	}
+
+	return &SignalDeduplicator{
+		config:     config,
+		bursts:     make(map[signalKey]*signalBurst),
+		passed:     make(map[string]int64),
+		suppressed: make(map[string]int64),
+	}
+}
+
+// Allow returns true if the signal should be handled, and the number of duplicates suppressed since the
+// last equal signal passed
+func (d *SignalDeduplicator) Allow(signal TradingSignal, now time.Time) (bool, int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cooldown := d.config.Cooldowns[signal.Type]
+	if cooldown <= 0 {
+		d.passed[signal.Type]++
+		return true, 0
+	}
+
+	key := signalKey{signalType: signal.Type, symbol: signal.Symbol, direction: signalDirection(signal)}
+	burst, ok := d.bursts[key]
+	if !ok {
+		burst = &signalBurst{}
+		d.bursts[key] = burst
+	}
+	if ok && now.Sub(burst.lastPassed) < cooldown {
+		burst.suppressed++
+		d.suppressed[signal.Type]++
+		if burst.suppressed > d.largestBurst.Size {
+			d.largestBurst = SignalBurst{Type: key.signalType, Symbol: key.symbol, Direction: key.direction, Size: burst.suppressed}
+		}
+		return false, burst.suppressed
+	}
+
+	suppressed := burst.suppressed
+	burst.lastPassed = now
+	burst.suppressed = 0
+	d.passed[signal.Type]++
+	return true, suppressed
+}
+
+// signalDirection returns the direction a signal points in: the breakout or reversal direction where the
+// signal carries one, otherwise its action
+func signalDirection(signal TradingSignal) string {
+	switch data := signal.Data.(type) {
+	case *strategy.BreakoutSignal:
+		return string(data.Type)
+	case *strategy.FalseBreakoutSignal:
+		return string(data.ReversalType)
+	}
+	return signal.Action
+}
+
+// GetSignalDedupStats returns signal deduplication statistics
+func (d *SignalDeduplicator) GetSignalDedupStats() map[string]interface{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	passed := make(map[string]int64, len(d.passed))
+	var totalPassed int64
+	for signalType, count := range d.passed {
+		passed[signalType] = count
+		totalPassed += count
+	}
+	suppressed := make(map[string]int64, len(d.suppressed))
+	var totalSuppressed int64
+	for signalType, count := range d.suppressed {
+		suppressed[signalType] = count
+		totalSuppressed += count
+	}
+	cooldowns := make(map[string]string, len(d.config.Cooldowns))
+	for signalType, cooldown := range d.config.Cooldowns {
+		cooldowns[signalType] = cooldown.String()
+	}
+	var active []SignalBurst
+	for key, burst := range d.bursts {
+		if burst.suppressed > 0 {
+			active = append(active, SignalBurst{Type: key.signalType, Symbol: key.symbol, Direction: key.direction, Size: burst.suppressed})
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Size > active[j].Size })
+
+	suppressionRate := 0.0
+	if total := totalPassed + totalSuppressed; total > 0 {
+		suppressionRate = float64(totalSuppressed) / float64(total)
+	}
+
+	return map[string]interface{}{
+		"cooldowns":        cooldowns,
+		"passed":           passed,
+		"suppressed":       suppressed,
+		"total_passed":     totalPassed,
+		"total_suppressed": totalSuppressed,
+		"suppression_rate": suppressionRate,
+		"largest_burst":    d.largestBurst,
+		"active_bursts":    active,
+	}
+}
@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/types"
+	"fmt"
+	"log"
+)
+
+// OrchestratorSnapshot is the strategy state saved in backtest checkpoints: session counters and metrics, plus
+// the candle history the indicators are computed from
+type OrchestratorSnapshot struct {
+	Symbol           string                   `json:"symbol"`
+	Mode             TradingMode              `json:"mode"`
+	TradeCount       int                      `json:"trade_count"`
+	SuccessfulTrades int                      `json:"successful_trades"`
+	TotalPnL         float64                  `json:"total_pnl"`
+	MaxDrawdown      float64                  `json:"max_drawdown"`
+	CurrentDrawdown  float64                  `json:"current_drawdown"`
+	Performance      PerformanceMetrics       `json:"performance"`
+	ModePnL          map[TradingMode]ModePnL  `json:"mode_pnl"`
+	RealizedEquity   float64                  `json:"realized_equity"`
+	EquityPeak       float64                  `json:"equity_peak"`
+	TradeReturns     int64                    `json:"trade_returns"`
+	TradeReturnSum   float64                  `json:"trade_return_sum"`
+	TradeReturnSumSq float64                  `json:"trade_return_sum_sq"`
+	Candles          map[string][]types.OHLCV `json:"candles"` // Analyzer history per streamed symbol
+}
+
+// Snapshot captures the orchestrator's strategy state
+func (o *Orchestrator) Snapshot() *OrchestratorSnapshot {
+	o.mu.RLock()
+	snapshot := &OrchestratorSnapshot{
+		Symbol:           o.activeSymbol,
+		Mode:             o.state.Mode,
+		TradeCount:       o.state.TradeCount,
+		SuccessfulTrades: o.state.SuccessfulTrades,
+		TotalPnL:         o.state.TotalPnL,
+		MaxDrawdown:      o.state.MaxDrawdown,
+		CurrentDrawdown:  o.state.CurrentDrawdown,
+		Performance:      o.performance,
+		ModePnL:          make(map[TradingMode]ModePnL, len(o.modePnL)),
+		RealizedEquity:   o.realizedEquity,
+		EquityPeak:       o.equityPeak,
+		TradeReturns:     o.tradeReturns,
+		TradeReturnSum:   o.tradeReturnSum,
+		TradeReturnSumSq: o.tradeReturnSumSq,
+		Candles:          make(map[string][]types.OHLCV),
+	}
+	for mode, pnl := range o.modePnL {
+		snapshot.ModePnL[mode] = pnl
+	}
+	o.mu.RUnlock()
+
+	for _, symbol := range o.subscriptions.Symbols() {
+		snapshot.Candles[symbol] = o.technicalAnalyzer.GetHistoricalData(symbol, 0)
+	}
+	return snapshot
+}
+
+// RestoreSnapshot loads a snapshot into an orchestrator that has not started yet. Counters, metrics and
+// indicator history carry over; on start, positions are adopted from the executor and the grid is laid
+// again from the warm indicators, since resting grid orders are not part of the snapshot
+func (o *Orchestrator) RestoreSnapshot(snapshot *OrchestratorSnapshot) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.state.IsActive {
+		return fmt.Errorf("cannot restore a snapshot into a running orchestrator")
+	}
+	if snapshot.Symbol != o.activeSymbol {
+		return fmt.Errorf("snapshot is for %s, not %s", snapshot.Symbol, o.activeSymbol)
+	}
+
+	o.state.TradeCount = snapshot.TradeCount
+	o.state.SuccessfulTrades = snapshot.SuccessfulTrades
+	o.state.TotalPnL = snapshot.TotalPnL
+	o.state.MaxDrawdown = snapshot.MaxDrawdown
+	o.state.CurrentDrawdown = snapshot.CurrentDrawdown
+	o.performance = snapshot.Performance
+	o.modePnL = make(map[TradingMode]ModePnL, len(snapshot.ModePnL))
+	for mode, pnl := range snapshot.ModePnL {
+		o.modePnL[mode] = pnl
+	}
+	o.realizedEquity = snapshot.RealizedEquity
+	o.equityPeak = snapshot.EquityPeak
+	o.tradeReturns = snapshot.TradeReturns
+	o.tradeReturnSum = snapshot.TradeReturnSum
+	o.tradeReturnSumSq = snapshot.TradeReturnSumSq
+
+	for _, candles := range snapshot.Candles {
+		for _, candle := range candles {
+			o.candleAggregator.AddCandle(candle)
+		}
+		o.technicalAnalyzer.AddCandles(candles)
+	}
+	o.restored = true
+
+	log.Printf("♻️ Restored snapshot: %d trades, PnL %.2f, last mode %s", snapshot.TradeCount, snapshot.TotalPnL, snapshot.Mode)
+	return nil
+}
+
+// adoptRestoredAccount takes over the account of a restored executor: its resting orders belonged to a grid
+// this orchestrator does not know, and its positions are tracked from here on
+func (o *Orchestrator) adoptRestoredAccount() {
+	orders, err := o.tradingExecutor.GetOpenOrders(o.activeSymbol)
+	if err != nil {
+		log.Printf("⚠️ Failed to read restored orders: %v", err)
+	}
+	for _, order := range orders {
+		if err := o.tradingExecutor.CancelOrder(order.ID); err != nil {
+			o.logf(logging.ComponentExecutor, logging.WarnLevel, "⚠️ Failed to cancel restored order %s: %v", order.ID, err)
+		}
+	}
+
+	price := o.candleAggregator.GetLatestPrice(o.activeSymbol)
+	if ticker, err := o.tradingExecutor.GetTicker(o.activeSymbol); err == nil && ticker.Price > 0 {
+		price = ticker.Price
+	}
+	if _, err := o.resyncPositions(o.activeSymbol, price); err != nil {
+		log.Printf("⚠️ Failed to adopt restored positions: %v", err)
+		return
+	}
+	log.Printf("♻️ Adopted restored account: %d resting orders cancelled", len(orders))
+}
@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpotPriceFeedConfig holds configuration for polling spot prices the basis of perpetuals is measured against
+type SpotPriceFeedConfig struct {
+	URL          string            `json:"url"`           // Binance-compatible spot REST API ("" disables basis monitoring)
+	Symbols      map[string]string `json:"symbols"`       // Perpetual -> spot symbol where they differ (e.g. 1000PEPEUSDT -> PEPEUSDT)
+	PollInterval time.Duration     `json:"poll_interval"` // Spot price refresh interval (5s)
+	Timeout      time.Duration     `json:"timeout"`       // Request timeout (10s)
+}
+
+// SpotPriceFeed polls the last price of the spot market matching a perpetual symbol
+type SpotPriceFeed struct {
+	config SpotPriceFeedConfig
+	client *http.Client
+
+	// Statistics
+	polls      int64
+	pollErrors int64
+	lastError  string
+
+	mu sync.Mutex
+}
+
+// NewSpotPriceFeed creates a spot price feed
+func NewSpotPriceFeed(config SpotPriceFeedConfig) *SpotPriceFeed {
+	if config.PollInterval == 0 {
+		config.PollInterval = 5 * time.Second // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &SpotPriceFeed{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// SpotSymbol returns the spot symbol a perpetual is compared with
+func (f *SpotPriceFeed) SpotSymbol(symbol string) string {
+	if spot, ok := f.config.Symbols[symbol]; ok && spot != "" {
+		return spot
+	}
+	return symbol
+}
+
+// Fetch reads the last spot price of the market matching a perpetual symbol
+func (f *SpotPriceFeed) Fetch(ctx context.Context, symbol string) (float64, error) {
+	price, err := f.fetch(ctx, f.SpotSymbol(symbol))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.polls++
+	if err != nil {
+		f.pollErrors++
+		f.lastError = err.Error()
+		return 0, err
+	}
+	f.lastError = ""
+	return price, nil
+}
+
+// fetch requests the spot ticker price of symbol
+func (f *SpotPriceFeed) fetch(ctx context.Context, symbol string) (float64, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.config.URL+"/api/v3/ticker/price?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create spot price request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch spot price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("spot ticker returned status %d", resp.StatusCode)
+	}
+
+	var ticker struct {
+		Symbol string `json:"symbol"`
+		Price  string `json:"price"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ticker); err != nil {
+		return 0, fmt.Errorf("failed to decode spot ticker: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(ticker.Price, 64)
+	if err != nil || price <= 0 {
+		return 0, fmt.Errorf("invalid spot price %q", ticker.Price)
+	}
+	return price, nil
+}
+
+// GetSpotPriceFeedStats returns spot price feed statistics
+func (f *SpotPriceFeed) GetSpotPriceFeedStats() map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return map[string]interface{}{
+		"url":         f.config.URL,
+		"polls":       f.polls,
+		"poll_errors": f.pollErrors,
+		"last_error":  f.lastError,
+	}
+}
@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"fmt"
+	"log"
+)
+
+// checkVolatility feeds a tick of the active symbol to the volatility circuit breaker and raises a signal
+// when the breaker trips, lets orders resume or clears
+func (o *Orchestrator) checkVolatility(ticker *types.Ticker) {
+	state, changed := o.volatilityBreaker.Update(ticker.Symbol, ticker.Price, ticker.Timestamp)
+	if !changed {
+		return
+	}
+
+	signal := TradingSignal{
+		Symbol:    ticker.Symbol,
+		Price:     ticker.Price,
+		Reason:    state.Reason,
+		Data:      state,
+		Timestamp: state.ChangedAt,
+	}
+	switch state.Level {
+	case strategy.BreakerTripped:
+		signal.Type, signal.Action = "volatility_halt", "pause_entries"
+		o.publishRiskAlert(RiskAlert{
+			Level:     "warning",
+			Type:      "volatility_breaker",
+			Message:   fmt.Sprintf("Volatility circuit breaker tripped for %s: %s", ticker.Symbol, state.Reason),
+			Symbol:    ticker.Symbol,
+			Value:     state.Ratio,
+			Threshold: o.config.VolatilityBreakerConfig.Multiple,
+			Timestamp: state.ChangedAt,
+		})
+	case strategy.BreakerRecovering:
+		signal.Type, signal.Action = "volatility_recovering", "resume_entries"
+	default:
+		signal.Type, signal.Action = "volatility_clear", "restore_grid"
+	}
+	o.publishSignal(signal)
+}
+
+// handleVolatilitySignal re-lays the grid with widened spacing when the breaker trips, without placing it,
+// places the widened grid once the spike has calmed down and restores the normal spacing when it clears
+func (o *Orchestrator) handleVolatilitySignal(signal TradingSignal) {
+	state := signal.Data.(strategy.BreakerState)
+
+	switch signal.Type {
+	case "volatility_halt":
+		o.logf(logging.ComponentRisk, logging.WarnLevel, "🌪️ Volatility circuit breaker tripped for %s, suspending new orders: %s", state.Symbol, state.Reason)
+	case "volatility_recovering":
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "🌪️ Volatility for %s calmed down, resuming orders on the widened grid", state.Symbol)
+		o.mu.RLock()
+		mode := o.state.Mode
+		o.mu.RUnlock()
+		if mode == ModeGrid {
+			o.placeGridOrders(signal.Context)
+		}
+		return
+	default:
+		o.logf(logging.ComponentRisk, logging.InfoLevel, "🌪️ Volatility circuit breaker cleared for %s, restoring grid spacing", state.Symbol)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.state.Mode == ModeGrid {
+		if err := o.setupGridMode(); err != nil {
+			log.Printf("⚠️ Failed to rebuild grid after volatility breaker change: %v", err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package bot
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Webhook event types
+const (
+	WebhookEventModeTransition = "mode_transition"
+	WebhookEventCriticalRisk   = "critical_risk"
+	WebhookEventAlertRule      = "alert_rule"
+)
+
+// Webhook request headers; the signature is hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret
+const (
+	WebhookHeaderEvent     = "X-Aibot-Event"
+	WebhookHeaderTimestamp = "X-Aibot-Timestamp"
+	WebhookHeaderSignature = "X-Aibot-Signature"
+)
+
+// WebhookConfig holds configuration for outgoing webhooks
+type WebhookConfig struct {
+	URL        string        `json:"url"`         // Endpoint receiving events ("" disables webhooks)
+	Secret     string        `json:"secret"`      // HMAC signing secret ("" sends unsigned payloads)
+	Events     []string      `json:"events"`      // Event types to send (all if empty)
+	Timeout    time.Duration `json:"timeout"`     // Per-request timeout (5s)
+	MaxRetries int           `json:"max_retries"` // Retries after a failed delivery (3)
+	RetryDelay time.Duration `json:"retry_delay"` // Delay before the first retry, doubled each attempt (1s)
+	QueueSize  int           `json:"queue_size"`  // Events buffered for delivery (100)
+}
+
+// WebhookEvent is the JSON payload posted to the webhook URL
+type WebhookEvent struct {
+	ID        int64       `json:"id"`
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Symbol    string      `json:"symbol,omitempty"`
+	Mode      TradingMode `json:"mode"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDispatcher delivers events asynchronously so callers never block on the network
+type WebhookDispatcher struct {
+	config WebhookConfig
+	client *http.Client
+	events map[string]bool
+	queue  chan WebhookEvent
+	done   chan struct{}
+
+	// Statistics
+	nextID    int64
+	delivered int64
+	failed    int64
+	dropped   int64
+
+	closed bool
+	mu     sync.Mutex
+}
+
+// NewWebhookDispatcher creates a dispatcher and starts its delivery worker
+func NewWebhookDispatcher(config WebhookConfig) *WebhookDispatcher {
+	if config.Timeout == 0 {
+		config.Timeout = 5 * time.Second // default
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3 // default
+	}
+	if config.RetryDelay == 0 {
+		config.RetryDelay = time.Second // default
+	}
+	if config.QueueSize == 0 {
+		config.QueueSize = 100 // default
+	}
+
+	events := make(map[string]bool)
+	for _, event := range config.Events {
+		events[event] = true
+	}
+
+	wd := &WebhookDispatcher{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		events: events,
+		queue:  make(chan WebhookEvent, config.QueueSize),
+		done:   make(chan struct{}),
+	}
+	go wd.run()
+	return wd
+}
+
+// Send queues an event for delivery; it never blocks and drops the event if the queue is full
+func (wd *WebhookDispatcher) Send(eventType string, symbol string, mode TradingMode, data interface{}) {
+	if len(wd.events) > 0 && !wd.events[eventType] {
+		return
+	}
+
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	if wd.closed {
+		return
+	}
+	wd.nextID++
+	event := WebhookEvent{
+		ID:        wd.nextID,
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Symbol:    symbol,
+		Mode:      mode,
+		Data:      data,
+	}
+
+	select {
+	case wd.queue <- event:
+	default:
+		wd.dropped++
+		log.Printf("⚠️ Webhook queue full, dropped %s event", eventType)
+	}
+}
+
+// Close stops accepting events and waits up to timeout for queued events to be delivered
+func (wd *WebhookDispatcher) Close(timeout time.Duration) {
+	wd.mu.Lock()
+	if !wd.closed {
+		wd.closed = true
+		close(wd.queue)
+	}
+	wd.mu.Unlock()
+
+	select {
+	case <-wd.done:
+	case <-time.After(timeout):
+		log.Printf("⚠️ Webhook delivery timeout reached, %d events undelivered", len(wd.queue))
+	}
+}
+
+// run delivers queued events until the queue is closed
+func (wd *WebhookDispatcher) run() {
+	defer close(wd.done)
+
+	for event := range wd.queue {
+		err := wd.deliver(event)
+
+		wd.mu.Lock()
+		if err != nil {
+			wd.failed++
+		} else {
+			wd.delivered++
+		}
+		wd.mu.Unlock()
+
+		if err != nil {
+			log.Printf("❌ Webhook %s event %d failed: %v", event.Type, event.ID, err)
+		}
+	}
+}
+
+// deliver posts an event, retrying with exponential backoff
+func (wd *WebhookDispatcher) deliver(event WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	delay := wd.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err = wd.post(event.Type, body)
+		if err == nil || attempt >= wd.config.MaxRetries {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post sends one signed request
+func (wd *WebhookDispatcher) post(eventType string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, wd.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeaderEvent, eventType)
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	if wd.config.Secret != "" {
+		req.Header.Set(WebhookHeaderSignature, "sha256="+SignWebhookPayload(wd.config.Secret, timestamp, body))
+	}
+
+	resp, err := wd.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 signature receivers use to verify a payload
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetWebhookStats returns webhook delivery statistics
+func (wd *WebhookDispatcher) GetWebhookStats() map[string]interface{} {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	return map[string]interface{}{
+		"url":       wd.config.URL,
+		"signed":    wd.config.Secret != "",
+		"sent":      wd.nextID,
+		"delivered": wd.delivered,
+		"failed":    wd.failed,
+		"dropped":   wd.dropped,
+		"queued":    len(wd.queue),
+	}
+}
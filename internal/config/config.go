@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,6 +23,22 @@ type Config struct {
 	Database DatabaseConfig `json:"database"`
 	Logging  LoggingConfig  `json:"logging"`
 	Backtest BacktestConfig `json:"backtest"`
+	Webhook  WebhookConfig  `json:"webhook"`
+	Grafana  GrafanaConfig  `json:"grafana"`
+	Control  ControlConfig  `json:"control"`
+	Tracing  TracingConfig  `json:"tracing"`
+	News     NewsConfig     `json:"news"`
+	ExchangeStatus ExchangeStatusConfig `json:"exchange_status"`
+	Funding  FundingConfig  `json:"funding"`
+	Basis    BasisConfig    `json:"basis"`
+	ProfitSweep ProfitSweepConfig `json:"profit_sweep"`
+	Email    EmailConfig    `json:"email"`
+	DailyReport DailyReportConfig `json:"daily_report"`
+	Alerts   AlertsConfig   `json:"alerts"`
+	Health   HealthConfig   `json:"health"`
+	Screener ScreenerConfig `json:"screener"`
+	Reconcile ReconcileConfig `json:"reconcile"`
+	Experiment ExperimentConfig `json:"experiment"`
 }
 
 // AppConfig contains basic application configuration
@@ -39,6 +58,12 @@ type TradingConfig struct {
 	InitialBalance    float64 `json:"initial_balance"`
 	MaxDailyLoss      float64 `json:"max_daily_loss"`
 	MaxConsecutiveLosses int    `json:"max_consecutive_losses"`
+	PnLCheckpointInterval time.Duration `json:"pnl_checkpoint_interval"` // Daily PnL is checkpointed this often so the loss limit survives restarts
+	AccountSnapshotInterval time.Duration `json:"account_snapshot_interval"` // Account snapshots are journaled this often (5m)
+	AccountingTimezone string `json:"accounting_timezone"` // IANA timezone the trading day rolls over in for the loss limit, daily reports and ledger ("" = UTC)
+	AccountingCurrency string `json:"accounting_currency"` // Currency PnL and portfolio risk are aggregated in across quote assets (USDT)
+	QuoteRates        map[string]float64 `json:"quote_rates"` // Asset -> accounting currency per unit, used when no streamed market prices the asset
+	RateSymbols       []string `json:"rate_symbols"`   // Streamed for live conversion rates, e.g. BTCUSDT when trading BTC-quoted pairs
 
 	// Position settings
 	DefaultLeverage   float64 `json:"default_leverage"`
@@ -50,21 +75,101 @@ type TradingConfig struct {
 	MakerFee          float64 `json:"maker_fee"`
 	TakerFee          float64 `json:"taker_fee"`
 	Slippage          float64 `json:"slippage"`
+	FeeSchedule       FeeScheduleConfig `json:"fee_schedule"` // VIP tiers for simulation and backtests (flat fees if no tiers)
+
+	// Exchange credentials (overridden by TRADING_BOT_API_KEY / TRADING_BOT_API_SECRET)
+	APIKey            string `json:"api_key"`
+	APISecret         string `json:"api_secret"`
+	AccountID         string `json:"account_id"` // Names the account whose equity high-water mark is persisted ("" derives it from the API key)
 
 	// Execution settings
-	ExecutionType     string `json:"execution_type"` // "live"
+	ExecutionType     string `json:"execution_type"` // "live", "simulation"
+	MarketType        string `json:"market_type"`    // "futures", "spot"
+	QuoteAsset        string `json:"quote_asset"`    // Quote asset for spot balances (USDT)
+	EnableHedging     bool   `json:"enable_hedging"` // Hold long and short positions per symbol at the same time
+	Contracts         map[string]ContractConfig `json:"contracts"` // Symbol -> futures contract; linear if not listed
 	OrderTimeout      time.Duration `json:"order_timeout"`
 	RetryAttempts     int    `json:"retry_attempts"`
 	RetryDelay        time.Duration `json:"retry_delay"`
+	IntentMaxAge      time.Duration `json:"intent_max_age"` // Orders left pending by a crash are resubmitted on restart only within this age
+	Execution         ExecutionAlgoConfig `json:"execution"`   // Execution algorithm per order intent
 
 	// Market settings
 	SupportedSymbols   []string `json:"supported_symbols"`
 	DefaultSymbol      string   `json:"default_symbol"`
 	MaxSymbols         int      `json:"max_symbols"`
+
+	// Fault injection for the simulation executor
+	Chaos              ChaosConfig `json:"chaos"`
+
+	// Margin model of the simulation executor
+	Margin             SimulationMarginConfig `json:"margin"`
+}
+
+// ExecutionAlgoConfig selects the execution algorithm of each order intent
+type ExecutionAlgoConfig struct {
+	Algos      map[string]string `json:"algos"`       // Intent ("recovery_close", "emergency_close", "breakout_entry", "breakout_exit", "aged_unwind") -> algo
+	LargeOrder LargeOrderConfig  `json:"large_order"` // Splitting of orders too large for the book
+	LimitChase LimitChaseConfig  `json:"limit_chase"` // Post-only order at the best price, repriced, market after a timeout
+	TWAP       TWAPConfig        `json:"twap"`        // Equal market slices over a window
+	Iceberg    IcebergConfig     `json:"iceberg"`     // Small chased child orders over a window
+}
+
+// LargeOrderConfig contains the notional above which orders are split
+type LargeOrderConfig struct {
+	MinNotional float64  `json:"min_notional"` // Orders at or above this notional are split (0 = never)
+	Algo        string   `json:"algo"`         // "twap" or "iceberg"
+	Intents     []string `json:"intents"`      // Intents whose large orders are split
+}
+
+// TWAPConfig contains TWAP execution settings
+type TWAPConfig struct {
+	Duration time.Duration `json:"duration"` // 30s
+	Slices   int           `json:"slices"`   // 6
+}
+
+// IcebergConfig contains iceberg execution settings
+type IcebergConfig struct {
+	DisplayFraction float64       `json:"display_fraction"` // 10% of the order visible at a time
+	Duration        time.Duration `json:"duration"`         // 2m
+}
+
+// LimitChaseConfig contains limit chase execution settings
+type LimitChaseConfig struct {
+	RepriceInterval  time.Duration `json:"reprice_interval"`  // 1s
+	RepriceThreshold float64       `json:"reprice_threshold"` // Best price move that triggers a reprice (0 = any move)
+	MaxReprices      int           `json:"max_reprices"`      // 20, then market
+	Timeout          time.Duration `json:"timeout"`           // 30s, then market
+}
+
+// ChaosConfig contains simulation fault injection settings
+type ChaosConfig struct {
+	Enabled            bool          `json:"enabled"`
+	Seed               int64         `json:"seed"`                // 0 picks a random seed
+	Latency            time.Duration `json:"latency"`             // Added to every request
+	LatencyJitter      time.Duration `json:"latency_jitter"`      // Random extra latency
+	DisconnectRate     float64       `json:"disconnect_rate"`     // Probability per request
+	DisconnectDuration time.Duration `json:"disconnect_duration"` // 5s
+	BusyRate           float64       `json:"busy_rate"`           // Probability per request
+	FillDelay          time.Duration `json:"fill_delay"`          // Delay before order updates are published
+	ReorderRate        float64       `json:"reorder_rate"`        // Probability an update arrives out of order
+	RateLimitRate      float64       `json:"rate_limit_rate"`     // Probability per request of a 429 with Retry-After
+	RateLimitDuration  time.Duration `json:"rate_limit_duration"` // 1m
+}
+
+// SimulationMarginConfig contains the maintenance margin, margin call and liquidation settings of
+// simulated futures accounts
+type SimulationMarginConfig struct {
+	MaintenanceMarginRate float64 `json:"maintenance_margin_rate"` // Share of position notional kept as maintenance margin (0.4%)
+	MarginCallRatio       float64 `json:"margin_call_ratio"`       // Maintenance margin / equity at which a margin call is raised (0.8); liquidation at 1
 }
 
 // StrategyConfig contains strategy-specific configuration
 type StrategyConfig struct {
+	// Parameter profile
+	Profile    string `json:"profile"`     // Named grid setup, breakout and risk parameter set, e.g. "balanced"
+	ProfileDir string `json:"profile_dir"` // Directory of custom profiles, searched before the built-in ones
+
 	// Grid strategy
 	Grid GridConfig `json:"grid"`
 
@@ -77,8 +182,27 @@ type StrategyConfig struct {
 	// Price stability detection
 	Stability StabilityConfig `json:"stability"`
 
+	// Equity curve trading filter
+	EquityCurve EquityCurveConfig `json:"equity_curve"`
+
+	// Fee budget governor
+	FeeBudget FeeBudgetConfig `json:"fee_budget"`
+
+	// Per-mode time limits
+	ModeWatchdog ModeWatchdogConfig `json:"mode_watchdog"`
+
+	// Escalation of a recovery that does not complete
+	RecoveryEscalation RecoveryEscalationConfig `json:"recovery_escalation"`
+
+	// Suppression of repeated signals
+	SignalDedup SignalDedupConfig `json:"signal_dedup"`
+
 	// Technical analysis
 	Technical TechnicalConfig `json:"technical"`
+
+	// Multi-strategy execution
+	ConcurrentStrategies bool               `json:"concurrent_strategies"` // Trend-follow breakouts alongside the grid
+	CapitalAllocation    map[string]float64 `json:"capital_allocation"`    // "grid", "breakout" -> fraction of capital
 }
 
 // GridConfig contains grid trading configuration
@@ -88,6 +212,7 @@ type GridConfig struct {
 	MaxGridSpacing    float64 `json:"max_grid_spacing"`    // 1.0%
 	MinGridLevels     int     `json:"min_grid_levels"`     // 10
 	MaxGridLevels     int     `json:"max_grid_levels"`     // 30
+	SpacingMode       string  `json:"spacing_mode"`        // "arithmetic" (equal price steps) or "geometric" (equal percent steps)
 
 	// Profitability
 	MinProfitPerLevel float64 `json:"min_profit_per_level"` // 0.15%
@@ -101,6 +226,13 @@ type GridConfig struct {
 	// Grid bounds
 	PriceBuffer       float64 `json:"price_buffer"`        // 5% buffer around current price
 	RangeExpansion    float64 `json:"range_expansion"`     // 2x ATR for range expansion
+
+	// Inventory skew control
+	MaxInventory         float64            `json:"max_inventory"`           // Max net base inventory per symbol (0 = unlimited)
+	MaxInventoryBySymbol map[string]float64 `json:"max_inventory_by_symbol"` // Per-symbol overrides
+	SkewSpacingFactor    float64            `json:"skew_spacing_factor"`     // 1.0 extra spacing on the heavy side at max inventory
+	UnloadThreshold      float64            `json:"unload_threshold"`        // 80% of max inventory triggers an unload at mid
+	UnloadFraction       float64            `json:"unload_fraction"`         // 50% of inventory unloaded
 }
 
 // BreakoutConfig contains breakout detection configuration
@@ -121,9 +253,26 @@ type BreakoutConfig struct {
 	// ATR
 	ATRMultiple         float64 `json:"atr_multiple"`          // 1.5x ATR
 
+	// Named indicators overriding the analyzer defaults ("" keeps the default)
+	RSIIndicator        string  `json:"rsi_indicator"`         // e.g. "rsi_7"
+	ATRIndicator        string  `json:"atr_indicator"`         // e.g. "atr_7"
+	VolumeIndicator     string  `json:"volume_indicator"`      // e.g. "volume_sma_10"
+
+	// Closed-candle confirmation ("" keeps the profile's mode)
+	ConfirmationMode      string `json:"confirmation_mode"`      // "tick" or "closed_candle"
+	ConfirmationTimeframe string `json:"confirmation_timeframe"` // "3s" or "15s"
+	ConfirmationCloses    int    `json:"confirmation_closes"`    // Consecutive closes beyond a bound (2)
+
+	// Strength normalization ("" keeps the profile's mode, 0 the profile's minimum)
+	StrengthMode          string  `json:"strength_mode"`          // "range" (fraction of grid range), "percent" or "atr"
+	MinStrengthPercent    float64 `json:"min_strength_percent"`   // Percent mode minimum, fraction of the bound (0.003)
+	MinStrengthATR        float64 `json:"min_strength_atr"`       // ATR mode minimum, ATR units (0.5)
+
 	// Performance tracking
 	MaxFalseBreakouts   int     `json:"max_false_breakouts"`   // Consecutive false breakout limit
 	ConfidenceThreshold float64 `json:"confidence_threshold"`  // 0.6 minimum confidence
+	SuccessPrior        float64 `json:"success_prior"`         // Outcomes at 50% the learned success rate of a symbol starts from (10)
+	SuccessWeight       float64 `json:"success_weight"`        // Confidence shift per unit of success rate away from 50% (1)
 }
 
 // FalseBreakoutConfig contains false breakout detection configuration
@@ -145,6 +294,23 @@ type FalseBreakoutConfig struct {
 
 	// Pattern detection
 	MaxFakeoutFrequency    float64 `json:"max_fakeout_frequency"`     // Per hour
+
+	// Data requirements
+	RequireVolumeData      bool    `json:"require_volume_data"`       // Skip signals without volume data
+	VolumeLookbackCandles  int     `json:"volume_lookback_candles"`   // 1s candles averaged for volume
+
+	// External scoring model blended with the rule-based confidence
+	ScorerType             string        `json:"scorer_type"`          // "" (disabled), "http", "onnx"
+	ScorerEndpoint         string        `json:"scorer_endpoint"`      // HTTP endpoint receiving features as JSON
+	ScorerModelPath        string        `json:"scorer_model_path"`    // ONNX model file
+	ScorerTimeout          time.Duration `json:"scorer_timeout"`       // 200ms
+	ScorerBlendWeight      float64       `json:"scorer_blend_weight"`  // 0.5
+
+	// Early warning when OBV or RSI diverges from the breakout's new extreme
+	DivergenceWarning      bool    `json:"divergence_warning"`
+	DivergenceLookback     int     `json:"divergence_lookback"`      // Candles searched for the earlier swing (30)
+	DivergenceSwingCandles int     `json:"divergence_swing_candles"` // Most recent candles searched for the latest swing (5)
+	DivergenceMinGap       float64 `json:"divergence_min_gap"`       // Oscillator gap between the swings as a fraction of its range (0.1)
 }
 
 // StabilityConfig contains price stability detection configuration
@@ -160,17 +326,83 @@ type StabilityConfig struct {
 	// Timeframes
 	PrimaryTimeframe     string  `json:"primary_timeframe"`     // "3s"
 	SecondaryTimeframe   string  `json:"secondary_timeframe"`   // "15s"
+	VolatilityIndicator  string  `json:"volatility_indicator"`  // Named ATR, e.g. "atr_30" ("" uses candle changes)
 
 	// Risk levels
 	LowRiskVolatility    float64 `json:"low_risk_volatility"`    // < 0.3%
 	MediumRiskVolatility float64 `json:"medium_risk_volatility"` // 0.3-0.7%
 	HighRiskVolatility   float64 `json:"high_risk_volatility"`   // > 0.7%
+
+	// Adaptive thresholds
+	Adaptive             AdaptiveStabilityConfig `json:"adaptive"`
+}
+
+// AdaptiveStabilityConfig calibrates the stability thresholds from rolling percentiles of recent market data
+type AdaptiveStabilityConfig struct {
+	Enabled              bool          `json:"enabled"`
+	Lookback             time.Duration `json:"lookback"`              // History the percentiles are taken over (24h)
+	SampleInterval       time.Duration `json:"sample_interval"`       // Time between samples (1m)
+	MinSamples           int           `json:"min_samples"`           // Samples before the static thresholds are replaced (60)
+	VolatilityPercentile float64       `json:"volatility_percentile"` // Stable below this percentile of volatility (0.3)
+	MomentumPercentile   float64       `json:"momentum_percentile"`   // Stable below this percentile of momentum (0.3)
+	ConformityPercentile float64       `json:"conformity_percentile"` // Conforming below this percentile of price dispersion (0.3)
+}
+
+// EquityCurveConfig contains equity curve filter configuration
+type EquityCurveConfig struct {
+	Enabled       bool    `json:"enabled"`        // Pause entries while equity is below its moving average
+	MAPeriod      int     `json:"ma_period"`      // Equity samples in the moving average (20)
+	DisableBuffer float64 `json:"disable_buffer"` // Fraction below the average that pauses trading (0.5%)
+}
+
+// ModeWatchdogConfig contains the time limits of each trading mode
+type ModeWatchdogConfig struct {
+	CheckInterval time.Duration                `json:"check_interval"` // 5s
+	Modes         map[string]ModeTimeoutConfig `json:"modes"`          // "breakout", "stability", "recovery", ... -> limits
+}
+
+// ModeTimeoutConfig contains the time limits of one mode
+type ModeTimeoutConfig struct {
+	MaxDuration time.Duration `json:"max_duration"` // Longest stay in the mode (0 = unlimited)
+	Inactivity  time.Duration `json:"inactivity"`   // Longest time without fills or mode changes (0 = unlimited)
+	Fallback    string        `json:"fallback"`     // Mode switched to on timeout ("" only raises a signal)
+}
+
+// RecoveryEscalationConfig contains the steps run while recovery mode goes on without finding stability
+type RecoveryEscalationConfig struct {
+	Steps []RecoveryStepConfig `json:"steps"` // Run in order of after; empty disables escalation
+}
+
+// RecoveryStepConfig contains one recovery escalation step
+type RecoveryStepConfig struct {
+	After    time.Duration `json:"after"`              // Time in recovery mode before the step runs
+	Action   string        `json:"action"`             // "reduce", "flatten" or "idle" (idle also raises a critical alert)
+	Fraction float64       `json:"fraction,omitempty"` // Reduce: share of the open position closed (0.5)
+}
+
+// SignalDedupConfig contains the cooldowns of repeated signals of the same type, symbol and direction
+type SignalDedupConfig struct {
+	Cooldowns map[string]time.Duration `json:"cooldowns"` // Signal type -> cooldown; types not listed are never suppressed
+}
+
+// FeeBudgetConfig contains fee budget governor configuration
+type FeeBudgetConfig struct {
+	Enabled      bool          `json:"enabled"`       // Throttle grid turnover while fees run ahead of the budget
+	HourlyBudget float64       `json:"hourly_budget"` // Max fees per hour in the quote asset (0 = no limit)
+	DailyBudget  float64       `json:"daily_budget"`  // Max fees per 24h in the quote asset (0 = no limit)
+	MaxFeeRatio  float64       `json:"max_fee_ratio"` // Max fees as a fraction of 24h gross profit (50%)
+	MinFills     int           `json:"min_fills"`     // Fills before the fee ratio is judged (10)
+	Cooldown     time.Duration `json:"cooldown"`      // 15m between throttle changes
+	MaxThrottle  int           `json:"max_throttle"`  // Highest throttle level (3)
+	SpacingStep  float64       `json:"spacing_step"`  // Extra grid spacing per level (50%)
+	LevelStep    float64       `json:"level_step"`    // Fraction of grid levels removed per level (20%)
 }
 
 // TechnicalConfig contains technical analysis configuration
 type TechnicalConfig struct {
 	// Indicators
 	IndicatorSettings map[string]interface{} `json:"indicator_settings"`
+	NamedIndicators   []string               `json:"named_indicators"` // Extra instances, e.g. "rsi_7", "ema_50"
 
 	// Timeframes
 	AnalysisTimeframes []string `json:"analysis_timeframes"`
@@ -206,6 +438,93 @@ type RiskConfig struct {
 	// Emergency conditions
 	MarginCallThreshold   float64 `json:"margin_call_threshold"`   // 90% margin usage
 	EmergencyStopLoss     float64 `json:"emergency_stop_loss"`     // 15% portfolio loss
+
+	// Drawdown de-risking policy, as fractions of max drawdown
+	DeRiskReduceAt        float64       `json:"derisk_reduce_at"`        // Halve position sizes at 50%
+	DeRiskReduceFactor    float64       `json:"derisk_reduce_factor"`    // 0.5
+	DeRiskHaltAt          float64       `json:"derisk_halt_at"`          // Stop new entries at 80%
+	DeRiskFlattenAt       float64       `json:"derisk_flatten_at"`       // Flatten and idle at 100%
+	DeRiskCooldown        time.Duration `json:"derisk_cooldown"`         // Idle period after flattening
+
+	// Declarative order constraints (banned symbols, leverage, notional, trading windows)
+	PolicyFile            string        `json:"policy_file"`             // YAML (.yaml, .yml) or JSON policy file ("" disables it)
+
+	// Liquidation estimates
+	MaintenanceMarginRate float64       `json:"maintenance_margin_rate"` // 0.4% of notional
+	LiquidationBuffer     float64       `json:"liquidation_buffer"`      // Alert within 5% of the liquidation price
+	MarginMode            string        `json:"margin_mode"`             // "cross", "isolated"
+
+	// Volatility-driven leverage
+	LeverageControl LeverageControlConfig `json:"leverage_control"`
+
+	// Order book checks before placing orders
+	Liquidity LiquidityConfig `json:"liquidity"`
+
+	// Order suspension during 1s volatility spikes
+	VolatilityBreaker VolatilityBreakerConfig `json:"volatility_breaker"`
+
+	// Caps on simultaneously open orders
+	OrderBudget OrderBudgetConfig `json:"order_budget"`
+
+	// Maximum position age and how older positions are unwound
+	AgedPositions AgedPositionConfig `json:"aged_positions"`
+
+	// Stop losses and take profits resting on the exchange
+	ExchangeExits ExchangeExitConfig `json:"exchange_exits"`
+}
+
+// ExchangeExitConfig contains the exchange orders that execute the stop loss and take profit of breakout positions
+type ExchangeExitConfig struct {
+	Enabled      bool    `json:"enabled"`       // Place them where the executor executes stops natively (true)
+	TrailingStop float64 `json:"trailing_stop"` // Stop losses trail the best price by this fraction (0 rests them at the stop loss)
+}
+
+// AgedPositionConfig contains the maximum position age and the unwind policy of positions held longer
+type AgedPositionConfig struct {
+	TimeoutHours int           `json:"timeout_hours"` // Maximum position age (24)
+	Policy       string        `json:"policy"`        // "market", "passive" or "stepped"
+	Window       time.Duration `json:"window"`        // Passive: post-only orders work the position this long before the rest goes at market (30m)
+	StepFraction float64       `json:"step_fraction"` // Stepped: fraction of the aged size closed per step (0.25)
+	StepInterval time.Duration `json:"step_interval"` // Stepped: time between steps (1h)
+}
+
+// OrderBudgetConfig contains the open order caps; grid levels nearest to price are placed first when they bind
+type OrderBudgetConfig struct {
+	MaxOpenOrders          int            `json:"max_open_orders"`            // Across all symbols, 0 disables (1000)
+	MaxOpenOrdersPerSymbol int            `json:"max_open_orders_per_symbol"` // Per symbol, 0 disables (200)
+	MaxOpenOrdersBySymbol  map[string]int `json:"max_open_orders_by_symbol"`  // Per-symbol overrides
+}
+
+// LiquidityConfig contains the spread and depth thresholds that defer order placement in thin markets
+type LiquidityConfig struct {
+	MaxSpread     float64       `json:"max_spread"`     // Bid/ask spread as a fraction of mid, 0 disables (0.1%)
+	MinDepth      float64       `json:"min_depth"`      // Quote notional at the best bid and ask, 0 disables
+	RetryInterval time.Duration `json:"retry_interval"` // 5s between checks while placement is deferred
+}
+
+// VolatilityBreakerConfig contains the circuit breaker that suspends new orders and widens the grid while
+// 1s realized volatility spikes above its rolling baseline
+type VolatilityBreakerConfig struct {
+	Multiple          float64       `json:"multiple"`           // Trip at this multiple of the baseline, 0 disables (5)
+	BaselineWindow    int           `json:"baseline_window"`    // 1s returns in the rolling baseline (300)
+	MinSamples        int           `json:"min_samples"`        // 1s returns needed before the breaker can trip (60)
+	MinMove           float64       `json:"min_move"`           // Smaller 1s moves never trip the breaker (0.05%)
+	Cooldown          time.Duration `json:"cooldown"`           // 30s calm before orders resume, and again before the spacing narrows
+	SpacingMultiplier float64       `json:"spacing_multiplier"` // Grid spacing widening until the breaker clears (2)
+}
+
+// LeverageControlConfig contains the dynamic leverage controller configuration
+type LeverageControlConfig struct {
+	Enabled       bool                 `json:"enabled"`        // Lower leverage while realized volatility is high
+	Window        int                  `json:"window"`         // 80 15s candles in the rolling volatility
+	Tiers         []LeverageTierConfig `json:"tiers"`          // Daily volatility thresholds and leverage caps
+	RestoreBuffer float64              `json:"restore_buffer"` // 10% below a threshold before leverage is restored
+}
+
+// LeverageTierConfig caps leverage from a realized volatility threshold
+type LeverageTierConfig struct {
+	Volatility float64 `json:"volatility"` // Daily realized volatility, e.g. 0.05 for 5%
+	Leverage   float64 `json:"leverage"`
 }
 
 // StreamConfig contains streaming data configuration
@@ -223,6 +542,35 @@ type StreamConfig struct {
 	BufferSize        int           `json:"buffer_size"`
 	BatchSize         int           `json:"batch_size"`
 	BatchTimeout      time.Duration `json:"batch_timeout"`
+	BaseInterval      time.Duration `json:"base_interval"`       // 300ms between feed updates; candle and confirmation timing derive from it
+	Backpressure      string        `json:"backpressure"`        // "coalesce", "drop_oldest" or "block" when processing falls behind
+	QueueSize         int           `json:"queue_size"`          // 100 updates queued before the backpressure policy applies
+	AggTrades         bool          `json:"agg_trades"`          // Build candle volume from aggregated trades, split into taker buys and sells
+
+	// Stale data rejection
+	StaleTolerance     time.Duration `json:"stale_tolerance"`      // Out-of-order window still accepted (0)
+	StaleAlertInterval time.Duration `json:"stale_alert_interval"` // 1m between stale data alerts
+
+	// Data gap handling
+	GapPolicy          string        `json:"gap_policy"`          // "mark" (pause entries) or "fill" (synthetic candles)
+	GapStallTimeout    time.Duration `json:"gap_stall_timeout"`   // 5s without ticks counts as a stall
+	GapMaxFill         time.Duration `json:"gap_max_fill"`        // 1m; longer gaps are marked even with the fill policy
+	GapRecoveryPeriod  time.Duration `json:"gap_recovery_period"` // 30s of continuous data before entries resume
+
+	// Event-time candles
+	EventTime          bool          `json:"event_time"`          // Bucket ticks into candles by exchange timestamp instead of arrival order
+	AllowedLateness    time.Duration `json:"allowed_lateness"`    // 500ms after its end a candle still takes out-of-order events
+
+	// Redundant feed
+	Failover           StreamFailoverConfig `json:"failover"`
+}
+
+// StreamFailoverConfig contains the backup feed the stream fails over to when the primary stalls
+type StreamFailoverConfig struct {
+	Enabled        bool          `json:"enabled"`
+	BackupURL      string        `json:"backup_url"`      // Second endpoint streaming the same symbols
+	StallTimeout   time.Duration `json:"stall_timeout"`   // 2s without data from the active feed before switching
+	RecoveryPeriod time.Duration `json:"recovery_period"` // 30s of primary data before switching back
 }
 
 // DatabaseConfig contains database configuration
@@ -245,6 +593,228 @@ type DatabaseConfig struct {
 	Timezone  string `json:"timezone"`
 }
 
+// WebhookConfig contains outgoing webhook configuration for external automation
+type WebhookConfig struct {
+	URL        string        `json:"url"`         // "" disables webhooks
+	Secret     string        `json:"secret"`      // HMAC signing secret (overridden by TRADING_BOT_WEBHOOK_SECRET)
+	Events     []string      `json:"events"`      // "mode_transition", "critical_risk" (all if empty)
+	Timeout    time.Duration `json:"timeout"`     // 5s
+	MaxRetries int           `json:"max_retries"` // 3
+	RetryDelay time.Duration `json:"retry_delay"` // 1s, doubled per retry
+	QueueSize  int           `json:"queue_size"`  // 100
+}
+
+// GrafanaConfig contains Grafana annotation configuration for chart-overlaid events
+type GrafanaConfig struct {
+	URL          string        `json:"url"`           // Grafana base URL, "" disables annotations
+	APIKey       string        `json:"api_key"`       // Service account token (overridden by TRADING_BOT_GRAFANA_API_KEY)
+	DashboardUID string        `json:"dashboard_uid"` // "" posts organization-wide annotations
+	Tags         []string      `json:"tags"`          // Added to every annotation (["aibot"])
+	Events       []string      `json:"events"`        // "mode_transition", "breakout", "risk_alert" (all if empty)
+	Timeout      time.Duration `json:"timeout"`       // 5s
+	QueueSize    int           `json:"queue_size"`    // 100
+}
+
+// FeeScheduleConfig contains a tiered maker/taker fee schedule
+type FeeScheduleConfig struct {
+	Tiers        []FeeTierConfig `json:"tiers"`
+	VolumeWindow time.Duration   `json:"volume_window"` // Rolling volume deciding the tier, 30 days
+	BNBDiscount  bool            `json:"bnb_discount"`  // Pay fees in BNB
+	DiscountRate float64         `json:"discount_rate"` // BNB discount, 0.1 (10%)
+}
+
+// FeeTierConfig is one VIP level of a fee schedule
+type FeeTierConfig struct {
+	Name      string  `json:"name"`
+	MinVolume float64 `json:"min_volume"` // Rolling volume required for the tier
+	MakerFee  float64 `json:"maker_fee"`
+	TakerFee  float64 `json:"taker_fee"`
+}
+
+// ContractConfig describes how a futures symbol is margined
+type ContractConfig struct {
+	Type       string    `json:"type"`       // "linear" (USDT-M) or "inverse" (COIN-M)
+	Multiplier float64   `json:"multiplier"` // Quote value per inverse contract, e.g. 100 for BTCUSD (1 for linear)
+	Expiry     time.Time `json:"expiry"`     // Settlement of a dated contract, RFC 3339 (omitted for perpetuals)
+	StepSize   float64   `json:"step_size"`  // Quantity increment; the exchange's trading rules are used when available
+	TickSize   float64   `json:"tick_size"`  // Price increment; the exchange's trading rules are used when available
+}
+
+// ControlConfig contains the local control server used by the CLI client and scripts
+type ControlConfig struct {
+	Address        string        `json:"address"`         // Loopback host:port ("" disables TCP)
+	SocketPath     string        `json:"socket_path"`     // Unix domain socket ("" disables the socket)
+	SocketMode     string        `json:"socket_mode"`     // Octal socket file permissions, "0600"
+	CommandTimeout time.Duration `json:"command_timeout"` // 5s
+}
+
+// SocketFileMode returns the parsed socket permissions, or 0 to use the default
+func (c ControlConfig) SocketFileMode() os.FileMode {
+	mode, err := strconv.ParseUint(c.SocketMode, 8, 32)
+	if err != nil {
+		return 0
+	}
+	return os.FileMode(mode)
+}
+
+// TracingConfig contains the OpenTelemetry tracing of the tick-to-order pipeline
+type TracingConfig struct {
+	Enabled     bool   `json:"enabled"`
+	SampleEvery int    `json:"sample_every"` // Trace one in N ticks
+	Exporter    string `json:"exporter"`     // "stdout", "otlp", "none" ("" is stdout with an output file, otherwise none)
+	OutputFile  string `json:"output_file"`  // JSON lines span file of the stdout exporter
+	Endpoint    string `json:"endpoint"`     // OTLP/HTTP collector host:port ("" uses OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318)
+	Insecure    bool   `json:"insecure"`     // Send OTLP without TLS
+	ServiceName string `json:"service_name"` // aibot
+}
+
+// NewsConfig contains the economic calendar that pauses grid entries around high-impact events
+type NewsConfig struct {
+	URL          string        `json:"url"`           // JSON event calendar ("" disables the integration)
+	PollInterval time.Duration `json:"poll_interval"` // 15m
+	Timeout      time.Duration `json:"timeout"`       // 10s
+	PauseBefore  time.Duration `json:"pause_before"`  // 15m
+	ResumeAfter  time.Duration `json:"resume_after"`  // 15m
+	MinImpact    string        `json:"min_impact"`    // "low", "medium", "high"
+	Currencies   []string      `json:"currencies"`    // Currencies or countries to watch (all if empty)
+}
+
+// ExchangeStatusConfig contains the exchange maintenance and symbol halt monitor
+type ExchangeStatusConfig struct {
+	URL          string        `json:"url"`           // Binance-compatible REST API ("" disables the monitor)
+	PollInterval time.Duration `json:"poll_interval"` // 30s
+	Timeout      time.Duration `json:"timeout"`       // 10s
+}
+
+// FundingConfig contains the funding and settlement calendar of futures symbols
+type FundingConfig struct {
+	URL                string        `json:"url"`                 // Binance-compatible futures REST API for funding rates ("" derives funding times from the interval)
+	PollInterval       time.Duration `json:"poll_interval"`       // 1m
+	Timeout            time.Duration `json:"timeout"`             // 10s
+	Interval           time.Duration `json:"interval"`            // 8h
+	Offset             time.Duration `json:"offset"`              // First funding of the UTC day, 0 = 00:00
+	EntryBlackout      time.Duration `json:"entry_blackout"`      // 30s; no new grid inventory this close to funding
+	SettlementBlackout time.Duration `json:"settlement_blackout"` // 1h; no new entries this close to settlement
+	ExpectedHolding    time.Duration `json:"expected_holding"`    // 4h; breakout carry cost horizon
+	MaxCarryFraction   float64       `json:"max_carry_fraction"`  // 25% of the breakout target move
+}
+
+// BasisConfig contains the spot-futures basis monitor of perpetual symbols
+type BasisConfig struct {
+	URL          string            `json:"url"`           // Binance-compatible spot REST API ("" disables basis monitoring)
+	Symbols      map[string]string `json:"symbols"`       // Perpetual -> spot symbol where they differ
+	PollInterval time.Duration     `json:"poll_interval"` // 5s
+	Timeout      time.Duration     `json:"timeout"`       // 10s
+	MaxBasis     float64           `json:"max_basis"`     // 1%; grid entries pause while |mark - spot| / spot is beyond it, 0 = never
+	BiasBasis    float64           `json:"bias_basis"`    // 0.3%; breakouts paying a smoothed premium this large are skipped, 0 = no bias
+	Smoothing    int               `json:"smoothing"`     // 20 readings
+	MaxAge       time.Duration     `json:"max_age"`       // 30s; older spot or mark prices make the basis stale
+}
+
+// ProfitSweepConfig contains the transfer of realized profit from the futures wallet to another wallet
+type ProfitSweepConfig struct {
+	Enabled        bool          `json:"enabled"`
+	WorkingCapital float64       `json:"working_capital"` // Balance kept for trading, 0 = initial balance
+	Threshold      float64       `json:"threshold"`       // Profit above the working capital that triggers a sweep
+	Asset          string        `json:"asset"`           // USDT
+	TransferType   string        `json:"transfer_type"`   // "UMFUTURE_MAIN" (spot) or "UMFUTURE_FUNDING" (funding wallet)
+	CheckInterval  time.Duration `json:"check_interval"`  // 5m
+	URL            string        `json:"url"`             // Wallet API, https://api.binance.com
+	Timeout        time.Duration `json:"timeout"`         // 10s
+}
+
+// EmailConfig contains the SMTP server notifications are sent through
+type EmailConfig struct {
+	SMTPHost string        `json:"smtp_host"` // "" disables email
+	SMTPPort int           `json:"smtp_port"` // 587 (STARTTLS) or 465 (implicit TLS)
+	Username string        `json:"username"`  // "" sends without authentication
+	Password string        `json:"password"`  // Overridden by TRADING_BOT_SMTP_PASSWORD
+	From     string        `json:"from"`
+	To       []string      `json:"to"`
+	Timeout  time.Duration `json:"timeout"` // 30s
+}
+
+// DailyReportConfig contains the end-of-day performance email
+type DailyReportConfig struct {
+	Enabled    bool   `json:"enabled"`
+	Time       string `json:"time"`        // Time of day in trading.accounting_timezone, "HH:MM"; the report covers the day before
+	AttachCSV  bool   `json:"attach_csv"`  // Attach the day's fills as CSV
+	AlertLevel string `json:"alert_level"` // Lowest risk alert level listed: "info", "warning" or "critical"
+}
+
+// AlertsConfig contains the user-defined alert rules
+type AlertsConfig struct {
+	CheckInterval time.Duration     `json:"check_interval"` // 5s
+	Email         bool              `json:"email"`          // Also email firings through the email settings
+	Rules         []AlertRuleConfig `json:"rules"`
+}
+
+// AlertRuleConfig is one alert rule, e.g. "unrealized_pnl < -100", "mode == recovery for > 10m" or "price crosses above grid_upper"
+type AlertRuleConfig struct {
+	Name      string        `json:"name"`
+	Condition string        `json:"condition"`
+	Level     string        `json:"level"`    // "info", "warning" or "critical" (warning)
+	Message   string        `json:"message"`  // "" describes the condition
+	Cooldown  time.Duration `json:"cooldown"` // Least time between firings (5m)
+}
+
+// HealthConfig contains the resource watchdog thresholds
+type HealthConfig struct {
+	CheckInterval time.Duration `json:"check_interval"`  // 5s
+	MaxGoroutines int           `json:"max_goroutines"`  // 0 disables the check
+	MaxMemoryMB   float64       `json:"max_memory_mb"`   // Heap in use; 0 disables the check
+	MaxBacklog    float64       `json:"max_backlog"`     // Channel fill fraction counted as backed up (0-1)
+	MaxStreamLag  time.Duration `json:"max_stream_lag"`  // Time without ticks; 0 disables the check
+	GracePeriod   time.Duration `json:"grace_period"`    // How long a threshold may stay exceeded before degrading
+	DegradeToIdle bool          `json:"degrade_to_idle"` // Switch to idle when a threshold stays exceeded
+	HTTPAddress   string        `json:"http_address"`    // Address of the /healthz and /readyz probes, e.g. ":8081" ("" disables)
+	MaxTickAge    time.Duration `json:"max_tick_age"`    // Age of the last tick beyond which /readyz fails (30s)
+}
+
+// ReconcileConfig contains the reconciliation of the trade journal against the exchange's trade history
+type ReconcileConfig struct {
+	RESTURL           string        `json:"rest_url"`           // Exchange REST API ("" uses Binance for the market type)
+	Timeout           time.Duration `json:"timeout"`            // 10s
+	Lookback          time.Duration `json:"lookback"`           // Period reconciled up to now (24h)
+	QuantityTolerance float64       `json:"quantity_tolerance"` // Fraction of the exchange quantity
+	FeeTolerance      float64       `json:"fee_tolerance"`      // Quote asset amount
+	Directory         string        `json:"directory"`          // Reconciliation reports
+}
+
+// ExperimentConfig contains the A/B experiment: two parameter profiles trade the same symbol side by side,
+// each on its own virtual book with a share of the initial balance
+type ExperimentConfig struct {
+	Name       string        `json:"name"`       // Names the experiment report
+	Duration   time.Duration `json:"duration"`   // How long the variants trade (24h)
+	Confidence float64       `json:"confidence"` // Confidence level a winner must reach (0.95)
+	Directory  string        `json:"directory"`  // Variant journals and experiment reports
+	Variants   []ExperimentVariantConfig `json:"variants"` // The two variants compared
+}
+
+// ExperimentVariantConfig contains one variant of an experiment
+type ExperimentVariantConfig struct {
+	Name         string  `json:"name"`          // e.g. "A"
+	Profile      string  `json:"profile"`       // Parameter profile the variant trades with
+	CapitalShare float64 `json:"capital_share"` // Fraction of the initial balance
+}
+
+// ScreenerConfig contains the grid suitability screener and daily symbol auto-selection
+type ScreenerConfig struct {
+	Symbols        []string      `json:"symbols"`          // Universe to screen (supported symbols if empty)
+	RESTURL        string        `json:"rest_url"`         // Exchange REST API for klines and 24h tickers
+	Timeout        time.Duration `json:"timeout"`          // 10s
+	Interval       string        `json:"interval"`         // Kline interval, e.g. "1h"
+	Lookback       int           `json:"lookback"`         // Klines analyzed
+	MinVolatility  float64       `json:"min_volatility"`   // Daily realized volatility band
+	MaxVolatility  float64       `json:"max_volatility"`
+	MaxADX         float64       `json:"max_adx"`          // Above it a symbol is trending
+	MinQuoteVolume float64       `json:"min_quote_volume"` // 24h volume in the quote asset
+	MaxSpread      float64       `json:"max_spread"`       // Bid-ask spread as a fraction of mid
+	TopN           int           `json:"top_n"`            // Symbols selected from the ranking
+	AutoSelect     bool          `json:"auto_select"`      // Trade the top symbols of the day's screen
+	Directory      string        `json:"directory"`        // Daily screener reports
+}
+
 // LoggingConfig contains logging configuration
 type LoggingConfig struct {
 	// Output
@@ -266,22 +836,40 @@ type LoggingConfig struct {
 	// Structured logging
 	EnableStructured bool     `json:"enable_structured"`
 	Fields          []string `json:"fields"` // Fields to include in structured logs
+
+	// Per-component filtering
+	ComponentLevels map[string]string `json:"component_levels"` // Component -> level, overriding level (orchestrator, stream, executor, risk)
+	TickSampleRate  int               `json:"tick_sample_rate"` // Log one in N ticks when stream debug logging is on
+
+	// Machine-readable event stream
+	Events          EventLogConfig    `json:"events"`
+}
+
+// EventLogConfig contains the JSON lines stream of trades, signals, alerts and mode changes for ingestion pipelines
+type EventLogConfig struct {
+	Output     string   `json:"output"`      // JSON lines file, or "stdout" ("" disables the stream)
+	Topics     []string `json:"topics"`      // signal, risk_alert, mode_change, order_update (all)
+	BufferSize int      `json:"buffer_size"` // Events buffered before they are dropped (1024)
 }
 
 // BacktestConfig contains backtesting configuration
 type BacktestConfig struct {
 	// Data
 	DataDirectory      string        `json:"data_directory"`
+	DataFormat         string        `json:"data_format"`    // "csv", "binance_zip", "parquet"
 	StartTime         time.Time     `json:"start_time"`
 	EndTime           time.Time     `json:"end_time"`
 	Symbols           []string      `json:"symbols"`
 	Timeframe         string        `json:"timeframe"`
 	InitialBalance    float64       `json:"initial_balance"`
+	WarmupPeriod      time.Duration `json:"warmup_period"` // Replayed data after start_time fed to indicators without trading or recording performance (0)
 
 	// Execution
 	Commission        float64       `json:"commission"`
 	Slippage          float64       `json:"slippage"`
 	Latency           time.Duration `json:"latency"`
+	IntraCandlePath   string        `json:"intra_candle_path"` // Price path inside replayed candles: "ohlc", "brownian" or "" (close only)
+	PathSteps         int           `json:"path_steps"`        // Prices per candle on the path (20)
 
 	// Output
 	ResultsDirectory   string        `json:"results_directory"`
@@ -289,6 +877,18 @@ type BacktestConfig struct {
 	GenerateCharts     bool          `json:"generate_charts"`
 	ExportTrades       bool          `json:"export_trades"`
 	ExportPerformance  bool          `json:"export_performance"`
+	BenchmarkInterval  time.Duration `json:"benchmark_interval"`  // Event time between equity samples compared with buy-and-hold and DCA in session reports (1m)
+	BenchmarkPoints    int           `json:"benchmark_points"`    // Equity samples kept before the curve is thinned (2000)
+	DCAInterval        time.Duration `json:"dca_interval"`        // Time between the equal buys of the DCA benchmark (24h)
+
+	// Checkpoints
+	CheckpointInterval time.Duration `json:"checkpoint_interval"` // Event time between checkpoints a stopped backtest resumes from (0 disables them)
+	CheckpointPath     string        `json:"checkpoint_path"`     // Latest checkpoint ("" keeps it in the journal directory)
+}
+
+// TradingStart returns the time trading is permitted from, once the warm-up period has passed
+func (c BacktestConfig) TradingStart() time.Time {
+	return c.StartTime.Add(c.WarmupPeriod)
 }
 
 // DefaultConfig returns a default configuration
@@ -307,27 +907,75 @@ func DefaultConfig() *Config {
 			InitialBalance:      10000.0,
 			MaxDailyLoss:        500.0,   // 5% of initial balance
 			MaxConsecutiveLosses: 5,
+			PnLCheckpointInterval: 1 * time.Minute,
+			AccountSnapshotInterval: 5 * time.Minute,
+			AccountingTimezone:  "UTC",
+			AccountingCurrency:  "USDT",
 			DefaultLeverage:     5.0,
 			MaxLeverage:         10.0,
 			MinPositionSize:     0.001,
 			MaxPositionSize:     1.0,
 			MakerFee:            0.0002, // 0.02%
 			TakerFee:            0.0006, // 0.06%
+			FeeSchedule: FeeScheduleConfig{
+				VolumeWindow: 30 * 24 * time.Hour,
+				DiscountRate: 0.1, // 10%
+			},
 			Slippage:            0.0005, // 0.05%
 			ExecutionType:       "live",
+			MarketType:          "futures",
+			QuoteAsset:          "USDT",
+			EnableHedging:       false,
 			OrderTimeout:        30 * time.Second,
 			RetryAttempts:       3,
 			RetryDelay:          1 * time.Second,
+			IntentMaxAge:        5 * time.Minute,
+			Execution: ExecutionAlgoConfig{
+				Algos: map[string]string{
+					"recovery_close":  "limit_chase",
+					"emergency_close": "market",
+				},
+				LargeOrder: LargeOrderConfig{
+					MinNotional: 50000,
+					Algo:        "twap",
+					Intents:     []string{"emergency_close", "breakout_entry"},
+				},
+				LimitChase: LimitChaseConfig{
+					RepriceInterval: 1 * time.Second,
+					MaxReprices:     20,
+					Timeout:         30 * time.Second,
+				},
+				TWAP: TWAPConfig{
+					Duration: 30 * time.Second,
+					Slices:   6,
+				},
+				Iceberg: IcebergConfig{
+					DisplayFraction: 0.1, // 10%
+					Duration:        2 * time.Minute,
+				},
+			},
 			SupportedSymbols:    []string{"BTCUSDT"},
 			DefaultSymbol:       "BTCUSDT",
-			MaxSymbols:          1,
+			MaxSymbols:          5,
+			Chaos: ChaosConfig{
+				Enabled:            false,
+				DisconnectDuration: 5 * time.Second,
+				RateLimitDuration:  time.Minute,
+			},
+			Margin: SimulationMarginConfig{
+				MaintenanceMarginRate: 0.004, // 0.4%
+				MarginCallRatio:       0.8,
+			},
 		},
 		Strategy: StrategyConfig{
+			Profile:    "balanced",
+			ProfileDir: "./config/profiles",
 			Grid: GridConfig{
 				MinGridSpacing:      0.0025, // 0.25%
 				MaxGridSpacing:      0.01,   // 1.0%
 				MinGridLevels:       10,
 				MaxGridLevels:       30,
+				SpacingMode:         "arithmetic",
 				MinProfitPerLevel:   0.0015, // 0.15%
 				TargetROI:           0.01,   // 1.0%
 				HistoryWindow:       200,
@@ -335,6 +983,9 @@ func DefaultConfig() *Config {
 				MinDataPoints:       50,
 				PriceBuffer:         0.05,   // 5%
 				RangeExpansion:      2.0,    // 2x ATR
+				SkewSpacingFactor:   1.0,
+				UnloadThreshold:     0.8,    // 80%
+				UnloadFraction:      0.5,    // 50%
 			},
 			Breakout: BreakoutConfig{
 				ConfirmationCandles:  3,
@@ -345,8 +996,16 @@ func DefaultConfig() *Config {
 				RSIOverbought:        70,
 				RSIOversold:          30,
 				ATRMultiple:          1.5,
+				ConfirmationMode:     "tick",
+				ConfirmationTimeframe: "3s",
+				ConfirmationCloses:   2,
+				StrengthMode:         "range",
+				MinStrengthPercent:   0.003, // 0.3%
+				MinStrengthATR:       0.5,
 				MaxFalseBreakouts:    3,
 				ConfidenceThreshold:  0.6,
+				SuccessPrior:         10,
+				SuccessWeight:        1,
 			},
 			FalseBreakout: FalseBreakoutConfig{
 				PriceReversionThreshold: 0.005, // 0.5%
@@ -357,6 +1016,14 @@ func DefaultConfig() *Config {
 				ATRMultiple:             1.5,
 				StdDevMultiplier:        2.0,
 				MaxFakeoutFrequency:     5.0, // Per hour
+				RequireVolumeData:       false,
+				VolumeLookbackCandles:   5,
+				ScorerTimeout:           200 * time.Millisecond,
+				ScorerBlendWeight:       0.5,
+				DivergenceWarning:       true,
+				DivergenceLookback:      30,
+				DivergenceSwingCandles:  5,
+				DivergenceMinGap:        0.1,
 			},
 			Stability: StabilityConfig{
 				AnalysisWindow:      10,
@@ -370,6 +1037,55 @@ func DefaultConfig() *Config {
 				LowRiskVolatility:    0.003, // < 0.3%
 				MediumRiskVolatility: 0.007, // 0.3-0.7%
 				HighRiskVolatility:   0.007, // > 0.7%
+				Adaptive: AdaptiveStabilityConfig{
+					Enabled:              false,
+					Lookback:             24 * time.Hour,
+					SampleInterval:       time.Minute,
+					MinSamples:           60,
+					VolatilityPercentile: 0.3,
+					MomentumPercentile:   0.3,
+					ConformityPercentile: 0.3,
+				},
+			},
+			ModeWatchdog: ModeWatchdogConfig{
+				CheckInterval: 5 * time.Second,
+				Modes: map[string]ModeTimeoutConfig{
+					"breakout":  {MaxDuration: 4 * time.Hour, Inactivity: 30 * time.Minute, Fallback: "grid"},
+					"stability": {MaxDuration: 30 * time.Minute, Inactivity: 10 * time.Minute, Fallback: "grid"},
+				},
+			},
+			RecoveryEscalation: RecoveryEscalationConfig{
+				Steps: []RecoveryStepConfig{
+					{After: 15 * time.Minute, Action: "reduce", Fraction: 0.5},
+					{After: 30 * time.Minute, Action: "flatten"},
+					{After: time.Hour, Action: "idle"},
+				},
+			},
+			SignalDedup: SignalDedupConfig{
+				Cooldowns: map[string]time.Duration{
+					"breakout":            30 * time.Second,
+					"false_breakout":      30 * time.Second,
+					"stability":           30 * time.Second,
+					"stability_confirmed": 30 * time.Second,
+					"stability_lost":      30 * time.Second,
+					"recovery_complete":   30 * time.Second,
+				},
+			},
+			EquityCurve: EquityCurveConfig{
+				Enabled:       false,
+				MAPeriod:      20,
+				DisableBuffer: 0.005, // 0.5%
+			},
+			FeeBudget: FeeBudgetConfig{
+				Enabled:      true,
+				HourlyBudget: 0,
+				DailyBudget:  0,
+				MaxFeeRatio:  0.5, // 50%
+				MinFills:     10,
+				Cooldown:     15 * time.Minute,
+				MaxThrottle:  3,
+				SpacingStep:  0.5, // 50%
+				LevelStep:    0.2, // 20%
 			},
 			Technical: TechnicalConfig{
 				IndicatorSettings: map[string]interface{}{
@@ -383,6 +1099,7 @@ func DefaultConfig() *Config {
 					"sma_period":     20,
 					"ema_period":     20,
 				},
+				NamedIndicators:       []string{},
 				AnalysisTimeframes:    []string{"1s", "3s", "15s"},
 				MinHistoryCandles:     50,
 				MaxHistoryCandles:     200,
@@ -400,6 +1117,50 @@ func DefaultConfig() *Config {
 			RiskAssessmentInterval:  1 * time.Minute,
 			MarginCallThreshold:     0.9, // 90% margin usage
 			EmergencyStopLoss:       0.15, // 15% portfolio loss
+			DeRiskReduceAt:          0.5,
+			DeRiskReduceFactor:      0.5,
+			DeRiskHaltAt:            0.8,
+			DeRiskFlattenAt:         1.0,
+			DeRiskCooldown:          1 * time.Hour,
+			MaintenanceMarginRate:   0.004,
+			LiquidationBuffer:       0.05,
+			MarginMode:              "cross",
+			LeverageControl: LeverageControlConfig{
+				Enabled: false,
+				Window:  80,
+				Tiers: []LeverageTierConfig{
+					{Volatility: 0.05, Leverage: 3},
+					{Volatility: 0.08, Leverage: 1},
+				},
+				RestoreBuffer: 0.1, // 10%
+			},
+			Liquidity: LiquidityConfig{
+				MaxSpread:     0.001, // 0.1%
+				MinDepth:      1000,
+				RetryInterval: 5 * time.Second,
+			},
+			VolatilityBreaker: VolatilityBreakerConfig{
+				Multiple:          5,
+				BaselineWindow:    300,
+				MinSamples:        60,
+				MinMove:           0.0005, // 0.05%
+				Cooldown:          30 * time.Second,
+				SpacingMultiplier: 2,
+			},
+			OrderBudget: OrderBudgetConfig{
+				MaxOpenOrders:          1000,
+				MaxOpenOrdersPerSymbol: 200,
+			},
+			AgedPositions: AgedPositionConfig{
+				TimeoutHours: 24,
+				Policy:       "market",
+				Window:       30 * time.Minute,
+				StepFraction: 0.25,
+				StepInterval: time.Hour,
+			},
+			ExchangeExits: ExchangeExitConfig{
+				Enabled: true,
+			},
 		},
 		Stream: StreamConfig{
 			ProviderType:    "live",
@@ -412,6 +1173,21 @@ func DefaultConfig() *Config {
 			BufferSize:      1000,
 			BatchSize:       100,
 			BatchTimeout:    1 * time.Second,
+			BaseInterval:    300 * time.Millisecond,
+			Backpressure:    "coalesce",
+			QueueSize:       100,
+			AggTrades:       true,
+			StaleAlertInterval: time.Minute,
+			GapPolicy:          "mark",
+			GapStallTimeout:    5 * time.Second,
+			GapMaxFill:         time.Minute,
+			GapRecoveryPeriod:  30 * time.Second,
+			AllowedLateness:    500 * time.Millisecond,
+			Failover: StreamFailoverConfig{
+				BackupURL:      "wss://stream.binance.com:9443/ws/btcusdt@ticker",
+				StallTimeout:   2 * time.Second,
+				RecoveryPeriod: 30 * time.Second,
+			},
 		},
 		Database: DatabaseConfig{
 			Driver:         "sqlite",
@@ -435,9 +1211,15 @@ func DefaultConfig() *Config {
 			BufferSize:       1000,
 			EnableStructured: true,
 			Fields:           []string{"timestamp", "level", "component", "message", "symbol", "price", "pnl"},
+			ComponentLevels:  map[string]string{},
+			TickSampleRate:   100,
+			Events: EventLogConfig{
+				BufferSize: 1024,
+			},
 		},
 	Backtest: BacktestConfig{
 			DataDirectory:      "./data",
+			DataFormat:         "csv",
 			StartTime:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 			EndTime:            time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
 			Symbols:            []string{"BTCUSDT"},
@@ -446,11 +1228,136 @@ func DefaultConfig() *Config {
 			Commission:         0.0004, // 0.04% (average of maker/taker)
 			Slippage:           0.0005, // 0.05%
 			Latency:            50 * time.Millisecond,
+			IntraCandlePath:    "ohlc",
+			PathSteps:          20,
 			ResultsDirectory:   "./backtest_results",
 			DetailedReports:    true,
 			GenerateCharts:     true,
 			ExportTrades:       true,
 			ExportPerformance:  true,
+			BenchmarkInterval:  time.Minute,
+			BenchmarkPoints:    2000,
+			DCAInterval:        24 * time.Hour,
+		},
+		Webhook: WebhookConfig{
+			Timeout:    5 * time.Second,
+			MaxRetries: 3,
+			RetryDelay: time.Second,
+			QueueSize:  100,
+		},
+		Grafana: GrafanaConfig{
+			Tags:      []string{"aibot"},
+			Timeout:   5 * time.Second,
+			QueueSize: 100,
+		},
+		Control: ControlConfig{
+			SocketPath:     "./data/aibot.sock",
+			SocketMode:     "0600",
+			CommandTimeout: 5 * time.Second,
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			SampleEvery: 100,
+			Exporter:    "stdout",
+			OutputFile:  "./logs/traces.jsonl",
+			ServiceName: "aibot",
+		},
+		News: NewsConfig{
+			PollInterval: 15 * time.Minute,
+			Timeout:      10 * time.Second,
+			PauseBefore:  15 * time.Minute,
+			ResumeAfter:  15 * time.Minute,
+			MinImpact:    "high",
+			Currencies:   []string{},
+		},
+		ExchangeStatus: ExchangeStatusConfig{
+			PollInterval: 30 * time.Second,
+			Timeout:      10 * time.Second,
+		},
+		ProfitSweep: ProfitSweepConfig{
+			Enabled:       false,
+			Threshold:     100,
+			Asset:         "USDT",
+			TransferType:  "UMFUTURE_MAIN",
+			CheckInterval: 5 * time.Minute,
+			URL:           "https://api.binance.com",
+			Timeout:       10 * time.Second,
+		},
+		Email: EmailConfig{
+			SMTPPort: 587,
+			To:       []string{},
+			Timeout:  30 * time.Second,
+		},
+		DailyReport: DailyReportConfig{
+			Enabled:    false,
+			Time:       "00:00",
+			AttachCSV:  true,
+			AlertLevel: "warning",
+		},
+		Funding: FundingConfig{
+			PollInterval:       time.Minute,
+			Timeout:            10 * time.Second,
+			Interval:           8 * time.Hour,
+			EntryBlackout:      30 * time.Second,
+			SettlementBlackout: time.Hour,
+			ExpectedHolding:    4 * time.Hour,
+			MaxCarryFraction:   0.25,
+		},
+		Basis: BasisConfig{
+			Symbols:      map[string]string{},
+			PollInterval: 5 * time.Second,
+			Timeout:      10 * time.Second,
+			MaxBasis:     0.01,
+			BiasBasis:    0.003,
+			Smoothing:    20,
+			MaxAge:       30 * time.Second,
+		},
+		Alerts: AlertsConfig{
+			CheckInterval: 5 * time.Second,
+			Rules:         []AlertRuleConfig{},
+		},
+		Health: HealthConfig{
+			CheckInterval: 5 * time.Second,
+			MaxGoroutines: 1000,
+			MaxMemoryMB:   1024,
+			MaxBacklog:    0.9,
+			MaxStreamLag:  30 * time.Second,
+			GracePeriod:   10 * time.Second,
+			DegradeToIdle: true,
+			HTTPAddress:   "",
+			MaxTickAge:    30 * time.Second,
+		},
+		Screener: ScreenerConfig{
+			Symbols:        []string{"BTCUSDT", "ETHUSDT", "BNBUSDT", "SOLUSDT", "XRPUSDT", "ADAUSDT"},
+			RESTURL:        "https://api.binance.com",
+			Timeout:        10 * time.Second,
+			Interval:       "1h",
+			Lookback:       168,
+			MinVolatility:  0.01,
+			MaxVolatility:  0.06,
+			MaxADX:         25,
+			MinQuoteVolume: 10000000,
+			MaxSpread:      0.001,
+			TopN:           3,
+			AutoSelect:     false,
+			Directory:      "./data/screener",
+		},
+		Reconcile: ReconcileConfig{
+			Timeout:           10 * time.Second,
+			Lookback:          24 * time.Hour,
+			QuantityTolerance: 0.000001,
+			FeeTolerance:      0.0001,
+			Directory:         "./data/reconcile",
+		},
+		Experiment: ExperimentConfig{
+			Name:       "ab",
+			Duration:   24 * time.Hour,
+			Confidence: 0.95,
+			Directory:  "./data/experiments",
+			Variants: []ExperimentVariantConfig{
+				{Name: "A", Profile: "balanced", CapitalShare: 0.5},
+				{Name: "B", Profile: "conservative", CapitalShare: 0.5},
+			},
 		},
 	}
 }
@@ -509,6 +1416,37 @@ func SaveConfig(config *Config, configPath string) error {
 	return nil
 }
 
+// Secrets returns the configured credentials, including their environment overrides, so they can be
+// masked in log output
+func (c *Config) Secrets() []string {
+	return []string{
+		c.Trading.APIKey, GetEnv("TRADING_BOT_API_KEY", ""),
+		c.Trading.APISecret, GetEnv("TRADING_BOT_API_SECRET", ""),
+		c.Database.Password,
+		c.Webhook.Secret, GetEnv("TRADING_BOT_WEBHOOK_SECRET", ""),
+		c.Grafana.APIKey, GetEnv("TRADING_BOT_GRAFANA_API_KEY", ""),
+		c.Email.Password, GetEnv("TRADING_BOT_SMTP_PASSWORD", ""),
+	}
+}
+
+// Redacted returns a copy of the configuration with credentials masked, for echoing it in logs or output
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	for _, secret := range []*string{
+		&redacted.Trading.APIKey,
+		&redacted.Trading.APISecret,
+		&redacted.Database.Password,
+		&redacted.Webhook.Secret,
+		&redacted.Grafana.APIKey,
+		&redacted.Email.Password,
+	} {
+		if *secret != "" {
+			*secret = "[REDACTED]"
+		}
+	}
+	return &redacted
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	// Validate app config
@@ -526,6 +1464,122 @@ func (c *Config) Validate() error {
 	if c.Trading.DefaultLeverage > c.Trading.MaxLeverage {
 		return fmt.Errorf("default leverage cannot exceed max leverage")
 	}
+	if c.Trading.MarketType != "" && c.Trading.MarketType != "futures" && c.Trading.MarketType != "spot" {
+		return fmt.Errorf("invalid market type: %s", c.Trading.MarketType)
+	}
+	if c.Trading.EnableHedging && c.Trading.MarketType == "spot" {
+		return fmt.Errorf("hedge mode is not available on spot markets")
+	}
+	for i, tier := range c.Trading.FeeSchedule.Tiers {
+		if tier.MinVolume < 0 || tier.MakerFee < -0.01 || tier.TakerFee < 0 || tier.MakerFee > 0.01 || tier.TakerFee > 0.01 {
+			return fmt.Errorf("fee tier %d (%s) has an invalid volume or fee rate", i, tier.Name)
+		}
+		if i > 0 && tier.MinVolume <= c.Trading.FeeSchedule.Tiers[i-1].MinVolume {
+			return fmt.Errorf("fee tiers must be ordered by increasing min volume")
+		}
+	}
+	if c.Trading.FeeSchedule.DiscountRate < 0 || c.Trading.FeeSchedule.DiscountRate >= 1 {
+		return fmt.Errorf("fee discount rate must be between 0 and 1")
+	}
+	for symbol, contract := range c.Trading.Contracts {
+		if contract.Type != "linear" && contract.Type != "inverse" {
+			return fmt.Errorf("invalid contract type for %s: %s", symbol, contract.Type)
+		}
+		if contract.Multiplier < 0 {
+			return fmt.Errorf("contract multiplier for %s cannot be negative", symbol)
+		}
+		if contract.StepSize < 0 || contract.TickSize < 0 {
+			return fmt.Errorf("contract step and tick size for %s cannot be negative", symbol)
+		}
+		if contract.Type == "inverse" {
+			if c.Trading.MarketType == "spot" {
+				return fmt.Errorf("inverse contract for %s is not available on spot markets", symbol)
+			}
+			if contract.Multiplier == 0 {
+				return fmt.Errorf("inverse contract for %s requires a multiplier", symbol)
+			}
+		}
+		if !contract.Expiry.IsZero() && c.Trading.MarketType == "spot" {
+			return fmt.Errorf("dated contract for %s is not available on spot markets", symbol)
+		}
+	}
+
+	if c.Trading.PnLCheckpointInterval < 0 {
+		return fmt.Errorf("pnl checkpoint interval cannot be negative")
+	}
+	if c.Trading.AccountSnapshotInterval < 0 {
+		return fmt.Errorf("account snapshot interval cannot be negative")
+	}
+	if _, err := time.LoadLocation(c.Trading.AccountingTimezone); err != nil {
+		return fmt.Errorf("invalid accounting timezone %q: %w", c.Trading.AccountingTimezone, err)
+	}
+	for asset, rate := range c.Trading.QuoteRates {
+		if rate <= 0 {
+			return fmt.Errorf("quote rate of %s must be positive", asset)
+		}
+	}
+	for _, symbol := range c.Trading.RateSymbols {
+		if symbol == "" {
+			return fmt.Errorf("rate symbols cannot be empty")
+		}
+	}
+	if c.Trading.IntentMaxAge < 0 {
+		return fmt.Errorf("intent max age cannot be negative")
+	}
+	execIntents := map[string]bool{"recovery_close": true, "emergency_close": true, "breakout_entry": true, "breakout_exit": true, "aged_unwind": true}
+	execAlgos := map[string]bool{"market": true, "limit_chase": true, "twap": true, "iceberg": true}
+	for intent, algo := range c.Trading.Execution.Algos {
+		if !execIntents[intent] {
+			return fmt.Errorf("unknown execution intent %q (must be recovery_close, emergency_close, breakout_entry, breakout_exit or aged_unwind)", intent)
+		}
+		if !execAlgos[algo] {
+			return fmt.Errorf("invalid execution algo %q for %s (must be market, limit_chase, twap or iceberg)", algo, intent)
+		}
+	}
+	largeOrder := c.Trading.Execution.LargeOrder
+	if largeOrder.MinNotional < 0 {
+		return fmt.Errorf("large order min notional cannot be negative")
+	}
+	if largeOrder.Algo != "" && largeOrder.Algo != "twap" && largeOrder.Algo != "iceberg" {
+		return fmt.Errorf("invalid large order algo %q (must be twap or iceberg)", largeOrder.Algo)
+	}
+	for _, intent := range largeOrder.Intents {
+		if !execIntents[intent] {
+			return fmt.Errorf("unknown large order intent %q", intent)
+		}
+	}
+	chase := c.Trading.Execution.LimitChase
+	if chase.RepriceInterval < 0 || chase.Timeout < 0 || chase.MaxReprices < 0 {
+		return fmt.Errorf("limit chase interval, timeout and max reprices cannot be negative")
+	}
+	if chase.RepriceThreshold < 0 || chase.RepriceThreshold >= 1 {
+		return fmt.Errorf("limit chase reprice threshold must be between 0 and 1")
+	}
+	if c.Trading.Execution.TWAP.Duration < 0 || c.Trading.Execution.TWAP.Slices < 0 || c.Trading.Execution.Iceberg.Duration < 0 {
+		return fmt.Errorf("twap and iceberg durations and slices cannot be negative")
+	}
+	if fraction := c.Trading.Execution.Iceberg.DisplayFraction; fraction < 0 || fraction > 1 {
+		return fmt.Errorf("iceberg display fraction must be between 0 and 1")
+	}
+	if c.Trading.Chaos.Enabled {
+		if c.Trading.ExecutionType != "simulation" {
+			return fmt.Errorf("chaos mode is only available with simulation execution")
+		}
+		for _, rate := range []float64{c.Trading.Chaos.DisconnectRate, c.Trading.Chaos.BusyRate, c.Trading.Chaos.ReorderRate, c.Trading.Chaos.RateLimitRate} {
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("chaos rates must be between 0 and 1")
+			}
+		}
+		if c.Trading.Chaos.RateLimitDuration < 0 {
+			return fmt.Errorf("chaos rate limit duration cannot be negative")
+		}
+	}
+	if rate := c.Trading.Margin.MaintenanceMarginRate; rate < 0 || rate >= 1 {
+		return fmt.Errorf("simulation maintenance margin rate must be between 0 and 1")
+	}
+	if ratio := c.Trading.Margin.MarginCallRatio; ratio < 0 || ratio >= 1 {
+		return fmt.Errorf("simulation margin call ratio must be between 0 and 1")
+	}
 
 	// Validate symbols
 	if len(c.Trading.SupportedSymbols) == 0 {
@@ -534,14 +1588,158 @@ func (c *Config) Validate() error {
 	if c.Trading.DefaultSymbol == "" {
 		return fmt.Errorf("default symbol is required")
 	}
+	if c.Trading.MaxSymbols < 0 {
+		return fmt.Errorf("max symbols cannot be negative")
+	}
 
 	// Validate strategy config
+	if strings.ContainsAny(c.Strategy.Profile, `/\.`) {
+		return fmt.Errorf("invalid strategy profile name: %s", c.Strategy.Profile)
+	}
 	if c.Strategy.Grid.MinGridLevels <= 0 {
 		return fmt.Errorf("min grid levels must be positive")
 	}
 	if c.Strategy.Grid.MaxGridLevels <= c.Strategy.Grid.MinGridLevels {
 		return fmt.Errorf("max grid levels must be greater than min grid levels")
 	}
+	allocated := 0.0
+	for bucket, fraction := range c.Strategy.CapitalAllocation {
+		if bucket != "grid" && bucket != "breakout" {
+			return fmt.Errorf("unknown capital allocation bucket: %s", bucket)
+		}
+		if fraction < 0 || fraction > 1 {
+			return fmt.Errorf("capital allocation for %s must be between 0 and 1", bucket)
+		}
+		allocated += fraction
+	}
+	if allocated > 1 {
+		return fmt.Errorf("capital allocations sum to %.2f, must not exceed 1", allocated)
+	}
+	if c.Strategy.Grid.MaxInventory < 0 {
+		return fmt.Errorf("grid max inventory cannot be negative")
+	}
+	for symbol, limit := range c.Strategy.Grid.MaxInventoryBySymbol {
+		if limit < 0 {
+			return fmt.Errorf("grid max inventory for %s cannot be negative", symbol)
+		}
+	}
+	if c.Strategy.Grid.SpacingMode != "" && c.Strategy.Grid.SpacingMode != "arithmetic" && c.Strategy.Grid.SpacingMode != "geometric" {
+		return fmt.Errorf("grid spacing mode must be arithmetic or geometric: %s", c.Strategy.Grid.SpacingMode)
+	}
+	if c.Strategy.Grid.SkewSpacingFactor < 0 {
+		return fmt.Errorf("grid skew spacing factor cannot be negative")
+	}
+	if c.Strategy.Grid.UnloadThreshold < 0 || c.Strategy.Grid.UnloadThreshold > 1 {
+		return fmt.Errorf("grid unload threshold must be between 0 and 1")
+	}
+	if c.Strategy.Grid.UnloadFraction < 0 || c.Strategy.Grid.UnloadFraction > 1 {
+		return fmt.Errorf("grid unload fraction must be between 0 and 1")
+	}
+
+	switch c.Strategy.Breakout.ConfirmationMode {
+	case "", "tick", "closed_candle":
+	default:
+		return fmt.Errorf("breakout confirmation mode must be tick or closed_candle: %s", c.Strategy.Breakout.ConfirmationMode)
+	}
+	switch c.Strategy.Breakout.ConfirmationTimeframe {
+	case "", "3s", "15s":
+	default:
+		return fmt.Errorf("breakout confirmation timeframe must be 3s or 15s: %s", c.Strategy.Breakout.ConfirmationTimeframe)
+	}
+	if c.Strategy.Breakout.ConfirmationCloses < 0 {
+		return fmt.Errorf("breakout confirmation closes cannot be negative")
+	}
+	switch c.Strategy.Breakout.StrengthMode {
+	case "", "range", "percent", "atr":
+	default:
+		return fmt.Errorf("breakout strength mode must be range, percent or atr: %s", c.Strategy.Breakout.StrengthMode)
+	}
+	if c.Strategy.Breakout.MinStrengthPercent < 0 || c.Strategy.Breakout.MinStrengthATR < 0 {
+		return fmt.Errorf("breakout minimum strengths cannot be negative")
+	}
+	if c.Strategy.Breakout.SuccessPrior < 0 || c.Strategy.Breakout.SuccessWeight < 0 {
+		return fmt.Errorf("breakout success prior and weight cannot be negative")
+	}
+
+	if c.Strategy.FalseBreakout.ScorerType != "" {
+		if c.Strategy.FalseBreakout.ScorerType != "http" && c.Strategy.FalseBreakout.ScorerType != "onnx" {
+			return fmt.Errorf("invalid false breakout scorer type: %s", c.Strategy.FalseBreakout.ScorerType)
+		}
+		if c.Strategy.FalseBreakout.ScorerBlendWeight < 0 || c.Strategy.FalseBreakout.ScorerBlendWeight > 1 {
+			return fmt.Errorf("false breakout scorer blend weight must be between 0 and 1")
+		}
+	}
+	falseBreakout := c.Strategy.FalseBreakout
+	if falseBreakout.DivergenceLookback < 0 || falseBreakout.DivergenceSwingCandles < 0 {
+		return fmt.Errorf("false breakout divergence lookback and swing candles cannot be negative")
+	}
+	if falseBreakout.DivergenceLookback > 0 && falseBreakout.DivergenceSwingCandles >= falseBreakout.DivergenceLookback {
+		return fmt.Errorf("false breakout divergence swing candles must be less than the lookback")
+	}
+	if falseBreakout.DivergenceMinGap < 0 || falseBreakout.DivergenceMinGap > 1 {
+		return fmt.Errorf("false breakout divergence min gap must be between 0 and 1")
+	}
+	feeBudget := c.Strategy.FeeBudget
+	if feeBudget.HourlyBudget < 0 || feeBudget.DailyBudget < 0 || feeBudget.MaxFeeRatio < 0 {
+		return fmt.Errorf("fee budgets and max fee ratio cannot be negative")
+	}
+	if feeBudget.MinFills < 0 || feeBudget.Cooldown < 0 || feeBudget.MaxThrottle < 0 {
+		return fmt.Errorf("fee budget min fills, cooldown and max throttle cannot be negative")
+	}
+	if feeBudget.SpacingStep < 0 || feeBudget.LevelStep < 0 || feeBudget.LevelStep >= 1 {
+		return fmt.Errorf("fee budget spacing step cannot be negative and level step must be between 0 and 1")
+	}
+	if c.Strategy.ModeWatchdog.CheckInterval < 0 {
+		return fmt.Errorf("mode watchdog check interval cannot be negative")
+	}
+	validModes := map[string]bool{"grid": true, "breakout": true, "recovery": true, "stability": true, "idle": true}
+	for mode, timeout := range c.Strategy.ModeWatchdog.Modes {
+		if !validModes[mode] {
+			return fmt.Errorf("invalid mode watchdog mode: %s", mode)
+		}
+		if timeout.MaxDuration < 0 || timeout.Inactivity < 0 {
+			return fmt.Errorf("mode watchdog limits of %s cannot be negative", mode)
+		}
+		if timeout.Fallback != "" && (!validModes[timeout.Fallback] || timeout.Fallback == mode) {
+			return fmt.Errorf("invalid mode watchdog fallback of %s: %s", mode, timeout.Fallback)
+		}
+	}
+	for i, step := range c.Strategy.RecoveryEscalation.Steps {
+		if step.Action != "reduce" && step.Action != "flatten" && step.Action != "idle" {
+			return fmt.Errorf("invalid recovery escalation action of step %d: %s", i, step.Action)
+		}
+		if step.After <= 0 {
+			return fmt.Errorf("recovery escalation step %d needs a positive delay", i)
+		}
+		if step.Fraction < 0 || step.Fraction > 1 {
+			return fmt.Errorf("recovery escalation fraction of step %d must be between 0 and 1", i)
+		}
+	}
+	validSignals := map[string]bool{
+		"grid_setup": true, "breakout": true, "false_breakout": true, "stability": true, "stability_confirmed": true,
+		"stability_lost": true, "recovery_complete": true, "news_pause": true, "news_resume": true,
+		"exchange_halt": true, "exchange_resume": true, "mode_timeout": true,
+	}
+	for signalType, cooldown := range c.Strategy.SignalDedup.Cooldowns {
+		if !validSignals[signalType] {
+			return fmt.Errorf("invalid signal dedup signal type: %s", signalType)
+		}
+		if cooldown < 0 {
+			return fmt.Errorf("signal dedup cooldown of %s cannot be negative", signalType)
+		}
+	}
+	adaptive := c.Strategy.Stability.Adaptive
+	if adaptive.Lookback < 0 || adaptive.SampleInterval < 0 || adaptive.MinSamples < 0 {
+		return fmt.Errorf("adaptive stability lookback, sample interval and minimum samples cannot be negative")
+	}
+	if adaptive.SampleInterval > 0 && adaptive.Lookback > 0 && adaptive.SampleInterval >= adaptive.Lookback {
+		return fmt.Errorf("adaptive stability sample interval must be shorter than the lookback")
+	}
+	for _, percentile := range []float64{adaptive.VolatilityPercentile, adaptive.MomentumPercentile, adaptive.ConformityPercentile} {
+		if percentile < 0 || percentile > 1 {
+			return fmt.Errorf("adaptive stability percentiles must be between 0 and 1")
+		}
+	}
 
 	// Validate risk config
 	if c.Risk.MaxPortfolioRisk <= 0 || c.Risk.MaxPortfolioRisk > 1 {
@@ -550,6 +1748,71 @@ func (c *Config) Validate() error {
 	if c.Risk.MaxPositionRisk <= 0 || c.Risk.MaxPositionRisk > 1 {
 		return fmt.Errorf("max position risk must be between 0 and 1")
 	}
+	if c.Risk.DeRiskReduceAt > c.Risk.DeRiskHaltAt || c.Risk.DeRiskHaltAt > c.Risk.DeRiskFlattenAt {
+		return fmt.Errorf("de-risking thresholds must increase: reduce <= halt <= flatten")
+	}
+	if c.Risk.DeRiskReduceFactor < 0 || c.Risk.DeRiskReduceFactor > 1 {
+		return fmt.Errorf("de-risk reduce factor must be between 0 and 1")
+	}
+	if c.Risk.PolicyFile != "" {
+		if _, err := os.Stat(c.Risk.PolicyFile); err != nil {
+			return fmt.Errorf("risk policy file not found: %s", c.Risk.PolicyFile)
+		}
+	}
+	if c.Risk.MaintenanceMarginRate < 0 || c.Risk.MaintenanceMarginRate >= 1 {
+		return fmt.Errorf("maintenance margin rate must be between 0 and 1")
+	}
+	if c.Risk.LiquidationBuffer < 0 || c.Risk.LiquidationBuffer >= 1 {
+		return fmt.Errorf("liquidation buffer must be between 0 and 1")
+	}
+	if c.Risk.MarginMode != "" && c.Risk.MarginMode != "cross" && c.Risk.MarginMode != "isolated" {
+		return fmt.Errorf("invalid margin mode: %s", c.Risk.MarginMode)
+	}
+	if c.Risk.LeverageControl.Window < 0 || c.Risk.LeverageControl.RestoreBuffer < 0 || c.Risk.LeverageControl.RestoreBuffer >= 1 {
+		return fmt.Errorf("leverage control window cannot be negative and restore buffer must be between 0 and 1")
+	}
+	for _, tier := range c.Risk.LeverageControl.Tiers {
+		if tier.Volatility <= 0 || tier.Leverage <= 0 {
+			return fmt.Errorf("leverage control tiers need a positive volatility and leverage")
+		}
+	}
+	if c.Risk.Liquidity.MaxSpread < 0 || c.Risk.Liquidity.MaxSpread >= 1 {
+		return fmt.Errorf("liquidity max spread must be between 0 and 1")
+	}
+	if c.Risk.Liquidity.MinDepth < 0 || c.Risk.Liquidity.RetryInterval < 0 {
+		return fmt.Errorf("liquidity min depth and retry interval cannot be negative")
+	}
+	if breaker := c.Risk.VolatilityBreaker; breaker.Multiple < 0 || breaker.BaselineWindow < 0 || breaker.MinSamples < 0 ||
+		breaker.MinMove < 0 || breaker.Cooldown < 0 {
+		return fmt.Errorf("volatility breaker settings cannot be negative")
+	}
+	if breaker := c.Risk.VolatilityBreaker; breaker.SpacingMultiplier != 0 && breaker.SpacingMultiplier < 1 {
+		return fmt.Errorf("volatility breaker spacing multiplier must be at least 1")
+	}
+	if breaker := c.Risk.VolatilityBreaker; breaker.BaselineWindow > 0 && breaker.MinSamples > breaker.BaselineWindow {
+		return fmt.Errorf("volatility breaker min samples cannot exceed the baseline window")
+	}
+	if c.Risk.OrderBudget.MaxOpenOrders < 0 || c.Risk.OrderBudget.MaxOpenOrdersPerSymbol < 0 {
+		return fmt.Errorf("open order caps cannot be negative")
+	}
+	for symbol, limit := range c.Risk.OrderBudget.MaxOpenOrdersBySymbol {
+		if limit < 0 {
+			return fmt.Errorf("open order cap for %s cannot be negative", symbol)
+		}
+	}
+	aged := c.Risk.AgedPositions
+	if aged.Policy != "" && aged.Policy != "market" && aged.Policy != "passive" && aged.Policy != "stepped" {
+		return fmt.Errorf("invalid aged position policy %q (must be market, passive or stepped)", aged.Policy)
+	}
+	if aged.TimeoutHours < 0 || aged.Window < 0 || aged.StepInterval < 0 {
+		return fmt.Errorf("aged position timeout, window and step interval cannot be negative")
+	}
+	if aged.StepFraction < 0 || aged.StepFraction > 1 {
+		return fmt.Errorf("aged position step fraction must be between 0 and 1")
+	}
+	if c.Risk.ExchangeExits.TrailingStop < 0 || c.Risk.ExchangeExits.TrailingStop >= 1 {
+		return fmt.Errorf("exchange exit trailing stop must be between 0 and 1")
+	}
 
 	// Validate logging config
 	validLevels := []string{"debug", "info", "warn", "error"}
@@ -563,6 +1826,21 @@ func (c *Config) Validate() error {
 	if !levelValid {
 		return fmt.Errorf("invalid log level: %s", c.Logging.Level)
 	}
+	for component, level := range c.Logging.ComponentLevels {
+		componentLevelValid := false
+		for _, validLevel := range validLevels {
+			if level == validLevel {
+				componentLevelValid = true
+				break
+			}
+		}
+		if !componentLevelValid {
+			return fmt.Errorf("invalid log level for %s: %s", component, level)
+		}
+	}
+	if c.Logging.TickSampleRate < 0 {
+		return fmt.Errorf("tick sample rate cannot be negative")
+	}
 
 	validFormats := []string{"json", "text"}
 	formatValid := false
@@ -575,8 +1853,83 @@ func (c *Config) Validate() error {
 	if !formatValid {
 		return fmt.Errorf("invalid log format: %s", c.Logging.Format)
 	}
+	for _, topic := range c.Logging.Events.Topics {
+		switch topic {
+		case "signal", "risk_alert", "mode_change", "order_update":
+		default:
+			return fmt.Errorf("invalid event log topic: %s", topic)
+		}
+	}
+	if c.Logging.Events.BufferSize < 0 {
+		return fmt.Errorf("event log buffer size cannot be negative")
+	}
+
+	// Validate tracing config
+	if c.Tracing.SampleEvery < 0 {
+		return fmt.Errorf("tracing sample rate cannot be negative")
+	}
+	if c.Tracing.Exporter != "" && c.Tracing.Exporter != "stdout" && c.Tracing.Exporter != "otlp" && c.Tracing.Exporter != "none" {
+		return fmt.Errorf("invalid tracing exporter: %s", c.Tracing.Exporter)
+	}
+
+	// Validate stream config
+	if c.Stream.BaseInterval < 0 || c.Stream.BaseInterval > time.Second {
+		return fmt.Errorf("base interval must be at most 1s (the smallest candle timeframe)")
+	}
+	if c.Stream.Backpressure != "" && c.Stream.Backpressure != "coalesce" && c.Stream.Backpressure != "drop_oldest" && c.Stream.Backpressure != "block" {
+		return fmt.Errorf("invalid backpressure policy: %s", c.Stream.Backpressure)
+	}
+	if c.Stream.ProviderType == "replay" {
+		if c.Backtest.DataDirectory == "" {
+			return fmt.Errorf("replay requires a backtest data directory")
+		}
+		if c.Trading.ExecutionType != "simulation" {
+			return fmt.Errorf("replay is only available with simulation execution")
+		}
+		if c.Stream.Backpressure != "block" {
+			return fmt.Errorf("replay requires the block backpressure policy, so no replayed data is dropped")
+		}
+	}
+	if c.Stream.QueueSize < 0 {
+		return fmt.Errorf("stream queue size cannot be negative")
+	}
+	if c.Stream.StaleTolerance < 0 {
+		return fmt.Errorf("stale tolerance cannot be negative")
+	}
+	if c.Stream.GapPolicy != "" && c.Stream.GapPolicy != "mark" && c.Stream.GapPolicy != "fill" {
+		return fmt.Errorf("invalid gap policy: %s", c.Stream.GapPolicy)
+	}
+	if c.Stream.AllowedLateness < 0 {
+		return fmt.Errorf("allowed lateness cannot be negative")
+	}
+	if c.Stream.Failover.Enabled && c.Stream.Failover.BackupURL == "" {
+		return fmt.Errorf("stream failover requires a backup URL")
+	}
+	if c.Stream.Failover.StallTimeout < 0 || c.Stream.Failover.RecoveryPeriod < 0 {
+		return fmt.Errorf("stream failover timeouts cannot be negative")
+	}
+	if c.Stream.Failover.StallTimeout > 0 && c.Stream.Failover.StallTimeout <= c.Stream.BaseInterval {
+		return fmt.Errorf("stream failover stall timeout must be longer than the base interval")
+	}
 
 	// Validate backtest config
+	switch c.Backtest.IntraCandlePath {
+	case "", "ohlc", "brownian":
+	default:
+		return fmt.Errorf("intra-candle path must be ohlc, brownian or empty: %s", c.Backtest.IntraCandlePath)
+	}
+	if c.Backtest.PathSteps < 0 {
+		return fmt.Errorf("intra-candle path steps cannot be negative")
+	}
+	if c.Backtest.BenchmarkInterval < 0 || c.Backtest.DCAInterval < 0 {
+		return fmt.Errorf("benchmark and DCA intervals cannot be negative")
+	}
+	if c.Backtest.CheckpointInterval < 0 {
+		return fmt.Errorf("backtest checkpoint interval cannot be negative")
+	}
+	if c.Backtest.BenchmarkPoints < 0 {
+		return fmt.Errorf("benchmark points cannot be negative")
+	}
 	if c.Backtest.DataDirectory != "" {
 		if len(c.Backtest.Symbols) == 0 {
 			return fmt.Errorf("at least one symbol is required for backtesting")
@@ -593,6 +1946,246 @@ func (c *Config) Validate() error {
 		if c.Backtest.InitialBalance <= 0 {
 			return fmt.Errorf("initial balance must be positive for backtesting")
 		}
+		if c.Backtest.WarmupPeriod < 0 {
+			return fmt.Errorf("backtest warm-up period cannot be negative")
+		}
+		if !c.Backtest.TradingStart().Before(c.Backtest.EndTime) {
+			return fmt.Errorf("backtest warm-up period must end before the end time")
+		}
+		if c.Backtest.DataFormat != "" && c.Backtest.DataFormat != "csv" && c.Backtest.DataFormat != "binance_zip" &&
+			c.Backtest.DataFormat != "parquet" {
+			return fmt.Errorf("unsupported backtest data format: %s", c.Backtest.DataFormat)
+		}
+	}
+
+	// Validate webhook config
+	if c.Webhook.URL != "" {
+		if !strings.HasPrefix(c.Webhook.URL, "http://") && !strings.HasPrefix(c.Webhook.URL, "https://") {
+			return fmt.Errorf("webhook url must be http or https: %s", c.Webhook.URL)
+		}
+		for _, event := range c.Webhook.Events {
+			if event != "mode_transition" && event != "critical_risk" {
+				return fmt.Errorf("unsupported webhook event: %s", event)
+			}
+		}
+		if c.Webhook.MaxRetries < 0 {
+			return fmt.Errorf("webhook max retries cannot be negative")
+		}
+	}
+
+	// Validate Grafana config
+	if c.Grafana.URL != "" {
+		if !strings.HasPrefix(c.Grafana.URL, "http://") && !strings.HasPrefix(c.Grafana.URL, "https://") {
+			return fmt.Errorf("grafana url must be http or https: %s", c.Grafana.URL)
+		}
+		for _, event := range c.Grafana.Events {
+			if event != "mode_transition" && event != "breakout" && event != "risk_alert" {
+				return fmt.Errorf("unsupported grafana annotation event: %s", event)
+			}
+		}
+		if c.Grafana.Timeout < 0 || c.Grafana.QueueSize < 0 {
+			return fmt.Errorf("grafana timeout and queue size cannot be negative")
+		}
+	}
+
+	// Validate news calendar config
+	if c.News.URL != "" {
+		if !strings.HasPrefix(c.News.URL, "http://") && !strings.HasPrefix(c.News.URL, "https://") {
+			return fmt.Errorf("news calendar url must be http or https: %s", c.News.URL)
+		}
+		if c.News.MinImpact != "" && c.News.MinImpact != "low" && c.News.MinImpact != "medium" && c.News.MinImpact != "high" {
+			return fmt.Errorf("news min impact must be low, medium or high: %s", c.News.MinImpact)
+		}
+		if c.News.PollInterval < 0 || c.News.PauseBefore < 0 || c.News.ResumeAfter < 0 {
+			return fmt.Errorf("news calendar intervals cannot be negative")
+		}
+	}
+
+	// Validate exchange status config
+	if c.ExchangeStatus.URL != "" {
+		if !strings.HasPrefix(c.ExchangeStatus.URL, "http://") && !strings.HasPrefix(c.ExchangeStatus.URL, "https://") {
+			return fmt.Errorf("exchange status url must be http or https: %s", c.ExchangeStatus.URL)
+		}
+		if c.ExchangeStatus.PollInterval < 0 || c.ExchangeStatus.Timeout < 0 {
+			return fmt.Errorf("exchange status poll interval and timeout cannot be negative")
+		}
+	}
+
+	// Validate profit sweep config
+	if c.ProfitSweep.Enabled {
+		if c.Trading.MarketType == "spot" {
+			return fmt.Errorf("profit sweep moves profit out of the futures wallet and is not available on spot markets")
+		}
+		if c.ProfitSweep.Threshold <= 0 {
+			return fmt.Errorf("profit sweep threshold must be positive")
+		}
+		if c.ProfitSweep.TransferType != "" && c.ProfitSweep.TransferType != "UMFUTURE_MAIN" && c.ProfitSweep.TransferType != "UMFUTURE_FUNDING" {
+			return fmt.Errorf("invalid profit sweep transfer type: %s", c.ProfitSweep.TransferType)
+		}
+	}
+	if c.ProfitSweep.WorkingCapital < 0 || c.ProfitSweep.Threshold < 0 || c.ProfitSweep.CheckInterval < 0 || c.ProfitSweep.Timeout < 0 {
+		return fmt.Errorf("profit sweep values cannot be negative")
+	}
+	if c.ProfitSweep.URL != "" && !strings.HasPrefix(c.ProfitSweep.URL, "http://") && !strings.HasPrefix(c.ProfitSweep.URL, "https://") {
+		return fmt.Errorf("profit sweep url must be http or https: %s", c.ProfitSweep.URL)
+	}
+
+	// Validate email and daily report config
+	if c.Email.SMTPPort < 0 || c.Email.SMTPPort > 65535 {
+		return fmt.Errorf("invalid smtp port: %d", c.Email.SMTPPort)
+	}
+	if c.Email.Timeout < 0 {
+		return fmt.Errorf("email timeout cannot be negative")
+	}
+	if c.DailyReport.Enabled && (c.Email.SMTPHost == "" || c.Email.From == "" || len(c.Email.To) == 0) {
+		return fmt.Errorf("daily report requires an email smtp_host, from and to")
+	}
+	if c.DailyReport.Time != "" {
+		if _, err := time.Parse("15:04", c.DailyReport.Time); err != nil {
+			return fmt.Errorf("daily report time must be HH:MM: %s", c.DailyReport.Time)
+		}
+	}
+	if c.DailyReport.AlertLevel != "" && c.DailyReport.AlertLevel != "info" && c.DailyReport.AlertLevel != "warning" && c.DailyReport.AlertLevel != "critical" {
+		return fmt.Errorf("invalid daily report alert level: %s", c.DailyReport.AlertLevel)
+	}
+
+	// Validate alert rules; conditions are parsed when the bot starts
+	if c.Alerts.CheckInterval < 0 {
+		return fmt.Errorf("alerts check interval cannot be negative")
+	}
+	if c.Alerts.Email && len(c.Alerts.Rules) > 0 && (c.Email.SMTPHost == "" || c.Email.From == "" || len(c.Email.To) == 0) {
+		return fmt.Errorf("emailed alerts require an email smtp_host, from and to")
+	}
+	for _, rule := range c.Alerts.Rules {
+		if rule.Name == "" || rule.Condition == "" {
+			return fmt.Errorf("alert rules require a name and a condition")
+		}
+		if rule.Level != "" && rule.Level != "info" && rule.Level != "warning" && rule.Level != "critical" {
+			return fmt.Errorf("invalid level of alert rule %s: %s", rule.Name, rule.Level)
+		}
+		if rule.Cooldown < 0 {
+			return fmt.Errorf("cooldown of alert rule %s cannot be negative", rule.Name)
+		}
+	}
+
+	// Validate funding config
+	if c.Funding.URL != "" && !strings.HasPrefix(c.Funding.URL, "http://") && !strings.HasPrefix(c.Funding.URL, "https://") {
+		return fmt.Errorf("funding url must be http or https: %s", c.Funding.URL)
+	}
+	if c.Funding.PollInterval < 0 || c.Funding.Timeout < 0 || c.Funding.Interval < 0 || c.Funding.EntryBlackout < 0 ||
+		c.Funding.SettlementBlackout < 0 || c.Funding.ExpectedHolding < 0 {
+		return fmt.Errorf("funding intervals cannot be negative")
+	}
+	if c.Funding.Offset < 0 || (c.Funding.Interval > 0 && c.Funding.Offset >= c.Funding.Interval) {
+		return fmt.Errorf("funding offset must be between 0 and the funding interval")
+	}
+	if c.Funding.MaxCarryFraction < 0 {
+		return fmt.Errorf("funding max carry fraction cannot be negative")
+	}
+
+	// Validate basis config
+	if c.Basis.URL != "" && !strings.HasPrefix(c.Basis.URL, "http://") && !strings.HasPrefix(c.Basis.URL, "https://") {
+		return fmt.Errorf("basis url must be http or https: %s", c.Basis.URL)
+	}
+	if c.Basis.PollInterval < 0 || c.Basis.Timeout < 0 || c.Basis.MaxAge < 0 {
+		return fmt.Errorf("basis intervals cannot be negative")
+	}
+	if c.Basis.MaxBasis < 0 || c.Basis.BiasBasis < 0 || c.Basis.Smoothing < 0 {
+		return fmt.Errorf("basis thresholds and smoothing cannot be negative")
+	}
+
+	// Validate health config
+	if c.Health.CheckInterval < 0 || c.Health.MaxStreamLag < 0 || c.Health.GracePeriod < 0 || c.Health.MaxTickAge < 0 {
+		return fmt.Errorf("health intervals cannot be negative")
+	}
+	if c.Health.MaxGoroutines < 0 || c.Health.MaxMemoryMB < 0 {
+		return fmt.Errorf("health limits cannot be negative")
+	}
+	if c.Health.MaxBacklog < 0 || c.Health.MaxBacklog > 1 {
+		return fmt.Errorf("health max backlog must be between 0 and 1")
+	}
+	if c.Health.HTTPAddress != "" {
+		if _, _, err := net.SplitHostPort(c.Health.HTTPAddress); err != nil {
+			return fmt.Errorf("invalid health HTTP address %s: %w", c.Health.HTTPAddress, err)
+		}
+	}
+
+	// Validate screener config
+	if c.Screener.Timeout < 0 || c.Screener.Lookback < 0 || c.Screener.TopN < 0 {
+		return fmt.Errorf("screener timeout, lookback and top N cannot be negative")
+	}
+	if c.Screener.MinVolatility < 0 || c.Screener.MaxVolatility < 0 ||
+		(c.Screener.MaxVolatility > 0 && c.Screener.MaxVolatility <= c.Screener.MinVolatility) {
+		return fmt.Errorf("screener volatility band must satisfy 0 <= min < max")
+	}
+	if c.Screener.MaxADX < 0 || c.Screener.MaxADX > 100 {
+		return fmt.Errorf("screener max ADX must be between 0 and 100")
+	}
+	if c.Screener.MinQuoteVolume < 0 || c.Screener.MaxSpread < 0 {
+		return fmt.Errorf("screener volume and spread limits cannot be negative")
+	}
+
+	// Validate reconcile config
+	if c.Reconcile.RESTURL != "" && !strings.HasPrefix(c.Reconcile.RESTURL, "http://") && !strings.HasPrefix(c.Reconcile.RESTURL, "https://") {
+		return fmt.Errorf("reconcile rest url must be http or https: %s", c.Reconcile.RESTURL)
+	}
+	if c.Reconcile.Timeout < 0 || c.Reconcile.Lookback < 0 {
+		return fmt.Errorf("reconcile timeout and lookback cannot be negative")
+	}
+	if c.Reconcile.QuantityTolerance < 0 || c.Reconcile.FeeTolerance < 0 {
+		return fmt.Errorf("reconcile tolerances cannot be negative")
+	}
+
+	// Validate experiment config
+	if c.Experiment.Duration < 0 {
+		return fmt.Errorf("experiment duration cannot be negative")
+	}
+	if c.Experiment.Confidence < 0 || c.Experiment.Confidence >= 1 {
+		return fmt.Errorf("experiment confidence must be between 0 and 1")
+	}
+	if len(c.Experiment.Variants) > 0 {
+		if len(c.Experiment.Variants) != 2 {
+			return fmt.Errorf("an experiment compares exactly two variants, got %d", len(c.Experiment.Variants))
+		}
+		totalShare := 0.0
+		for _, variant := range c.Experiment.Variants {
+			if variant.Name == "" || variant.Profile == "" {
+				return fmt.Errorf("experiment variants need a name and a profile")
+			}
+			if variant.CapitalShare <= 0 || variant.CapitalShare > 1 {
+				return fmt.Errorf("experiment variant %s capital share must be between 0 and 1", variant.Name)
+			}
+			totalShare += variant.CapitalShare
+		}
+		if c.Experiment.Variants[0].Name == c.Experiment.Variants[1].Name {
+			return fmt.Errorf("experiment variants need distinct names")
+		}
+		if totalShare > 1+1e-9 {
+			return fmt.Errorf("experiment capital shares add up to more than the initial balance")
+		}
+	}
+
+	// Validate control config
+	if c.Control.Address != "" {
+		host, _, err := net.SplitHostPort(c.Control.Address)
+		if err != nil {
+			return fmt.Errorf("invalid control address %s: %w", c.Control.Address, err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("control address must be a loopback address: %s", c.Control.Address)
+		}
+	}
+	if c.Control.SocketMode != "" {
+		mode, err := strconv.ParseUint(c.Control.SocketMode, 8, 32)
+		if err != nil || mode > 0777 {
+			return fmt.Errorf("invalid control socket mode: %s", c.Control.SocketMode)
+		}
+		if mode&0007 != 0 {
+			return fmt.Errorf("control socket mode %s must not grant access to other users", c.Control.SocketMode)
+		}
+	}
+	if c.Control.CommandTimeout < 0 {
+		return fmt.Errorf("control command timeout cannot be negative")
 	}
 
 	return nil
@@ -17,6 +17,9 @@ const (
 	Timeframe1m  CandleTimeframe = "1m"
 )
 
+// DefaultBaseInterval is the feed update interval used when none is configured
+const DefaultBaseInterval = 300 * time.Millisecond
+
 // CandleAggregator aggregates high-frequency data into different timeframes
 type CandleAggregator struct {
 	// Data storage per symbol and timeframe
@@ -24,8 +27,27 @@ type CandleAggregator struct {
 	mu   sync.RWMutex
 
 	// Configuration
-	baseInterval time.Duration // Base interval (300ms in your case)
+	baseInterval time.Duration // Interval between feed updates the candles are built from
 	maxHistory  int           // Maximum candles to keep per timeframe
+
+	// Gap handling
+	gapConfig GapConfig
+	gaps      map[string]*GapState
+
+	// Aggregated trade flow per symbol; symbols in here take their volume from trades only
+	tradeFlows map[string]*TradeFlow
+
+	// Event-time bucketing
+	eventTime  EventTimeConfig
+	watermarks map[string]*WatermarkState
+}
+
+// TradeFlow tracks the aggregated trades of a symbol
+type TradeFlow struct {
+	Trades      int64   `json:"trades"`
+	Duplicates  int64   `json:"duplicates"` // Trades dropped because their ID was not newer than the last
+	LastTradeID int64   `json:"last_trade_id"`
+	CVD         float64 `json:"cvd"` // Cumulative volume delta: taker buys minus taker sells since tracking began
 }
 
 // TimeframeData stores candle data for a specific timeframe
@@ -36,21 +58,25 @@ type TimeframeData struct {
 	// Current incomplete candle being built
 	CurrentCandle *types.OHLCV
 	LastUpdateTime time.Time
+	// Candles still accepting late events, oldest first; the newest is CurrentCandle (event-time bucketing)
+	openCandles []*openCandle
 }
 
 // AggregatorConfig holds configuration for the candle aggregator
 type AggregatorConfig struct {
-	BaseInterval time.Duration            `json:"base_interval"`    // 300ms
+	BaseInterval time.Duration            `json:"base_interval"`    // Feed update interval (DefaultBaseInterval)
 	MaxHistory   int                      `json:"max_history"`     // Candles per timeframe
 	Timeframes   []CandleTimeframe        `json:"timeframes"`      // Which timeframes to generate
 	Symbols      []string                 `json:"symbols"`         // Symbols to track
+	Gaps         GapConfig                `json:"gaps"`            // Feed stall handling
+	EventTime    EventTimeConfig          `json:"event_time"`      // Bucket by exchange timestamp with allowed lateness
 }
 
 // NewCandleAggregator creates a new candle aggregator
 func NewCandleAggregator(config AggregatorConfig) *CandleAggregator {
 	// Set defaults
 	if config.BaseInterval == 0 {
-		config.BaseInterval = 300 * time.Millisecond
+		config.BaseInterval = DefaultBaseInterval
 	}
 	if config.MaxHistory == 0 {
 		config.MaxHistory = 200
@@ -58,11 +84,18 @@ func NewCandleAggregator(config AggregatorConfig) *CandleAggregator {
 	if len(config.Timeframes) == 0 {
 		config.Timeframes = []CandleTimeframe{Timeframe1s, Timeframe3s, Timeframe15s}
 	}
+	config.Gaps = config.Gaps.withDefaults()
+	config.EventTime = config.EventTime.withDefaults()
 
 	aggregator := &CandleAggregator{
 		data:        make(map[string]map[CandleTimeframe]*TimeframeData),
 		baseInterval: config.BaseInterval,
 		maxHistory:  config.MaxHistory,
+		gapConfig:   config.Gaps,
+		gaps:        make(map[string]*GapState),
+		tradeFlows:  make(map[string]*TradeFlow),
+		eventTime:   config.EventTime,
+		watermarks:  make(map[string]*WatermarkState),
 	}
 
 	// Initialize data structures for all symbols and timeframes
@@ -81,6 +114,11 @@ func NewCandleAggregator(config AggregatorConfig) *CandleAggregator {
 	return aggregator
 }
 
+// BaseInterval returns the interval between feed updates
+func (ca *CandleAggregator) BaseInterval() time.Duration {
+	return ca.baseInterval
+}
+
 // AddTick adds a single tick/price update and updates all timeframes
 func (ca *CandleAggregator) AddTick(ticker types.Ticker) {
 	ca.mu.Lock()
@@ -93,11 +131,90 @@ func (ca *CandleAggregator) AddTick(ticker types.Ticker) {
 		symbolData = ca.data[ticker.Symbol]
 	}
 
+	ca.trackGap(ticker.Symbol, ticker.Timestamp)
+
+	// Volume of trade-fed symbols comes from their trades, so ticks would count it twice
+	if _, trades := ca.tradeFlows[ticker.Symbol]; trades {
+		ticker.Volume = 0
+	}
+
+	if ca.eventTime.Enabled {
+		ca.addEventByEventTime(symbolData, ticker, 0, 0)
+		return
+	}
 	for _, tfData := range symbolData {
 		ca.updateTimeframe(tfData, ticker)
 	}
 }
 
+// AddAggTrade adds an aggregated trade to all timeframes, splitting candle volume into taker buys and sells.
+// Once a symbol receives trades its ticks only move prices. Returns false for a trade whose ID was already seen.
+func (ca *CandleAggregator) AddAggTrade(trade types.AggTrade) bool {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	flow, exists := ca.tradeFlows[trade.Symbol]
+	if !exists {
+		flow = &TradeFlow{}
+		ca.tradeFlows[trade.Symbol] = flow
+	}
+	if trade.AggTradeID != 0 && trade.AggTradeID <= flow.LastTradeID {
+		flow.Duplicates++
+		return false
+	}
+
+	symbolData, exists := ca.data[trade.Symbol]
+	if !exists {
+		// Initialize symbol if not exists
+		ca.data[trade.Symbol] = make(map[CandleTimeframe]*TimeframeData)
+		symbolData = ca.data[trade.Symbol]
+	}
+
+	ca.trackGap(trade.Symbol, trade.Timestamp)
+
+	buyVolume, sellVolume := 0.0, 0.0
+	if trade.IsTakerBuy() {
+		buyVolume = trade.Quantity
+	} else {
+		sellVolume = trade.Quantity
+	}
+
+	ticker := types.Ticker{
+		Symbol:    trade.Symbol,
+		Timestamp: trade.Timestamp,
+		Price:     trade.Price,
+		Volume:    trade.Quantity,
+	}
+	if ca.eventTime.Enabled {
+		ca.addEventByEventTime(symbolData, ticker, buyVolume, sellVolume)
+	} else {
+		for _, tfData := range symbolData {
+			ca.updateTimeframe(tfData, ticker)
+			tfData.CurrentCandle.BuyVolume += buyVolume
+			tfData.CurrentCandle.SellVolume += sellVolume
+		}
+	}
+
+	flow.Trades++
+	if trade.AggTradeID != 0 {
+		flow.LastTradeID = trade.AggTradeID
+	}
+	flow.CVD += buyVolume - sellVolume
+	return true
+}
+
+// GetTradeFlow returns the aggregated trade flow of a symbol, or false if it received no trades
+func (ca *CandleAggregator) GetTradeFlow(symbol string) (TradeFlow, bool) {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	flow, exists := ca.tradeFlows[symbol]
+	if !exists {
+		return TradeFlow{}, false
+	}
+	return *flow, true
+}
+
 // AddCandle adds a complete OHLCV candle and updates all timeframes
 func (ca *CandleAggregator) AddCandle(candle types.OHLCV) {
 	ca.mu.Lock()
@@ -137,11 +254,14 @@ func (ca *CandleAggregator) updateTimeframe(tfData *TimeframeData, ticker types.
 	// Check if we need to close the current candle and start a new one
 	candleEndTime := tfData.CurrentCandle.Timestamp.Add(tfData.Interval)
 	if now.After(candleEndTime) || now.Equal(candleEndTime) {
-		// Close current candle
+		// Close current candle, filling any intervals the feed skipped
+		candle := tfData.CurrentCandle
+		lastClose := candle.Close
 		ca.closeCurrentCandle(tfData)
+		ca.fillGap(tfData, ticker.Symbol, candleEndTime, ca.alignTimeToTimeframe(now, tfData.Interval), lastClose)
 
-		// Start new candle
-		tfData.CurrentCandle = &types.OHLCV{
+		// Start new candle, reusing the closed one's struct since history holds a copy
+		*candle = types.OHLCV{
 			Symbol:    ticker.Symbol,
 			Timestamp: ca.alignTimeToTimeframe(now, tfData.Interval),
 			Open:      ticker.Price,
@@ -150,6 +270,7 @@ func (ca *CandleAggregator) updateTimeframe(tfData *TimeframeData, ticker types.
 			Close:     ticker.Price,
 			Volume:    ticker.Volume,
 		}
+		tfData.CurrentCandle = candle
 	} else {
 		// Update current candle
 		ca.updateCurrentCandle(tfData.CurrentCandle, ticker)
@@ -326,6 +447,9 @@ func (ca *CandleAggregator) RemoveSymbol(symbol string) {
 	defer ca.mu.Unlock()
 
 	delete(ca.data, symbol)
+	delete(ca.gaps, symbol)
+	delete(ca.tradeFlows, symbol)
+	delete(ca.watermarks, symbol)
 }
 
 // Clear removes all data
@@ -334,6 +458,9 @@ func (ca *CandleAggregator) Clear() {
 	defer ca.mu.Unlock()
 
 	ca.data = make(map[string]map[CandleTimeframe]*TimeframeData)
+	ca.gaps = make(map[string]*GapState)
+	ca.tradeFlows = make(map[string]*TradeFlow)
+	ca.watermarks = make(map[string]*WatermarkState)
 }
 
 // GetStats returns statistics about the aggregator
@@ -358,6 +485,28 @@ func (ca *CandleAggregator) GetStats() map[string]interface{} {
 	}
 	stats["symbols"] = symbolStats
 
+	gapStats := make(map[string]interface{})
+	for symbol, state := range ca.gaps {
+		gapStats[symbol] = *state
+	}
+	stats["gap_policy"] = ca.gapConfig.Policy
+	stats["gaps"] = gapStats
+
+	flowStats := make(map[string]interface{})
+	for symbol, flow := range ca.tradeFlows {
+		flowStats[symbol] = *flow
+	}
+	stats["trade_flows"] = flowStats
+
+	if ca.eventTime.Enabled {
+		watermarkStats := make(map[string]interface{})
+		for symbol, state := range ca.watermarks {
+			watermarkStats[symbol] = *state
+		}
+		stats["allowed_lateness"] = ca.eventTime.AllowedLateness.String()
+		stats["watermarks"] = watermarkStats
+	}
+
 	return stats
 }
 
@@ -0,0 +1,169 @@
+package data
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// CandlePathModel selects how a replayed candle's price moves between its open and close
+type CandlePathModel string
+
+const (
+	CandlePathOHLC     CandlePathModel = "ohlc"     // Straight legs open, nearer extreme, farther extreme, close
+	CandlePathBrownian CandlePathModel = "brownian" // Brownian bridges through the same extremes, within the candle's range
+)
+
+// IsValid returns true for a known path model
+func (m CandlePathModel) IsValid() bool {
+	return m == CandlePathOHLC || m == CandlePathBrownian
+}
+
+// CandlePathConfig holds configuration for intra-candle price paths
+type CandlePathConfig struct {
+	Model    CandlePathModel `json:"model"`    // "ohlc" or "brownian" (ohlc)
+	Steps    int             `json:"steps"`    // Prices per candle, including open and close (20)
+	Interval time.Duration   `json:"interval"` // Candle interval the path's ticks are spread over (1m)
+	Seed     int64           `json:"seed"`     // Brownian seed, mixed with the candle time so replays repeat (1)
+}
+
+// CandlePath turns replayed candles into tick sequences, so resting orders see every price a candle
+// traded through in a plausible order instead of only its close
+type CandlePath struct {
+	config CandlePathConfig
+}
+
+// NewCandlePath creates a candle path generator
+func NewCandlePath(config CandlePathConfig) *CandlePath {
+	if config.Model == "" {
+		config.Model = CandlePathOHLC // default
+	}
+	if config.Steps == 0 {
+		config.Steps = 20 // default
+	}
+	if config.Steps < 4 {
+		config.Steps = 4 // open, both extremes and close
+	}
+	if config.Interval == 0 {
+		config.Interval = time.Minute // default
+	}
+	if config.Seed == 0 {
+		config.Seed = 1 // default
+	}
+	return &CandlePath{config: config}
+}
+
+// Model returns the path model
+func (p *CandlePath) Model() CandlePathModel {
+	return p.config.Model
+}
+
+// Ticks returns the candle as ticks from open to close. The extreme nearer the open is visited first,
+// so a stop and a take-profit both inside the range trigger in the order the market most likely hit them.
+// Both extremes are reached exactly; the candle's volume is spread evenly over the ticks.
+func (p *CandlePath) Ticks(candle types.OHLCV) ([]types.Ticker, error) {
+	if candle.High < candle.Low || candle.Open > candle.High || candle.Open < candle.Low ||
+		candle.Close > candle.High || candle.Close < candle.Low {
+		return nil, fmt.Errorf("invalid %s candle at %s: open and close must lie within low and high",
+			candle.Symbol, candle.Timestamp.Format(time.RFC3339))
+	}
+
+	first, second := candle.High, candle.Low
+	if candle.Open-candle.Low < candle.High-candle.Open {
+		first, second = candle.Low, candle.High
+	}
+	anchors := []float64{candle.Open, first, second, candle.Close}
+
+	var prices []float64
+	if p.config.Model == CandlePathBrownian {
+		rng := rand.New(rand.NewSource(p.config.Seed ^ candle.Timestamp.UnixNano()))
+		prices = bridgePath(anchors, p.config.Steps, candle.Low, candle.High, rng)
+	} else {
+		prices = linearPath(anchors, p.config.Steps)
+	}
+
+	ticks := make([]types.Ticker, len(prices))
+	step := p.config.Interval / time.Duration(len(prices))
+	volume := candle.Volume / float64(len(prices))
+	for i, price := range prices {
+		ticks[i] = types.Ticker{
+			Symbol:    candle.Symbol,
+			Timestamp: candle.Timestamp.Add(time.Duration(i) * step),
+			Price:     price,
+			Volume:    volume,
+		}
+	}
+	return ticks, nil
+}
+
+// legSteps splits steps-1 moves over the legs between anchors in proportion to their length, giving
+// every leg at least one move; steps must be at least the number of anchors
+func legSteps(anchors []float64, steps int) []int {
+	legs := len(anchors) - 1
+	moves := steps - 1
+	total := 0.0
+	for i := 0; i < legs; i++ {
+		total += math.Abs(anchors[i+1] - anchors[i])
+	}
+
+	counts := make([]int, legs)
+	assigned := 0
+	for i := range counts {
+		counts[i] = 1
+		if total > 0 {
+			counts[i] = int(float64(moves-legs)*math.Abs(anchors[i+1]-anchors[i])/total) + 1
+		}
+		assigned += counts[i]
+	}
+	// Rounding leftovers go to the longest leg
+	longest := 0
+	for i := range counts {
+		if math.Abs(anchors[i+1]-anchors[i]) > math.Abs(anchors[longest+1]-anchors[longest]) {
+			longest = i
+		}
+	}
+	counts[longest] += moves - assigned
+	return counts
+}
+
+// linearPath walks straight lines between the anchors
+func linearPath(anchors []float64, steps int) []float64 {
+	prices := []float64{anchors[0]}
+	for i, count := range legSteps(anchors, steps) {
+		from, to := anchors[i], anchors[i+1]
+		for j := 1; j <= count; j++ {
+			prices = append(prices, from+(to-from)*float64(j)/float64(count))
+		}
+	}
+	return prices
+}
+
+// bridgePath joins the anchors with Brownian bridges, clamped to [low, high] so the candle's extremes hold.
+// The first leg stays on its side of the open, so the farther extreme is not touched before the nearer one.
+func bridgePath(anchors []float64, steps int, low, high float64, rng *rand.Rand) []float64 {
+	scale := (high - low) / 4
+	prices := []float64{anchors[0]}
+	for i, count := range legSteps(anchors, steps) {
+		from, to := anchors[i], anchors[i+1]
+		floor, ceiling := low, high
+		if i == 0 {
+			floor, ceiling = math.Max(low, math.Min(from, to)), math.Min(high, math.Max(from, to))
+		}
+
+		// A random walk with its drift removed so it ends where it starts: a standard Brownian bridge
+		walk := make([]float64, count+1)
+		for j := 1; j <= count; j++ {
+			walk[j] = walk[j-1] + rng.NormFloat64()/math.Sqrt(float64(count))
+		}
+		for j := 1; j <= count; j++ {
+			t := float64(j) / float64(count)
+			bridge := walk[j] - t*walk[count]
+			price := from + (to-from)*t + bridge*scale
+			prices = append(prices, math.Min(ceiling, math.Max(floor, price)))
+		}
+		prices[len(prices)-1] = to
+	}
+	return prices
+}
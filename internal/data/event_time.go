@@ -0,0 +1,180 @@
+package data
+
+import (
+	"aibot/internal/types"
+	"sort"
+	"time"
+)
+
+// EventTimeConfig holds configuration for bucketing ticks into candles by exchange timestamp. Without it
+// candles follow arrival order: a tick delivered after a newer one lands in the newer candle. With it a
+// candle stays open until the watermark, the newest event time seen minus the allowed lateness, passes its
+// end, so candles match the exchange's klines even when the feed reorders events.
+type EventTimeConfig struct {
+	Enabled         bool          `json:"enabled"`
+	AllowedLateness time.Duration `json:"allowed_lateness"` // How long after its end a candle accepts out-of-order events (500ms)
+}
+
+// withDefaults fills zero values with defaults
+func (c EventTimeConfig) withDefaults() EventTimeConfig {
+	if c.AllowedLateness == 0 {
+		c.AllowedLateness = 500 * time.Millisecond // default
+	}
+	return c
+}
+
+// WatermarkState describes the event-time progress of a symbol
+type WatermarkState struct {
+	MaxEventTime time.Time `json:"max_event_time"` // Newest event time seen
+	Watermark    time.Time `json:"watermark"`      // Candles ending at or before this are final
+	Reordered    int64     `json:"reordered"`      // Events older than the newest one, still placed in their candle
+	Late         int64     `json:"late"`           // Events dropped from candles that were already final
+}
+
+// openCandle is a candle still accepting events, with the event times its open and close prices came from
+type openCandle struct {
+	candle     types.OHLCV
+	firstEvent time.Time
+	lastEvent  time.Time
+}
+
+// watermark returns the watermark state of a symbol; caller must hold ca.mu
+func (ca *CandleAggregator) watermark(symbol string) *WatermarkState {
+	state, exists := ca.watermarks[symbol]
+	if !exists {
+		state = &WatermarkState{}
+		ca.watermarks[symbol] = state
+	}
+	return state
+}
+
+// addEventByEventTime adds a tick, with the taker buy and sell volume of a trade, to the candles of every
+// timeframe its timestamp falls in and advances the watermark; caller must hold ca.mu
+func (ca *CandleAggregator) addEventByEventTime(symbolData map[CandleTimeframe]*TimeframeData, ticker types.Ticker, buyVolume, sellVolume float64) {
+	state := ca.watermark(ticker.Symbol)
+	late := false
+	for _, tfData := range symbolData {
+		candle := ca.updateTimeframeByEventTime(tfData, ticker, state.Watermark)
+		if candle == nil {
+			late = true
+			continue
+		}
+		candle.BuyVolume += buyVolume
+		candle.SellVolume += sellVolume
+	}
+
+	switch {
+	case late:
+		state.Late++
+	case ticker.Timestamp.Before(state.MaxEventTime):
+		state.Reordered++
+	}
+	ca.advanceWatermark(ticker.Symbol, symbolData, ticker.Timestamp)
+}
+
+// updateTimeframeByEventTime places a tick in the candle its timestamp falls in and returns that candle,
+// or nil if the candle is already final; caller must hold ca.mu
+func (ca *CandleAggregator) updateTimeframeByEventTime(tfData *TimeframeData, ticker types.Ticker, watermark time.Time) *types.OHLCV {
+	eventTime := ticker.Timestamp
+	start := ca.alignTimeToTimeframe(eventTime, tfData.Interval)
+	if !watermark.IsZero() && !start.Add(tfData.Interval).After(watermark) {
+		return nil
+	}
+
+	index := sort.Search(len(tfData.openCandles), func(i int) bool {
+		return !tfData.openCandles[i].candle.Timestamp.Before(start)
+	})
+	if index < len(tfData.openCandles) && tfData.openCandles[index].candle.Timestamp.Equal(start) {
+		open := tfData.openCandles[index]
+		candle := &open.candle
+		candle.High = max(candle.High, ticker.Price)
+		candle.Low = min(candle.Low, ticker.Price)
+		candle.Volume += ticker.Volume
+		if eventTime.Before(open.firstEvent) {
+			candle.Open = ticker.Price
+			open.firstEvent = eventTime
+		}
+		if !eventTime.Before(open.lastEvent) {
+			candle.Close = ticker.Price
+			open.lastEvent = eventTime
+		}
+		ca.refreshCurrentCandle(tfData)
+		return candle
+	}
+
+	open := &openCandle{
+		candle: types.OHLCV{
+			Symbol:    ticker.Symbol,
+			Timestamp: start,
+			Open:      ticker.Price,
+			High:      ticker.Price,
+			Low:       ticker.Price,
+			Close:     ticker.Price,
+			Volume:    ticker.Volume,
+		},
+		firstEvent: eventTime,
+		lastEvent:  eventTime,
+	}
+	tfData.openCandles = append(tfData.openCandles, nil)
+	copy(tfData.openCandles[index+1:], tfData.openCandles[index:])
+	tfData.openCandles[index] = open
+	ca.refreshCurrentCandle(tfData)
+	return &open.candle
+}
+
+// advanceWatermark moves a symbol's watermark with the event time of a tick that was placed and closes the
+// candles that ended at or before it; caller must hold ca.mu
+func (ca *CandleAggregator) advanceWatermark(symbol string, symbolData map[CandleTimeframe]*TimeframeData, eventTime time.Time) {
+	state := ca.watermark(symbol)
+	if !eventTime.After(state.MaxEventTime) {
+		return
+	}
+	state.MaxEventTime = eventTime
+	state.Watermark = eventTime.Add(-ca.eventTime.AllowedLateness)
+
+	for _, tfData := range symbolData {
+		closed := 0
+		for _, open := range tfData.openCandles {
+			if open.candle.Timestamp.Add(tfData.Interval).After(state.Watermark) {
+				break
+			}
+			if count := len(tfData.Candles); count > 0 {
+				last := tfData.Candles[count-1]
+				ca.fillGap(tfData, symbol, last.Timestamp.Add(tfData.Interval), open.candle.Timestamp, last.Close)
+			}
+			ca.addCandleToHistory(tfData, open.candle)
+			closed++
+		}
+		if closed > 0 {
+			tfData.openCandles = append(tfData.openCandles[:0], tfData.openCandles[closed:]...)
+			ca.refreshCurrentCandle(tfData)
+		}
+		tfData.LastUpdateTime = eventTime
+	}
+}
+
+// refreshCurrentCandle points the current candle at the newest open candle; caller must hold ca.mu
+func (ca *CandleAggregator) refreshCurrentCandle(tfData *TimeframeData) {
+	if len(tfData.openCandles) == 0 {
+		tfData.CurrentCandle = nil
+		return
+	}
+	tfData.CurrentCandle = &tfData.openCandles[len(tfData.openCandles)-1].candle
+}
+
+// EventTime returns the event-time bucketing configuration in effect
+func (ca *CandleAggregator) EventTime() EventTimeConfig {
+	return ca.eventTime
+}
+
+// GetWatermark returns the event-time progress of a symbol, or false without event-time bucketing or events
+func (ca *CandleAggregator) GetWatermark(symbol string) (WatermarkState, bool) {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	state, exists := ca.watermarks[symbol]
+	if !exists {
+		return WatermarkState{}, false
+	}
+	return *state, true
+}
@@ -0,0 +1,135 @@
+package data
+
+import (
+	"aibot/internal/types"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Candle export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// exportTimestampFormat is a CSV timestamp layout the CSV data source reads back, so exports can be replayed
+const exportTimestampFormat = "2006-01-02T15:04:05.000Z"
+
+// CandleQuery selects the in-memory candles of a symbol and timeframe to export
+type CandleQuery struct {
+	Symbol         string          `json:"symbol"`
+	Timeframe      CandleTimeframe `json:"timeframe"`
+	Start          time.Time       `json:"start,omitempty"`           // Candles opened at or after (zero: oldest kept)
+	End            time.Time       `json:"end,omitempty"`             // Candles opened at or before (zero: latest)
+	Limit          int             `json:"limit,omitempty"`           // Most recent candles of the window (0: all)
+	IncludeCurrent bool            `json:"include_current,omitempty"` // Append the candle still being built
+}
+
+// Validate checks the query
+func (q CandleQuery) Validate() error {
+	if q.Symbol == "" {
+		return fmt.Errorf("candle query requires a symbol")
+	}
+	if q.Timeframe == "" {
+		return fmt.Errorf("candle query requires a timeframe")
+	}
+	if q.Limit < 0 {
+		return fmt.Errorf("candle limit cannot be negative, got %d", q.Limit)
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && q.End.Before(q.Start) {
+		return fmt.Errorf("candle window end %s is before start %s", q.End.Format(time.RFC3339), q.Start.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// QueryCandles returns the candles the aggregator holds for the query, oldest first
+func (ca *CandleAggregator) QueryCandles(query CandleQuery) ([]types.OHLCV, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	symbolData, exists := ca.data[query.Symbol]
+	if !exists {
+		return nil, fmt.Errorf("no candles for symbol %s", query.Symbol)
+	}
+	tfData, exists := symbolData[query.Timeframe]
+	if !exists {
+		return nil, fmt.Errorf("timeframe %s is not aggregated for %s", query.Timeframe, query.Symbol)
+	}
+
+	inWindow := func(candle types.OHLCV) bool {
+		return (query.Start.IsZero() || !candle.Timestamp.Before(query.Start)) &&
+			(query.End.IsZero() || !candle.Timestamp.After(query.End))
+	}
+	candles := make([]types.OHLCV, 0, len(tfData.Candles)+1)
+	for _, candle := range tfData.Candles {
+		if inWindow(candle) {
+			candles = append(candles, candle)
+		}
+	}
+	if query.IncludeCurrent && tfData.CurrentCandle != nil && inWindow(*tfData.CurrentCandle) {
+		candles = append(candles, *tfData.CurrentCandle)
+	}
+
+	if query.Limit > 0 && len(candles) > query.Limit {
+		candles = candles[len(candles)-query.Limit:]
+	}
+	return candles, nil
+}
+
+// ExportCandles writes candles as CSV (the layout the CSV data source reads) or as a JSON array
+func ExportCandles(w io.Writer, candles []types.OHLCV, format string) error {
+	switch format {
+	case ExportFormatCSV, "":
+		return writeCandleCSV(w, candles)
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(candles); err != nil {
+			return fmt.Errorf("failed to encode candles: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// writeCandleCSV writes a header and one row per candle
+func writeCandleCSV(w io.Writer, candles []types.OHLCV) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"timestamp", "open", "high", "low", "close", "volume", "synthetic", "buy_volume", "sell_volume"}); err != nil {
+		return fmt.Errorf("failed to write candles: %w", err)
+	}
+
+	format := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	for _, candle := range candles {
+		record := []string{
+			candle.Timestamp.UTC().Format(exportTimestampFormat),
+			format(candle.Open),
+			format(candle.High),
+			format(candle.Low),
+			format(candle.Close),
+			format(candle.Volume),
+			strconv.FormatBool(candle.Synthetic),
+			format(candle.BuyVolume),
+			format(candle.SellVolume),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write candles: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write candles: %w", err)
+	}
+	return nil
+}
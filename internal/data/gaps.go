@@ -0,0 +1,123 @@
+package data
+
+import (
+	"aibot/internal/types"
+	"time"
+)
+
+// Gap handling policies
+const (
+	GapPolicyFill = "fill" // Emit flagged carry-forward candles for missing intervals
+	GapPolicyMark = "mark" // Leave the hole and flag the symbol until data has recovered
+)
+
+// GapConfig holds configuration for feed stall detection
+type GapConfig struct {
+	Policy         string        `json:"policy"`          // "fill" or "mark" (mark)
+	StallTimeout   time.Duration `json:"stall_timeout"`   // Silence after which the feed counts as stalled (5s)
+	MaxFill        time.Duration `json:"max_fill"`        // Longest gap filled with synthetic candles; longer gaps are marked (1m)
+	RecoveryPeriod time.Duration `json:"recovery_period"` // Continuous data required after a gap before trading resumes (30s)
+}
+
+// withDefaults fills zero values with defaults
+func (c GapConfig) withDefaults() GapConfig {
+	if c.Policy == "" {
+		c.Policy = GapPolicyMark // default
+	}
+	if c.StallTimeout == 0 {
+		c.StallTimeout = 5 * time.Second // default
+	}
+	if c.MaxFill == 0 {
+		c.MaxFill = time.Minute // default
+	}
+	if c.RecoveryPeriod == 0 {
+		c.RecoveryPeriod = 30 * time.Second // default
+	}
+	return c
+}
+
+// GapState describes feed gaps for a symbol
+type GapState struct {
+	Active           bool          `json:"active"`     // Trading must wait until the data has recovered
+	Since            time.Time     `json:"since"`      // Last tick before the most recent gap
+	ResumedAt        time.Time     `json:"resumed_at"` // First tick after the most recent gap
+	LastTick         time.Time     `json:"last_tick"`
+	Gaps             int64         `json:"gaps"`
+	LongestGap       time.Duration `json:"longest_gap"`
+	SyntheticCandles int64         `json:"synthetic_candles"`
+}
+
+// trackGap records a tick time and detects stalls; caller must hold ca.mu
+func (ca *CandleAggregator) trackGap(symbol string, timestamp time.Time) {
+	state, exists := ca.gaps[symbol]
+	if !exists {
+		state = &GapState{}
+		ca.gaps[symbol] = state
+	}
+
+	if !state.LastTick.IsZero() {
+		if gap := timestamp.Sub(state.LastTick); gap > ca.gapConfig.StallTimeout {
+			state.Gaps++
+			state.Since = state.LastTick
+			state.ResumedAt = timestamp
+			if gap > state.LongestGap {
+				state.LongestGap = gap
+			}
+			// Filled gaps keep windows intact; anything else leaves a hole strategies must wait out
+			state.Active = ca.gapConfig.Policy != GapPolicyFill || gap > ca.gapConfig.MaxFill
+		}
+	}
+
+	if timestamp.After(state.LastTick) {
+		state.LastTick = timestamp
+	}
+	if state.Active && timestamp.Sub(state.ResumedAt) >= ca.gapConfig.RecoveryPeriod {
+		state.Active = false
+	}
+}
+
+// fillGap adds synthetic candles for intervals in [from, to) that received no ticks; caller must hold ca.mu
+func (ca *CandleAggregator) fillGap(tfData *TimeframeData, symbol string, from, to time.Time, lastClose float64) {
+	if ca.gapConfig.Policy != GapPolicyFill || !to.After(from) || to.Sub(from) > ca.gapConfig.MaxFill {
+		return
+	}
+
+	for t := from; t.Before(to); t = t.Add(tfData.Interval) {
+		ca.addCandleToHistory(tfData, types.OHLCV{
+			Symbol:    symbol,
+			Timestamp: t,
+			Open:      lastClose,
+			High:      lastClose,
+			Low:       lastClose,
+			Close:     lastClose,
+			Synthetic: true,
+		})
+		if state, exists := ca.gaps[symbol]; exists {
+			state.SyntheticCandles++
+		}
+	}
+}
+
+// HasDataGap returns true while a symbol's feed is stalled or recovering from a gap that was not filled
+func (ca *CandleAggregator) HasDataGap(symbol string, now time.Time) bool {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	state, exists := ca.gaps[symbol]
+	if !exists {
+		return false
+	}
+	return state.Active || now.Sub(state.LastTick) > ca.gapConfig.StallTimeout
+}
+
+// GetGapState returns the gap state for a symbol
+func (ca *CandleAggregator) GetGapState(symbol string) (GapState, bool) {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	state, exists := ca.gaps[symbol]
+	if !exists {
+		return GapState{}, false
+	}
+	return *state, true
+}
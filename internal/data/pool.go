@@ -0,0 +1,110 @@
+package data
+
+import (
+	"aibot/internal/types"
+	"sync"
+	"sync/atomic"
+)
+
+// Pools recycle the market data structs handed from the stream receiver to data processing, so a feed
+// ticking every few hundred milliseconds across many symbols does not allocate one per update
+var (
+	tickerPool   = newPool(func() interface{} { return new(types.Ticker) })
+	candlePool   = newPool(func() interface{} { return new(types.OHLCV) })
+	aggTradePool = newPool(func() interface{} { return new(types.AggTrade) })
+)
+
+// pool is a sync.Pool that counts how often it had to allocate
+type pool struct {
+	pool      sync.Pool
+	acquired  atomic.Int64
+	allocated atomic.Int64
+}
+
+// newPool creates a pool whose items are made by create
+func newPool(create func() interface{}) *pool {
+	p := &pool{}
+	p.pool.New = func() interface{} {
+		p.allocated.Add(1)
+		return create()
+	}
+	return p
+}
+
+// get takes an item from the pool
+func (p *pool) get() interface{} {
+	p.acquired.Add(1)
+	return p.pool.Get()
+}
+
+// stats returns how many items were acquired and how many of those had to be allocated
+func (p *pool) stats() map[string]interface{} {
+	acquired := p.acquired.Load()
+	allocated := p.allocated.Load()
+	reuseRate := 0.0
+	if acquired > 0 {
+		reuseRate = float64(acquired-allocated) / float64(acquired)
+	}
+	return map[string]interface{}{
+		"acquired":   acquired,
+		"allocated":  allocated,
+		"reuse_rate": reuseRate,
+	}
+}
+
+// AcquireTicker returns a pooled copy of a ticker; hand it back with ReleaseTicker once nothing references it
+func AcquireTicker(ticker types.Ticker) *types.Ticker {
+	pooled := tickerPool.get().(*types.Ticker)
+	*pooled = ticker
+	return pooled
+}
+
+// ReleaseTicker returns a ticker to the pool; nil is ignored
+func ReleaseTicker(ticker *types.Ticker) {
+	if ticker == nil {
+		return
+	}
+	*ticker = types.Ticker{}
+	tickerPool.pool.Put(ticker)
+}
+
+// AcquireCandle returns a pooled copy of a candle; hand it back with ReleaseCandle once nothing references it
+func AcquireCandle(candle types.OHLCV) *types.OHLCV {
+	pooled := candlePool.get().(*types.OHLCV)
+	*pooled = candle
+	return pooled
+}
+
+// ReleaseCandle returns a candle to the pool; nil is ignored
+func ReleaseCandle(candle *types.OHLCV) {
+	if candle == nil {
+		return
+	}
+	*candle = types.OHLCV{}
+	candlePool.pool.Put(candle)
+}
+
+// AcquireAggTrade returns a pooled copy of a trade; hand it back with ReleaseAggTrade once nothing references it
+func AcquireAggTrade(trade types.AggTrade) *types.AggTrade {
+	pooled := aggTradePool.get().(*types.AggTrade)
+	*pooled = trade
+	return pooled
+}
+
+// ReleaseAggTrade returns a trade to the pool; nil is ignored
+func ReleaseAggTrade(trade *types.AggTrade) {
+	if trade == nil {
+		return
+	}
+	*trade = types.AggTrade{}
+	aggTradePool.pool.Put(trade)
+}
+
+// GetPoolStats returns acquisition and allocation counts per pool
+func GetPoolStats() map[string]interface{} {
+	return map[string]interface{}{
+		"tickers":    tickerPool.stats(),
+		"candles":    candlePool.stats(),
+		"agg_trades": aggTradePool.stats(),
+	}
+}
@@ -0,0 +1,423 @@
+package data
+
+import (
+	"aibot/internal/types"
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// DataSource loads historical candles for backtest replays
+type DataSource interface {
+	// LoadCandles returns candles for symbol within [start, end] sorted by time; zero times leave a side unbounded
+	LoadCandles(symbol string, start, end time.Time) ([]types.OHLCV, error)
+
+	// Name returns the data format handled by the source
+	Name() string
+}
+
+// Supported historical data formats
+const (
+	FormatCSV        = "csv"
+	FormatBinanceZip = "binance_zip"
+	FormatParquet    = "parquet"
+)
+
+// DataSourceConfig holds configuration for historical data sources
+type DataSourceConfig struct {
+	Format    string `json:"format"`    // "csv", "binance_zip", "parquet"
+	Directory string `json:"directory"` // Directory holding the data files
+	Timeframe string `json:"timeframe"` // Kline interval used in file names ("1m")
+}
+
+// csvTimestampFormats are the timestamp layouts accepted in CSV files
+var csvTimestampFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05.000Z",
+	"2006/01/02 15:04:05",
+}
+
+// parquetColumns are the candle columns of Parquet files, in OHLCV order
+var parquetColumns = []string{"timestamp", "open", "high", "low", "close", "volume"}
+
+// NewDataSource creates the data source for the configured format
+func NewDataSource(config DataSourceConfig) (DataSource, error) {
+	if config.Format == "" {
+		config.Format = FormatCSV // default
+	}
+	if config.Directory == "" {
+		config.Directory = "./data" // default
+	}
+	if config.Timeframe == "" {
+		config.Timeframe = "1m" // default
+	}
+
+	switch config.Format {
+	case FormatCSV:
+		return &CSVDataSource{config: config}, nil
+	case FormatBinanceZip:
+		return &BinanceKlineSource{config: config}, nil
+	case FormatParquet:
+		return &ParquetDataSource{config: config}, nil
+	default:
+		return nil, fmt.Errorf("unsupported data format: %s", config.Format)
+	}
+}
+
+// CSVDataSource reads timestamp,open,high,low,close,volume CSV files
+type CSVDataSource struct {
+	config DataSourceConfig
+}
+
+// Name returns the data format handled by the source
+func (s *CSVDataSource) Name() string {
+	return FormatCSV
+}
+
+// LoadCandles reads the symbol's CSV file, trying SYMBOL.csv, symbol.csv and SYMBOL_<timeframe>.csv
+func (s *CSVDataSource) LoadCandles(symbol string, start, end time.Time) ([]types.OHLCV, error) {
+	candidates := []string{
+		symbol + ".csv",
+		strings.ToLower(symbol) + ".csv",
+		symbol + "_" + s.config.Timeframe + ".csv",
+	}
+
+	for _, name := range candidates {
+		path := filepath.Join(s.config.Directory, name)
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+
+		candles, err := readCandleCSV(file, symbol, start, end, parseCSVTimestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return candles, nil
+	}
+
+	return nil, fmt.Errorf("no CSV data for %s in %s", symbol, s.config.Directory)
+}
+
+// BinanceKlineSource reads Binance public monthly kline dumps (SYMBOL-1m-2024-01.zip) without unpacking them
+type BinanceKlineSource struct {
+	config DataSourceConfig
+}
+
+// Name returns the data format handled by the source
+func (s *BinanceKlineSource) Name() string {
+	return FormatBinanceZip
+}
+
+// LoadCandles reads every monthly archive overlapping [start, end]
+func (s *BinanceKlineSource) LoadCandles(symbol string, start, end time.Time) ([]types.OHLCV, error) {
+	pattern := filepath.Join(s.config.Directory, fmt.Sprintf("%s-%s-*.zip", symbol, s.config.Timeframe))
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	candles := make([]types.OHLCV, 0)
+	for _, path := range paths {
+		if !s.monthOverlaps(path, symbol, start, end) {
+			continue
+		}
+		monthly, err := s.readArchive(path, symbol, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		candles = append(candles, monthly...)
+	}
+
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no Binance kline archives for %s %s in %s", symbol, s.config.Timeframe, s.config.Directory)
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Timestamp.Before(candles[j].Timestamp)
+	})
+	return candles, nil
+}
+
+// monthOverlaps returns true if the archive's month intersects [start, end]; unparseable names are read anyway
+func (s *BinanceKlineSource) monthOverlaps(path, symbol string, start, end time.Time) bool {
+	name := strings.TrimSuffix(filepath.Base(path), ".zip")
+	month, err := time.Parse("2006-01", strings.TrimPrefix(name, symbol+"-"+s.config.Timeframe+"-"))
+	if err != nil {
+		return true
+	}
+
+	monthEnd := month.AddDate(0, 1, 0)
+	if !start.IsZero() && !monthEnd.After(start) {
+		return false
+	}
+	if !end.IsZero() && month.After(end) {
+		return false
+	}
+	return true
+}
+
+// readArchive reads all CSV entries inside a kline archive
+func (s *BinanceKlineSource) readArchive(path, symbol string, start, end time.Time) ([]types.OHLCV, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	candles := make([]types.OHLCV, 0)
+	for _, entry := range archive.File {
+		if !strings.HasSuffix(entry.Name, ".csv") {
+			continue
+		}
+		reader, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		entryCandles, err := readCandleCSV(reader, symbol, start, end, parseEpochTimestamp)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name, err)
+		}
+		candles = append(candles, entryCandles...)
+	}
+	return candles, nil
+}
+
+// ParquetDataSource reads Parquet files with timestamp, open, high, low, close and volume columns
+type ParquetDataSource struct {
+	config DataSourceConfig
+}
+
+// Name returns the data format handled by the source
+func (s *ParquetDataSource) Name() string {
+	return FormatParquet
+}
+
+// LoadCandles reads the symbol's Parquet file, trying SYMBOL.parquet, symbol.parquet and SYMBOL_<timeframe>.parquet
+func (s *ParquetDataSource) LoadCandles(symbol string, start, end time.Time) ([]types.OHLCV, error) {
+	candidates := []string{
+		symbol + ".parquet",
+		strings.ToLower(symbol) + ".parquet",
+		symbol + "_" + s.config.Timeframe + ".parquet",
+	}
+
+	for _, name := range candidates {
+		path := filepath.Join(s.config.Directory, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		candles, err := readCandleParquet(path, symbol, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return candles, nil
+	}
+
+	return nil, fmt.Errorf("no Parquet data for %s in %s", symbol, s.config.Directory)
+}
+
+// readCandleParquet reads the candle columns of a Parquet file; other columns are ignored and rows outside
+// [start, end] are dropped
+func readCandleParquet(path, symbol string, start, end time.Time) ([]types.OHLCV, error) {
+	file, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	columnReader, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer columnReader.ReadStop()
+
+	rows := columnReader.GetNumRows()
+	root := columnReader.SchemaHandler.GetRootExName()
+	columns := make([][]interface{}, len(parquetColumns))
+	for i, name := range parquetColumns {
+		values, _, _, err := columnReader.ReadColumnByPath(root+common.PAR_GO_PATH_DELIMITER+name, rows)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		if int64(len(values)) != rows {
+			return nil, fmt.Errorf("column %s: expected %d values, got %d", name, rows, len(values))
+		}
+		columns[i] = values
+	}
+	parseTime := parquetTimestampParser(columnReader.Footer.GetSchema())
+
+	candles := make([]types.OHLCV, 0, rows)
+	for row := 0; row < int(rows); row++ {
+		timestamp, err := parseTime(columns[0][row])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row+1, err)
+		}
+		if (!start.IsZero() && timestamp.Before(start)) || (!end.IsZero() && timestamp.After(end)) {
+			continue
+		}
+
+		values := make([]float64, 5)
+		for i := range values {
+			values[i], err = parquetFloat(columns[i+1][row])
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %s: %w", row+1, parquetColumns[i+1], err)
+			}
+		}
+		candles = append(candles, types.NewOHLCV(symbol, timestamp, values[0], values[1], values[2], values[3], values[4]))
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Timestamp.Before(candles[j].Timestamp)
+	})
+	return candles, nil
+}
+
+// parquetTimestampParser returns the parser of the timestamp column: integers annotated as timestamps are read
+// in their unit, plain integers as epoch milliseconds or microseconds like Binance open times, and strings in
+// the CSV timestamp formats
+func parquetTimestampParser(schema []*parquet.SchemaElement) func(interface{}) (time.Time, error) {
+	unit := time.Duration(0)
+	for _, element := range schema {
+		if element.GetName() != parquetColumns[0] {
+			continue
+		}
+		switch {
+		case element.LogicalType != nil && element.LogicalType.IsSetTIMESTAMP():
+			timeUnit := element.LogicalType.GetTIMESTAMP().GetUnit()
+			switch {
+			case timeUnit.IsSetNANOS():
+				unit = time.Nanosecond
+			case timeUnit.IsSetMICROS():
+				unit = time.Microsecond
+			default:
+				unit = time.Millisecond
+			}
+		case element.ConvertedType != nil && *element.ConvertedType == parquet.ConvertedType_TIMESTAMP_MICROS:
+			unit = time.Microsecond
+		case element.ConvertedType != nil && *element.ConvertedType == parquet.ConvertedType_TIMESTAMP_MILLIS:
+			unit = time.Millisecond
+		}
+	}
+
+	return func(value interface{}) (time.Time, error) {
+		switch v := value.(type) {
+		case int64:
+			if unit == 0 {
+				return parseEpochTimestamp(strconv.FormatInt(v, 10))
+			}
+			return time.Unix(0, v*int64(unit)).UTC(), nil
+		case string:
+			return parseCSVTimestamp(strings.TrimSpace(v))
+		case nil:
+			return time.Time{}, fmt.Errorf("missing timestamp")
+		default:
+			return time.Time{}, fmt.Errorf("unsupported timestamp type %T", value)
+		}
+	}
+}
+
+// parquetFloat converts a numeric Parquet value to float64
+func parquetFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	case nil:
+		return 0, fmt.Errorf("missing value")
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// readCandleCSV parses rows whose first six columns are time, open, high, low, close and volume.
+// A header row is skipped; rows outside [start, end] are dropped.
+func readCandleCSV(r io.Reader, symbol string, start, end time.Time, parseTime func(string) (time.Time, error)) ([]types.OHLCV, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	candles := make([]types.OHLCV, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(record) < 6 {
+			return nil, fmt.Errorf("line %d: expected at least 6 columns, got %d", line, len(record))
+		}
+
+		timestamp, err := parseTime(strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue // Header row
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if (!start.IsZero() && timestamp.Before(start)) || (!end.IsZero() && timestamp.After(end)) {
+			continue
+		}
+
+		values := make([]float64, 5)
+		for i := range values {
+			values[i], err = strconv.ParseFloat(strings.TrimSpace(record[i+1]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid number %q", line, record[i+1])
+			}
+		}
+		candles = append(candles, types.NewOHLCV(symbol, timestamp, values[0], values[1], values[2], values[3], values[4]))
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Timestamp.Before(candles[j].Timestamp)
+	})
+	return candles, nil
+}
+
+// parseCSVTimestamp parses the timestamp formats documented for CSV data files
+func parseCSVTimestamp(value string) (time.Time, error) {
+	for _, layout := range csvTimestampFormats {
+		if timestamp, err := time.Parse(layout, value); err == nil {
+			return timestamp, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp format: %q", value)
+}
+
+// parseEpochTimestamp parses Binance open times, which are milliseconds or (in newer dumps) microseconds
+func parseEpochTimestamp(value string) (time.Time, error) {
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid epoch timestamp: %q", value)
+	}
+	if epoch > 1e14 {
+		return time.UnixMicro(epoch).UTC(), nil
+	}
+	return time.UnixMilli(epoch).UTC(), nil
+}
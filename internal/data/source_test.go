@@ -0,0 +1,50 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParquetDataSourceLoadsCandles(t *testing.T) {
+	source, err := NewDataSource(DataSourceConfig{Format: FormatParquet, Directory: "testdata"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source.Name() != FormatParquet {
+		t.Fatalf("expected a %s source, got %s", FormatParquet, source.Name())
+	}
+
+	// The fixture holds five 1m candles from 2024-01-01 00:00 UTC, stored out of order, with an extra trades column
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles, err := source.LoadCandles("BTCUSDT", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 5 {
+		t.Fatalf("expected 5 candles, got %d", len(candles))
+	}
+	for i, candle := range candles {
+		if want := start.Add(time.Duration(i) * time.Minute); !candle.Timestamp.Equal(want) {
+			t.Fatalf("candle %d: expected %s, got %s", i, want, candle.Timestamp)
+		}
+		if candle.Symbol != "BTCUSDT" {
+			t.Fatalf("candle %d: expected symbol BTCUSDT, got %s", i, candle.Symbol)
+		}
+	}
+	first := candles[0]
+	if first.Open != 42000 || first.High != 42100.5 || first.Low != 41950 || first.Close != 42050.25 || first.Volume != 12.5 {
+		t.Fatalf("unexpected first candle: %+v", first)
+	}
+
+	window, err := source.LoadCandles("BTCUSDT", start.Add(time.Minute), start.Add(3*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(window) != 3 || !window[0].Timestamp.Equal(start.Add(time.Minute)) || window[2].Close != 41950 {
+		t.Fatalf("expected the candles from 00:01 to 00:03, got %+v", window)
+	}
+
+	if _, err := source.LoadCandles("ETHUSDT", time.Time{}, time.Time{}); err == nil {
+		t.Fatalf("expected an error for a symbol without a Parquet file")
+	}
+}
@@ -0,0 +1,251 @@
+package execution
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Algo names an execution algorithm
+type Algo string
+
+const (
+	AlgoMarket     Algo = "market"      // Single market order, always a taker
+	AlgoLimitChase Algo = "limit_chase" // Post-only order chasing the best price, market after a timeout
+	AlgoTWAP       Algo = "twap"        // Equal market slices spread over a time window
+	AlgoIceberg    Algo = "iceberg"     // Small chased child orders showing only part of the size
+)
+
+// IsValid returns true for known algorithms
+func (a Algo) IsValid() bool {
+	return a == AlgoMarket || a == AlgoLimitChase || a == AlgoTWAP || a == AlgoIceberg
+}
+
+// Intent names why an order is sent, so each kind of order can use its own algorithm
+type Intent string
+
+const (
+	IntentRecoveryClose  Intent = "recovery_close"  // Closing a position after a false breakout
+	IntentEmergencyClose Intent = "emergency_close" // Flattening on a risk event
+	IntentBreakoutEntry  Intent = "breakout_entry"  // Opening or adding to a breakout position
+	IntentBreakoutExit   Intent = "breakout_exit"   // Closing a breakout position
+	IntentAgedUnwind     Intent = "aged_unwind"     // Reducing a position held past its maximum age
+)
+
+// Executor is the part of a trading executor the algorithms drive
+type Executor interface {
+	PlaceOrder(order *types.Order) (*types.OrderResult, error)
+	CancelOrder(orderID string) error
+	GetOrder(orderID string) (*types.Order, error)
+	GetTicker(symbol string) (*types.Ticker, error)
+}
+
+// Config selects the algorithm per intent and holds the algorithm settings
+type Config struct {
+	Algos      map[Intent]Algo  `json:"algos"`       // Intent -> algorithm; intents not listed use market orders
+	LargeOrder LargeOrderConfig `json:"large_order"` // Splitting of orders too large for the book
+	LimitChase LimitChaseConfig `json:"limit_chase"` // Limit chase settings
+	TWAP       TWAPConfig       `json:"twap"`        // TWAP settings
+	Iceberg    IcebergConfig    `json:"iceberg"`     // Iceberg settings
+	AgedUnwind LimitChaseConfig `json:"aged_unwind"` // Limit chase settings of aged unwinds, patient enough to stay passive (30m)
+}
+
+// LargeOrderConfig routes orders above a notional to a splitting algorithm so they do not move thin markets
+type LargeOrderConfig struct {
+	MinNotional float64  `json:"min_notional"` // Orders at or above this notional are split (0 = never)
+	Algo        Algo     `json:"algo"`         // "twap" or "iceberg" (twap)
+	Intents     []Intent `json:"intents"`      // Intents whose large orders are split (emergency_close, breakout_entry)
+}
+
+// hookedExecutor calls onPlace with every order before it is placed
+type hookedExecutor struct {
+	Executor
+	onPlace func(order *types.Order)
+}
+
+// PlaceOrder calls the hook and places the order
+func (h *hookedExecutor) PlaceOrder(order *types.Order) (*types.OrderResult, error) {
+	h.onPlace(order)
+	return h.Executor.PlaceOrder(order)
+}
+
+// Result summarizes an algorithmic execution
+type Result struct {
+	Algo           Algo          `json:"algo"`
+	Quantity       float64       `json:"quantity"`
+	FilledQty      float64       `json:"filled_qty"`
+	AvgPrice       float64       `json:"avg_price"`
+	Fee            float64       `json:"fee"`
+	MakerQty       float64       `json:"maker_qty"`  // Filled by resting post-only orders
+	MarketQty      float64       `json:"market_qty"` // Filled by the market fallback
+	Orders         int           `json:"orders"`
+	Reprices       int           `json:"reprices"`
+	MarketFallback bool          `json:"market_fallback"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// addFill books a fill of quantity at price into the result
+func (r *Result) addFill(quantity, price, fee float64) {
+	if quantity <= 0 {
+		return
+	}
+	r.AvgPrice = (r.AvgPrice*r.FilledQty + price*quantity) / (r.FilledQty + quantity)
+	r.FilledQty += quantity
+	r.Fee += fee
+}
+
+// Engine routes orders to the algorithm configured for their intent and size
+type Engine struct {
+	config   Config
+	executor Executor
+
+	// Statistics
+	executions map[Algo]int64
+	makerQty   float64
+	marketQty  float64
+	failures   int64
+
+	mu sync.Mutex
+}
+
+// NewEngine creates an execution engine around an executor
+func NewEngine(config Config, executor Executor) *Engine {
+	if config.Algos == nil {
+		config.Algos = map[Intent]Algo{IntentRecoveryClose: AlgoLimitChase} // default
+	}
+	if config.LargeOrder.Algo == "" {
+		config.LargeOrder.Algo = AlgoTWAP // default
+	}
+	if len(config.LargeOrder.Intents) == 0 {
+		config.LargeOrder.Intents = []Intent{IntentEmergencyClose, IntentBreakoutEntry} // default
+	}
+	if config.AgedUnwind.RepriceInterval == 0 {
+		config.AgedUnwind.RepriceInterval = 5 * time.Second // default
+	}
+	if config.AgedUnwind.Timeout == 0 {
+		config.AgedUnwind.Timeout = 30 * time.Minute // default
+	}
+	if config.AgedUnwind.MaxReprices == 0 {
+		// Reprices may follow the market for the whole window
+		config.AgedUnwind.MaxReprices = int(config.AgedUnwind.Timeout / config.AgedUnwind.RepriceInterval) // default
+	}
+
+	return &Engine{
+		config:     config,
+		executor:   executor,
+		executions: make(map[Algo]int64),
+	}
+}
+
+// AlgoFor returns the algorithm used for an intent
+func (e *Engine) AlgoFor(intent Intent) Algo {
+	if algo, ok := e.config.Algos[intent]; ok && algo.IsValid() {
+		return algo
+	}
+	return AlgoMarket
+}
+
+// Route returns the algorithm for an order: the large order algorithm if its notional reaches the
+// threshold, otherwise the algorithm of its intent
+func (e *Engine) Route(intent Intent, order *types.Order) Algo {
+	algo := e.AlgoFor(intent)
+	if e.config.LargeOrder.MinNotional <= 0 || !e.splits(intent) {
+		return algo
+	}
+
+	price := order.Price
+	if price <= 0 {
+		if ticker, err := e.executor.GetTicker(order.Symbol); err == nil {
+			price = ticker.Price
+		}
+	}
+	if order.Quantity*price >= e.config.LargeOrder.MinNotional {
+		return e.config.LargeOrder.Algo
+	}
+	return algo
+}
+
+// splits returns true if large orders of the intent are split
+func (e *Engine) splits(intent Intent) bool {
+	for _, split := range e.config.LargeOrder.Intents {
+		if split == intent {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute works an order of an intent with an algorithm; onPlace, if set, is called with every child order
+// before it is placed. Market orders return an error so callers keep their own market order path.
+func (e *Engine) Execute(ctx context.Context, intent Intent, algo Algo, order *types.Order, onPlace func(child *types.Order)) (*Result, error) {
+	var executor Executor = e.executor
+	if onPlace != nil {
+		executor = &hookedExecutor{Executor: e.executor, onPlace: onPlace}
+	}
+
+	var result *Result
+	var err error
+	switch algo {
+	case AlgoLimitChase:
+		result, err = NewLimitChaser(e.chaseConfig(intent), executor).Execute(ctx, order)
+	case AlgoTWAP:
+		result, err = NewTWAP(e.config.TWAP, executor).Execute(ctx, order)
+	case AlgoIceberg:
+		result, err = NewIceberg(e.config.Iceberg, e.config.LimitChase, executor).Execute(ctx, order)
+	default:
+		return nil, fmt.Errorf("%s orders are placed directly", algo)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executions[algo]++
+	if result != nil {
+		e.makerQty += result.MakerQty
+		e.marketQty += result.MarketQty
+	}
+	if err != nil {
+		e.failures++
+	}
+	return result, err
+}
+
+// chaseConfig returns the limit chase settings of an intent; aged unwinds have their own, more patient ones
+func (e *Engine) chaseConfig(intent Intent) LimitChaseConfig {
+	if intent == IntentAgedUnwind {
+		return e.config.AgedUnwind
+	}
+	return e.config.LimitChase
+}
+
+// GetAlgoStats returns execution algorithm statistics
+func (e *Engine) GetAlgoStats() map[string]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	algos := make(map[string]string, len(e.config.Algos))
+	for intent, algo := range e.config.Algos {
+		algos[string(intent)] = string(algo)
+	}
+	executions := make(map[string]int64, len(e.executions))
+	for algo, count := range e.executions {
+		executions[string(algo)] = count
+	}
+	makerRatio := 0.0
+	if total := e.makerQty + e.marketQty; total > 0 {
+		makerRatio = e.makerQty / total
+	}
+
+	return map[string]interface{}{
+		"algos":                algos,
+		"large_order_notional": e.config.LargeOrder.MinNotional,
+		"large_order_algo":     string(e.config.LargeOrder.Algo),
+		"aged_unwind_window":   e.config.AgedUnwind.Timeout.String(),
+		"executions":           executions,
+		"maker_qty":            e.makerQty,
+		"market_qty":           e.marketQty,
+		"maker_ratio":          makerRatio,
+		"failures":             e.failures,
+	}
+}
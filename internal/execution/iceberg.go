@@ -0,0 +1,92 @@
+package execution
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// IcebergConfig holds the settings of the iceberg algorithm
+type IcebergConfig struct {
+	DisplayFraction float64       `json:"display_fraction"` // Child order size as a fraction of the order (0.1)
+	Duration        time.Duration `json:"duration"`         // Window for all child orders (2m)
+}
+
+// Iceberg works an order as a sequence of small child orders, each chased as a post-only order at the
+// best price, so the book only ever shows a fraction of the size. Each child gets an even share of the
+// remaining window and falls back to market when its share runs out, so the order completes in time.
+type Iceberg struct {
+	config   IcebergConfig
+	chase    LimitChaseConfig
+	executor Executor
+}
+
+// NewIceberg creates an iceberg executor; chase holds the repricing settings of the child orders
+func NewIceberg(config IcebergConfig, chase LimitChaseConfig, executor Executor) *Iceberg {
+	if config.DisplayFraction == 0 {
+		config.DisplayFraction = 0.1 // default
+	}
+	if config.Duration == 0 {
+		config.Duration = 2 * time.Minute // default
+	}
+
+	return &Iceberg{
+		config:   config,
+		chase:    chase,
+		executor: executor,
+	}
+}
+
+// Execute works the order's side, quantity, position type and reduce-only flag as child orders
+func (ib *Iceberg) Execute(ctx context.Context, order *types.Order) (*Result, error) {
+	start := time.Now()
+	result := &Result{Algo: AlgoIceberg, Quantity: order.Quantity}
+	defer func() { result.Duration = time.Since(start) }()
+
+	display := order.Quantity * ib.config.DisplayFraction
+	children := int(math.Ceil(1 / ib.config.DisplayFraction))
+	deadline := start.Add(ib.config.Duration)
+
+	// A child that returns without error is complete, since the chaser sends its rest at market; counting
+	// sent rather than filled quantity keeps fills reported late from being sent twice
+	sent := 0.0
+	for i := 0; i < children; i++ {
+		remaining := order.Quantity - sent
+		if remaining <= order.Quantity*dustFraction {
+			break
+		}
+
+		quantity := math.Min(display, remaining)
+		if i == children-1 {
+			quantity = remaining
+		}
+		child := types.NewLimitOrder("", order.Symbol, order.Side, quantity, 0, order.PositionType)
+		child.SetReduceOnly(order.ReduceOnly)
+		child.Tag = order.Tag
+		if order.ClientOrderID != "" {
+			child.ClientOrderID = fmt.Sprintf("%s-%d", order.ClientOrderID, i+1)
+		}
+
+		chase := ib.chase
+		chase.Timeout = time.Until(deadline) / time.Duration(children-i)
+		if chase.Timeout <= 0 {
+			chase.Timeout = time.Millisecond
+		}
+		childResult, err := NewLimitChaser(chase, ib.executor).Execute(ctx, child)
+		if childResult != nil {
+			result.addFill(childResult.FilledQty, childResult.AvgPrice, childResult.Fee)
+			result.MakerQty += childResult.MakerQty
+			result.MarketQty += childResult.MarketQty
+			result.Orders += childResult.Orders
+			result.Reprices += childResult.Reprices
+			result.MarketFallback = result.MarketFallback || childResult.MarketFallback
+		}
+		if err != nil {
+			return result, fmt.Errorf("iceberg child %d of %d failed: %w", i+1, children, err)
+		}
+		sent += quantity
+	}
+	return result, nil
+}
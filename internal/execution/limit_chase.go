@@ -0,0 +1,225 @@
+package execution
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// dustFraction is the unfilled fraction of an order treated as fully filled
+const dustFraction = 1e-9
+
+// LimitChaseConfig holds the settings of the limit chase algorithm
+type LimitChaseConfig struct {
+	RepriceInterval  time.Duration `json:"reprice_interval"`  // How often the working order is checked against the book (1s)
+	RepriceThreshold float64       `json:"reprice_threshold"` // Best price move, as a fraction, that triggers a reprice (0 = any move)
+	MaxReprices      int           `json:"max_reprices"`      // Reprices before falling back to market (20)
+	Timeout          time.Duration `json:"timeout"`           // Time to fill as a maker before falling back to market (30s)
+}
+
+// chaseOrder is a working post-only order and the part of its fills already booked
+type chaseOrder struct {
+	id             string
+	price          float64
+	bookedQty      float64
+	bookedNotional float64
+	bookedFee      float64
+}
+
+// LimitChaser works an order as a post-only limit order at the best bid (buys) or ask (sells), moves it
+// with the market and sends whatever is left as a market order once the timeout or reprice limit is hit,
+// so closes that are not urgent earn maker fees instead of paying taker fees
+type LimitChaser struct {
+	config   LimitChaseConfig
+	executor Executor
+}
+
+// NewLimitChaser creates a limit chaser
+func NewLimitChaser(config LimitChaseConfig, executor Executor) *LimitChaser {
+	if config.RepriceInterval == 0 {
+		config.RepriceInterval = time.Second // default
+	}
+	if config.MaxReprices == 0 {
+		config.MaxReprices = 20 // default
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second // default
+	}
+
+	return &LimitChaser{
+		config:   config,
+		executor: executor,
+	}
+}
+
+// Execute works the order's side, quantity, position type and reduce-only flag until it is filled; its
+// type and price are ignored. A cancelled context cancels the working order and returns the partial result.
+func (c *LimitChaser) Execute(ctx context.Context, order *types.Order) (*Result, error) {
+	start := time.Now()
+	result := &Result{Algo: AlgoLimitChase, Quantity: order.Quantity}
+	defer func() { result.Duration = time.Since(start) }()
+
+	deadline := start.Add(c.config.Timeout)
+	ticker := time.NewTicker(c.config.RepriceInterval)
+	defer ticker.Stop()
+
+	var working *chaseOrder
+	for c.remaining(order, result) > 0 && time.Now().Before(deadline) && result.Reprices < c.config.MaxReprices {
+		if working == nil {
+			// Rejections, e.g. a post-only order the market moved through, are retried at the next check
+			if price, err := c.bestPrice(order); err == nil {
+				working = c.place(order, price, c.remaining(order, result), result)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := c.cancel(working, result); err != nil {
+				return result, err
+			}
+			return result, ctx.Err()
+		case <-ticker.C:
+		}
+
+		if working == nil {
+			continue
+		}
+		open, err := c.poll(working, result)
+		if err != nil {
+			continue
+		}
+		if !open {
+			// Filled, or cancelled outside the chaser; anything left is placed again
+			working = nil
+			continue
+		}
+		if best, err := c.bestPrice(order); err == nil && c.moved(working.price, best) {
+			if err := c.cancel(working, result); err != nil {
+				return result, err
+			}
+			working = nil
+			result.Reprices++
+		}
+	}
+
+	if err := c.cancel(working, result); err != nil {
+		return result, err
+	}
+	remaining := c.remaining(order, result)
+	if remaining <= 0 {
+		return result, nil
+	}
+
+	result.MarketFallback = true
+	fallback := types.NewMarketOrder("", order.Symbol, order.Side, remaining, order.PositionType)
+	fallback.SetReduceOnly(order.ReduceOnly)
+	fallback.Tag = order.Tag
+	fallback.ClientOrderID = c.childID(order, result)
+	result.Orders++
+	fill, err := c.executor.PlaceOrder(fallback)
+	if err != nil {
+		return result, fmt.Errorf("market fallback failed: %w", err)
+	}
+	result.addFill(fill.FilledQty, fill.FilledPrice, fill.Fee)
+	result.MarketQty += fill.FilledQty
+	return result, nil
+}
+
+// place submits a post-only order at price; returns nil if it was not accepted
+func (c *LimitChaser) place(order *types.Order, price, quantity float64, result *Result) *chaseOrder {
+	limit := types.NewLimitOrder("", order.Symbol, order.Side, quantity, price, order.PositionType)
+	limit.SetReduceOnly(order.ReduceOnly)
+	limit.SetPostOnly()
+	limit.Tag = order.Tag
+	limit.ClientOrderID = c.childID(order, result)
+	result.Orders++
+
+	placed, err := c.executor.PlaceOrder(limit)
+	if err != nil || placed == nil || placed.OrderID == "" {
+		return nil
+	}
+	return &chaseOrder{id: placed.OrderID, price: price}
+}
+
+// poll books fills of the working order since the last poll and returns true while it is still open
+func (c *LimitChaser) poll(working *chaseOrder, result *Result) (bool, error) {
+	current, err := c.executor.GetOrder(working.id)
+	if err != nil {
+		return false, err
+	}
+
+	if quantity := current.FilledQty - working.bookedQty; quantity > 0 {
+		notional := current.AvgFillPrice * current.FilledQty
+		result.addFill(quantity, (notional-working.bookedNotional)/quantity, current.Fee-working.bookedFee)
+		result.MakerQty += quantity
+		working.bookedQty = current.FilledQty
+		working.bookedNotional = notional
+		working.bookedFee = current.Fee
+	}
+	return current.IsActive(), nil
+}
+
+// cancel cancels the working order and books the fills it got before the cancel. An order that can be
+// neither cancelled nor found filled is an error so the caller never sends the quantity twice.
+func (c *LimitChaser) cancel(working *chaseOrder, result *Result) error {
+	if working == nil {
+		return nil
+	}
+
+	cancelErr := c.executor.CancelOrder(working.id)
+	open, err := c.poll(working, result)
+	if err != nil {
+		return fmt.Errorf("failed to check chase order %s: %w", working.id, err)
+	}
+	if open {
+		return fmt.Errorf("failed to cancel chase order %s: %v", working.id, cancelErr)
+	}
+	return nil
+}
+
+// bestPrice returns the price a post-only order joins: the best bid for buys and the best ask for sells
+func (c *LimitChaser) bestPrice(order *types.Order) (float64, error) {
+	ticker, err := c.executor.GetTicker(order.Symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	price := ticker.Ask
+	if order.IsBuy() {
+		price = ticker.Bid
+	}
+	if price <= 0 {
+		price = ticker.Price
+	}
+	if price <= 0 {
+		return 0, fmt.Errorf("no price available for %s", order.Symbol)
+	}
+	return price, nil
+}
+
+// moved returns true if the best price moved far enough from the working price to reprice
+func (c *LimitChaser) moved(working, best float64) bool {
+	if best == working {
+		return false
+	}
+	return math.Abs(best-working)/working >= c.config.RepriceThreshold
+}
+
+// remaining returns the quantity left to fill, zero once only dust is left
+func (c *LimitChaser) remaining(order *types.Order, result *Result) float64 {
+	remaining := order.Quantity - result.FilledQty
+	if remaining <= order.Quantity*dustFraction {
+		return 0
+	}
+	return remaining
+}
+
+// childID returns a unique client order ID for a child order, or "" if the parent has none
+func (c *LimitChaser) childID(order *types.Order, result *Result) string {
+	if order.ClientOrderID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", order.ClientOrderID, result.Orders+1)
+}
@@ -0,0 +1,83 @@
+package execution
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// TWAPConfig holds the settings of the TWAP algorithm
+type TWAPConfig struct {
+	Duration time.Duration `json:"duration"` // Window the order is spread over (30s)
+	Slices   int           `json:"slices"`   // Market orders the order is split into (6)
+}
+
+// TWAP splits an order into equal market slices sent at even intervals over a time window
+type TWAP struct {
+	config   TWAPConfig
+	executor Executor
+}
+
+// NewTWAP creates a TWAP executor
+func NewTWAP(config TWAPConfig, executor Executor) *TWAP {
+	if config.Duration == 0 {
+		config.Duration = 30 * time.Second // default
+	}
+	if config.Slices == 0 {
+		config.Slices = 6 // default
+	}
+
+	return &TWAP{
+		config:   config,
+		executor: executor,
+	}
+}
+
+// Execute sends the order's side, quantity, position type and reduce-only flag as market slices. A slice
+// that fails is added to the next one; a cancelled context stops before the next slice.
+func (t *TWAP) Execute(ctx context.Context, order *types.Order) (*Result, error) {
+	start := time.Now()
+	result := &Result{Algo: AlgoTWAP, Quantity: order.Quantity}
+	defer func() { result.Duration = time.Since(start) }()
+
+	interval := t.config.Duration / time.Duration(t.config.Slices)
+	sent := 0.0
+	var lastErr error
+	for i := 0; i < t.config.Slices; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		// Sent rather than filled quantity, so a fill reported late is never sent twice
+		quantity := (order.Quantity - sent) / float64(t.config.Slices-i)
+		if quantity <= order.Quantity*dustFraction {
+			break
+		}
+		slice := types.NewMarketOrder("", order.Symbol, order.Side, quantity, order.PositionType)
+		slice.SetReduceOnly(order.ReduceOnly)
+		slice.Tag = order.Tag
+		if order.ClientOrderID != "" {
+			slice.ClientOrderID = fmt.Sprintf("%s-%d", order.ClientOrderID, i+1)
+		}
+		result.Orders++
+
+		fill, err := t.executor.PlaceOrder(slice)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		sent += quantity
+		result.addFill(fill.FilledQty, fill.FilledPrice, fill.Fee)
+		result.MarketQty += fill.FilledQty
+	}
+
+	if unsent := order.Quantity - sent; unsent > order.Quantity*dustFraction {
+		return result, fmt.Errorf("twap left %.6f of %.6f unsent: %w", unsent, order.Quantity, lastErr)
+	}
+	return result, nil
+}
@@ -2,37 +2,46 @@ package indicators
 
 import (
 	"aibot/internal/types"
-	"github.com/cinar/indicator"
+	"math"
 	"sync"
 )
 
-// TechnicalAnalyzer wraps the Indicator Go library for technical analysis
+// TechnicalAnalyzer maintains streaming technical indicators per symbol
 type TechnicalAnalyzer struct {
+	config AnalyzerConfig
+	named  map[string]IndicatorSpec // Named indicators created for every symbol
+
+	// Divergence of price with OBV and RSI
+	divergence *DivergenceDetector
+
 	// Data storage per symbol
 	data map[string]*SymbolData
 	mu   sync.RWMutex
 }
 
-// SymbolData stores OHLCV data and calculated indicators for a symbol
+// SymbolData stores OHLCV data and incrementally updated indicators for a symbol
 type SymbolData struct {
 	Symbol string
 	Candles []types.OHLCV
-	// Indicator values
+	Seen    int // Candles added, including ones dropped from history
 	// Trend indicators
-	SMA    []float64  // Simple Moving Average
-	EMA    []float64  // Exponential Moving Average
+	SMA    *StreamingSMA  // Simple Moving Average
+	EMA    *StreamingEMA  // Exponential Moving Average
 	// Momentum indicators
-	RSI    []float64  // Relative Strength Index
-	MACD   []float64  // MACD line
-	MACDSignal []float64 // MACD signal line
-	MACDHist []float64  // MACD histogram
+	RSI    *StreamingRSI  // Relative Strength Index
+	MACD   *StreamingMACD // MACD line, signal line and histogram
+	ROC    *StreamingROC  // Rate of Change
 	// Volatility indicators
-	ATR    []float64  // Average True Range
-	BollingerUpper []float64 // Bollinger Bands upper
-	BollingerMiddle []float64 // Bollinger Bands middle
-	BollingerLower []float64 // Bollinger Bands lower
+	ATR    *StreamingATR  // Average True Range
+	Bollinger *StreamingBollinger // Bollinger Bands
 	// Volume indicators
-	VolumeSMA []float64 // Volume Simple Moving Average
+	VolumeSMA *StreamingSMA // Volume Simple Moving Average
+	OBV    *StreamingOBV  // On-Balance Volume
+	// Oscillator values per candle, aligned with Candles (NaN until ready), for divergence detection
+	RSIHistory []float64
+	OBVHistory []float64
+	// Named indicators with custom periods
+	Named map[string]*NamedIndicator
 }
 
 // AnalyzerConfig holds configuration for technical analysis
@@ -46,12 +55,19 @@ type AnalyzerConfig struct {
 	MACDFast int `json:"macd_fast"`
 	MACDSlow int `json:"macd_slow"`
 	MACDSignal int `json:"macd_signal"`
+	ROCPeriod int `json:"roc_period"`
 	// Volatility indicator periods
 	ATRPeriod int `json:"atr_period"`
 	BollingerPeriod int `json:"bollinger_period"`
 	BollingerStdDev float64 `json:"bollinger_std_dev"`
 	// Volume indicator periods
 	VolumeSMAPeriod int `json:"volume_sma_period"`
+	// Divergence of price with OBV and RSI
+	Divergence DivergenceConfig `json:"divergence"`
+	// Additional named indicators, e.g. "rsi_7", "ema_50"
+	NamedIndicators []string `json:"named_indicators"`
+	// Candles a symbol needs before it is ready, on top of the indicator warm-ups (0 = warm-ups only)
+	MinCandles int `json:"min_candles"`
 }
 
 // NewTechnicalAnalyzer creates a new technical analyzer
@@ -90,10 +106,23 @@ func NewTechnicalAnalyzer(config AnalyzerConfig) *TechnicalAnalyzer {
 	if config.VolumeSMAPeriod == 0 {
 		config.VolumeSMAPeriod = 20
 	}
+	if config.ROCPeriod == 0 {
+		config.ROCPeriod = 12
+	}
+	if config.Divergence.Lookback > config.MaxHistoryCandles {
+		config.Divergence.Lookback = config.MaxHistoryCandles // Swings are only compared within the kept history
+	}
 
-	return &TechnicalAnalyzer{
-		data: make(map[string]*SymbolData),
+	ta := &TechnicalAnalyzer{
+		config:     config,
+		named:      make(map[string]IndicatorSpec),
+		divergence: NewDivergenceDetector(config.Divergence),
+		data:       make(map[string]*SymbolData),
+	}
+	for _, name := range config.NamedIndicators {
+		ta.RegisterIndicator(name) // Invalid names are skipped; the orchestrator validates them up front
 	}
+	return ta
 }
 
 // AddCandle adds a new OHLCV candle and updates all indicators
@@ -103,23 +132,23 @@ func (ta *TechnicalAnalyzer) AddCandle(candle types.OHLCV) {
 
 	symbolData, exists := ta.data[candle.Symbol]
 	if !exists {
-		symbolData = &SymbolData{
-			Symbol:  candle.Symbol,
-			Candles: make([]types.OHLCV, 0),
-		}
+		symbolData = ta.newSymbolData(candle.Symbol)
 		ta.data[candle.Symbol] = symbolData
 	}
 
 	// Add new candle
 	symbolData.Candles = append(symbolData.Candles, candle)
+	symbolData.Seen++
+
+	// Update all indicators with the new candle only
+	ta.updateIndicators(symbolData, candle)
 
 	// Limit history size
-	if len(symbolData.Candles) > 200 { // Maximum history
+	if len(symbolData.Candles) > ta.config.MaxHistoryCandles {
 		symbolData.Candles = symbolData.Candles[1:]
+		symbolData.RSIHistory = symbolData.RSIHistory[1:]
+		symbolData.OBVHistory = symbolData.OBVHistory[1:]
 	}
-
-	// Update all indicators
-	ta.updateIndicators(symbolData)
 }
 
 // AddCandles adds multiple candles at once
@@ -139,20 +168,25 @@ func (ta *TechnicalAnalyzer) GetIndicatorValues(symbol string) *IndicatorValues
 		return nil
 	}
 
+	macd, macdSignal, macdHist := symbolData.MACD.Value()
+	bbUpper, bbMiddle, bbLower := symbolData.Bollinger.Value()
+
 	return &IndicatorValues{
 		Symbol:         symbol,
 		CurrentPrice:   ta.getCurrentPrice(symbolData),
-		SMA:            ta.getLastValue(symbolData.SMA),
-		EMA:            ta.getLastValue(symbolData.EMA),
-		RSI:            ta.getLastValue(symbolData.RSI),
-		MACD:           ta.getLastValue(symbolData.MACD),
-		MACDSignal:     ta.getLastValue(symbolData.MACDSignal),
-		MACDHist:       ta.getLastValue(symbolData.MACDHist),
-		ATR:            ta.getLastValue(symbolData.ATR),
-		BollingerUpper: ta.getLastValue(symbolData.BollingerUpper),
-		BollingerMiddle: ta.getLastValue(symbolData.BollingerMiddle),
-		BollingerLower: ta.getLastValue(symbolData.BollingerLower),
-		VolumeSMA:      ta.getLastValue(symbolData.VolumeSMA),
+		SMA:            symbolData.SMA.Value(),
+		EMA:            symbolData.EMA.Value(),
+		RSI:            symbolData.RSI.Value(),
+		ROC:            symbolData.ROC.Value(),
+		MACD:           macd,
+		MACDSignal:     macdSignal,
+		MACDHist:       macdHist,
+		ATR:            symbolData.ATR.Value(),
+		BollingerUpper: bbUpper,
+		BollingerMiddle: bbMiddle,
+		BollingerLower: bbLower,
+		VolumeSMA:      symbolData.VolumeSMA.Value(),
+		OBV:            symbolData.OBV.Value(),
 	}
 }
 
@@ -163,6 +197,7 @@ type IndicatorValues struct {
 	SMA            float64 `json:"sma"`
 	EMA            float64 `json:"ema"`
 	RSI            float64 `json:"rsi"`
+	ROC            float64 `json:"roc"` // Percent
 	MACD           float64 `json:"macd"`
 	MACDSignal     float64 `json:"macd_signal"`
 	MACDHist       float64 `json:"macd_hist"`
@@ -171,81 +206,69 @@ type IndicatorValues struct {
 	BollingerMiddle float64 `json:"bollinger_middle"`
 	BollingerLower float64 `json:"bollinger_lower"`
 	VolumeSMA      float64 `json:"volume_sma"`
+	OBV            float64 `json:"obv"`
 }
 
-// updateIndicators recalculates all indicators for a symbol
-func (ta *TechnicalAnalyzer) updateIndicators(symbolData *SymbolData) {
-	if len(symbolData.Candles) < 2 {
-		return
+// newSymbolData creates empty indicator state for a symbol
+func (ta *TechnicalAnalyzer) newSymbolData(symbol string) *SymbolData {
+	symbolData := &SymbolData{
+		Symbol:    symbol,
+		Candles:   make([]types.OHLCV, 0, ta.config.MaxHistoryCandles+1),
+		SMA:       NewStreamingSMA(ta.config.SMAPeriod),
+		EMA:       NewStreamingEMA(ta.config.EMAPeriod),
+		RSI:       NewStreamingRSI(ta.config.RSIPeriod),
+		MACD:      NewStreamingMACD(ta.config.MACDFast, ta.config.MACDSlow, ta.config.MACDSignal),
+		ROC:       NewStreamingROC(ta.config.ROCPeriod),
+		ATR:       NewStreamingATR(ta.config.ATRPeriod),
+		Bollinger: NewStreamingBollinger(ta.config.BollingerPeriod, ta.config.BollingerStdDev),
+		VolumeSMA: NewStreamingSMA(ta.config.VolumeSMAPeriod),
+		OBV:       NewStreamingOBV(),
+		RSIHistory: make([]float64, 0, ta.config.MaxHistoryCandles+1),
+		OBVHistory: make([]float64, 0, ta.config.MaxHistoryCandles+1),
+		Named:     make(map[string]*NamedIndicator, len(ta.named)),
 	}
-
-	// Extract price and volume arrays
-	closes := ta.extractCloses(symbolData.Candles)
-	highs := ta.extractHighs(symbolData.Candles)
-	lows := ta.extractLows(symbolData.Candles)
-	volumes := ta.extractVolumes(symbolData.Candles)
-
-	// Update trend indicators
-	symbolData.SMA = indicator.Sma(20, closes)
-	symbolData.EMA = indicator.Ema(20, closes)
-
-	// Update momentum indicators
-	rsiValues, _ := indicator.Rsi(closes)
-	symbolData.RSI = rsiValues
-	macdLine, signalLine := indicator.Macd(closes)
-	symbolData.MACD = macdLine
-	symbolData.MACDSignal = signalLine
-	// Calculate MACD histogram
-	symbolData.MACDHist = make([]float64, len(macdLine))
-	for i := range macdLine {
-		if i < len(signalLine) {
-			symbolData.MACDHist[i] = macdLine[i] - signalLine[i]
-		}
+	for name, spec := range ta.named {
+		symbolData.Named[name] = newNamedIndicator(spec)
 	}
+	return symbolData
+}
 
-	// Update volatility indicators
-	atrValues, _ := indicator.Atr(14, highs, lows, closes)
-	symbolData.ATR = atrValues
-	bbUpper, bbMiddle, bbLower := indicator.BollingerBands(closes)
-	symbolData.BollingerUpper = bbUpper
-	symbolData.BollingerMiddle = bbMiddle
-	symbolData.BollingerLower = bbLower
+// updateIndicators folds a new candle into every indicator in O(1)
+func (ta *TechnicalAnalyzer) updateIndicators(symbolData *SymbolData, candle types.OHLCV) {
+	// Trend indicators
+	symbolData.SMA.Update(candle.Close)
+	symbolData.EMA.Update(candle.Close)
 
-	// Update volume indicators
-	symbolData.VolumeSMA = indicator.Sma(20, volumes)
-}
+	// Momentum indicators
+	symbolData.RSI.Update(candle.Close)
+	symbolData.MACD.Update(candle.Close)
+	symbolData.ROC.Update(candle.Close)
 
-// Helper functions to extract data arrays
-func (ta *TechnicalAnalyzer) extractCloses(candles []types.OHLCV) []float64 {
-	closes := make([]float64, len(candles))
-	for i, candle := range candles {
-		closes[i] = candle.Close
-	}
-	return closes
-}
+	// Volatility indicators
+	symbolData.ATR.Update(candle.High, candle.Low, candle.Close)
+	symbolData.Bollinger.Update(candle.Close)
 
-func (ta *TechnicalAnalyzer) extractHighs(candles []types.OHLCV) []float64 {
-	highs := make([]float64, len(candles))
-	for i, candle := range candles {
-		highs[i] = candle.High
-	}
-	return highs
-}
+	// Volume indicators
+	symbolData.VolumeSMA.Update(candle.Volume)
+	symbolData.OBV.Update(candle.Close, candle.Volume)
+
+	// Oscillator history for divergence detection
+	symbolData.RSIHistory = append(symbolData.RSIHistory, readyValue(symbolData.RSI.Value(), symbolData.RSI.Ready()))
+	symbolData.OBVHistory = append(symbolData.OBVHistory, readyValue(symbolData.OBV.Value(), symbolData.OBV.Ready()))
 
-func (ta *TechnicalAnalyzer) extractLows(candles []types.OHLCV) []float64 {
-	lows := make([]float64, len(candles))
-	for i, candle := range candles {
-		lows[i] = candle.Low
+	// Named indicators
+	for _, indicator := range symbolData.Named {
+		indicator.update(candle)
+		indicator.seen++
 	}
-	return lows
 }
 
-func (ta *TechnicalAnalyzer) extractVolumes(candles []types.OHLCV) []float64 {
-	volumes := make([]float64, len(candles))
-	for i, candle := range candles {
-		volumes[i] = candle.Volume
+// readyValue returns value, or NaN if the indicator is not ready
+func readyValue(value float64, ready bool) float64 {
+	if !ready {
+		return math.NaN()
 	}
-	return volumes
+	return value
 }
 
 // getCurrentPrice returns the most recent price
@@ -256,16 +279,6 @@ func (ta *TechnicalAnalyzer) getCurrentPrice(symbolData *SymbolData) float64 {
 	return symbolData.Candles[len(symbolData.Candles)-1].Close
 }
 
-// getLastValue returns the last non-zero value from an array
-func (ta *TechnicalAnalyzer) getLastValue(values []float64) float64 {
-	for i := len(values) - 1; i >= 0; i-- {
-		if values[i] != 0 {
-			return values[i]
-		}
-	}
-	return 0
-}
-
 // GetHistoricalData returns historical OHLCV data for a symbol
 func (ta *TechnicalAnalyzer) GetHistoricalData(symbol string, limit int) []types.OHLCV {
 	ta.mu.RLock()
@@ -0,0 +1,160 @@
+package indicators
+
+import (
+	"math"
+)
+
+// Divergence kinds
+const (
+	DivergenceBearish = "bearish" // Price makes a higher high while the oscillator makes a lower high
+	DivergenceBullish = "bullish" // Price makes a lower low while the oscillator makes a higher low
+)
+
+// Oscillators checked for divergence with price
+const (
+	OscillatorOBV = "obv"
+	OscillatorRSI = "rsi"
+)
+
+// DivergenceConfig holds configuration for divergence detection
+type DivergenceConfig struct {
+	Lookback     int     `json:"lookback"`      // Candles searched for the earlier swing, including the recent ones (30)
+	SwingCandles int     `json:"swing_candles"` // Most recent candles searched for the latest swing (5)
+	MinGap       float64 `json:"min_gap"`       // Oscillator gap between the swings as a fraction of its range over the lookback (0.1)
+}
+
+// Divergence is a swing of price the oscillator did not confirm, an early sign the move is running out
+type Divergence struct {
+	Kind             string  `json:"kind"`
+	Oscillator       string  `json:"oscillator"`
+	PriorPrice       float64 `json:"prior_price"` // Swing high (bearish) or low (bullish) before the recent candles
+	RecentPrice      float64 `json:"recent_price"`
+	PriorOscillator  float64 `json:"prior_oscillator"` // Oscillator at the candle of each swing
+	RecentOscillator float64 `json:"recent_oscillator"`
+	Strength         float64 `json:"strength"` // Oscillator gap as a fraction of its range over the lookback, in (0, 1]
+	Candles          int     `json:"candles"`  // Candles between the swings
+}
+
+// DivergenceDetector compares the latest swing of price with the swing before it and reports oscillators
+// that moved the other way
+type DivergenceDetector struct {
+	config DivergenceConfig
+}
+
+// NewDivergenceDetector creates a divergence detector
+func NewDivergenceDetector(config DivergenceConfig) *DivergenceDetector {
+	if config.Lookback == 0 {
+		config.Lookback = 30 // default
+	}
+	if config.SwingCandles == 0 {
+		config.SwingCandles = 5 // default
+	}
+	if config.MinGap == 0 {
+		config.MinGap = 0.1 // default
+	}
+	if config.SwingCandles >= config.Lookback {
+		config.SwingCandles = config.Lookback / 2
+	}
+
+	return &DivergenceDetector{config: config}
+}
+
+// Candles returns the candles needed before divergences can be detected
+func (d *DivergenceDetector) Candles() int {
+	return d.config.Lookback
+}
+
+// Detect returns the bearish and bullish divergences between price and an oscillator over the last
+// Lookback candles; highs, lows and values are aligned oldest first and NaN values are not ready yet
+func (d *DivergenceDetector) Detect(oscillator string, highs, lows, values []float64) []Divergence {
+	divergences := make([]Divergence, 0)
+	n := len(values)
+	if len(highs) != n || len(lows) != n || n < d.config.Lookback || d.config.SwingCandles < 1 {
+		return divergences
+	}
+	highs, lows, values = highs[n-d.config.Lookback:], lows[n-d.config.Lookback:], values[n-d.config.Lookback:]
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if math.IsNaN(value) {
+			return divergences
+		}
+		low, high = math.Min(low, value), math.Max(high, value)
+	}
+	oscillatorRange := high - low
+	if oscillatorRange <= 0 {
+		return divergences
+	}
+
+	split := len(values) - d.config.SwingCandles
+	prior, recent := argMax(highs[:split]), split+argMax(highs[split:])
+	if highs[recent] > highs[prior] && values[prior]-values[recent] >= d.config.MinGap*oscillatorRange {
+		divergences = append(divergences, d.divergence(DivergenceBearish, oscillator, highs, values, prior, recent, oscillatorRange))
+	}
+
+	prior, recent = argMin(lows[:split]), split+argMin(lows[split:])
+	if lows[recent] < lows[prior] && values[recent]-values[prior] >= d.config.MinGap*oscillatorRange {
+		divergences = append(divergences, d.divergence(DivergenceBullish, oscillator, lows, values, prior, recent, oscillatorRange))
+	}
+	return divergences
+}
+
+// divergence describes the swings at prior and recent
+func (d *DivergenceDetector) divergence(kind, oscillator string, prices, values []float64, prior, recent int, oscillatorRange float64) Divergence {
+	return Divergence{
+		Kind:             kind,
+		Oscillator:       oscillator,
+		PriorPrice:       prices[prior],
+		RecentPrice:      prices[recent],
+		PriorOscillator:  values[prior],
+		RecentOscillator: values[recent],
+		Strength:         math.Min(1, math.Abs(values[prior]-values[recent])/oscillatorRange),
+		Candles:          recent - prior,
+	}
+}
+
+// argMax returns the index of the last largest value
+func argMax(values []float64) int {
+	index := 0
+	for i, value := range values {
+		if value >= values[index] {
+			index = i
+		}
+	}
+	return index
+}
+
+// argMin returns the index of the last smallest value
+func argMin(values []float64) int {
+	index := 0
+	for i, value := range values {
+		if value <= values[index] {
+			index = i
+		}
+	}
+	return index
+}
+
+// GetDivergences returns the current divergences of price with OBV and RSI for a symbol
+func (ta *TechnicalAnalyzer) GetDivergences(symbol string) []Divergence {
+	ta.mu.RLock()
+	defer ta.mu.RUnlock()
+
+	divergences := make([]Divergence, 0)
+	symbolData, exists := ta.data[symbol]
+	if !exists || len(symbolData.Candles) < ta.divergence.Candles() {
+		return divergences
+	}
+
+	candles := symbolData.Candles[len(symbolData.Candles)-ta.divergence.Candles():]
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	for i, candle := range candles {
+		highs[i], lows[i] = candle.High, candle.Low
+	}
+
+	history := len(symbolData.OBVHistory) - len(candles)
+	divergences = append(divergences, ta.divergence.Detect(OscillatorOBV, highs, lows, symbolData.OBVHistory[history:])...)
+	divergences = append(divergences, ta.divergence.Detect(OscillatorRSI, highs, lows, symbolData.RSIHistory[history:])...)
+	return divergences
+}
@@ -0,0 +1,172 @@
+package indicators
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Named indicator types; a name is "<type>_<period>", e.g. "rsi_7" or "volume_sma_50"
+const (
+	IndicatorSMA       = "sma"
+	IndicatorEMA       = "ema"
+	IndicatorRSI       = "rsi"
+	IndicatorATR       = "atr"
+	IndicatorADX       = "adx"
+	IndicatorROC       = "roc"
+	IndicatorVolumeSMA = "volume_sma"
+)
+
+// IndicatorSpec describes a named indicator instance
+type IndicatorSpec struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Period int    `json:"period"`
+}
+
+// ParseIndicatorName parses a "<type>_<period>" indicator name
+func ParseIndicatorName(name string) (IndicatorSpec, error) {
+	separator := strings.LastIndex(name, "_")
+	if separator <= 0 {
+		return IndicatorSpec{}, fmt.Errorf("invalid indicator name %q: expected <type>_<period>", name)
+	}
+
+	indicatorType := strings.ToLower(name[:separator])
+	period, err := strconv.Atoi(name[separator+1:])
+	if err != nil || period <= 0 {
+		return IndicatorSpec{}, fmt.Errorf("invalid indicator name %q: period must be a positive integer", name)
+	}
+
+	switch indicatorType {
+	case IndicatorSMA, IndicatorEMA, IndicatorRSI, IndicatorATR, IndicatorADX, IndicatorROC, IndicatorVolumeSMA:
+	default:
+		return IndicatorSpec{}, fmt.Errorf("invalid indicator name %q: unknown type %q", name, indicatorType)
+	}
+
+	return IndicatorSpec{
+		Name:   strings.ToLower(name),
+		Type:   indicatorType,
+		Period: period,
+	}, nil
+}
+
+// NamedIndicator is a single-value streaming indicator registered under a name
+type NamedIndicator struct {
+	Spec IndicatorSpec
+	seen int // Candles fed, including the warm-up from stored candles
+
+	update func(candle types.OHLCV)
+	value  func() float64
+	ready  func() bool
+}
+
+// newNamedIndicator creates the streaming indicator for a spec
+func newNamedIndicator(spec IndicatorSpec) *NamedIndicator {
+	named := &NamedIndicator{Spec: spec}
+
+	switch spec.Type {
+	case IndicatorEMA:
+		ema := NewStreamingEMA(spec.Period)
+		named.update = func(candle types.OHLCV) { ema.Update(candle.Close) }
+		named.value, named.ready = ema.Value, ema.Ready
+	case IndicatorRSI:
+		rsi := NewStreamingRSI(spec.Period)
+		named.update = func(candle types.OHLCV) { rsi.Update(candle.Close) }
+		named.value, named.ready = rsi.Value, rsi.Ready
+	case IndicatorATR:
+		atr := NewStreamingATR(spec.Period)
+		named.update = func(candle types.OHLCV) { atr.Update(candle.High, candle.Low, candle.Close) }
+		named.value, named.ready = atr.Value, atr.Ready
+	case IndicatorADX:
+		adx := NewStreamingADX(spec.Period)
+		named.update = func(candle types.OHLCV) { adx.Update(candle.High, candle.Low, candle.Close) }
+		named.value, named.ready = adx.Value, adx.Ready
+	case IndicatorROC:
+		roc := NewStreamingROC(spec.Period)
+		named.update = func(candle types.OHLCV) { roc.Update(candle.Close) }
+		named.value, named.ready = roc.Value, roc.Ready
+	case IndicatorVolumeSMA:
+		sma := NewStreamingSMA(spec.Period)
+		named.update = func(candle types.OHLCV) { sma.Update(candle.Volume) }
+		named.value, named.ready = sma.Value, sma.Ready
+	default:
+		sma := NewStreamingSMA(spec.Period)
+		named.update = func(candle types.OHLCV) { sma.Update(candle.Close) }
+		named.value, named.ready = sma.Value, sma.Ready
+	}
+
+	return named
+}
+
+// Value returns the current indicator value, or 0 until ready
+func (n *NamedIndicator) Value() float64 {
+	return n.value()
+}
+
+// Ready returns true once the indicator has seen enough candles
+func (n *NamedIndicator) Ready() bool {
+	return n.ready()
+}
+
+// RegisterIndicator adds a named indicator for every symbol, warming it up from stored candles.
+// Registering an existing name is a no-op.
+func (ta *TechnicalAnalyzer) RegisterIndicator(name string) error {
+	spec, err := ParseIndicatorName(name)
+	if err != nil {
+		return err
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	if _, exists := ta.named[spec.Name]; exists {
+		return nil
+	}
+	ta.named[spec.Name] = spec
+
+	for _, symbolData := range ta.data {
+		indicator := newNamedIndicator(spec)
+		for _, candle := range symbolData.Candles {
+			indicator.update(candle)
+			indicator.seen++
+		}
+		symbolData.Named[spec.Name] = indicator
+	}
+	return nil
+}
+
+// GetNamedIndicator returns a named indicator's value for a symbol; ok is false if the
+// name is not registered or the indicator has not warmed up yet
+func (ta *TechnicalAnalyzer) GetNamedIndicator(symbol, name string) (float64, bool) {
+	ta.mu.RLock()
+	defer ta.mu.RUnlock()
+
+	symbolData, exists := ta.data[symbol]
+	if !exists {
+		return 0, false
+	}
+	indicator, exists := symbolData.Named[strings.ToLower(name)]
+	if !exists || !indicator.Ready() {
+		return 0, false
+	}
+	return indicator.Value(), true
+}
+
+// GetNamedIndicators returns the values of all warmed-up named indicators for a symbol
+func (ta *TechnicalAnalyzer) GetNamedIndicators(symbol string) map[string]float64 {
+	ta.mu.RLock()
+	defer ta.mu.RUnlock()
+
+	values := make(map[string]float64)
+	symbolData, exists := ta.data[symbol]
+	if !exists {
+		return values
+	}
+	for name, indicator := range symbolData.Named {
+		if indicator.Ready() {
+			values[name] = indicator.Value()
+		}
+	}
+	return values
+}
@@ -0,0 +1,496 @@
+package indicators
+
+import (
+	"math"
+)
+
+// ringBuffer is a fixed-size window of values with running sums
+type ringBuffer struct {
+	values []float64
+	head   int // Index of the next write
+	count  int
+	sum    float64
+	sumSq  float64
+}
+
+// newRingBuffer creates a ring buffer holding up to size values
+func newRingBuffer(size int) *ringBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &ringBuffer{values: make([]float64, size)}
+}
+
+// Push adds a value, evicting the oldest one once the window is full
+func (r *ringBuffer) Push(value float64) {
+	if r.count == len(r.values) {
+		old := r.values[r.head]
+		r.sum -= old
+		r.sumSq -= old * old
+	} else {
+		r.count++
+	}
+
+	r.values[r.head] = value
+	r.sum += value
+	r.sumSq += value * value
+	r.head = (r.head + 1) % len(r.values)
+
+	// Rebuild the running sums once per full cycle so float error cannot accumulate
+	if r.head == 0 && r.count == len(r.values) {
+		r.sum, r.sumSq = 0, 0
+		for _, v := range r.values {
+			r.sum += v
+			r.sumSq += v * v
+		}
+	}
+}
+
+// Full returns true once the window holds size values
+func (r *ringBuffer) Full() bool {
+	return r.count == len(r.values)
+}
+
+// Mean returns the average of the values in the window
+func (r *ringBuffer) Mean() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return r.sum / float64(r.count)
+}
+
+// StdDev returns the population standard deviation of the window
+func (r *ringBuffer) StdDev() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	mean := r.Mean()
+	return math.Sqrt(math.Max(0, r.sumSq/float64(r.count)-mean*mean))
+}
+
+// StreamingSMA is a simple moving average updated in O(1) per value
+type StreamingSMA struct {
+	window *ringBuffer
+}
+
+// NewStreamingSMA creates a simple moving average over period values
+func NewStreamingSMA(period int) *StreamingSMA {
+	return &StreamingSMA{window: newRingBuffer(period)}
+}
+
+// Update adds a value and returns the current average (0 until the window is full)
+func (s *StreamingSMA) Update(value float64) float64 {
+	s.window.Push(value)
+	return s.Value()
+}
+
+// Value returns the current average, or 0 until the window is full
+func (s *StreamingSMA) Value() float64 {
+	if !s.window.Full() {
+		return 0
+	}
+	return s.window.Mean()
+}
+
+// Ready returns true once period values have been seen
+func (s *StreamingSMA) Ready() bool {
+	return s.window.Full()
+}
+
+// StreamingEMA is an exponential moving average seeded with the SMA of the first period values
+type StreamingEMA struct {
+	period int
+	alpha  float64
+	seen   int
+	seed   float64
+	value  float64
+}
+
+// NewStreamingEMA creates an exponential moving average over period values
+func NewStreamingEMA(period int) *StreamingEMA {
+	if period < 1 {
+		period = 1
+	}
+	return &StreamingEMA{
+		period: period,
+		alpha:  2.0 / float64(period+1),
+	}
+}
+
+// Update adds a value and returns the current average (0 until seeded)
+func (e *StreamingEMA) Update(value float64) float64 {
+	e.seen++
+	if e.seen <= e.period {
+		e.seed += value
+		if e.seen == e.period {
+			e.value = e.seed / float64(e.period)
+		}
+		return e.Value()
+	}
+
+	e.value += e.alpha * (value - e.value)
+	return e.value
+}
+
+// Value returns the current average, or 0 until seeded
+func (e *StreamingEMA) Value() float64 {
+	if !e.Ready() {
+		return 0
+	}
+	return e.value
+}
+
+// Ready returns true once period values have been seen
+func (e *StreamingEMA) Ready() bool {
+	return e.seen >= e.period
+}
+
+// StreamingRSI is Wilder's relative strength index
+type StreamingRSI struct {
+	period    int
+	seen      int // Price changes seen
+	prevClose float64
+	hasPrev   bool
+	avgGain   float64
+	avgLoss   float64
+}
+
+// NewStreamingRSI creates an RSI over period price changes
+func NewStreamingRSI(period int) *StreamingRSI {
+	if period < 1 {
+		period = 1
+	}
+	return &StreamingRSI{period: period}
+}
+
+// Update adds a close and returns the current RSI (0 until ready)
+func (r *StreamingRSI) Update(close float64) float64 {
+	if !r.hasPrev {
+		r.prevClose = close
+		r.hasPrev = true
+		return 0
+	}
+
+	change := close - r.prevClose
+	r.prevClose = close
+	gain, loss := math.Max(change, 0), math.Max(-change, 0)
+
+	r.seen++
+	if r.seen <= r.period {
+		// Simple average of the first period changes
+		r.avgGain += gain / float64(r.period)
+		r.avgLoss += loss / float64(r.period)
+	} else {
+		r.avgGain = (r.avgGain*float64(r.period-1) + gain) / float64(r.period)
+		r.avgLoss = (r.avgLoss*float64(r.period-1) + loss) / float64(r.period)
+	}
+
+	return r.Value()
+}
+
+// Value returns the current RSI in [0, 100], or 0 until ready
+func (r *StreamingRSI) Value() float64 {
+	if !r.Ready() {
+		return 0
+	}
+	if r.avgLoss == 0 {
+		return 100
+	}
+	return 100 - 100/(1+r.avgGain/r.avgLoss)
+}
+
+// Ready returns true once period price changes have been seen
+func (r *StreamingRSI) Ready() bool {
+	return r.seen >= r.period
+}
+
+// StreamingATR is Wilder's average true range
+type StreamingATR struct {
+	period    int
+	seen      int
+	prevClose float64
+	value     float64
+}
+
+// NewStreamingATR creates an ATR over period candles
+func NewStreamingATR(period int) *StreamingATR {
+	if period < 1 {
+		period = 1
+	}
+	return &StreamingATR{period: period}
+}
+
+// Update adds a candle and returns the current ATR (0 until ready)
+func (a *StreamingATR) Update(high, low, close float64) float64 {
+	trueRange := high - low
+	if a.seen > 0 {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(high-a.prevClose), math.Abs(low-a.prevClose)))
+	}
+	a.prevClose = close
+
+	a.seen++
+	if a.seen <= a.period {
+		a.value += trueRange / float64(a.period)
+	} else {
+		a.value = (a.value*float64(a.period-1) + trueRange) / float64(a.period)
+	}
+
+	return a.Value()
+}
+
+// Value returns the current ATR, or 0 until ready
+func (a *StreamingATR) Value() float64 {
+	if !a.Ready() {
+		return 0
+	}
+	return a.value
+}
+
+// Ready returns true once period candles have been seen
+func (a *StreamingATR) Ready() bool {
+	return a.seen >= a.period
+}
+
+// StreamingMACD is the MACD line, signal line and histogram
+type StreamingMACD struct {
+	fast   *StreamingEMA
+	slow   *StreamingEMA
+	signal *StreamingEMA
+	macd   float64
+}
+
+// NewStreamingMACD creates a MACD with the given fast, slow and signal periods
+func NewStreamingMACD(fastPeriod, slowPeriod, signalPeriod int) *StreamingMACD {
+	return &StreamingMACD{
+		fast:   NewStreamingEMA(fastPeriod),
+		slow:   NewStreamingEMA(slowPeriod),
+		signal: NewStreamingEMA(signalPeriod),
+	}
+}
+
+// Update adds a close and returns the MACD line, signal line and histogram
+func (m *StreamingMACD) Update(close float64) (float64, float64, float64) {
+	fast := m.fast.Update(close)
+	slow := m.slow.Update(close)
+	if m.fast.Ready() && m.slow.Ready() {
+		m.macd = fast - slow
+		m.signal.Update(m.macd)
+	}
+	return m.Value()
+}
+
+// Value returns the MACD line, signal line and histogram; the histogram is 0 until the signal is ready
+func (m *StreamingMACD) Value() (float64, float64, float64) {
+	if !m.signal.Ready() {
+		return m.macd, 0, 0
+	}
+	signal := m.signal.Value()
+	return m.macd, signal, m.macd - signal
+}
+
+// Ready returns true once the signal line has been seeded (slow + signal - 1 closes)
+func (m *StreamingMACD) Ready() bool {
+	return m.signal.Ready()
+}
+
+// StreamingBollinger is a Bollinger band over a rolling window
+type StreamingBollinger struct {
+	window *ringBuffer
+	stdDev float64
+}
+
+// NewStreamingBollinger creates Bollinger bands over period values at stdDev deviations
+func NewStreamingBollinger(period int, stdDev float64) *StreamingBollinger {
+	return &StreamingBollinger{
+		window: newRingBuffer(period),
+		stdDev: stdDev,
+	}
+}
+
+// Update adds a close and returns the upper, middle and lower bands
+func (b *StreamingBollinger) Update(close float64) (float64, float64, float64) {
+	b.window.Push(close)
+	return b.Value()
+}
+
+// Value returns the upper, middle and lower bands, or zeros until the window is full
+func (b *StreamingBollinger) Value() (float64, float64, float64) {
+	if !b.window.Full() {
+		return 0, 0, 0
+	}
+	middle := b.window.Mean()
+	width := b.stdDev * b.window.StdDev()
+	return middle + width, middle, middle - width
+}
+
+// Ready returns true once period closes have been seen
+func (b *StreamingBollinger) Ready() bool {
+	return b.window.Full()
+}
+
+// StreamingADX is Wilder's average directional index, the strength of a trend regardless of its direction
+type StreamingADX struct {
+	period    int
+	seen      int // Candles seen
+	dxSeen    int
+	prevHigh  float64
+	prevLow   float64
+	prevClose float64
+	trueRange float64 // Wilder-smoothed sums
+	plusDM    float64
+	minusDM   float64
+	value     float64
+}
+
+// NewStreamingADX creates an ADX over period candles
+func NewStreamingADX(period int) *StreamingADX {
+	if period < 1 {
+		period = 1
+	}
+	return &StreamingADX{period: period}
+}
+
+// Update adds a candle and returns the current ADX (0 until ready)
+func (a *StreamingADX) Update(high, low, close float64) float64 {
+	a.seen++
+	if a.seen == 1 {
+		a.prevHigh, a.prevLow, a.prevClose = high, low, close
+		return 0
+	}
+
+	trueRange := math.Max(high-low, math.Max(math.Abs(high-a.prevClose), math.Abs(low-a.prevClose)))
+	upMove, downMove := high-a.prevHigh, a.prevLow-low
+	plusDM, minusDM := 0.0, 0.0
+	if upMove > downMove && upMove > 0 {
+		plusDM = upMove
+	}
+	if downMove > upMove && downMove > 0 {
+		minusDM = downMove
+	}
+	a.prevHigh, a.prevLow, a.prevClose = high, low, close
+
+	// The first period moves are summed, later ones smoothed
+	period := float64(a.period)
+	if a.seen <= a.period+1 {
+		a.trueRange += trueRange
+		a.plusDM += plusDM
+		a.minusDM += minusDM
+		if a.seen < a.period+1 {
+			return 0
+		}
+	} else {
+		a.trueRange += trueRange - a.trueRange/period
+		a.plusDM += plusDM - a.plusDM/period
+		a.minusDM += minusDM - a.minusDM/period
+	}
+
+	dx := 0.0
+	if a.trueRange > 0 {
+		plusDI, minusDI := a.plusDM/a.trueRange, a.minusDM/a.trueRange
+		if plusDI+minusDI > 0 {
+			dx = 100 * math.Abs(plusDI-minusDI) / (plusDI + minusDI)
+		}
+	}
+
+	a.dxSeen++
+	if a.dxSeen <= a.period {
+		a.value += dx / period
+	} else {
+		a.value = (a.value*(period-1) + dx) / period
+	}
+	return a.Value()
+}
+
+// Value returns the current ADX in [0, 100], or 0 until ready
+func (a *StreamingADX) Value() float64 {
+	if !a.Ready() {
+		return 0
+	}
+	return a.value
+}
+
+// Ready returns true once period directional movements have been averaged (2·period candles)
+func (a *StreamingADX) Ready() bool {
+	return a.dxSeen >= a.period
+}
+
+// StreamingROC is the rate of change, the percent change of the close over period candles
+type StreamingROC struct {
+	closes []float64 // The last period+1 closes, oldest first once full
+	head   int
+	count  int
+}
+
+// NewStreamingROC creates a rate of change over period candles
+func NewStreamingROC(period int) *StreamingROC {
+	if period < 1 {
+		period = 1
+	}
+	return &StreamingROC{closes: make([]float64, period+1)}
+}
+
+// Update adds a close and returns the current rate of change (0 until ready)
+func (r *StreamingROC) Update(close float64) float64 {
+	r.closes[r.head] = close
+	r.head = (r.head + 1) % len(r.closes)
+	if r.count < len(r.closes) {
+		r.count++
+	}
+	return r.Value()
+}
+
+// Value returns the change of the close over the period in percent, or 0 until ready
+func (r *StreamingROC) Value() float64 {
+	if !r.Ready() {
+		return 0
+	}
+	oldest := r.closes[r.head]
+	latest := r.closes[(r.head+len(r.closes)-1)%len(r.closes)]
+	if oldest == 0 {
+		return 0
+	}
+	return (latest - oldest) / oldest * 100
+}
+
+// Ready returns true once period price changes have been seen (period+1 candles)
+func (r *StreamingROC) Ready() bool {
+	return r.count == len(r.closes)
+}
+
+// StreamingOBV is on-balance volume, the running sum of volume added on up closes and subtracted on down
+// closes
+type StreamingOBV struct {
+	seen      int
+	prevClose float64
+	value     float64
+}
+
+// NewStreamingOBV creates an on-balance volume starting at 0
+func NewStreamingOBV() *StreamingOBV {
+	return &StreamingOBV{}
+}
+
+// Update adds a candle and returns the current on-balance volume
+func (o *StreamingOBV) Update(close, volume float64) float64 {
+	o.seen++
+	if o.seen > 1 {
+		switch {
+		case close > o.prevClose:
+			o.value += volume
+		case close < o.prevClose:
+			o.value -= volume
+		}
+	}
+	o.prevClose = close
+	return o.value
+}
+
+// Value returns the current on-balance volume; only its changes are meaningful, not its level
+func (o *StreamingOBV) Value() float64 {
+	return o.value
+}
+
+// Ready returns true once a price change has been seen (2 candles)
+func (o *StreamingOBV) Ready() bool {
+	return o.seen >= 2
+}
@@ -0,0 +1,229 @@
+package indicators
+
+import (
+	"aibot/internal/types"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cinar/indicator"
+)
+
+// syntheticCandles generates a deterministic oscillating price series
+func syntheticCandles(symbol string, count int) []types.OHLCV {
+	candles := make([]types.OHLCV, count)
+	start := time.Unix(0, 0).UTC()
+	for i := range candles {
+		price := 100 + 5*math.Sin(float64(i)/15) + 2*math.Sin(float64(i)/3.7)
+		candles[i] = types.NewOHLCV(symbol, start.Add(time.Duration(i)*time.Second),
+			price-0.2, price+0.5, price-0.5, price, 10+float64(i%7))
+	}
+	return candles
+}
+
+// recomputed holds indicator values calculated from scratch over every candle seen
+type recomputed struct {
+	sma, ema, rsi, roc, atr    float64
+	macd, macdSignal, macdHist float64
+	upper, middle, lower       float64
+	volumeSMA, obv             float64
+}
+
+// recompute calculates the analyzer's default indicators over the full candle history
+func recompute(candles []types.OHLCV) recomputed {
+	closes := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	for i, candle := range candles {
+		closes[i] = candle.Close
+		volumes[i] = candle.Volume
+	}
+
+	var values recomputed
+	values.sma = mean(closes[len(closes)-20:])
+	values.ema = emaSeries(closes, 20)[len(closes)-1]
+	values.rsi = wilderRSI(closes, 14)
+	values.roc = (closes[len(closes)-1] - closes[len(closes)-13]) / closes[len(closes)-13] * 100
+	values.atr = wilderATR(candles, 14)
+
+	fast, slow := emaSeries(closes, 12), emaSeries(closes, 26)
+	macdLine := make([]float64, 0, len(closes))
+	for i := 25; i < len(closes); i++ {
+		macdLine = append(macdLine, fast[i]-slow[i])
+	}
+	signal := emaSeries(macdLine, 9)
+	values.macd = macdLine[len(macdLine)-1]
+	values.macdSignal = signal[len(signal)-1]
+	values.macdHist = values.macd - values.macdSignal
+
+	window := closes[len(closes)-20:]
+	values.middle = mean(window)
+	variance := 0.0
+	for _, value := range window {
+		variance += (value - values.middle) * (value - values.middle)
+	}
+	width := 2 * math.Sqrt(variance/float64(len(window)))
+	values.upper, values.lower = values.middle+width, values.middle-width
+
+	values.volumeSMA = mean(volumes[len(volumes)-20:])
+	for i := 1; i < len(closes); i++ {
+		switch {
+		case closes[i] > closes[i-1]:
+			values.obv += volumes[i]
+		case closes[i] < closes[i-1]:
+			values.obv -= volumes[i]
+		}
+	}
+	return values
+}
+
+// mean returns the average of values
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// emaSeries returns the EMA at every value, seeded with the SMA of the first period values (0 before)
+func emaSeries(values []float64, period int) []float64 {
+	series := make([]float64, len(values))
+	if len(values) < period {
+		return series
+	}
+	alpha := 2.0 / float64(period+1)
+	series[period-1] = mean(values[:period])
+	for i := period; i < len(values); i++ {
+		series[i] = series[i-1] + alpha*(values[i]-series[i-1])
+	}
+	return series
+}
+
+// wilderRSI returns Wilder's RSI of the last close
+func wilderRSI(closes []float64, period int) float64 {
+	avgGain, avgLoss := 0.0, 0.0
+	for i := 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		gain, loss := math.Max(change, 0), math.Max(-change, 0)
+		if i <= period {
+			avgGain += gain / float64(period)
+			avgLoss += loss / float64(period)
+			continue
+		}
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+	}
+	if avgLoss == 0 {
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// wilderATR returns Wilder's ATR of the last candle
+func wilderATR(candles []types.OHLCV, period int) float64 {
+	atr := 0.0
+	for i, candle := range candles {
+		trueRange := candle.High - candle.Low
+		if i > 0 {
+			prevClose := candles[i-1].Close
+			trueRange = math.Max(trueRange, math.Max(math.Abs(candle.High-prevClose), math.Abs(candle.Low-prevClose)))
+		}
+		if i < period {
+			atr += trueRange / float64(period)
+			continue
+		}
+		atr = (atr*float64(period-1) + trueRange) / float64(period)
+	}
+	return atr
+}
+
+// expectClose fails the test if got differs from want beyond float rounding
+func expectClose(t *testing.T, candle int, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+		t.Fatalf("candle %d: streaming %s %.12f, recomputed %.12f", candle, name, got, want)
+	}
+}
+
+func TestStreamingMatchesRecompute(t *testing.T) {
+	candles := syntheticCandles("TEST", 600)
+	analyzer := NewTechnicalAnalyzer(AnalyzerConfig{MaxHistoryCandles: 200})
+
+	// MACD is the last default indicator to become ready, after 26 + 9 - 1 candles
+	const warmup = 34
+	for i, candle := range candles {
+		analyzer.AddCandle(candle)
+		if i+1 < warmup {
+			continue
+		}
+
+		got := analyzer.GetIndicatorValues("TEST")
+		want := recompute(candles[:i+1])
+		expectClose(t, i, "sma", got.SMA, want.sma)
+		expectClose(t, i, "ema", got.EMA, want.ema)
+		expectClose(t, i, "rsi", got.RSI, want.rsi)
+		expectClose(t, i, "roc", got.ROC, want.roc)
+		expectClose(t, i, "atr", got.ATR, want.atr)
+		expectClose(t, i, "macd", got.MACD, want.macd)
+		expectClose(t, i, "macd signal", got.MACDSignal, want.macdSignal)
+		expectClose(t, i, "macd histogram", got.MACDHist, want.macdHist)
+		expectClose(t, i, "bollinger upper", got.BollingerUpper, want.upper)
+		expectClose(t, i, "bollinger middle", got.BollingerMiddle, want.middle)
+		expectClose(t, i, "bollinger lower", got.BollingerLower, want.lower)
+		expectClose(t, i, "volume sma", got.VolumeSMA, want.volumeSMA)
+		expectClose(t, i, "obv", got.OBV, want.obv)
+	}
+}
+
+// benchmarkHistory is the candle history the analyzer benchmarks keep per symbol
+const benchmarkHistory = 200
+
+// BenchmarkAddCandleStreaming measures adding a candle with streaming indicator updates
+func BenchmarkAddCandleStreaming(b *testing.B) {
+	candles := syntheticCandles("BENCH", benchmarkHistory*4)
+	analyzer := NewTechnicalAnalyzer(AnalyzerConfig{MaxHistoryCandles: benchmarkHistory})
+	analyzer.AddCandles(candles[:benchmarkHistory])
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analyzer.AddCandle(candles[i%len(candles)])
+	}
+}
+
+// BenchmarkAddCandleRecompute measures recomputing every indicator over the history per candle, which is
+// what the analyzer did before its indicators were streamed
+func BenchmarkAddCandleRecompute(b *testing.B) {
+	candles := syntheticCandles("BENCH", benchmarkHistory*4)
+	window := make([]types.OHLCV, 0, benchmarkHistory+1)
+	window = append(window, candles[:benchmarkHistory]...)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		window = append(window[1:], candles[i%len(candles)])
+		recomputeIndicators(window)
+	}
+}
+
+// recomputeIndicators calculates every indicator over the full window with the indicator library
+func recomputeIndicators(candles []types.OHLCV) {
+	closes := make([]float64, len(candles))
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	for i, candle := range candles {
+		closes[i] = candle.Close
+		highs[i] = candle.High
+		lows[i] = candle.Low
+		volumes[i] = candle.Volume
+	}
+
+	indicator.Sma(20, closes)
+	indicator.Ema(20, closes)
+	indicator.Rsi(closes)
+	indicator.Macd(closes)
+	indicator.Atr(14, highs, lows, closes)
+	indicator.BollingerBands(closes)
+	indicator.Sma(20, volumes)
+}
@@ -0,0 +1,27 @@
+package indicators
+
+import (
+	"aibot/internal/types"
+	"math"
+)
+
+// RealizedVolatility returns the standard deviation of close-to-close log returns scaled to one day
+func RealizedVolatility(candles []types.OHLCV, candlesPerDay float64) float64 {
+	var sum, sumSq float64
+	count := 0
+	for i := 1; i < len(candles); i++ {
+		if candles[i-1].Close <= 0 || candles[i].Close <= 0 {
+			continue
+		}
+		r := math.Log(candles[i].Close / candles[i-1].Close)
+		sum += r
+		sumSq += r * r
+		count++
+	}
+	if count < 2 {
+		return 0
+	}
+	mean := sum / float64(count)
+	variance := (sumSq - float64(count)*mean*mean) / float64(count-1)
+	return math.Sqrt(math.Max(0, variance) * candlesPerDay)
+}
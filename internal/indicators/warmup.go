@@ -0,0 +1,140 @@
+package indicators
+
+import (
+	"sort"
+)
+
+// IndicatorWarmup is the warm-up progress of one indicator for a symbol
+type IndicatorWarmup struct {
+	Name     string `json:"name"`
+	Required int    `json:"required"` // Candles needed before the value is valid
+	Have     int    `json:"have"`     // Candles fed so far
+	Ready    bool   `json:"ready"`
+}
+
+// WarmupStatus is the warm-up progress of every indicator of a symbol
+type WarmupStatus struct {
+	Symbol     string            `json:"symbol"`
+	Ready      bool              `json:"ready"`    // All indicators and the minimum candle count are ready
+	Required   int               `json:"required"` // Candles needed by the slowest indicator or the minimum, whichever is larger
+	Have       int               `json:"have"`
+	Indicators []IndicatorWarmup `json:"indicators"`
+}
+
+// Progress returns the warm-up progress in [0, 1]
+func (w WarmupStatus) Progress() float64 {
+	if w.Ready || w.Required <= 0 || w.Have >= w.Required {
+		return 1
+	}
+	return float64(w.Have) / float64(w.Required)
+}
+
+// Pending returns the names of the indicators that are not ready yet
+func (w WarmupStatus) Pending() []string {
+	pending := make([]string, 0)
+	for _, indicator := range w.Indicators {
+		if !indicator.Ready {
+			pending = append(pending, indicator.Name)
+		}
+	}
+	return pending
+}
+
+// WarmupCandles returns the candles an indicator of the given type and period needs before its value is valid
+func WarmupCandles(indicatorType string, period int) int {
+	if period < 1 {
+		period = 1
+	}
+	switch indicatorType {
+	case IndicatorRSI, IndicatorROC:
+		return period + 1 // period price changes
+	case IndicatorADX:
+		return 2 * period // period directional movements, each averaged over period candles
+	default:
+		return period
+	}
+}
+
+// IsReady returns true once every indicator of the symbol has warmed up and the minimum candle count is met
+func (ta *TechnicalAnalyzer) IsReady(symbol string) bool {
+	return ta.GetWarmup(symbol).Ready
+}
+
+// GetWarmup returns the warm-up progress of every indicator of a symbol; a symbol without candles is not ready
+func (ta *TechnicalAnalyzer) GetWarmup(symbol string) WarmupStatus {
+	ta.mu.RLock()
+	defer ta.mu.RUnlock()
+
+	status := WarmupStatus{Symbol: symbol, Required: ta.config.MinCandles}
+	symbolData, exists := ta.data[symbol]
+	if exists {
+		status.Have = symbolData.Seen
+	}
+
+	add := func(name string, required, have int, ready bool) {
+		if required > status.Required {
+			status.Required = required
+		}
+		status.Indicators = append(status.Indicators, IndicatorWarmup{
+			Name:     name,
+			Required: required,
+			Have:     have,
+			Ready:    ready,
+		})
+	}
+
+	seen := status.Have
+	core := []struct {
+		name     string
+		required int
+		ready    func(*SymbolData) bool
+	}{
+		{"sma", ta.config.SMAPeriod, func(d *SymbolData) bool { return d.SMA.Ready() }},
+		{"ema", ta.config.EMAPeriod, func(d *SymbolData) bool { return d.EMA.Ready() }},
+		{"rsi", WarmupCandles(IndicatorRSI, ta.config.RSIPeriod), func(d *SymbolData) bool { return d.RSI.Ready() }},
+		{"macd", ta.config.MACDSlow + ta.config.MACDSignal - 1, func(d *SymbolData) bool { return d.MACD.Ready() }},
+		{"atr", ta.config.ATRPeriod, func(d *SymbolData) bool { return d.ATR.Ready() }},
+		{"bollinger", ta.config.BollingerPeriod, func(d *SymbolData) bool { return d.Bollinger.Ready() }},
+		{"roc", WarmupCandles(IndicatorROC, ta.config.ROCPeriod), func(d *SymbolData) bool { return d.ROC.Ready() }},
+		{"volume_sma", ta.config.VolumeSMAPeriod, func(d *SymbolData) bool { return d.VolumeSMA.Ready() }},
+		{"obv", 2, func(d *SymbolData) bool { return d.OBV.Ready() }},
+	}
+	for _, indicator := range core {
+		add(indicator.name, indicator.required, seen, exists && indicator.ready(symbolData))
+	}
+
+	names := make([]string, 0, len(ta.named))
+	for name := range ta.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec := ta.named[name]
+		have, ready := 0, false
+		if exists {
+			if indicator, ok := symbolData.Named[name]; ok {
+				have, ready = indicator.seen, indicator.Ready()
+			}
+		}
+		add(name, WarmupCandles(spec.Type, spec.Period), have, ready)
+	}
+
+	status.Ready = exists && seen >= ta.config.MinCandles && len(status.Pending()) == 0
+	return status
+}
+
+// GetWarmupStats returns warm-up statistics for every tracked symbol
+func (ta *TechnicalAnalyzer) GetWarmupStats() map[string]interface{} {
+	stats := make(map[string]interface{})
+	for _, symbol := range ta.GetSymbols() {
+		status := ta.GetWarmup(symbol)
+		stats[symbol] = map[string]interface{}{
+			"ready":    status.Ready,
+			"have":     status.Have,
+			"required": status.Required,
+			"progress": status.Progress(),
+			"pending":  status.Pending(),
+		}
+	}
+	return stats
+}
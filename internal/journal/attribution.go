@@ -0,0 +1,123 @@
+package journal
+
+import (
+	"aibot/internal/types"
+	"sort"
+)
+
+// UntaggedOrders is the tag fills of orders without a tag are attributed to, e.g. orders placed before
+// tagging or by an exchange that does not echo tags
+const UntaggedOrders = "untagged"
+
+// TagAttribution sums the fills of the orders of one tag, or of all tags of one component
+type TagAttribution struct {
+	Tag         string  `json:"tag"` // Order tag, or the component in per-component totals
+	Fills       int64   `json:"fills"`
+	Volume      float64 `json:"volume"`
+	Fees        float64 `json:"fees"`
+	RealizedPnL float64 `json:"realized_pnl"`
+	NetPnL      float64 `json:"net_pnl"` // Realized PnL minus fees
+	Wins        int64   `json:"wins"`    // Fills that realized a profit
+	Losses      int64   `json:"losses"`  // Fills that realized a loss
+}
+
+// AttributionReport breaks fills down by the component and the order tag that created them
+type AttributionReport struct {
+	Components []TagAttribution `json:"components"` // E.g. all grid levels together, by net PnL
+	Tags       []TagAttribution `json:"tags"`       // E.g. each grid level, by tag
+}
+
+// Attribution accumulates fills per order tag so PnL and costs can be traced to the component, and the
+// part of it, that created each order
+type Attribution struct {
+	tags map[string]*TagAttribution
+}
+
+// NewAttribution creates an empty attribution
+func NewAttribution() *Attribution {
+	return &Attribution{tags: make(map[string]*TagAttribution)}
+}
+
+// AttributeEntries attributes the fills of journal entries, e.g. a journal file read with ReadJournal
+func AttributeEntries(entries []JournalEntry) *Attribution {
+	attribution := NewAttribution()
+	for _, entry := range entries {
+		attribution.Add(entry)
+	}
+	return attribution
+}
+
+// Add attributes a journal entry to its tag if it is a fill
+func (a *Attribution) Add(entry JournalEntry) {
+	if entry.EventType != "fill" && entry.EventType != "partial_fill" {
+		return
+	}
+	tag := entry.Tag
+	if tag == "" {
+		tag = UntaggedOrders
+	}
+	totals, exists := a.tags[tag]
+	if !exists {
+		totals = &TagAttribution{Tag: tag}
+		a.tags[tag] = totals
+	}
+	totals.add(entry)
+}
+
+// Report returns the totals per component, best net PnL first, and per tag, in tag order
+func (a *Attribution) Report() AttributionReport {
+	report := AttributionReport{
+		Components: make([]TagAttribution, 0),
+		Tags:       make([]TagAttribution, 0, len(a.tags)),
+	}
+	components := make(map[string]*TagAttribution)
+	for tag, totals := range a.tags {
+		report.Tags = append(report.Tags, *totals)
+
+		component := types.ComponentFromTag(tag)
+		sum, exists := components[component]
+		if !exists {
+			sum = &TagAttribution{Tag: component}
+			components[component] = sum
+		}
+		sum.merge(*totals)
+	}
+	for _, sum := range components {
+		report.Components = append(report.Components, *sum)
+	}
+
+	sort.Slice(report.Tags, func(i, j int) bool { return report.Tags[i].Tag < report.Tags[j].Tag })
+	sort.Slice(report.Components, func(i, j int) bool {
+		if report.Components[i].NetPnL != report.Components[j].NetPnL {
+			return report.Components[i].NetPnL > report.Components[j].NetPnL
+		}
+		return report.Components[i].Tag < report.Components[j].Tag
+	})
+	return report
+}
+
+// add sums a fill
+func (t *TagAttribution) add(entry JournalEntry) {
+	t.Fills++
+	t.Volume += entry.Quantity * entry.Price
+	t.Fees += entry.Fee
+	t.RealizedPnL += entry.RealizedPnL
+	t.NetPnL += entry.RealizedPnL - entry.Fee
+	switch {
+	case entry.RealizedPnL > 0:
+		t.Wins++
+	case entry.RealizedPnL < 0:
+		t.Losses++
+	}
+}
+
+// merge sums the totals of another tag
+func (t *TagAttribution) merge(other TagAttribution) {
+	t.Fills += other.Fills
+	t.Volume += other.Volume
+	t.Fees += other.Fees
+	t.RealizedPnL += other.RealizedPnL
+	t.NetPnL += other.NetPnL
+	t.Wins += other.Wins
+	t.Losses += other.Losses
+}
@@ -0,0 +1,280 @@
+package journal
+
+import (
+	"aibot/internal/types"
+	"math"
+	"sync"
+	"time"
+)
+
+// Benchmark names
+const (
+	BenchmarkBuyAndHold = "buy_and_hold" // The initial balance invested in the symbol at the first sample
+	BenchmarkDCA        = "dca"          // The initial balance invested in equal parts at a fixed interval
+)
+
+// BenchmarkConfig holds configuration for comparing a session with passive benchmarks
+type BenchmarkConfig struct {
+	SampleInterval time.Duration `json:"sample_interval"` // Event time between equity samples (1m)
+	MaxPoints      int           `json:"max_points"`      // Samples kept; when full every other one is dropped and the interval doubles (2000)
+	DCAInterval    time.Duration `json:"dca_interval"`    // Time between the equal buys of the DCA benchmark (24h)
+}
+
+// BenchmarkPoint is a sample of the strategy equity and the benchmark equity at the same time
+type BenchmarkPoint struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Price      float64   `json:"price"`
+	Equity     float64   `json:"equity"` // Strategy equity marked at the price
+	BuyAndHold float64   `json:"buy_and_hold"`
+	DCA        float64   `json:"dca"`
+}
+
+// BenchmarkResult compares the strategy with one benchmark over the same samples
+type BenchmarkResult struct {
+	Name         string  `json:"name"`
+	Return       float64 `json:"return"`        // Benchmark return over the period, before fees
+	MaxDrawdown  float64 `json:"max_drawdown"`  // Fraction of the benchmark equity peak
+	ExcessReturn float64 `json:"excess_return"` // Strategy return minus benchmark return
+	Alpha        float64 `json:"alpha"`         // Strategy return per sample not explained by beta, summed over the period
+	Beta         float64 `json:"beta"`          // Sensitivity of strategy sample returns to benchmark sample returns
+	Correlation  float64 `json:"correlation"`   // Correlation of strategy and benchmark sample returns
+}
+
+// BenchmarkReport sets the strategy equity curve against buy-and-hold and DCA of the traded symbol
+type BenchmarkReport struct {
+	Symbol      string            `json:"symbol"`
+	Start       time.Time         `json:"start"`
+	End         time.Time         `json:"end"`
+	Samples     int               `json:"samples"`
+	Interval    string            `json:"interval"`     // Sampling interval of the curve after thinning
+	Return      float64           `json:"return"`       // Strategy return over the period, marked to market
+	MaxDrawdown float64           `json:"max_drawdown"` // Fraction of the strategy equity peak
+	Benchmarks  []BenchmarkResult `json:"benchmarks"`
+	Curve       []BenchmarkPoint  `json:"curve"`
+}
+
+// BenchmarkTracker samples the marked-to-market equity of a strategy trading one symbol, in event time, so
+// the session can be compared with simply holding the symbol. Equity is the initial balance plus the cash
+// flow of the symbol's fills plus the net position valued at the sampled price.
+type BenchmarkTracker struct {
+	config         BenchmarkConfig
+	symbol         string
+	initialBalance float64
+
+	cash     float64 // Initial balance plus fill cash flow net of fees
+	quantity float64 // Net position, negative when short
+	interval time.Duration
+	points   []BenchmarkPoint
+	last     BenchmarkPoint // Latest tick, so the curve ends at the end of the session
+
+	mu sync.Mutex
+}
+
+// NewBenchmarkTracker creates a benchmark tracker of a symbol
+func NewBenchmarkTracker(config BenchmarkConfig, symbol string, initialBalance float64) *BenchmarkTracker {
+	if config.SampleInterval == 0 {
+		config.SampleInterval = time.Minute // default
+	}
+	if config.MaxPoints < 2 {
+		config.MaxPoints = 2000 // default
+	}
+	if config.DCAInterval == 0 {
+		config.DCAInterval = 24 * time.Hour // default
+	}
+
+	return &BenchmarkTracker{
+		config:         config,
+		symbol:         symbol,
+		initialBalance: initialBalance,
+		cash:           initialBalance,
+		interval:       config.SampleInterval,
+	}
+}
+
+// RecordFill books the cash flow of a fill of the tracked symbol, with prices and fees in the currency of
+// the initial balance
+func (t *BenchmarkTracker) RecordFill(update types.OrderUpdate) {
+	if update.Symbol != t.symbol || !update.IsFill() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	quantity := update.LastFillQty
+	if update.Side == types.OrderSideSell {
+		quantity = -quantity
+	}
+	t.quantity += quantity
+	t.cash -= quantity*update.LastFillPrice + update.Fee
+}
+
+// Sample marks the strategy equity at the price of a tick of the tracked symbol, in the currency of the
+// initial balance; ticks between samples only move the end point of the curve
+func (t *BenchmarkTracker) Sample(symbol string, timestamp time.Time, price float64) {
+	if symbol != t.symbol || price <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	point := BenchmarkPoint{Timestamp: timestamp, Price: price, Equity: t.cash + t.quantity*price}
+	t.last = point
+	if len(t.points) > 0 && timestamp.Before(t.points[len(t.points)-1].Timestamp.Add(t.interval)) {
+		return
+	}
+
+	if len(t.points) >= t.config.MaxPoints {
+		// Keep every other sample from the first and sample half as often from now on
+		kept := t.points[:0]
+		for i := 0; i < len(t.points); i += 2 {
+			kept = append(kept, t.points[i])
+		}
+		t.points = kept
+		t.interval *= 2
+		if timestamp.Before(t.points[len(t.points)-1].Timestamp.Add(t.interval)) {
+			return
+		}
+	}
+	t.points = append(t.points, point)
+}
+
+// Report computes the benchmark curves over the sampled period and compares the strategy with them
+func (t *BenchmarkTracker) Report() *BenchmarkReport {
+	t.mu.Lock()
+	curve := append([]BenchmarkPoint(nil), t.points...)
+	if len(curve) > 0 && t.last.Timestamp.After(curve[len(curve)-1].Timestamp) {
+		curve = append(curve, t.last)
+	}
+	interval := t.interval
+	t.mu.Unlock()
+
+	report := &BenchmarkReport{
+		Symbol:   t.symbol,
+		Samples:  len(curve),
+		Interval: interval.String(),
+		Curve:    curve,
+	}
+	if len(curve) == 0 {
+		return report
+	}
+	report.Start = curve[0].Timestamp
+	report.End = curve[len(curve)-1].Timestamp
+
+	fillBuyAndHold(curve, t.initialBalance)
+	fillDCA(curve, t.initialBalance, t.config.DCAInterval)
+
+	equity := make([]float64, len(curve))
+	buyAndHold := make([]float64, len(curve))
+	dca := make([]float64, len(curve))
+	for i, point := range curve {
+		equity[i], buyAndHold[i], dca[i] = point.Equity, point.BuyAndHold, point.DCA
+	}
+	report.Return = totalReturn(equity)
+	report.MaxDrawdown = maxDrawdown(equity)
+	report.Benchmarks = []BenchmarkResult{
+		compareBenchmark(BenchmarkBuyAndHold, equity, buyAndHold),
+		compareBenchmark(BenchmarkDCA, equity, dca),
+	}
+	return report
+}
+
+// fillBuyAndHold values the initial balance invested at the first sampled price
+func fillBuyAndHold(curve []BenchmarkPoint, initialBalance float64) {
+	quantity := initialBalance / curve[0].Price
+	for i := range curve {
+		curve[i].BuyAndHold = quantity * curve[i].Price
+	}
+}
+
+// fillDCA values the initial balance invested in equal parts at the first sample at or after each buy time,
+// the first at the start of the period
+func fillDCA(curve []BenchmarkPoint, initialBalance float64, interval time.Duration) {
+	start := curve[0].Timestamp
+	buys := int(curve[len(curve)-1].Timestamp.Sub(start)/interval) + 1
+	amount := initialBalance / float64(buys)
+
+	cash, quantity, bought := initialBalance, 0.0, 0
+	for i := range curve {
+		for bought < buys && !curve[i].Timestamp.Before(start.Add(time.Duration(bought)*interval)) {
+			quantity += amount / curve[i].Price
+			cash -= amount
+			bought++
+		}
+		curve[i].DCA = cash + quantity*curve[i].Price
+	}
+}
+
+// compareBenchmark computes the benchmark metrics and the strategy's relative metrics from sample returns
+func compareBenchmark(name string, equity, benchmark []float64) BenchmarkResult {
+	result := BenchmarkResult{
+		Name:        name,
+		Return:      totalReturn(benchmark),
+		MaxDrawdown: maxDrawdown(benchmark),
+	}
+	result.ExcessReturn = totalReturn(equity) - result.Return
+
+	strategyReturns, benchmarkReturns := sampleReturns(equity), sampleReturns(benchmark)
+	n := float64(len(strategyReturns))
+	if n < 2 {
+		return result
+	}
+	var meanStrategy, meanBenchmark float64
+	for i := range strategyReturns {
+		meanStrategy += strategyReturns[i]
+		meanBenchmark += benchmarkReturns[i]
+	}
+	meanStrategy /= n
+	meanBenchmark /= n
+
+	var covariance, varStrategy, varBenchmark float64
+	for i := range strategyReturns {
+		ds, db := strategyReturns[i]-meanStrategy, benchmarkReturns[i]-meanBenchmark
+		covariance += ds * db
+		varStrategy += ds * ds
+		varBenchmark += db * db
+	}
+	if varBenchmark > 0 {
+		result.Beta = covariance / varBenchmark
+	}
+	if varStrategy > 0 && varBenchmark > 0 {
+		result.Correlation = covariance / math.Sqrt(varStrategy*varBenchmark)
+	}
+	result.Alpha = (meanStrategy - result.Beta*meanBenchmark) * n
+	return result
+}
+
+// sampleReturns returns the simple returns between consecutive samples, zero after a non-positive sample
+func sampleReturns(values []float64) []float64 {
+	if len(values) < 2 {
+		return nil
+	}
+	returns := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		if values[i-1] > 0 {
+			returns[i-1] = values[i]/values[i-1] - 1
+		}
+	}
+	return returns
+}
+
+// totalReturn returns the change from the first to the last value as a fraction of the first
+func totalReturn(values []float64) float64 {
+	if len(values) == 0 || values[0] <= 0 {
+		return 0
+	}
+	return values[len(values)-1]/values[0] - 1
+}
+
+// maxDrawdown returns the largest decline from a running peak as a fraction of the peak
+func maxDrawdown(values []float64) float64 {
+	var peak, drawdown float64
+	for _, value := range values {
+		if value > peak {
+			peak = value
+		}
+		if peak > 0 && (peak-value)/peak > drawdown {
+			drawdown = (peak - value) / peak
+		}
+	}
+	return drawdown
+}
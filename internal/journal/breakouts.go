@@ -0,0 +1,121 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Breakout outcomes
+const (
+	BreakoutConfirmed   = "confirmed"      // Held beyond the bound for the confirmation candles
+	BreakoutFalse       = "false_breakout" // Reversed back into the range
+	BreakoutUnconfirmed = "unconfirmed"    // Ended without being confirmed or reversing
+)
+
+// BreakoutRecord is a resolved breakout
+type BreakoutRecord struct {
+	ID         string    `json:"id"`
+	Symbol     string    `json:"symbol"`
+	Type       string    `json:"type"` // "up" or "down"
+	Confidence float64   `json:"confidence"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitPrice  float64   `json:"exit_price"` // Price when the outcome was decided
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Outcome    string    `json:"outcome"`
+	WasReal    bool      `json:"was_real"` // Counts as a success for the symbol's breakout success rate
+}
+
+// BreakoutHistoryConfig holds configuration for the breakout history
+type BreakoutHistoryConfig struct {
+	Directory string `json:"directory"` // Directory of the JSONL history file ("" disables the history)
+	FileName  string `json:"file_name"` // History file name (breakouts.jsonl)
+}
+
+// BreakoutHistory keeps every resolved breakout across restarts, one JSON line per breakout
+type BreakoutHistory struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewBreakoutHistory creates a breakout history, creating its directory; the file is created on the first record
+func NewBreakoutHistory(config BreakoutHistoryConfig) (*BreakoutHistory, error) {
+	if config.FileName == "" {
+		config.FileName = "breakouts.jsonl" // default
+	}
+	if err := os.MkdirAll(config.Directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create breakout history directory: %w", err)
+	}
+	return &BreakoutHistory{path: filepath.Join(config.Directory, config.FileName)}, nil
+}
+
+// Path returns the history file
+func (h *BreakoutHistory) Path() string {
+	return h.path
+}
+
+// Record appends a resolved breakout
+func (h *BreakoutHistory) Record(record BreakoutRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal breakout record: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open breakout history: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write breakout record: %w", err)
+	}
+	return file.Close()
+}
+
+// Records returns all resolved breakouts, oldest first; a missing file has none. A final line cut short by
+// a crash is skipped.
+func (h *BreakoutHistory) Records() ([]BreakoutRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	records := make([]BreakoutRecord, 0)
+	file, err := os.Open(h.path)
+	if os.IsNotExist(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breakout history: %w", err)
+	}
+	defer file.Close()
+
+	var parseErr error
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record BreakoutRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			parseErr = fmt.Errorf("invalid breakout record on line %d: %w", lineNumber, err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breakout history: %w", err)
+	}
+	return records, nil
+}
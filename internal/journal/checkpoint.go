@@ -0,0 +1,90 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CheckpointStore persists a single JSON document, replacing it atomically on every save so a crash
+// mid-write leaves the previous checkpoint intact
+type CheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewCheckpointStore creates a checkpoint store for the file at path
+func NewCheckpointStore(path string) (*CheckpointStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	return &CheckpointStore{path: path}, nil
+}
+
+// Path returns the checkpoint file path
+func (s *CheckpointStore) Path() string {
+	return s.path
+}
+
+// Save writes value as the current checkpoint
+func (s *CheckpointStore) Save(value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Load decodes the current checkpoint into value; returns false if no checkpoint has been saved
+func (s *CheckpointStore) Load(value interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return false, fmt.Errorf("failed to decode checkpoint %s: %w", s.path, err)
+	}
+	return true, nil
+}
+
+// Remove deletes the current checkpoint; removing a missing checkpoint is not an error
+func (s *CheckpointStore) Remove() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ReturnSample summarizes per-trade returns by their count, sum and sum of squares
+type ReturnSample struct {
+	Count int64   `json:"count"`
+	Sum   float64 `json:"sum"`
+	SumSq float64 `json:"sum_sq"`
+}
+
+// Mean returns the mean return, or 0 without returns
+func (s ReturnSample) Mean() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// Variance returns the sample variance of the returns, or 0 with fewer than two
+func (s ReturnSample) Variance() float64 {
+	if s.Count < 2 {
+		return 0
+	}
+	n := float64(s.Count)
+	return math.Max(0, (s.SumSq-s.Sum*s.Sum/n)/(n-1))
+}
+
+// VariantResult is the outcome of one experiment variant
+type VariantResult struct {
+	Name            string       `json:"name"`
+	Profile         string       `json:"profile"`
+	ConfigHash      string       `json:"config_hash"`
+	InitialCapital  float64      `json:"initial_capital"`
+	FinalBalance    float64      `json:"final_balance"`
+	NetPnL          float64      `json:"net_pnl"`
+	Return          float64      `json:"return"` // Net PnL as a fraction of the variant's capital
+	MaxDrawdown     float64      `json:"max_drawdown"`
+	Trades          int64        `json:"trades"`
+	WinRate         float64      `json:"win_rate"`
+	Sharpe          float64      `json:"sharpe"`
+	MeanTradeReturn float64      `json:"mean_trade_return"`
+	TradeReturns    ReturnSample `json:"trade_returns"`
+}
+
+// ExperimentReport compares the per-trade returns of two variants with Welch's t-test
+type ExperimentReport struct {
+	Name             string          `json:"name"`
+	Symbol           string          `json:"symbol"`
+	Start            time.Time       `json:"start"`
+	End              time.Time       `json:"end"`
+	Variants         []VariantResult `json:"variants"`
+	TStatistic       float64         `json:"t_statistic"` // Positive when the first variant's mean trade return is higher
+	DegreesOfFreedom float64         `json:"degrees_of_freedom"`
+	PValue           float64         `json:"p_value"` // Two-tailed
+	Confidence       float64         `json:"confidence"`
+	Winner           string          `json:"winner,omitempty"` // Empty unless the difference is significant
+	Conclusion       string          `json:"conclusion"`
+}
+
+// minExperimentTrades is the number of trades each variant needs before the test is run
+const minExperimentTrades = 2
+
+// CompareVariants runs Welch's t-test on the per-trade returns of two variants and names the winner when
+// the difference is significant at the confidence level
+func CompareVariants(a, b VariantResult, confidence float64) ExperimentReport {
+	report := ExperimentReport{
+		Variants:   []VariantResult{a, b},
+		Confidence: confidence,
+	}
+	if a.TradeReturns.Count < minExperimentTrades || b.TradeReturns.Count < minExperimentTrades {
+		report.PValue = 1
+		report.Conclusion = fmt.Sprintf("Inconclusive: each variant needs at least %d trades (%s: %d, %s: %d)",
+			minExperimentTrades, a.Name, a.TradeReturns.Count, b.Name, b.TradeReturns.Count)
+		return report
+	}
+
+	report.TStatistic, report.DegreesOfFreedom, report.PValue = WelchTTest(a.TradeReturns, b.TradeReturns)
+	if report.PValue > 1-confidence {
+		report.Conclusion = fmt.Sprintf("No significant difference at %.0f%% confidence (p = %.3f)", confidence*100, report.PValue)
+		return report
+	}
+	winner, loser := a, b
+	if report.TStatistic < 0 {
+		winner, loser = b, a
+	}
+	report.Winner = winner.Name
+	report.Conclusion = fmt.Sprintf("%s wins at %.0f%% confidence (p = %.3f): mean trade return %.4f%% vs %.4f%% for %s",
+		winner.Name, confidence*100, report.PValue, winner.MeanTradeReturn*100, loser.MeanTradeReturn*100, loser.Name)
+	return report
+}
+
+// WelchTTest returns the t statistic, the Welch-Satterthwaite degrees of freedom and the two-tailed p-value
+// of the difference between the means of two samples with possibly unequal variances
+func WelchTTest(a, b ReturnSample) (float64, float64, float64) {
+	if a.Count < 2 || b.Count < 2 {
+		return 0, 0, 1
+	}
+	varA := a.Variance() / float64(a.Count)
+	varB := b.Variance() / float64(b.Count)
+	diff := a.Mean() - b.Mean()
+	if varA+varB == 0 {
+		if diff == 0 {
+			return 0, 0, 1
+		}
+		// Constant returns that differ leave no doubt
+		return math.Copysign(math.Inf(1), diff), float64(a.Count + b.Count - 2), 0
+	}
+
+	t := diff / math.Sqrt(varA+varB)
+	df := (varA + varB) * (varA + varB) /
+		(varA*varA/float64(a.Count-1) + varB*varB/float64(b.Count-1))
+	return t, df, studentTwoTailed(t, df)
+}
+
+// studentTwoTailed returns P(|T| >= |t|) for Student's t distribution with df degrees of freedom
+func studentTwoTailed(t, df float64) float64 {
+	return regularizedBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// regularizedBeta returns the regularized incomplete beta function I_x(a, b)
+func regularizedBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lgab, _ := math.Lgamma(a + b)
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly below the mean; use the symmetry relation above it
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(x, a, b) / a
+	}
+	return 1 - front*betaFraction(1-x, b, a)/b
+}
+
+// betaFraction evaluates the continued fraction of the incomplete beta function by Lentz's method
+func betaFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 1e-12
+		tiny          = 1e-300
+	)
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+
+		// Even step
+		numerator := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		result *= d * c
+
+		// Odd step
+		numerator = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		result *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return result
+}
+
+// SaveExperimentReport writes a report as indented JSON into directory, named after the experiment and its
+// start time, and returns the file path
+func SaveExperimentReport(directory string, report ExperimentReport) (string, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", fmt.Errorf("failed to create experiment directory: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal experiment report: %w", err)
+	}
+	path := filepath.Join(directory, fmt.Sprintf("%s-%s.json", report.Name, report.Start.UTC().Format("20060102-150405")))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write experiment report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,311 @@
+package journal
+
+import (
+	"aibot/internal/types"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// IntentStatus is the execution state of an order intent
+type IntentStatus string
+
+const (
+	IntentPending IntentStatus = "pending" // Persisted but not yet acknowledged by the exchange
+	IntentAcked   IntentStatus = "acked"
+	IntentFailed  IntentStatus = "failed"
+	IntentExpired IntentStatus = "expired" // Left pending by a crash for too long to be retried
+)
+
+// IntentQueueConfig holds configuration for the order intent queue
+type IntentQueueConfig struct {
+	Directory string        `json:"directory"` // Directory for the JSONL intent log ("" keeps intents in memory only)
+	FileName  string        `json:"file_name"` // Intent log file name (intents.jsonl)
+	MaxAge    time.Duration `json:"max_age"`   // Pending intents older than this expire instead of being retried (5m)
+}
+
+// OrderIntent is an order accepted for execution, persisted before it is submitted
+type OrderIntent struct {
+	ID        string       `json:"id"`
+	Tag       string       `json:"tag"` // Strategy step that produced the order, e.g. "breakout-entry"
+	Order     types.Order  `json:"order"`
+	Status    IntentStatus `json:"status"`
+	OrderID   string       `json:"order_id,omitempty"` // Exchange order ID once acknowledged
+	Attempts  int          `json:"attempts"`
+	Error     string       `json:"error,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// IntentQueue is a write-ahead log between signal processing and order execution. Every state change
+// is appended and synced to disk, so intents still pending after a crash can be resolved on restart.
+type IntentQueue struct {
+	config  IntentQueueConfig
+	file    *os.File
+	pending map[string]*OrderIntent
+	seq     int64
+
+	// Statistics
+	enqueued  int64
+	acked     int64
+	failed    int64
+	expired   int64
+	recovered int64 // Intents found pending when the log was opened
+
+	mu sync.Mutex
+}
+
+// NewIntentQueue creates an intent queue, loading intents left pending by a previous run and
+// compacting the log down to them
+func NewIntentQueue(config IntentQueueConfig) (*IntentQueue, error) {
+	// Set defaults
+	if config.FileName == "" {
+		config.FileName = "intents.jsonl"
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = 5 * time.Minute
+	}
+
+	queue := &IntentQueue{
+		config:  config,
+		pending: make(map[string]*OrderIntent),
+	}
+	if config.Directory == "" {
+		return queue, nil
+	}
+
+	if err := os.MkdirAll(config.Directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create intent directory: %w", err)
+	}
+	path := filepath.Join(config.Directory, config.FileName)
+	if err := queue.load(path); err != nil {
+		return nil, err
+	}
+	if err := queue.compact(path); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open intent log: %w", err)
+	}
+	queue.file = file
+	queue.recovered = int64(len(queue.pending))
+	return queue, nil
+}
+
+// load replays the intent log; the last record of an intent holds its current state
+func (q *IntentQueue) load(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open intent log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var intent OrderIntent
+		if err := json.Unmarshal(scanner.Bytes(), &intent); err != nil {
+			continue // A record torn by a crash mid-write was never acted on
+		}
+		if intent.Status == IntentPending {
+			q.pending[intent.ID] = &intent
+		} else {
+			delete(q.pending, intent.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read intent log: %w", err)
+	}
+	return nil
+}
+
+// compact rewrites the log with only the pending intents
+func (q *IntentQueue) compact(path string) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to compact intent log: %w", err)
+	}
+	for _, intent := range q.sortedPending() {
+		data, err := json.Marshal(intent)
+		if err == nil {
+			_, err = file.Write(append(data, '\n'))
+		}
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("failed to compact intent log: %w", err)
+		}
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to compact intent log: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to compact intent log: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to compact intent log: %w", err)
+	}
+	return nil
+}
+
+// Enqueue persists an order before it is submitted; the order must carry its client order ID
+func (q *IntentQueue) Enqueue(order *types.Order, tag string) (OrderIntent, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	q.seq++
+	intent := &OrderIntent{
+		ID:        fmt.Sprintf("%s-%d", now.Format("20060102150405.000000"), q.seq),
+		Tag:       tag,
+		Order:     *order,
+		Status:    IntentPending,
+		Attempts:  1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := q.write(intent); err != nil {
+		return OrderIntent{}, err
+	}
+	q.pending[intent.ID] = intent
+	q.enqueued++
+	return *intent, nil
+}
+
+// Retry records another submission attempt of a pending intent
+func (q *IntentQueue) Retry(id string) error {
+	return q.update(id, func(intent *OrderIntent) {
+		intent.Attempts++
+	})
+}
+
+// Ack marks an intent as accepted by the exchange
+func (q *IntentQueue) Ack(id, orderID string) error {
+	return q.update(id, func(intent *OrderIntent) {
+		intent.Status = IntentAcked
+		intent.OrderID = orderID
+		q.acked++
+	})
+}
+
+// Fail marks an intent as rejected; it will not be retried
+func (q *IntentQueue) Fail(id string, cause error) error {
+	return q.update(id, func(intent *OrderIntent) {
+		intent.Status = IntentFailed
+		intent.Error = cause.Error()
+		q.failed++
+	})
+}
+
+// Expire gives up on an intent that stayed pending for longer than the maximum age
+func (q *IntentQueue) Expire(id string) error {
+	return q.update(id, func(intent *OrderIntent) {
+		intent.Status = IntentExpired
+		q.expired++
+	})
+}
+
+// update applies a state change to a pending intent and persists it
+func (q *IntentQueue) update(id string, apply func(intent *OrderIntent)) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	intent, exists := q.pending[id]
+	if !exists {
+		return fmt.Errorf("order intent %s is not pending", id)
+	}
+	updated := *intent
+	apply(&updated)
+	updated.UpdatedAt = time.Now()
+	if err := q.write(&updated); err != nil {
+		return err
+	}
+
+	if updated.Status == IntentPending {
+		q.pending[id] = &updated
+	} else {
+		delete(q.pending, id)
+	}
+	return nil
+}
+
+// write appends an intent record and syncs it to disk; caller must hold mu
+func (q *IntentQueue) write(intent *OrderIntent) error {
+	if q.file == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(intent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal order intent: %w", err)
+	}
+	if _, err := q.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write order intent: %w", err)
+	}
+	if err := q.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync order intent: %w", err)
+	}
+	return nil
+}
+
+// Pending returns the pending intents, oldest first
+func (q *IntentQueue) Pending() []OrderIntent {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.sortedPending()
+}
+
+// sortedPending copies the pending intents ordered by creation; caller must hold mu
+func (q *IntentQueue) sortedPending() []OrderIntent {
+	intents := make([]OrderIntent, 0, len(q.pending))
+	for _, intent := range q.pending {
+		intents = append(intents, *intent)
+	}
+	sort.Slice(intents, func(i, j int) bool { return intents[i].CreatedAt.Before(intents[j].CreatedAt) })
+	return intents
+}
+
+// MaxAge returns how long a pending intent may still be retried
+func (q *IntentQueue) MaxAge() time.Duration {
+	return q.config.MaxAge
+}
+
+// GetIntentStats returns intent queue statistics
+func (q *IntentQueue) GetIntentStats() map[string]interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return map[string]interface{}{
+		"persistent": q.file != nil,
+		"pending":    len(q.pending),
+		"enqueued":   q.enqueued,
+		"acked":      q.acked,
+		"failed":     q.failed,
+		"expired":    q.expired,
+		"recovered":  q.recovered,
+	}
+}
+
+// Close closes the intent log
+func (q *IntentQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.file == nil {
+		return nil
+	}
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
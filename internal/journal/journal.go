@@ -0,0 +1,220 @@
+package journal
+
+import (
+	"aibot/internal/types"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TradeJournal records executed fills and order events for post-trade analysis
+type TradeJournal struct {
+	config      JournalConfig
+	entries     []JournalEntry
+	file        *os.File
+	stats       JournalStats
+	attribution *Attribution // Fills of the session per order tag
+	mu          sync.RWMutex
+}
+
+// JournalConfig holds configuration for the trade journal
+type JournalConfig struct {
+	Directory  string `json:"directory"`   // Directory for the JSONL journal file ("" disables persistence)
+	FileName   string `json:"file_name"`   // Journal file name (trades.jsonl)
+	MaxEntries int    `json:"max_entries"` // In-memory entries kept for queries (1000)
+}
+
+// JournalEntry represents a single journaled order event
+type JournalEntry struct {
+	ID            string             `json:"id"`
+	OrderID       string             `json:"order_id"`
+	ClientOrderID string             `json:"client_order_id,omitempty"`
+	Symbol        string             `json:"symbol"`
+	EventType     string             `json:"event_type"` // "fill", "partial_fill", "cancel", "reject", "resync"
+	Side          types.OrderSide    `json:"side"`
+	PositionType  types.PositionType `json:"position_type"`
+	Quantity      float64            `json:"quantity"`
+	Price         float64            `json:"price"`
+	Fee           float64            `json:"fee"`
+	RealizedPnL   float64            `json:"realized_pnl"`
+	Mode          string             `json:"mode,omitempty"` // Bot mode when the event arrived
+	Reason        string             `json:"reason,omitempty"`
+	Tag           string             `json:"tag,omitempty"` // Component that created the order, e.g. "grid:level-3"
+	Timestamp     time.Time          `json:"timestamp"`
+}
+
+// EventResync is the event type of a position resynced with the executor after missed order updates; the
+// entry holds the size booked to match the executor
+const EventResync = "resync"
+
+// JournalStats summarizes journaled activity
+type JournalStats struct {
+	TotalEntries int64     `json:"total_entries"`
+	Fills        int64     `json:"fills"`
+	Cancels      int64     `json:"cancels"`
+	Rejects      int64     `json:"rejects"`
+	TotalVolume  float64   `json:"total_volume"`
+	TotalFees    float64   `json:"total_fees"`
+	RealizedPnL  float64   `json:"realized_pnl"`
+	LastEntry    time.Time `json:"last_entry"`
+}
+
+// NewTradeJournal creates a new trade journal, opening the journal file if persistence is enabled
+func NewTradeJournal(config JournalConfig) (*TradeJournal, error) {
+	// Set defaults
+	if config.FileName == "" {
+		config.FileName = "trades.jsonl"
+	}
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 1000
+	}
+
+	journal := &TradeJournal{
+		config:      config,
+		entries:     make([]JournalEntry, 0),
+		attribution: NewAttribution(),
+	}
+
+	if config.Directory != "" {
+		if err := os.MkdirAll(config.Directory, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create journal directory: %w", err)
+		}
+		path := filepath.Join(config.Directory, config.FileName)
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open journal file: %w", err)
+		}
+		journal.file = file
+	}
+
+	return journal, nil
+}
+
+// RecordOrderUpdate converts an order update into a journal entry and records it
+func (j *TradeJournal) RecordOrderUpdate(update types.OrderUpdate, mode string) error {
+	entry := JournalEntry{
+		OrderID:       update.OrderID,
+		ClientOrderID: update.ClientOrderID,
+		Symbol:        update.Symbol,
+		EventType:     eventTypeForStatus(update.Status),
+		Side:          update.Side,
+		PositionType:  update.PositionType,
+		Quantity:      update.LastFillQty,
+		Price:         update.LastFillPrice,
+		Fee:           update.Fee,
+		RealizedPnL:   update.RealizedPnL,
+		Mode:          mode,
+		Reason:        update.Reason,
+		Tag:           update.Tag,
+		Timestamp:     update.Timestamp,
+	}
+	return j.Record(entry)
+}
+
+// Record appends an entry to the journal
+func (j *TradeJournal) Record(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	j.stats.TotalEntries++
+	if entry.ID == "" {
+		entry.ID = fmt.Sprintf("%s-%d", entry.Timestamp.Format("20060102150405"), j.stats.TotalEntries)
+	}
+
+	switch entry.EventType {
+	case "fill", "partial_fill":
+		j.stats.Fills++
+		j.stats.TotalVolume += entry.Quantity * entry.Price
+	case "cancel":
+		j.stats.Cancels++
+	case "reject":
+		j.stats.Rejects++
+	}
+	j.stats.TotalFees += entry.Fee
+	j.stats.RealizedPnL += entry.RealizedPnL
+	j.stats.LastEntry = entry.Timestamp
+	j.attribution.Add(entry)
+
+	j.entries = append(j.entries, entry)
+	if len(j.entries) > j.config.MaxEntries {
+		j.entries = j.entries[len(j.entries)-j.config.MaxEntries:]
+	}
+
+	if j.file == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetEntries returns the most recent entries, oldest first
+func (j *TradeJournal) GetEntries(limit int) []JournalEntry {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	start := 0
+	if limit > 0 && len(j.entries) > limit {
+		start = len(j.entries) - limit
+	}
+
+	entries := make([]JournalEntry, len(j.entries)-start)
+	copy(entries, j.entries[start:])
+	return entries
+}
+
+// GetStats returns journal statistics
+func (j *TradeJournal) GetStats() JournalStats {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.stats
+}
+
+// GetAttribution returns the session's fills summed per component and per order tag
+func (j *TradeJournal) GetAttribution() AttributionReport {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.attribution.Report()
+}
+
+// Close flushes and closes the journal file
+func (j *TradeJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// eventTypeForStatus maps an order status to a journal event type
+func eventTypeForStatus(status types.OrderStatus) string {
+	switch status {
+	case types.OrderStatusFilled:
+		return "fill"
+	case types.OrderStatusPartial:
+		return "partial_fill"
+	case types.OrderStatusCancelled:
+		return "cancel"
+	case types.OrderStatusRejected:
+		return "reject"
+	default:
+		return string(status)
+	}
+}
@@ -0,0 +1,276 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Session result sources
+const (
+	SourceLive       = "live"
+	SourceSimulation = "simulation"
+	SourceBacktest   = "backtest"
+)
+
+// Leaderboard ranking metrics
+const (
+	MetricSharpe   = "sharpe"   // Mean per-trade Sharpe ratio
+	MetricReturn   = "return"   // Mean session return
+	MetricDrawdown = "drawdown" // Mean max drawdown, lowest first
+	MetricCalmar   = "calmar"   // Mean return over mean max drawdown
+)
+
+// ConfigSummary identifies the configuration a session ran with
+type ConfigSummary struct {
+	Hash       string          `json:"config_hash"`          // Hash of the strategy and risk parameters
+	Profile    string          `json:"profile,omitempty"`    // Parameter profile the parameters came from
+	Source     string          `json:"source"`               // "live", "simulation" or "backtest"
+	Parameters json.RawMessage `json:"parameters,omitempty"` // Strategy and risk parameters that were hashed
+}
+
+// SessionResult summarizes one live session or backtest
+type SessionResult struct {
+	ConfigSummary
+	Symbol         string    `json:"symbol"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	InitialBalance float64   `json:"initial_balance"`
+	NetPnL         float64   `json:"net_pnl"`
+	Return         float64   `json:"return"`       // Net PnL as a fraction of the initial balance
+	MaxDrawdown    float64   `json:"max_drawdown"` // Fraction of the equity peak
+	Sharpe         float64   `json:"sharpe"`       // Mean over standard deviation of per-trade returns
+	Trades         int64     `json:"trades"`
+	WinRate        float64   `json:"win_rate"`
+}
+
+// PerformanceDBConfig holds configuration for the performance database
+type PerformanceDBConfig struct {
+	Directory string `json:"directory"` // Directory of the JSONL database file ("" disables the database)
+	FileName  string `json:"file_name"` // Database file name (performance.jsonl)
+}
+
+// PerformanceDB keeps the results of every session across restarts, one JSON line per session
+type PerformanceDB struct {
+	path string
+	mu   sync.Mutex
+}
+
+// LeaderboardQuery selects and ranks the sessions of a leaderboard
+type LeaderboardQuery struct {
+	Source      string `json:"source,omitempty"` // Only sessions from this source ("" for all)
+	Symbol      string `json:"symbol,omitempty"` // Only sessions of this symbol ("" for all)
+	Metric      string `json:"metric"`           // Ranking metric (sharpe)
+	MinSessions int    `json:"min_sessions"`     // Configurations with fewer sessions are left out (1)
+	Limit       int    `json:"limit"`            // Top configurations returned (0 for all)
+}
+
+// ConfigRanking aggregates the sessions of one configuration
+type ConfigRanking struct {
+	Rank           int             `json:"rank"`
+	ConfigHash     string          `json:"config_hash"`
+	Profile        string          `json:"profile,omitempty"`
+	Sessions       int             `json:"sessions"`
+	ProfitableRate float64         `json:"profitable_rate"` // Fraction of sessions with a positive net PnL
+	MeanReturn     float64         `json:"mean_return"`
+	WorstReturn    float64         `json:"worst_return"`
+	MeanDrawdown   float64         `json:"mean_drawdown"`
+	WorstDrawdown  float64         `json:"worst_drawdown"`
+	MeanSharpe     float64         `json:"mean_sharpe"`
+	Trades         int64           `json:"trades"`
+	Score          float64         `json:"score"` // Value of the ranking metric
+	LastSeen       time.Time       `json:"last_seen"`
+	Parameters     json.RawMessage `json:"parameters,omitempty"` // Parameters of the latest session
+}
+
+// NewPerformanceDB creates a performance database, creating its directory; the file is created on the first record
+func NewPerformanceDB(config PerformanceDBConfig) (*PerformanceDB, error) {
+	if config.FileName == "" {
+		config.FileName = "performance.jsonl" // default
+	}
+	if err := os.MkdirAll(config.Directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create performance database directory: %w", err)
+	}
+	return &PerformanceDB{path: filepath.Join(config.Directory, config.FileName)}, nil
+}
+
+// OpenPerformanceDB opens an existing performance database file for queries
+func OpenPerformanceDB(path string) *PerformanceDB {
+	return &PerformanceDB{path: path}
+}
+
+// Path returns the database file
+func (db *PerformanceDB) Path() string {
+	return db.path
+}
+
+// Record appends a session result
+func (db *PerformanceDB) Record(result SessionResult) error {
+	line, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session result: %w", err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	file, err := os.OpenFile(db.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open performance database: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write session result: %w", err)
+	}
+	return file.Close()
+}
+
+// Results returns all recorded sessions, oldest first. A final line cut short by a crash is skipped.
+func (db *PerformanceDB) Results() ([]SessionResult, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	file, err := os.Open(db.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open performance database: %w", err)
+	}
+	defer file.Close()
+
+	results := make([]SessionResult, 0)
+	var parseErr error
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var result SessionResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			parseErr = fmt.Errorf("invalid session result on line %d: %w", lineNumber, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read performance database: %w", err)
+	}
+	return results, nil
+}
+
+// Leaderboard groups sessions by configuration hash and ranks the configurations by the query's metric.
+// Averages over several sessions reward parameters that hold up, not one lucky run.
+func Leaderboard(results []SessionResult, query LeaderboardQuery) ([]ConfigRanking, error) {
+	if query.Metric == "" {
+		query.Metric = MetricSharpe // default
+	}
+	if query.MinSessions == 0 {
+		query.MinSessions = 1 // default
+	}
+	switch query.Metric {
+	case MetricSharpe, MetricReturn, MetricDrawdown, MetricCalmar:
+	default:
+		return nil, fmt.Errorf("unknown ranking metric %q: use sharpe, return, drawdown or calmar", query.Metric)
+	}
+
+	byHash := make(map[string]*ConfigRanking)
+	for _, result := range results {
+		if query.Source != "" && result.Source != query.Source {
+			continue
+		}
+		if query.Symbol != "" && !strings.EqualFold(result.Symbol, query.Symbol) {
+			continue
+		}
+
+		ranking, ok := byHash[result.Hash]
+		if !ok {
+			ranking = &ConfigRanking{
+				ConfigHash:  result.Hash,
+				WorstReturn: math.Inf(1),
+			}
+			byHash[result.Hash] = ranking
+		}
+		ranking.Sessions++
+		if result.NetPnL > 0 {
+			ranking.ProfitableRate++
+		}
+		ranking.MeanReturn += result.Return
+		ranking.WorstReturn = math.Min(ranking.WorstReturn, result.Return)
+		ranking.MeanDrawdown += result.MaxDrawdown
+		ranking.WorstDrawdown = math.Max(ranking.WorstDrawdown, result.MaxDrawdown)
+		ranking.MeanSharpe += result.Sharpe
+		ranking.Trades += result.Trades
+		if !result.End.Before(ranking.LastSeen) {
+			ranking.LastSeen = result.End
+			ranking.Profile = result.Profile
+			ranking.Parameters = result.Parameters
+		}
+	}
+
+	rankings := make([]ConfigRanking, 0, len(byHash))
+	for _, ranking := range byHash {
+		if ranking.Sessions < query.MinSessions {
+			continue
+		}
+		sessions := float64(ranking.Sessions)
+		ranking.ProfitableRate /= sessions
+		ranking.MeanReturn /= sessions
+		ranking.MeanDrawdown /= sessions
+		ranking.MeanSharpe /= sessions
+
+		switch query.Metric {
+		case MetricSharpe:
+			ranking.Score = ranking.MeanSharpe
+		case MetricReturn:
+			ranking.Score = ranking.MeanReturn
+		case MetricDrawdown:
+			// Lower drawdown ranks higher
+			ranking.Score = -ranking.MeanDrawdown
+		case MetricCalmar:
+			ranking.Score = ranking.MeanReturn / math.Max(ranking.MeanDrawdown, 0.0001)
+		}
+		rankings = append(rankings, *ranking)
+	}
+
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].Score != rankings[j].Score {
+			return rankings[i].Score > rankings[j].Score
+		}
+		if rankings[i].Sessions != rankings[j].Sessions {
+			return rankings[i].Sessions > rankings[j].Sessions
+		}
+		return rankings[i].ConfigHash < rankings[j].ConfigHash
+	})
+	if query.Limit > 0 && len(rankings) > query.Limit {
+		rankings = rankings[:query.Limit]
+	}
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+	}
+	return rankings, nil
+}
+
+// TradeSharpe returns the mean over the sample standard deviation of per-trade returns, given their count,
+// sum and sum of squares; 0 with fewer than two trades or no dispersion
+func TradeSharpe(count int64, sum, sumSq float64) float64 {
+	if count < 2 {
+		return 0
+	}
+	n := float64(count)
+	mean := sum / n
+	variance := (sumSq - n*mean*mean) / (n - 1)
+	if variance <= 0 {
+		return 0
+	}
+	return mean / math.Sqrt(variance)
+}
@@ -0,0 +1,88 @@
+package journal
+
+import (
+	"aibot/internal/types"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ReadJournal loads every entry of a JSONL journal file, skipping lines that cannot be decoded
+func ReadJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // A record torn by a crash mid-write
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// OpenPositions replays journaled fills and returns the positions still open at the end, ordered by
+// symbol. Buys open longs and close shorts, sells the opposite; fills without a position type are
+// treated as long (spot). Mark prices are set to the last fill price of each symbol, and leverage
+// is not journaled so every position gets the given one.
+func OpenPositions(entries []JournalEntry, contracts map[string]types.ContractSpec, leverage float64) []*types.Position {
+	if leverage <= 0 {
+		leverage = 1
+	}
+	positions := make(map[string]*types.Position)
+	lastPrices := make(map[string]float64)
+
+	for _, entry := range entries {
+		if (entry.EventType != "fill" && entry.EventType != "partial_fill") || entry.Quantity <= 0 {
+			continue
+		}
+		lastPrices[entry.Symbol] = entry.Price
+
+		positionType := entry.PositionType
+		if positionType == "" {
+			positionType = types.PositionTypeLong
+		}
+		opening := (positionType == types.PositionTypeLong) == (entry.Side == types.OrderSideBuy)
+		key := types.PositionKey(entry.Symbol, positionType)
+		position, exists := positions[key]
+
+		switch {
+		case opening && !exists:
+			position = types.NewPosition(key, entry.Symbol, positionType, entry.Quantity, entry.Price, leverage)
+			position.EntryTime = entry.Timestamp
+			position.SetContract(contracts[entry.Symbol])
+			positions[key] = position
+		case opening:
+			position.EntryPrice = position.Contract().AverageEntry(position.Size, position.EntryPrice, entry.Quantity, entry.Price)
+			position.Size += entry.Quantity
+			position.UpdateMargin()
+		case exists:
+			position.Size -= entry.Quantity
+			if position.IsFlat() || position.Size < 0 {
+				delete(positions, key)
+			} else {
+				position.UpdateMargin()
+			}
+		}
+	}
+
+	open := make([]*types.Position, 0, len(positions))
+	for _, position := range positions {
+		position.UpdateMarkPrice(lastPrices[position.Symbol])
+		open = append(open, position)
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].ID < open[j].ID })
+	return open
+}
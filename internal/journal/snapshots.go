@@ -0,0 +1,67 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SnapshotLogConfig holds configuration for a periodic snapshot log
+type SnapshotLogConfig struct {
+	Directory string `json:"directory"` // Directory of the JSONL snapshot file ("" disables the log)
+	FileName  string `json:"file_name"` // Snapshot file name (account_snapshots.jsonl)
+}
+
+// SnapshotLog appends periodic snapshots, one JSON line each, for later charting and audits
+type SnapshotLog struct {
+	path    string
+	records int64
+	mu      sync.Mutex
+}
+
+// NewSnapshotLog creates a snapshot log, creating its directory; the file is created on the first snapshot
+func NewSnapshotLog(config SnapshotLogConfig) (*SnapshotLog, error) {
+	if config.FileName == "" {
+		config.FileName = "account_snapshots.jsonl" // default
+	}
+	if err := os.MkdirAll(config.Directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot log directory: %w", err)
+	}
+	return &SnapshotLog{path: filepath.Join(config.Directory, config.FileName)}, nil
+}
+
+// Path returns the snapshot file
+func (l *SnapshotLog) Path() string {
+	return l.path
+}
+
+// Append writes a snapshot
+func (l *SnapshotLog) Append(snapshot interface{}) error {
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot log: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	l.records++
+	return file.Close()
+}
+
+// Records returns the number of snapshots written since the log was opened
+func (l *SnapshotLog) Records() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.records
+}
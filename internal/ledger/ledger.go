@@ -0,0 +1,384 @@
+package ledger
+
+import (
+	"aibot/internal/types"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Category classifies a balance change
+type Category string
+
+const (
+	CategoryOpeningBalance Category = "opening_balance"
+	CategoryRealizedPnL    Category = "realized_pnl"
+	CategoryCommission     Category = "commission"
+	CategoryFunding        Category = "funding"
+	CategorySlippage       Category = "slippage"
+	CategoryProfitSweep    Category = "profit_sweep" // Profit moved out of the trading wallet; not part of PnL
+)
+
+// AccountWallet is the account holding the quote balance; every other account is named after its category
+const AccountWallet = "wallet"
+
+// dayFormat is the layout of the day key used for daily totals
+const dayFormat = "2006-01-02"
+
+// Entry is a double-entry posting: Amount moves from the Credit account to the Debit account
+type Entry struct {
+	ID        int64     `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Day       string    `json:"day"`
+	Symbol    string    `json:"symbol,omitempty"`
+	Category  Category  `json:"category"`
+	Debit     string    `json:"debit"`
+	Credit    string    `json:"credit"`
+	Amount    float64   `json:"amount"`              // Always positive
+	Reference string    `json:"reference,omitempty"` // Order ID or other source reference
+	Memo      string    `json:"memo,omitempty"`
+}
+
+// CategoryTotals holds signed totals per category from the wallet's point of view (positive adds to balance)
+type CategoryTotals struct {
+	RealizedPnL float64 `json:"realized_pnl"`
+	Commission  float64 `json:"commission"`
+	Funding     float64 `json:"funding"`
+	Slippage    float64 `json:"slippage"`
+	Net         float64 `json:"net"`
+}
+
+// DailyTotals holds category totals for one symbol on one day
+type DailyTotals struct {
+	Day    string `json:"day"`
+	Symbol string `json:"symbol"`
+	CategoryTotals
+}
+
+// Summary is the ledger export attached to session reports
+type Summary struct {
+	OpeningBalance float64                   `json:"opening_balance"`
+	Balance        float64                   `json:"balance"`
+	Totals         CategoryTotals            `json:"totals"`
+	BySymbol       map[string]CategoryTotals `json:"by_symbol"`
+	Daily          []DailyTotals             `json:"daily"`
+	TrialBalance   map[string]float64        `json:"trial_balance"`
+	Swept          float64                   `json:"swept"` // Profit transferred to another wallet
+	Entries        int64                     `json:"entries"`
+}
+
+// LedgerConfig holds configuration for the ledger
+type LedgerConfig struct {
+	InitialBalance float64        `json:"initial_balance"`
+	MaxEntries     int            `json:"max_entries"` // Postings kept in memory for queries (10000); totals cover all postings
+	Location       *time.Location `json:"-"`           // Accounting timezone of the day keys (UTC)
+}
+
+// Ledger records every balance change as a double-entry posting so fees, funding and slippage can be audited
+type Ledger struct {
+	config LedgerConfig
+
+	entries  []Entry
+	nextID   int64
+	accounts map[string]float64                 // Account balances; they always sum to zero
+	daily    map[string]map[string]*DailyTotals // Day -> symbol -> totals
+
+	mu sync.RWMutex
+}
+
+// NewLedger creates a ledger opened with the initial balance
+func NewLedger(config LedgerConfig) *Ledger {
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 10000 // default
+	}
+	if config.Location == nil {
+		config.Location = time.UTC // default
+	}
+
+	l := &Ledger{
+		config:   config,
+		entries:  make([]Entry, 0),
+		accounts: make(map[string]float64),
+		daily:    make(map[string]map[string]*DailyTotals),
+	}
+	if config.InitialBalance != 0 {
+		l.post(time.Now(), "", CategoryOpeningBalance, AccountWallet, string(CategoryOpeningBalance),
+			config.InitialBalance, "", "opening balance")
+	}
+	return l
+}
+
+// RecordFill books realized PnL and commission for a fill. If expectedPrice is known (the price when a
+// market order was sent), the slippage cost is moved out of realized PnL into its own category.
+func (l *Ledger) RecordFill(update types.OrderUpdate, expectedPrice float64) {
+	if !update.IsFill() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	timestamp := update.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	l.postSigned(timestamp, update.Symbol, CategoryRealizedPnL, update.RealizedPnL, update.OrderID, "")
+	l.postSigned(timestamp, update.Symbol, CategoryCommission, -update.Fee, update.OrderID, "")
+
+	if expectedPrice > 0 {
+		slippage := (update.LastFillPrice - expectedPrice) * update.LastFillQty
+		if update.Side == types.OrderSideSell {
+			slippage = -slippage
+		}
+		if slippage != 0 {
+			// Reclassification only: the cost is already in the fill price, so the wallet is untouched
+			l.postTransfer(timestamp, update.Symbol, CategorySlippage, -slippage, update.OrderID)
+		}
+	}
+}
+
+// RecordFunding books a funding payment; positive amounts are received, negative amounts paid
+func (l *Ledger) RecordFunding(symbol string, amount float64, timestamp time.Time, reference string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.postSigned(timestamp, symbol, CategoryFunding, amount, reference, "")
+}
+
+// RecordSweep books profit transferred out of the trading wallet; it lowers the balance without counting
+// as a loss
+func (l *Ledger) RecordSweep(amount float64, timestamp time.Time, reference, memo string) {
+	if amount <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.post(timestamp, "", CategoryProfitSweep, string(CategoryProfitSweep), AccountWallet, amount, reference, memo)
+}
+
+// postSigned posts a wallet change of amount against the category account; caller must hold l.mu
+func (l *Ledger) postSigned(timestamp time.Time, symbol string, category Category, amount float64, reference, memo string) {
+	if amount == 0 {
+		return
+	}
+	if amount > 0 {
+		l.post(timestamp, symbol, category, AccountWallet, string(category), amount, reference, memo)
+	} else {
+		l.post(timestamp, symbol, category, string(category), AccountWallet, -amount, reference, memo)
+	}
+	l.addTotals(timestamp, symbol, category, amount)
+}
+
+// postTransfer moves a slippage amount between realized PnL and the slippage account; caller must hold l.mu
+func (l *Ledger) postTransfer(timestamp time.Time, symbol string, category Category, amount float64, reference string) {
+	if amount < 0 {
+		l.post(timestamp, symbol, category, string(category), string(CategoryRealizedPnL), -amount, reference, "slippage cost")
+	} else {
+		l.post(timestamp, symbol, category, string(CategoryRealizedPnL), string(category), amount, reference, "price improvement")
+	}
+	l.addTotals(timestamp, symbol, category, amount)
+	l.addTotals(timestamp, symbol, CategoryRealizedPnL, -amount)
+}
+
+// post appends a posting and updates account balances; caller must hold l.mu
+func (l *Ledger) post(timestamp time.Time, symbol string, category Category, debit, credit string, amount float64, reference, memo string) {
+	l.nextID++
+	l.accounts[debit] += amount
+	l.accounts[credit] -= amount
+
+	l.entries = append(l.entries, Entry{
+		ID:        l.nextID,
+		Timestamp: timestamp,
+		Day:       l.day(timestamp),
+		Symbol:    symbol,
+		Category:  category,
+		Debit:     debit,
+		Credit:    credit,
+		Amount:    amount,
+		Reference: reference,
+		Memo:      memo,
+	})
+	if len(l.entries) > l.config.MaxEntries {
+		l.entries = l.entries[len(l.entries)-l.config.MaxEntries:]
+	}
+}
+
+// addTotals adds a signed amount to the daily totals; caller must hold l.mu
+func (l *Ledger) addTotals(timestamp time.Time, symbol string, category Category, amount float64) {
+	day := l.day(timestamp)
+	symbols, exists := l.daily[day]
+	if !exists {
+		symbols = make(map[string]*DailyTotals)
+		l.daily[day] = symbols
+	}
+	totals, exists := symbols[symbol]
+	if !exists {
+		totals = &DailyTotals{Day: day, Symbol: symbol}
+		symbols[symbol] = totals
+	}
+	totals.CategoryTotals.add(category, amount)
+}
+
+// day returns the accounting day a timestamp is booked on
+func (l *Ledger) day(timestamp time.Time) string {
+	return timestamp.In(l.config.Location).Format(dayFormat)
+}
+
+// add adds a signed amount to a category
+func (t *CategoryTotals) add(category Category, amount float64) {
+	switch category {
+	case CategoryRealizedPnL:
+		t.RealizedPnL += amount
+	case CategoryCommission:
+		t.Commission += amount
+	case CategoryFunding:
+		t.Funding += amount
+	case CategorySlippage:
+		t.Slippage += amount
+	default:
+		return
+	}
+	t.Net = t.RealizedPnL + t.Commission + t.Funding + t.Slippage
+}
+
+// merge adds other's totals
+func (t *CategoryTotals) merge(other CategoryTotals) {
+	t.RealizedPnL += other.RealizedPnL
+	t.Commission += other.Commission
+	t.Funding += other.Funding
+	t.Slippage += other.Slippage
+	t.Net = t.RealizedPnL + t.Commission + t.Funding + t.Slippage
+}
+
+// GetBalance returns the wallet balance implied by the postings
+func (l *Ledger) GetBalance() float64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.accounts[AccountWallet]
+}
+
+// GetEntries returns postings for a symbol ("" for all) within [from, to], newest last; zero times are unbounded
+func (l *Ledger) GetEntries(symbol string, from, to time.Time, limit int) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := make([]Entry, 0)
+	for _, entry := range l.entries {
+		if symbol != "" && entry.Symbol != symbol {
+			continue
+		}
+		if (!from.IsZero() && entry.Timestamp.Before(from)) || (!to.IsZero() && entry.Timestamp.After(to)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries
+}
+
+// GetTotals returns all-time category totals for a symbol ("" for all symbols)
+func (l *Ledger) GetTotals(symbol string) CategoryTotals {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var totals CategoryTotals
+	for _, symbols := range l.daily {
+		for s, daily := range symbols {
+			if symbol == "" || s == symbol {
+				totals.merge(daily.CategoryTotals)
+			}
+		}
+	}
+	return totals
+}
+
+// GetDailyTotals returns category totals per day and symbol, ordered by day then symbol
+func (l *Ledger) GetDailyTotals() []DailyTotals {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.dailyTotals()
+}
+
+// dailyTotals returns sorted daily totals; caller must hold l.mu
+func (l *Ledger) dailyTotals() []DailyTotals {
+	result := make([]DailyTotals, 0)
+	for _, symbols := range l.daily {
+		for _, totals := range symbols {
+			result = append(result, *totals)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Day != result[j].Day {
+			return result[i].Day < result[j].Day
+		}
+		return result[i].Symbol < result[j].Symbol
+	})
+	return result
+}
+
+// GetTrialBalance returns every account balance; a consistent ledger sums to zero
+func (l *Ledger) GetTrialBalance() map[string]float64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	balances := make(map[string]float64, len(l.accounts))
+	for account, balance := range l.accounts {
+		balances[account] = balance
+	}
+	return balances
+}
+
+// IsBalanced returns true if debits equal credits within float tolerance
+func (l *Ledger) IsBalanced() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	sum := 0.0
+	for _, balance := range l.accounts {
+		sum += balance
+	}
+	return math.Abs(sum) < 1e-6
+}
+
+// GetSummary returns the ledger export for reports
+func (l *Ledger) GetSummary() *Summary {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	summary := &Summary{
+		OpeningBalance: l.config.InitialBalance,
+		Balance:        l.accounts[AccountWallet],
+		BySymbol:       make(map[string]CategoryTotals),
+		Daily:          l.dailyTotals(),
+		TrialBalance:   make(map[string]float64, len(l.accounts)),
+		Swept:          l.accounts[string(CategoryProfitSweep)],
+		Entries:        l.nextID,
+	}
+	for _, daily := range summary.Daily {
+		summary.Totals.merge(daily.CategoryTotals)
+		bySymbol := summary.BySymbol[daily.Symbol]
+		bySymbol.merge(daily.CategoryTotals)
+		summary.BySymbol[daily.Symbol] = bySymbol
+	}
+	for account, balance := range l.accounts {
+		summary.TrialBalance[account] = balance
+	}
+	return summary
+}
+
+// GetLedgerStats returns ledger statistics
+func (l *Ledger) GetLedgerStats() map[string]interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return map[string]interface{}{
+		"entries":           l.nextID,
+		"entries_in_memory": len(l.entries),
+		"balance":           l.accounts[AccountWallet],
+		"swept":             l.accounts[string(CategoryProfitSweep)],
+		"days":              len(l.daily),
+	}
+}
@@ -0,0 +1,212 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"aibot/internal/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Components whose log level can be adjusted at runtime
+const (
+	ComponentOrchestrator = "orchestrator"
+	ComponentStream       = "stream"
+	ComponentExecutor     = "executor"
+	ComponentRisk         = "risk"
+)
+
+// DefaultComponent names the level used by components without their own
+const DefaultComponent = "default"
+
+// Components lists the components with adjustable levels
+var Components = []string{ComponentOrchestrator, ComponentStream, ComponentExecutor, ComponentRisk}
+
+// Levels holds the default level, per-component overrides and the logrus loggers they apply to. Each
+// Logger created by NewLogger owns its levels, so bot instances in one process are adjusted separately.
+// A nil Levels logs every component at info.
+type Levels struct {
+	base       logrus.Level
+	components map[string]logrus.Level
+	loggers    []*logrus.Logger
+
+	mu sync.RWMutex
+}
+
+// NewLevels creates levels from the configured default and component levels; invalid values are
+// ignored because the configuration is validated on load
+func NewLevels(cfg config.LoggingConfig) *Levels {
+	l := &Levels{
+		base:       logrus.InfoLevel, // default
+		components: make(map[string]logrus.Level),
+	}
+	if level, err := logrus.ParseLevel(cfg.Level); err == nil {
+		l.base = level
+	}
+	for component, value := range cfg.ComponentLevels {
+		if level, err := logrus.ParseLevel(value); err == nil {
+			l.components[component] = level
+		}
+	}
+	return l
+}
+
+// attach lets the levels raise a logrus logger's level when a component needs more detail
+func (l *Levels) attach(logger *logrus.Logger) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.loggers = append(l.loggers, logger)
+	l.syncLoggers()
+}
+
+// detach stops the levels from adjusting a logrus logger
+func (l *Levels) detach(logger *logrus.Logger) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, attached := range l.loggers {
+		if attached == logger {
+			l.loggers = append(l.loggers[:i:i], l.loggers[i+1:]...)
+			return
+		}
+	}
+}
+
+// syncLoggers sets attached loggers to the most verbose level in use; Logger methods filter
+// per component. Callers hold the lock.
+func (l *Levels) syncLoggers() {
+	verbose := l.base
+	for _, level := range l.components {
+		if level > verbose {
+			verbose = level
+		}
+	}
+	for _, logger := range l.loggers {
+		logger.SetLevel(verbose)
+	}
+}
+
+// Set changes a component's level at runtime. The default component (or "") changes the level of
+// every component without its own; level "default" removes a component's override.
+func (l *Levels) Set(component, level string) error {
+	if l == nil {
+		return fmt.Errorf("log levels are not configured")
+	}
+	component = strings.ToLower(strings.TrimSpace(component))
+	level = strings.ToLower(strings.TrimSpace(level))
+	if component == "" {
+		component = DefaultComponent
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if level == DefaultComponent {
+		if component == DefaultComponent {
+			return fmt.Errorf("the default level cannot be reset, set a level instead")
+		}
+		delete(l.components, component)
+		l.syncLoggers()
+		return nil
+	}
+
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+	if component == DefaultComponent {
+		l.base = parsed
+	} else {
+		l.components[component] = parsed
+	}
+	l.syncLoggers()
+	return nil
+}
+
+// Level returns the level in force for a component
+func (l *Levels) Level(component string) logrus.Level {
+	if l == nil {
+		return logrus.InfoLevel
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if level, exists := l.components[component]; exists {
+		return level
+	}
+	return l.base
+}
+
+// Enabled returns true if a message at level should be logged for a component
+func (l *Levels) Enabled(component string, level logrus.Level) bool {
+	return level <= l.Level(component)
+}
+
+// Get returns the default level and the level in force for every known component
+func (l *Levels) Get() map[string]string {
+	base := l.Level(DefaultComponent)
+	result := map[string]string{DefaultComponent: base.String()}
+	for _, component := range Components {
+		result[component] = base.String()
+	}
+	if l == nil {
+		return result
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for component, level := range l.components {
+		result[component] = level.String()
+	}
+	return result
+}
+
+// SortedComponents returns the component names of a level map, default first
+func SortedComponents(levelMap map[string]string) []string {
+	names := make([]string, 0, len(levelMap))
+	for name := range levelMap {
+		if name != DefaultComponent {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, exists := levelMap[DefaultComponent]; exists {
+		names = append([]string{DefaultComponent}, names...)
+	}
+	return names
+}
+
+// Sampler lets one in every N events through, for logs on high-frequency paths such as ticks
+type Sampler struct {
+	every      uint64
+	count      uint64
+	suppressed uint64
+}
+
+// NewSampler creates a sampler passing one of every `every` events; values below 2 pass everything
+func NewSampler(every int) *Sampler {
+	if every < 1 {
+		every = 1
+	}
+	return &Sampler{every: uint64(every)}
+}
+
+// Allow returns true for the first event and every Nth one after it
+func (s *Sampler) Allow() bool {
+	n := atomic.AddUint64(&s.count, 1)
+	if (n-1)%s.every == 0 {
+		return true
+	}
+	atomic.AddUint64(&s.suppressed, 1)
+	return false
+}
+
+// Suppressed returns how many events were dropped
+func (s *Sampler) Suppressed() uint64 {
+	return atomic.LoadUint64(&s.suppressed)
+}
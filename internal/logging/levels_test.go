@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"testing"
+
+	"aibot/internal/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLevelsAreKeptPerLogger(t *testing.T) {
+	first := NewLogger(config.LoggingConfig{Level: "info", Output: "stdout"})
+	second := NewLogger(config.LoggingConfig{Level: "info", Output: "stdout"})
+	defer first.Close()
+	defer second.Close()
+
+	if err := first.Levels().Set(ComponentStream, "debug"); err != nil {
+		t.Fatalf("set level: %v", err)
+	}
+	if !first.Levels().Enabled(ComponentStream, logrus.DebugLevel) {
+		t.Fatal("expected stream debug logs on the first logger")
+	}
+	if second.Levels().Enabled(ComponentStream, logrus.DebugLevel) {
+		t.Fatal("the second logger followed the first logger's level")
+	}
+	if first.Logger.GetLevel() != logrus.DebugLevel || second.Logger.GetLevel() != logrus.InfoLevel {
+		t.Fatalf("logrus levels %s and %s, expected debug and info", first.Logger.GetLevel(), second.Logger.GetLevel())
+	}
+}
+
+func TestCloseDetachesLogger(t *testing.T) {
+	logger := NewLogger(config.LoggingConfig{Level: "info", Output: "stdout"})
+	levels := logger.Levels()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if len(levels.loggers) != 0 {
+		t.Fatalf("%d loggers still attached after close", len(levels.loggers))
+	}
+
+	if err := levels.Set(DefaultComponent, "debug"); err != nil {
+		t.Fatalf("set level: %v", err)
+	}
+	if logger.Logger.GetLevel() != logrus.InfoLevel {
+		t.Fatalf("closed logger followed a level change to %s", logger.Logger.GetLevel())
+	}
+}
+
+func TestNilLevelsLogAtInfo(t *testing.T) {
+	var levels *Levels
+	if !levels.Enabled(ComponentRisk, logrus.InfoLevel) || levels.Enabled(ComponentRisk, logrus.DebugLevel) {
+		t.Fatal("expected nil levels to log at info")
+	}
+	if err := levels.Set(ComponentRisk, "debug"); err == nil {
+		t.Fatal("expected an error setting a level without levels")
+	}
+}
@@ -18,6 +18,9 @@ import (
 type Logger struct {
 	*logrus.Logger
 	component string
+	fields    *logrus.Entry // Fields added with WithField(s), nil if none
+	levels    *Levels       // Levels shared by the loggers derived from this one
+	file      io.Closer     // Rotating log file, nil when logging to stdout only
 }
 
 // LoggerConfig holds logger configuration
@@ -59,12 +62,13 @@ var globalLogger *Logger
 func NewLogger(cfg config.LoggingConfig) *Logger {
 	logger := logrus.New()
 
-	// Set log level
-	level, err := logrus.ParseLevel(cfg.Level)
-	if err != nil {
-		level = logrus.InfoLevel
-	}
-	logger.SetLevel(level)
+	// Set log levels; the logger runs at the most verbose level in use and filters per component
+	levels := NewLevels(cfg)
+	levels.attach(logger)
+
+	// Mask secrets in messages and fields, including log.Printf output
+	logger.AddHook(redactionHook{})
+	redactStandardLogger()
 
 	// Set formatter
 	if cfg.Format == "json" {
@@ -80,24 +84,50 @@ func NewLogger(cfg config.LoggingConfig) *Logger {
 
 	// Set output
 	var output io.Writer
+	var file io.Closer
 	switch cfg.Output {
 	case "stdout":
 		output = os.Stdout
 	case "file":
 		output = createFileWriter(cfg)
+		file, _ = output.(io.Closer)
 	case "both":
-		output = io.MultiWriter(os.Stdout, createFileWriter(cfg))
+		fileWriter := createFileWriter(cfg)
+		file, _ = fileWriter.(io.Closer)
+		output = io.MultiWriter(os.Stdout, fileWriter)
 	default:
 		output = os.Stdout
 	}
+	if file == io.Closer(os.Stdout) {
+		file = nil
+	}
 
 	logger.SetOutput(output)
 
 	return &Logger{
 		Logger: logger,
+		levels: levels,
+		file:   file,
 	}
 }
 
+// Levels returns the log levels of the logger, which can be changed at runtime
+func (l *Logger) Levels() *Levels {
+	return l.levels
+}
+
+// Close detaches the logger from its levels and closes its log file. It is called once, on the logger
+// returned by NewLogger, when the instance using it shuts down.
+func (l *Logger) Close() error {
+	if l.levels != nil {
+		l.levels.detach(l.Logger)
+	}
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
 // createFileWriter creates a rotating file writer
 func createFileWriter(cfg config.LoggingConfig) io.Writer {
 	// Ensure log directory exists
@@ -141,141 +171,132 @@ func NewComponentLogger(component string) *Logger {
 	return &Logger{
 		Logger:    baseLogger.Logger,
 		component: component,
+		fields:    baseLogger.fields,
+		levels:    baseLogger.levels,
 	}
 }
 
 // Logging methods with component awareness
 
+// entry returns the log entry carrying the logger's fields and component
+func (l *Logger) entry() *logrus.Entry {
+	entry := l.fields
+	if entry == nil {
+		entry = logrus.NewEntry(l.Logger)
+	}
+	if l.component != "" {
+		entry = entry.WithField("component", l.component)
+	}
+	return entry
+}
+
+// enabled returns true if the logger's component logs at level
+func (l *Logger) enabled(level logrus.Level) bool {
+	component := l.component
+	if component == "" {
+		component = DefaultComponent
+	}
+	return l.levels.Enabled(component, level)
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Debug(args...)
-	} else {
-		l.Logger.Debug(args...)
+	if l.enabled(DebugLevel) {
+		l.entry().Debug(args...)
 	}
 }
 
 // Debugf logs a formatted debug message
 func (l *Logger) Debugf(format string, args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Debugf(format, args...)
-	} else {
-		l.Logger.Debugf(format, args...)
+	if l.enabled(DebugLevel) {
+		l.entry().Debugf(format, args...)
 	}
 }
 
 // Info logs an info message
 func (l *Logger) Info(args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Info(args...)
-	} else {
-		l.Logger.Info(args...)
+	if l.enabled(InfoLevel) {
+		l.entry().Info(args...)
 	}
 }
 
 // Infof logs a formatted info message
 func (l *Logger) Infof(format string, args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Infof(format, args...)
-	} else {
-		l.Logger.Infof(format, args...)
+	if l.enabled(InfoLevel) {
+		l.entry().Infof(format, args...)
 	}
 }
 
 // Warn logs a warning message
 func (l *Logger) Warn(args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Warn(args...)
-	} else {
-		l.Logger.Warn(args...)
+	if l.enabled(WarnLevel) {
+		l.entry().Warn(args...)
 	}
 }
 
 // Warnf logs a formatted warning message
 func (l *Logger) Warnf(format string, args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Warnf(format, args...)
-	} else {
-		l.Logger.Warnf(format, args...)
+	if l.enabled(WarnLevel) {
+		l.entry().Warnf(format, args...)
 	}
 }
 
 // Error logs an error message
 func (l *Logger) Error(args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Error(args...)
-	} else {
-		l.Logger.Error(args...)
+	if l.enabled(ErrorLevel) {
+		l.entry().Error(args...)
 	}
 }
 
 // Errorf logs a formatted error message
 func (l *Logger) Errorf(format string, args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Errorf(format, args...)
-	} else {
-		l.Logger.Errorf(format, args...)
+	if l.enabled(ErrorLevel) {
+		l.entry().Errorf(format, args...)
 	}
 }
 
 // Fatal logs a fatal message and exits
 func (l *Logger) Fatal(args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Fatal(args...)
-	} else {
-		l.Logger.Fatal(args...)
-	}
+	l.entry().Fatal(args...)
 }
 
 // Fatalf logs a formatted fatal message and exits
 func (l *Logger) Fatalf(format string, args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Fatalf(format, args...)
-	} else {
-		l.Logger.Fatalf(format, args...)
-	}
+	l.entry().Fatalf(format, args...)
 }
 
 // Panic logs a panic message and panics
 func (l *Logger) Panic(args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Panic(args...)
-	} else {
-		l.Logger.Panic(args...)
-	}
+	l.entry().Panic(args...)
 }
 
 // Panicf logs a formatted panic message and panics
 func (l *Logger) Panicf(format string, args ...interface{}) {
-	if l.component != "" {
-		l.WithField("component", l.component).Panicf(format, args...)
-	} else {
-		l.Logger.Panicf(format, args...)
-	}
+	l.entry().Panicf(format, args...)
 }
 
 // WithFields adds multiple fields to the logger
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
+	base := l.fields
+	if base == nil {
+		base = logrus.NewEntry(l.Logger)
+	}
 	return &Logger{
-		Logger:    l.Logger.WithFields(fields).Logger,
+		Logger:    l.Logger,
 		component: l.component,
+		fields:    base.WithFields(fields),
+		levels:    l.levels,
 	}
 }
 
 // WithField adds a single field to the logger
 func (l *Logger) WithField(key string, value interface{}) *Logger {
-	return &Logger{
-		Logger:    l.Logger.WithField(key, value).Logger,
-		component: l.component,
-	}
+	return l.WithFields(logrus.Fields{key: value})
 }
 
 // WithError adds an error field to the logger
 func (l *Logger) WithError(err error) *Logger {
-	return &Logger{
-		Logger:    l.Logger.WithError(err).Logger,
-		component: l.component,
-	}
+	return l.WithField(logrus.ErrorKey, err)
 }
 
 // WithCaller adds caller information to the logger
@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testAPIKey    = "vmPUZE6mv9SD5VNHk4HlWFsOr6aKE2zvsw0MuIgwCIPy6utIco14y7Ju91duEh8A"
+	testSignature = "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"
+)
+
+// signedRequestError is what the HTTP client returns for a failed signed request
+func signedRequestError() error {
+	return &url.Error{
+		Op:  "Get",
+		URL: "https://fapi.binance.com/fapi/v2/account?timestamp=1700000000000&signature=" + testSignature + "&apiKey=" + testAPIKey,
+		Err: errors.New("dial tcp: i/o timeout"),
+	}
+}
+
+// expectRedacted fails the test if text still holds a test secret or lost the redaction marker
+func expectRedacted(t *testing.T, text string) {
+	t.Helper()
+	for _, secret := range []string{testAPIKey, testSignature} {
+		if strings.Contains(text, secret) {
+			t.Fatalf("secret leaked: %s", text)
+		}
+	}
+	if !strings.Contains(text, RedactedValue) {
+		t.Fatalf("expected %s in %s", RedactedValue, text)
+	}
+}
+
+func TestRedactURLError(t *testing.T) {
+	text := Redact(signedRequestError().Error())
+
+	expectRedacted(t, text)
+	if !strings.Contains(text, "timestamp=1700000000000") || !strings.Contains(text, "i/o timeout") {
+		t.Fatalf("expected the rest of the error kept, got %s", text)
+	}
+}
+
+func TestRedactWrappedError(t *testing.T) {
+	err := fmt.Errorf("failed to fetch account: %w", signedRequestError())
+	err = fmt.Errorf("sync positions: %w", err)
+
+	expectRedacted(t, Redact(err.Error()))
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatal("expected the url.Error to stay reachable through the wrapping")
+	}
+}
+
+func TestRedactFieldsError(t *testing.T) {
+	fields := RedactFields(logrus.Fields{
+		"error":   fmt.Errorf("place order: %w", signedRequestError()),
+		"apiKey":  testAPIKey,
+		"attempt": 3,
+	})
+
+	if fields["apiKey"] != RedactedValue {
+		t.Fatalf("expected apiKey masked, got %v", fields["apiKey"])
+	}
+	text, ok := fields["error"].(string)
+	if !ok {
+		t.Fatalf("expected the error redacted to a string, got %T", fields["error"])
+	}
+	expectRedacted(t, text)
+	if fields["attempt"] != 3 {
+		t.Fatalf("expected non-secret fields kept, got %v", fields["attempt"])
+	}
+}
+
+func TestRedactionHook(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.AddHook(redactionHook{})
+
+	err := fmt.Errorf("cancel order: %w", signedRequestError())
+	logger.WithError(err).Errorf("request failed: %v", err)
+
+	expectRedacted(t, out.String())
+}
+
+func TestRedactingWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := NewRedactingWriter(&out)
+
+	line := fmt.Sprintf("❌ Failed to sync: %v\n", fmt.Errorf("account: %w", signedRequestError()))
+	n, err := writer.Write([]byte(line))
+	if err != nil || n != len(line) {
+		t.Fatalf("expected %d bytes written, got %d (%v)", len(line), n, err)
+	}
+	expectRedacted(t, out.String())
+}
+
+func TestRedactRegisteredSecret(t *testing.T) {
+	RegisterSecrets(testAPIKey)
+
+	// A bare key without a sensitive parameter name is only caught once registered
+	err := fmt.Errorf("auth: %w", errors.New("invalid key "+testAPIKey))
+	expectRedacted(t, Redact(err.Error()))
+}
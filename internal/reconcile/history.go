@@ -0,0 +1,214 @@
+package reconcile
+
+import (
+	"aibot/internal/types"
+	"aibot/pkg/trading"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default Binance REST APIs of each market type
+const (
+	DefaultFuturesURL = "https://fapi.binance.com"
+	DefaultSpotURL    = "https://api.binance.com"
+)
+
+// historyPageLimit is the maximum number of trades the exchange returns per request
+const historyPageLimit = 1000
+
+// HistoryConfig holds configuration for reading the account's trade history
+type HistoryConfig struct {
+	URL        string           `json:"url"`         // Binance-compatible REST API (by market type)
+	MarketType types.MarketType `json:"market_type"` // Selects the spot or futures endpoint (futures)
+	APIKey     string           `json:"-"`
+	APISecret  string           `json:"-"`
+	Timeout    time.Duration    `json:"timeout"`     // Request timeout (10s)
+	RecvWindow time.Duration    `json:"recv_window"` // Validity of a signed request (5s)
+}
+
+// HistoryClient reads the account's executed trades from a Binance-compatible REST API with signed requests
+type HistoryClient struct {
+	config HistoryConfig
+	client *http.Client
+}
+
+// NewHistoryClient creates a trade history client
+func NewHistoryClient(config HistoryConfig) *HistoryClient {
+	if config.MarketType == "" {
+		config.MarketType = types.MarketTypeFutures // default
+	}
+	if config.URL == "" {
+		config.URL = DefaultFuturesURL // default
+		if config.MarketType.IsSpot() {
+			config.URL = DefaultSpotURL
+		}
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second // default
+	}
+	if config.RecvWindow == 0 {
+		config.RecvWindow = 5 * time.Second // default
+	}
+	config.URL = strings.TrimRight(config.URL, "/")
+
+	return &HistoryClient{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// window returns the longest time range the exchange accepts in one trade history request
+func (c *HistoryClient) window() time.Duration {
+	if c.config.MarketType.IsSpot() {
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// GetTrades returns the account's trades of symbol executed in [start, end), oldest first
+func (c *HistoryClient) GetTrades(ctx context.Context, symbol string, start, end time.Time) ([]trading.Trade, error) {
+	if c.config.APIKey == "" || c.config.APISecret == "" {
+		return nil, fmt.Errorf("trade history requires API credentials")
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("trade history end %s is not after start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	var trades []trading.Trade
+	for from := start; from.Before(end); {
+		to := from.Add(c.window())
+		if to.After(end) {
+			to = end
+		}
+		page, err := c.fetchTrades(ctx, symbol, from, to)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, page...)
+
+		// A full page may have left trades of the window behind; continue after the last one
+		if len(page) == historyPageLimit {
+			from = page[len(page)-1].Timestamp.Add(time.Millisecond)
+			continue
+		}
+		from = to
+	}
+	return trades, nil
+}
+
+// historyTrade is a trade of the spot myTrades or futures userTrades endpoint
+type historyTrade struct {
+	ID              int64  `json:"id"`
+	OrderID         int64  `json:"orderId"`
+	Symbol          string `json:"symbol"`
+	Side            string `json:"side"`    // Futures only
+	IsBuyer         bool   `json:"isBuyer"` // Spot only
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	RealizedPnl     string `json:"realizedPnl"` // Futures only
+	Maker           bool   `json:"maker"`       // Futures
+	IsMaker         bool   `json:"isMaker"`     // Spot
+	Time            int64  `json:"time"`
+}
+
+// fetchTrades requests one page of trades of symbol in [from, to)
+func (c *HistoryClient) fetchTrades(ctx context.Context, symbol string, from, to time.Time) ([]trading.Trade, error) {
+	path := "/fapi/v1/userTrades"
+	if c.config.MarketType.IsSpot() {
+		path = "/api/v3/myTrades"
+	}
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	query.Set("startTime", strconv.FormatInt(from.UnixMilli(), 10))
+	query.Set("endTime", strconv.FormatInt(to.UnixMilli()-1, 10))
+	query.Set("limit", strconv.Itoa(historyPageLimit))
+
+	var rows []historyTrade
+	if err := c.signedGet(ctx, path, query, &rows); err != nil {
+		return nil, err
+	}
+
+	trades := make([]trading.Trade, 0, len(rows))
+	for _, row := range rows {
+		trade := trading.Trade{
+			ID:          strconv.FormatInt(row.ID, 10),
+			TradeID:     strconv.FormatInt(row.ID, 10),
+			OrderID:     strconv.FormatInt(row.OrderID, 10),
+			Symbol:      row.Symbol,
+			Side:        types.OrderSide(strings.ToLower(row.Side)),
+			Price:       parseFloat(row.Price),
+			Quantity:    parseFloat(row.Qty),
+			Fee:         parseFloat(row.Commission),
+			FeeAsset:    row.CommissionAsset,
+			RealizedPnL: parseFloat(row.RealizedPnl),
+			IsMaker:     row.Maker || row.IsMaker,
+			Timestamp:   time.UnixMilli(row.Time),
+		}
+		if row.Side == "" {
+			trade.Side = types.OrderSideSell
+			if row.IsBuyer {
+				trade.Side = types.OrderSideBuy
+			}
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// signedGet requests a signed endpoint and decodes its JSON response
+func (c *HistoryClient) signedGet(ctx context.Context, path string, query url.Values, out interface{}) error {
+	query.Set("recvWindow", strconv.FormatInt(c.config.RecvWindow.Milliseconds(), 10))
+	query.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	payload := query.Encode()
+	mac := hmac.New(sha256.New, []byte(c.config.APISecret))
+	mac.Write([]byte(payload))
+	target := c.config.URL + path + "?" + payload + "&signature=" + hex.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create trade history request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-MBX-APIKEY", c.config.APIKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Msg != "" {
+			return fmt.Errorf("%s returned status %d: %s (code %d)", path, resp.StatusCode, apiErr.Msg, apiErr.Code)
+		}
+		return fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseFloat parses a decimal string of the exchange, returning 0 for an empty or malformed value
+func parseFloat(value string) float64 {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
@@ -0,0 +1,273 @@
+package reconcile
+
+import (
+	"aibot/internal/journal"
+	"aibot/pkg/trading"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DiscrepancyType classifies a difference between the exchange's trade history and the trade journal
+type DiscrepancyType string
+
+const (
+	DiscrepancyManualTrade     DiscrepancyType = "manual_trade"     // Exchange order the journal knows nothing of, e.g. placed by hand
+	DiscrepancyMissedFill      DiscrepancyType = "missed_fill"      // The exchange filled more of an order than was journaled
+	DiscrepancyUnconfirmedFill DiscrepancyType = "unconfirmed_fill" // The journal holds fills the exchange does not report
+	DiscrepancyFeeMismatch     DiscrepancyType = "fee_mismatch"     // Fees of an order differ beyond the tolerance
+)
+
+// ReconcileConfig holds configuration for reconciling the trade journal against the exchange
+type ReconcileConfig struct {
+	QuoteAsset        string  `json:"quote_asset"`        // Fees charged in other assets (e.g. BNB) are not compared (USDT)
+	QuantityTolerance float64 `json:"quantity_tolerance"` // Quantity difference ignored, as a fraction of the exchange quantity (0.000001)
+	FeeTolerance      float64 `json:"fee_tolerance"`      // Fee difference ignored, in the quote asset (0.0001)
+}
+
+// Discrepancy is one order whose exchange trades and journal fills disagree
+type Discrepancy struct {
+	Type        DiscrepancyType `json:"type"`
+	OrderID     string          `json:"order_id"`
+	Symbol      string          `json:"symbol"`
+	Side        string          `json:"side"`
+	ExchangeQty float64         `json:"exchange_qty"`
+	JournalQty  float64         `json:"journal_qty"`
+	ExchangeFee float64         `json:"exchange_fee"`
+	JournalFee  float64         `json:"journal_fee"`
+	Timestamp   time.Time       `json:"timestamp"` // First trade or fill of the order
+	Detail      string          `json:"detail"`
+}
+
+// Report is the result of reconciling the trade journal against the exchange for one symbol and period
+type Report struct {
+	Symbol         string                  `json:"symbol"`
+	Start          time.Time               `json:"start"`
+	End            time.Time               `json:"end"`
+	GeneratedAt    time.Time               `json:"generated_at"`
+	ExchangeTrades int                     `json:"exchange_trades"`
+	ExchangeOrders int                     `json:"exchange_orders"`
+	JournalFills   int                     `json:"journal_fills"`
+	JournalOrders  int                     `json:"journal_orders"`
+	Matched        int                     `json:"matched"`        // Orders agreeing on quantity and fee
+	FeesUnchecked  int                     `json:"fees_unchecked"` // Orders whose exchange fee is not in the quote asset
+	ExchangeFees   float64                 `json:"exchange_fees"`  // Quote asset fees only
+	JournalFees    float64                 `json:"journal_fees"`
+	ExchangePnL    float64                 `json:"exchange_pnl"` // Realized PnL reported by the exchange (futures)
+	JournalPnL     float64                 `json:"journal_pnl"`
+	Counts         map[DiscrepancyType]int `json:"counts"`
+	Discrepancies  []Discrepancy           `json:"discrepancies"`
+}
+
+// Clean returns true if no discrepancy was found
+func (r *Report) Clean() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// orderFills aggregates the trades or fills of one order
+type orderFills struct {
+	symbol   string
+	side     string
+	qty      float64
+	fee      float64
+	feeAsset string // "" if fees were charged in several assets
+	first    time.Time
+	count    int
+}
+
+// add accumulates one trade or fill
+func (f *orderFills) add(symbol, side string, qty, fee float64, feeAsset string, at time.Time) {
+	if f.count == 0 {
+		f.symbol, f.side, f.feeAsset, f.first = symbol, side, feeAsset, at
+	} else if f.feeAsset != feeAsset {
+		f.feeAsset = ""
+	}
+	if at.Before(f.first) {
+		f.first = at
+	}
+	f.qty += qty
+	f.fee += fee
+	f.count++
+}
+
+// Reconciler compares the account's trade history on the exchange with the bot's trade journal
+type Reconciler struct {
+	config ReconcileConfig
+}
+
+// NewReconciler creates a reconciler
+func NewReconciler(config ReconcileConfig) *Reconciler {
+	if config.QuoteAsset == "" {
+		config.QuoteAsset = "USDT" // default
+	}
+	if config.QuantityTolerance == 0 {
+		config.QuantityTolerance = 0.000001 // default
+	}
+	if config.FeeTolerance == 0 {
+		config.FeeTolerance = 0.0001 // default
+	}
+	return &Reconciler{config: config}
+}
+
+// Reconcile matches exchange trades and journaled fills of symbol in [start, end) by order ID and reports
+// every order whose quantity or fee disagrees
+func (r *Reconciler) Reconcile(symbol string, start, end time.Time, trades []trading.Trade, entries []journal.JournalEntry) *Report {
+	report := &Report{
+		Symbol:      symbol,
+		Start:       start,
+		End:         end,
+		GeneratedAt: time.Now(),
+		Counts:      make(map[DiscrepancyType]int),
+	}
+	inPeriod := func(at time.Time) bool {
+		return !at.Before(start) && at.Before(end)
+	}
+
+	exchange := make(map[string]*orderFills)
+	for _, trade := range trades {
+		if !strings.EqualFold(trade.Symbol, symbol) || !inPeriod(trade.Timestamp) {
+			continue
+		}
+		report.ExchangeTrades++
+		report.ExchangePnL += trade.RealizedPnL
+		if exchange[trade.OrderID] == nil {
+			exchange[trade.OrderID] = &orderFills{}
+		}
+		exchange[trade.OrderID].add(trade.Symbol, string(trade.Side), trade.Quantity, trade.Fee, strings.ToUpper(trade.FeeAsset), trade.Timestamp)
+	}
+
+	journaled := make(map[string]*orderFills)
+	for _, entry := range entries {
+		if entry.EventType != "fill" && entry.EventType != "partial_fill" {
+			continue
+		}
+		if !strings.EqualFold(entry.Symbol, symbol) || !inPeriod(entry.Timestamp) {
+			continue
+		}
+		report.JournalFills++
+		report.JournalPnL += entry.RealizedPnL
+		report.JournalFees += entry.Fee
+		if journaled[entry.OrderID] == nil {
+			journaled[entry.OrderID] = &orderFills{}
+		}
+		journaled[entry.OrderID].add(entry.Symbol, string(entry.Side), entry.Quantity, entry.Fee, r.config.QuoteAsset, entry.Timestamp)
+	}
+	report.ExchangeOrders = len(exchange)
+	report.JournalOrders = len(journaled)
+
+	for orderID, onExchange := range exchange {
+		quoteFee := onExchange.feeAsset == r.config.QuoteAsset
+		if quoteFee {
+			report.ExchangeFees += onExchange.fee
+		}
+
+		inJournal, exists := journaled[orderID]
+		if !exists {
+			r.flag(report, DiscrepancyManualTrade, orderID, onExchange, &orderFills{},
+				fmt.Sprintf("%d exchange trades of %.8g with no journaled fill", onExchange.count, onExchange.qty))
+			continue
+		}
+
+		tolerance := onExchange.qty * r.config.QuantityTolerance
+		switch {
+		case onExchange.qty-inJournal.qty > tolerance:
+			r.flag(report, DiscrepancyMissedFill, orderID, onExchange, inJournal,
+				fmt.Sprintf("journal is missing %.8g of the filled quantity", onExchange.qty-inJournal.qty))
+		case inJournal.qty-onExchange.qty > tolerance:
+			r.flag(report, DiscrepancyUnconfirmedFill, orderID, onExchange, inJournal,
+				fmt.Sprintf("journal holds %.8g more than the exchange filled", inJournal.qty-onExchange.qty))
+		case !quoteFee:
+			report.FeesUnchecked++
+			report.Matched++
+		case math.Abs(onExchange.fee-inJournal.fee) > r.config.FeeTolerance:
+			r.flag(report, DiscrepancyFeeMismatch, orderID, onExchange, inJournal,
+				fmt.Sprintf("exchange charged %.8g %s, journal recorded %.8g", onExchange.fee, onExchange.feeAsset, inJournal.fee))
+		default:
+			report.Matched++
+		}
+	}
+
+	for orderID, inJournal := range journaled {
+		if _, exists := exchange[orderID]; !exists {
+			r.flag(report, DiscrepancyUnconfirmedFill, orderID, &orderFills{symbol: inJournal.symbol, side: inJournal.side}, inJournal,
+				fmt.Sprintf("%d journaled fills of %.8g not found on the exchange", inJournal.count, inJournal.qty))
+		}
+	}
+
+	sort.Slice(report.Discrepancies, func(i, j int) bool {
+		a, b := report.Discrepancies[i], report.Discrepancies[j]
+		if !a.Timestamp.Equal(b.Timestamp) {
+			return a.Timestamp.Before(b.Timestamp)
+		}
+		return a.OrderID < b.OrderID
+	})
+	return report
+}
+
+// flag records a discrepancy of an order
+func (r *Reconciler) flag(report *Report, discrepancyType DiscrepancyType, orderID string, onExchange, inJournal *orderFills, detail string) {
+	side, symbol, at := onExchange.side, onExchange.symbol, onExchange.first
+	if onExchange.count == 0 {
+		side, symbol, at = inJournal.side, inJournal.symbol, inJournal.first
+	}
+	report.Counts[discrepancyType]++
+	report.Discrepancies = append(report.Discrepancies, Discrepancy{
+		Type:        discrepancyType,
+		OrderID:     orderID,
+		Symbol:      symbol,
+		Side:        side,
+		ExchangeQty: onExchange.qty,
+		JournalQty:  inJournal.qty,
+		ExchangeFee: onExchange.fee,
+		JournalFee:  inJournal.fee,
+		Timestamp:   at,
+		Detail:      detail,
+	})
+}
+
+// FormatText renders the report as a human-readable summary
+func (r *Report) FormatText() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Period:           %s - %s\n", r.Start.UTC().Format(time.RFC3339), r.End.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Exchange:         %d trades in %d orders\n", r.ExchangeTrades, r.ExchangeOrders)
+	fmt.Fprintf(&b, "Journal:          %d fills in %d orders\n", r.JournalFills, r.JournalOrders)
+	fmt.Fprintf(&b, "Matched orders:   %d (%d with fees in another asset)\n", r.Matched, r.FeesUnchecked)
+	fmt.Fprintf(&b, "Fees:             exchange %.4f, journal %.4f\n", r.ExchangeFees, r.JournalFees)
+	fmt.Fprintf(&b, "Realized PnL:     exchange %.4f, journal %.4f\n", r.ExchangePnL, r.JournalPnL)
+	if r.Clean() {
+		b.WriteString("No discrepancies\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Discrepancies:    %d\n", len(r.Discrepancies))
+	for _, discrepancyType := range []DiscrepancyType{DiscrepancyManualTrade, DiscrepancyMissedFill, DiscrepancyUnconfirmedFill, DiscrepancyFeeMismatch} {
+		if count := r.Counts[discrepancyType]; count > 0 {
+			fmt.Fprintf(&b, "  %-18s %d\n", discrepancyType, count)
+		}
+	}
+	b.WriteString("\n")
+	for _, d := range r.Discrepancies {
+		fmt.Fprintf(&b, "%s  %-16s order %-14s %-4s %s\n", d.Timestamp.UTC().Format("2006-01-02 15:04:05"), d.Type, d.OrderID, d.Side, d.Detail)
+	}
+	return b.String()
+}
+
+// SaveReport writes the report as JSON to path
+func SaveReport(report *Report, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal reconciliation report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write reconciliation report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package screener
+
+import (
+	"aibot/internal/types"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRESTURL is the Binance spot REST API
+const DefaultRESTURL = "https://api.binance.com"
+
+// RESTClient reads klines and 24h tickers from a Binance-compatible REST API
+type RESTClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewRESTClient creates a REST client for baseURL
+func NewRESTClient(baseURL string, timeout time.Duration) *RESTClient {
+	if baseURL == "" {
+		baseURL = DefaultRESTURL // default
+	}
+	if timeout == 0 {
+		timeout = 10 * time.Second // default
+	}
+
+	return &RESTClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// GetCandles returns the most recent closed and open klines of a symbol, oldest first
+func (c *RESTClient) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]types.OHLCV, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	query.Set("interval", interval)
+	query.Set("limit", strconv.Itoa(limit))
+
+	var rows [][]interface{}
+	if err := c.get(ctx, "/api/v3/klines", query, &rows); err != nil {
+		return nil, err
+	}
+
+	candles := make([]types.OHLCV, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 6 {
+			return nil, fmt.Errorf("malformed kline for %s", symbol)
+		}
+		openTime, ok := row[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("malformed kline open time for %s", symbol)
+		}
+		values := make([]float64, 5)
+		for i := range values {
+			text, _ := row[i+1].(string)
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed kline value for %s: %q", symbol, text)
+			}
+			values[i] = value
+		}
+		candles = append(candles, types.NewOHLCV(symbol, time.UnixMilli(int64(openTime)),
+			values[0], values[1], values[2], values[3], values[4]))
+	}
+	return candles, nil
+}
+
+// GetStats returns the 24h ticker of a symbol
+func (c *RESTClient) GetStats(ctx context.Context, symbol string) (*MarketStats, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+
+	var ticker struct {
+		LastPrice   string `json:"lastPrice"`
+		BidPrice    string `json:"bidPrice"`
+		AskPrice    string `json:"askPrice"`
+		QuoteVolume string `json:"quoteVolume"`
+	}
+	if err := c.get(ctx, "/api/v3/ticker/24hr", query, &ticker); err != nil {
+		return nil, err
+	}
+
+	stats := &MarketStats{}
+	for _, field := range []struct {
+		text  string
+		value *float64
+	}{
+		{ticker.LastPrice, &stats.LastPrice},
+		{ticker.BidPrice, &stats.BidPrice},
+		{ticker.AskPrice, &stats.AskPrice},
+		{ticker.QuoteVolume, &stats.QuoteVolume},
+	} {
+		value, err := strconv.ParseFloat(field.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed ticker for %s: %q", symbol, field.text)
+		}
+		*field.value = value
+	}
+	return stats, nil
+}
+
+// get requests a REST endpoint and decodes its JSON response
+func (c *RESTClient) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiError struct {
+			Code    int    `json:"code"`
+			Message string `json:"msg"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiError) == nil && apiError.Message != "" {
+			return fmt.Errorf("%s returned status %d: %s", path, resp.StatusCode, apiError.Message)
+		}
+		return fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package screener
+
+import (
+	"aibot/internal/indicators"
+	"aibot/internal/types"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Weights of the metric scores in the overall grid suitability score
+const (
+	volatilityWeight = 0.35
+	adxWeight        = 0.30
+	volumeWeight     = 0.20
+	spreadWeight     = 0.15
+)
+
+// adxPeriod is the ADX period used to measure trend strength
+const adxPeriod = 14
+
+// MarketData is the exchange data the screener evaluates symbols with
+type MarketData interface {
+	GetCandles(ctx context.Context, symbol, interval string, limit int) ([]types.OHLCV, error)
+	GetStats(ctx context.Context, symbol string) (*MarketStats, error)
+}
+
+// MarketStats is a symbol's 24h ticker
+type MarketStats struct {
+	LastPrice   float64 `json:"last_price"`
+	BidPrice    float64 `json:"bid_price"`
+	AskPrice    float64 `json:"ask_price"`
+	QuoteVolume float64 `json:"quote_volume"` // 24h volume in the quote asset
+}
+
+// ScreenerConfig holds the symbol universe and the grid-friendliness limits
+type ScreenerConfig struct {
+	Symbols        []string `json:"symbols"`          // Universe to screen
+	Interval       string   `json:"interval"`         // Candle interval for volatility and ADX (1h)
+	Lookback       int      `json:"lookback"`         // Candles analyzed (168)
+	MinVolatility  float64  `json:"min_volatility"`   // Lowest daily realized volatility worth gridding (0.01)
+	MaxVolatility  float64  `json:"max_volatility"`   // Highest daily realized volatility before ranges break (0.06)
+	MaxADX         float64  `json:"max_adx"`          // Highest ADX; above it the symbol is trending (25)
+	MinQuoteVolume float64  `json:"min_quote_volume"` // Lowest 24h quote volume (10,000,000)
+	MaxSpread      float64  `json:"max_spread"`       // Widest bid-ask spread as a fraction of mid (0.001)
+	TopN           int      `json:"top_n"`            // Symbols selected from the ranking (3)
+}
+
+// SymbolScore is the evaluation of one symbol; scores are in [0, 1] and higher is more grid friendly
+type SymbolScore struct {
+	Symbol      string   `json:"symbol"`
+	Volatility  float64  `json:"volatility"` // Daily realized volatility
+	ADX         float64  `json:"adx"`
+	QuoteVolume float64  `json:"quote_volume"`
+	Spread      float64  `json:"spread"`
+	Score       float64  `json:"score"`
+	Eligible    bool     `json:"eligible"`
+	Reasons     []string `json:"reasons,omitempty"` // Limits the symbol failed
+	Error       string   `json:"error,omitempty"`   // Data could not be fetched
+}
+
+// Report is a ranked screening run; eligible symbols come first, ordered by score
+type Report struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Interval    string        `json:"interval"`
+	Lookback    int           `json:"lookback"`
+	Results     []SymbolScore `json:"results"`
+	Selected    []string      `json:"selected"` // Top eligible symbols
+}
+
+// Screener ranks symbols by how well they suit grid trading: volatility inside a band, weak trend,
+// deep volume and a tight spread
+type Screener struct {
+	config ScreenerConfig
+	source MarketData
+}
+
+// NewScreener creates a screener reading exchange data from source
+func NewScreener(config ScreenerConfig, source MarketData) *Screener {
+	if config.Interval == "" {
+		config.Interval = "1h" // default
+	}
+	if config.Lookback == 0 {
+		config.Lookback = 168 // default
+	}
+	if config.MinVolatility == 0 {
+		config.MinVolatility = 0.01 // default
+	}
+	if config.MaxVolatility == 0 {
+		config.MaxVolatility = 0.06 // default
+	}
+	if config.MaxADX == 0 {
+		config.MaxADX = 25 // default
+	}
+	if config.MinQuoteVolume == 0 {
+		config.MinQuoteVolume = 10000000 // default
+	}
+	if config.MaxSpread == 0 {
+		config.MaxSpread = 0.001 // default
+	}
+	if config.TopN == 0 {
+		config.TopN = 3 // default
+	}
+
+	return &Screener{
+		config: config,
+		source: source,
+	}
+}
+
+// Screen evaluates every symbol of the universe and ranks them; a symbol whose data cannot be fetched
+// is reported with its error rather than failing the run
+func (s *Screener) Screen(ctx context.Context) (*Report, error) {
+	if len(s.config.Symbols) == 0 {
+		return nil, fmt.Errorf("no symbols to screen")
+	}
+	candlesPerDay, err := candlesPerDay(s.config.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		GeneratedAt: time.Now(),
+		Interval:    s.config.Interval,
+		Lookback:    s.config.Lookback,
+		Results:     make([]SymbolScore, 0, len(s.config.Symbols)),
+	}
+	for _, symbol := range s.config.Symbols {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, s.evaluate(ctx, symbol, candlesPerDay))
+	}
+
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+		if a.Eligible != b.Eligible {
+			return a.Eligible
+		}
+		return a.Score > b.Score
+	})
+	for _, result := range report.Results {
+		if result.Eligible && len(report.Selected) < s.config.TopN {
+			report.Selected = append(report.Selected, result.Symbol)
+		}
+	}
+	return report, nil
+}
+
+// evaluate measures and scores one symbol
+func (s *Screener) evaluate(ctx context.Context, symbol string, candlesPerDay float64) SymbolScore {
+	result := SymbolScore{Symbol: symbol}
+
+	candles, err := s.source.GetCandles(ctx, symbol, s.config.Interval, s.config.Lookback)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if len(candles) < 2*adxPeriod+1 {
+		result.Error = fmt.Sprintf("not enough candles: need %d, have %d", 2*adxPeriod+1, len(candles))
+		return result
+	}
+	stats, err := s.source.GetStats(ctx, symbol)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Volatility = indicators.RealizedVolatility(candles, candlesPerDay)
+	result.ADX = averageDirectionalIndex(candles)
+	result.QuoteVolume = stats.QuoteVolume
+	if mid := (stats.BidPrice + stats.AskPrice) / 2; mid > 0 && stats.AskPrice >= stats.BidPrice {
+		result.Spread = (stats.AskPrice - stats.BidPrice) / mid
+	}
+
+	// Volatility scores best in the middle of the band
+	center := (s.config.MinVolatility + s.config.MaxVolatility) / 2
+	halfWidth := (s.config.MaxVolatility - s.config.MinVolatility) / 2
+	volatilityScore := 0.0
+	if halfWidth > 0 {
+		volatilityScore = clamp(1 - math.Abs(result.Volatility-center)/halfWidth)
+	}
+	adxScore := clamp(1 - result.ADX/s.config.MaxADX)
+	volumeScore := 0.0
+	if result.QuoteVolume > 0 {
+		volumeScore = clamp(math.Log10(result.QuoteVolume/s.config.MinQuoteVolume) / 2) // 100x the minimum scores 1
+	}
+	spreadScore := clamp(1 - result.Spread/s.config.MaxSpread)
+	result.Score = volatilityWeight*volatilityScore + adxWeight*adxScore + volumeWeight*volumeScore + spreadWeight*spreadScore
+
+	if result.Volatility < s.config.MinVolatility {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("volatility %.2f%% below %.2f%%", result.Volatility*100, s.config.MinVolatility*100))
+	}
+	if result.Volatility > s.config.MaxVolatility {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("volatility %.2f%% above %.2f%%", result.Volatility*100, s.config.MaxVolatility*100))
+	}
+	if result.ADX > s.config.MaxADX {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("ADX %.1f above %.1f (trending)", result.ADX, s.config.MaxADX))
+	}
+	if result.QuoteVolume < s.config.MinQuoteVolume {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("24h volume %.0f below %.0f", result.QuoteVolume, s.config.MinQuoteVolume))
+	}
+	if result.Spread > s.config.MaxSpread {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("spread %.3f%% above %.3f%%", result.Spread*100, s.config.MaxSpread*100))
+	}
+	result.Eligible = len(result.Reasons) == 0
+	return result
+}
+
+// SelectDaily returns the symbols selected today, screening once per day and reusing the saved report
+// of the day afterwards
+func (s *Screener) SelectDaily(ctx context.Context, dir string, now time.Time) (*Report, error) {
+	path := filepath.Join(dir, fmt.Sprintf("screen-%s.json", now.Format("2006-01-02")))
+	if report, err := LoadReport(path); err == nil {
+		return report, nil
+	}
+
+	report, err := s.Screen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveReport(report, path); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// SaveReport writes a report as indented JSON
+func SaveReport(report *Report, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal screener report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create screener directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write screener report: %w", err)
+	}
+	return nil
+}
+
+// LoadReport reads a saved report
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse screener report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// averageDirectionalIndex returns the ADX at the last candle
+func averageDirectionalIndex(candles []types.OHLCV) float64 {
+	adx := indicators.NewStreamingADX(adxPeriod)
+	for _, candle := range candles {
+		adx.Update(candle.High, candle.Low, candle.Close)
+	}
+	return adx.Value()
+}
+
+// candlesPerDay converts an exchange kline interval such as "15m", "1h" or "1d" to candles per day
+func candlesPerDay(interval string) (float64, error) {
+	if len(interval) >= 2 && interval[len(interval)-1] == 'd' {
+		var days int
+		if _, err := fmt.Sscanf(interval, "%dd", &days); err != nil || days <= 0 {
+			return 0, fmt.Errorf("invalid screener interval: %s", interval)
+		}
+		return 1 / float64(days), nil
+	}
+	duration, err := time.ParseDuration(interval)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid screener interval: %s", interval)
+	}
+	return float64(24*time.Hour) / float64(duration), nil
+}
+
+// clamp limits a score to [0, 1]
+func clamp(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
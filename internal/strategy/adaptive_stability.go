@@ -0,0 +1,229 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"math"
+	"sort"
+	"time"
+)
+
+// AdaptiveStabilityConfig calibrates the stability thresholds from rolling percentiles of recent market data,
+// so "stable" means quiet for the asset rather than quiet by a fixed fraction of price
+type AdaptiveStabilityConfig struct {
+	Enabled              bool          `json:"enabled"`
+	Lookback             time.Duration `json:"lookback"`              // History the percentiles are taken over (24h)
+	SampleInterval       time.Duration `json:"sample_interval"`       // Time between samples of the analysis window (1m)
+	MinSamples           int           `json:"min_samples"`           // Samples needed before the static thresholds are replaced (60)
+	VolatilityPercentile float64       `json:"volatility_percentile"` // Volatility below this percentile counts as stable (0.3)
+	MomentumPercentile   float64       `json:"momentum_percentile"`   // Momentum below this percentile counts as stable (0.3)
+	ConformityPercentile float64       `json:"conformity_percentile"` // Price dispersion below this percentile counts as conforming (0.3)
+}
+
+// StabilityThresholds are the limits a stability check scores against
+type StabilityThresholds struct {
+	Volatility float64 `json:"volatility"` // Max average candle change or ATR, as a fraction of price
+	Momentum   float64 `json:"momentum"`   // Max average momentum, as a fraction of price
+	Conformity float64 `json:"conformity"` // Max coefficient of variation of closes
+	Adaptive   bool    `json:"adaptive"`   // Calibrated from recent history rather than the static configuration
+	Samples    int     `json:"samples"`
+}
+
+// stabilitySample is one measurement of the analysis window
+type stabilitySample struct {
+	at         time.Time
+	volatility float64
+	momentum   float64
+	conformity float64
+}
+
+// adaptiveHistory holds the samples and calibrated thresholds of one symbol
+type adaptiveHistory struct {
+	samples    []stabilitySample
+	thresholds StabilityThresholds
+}
+
+// staticTargetCV is the coefficient of variation below which closes fully conform when not calibrated
+const staticTargetCV = 0.01
+
+// Observe samples the analysis window of a symbol once per sample interval and recalibrates its thresholds.
+// It is called on every tick whatever the mode, so the history covers quiet and busy markets alike.
+func (ps *PriceStabilityDetector) Observe(symbol string, now time.Time) {
+	if !ps.adaptive.Enabled {
+		return
+	}
+
+	ps.adaptiveMu.Lock()
+	history, ok := ps.histories[symbol]
+	if !ok {
+		history = &adaptiveHistory{}
+		ps.histories[symbol] = history
+	}
+	due := len(history.samples) == 0 || now.Sub(history.samples[len(history.samples)-1].at) >= ps.adaptive.SampleInterval
+	ps.adaptiveMu.Unlock()
+	if !due {
+		return
+	}
+
+	candles := ps.candleAggregator.GetCandles(symbol, ps.PrimaryTimeframe, ps.StabilityWindow)
+	if len(candles) < ps.StabilityWindow {
+		return
+	}
+	sample := stabilitySample{
+		at:         now,
+		volatility: measureVolatility(candles),
+		momentum:   measureMomentum(candles),
+		conformity: measureDispersion(candles),
+	}
+	if atr, ok := ps.namedVolatility(symbol, candles[len(candles)-1].Close); ok {
+		sample.volatility = atr
+	}
+
+	ps.adaptiveMu.Lock()
+	defer ps.adaptiveMu.Unlock()
+
+	history.samples = append(history.samples, sample)
+	cutoff := now.Add(-ps.adaptive.Lookback)
+	first := sort.Search(len(history.samples), func(i int) bool { return history.samples[i].at.After(cutoff) })
+	history.samples = history.samples[first:]
+	history.thresholds = ps.calibrate(history.samples)
+}
+
+// calibrate derives thresholds from samples, keeping a static threshold where the percentile is zero
+// (a flat market would otherwise make every check unstable)
+func (ps *PriceStabilityDetector) calibrate(samples []stabilitySample) StabilityThresholds {
+	thresholds := ps.staticThresholds()
+	thresholds.Samples = len(samples)
+	if len(samples) < ps.adaptive.MinSamples {
+		return thresholds
+	}
+
+	volatility := make([]float64, len(samples))
+	momentum := make([]float64, len(samples))
+	conformity := make([]float64, len(samples))
+	for i, sample := range samples {
+		volatility[i] = sample.volatility
+		momentum[i] = sample.momentum
+		conformity[i] = sample.conformity
+	}
+	if value := percentile(volatility, ps.adaptive.VolatilityPercentile); value > 0 {
+		thresholds.Volatility = value
+	}
+	if value := percentile(momentum, ps.adaptive.MomentumPercentile); value > 0 {
+		thresholds.Momentum = value
+	}
+	if value := percentile(conformity, ps.adaptive.ConformityPercentile); value > 0 {
+		thresholds.Conformity = value
+	}
+	thresholds.Adaptive = true
+	return thresholds
+}
+
+// Thresholds returns the thresholds stability checks of a symbol score against
+func (ps *PriceStabilityDetector) Thresholds(symbol string) StabilityThresholds {
+	ps.adaptiveMu.Lock()
+	defer ps.adaptiveMu.Unlock()
+
+	if history, ok := ps.histories[symbol]; ok && history.thresholds.Adaptive {
+		return history.thresholds
+	}
+	thresholds := ps.staticThresholds()
+	if history, ok := ps.histories[symbol]; ok {
+		thresholds.Samples = len(history.samples)
+	}
+	return thresholds
+}
+
+// staticThresholds returns the configured thresholds
+func (ps *PriceStabilityDetector) staticThresholds() StabilityThresholds {
+	return StabilityThresholds{
+		Volatility: ps.VolatilityThreshold,
+		Momentum:   ps.MomentumThreshold,
+		Conformity: staticTargetCV,
+	}
+}
+
+// GetAdaptiveStats returns the thresholds in effect per symbol
+func (ps *PriceStabilityDetector) GetAdaptiveStats() map[string]interface{} {
+	ps.adaptiveMu.Lock()
+	defer ps.adaptiveMu.Unlock()
+
+	symbols := make(map[string]StabilityThresholds, len(ps.histories))
+	for symbol, history := range ps.histories {
+		thresholds := history.thresholds
+		if !thresholds.Adaptive {
+			thresholds = ps.staticThresholds()
+			thresholds.Samples = len(history.samples)
+		}
+		symbols[symbol] = thresholds
+	}
+	return map[string]interface{}{
+		"enabled":     ps.adaptive.Enabled,
+		"lookback":    ps.adaptive.Lookback.String(),
+		"min_samples": ps.adaptive.MinSamples,
+		"symbols":     symbols,
+	}
+}
+
+// measureVolatility returns the average absolute close-to-close change
+func measureVolatility(candles []types.OHLCV) float64 {
+	if len(candles) < 2 {
+		return 0
+	}
+	changes := make([]float64, len(candles)-1)
+	for i := 1; i < len(candles); i++ {
+		changes[i-1] = math.Abs((candles[i].Close - candles[i-1].Close) / candles[i-1].Close)
+	}
+	return calculateAverage(changes)
+}
+
+// measureMomentum returns the average absolute momentum over the last 3 candles and half the window
+func measureMomentum(candles []types.OHLCV) float64 {
+	if len(candles) < 3 {
+		return 0
+	}
+	return (math.Abs(momentumOver(candles, 3)) + math.Abs(momentumOver(candles, len(candles)/2))) / 2
+}
+
+// momentumOver returns the price change over the last period candles
+func momentumOver(candles []types.OHLCV, period int) float64 {
+	if len(candles) < period || period < 2 {
+		return 0
+	}
+	start := candles[len(candles)-period].Close
+	return (candles[len(candles)-1].Close - start) / start
+}
+
+// measureDispersion returns the coefficient of variation of the closes
+func measureDispersion(candles []types.OHLCV) float64 {
+	if len(candles) == 0 {
+		return 0
+	}
+	prices := make([]float64, len(candles))
+	for i, candle := range candles {
+		prices[i] = candle.Close
+	}
+	mean := calculateAverage(prices)
+	if mean == 0 {
+		return 0
+	}
+	sumSquares := 0.0
+	for _, price := range prices {
+		sumSquares += (price - mean) * (price - mean)
+	}
+	return math.Sqrt(sumSquares/float64(len(prices))) / mean
+}
+
+// percentile returns the p-quantile (0-1) of values by linear interpolation; values are sorted in place
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	position := p * float64(len(values)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	if lower == upper {
+		return values[lower]
+	}
+	return values[lower] + (values[upper]-values[lower])*(position-float64(lower))
+}
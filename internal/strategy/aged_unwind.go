@@ -0,0 +1,116 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Aged position unwind policies
+const (
+	UnwindMarket  = "market"  // Close the whole position at once
+	UnwindPassive = "passive" // Close the whole position; the caller works the close with passive limit orders
+	UnwindStepped = "stepped" // Close a fraction of the position every step interval
+)
+
+// AgedUnwindConfig holds how positions held past the timeout are unwound
+type AgedUnwindConfig struct {
+	Policy       string        `json:"policy"`        // "market", "passive" or "stepped" (market)
+	StepFraction float64       `json:"step_fraction"` // Stepped: fraction of the aged size closed per step (0.25)
+	StepInterval time.Duration `json:"step_interval"` // Stepped: time between steps (1h)
+}
+
+// Steps returns the number of closes an unwind takes
+func (c AgedUnwindConfig) Steps() int {
+	if c.Policy != UnwindStepped {
+		return 1
+	}
+	return int(math.Ceil(1/c.StepFraction - 1e-9))
+}
+
+// AgedUnwind is a close of an aged position, booked by the position manager for the caller to execute
+type AgedUnwind struct {
+	Key    string             `json:"key"` // Position key
+	Policy string             `json:"policy"`
+	Step   int                `json:"step"` // 1-based step of the unwind
+	Steps  int                `json:"steps"`
+	Age    time.Duration      `json:"age"`
+	Result *types.OrderResult `json:"result"`
+}
+
+// agedUnwind tracks a stepped unwind in progress
+type agedUnwind struct {
+	started time.Time
+	size    float64 // Position size when the unwind started
+	steps   int     // Steps taken
+}
+
+// ProcessAgedPositions books the unwind steps due for positions of a symbol held longer than the timeout;
+// in hedge mode both sides are checked
+func (pm *PositionManager) ProcessAgedPositions(symbol string, currentPrice float64, now time.Time) ([]AgedUnwind, error) {
+	keys := []string{symbol}
+	if pm.HedgeMode {
+		keys = []string{types.PositionKey(symbol, types.PositionTypeLong), types.PositionKey(symbol, types.PositionTypeShort)}
+	}
+
+	var unwinds []AgedUnwind
+	for _, key := range keys {
+		unwind, err := pm.processAgedPosition(key, currentPrice, now)
+		if err != nil {
+			return nil, err
+		}
+		if unwind != nil {
+			unwinds = append(unwinds, *unwind)
+		}
+	}
+	return unwinds, nil
+}
+
+// processAgedPosition books the next unwind step of a single position, if one is due
+func (pm *PositionManager) processAgedPosition(key string, currentPrice float64, now time.Time) (*AgedUnwind, error) {
+	state, exists := pm.positions[key]
+	if !exists {
+		return nil, nil
+	}
+	age := now.Sub(state.EntryTime)
+	if age <= time.Duration(pm.TimeoutHours)*time.Hour {
+		return nil, nil
+	}
+
+	steps := pm.AgedUnwind.Steps()
+	step := 1
+	quantity := state.Position.Size
+	if pm.AgedUnwind.Policy == UnwindStepped {
+		progress, ok := pm.unwinds[key]
+		if !ok {
+			progress = &agedUnwind{started: now, size: state.Position.Size}
+			pm.unwinds[key] = progress
+		}
+		if now.Before(progress.started.Add(time.Duration(progress.steps) * pm.AgedUnwind.StepInterval)) {
+			return nil, nil
+		}
+		progress.steps++
+		step = progress.steps
+		if step < steps {
+			quantity = progress.size * pm.AgedUnwind.StepFraction
+		}
+	}
+
+	reason := "Position timeout"
+	if steps > 1 {
+		reason = fmt.Sprintf("Aged position unwind %d/%d", step, steps)
+	}
+	result, err := pm.ClosePosition(key, quantity, currentPrice, reason, TriggerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &AgedUnwind{
+		Key:    key,
+		Policy: pm.AgedUnwind.Policy,
+		Step:   step,
+		Steps:  steps,
+		Age:    age,
+		Result: result,
+	}, nil
+}
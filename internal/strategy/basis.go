@@ -0,0 +1,235 @@
+package strategy
+
+import (
+	"aibot/internal/indicators"
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// BasisConfig holds the thresholds of the spot-futures basis monitor
+type BasisConfig struct {
+	MaxBasis  float64       `json:"max_basis"`  // |mark - spot| / spot above which grid entries pause (0 = never pause)
+	BiasBasis float64       `json:"bias_basis"` // Smoothed basis beyond which breakouts paying the premium are skipped (0 = no bias)
+	Smoothing int           `json:"smoothing"`  // EMA period of the smoothed basis, in readings (20)
+	MaxAge    time.Duration `json:"max_age"`    // A spot or mark price older than this makes the reading stale (30s)
+}
+
+// BasisReading is the spread between the perpetual mark price and the spot price of a symbol
+type BasisReading struct {
+	Symbol    string    `json:"symbol"`
+	Spot      float64   `json:"spot"`
+	Mark      float64   `json:"mark"`
+	Basis     float64   `json:"basis"`            // (mark - spot) / spot; positive when the perpetual trades at a premium
+	Smoothed  float64   `json:"smoothed"`         // EMA of the basis
+	Extreme   bool      `json:"extreme"`          // Basis beyond the pause threshold
+	Stale     bool      `json:"stale"`            // A price is missing or too old to trust the basis
+	Reason    string    `json:"reason,omitempty"` // Why the reading is extreme or stale
+	SpotTime  time.Time `json:"spot_time"`
+	MarkTime  time.Time `json:"mark_time"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// basisState holds the latest prices and readings of one symbol
+type basisState struct {
+	spot     float64
+	mark     float64
+	spotTime time.Time
+	markTime time.Time
+	ema      *indicators.StreamingEMA
+	last     BasisReading
+}
+
+// BasisMonitor tracks the basis between spot and perpetual prices per symbol. An extreme basis pauses grid
+// entries and a persistent premium or discount biases breakouts against paying it. A stale or broken spot
+// feed shows up as a stale reading or a basis that drifts away as the perpetual moves.
+type BasisMonitor struct {
+	config BasisConfig
+	states map[string]*basisState
+
+	// Statistics
+	readings      int64
+	extremeEvents int64 // Transitions into an extreme basis
+	staleEvents   int64 // Transitions into a stale reading
+	biasSkipped   int64
+
+	mu sync.RWMutex
+}
+
+// NewBasisMonitor creates a basis monitor
+func NewBasisMonitor(config BasisConfig) *BasisMonitor {
+	if config.Smoothing == 0 {
+		config.Smoothing = 20 // default
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = 30 * time.Second // default
+	}
+
+	return &BasisMonitor{
+		config: config,
+		states: make(map[string]*basisState),
+	}
+}
+
+// state returns the state of a symbol, creating it; caller must hold bm.mu
+func (bm *BasisMonitor) state(symbol string) *basisState {
+	state, exists := bm.states[symbol]
+	if !exists {
+		state = &basisState{ema: indicators.NewStreamingEMA(bm.config.Smoothing)}
+		bm.states[symbol] = state
+	}
+	return state
+}
+
+// UpdateSpot records the spot price of a symbol
+func (bm *BasisMonitor) UpdateSpot(symbol string, price float64, at time.Time) {
+	if bm == nil || price <= 0 {
+		return
+	}
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	state := bm.state(symbol)
+	state.spot, state.spotTime = price, at
+}
+
+// UpdateMark records the perpetual mark price of a symbol
+func (bm *BasisMonitor) UpdateMark(symbol string, price float64, at time.Time) {
+	if bm == nil || price <= 0 {
+		return
+	}
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	state := bm.state(symbol)
+	state.mark, state.markTime = price, at
+}
+
+// Evaluate computes the basis of a symbol from the latest prices and reports whether it changed between
+// normal, extreme and stale. Stale readings do not feed the smoothed basis and do not pause entries.
+func (bm *BasisMonitor) Evaluate(symbol string, now time.Time) (BasisReading, bool) {
+	if bm == nil {
+		return BasisReading{}, false
+	}
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	state := bm.state(symbol)
+	reading := BasisReading{
+		Symbol:    symbol,
+		Spot:      state.spot,
+		Mark:      state.mark,
+		Smoothed:  state.ema.Value(),
+		SpotTime:  state.spotTime,
+		MarkTime:  state.markTime,
+		Timestamp: now,
+	}
+
+	switch {
+	case state.spot <= 0 || state.mark <= 0:
+		reading.Stale = true
+		reading.Reason = fmt.Sprintf("no spot or mark price for %s", symbol)
+	case now.Sub(state.spotTime) > bm.config.MaxAge:
+		reading.Stale = true
+		reading.Reason = fmt.Sprintf("spot price of %s not updated for %v", symbol, now.Sub(state.spotTime).Round(time.Second))
+	case now.Sub(state.markTime) > bm.config.MaxAge:
+		reading.Stale = true
+		reading.Reason = fmt.Sprintf("mark price of %s not updated for %v", symbol, now.Sub(state.markTime).Round(time.Second))
+	default:
+		reading.Basis = (state.mark - state.spot) / state.spot
+		reading.Smoothed = state.ema.Update(reading.Basis)
+		if bm.config.MaxBasis > 0 && math.Abs(reading.Basis) >= bm.config.MaxBasis {
+			reading.Extreme = true
+			reading.Reason = fmt.Sprintf("basis of %s at %.4f%% beyond %.4f%%", symbol, reading.Basis*100, bm.config.MaxBasis*100)
+		}
+	}
+
+	// Prices missing at startup are not a stale feed yet
+	previous := state.last
+	if previous.Timestamp.IsZero() {
+		previous.Stale = reading.Stale
+	}
+	if reading.Extreme && !previous.Extreme {
+		bm.extremeEvents++
+	}
+	if reading.Stale && !previous.Stale {
+		bm.staleEvents++
+	}
+	state.last = reading
+	bm.readings++
+	return reading, reading.Extreme != previous.Extreme || reading.Stale != previous.Stale
+}
+
+// Basis returns the latest reading of a symbol, or false if it was never evaluated
+func (bm *BasisMonitor) Basis(symbol string) (BasisReading, bool) {
+	if bm == nil {
+		return BasisReading{}, false
+	}
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	state, exists := bm.states[symbol]
+	if !exists || state.last.Timestamp.IsZero() {
+		return BasisReading{}, false
+	}
+	return state.last, true
+}
+
+// EntryBlock returns why new grid inventory is paused for a symbol, or "" while the basis is normal
+func (bm *BasisMonitor) EntryBlock(symbol string) string {
+	reading, ok := bm.Basis(symbol)
+	if !ok || !reading.Extreme {
+		return ""
+	}
+	return reading.Reason
+}
+
+// CheckDirection returns why a breakout in the direction of positionType is skipped, or "" if allowed.
+// While the perpetual trades at a persistent premium longs pay it and are skipped; at a discount, shorts.
+func (bm *BasisMonitor) CheckDirection(symbol string, positionType types.PositionType) string {
+	if bm == nil || bm.config.BiasBasis <= 0 {
+		return ""
+	}
+	reading, ok := bm.Basis(symbol)
+	if !ok || reading.Stale {
+		return ""
+	}
+
+	reason := ""
+	switch {
+	case positionType == types.PositionTypeLong && reading.Smoothed >= bm.config.BiasBasis:
+		reason = fmt.Sprintf("%s perpetual at a %.4f%% premium to spot, not buying it", symbol, reading.Smoothed*100)
+	case positionType == types.PositionTypeShort && reading.Smoothed <= -bm.config.BiasBasis:
+		reason = fmt.Sprintf("%s perpetual at a %.4f%% discount to spot, not selling it", symbol, -reading.Smoothed*100)
+	}
+	if reason != "" {
+		bm.mu.Lock()
+		bm.biasSkipped++
+		bm.mu.Unlock()
+	}
+	return reason
+}
+
+// GetBasisStats returns basis monitoring statistics
+func (bm *BasisMonitor) GetBasisStats() map[string]interface{} {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+
+	basis := make(map[string]BasisReading, len(bm.states))
+	for symbol, state := range bm.states {
+		if !state.last.Timestamp.IsZero() {
+			basis[symbol] = state.last
+		}
+	}
+	return map[string]interface{}{
+		"max_basis":      bm.config.MaxBasis,
+		"bias_basis":     bm.config.BiasBasis,
+		"readings":       bm.readings,
+		"extreme_events": bm.extremeEvents,
+		"stale_events":   bm.staleEvents,
+		"bias_skipped":   bm.biasSkipped,
+		"basis":          basis,
+	}
+}
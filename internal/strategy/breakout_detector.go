@@ -4,7 +4,9 @@ import (
 	"aibot/internal/data"
 	"aibot/internal/indicators"
 	"aibot/internal/types"
+	"fmt"
 	"math"
+	"sync"
 	"time"
 )
 
@@ -20,15 +22,29 @@ type BreakoutDetector struct {
 	// State tracking
 	breakoutHistory      []BreakoutEvent `json:"breakout_history"`
 	recentPrices         []float64       `json:"recent_prices"`
-	recentVolumes        []float64       `json:"recent_volumes"`
 	candleAggregator     *data.CandleAggregator
 	technicalAnalyzer    *indicators.TechnicalAnalyzer
 	signalGenerator      *indicators.SignalGenerator
+	rsiIndicator         string // Named indicator overriding the analyzer's RSI
+	atrIndicator         string // Named indicator overriding the analyzer's ATR
+	volumeIndicator      string // Named indicator overriding the analyzer's volume SMA
+	confirmationMode     BreakoutConfirmationMode
+	confirmationTimeframe data.CandleTimeframe
+	confirmationCloses   int
+	lastEvaluatedCandle  time.Time // Open time of the last closed candle evaluated in closed_candle mode
+	strengthMode         BreakoutStrengthMode
+	minStrengthPercent   float64 // Minimum strength in percent mode, as a fraction of the bound
+	minStrengthATR       float64 // Minimum strength in atr mode, in ATR units
 
 	// Performance tracking
 	falseBreakoutCount   int `json:"false_breakout_count"`
 	trueBreakoutCount    int `json:"true_breakout_count"`
-	consecutiveFailures  int `json:"consecutive_failures"`
+
+	// Long-run success rates fed back into confidence
+	records              map[string]*breakoutRecord // Symbol -> resolved breakouts, including restored history
+	successPrior         float64
+	successWeight        float64
+	learningMu           sync.Mutex
 }
 
 // BreakoutType represents the type of breakout
@@ -40,12 +56,64 @@ const (
 	BreakoutTypeNone   BreakoutType = "none"
 )
 
+const (
+	volumeBaselineCandles  = 20  // Closed candles averaged as the volume baseline of trade-built candles
+	flowImbalanceThreshold = 0.2 // Taker flow imbalance that confirms or opposes a breakout
+)
+
+// BreakoutConfirmationMode selects the prices breakouts are evaluated on
+type BreakoutConfirmationMode string
+
+const (
+	BreakoutConfirmTick         BreakoutConfirmationMode = "tick"          // Every tick, so wicks can trigger
+	BreakoutConfirmClosedCandle BreakoutConfirmationMode = "closed_candle" // Consecutive closed candles only
+)
+
+// IsValid returns true for a known confirmation mode
+func (m BreakoutConfirmationMode) IsValid() bool {
+	return m == BreakoutConfirmTick || m == BreakoutConfirmClosedCandle
+}
+
+// BreakoutStrengthMode selects how breakout strength is measured, and with it the distance beyond a bound
+// that counts as a breakout
+type BreakoutStrengthMode string
+
+const (
+	BreakoutStrengthRange   BreakoutStrengthMode = "range"   // Beyond MinBreakoutStrength of the grid range; strength in percent of the bound
+	BreakoutStrengthPercent BreakoutStrengthMode = "percent" // Percent beyond the bound, whatever the grid's width
+	BreakoutStrengthATR     BreakoutStrengthMode = "atr"     // ATR units beyond the bound, whatever the timeframe or volatility
+)
+
+// Normalized strengths are scored in multiples of the minimum strength
+const (
+	strongBreakoutMultiple       = 2.0 // Reported as a strong breakout
+	fullConfidenceMultiple       = 5.0 // Full strength score in the confidence
+	legacyStrongStrength         = 0.5 // Percent beyond the bound reported as strong in range mode
+	legacyFullConfidenceStrength = 5.0 // Percent beyond the bound scored in full in range mode
+)
+
+// IsValid returns true for a known strength mode
+func (m BreakoutStrengthMode) IsValid() bool {
+	return m == BreakoutStrengthRange || m == BreakoutStrengthPercent || m == BreakoutStrengthATR
+}
+
+// Unit returns the unit strengths are measured in
+func (m BreakoutStrengthMode) Unit() string {
+	if m == BreakoutStrengthATR {
+		return "atr"
+	}
+	return "percent"
+}
+
 // BreakoutSignal represents a detected breakout
 type BreakoutSignal struct {
 	Type         BreakoutType `json:"type"`
 	Confidence   float64      `json:"confidence"`   // 0-1
-	Strength     float64      `json:"strength"`     // How far beyond bounds (%)
+	Strength     float64      `json:"strength"`     // How far beyond bounds, in StrengthUnit
+	StrengthUnit string       `json:"strength_unit"` // "percent" of the bound or "atr" units
 	VolumeRatio  float64      `json:"volume_ratio"`  // Current vs average volume
+	VolumeDelta  float64      `json:"volume_delta"`  // Taker buys minus sells of the last candle (0 without trade data)
+	FlowImbalance float64     `json:"flow_imbalance"` // Volume delta share toward the breakout direction (-1 to 1)
 	ConfirmCandles int         `json:"confirm_candles"` // Candles confirmed
 	Timestamp    time.Time    `json:"timestamp"`
 	Symbol       string       `json:"symbol"`
@@ -81,13 +149,24 @@ type GridBounds struct {
 
 // BreakoutConfig holds configuration for breakout detection
 type BreakoutConfig struct {
-	ConfirmationPeriod  int     `json:"confirmation_period"`  // 3 candles (900ms at 300ms each)
+	ConfirmationPeriod  int     `json:"confirmation_period"`  // 3 candles of the aggregator base interval (900ms at 300ms)
 	MinBreakoutStrength float64 `json:"min_breakout_strength"` // 0.3% (0.003)
 	VolumeMultiplier    float64 `json:"volume_multiplier"`    // 1.5x average volume
 	RSIOverbought       float64 `json:"rsi_overbought"`       // 70
 	RSIOversold         float64 `json:"rsi_oversold"`         // 30
 	ATRMultiple         float64 `json:"atr_multiple"`         // 1.5x ATR
 	MomentumThreshold   float64 `json:"momentum_threshold"`   // 0.5% momentum requirement
+	RSIIndicator        string  `json:"rsi_indicator"`        // Named RSI, e.g. "rsi_7" ("" uses the analyzer default)
+	ATRIndicator        string  `json:"atr_indicator"`        // Named ATR, e.g. "atr_7"
+	VolumeIndicator     string  `json:"volume_indicator"`     // Named volume SMA, e.g. "volume_sma_10"
+	ConfirmationMode    BreakoutConfirmationMode `json:"confirmation_mode"`     // "tick" or "closed_candle" ("tick")
+	ConfirmationTimeframe data.CandleTimeframe   `json:"confirmation_timeframe"` // Closed candles evaluated in closed_candle mode (3s)
+	ConfirmationCloses  int     `json:"confirmation_closes"`  // Consecutive closes beyond a bound in closed_candle mode (2)
+	StrengthMode        BreakoutStrengthMode `json:"strength_mode"`        // "range", "percent" or "atr" ("range")
+	MinStrengthPercent  float64 `json:"min_strength_percent"` // Minimum strength in percent mode, fraction of the bound (0.003)
+	MinStrengthATR      float64 `json:"min_strength_atr"`     // Minimum strength in atr mode, ATR units (0.5)
+	SuccessPrior        float64 `json:"success_prior"`        // Outcomes at 50% the learned success rate starts from (10)
+	SuccessWeight       float64 `json:"success_weight"`       // Confidence shift per unit of success rate away from 50% (1)
 }
 
 // NewBreakoutDetector creates a new breakout detector
@@ -114,6 +193,37 @@ func NewBreakoutDetector(config BreakoutConfig, aggregator *data.CandleAggregato
 	if config.MomentumThreshold == 0 {
 		config.MomentumThreshold = 0.005 // 0.5%
 	}
+	if config.ConfirmationMode == "" {
+		config.ConfirmationMode = BreakoutConfirmTick
+	}
+	if config.ConfirmationTimeframe == "" {
+		config.ConfirmationTimeframe = data.Timeframe3s
+	}
+	if config.ConfirmationCloses == 0 {
+		config.ConfirmationCloses = 2
+	}
+	if config.StrengthMode == "" {
+		config.StrengthMode = BreakoutStrengthRange
+	}
+	if config.MinStrengthPercent == 0 {
+		config.MinStrengthPercent = 0.003 // 0.3%
+	}
+	if config.MinStrengthATR == 0 {
+		config.MinStrengthATR = 0.5
+	}
+	if config.SuccessPrior == 0 {
+		config.SuccessPrior = 10
+	}
+	if config.SuccessWeight == 0 {
+		config.SuccessWeight = 1
+	}
+
+	// Named indicators are shared with other consumers of the analyzer
+	for _, name := range []string{config.RSIIndicator, config.ATRIndicator, config.VolumeIndicator} {
+		if name != "" {
+			analyzer.RegisterIndicator(name)
+		}
+	}
 
 	return &BreakoutDetector{
 		ConfirmationCandles:  config.ConfirmationPeriod,
@@ -122,13 +232,24 @@ func NewBreakoutDetector(config BreakoutConfig, aggregator *data.CandleAggregato
 		MomentumThreshold:    config.MomentumThreshold,
 		candleAggregator:     aggregator,
 		technicalAnalyzer:    analyzer,
+		rsiIndicator:         config.RSIIndicator,
+		atrIndicator:         config.ATRIndicator,
+		volumeIndicator:      config.VolumeIndicator,
+		confirmationMode:     config.ConfirmationMode,
+		confirmationTimeframe: config.ConfirmationTimeframe,
+		confirmationCloses:   config.ConfirmationCloses,
+		strengthMode:         config.StrengthMode,
+		minStrengthPercent:   config.MinStrengthPercent,
+		minStrengthATR:       config.MinStrengthATR,
 		signalGenerator:      indicators.NewSignalGenerator(indicators.SignalThresholds{
 			RSIOverbought: config.RSIOverbought,
 			RSIOversold:   config.RSIOversold,
 		}),
 		breakoutHistory: make([]BreakoutEvent, 0),
 		recentPrices:    make([]float64, 0),
-		recentVolumes:   make([]float64, 0),
+		records:         make(map[string]*breakoutRecord),
+		successPrior:    config.SuccessPrior,
+		successWeight:   config.SuccessWeight,
 	}
 }
 
@@ -137,8 +258,28 @@ func (bd *BreakoutDetector) DetectBreakout(symbol string, gridBounds GridBounds,
 	// Update price history
 	bd.updatePriceHistory(currentPrice)
 
-	// Check for breakout conditions
-	breakoutType := bd.checkBreakoutCondition(gridBounds, currentPrice)
+	// ATR-normalized strength cannot be measured until the ATR has warmed up
+	atr := 0.0
+	if bd.strengthMode == BreakoutStrengthATR {
+		if atr = bd.currentATR(symbol); atr <= 0 {
+			return nil
+		}
+	}
+
+	// Check for breakout conditions, on the tick or on the closes of the confirmation timeframe
+	breakoutType := BreakoutTypeNone
+	breakoutPrice := currentPrice
+	confirmCandles := 0
+	var closedCandle *types.OHLCV
+	if bd.confirmationMode == BreakoutConfirmClosedCandle {
+		breakoutType, closedCandle = bd.checkClosedCandles(symbol, gridBounds, atr)
+		if closedCandle != nil {
+			breakoutPrice = closedCandle.Close
+			confirmCandles = bd.confirmationCloses
+		}
+	} else {
+		breakoutType = bd.checkBreakoutCondition(gridBounds, currentPrice, atr)
+	}
 	if breakoutType == BreakoutTypeNone {
 		return nil
 	}
@@ -148,15 +289,19 @@ func (bd *BreakoutDetector) DetectBreakout(symbol string, gridBounds GridBounds,
 	if indicatorValues == nil {
 		return nil
 	}
+	indicatorValues = bd.applyNamedIndicators(symbol, indicatorValues)
 
 	// Calculate breakout strength
-	strength := bd.calculateBreakoutStrength(gridBounds, currentPrice, breakoutType)
+	strength := bd.calculateBreakoutStrength(gridBounds, breakoutPrice, breakoutType, atr)
 
 	// Check volume confirmation
-	volumeRatio := bd.checkVolumeConfirmation(symbol, indicatorValues)
+	volume := bd.checkVolumeConfirmation(symbol, indicatorValues, breakoutType)
 
 	// Get current timeframe for momentum analysis
 	currentCandle := bd.candleAggregator.GetCurrentCandle(symbol, data.Timeframe3s)
+	if closedCandle != nil {
+		currentCandle = closedCandle // The forming candle's wick is what closed_candle mode ignores
+	}
 	if currentCandle == nil {
 		return nil
 	}
@@ -167,18 +312,21 @@ func (bd *BreakoutDetector) DetectBreakout(symbol string, gridBounds GridBounds,
 	atrCondition := bd.checkATRCondition(indicatorValues, currentPrice)
 
 	// Calculate confidence score
-	confidence := bd.calculateConfidence(strength, volumeRatio, momentum, rsiCondition, atrCondition)
+	confidence := bd.calculateConfidence(symbol, strength, volume, momentum, rsiCondition, atrCondition)
 
 	// Generate reasons
-	reasons := bd.generateBreakoutReasons(breakoutType, strength, volumeRatio, momentum, rsiCondition, atrCondition)
+	reasons := bd.generateBreakoutReasons(symbol, breakoutType, strength, volume, momentum, rsiCondition, atrCondition)
 
 	// Create breakout signal
 	signal := &BreakoutSignal{
 		Type:           breakoutType,
 		Confidence:     confidence,
 		Strength:       strength,
-		VolumeRatio:    volumeRatio,
-		ConfirmCandles: 0,
+		StrengthUnit:   bd.strengthMode.Unit(),
+		VolumeRatio:    volume.Ratio,
+		VolumeDelta:    volume.Delta,
+		FlowImbalance:  volume.Imbalance,
+		ConfirmCandles: confirmCandles,
 		Timestamp:      time.Now(),
 		Symbol:         symbol,
 		Price:          currentPrice,
@@ -215,11 +363,10 @@ func (bd *BreakoutDetector) ConfirmBreakout(breakoutID string, symbol string, cu
 	if isValid {
 		breakout.Confirmation = true
 		bd.trueBreakoutCount++
-		bd.consecutiveFailures = 0
 	} else {
 		bd.falseBreakoutCount++
-		bd.consecutiveFailures++
 	}
+	bd.RecordOutcome(symbol, isValid)
 
 	// End the breakout
 	now := time.Now()
@@ -230,37 +377,101 @@ func (bd *BreakoutDetector) ConfirmBreakout(breakoutID string, symbol string, cu
 	return isValid
 }
 
-// updatePriceHistory updates the recent price and volume history
+// updatePriceHistory updates the recent price history; volume comes from the aggregator's candles
 func (bd *BreakoutDetector) updatePriceHistory(currentPrice float64) {
 	// Add to recent prices (keep last 20)
 	bd.recentPrices = append(bd.recentPrices, currentPrice)
 	if len(bd.recentPrices) > 20 {
 		bd.recentPrices = bd.recentPrices[1:]
 	}
-
-	// Add to recent volumes (using a simple estimation)
-	estimatedVolume := 1000.0 // This would come from actual ticker data
-	bd.recentVolumes = append(bd.recentVolumes, estimatedVolume)
-	if len(bd.recentVolumes) > 20 {
-		bd.recentVolumes = bd.recentVolumes[1:]
-	}
 }
 
-// checkBreakoutCondition checks if price has broken out of grid bounds
-func (bd *BreakoutDetector) checkBreakoutCondition(bounds GridBounds, price float64) BreakoutType {
-	breakoutThreshold := bounds.Range * bd.MinBreakoutStrength
-
-	if price > bounds.UpperBound+breakoutThreshold {
+// checkBreakoutCondition checks if price has broken out of grid bounds by the minimum strength of the
+// strength mode; atr is only used in atr mode
+func (bd *BreakoutDetector) checkBreakoutCondition(bounds GridBounds, price, atr float64) BreakoutType {
+	upperThreshold := bounds.Range * bd.MinBreakoutStrength
+	lowerThreshold := upperThreshold
+	switch bd.strengthMode {
+	case BreakoutStrengthPercent:
+		upperThreshold = bounds.UpperBound * bd.minStrengthPercent
+		lowerThreshold = bounds.LowerBound * bd.minStrengthPercent
+	case BreakoutStrengthATR:
+		upperThreshold = atr * bd.minStrengthATR
+		lowerThreshold = upperThreshold
+	}
+
+	if price > bounds.UpperBound+upperThreshold {
 		return BreakoutTypeUp
-	} else if price < bounds.LowerBound-breakoutThreshold {
+	} else if price < bounds.LowerBound-lowerThreshold {
 		return BreakoutTypeDown
 	}
 
 	return BreakoutTypeNone
 }
 
-// calculateBreakoutStrength calculates how strong the breakout is
-func (bd *BreakoutDetector) calculateBreakoutStrength(bounds GridBounds, price float64, breakoutType BreakoutType) float64 {
+// checkClosedCandles returns the breakout direction once the last confirmation closes are all beyond the
+// same bound, together with the latest closed candle. Each closed candle is evaluated once.
+func (bd *BreakoutDetector) checkClosedCandles(symbol string, bounds GridBounds, atr float64) (BreakoutType, *types.OHLCV) {
+	candles := bd.candleAggregator.GetCandles(symbol, bd.confirmationTimeframe, bd.confirmationCloses)
+	if len(candles) < bd.confirmationCloses {
+		return BreakoutTypeNone, nil
+	}
+
+	last := candles[len(candles)-1]
+	if !last.Timestamp.After(bd.lastEvaluatedCandle) {
+		return BreakoutTypeNone, nil
+	}
+	bd.lastEvaluatedCandle = last.Timestamp
+
+	breakoutType := bd.checkBreakoutCondition(bounds, candles[0].Close, atr)
+	for _, candle := range candles[1:] {
+		if bd.checkBreakoutCondition(bounds, candle.Close, atr) != breakoutType {
+			return BreakoutTypeNone, nil
+		}
+	}
+	return breakoutType, &last
+}
+
+// applyNamedIndicators returns a copy of values with the configured named indicators substituted
+func (bd *BreakoutDetector) applyNamedIndicators(symbol string, values *indicators.IndicatorValues) *indicators.IndicatorValues {
+	overridden := *values
+	if value, ok := bd.namedIndicator(symbol, bd.rsiIndicator); ok {
+		overridden.RSI = value
+	}
+	if value, ok := bd.namedIndicator(symbol, bd.atrIndicator); ok {
+		overridden.ATR = value
+	}
+	if value, ok := bd.namedIndicator(symbol, bd.volumeIndicator); ok {
+		overridden.VolumeSMA = value
+	}
+	return &overridden
+}
+
+// namedIndicator returns a named indicator value, or false if unset or still warming up
+func (bd *BreakoutDetector) namedIndicator(symbol, name string) (float64, bool) {
+	if name == "" {
+		return 0, false
+	}
+	return bd.technicalAnalyzer.GetNamedIndicator(symbol, name)
+}
+
+// calculateBreakoutStrength calculates how far beyond the bound the breakout is: in ATR units in atr mode,
+// in percent of the bound otherwise
+func (bd *BreakoutDetector) calculateBreakoutStrength(bounds GridBounds, price float64, breakoutType BreakoutType, atr float64) float64 {
+	if bd.strengthMode == BreakoutStrengthATR {
+		if atr <= 0 {
+			return 0
+		}
+		switch breakoutType {
+		case BreakoutTypeUp:
+			return (price - bounds.UpperBound) / atr
+		case BreakoutTypeDown:
+			return (bounds.LowerBound - price) / atr
+		default:
+			return 0
+		}
+	}
+
 	switch breakoutType {
 	case BreakoutTypeUp:
 		return ((price - bounds.UpperBound) / bounds.UpperBound) * 100
@@ -271,22 +482,89 @@ func (bd *BreakoutDetector) calculateBreakoutStrength(bounds GridBounds, price f
 	}
 }
 
-// checkVolumeConfirmation checks if volume supports the breakout
-func (bd *BreakoutDetector) checkVolumeConfirmation(symbol string, indicatorValues *indicators.IndicatorValues) float64 {
-	if indicatorValues.VolumeSMA == 0 {
-		return 1.0 // Default if no volume data
+// strengthLevels returns the strengths reported as strong and scored in full confidence. Normalized modes
+// scale both with the minimum strength, so the same setting means the same thing on any timeframe.
+func (bd *BreakoutDetector) strengthLevels() (strong, full float64) {
+	switch bd.strengthMode {
+	case BreakoutStrengthPercent:
+		minimum := bd.minStrengthPercent * 100
+		return minimum * strongBreakoutMultiple, minimum * fullConfidenceMultiple
+	case BreakoutStrengthATR:
+		return bd.minStrengthATR * strongBreakoutMultiple, bd.minStrengthATR * fullConfidenceMultiple
+	default:
+		return legacyStrongStrength, legacyFullConfidenceStrength
+	}
+}
+
+// formatStrength renders a strength with its unit
+func (bd *BreakoutDetector) formatStrength(strength float64) string {
+	if bd.strengthMode == BreakoutStrengthATR {
+		return fmt.Sprintf("%.2f ATR", strength)
 	}
+	return fmt.Sprintf("%.2f%%", strength)
+}
+
+// currentATR returns the ATR breakouts are measured in, or 0 while it is warming up
+func (bd *BreakoutDetector) currentATR(symbol string) float64 {
+	if value, ok := bd.namedIndicator(symbol, bd.atrIndicator); ok {
+		return value
+	}
+	values := bd.technicalAnalyzer.GetIndicatorValues(symbol)
+	if values == nil || math.IsNaN(values.ATR) {
+		return 0
+	}
+	return values.ATR
+}
 
-	// Get recent candle for volume
-	candles := bd.candleAggregator.GetCandles(symbol, data.Timeframe3s, 1)
+// volumeConfirmation is the volume of the last closed candle relative to its baseline, and its taker flow
+type volumeConfirmation struct {
+	Ratio     float64 // Volume vs average volume (1.0 without data)
+	Delta     float64 // Taker buys minus taker sells
+	Imbalance float64 // Delta share signed toward the breakout direction (0 without trade data)
+}
+
+// checkVolumeConfirmation checks if volume supports the breakout. Candles built from aggregated trades are
+// compared with the average of the preceding closed candles and also report whether takers push the breakout.
+func (bd *BreakoutDetector) checkVolumeConfirmation(symbol string, indicatorValues *indicators.IndicatorValues, breakoutType BreakoutType) volumeConfirmation {
+	volume := volumeConfirmation{Ratio: 1.0} // Default if no volume data
+
+	// Get recent candles for volume
+	candles := bd.candleAggregator.GetCandles(symbol, data.Timeframe3s, volumeBaselineCandles+1)
 	if len(candles) == 0 {
-		return 1.0
+		return volume
+	}
+	last := candles[len(candles)-1]
+
+	if !last.HasVolumeFlow() {
+		if indicatorValues.VolumeSMA > 0 {
+			volume.Ratio = last.Volume / indicatorValues.VolumeSMA
+		}
+		return volume
 	}
 
-	currentVolume := candles[len(candles)-1].Volume
-	volumeRatio := currentVolume / indicatorValues.VolumeSMA
+	volume.Delta = last.GetVolumeDelta()
+	volume.Imbalance = last.GetFlowImbalance()
+	if breakoutType == BreakoutTypeDown {
+		volume.Imbalance = -volume.Imbalance
+	}
 
-	return volumeRatio
+	// A named volume indicator still sets the baseline; otherwise average the real candles, skipping synthetic ones
+	if bd.volumeIndicator != "" && indicatorValues.VolumeSMA > 0 {
+		volume.Ratio = last.Volume / indicatorValues.VolumeSMA
+		return volume
+	}
+	total, count := 0.0, 0
+	for _, candle := range candles[:len(candles)-1] {
+		if candle.Synthetic {
+			continue
+		}
+		total += candle.Volume
+		count++
+	}
+	if count > 0 && total > 0 {
+		volume.Ratio = last.Volume / (total / float64(count))
+	}
+	return volume
 }
 
 // calculateMomentum calculates price momentum
@@ -328,12 +606,13 @@ func (bd *BreakoutDetector) checkATRCondition(indicatorValues *indicators.Indica
 }
 
 // calculateConfidence calculates overall confidence in the breakout
-func (bd *BreakoutDetector) calculateConfidence(strength, volumeRatio, momentum float64, rsiCondition, atrCondition bool) float64 {
+func (bd *BreakoutDetector) calculateConfidence(symbol string, strength float64, volume volumeConfirmation, momentum float64, rsiCondition, atrCondition bool) float64 {
 	// Base confidence from strength
-	strengthScore := min(1.0, strength/5.0) // 5% strength = full confidence
+	_, fullStrength := bd.strengthLevels()
+	strengthScore := min(1.0, strength/fullStrength)
 
-	// Volume confirmation
-	volumeScore := min(1.0, volumeRatio/2.0) // 2x volume = full confidence
+	// Volume confirmation, weighted up to 1.5x by taker flow in the breakout direction and down to 0.5x against it
+	volumeScore := min(1.0, volume.Ratio/2.0*(1+0.5*volume.Imbalance)) // 2x volume = full confidence
 
 	// Momentum score
 	momentumScore := min(1.0, math.Abs(momentum)/0.01) // 1% move = full confidence
@@ -347,19 +626,17 @@ func (bd *BreakoutDetector) calculateConfidence(strength, volumeRatio, momentum
 		technicalScore += 0.25
 	}
 
-	// Adjust for false breakout history
-	penalty := float64(bd.consecutiveFailures) * 0.1
-	penalty = min(0.5, penalty) // Max 50% penalty
-
 	// Weighted average
 	confidence := (strengthScore*0.3 + volumeScore*0.25 + momentumScore*0.25 + technicalScore*0.2)
-	confidence -= penalty
+
+	// Adjust for how the symbol's breakouts have resolved in the long run
+	confidence += bd.historyAdjustment(symbol)
 
 	return max(0, min(1.0, confidence))
 }
 
 // generateBreakoutReasons creates human-readable reasons for the breakout
-func (bd *BreakoutDetector) generateBreakoutReasons(breakoutType BreakoutType, strength, volumeRatio, momentum float64, rsiCondition, atrCondition bool) []string {
+func (bd *BreakoutDetector) generateBreakoutReasons(symbol string, breakoutType BreakoutType, strength float64, volume volumeConfirmation, momentum float64, rsiCondition, atrCondition bool) []string {
 	var reasons []string
 
 	switch breakoutType {
@@ -369,16 +646,24 @@ func (bd *BreakoutDetector) generateBreakoutReasons(breakoutType BreakoutType, s
 		reasons = append(reasons, "Price broke below lower grid bound")
 	}
 
-	if strength > 0.5 {
-		reasons = append(reasons, "Strong breakout detected")
+	if strongStrength, _ := bd.strengthLevels(); strength > strongStrength {
+		reasons = append(reasons, fmt.Sprintf("Strong breakout detected (%s beyond bound)", bd.formatStrength(strength)))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Breakout %s beyond bound", bd.formatStrength(strength)))
 	}
 
-	if volumeRatio > bd.VolumeMultiplier {
+	if volume.Ratio > bd.VolumeMultiplier {
 		reasons = append(reasons, "High volume confirmation")
-	} else if volumeRatio > 1.0 {
+	} else if volume.Ratio > 1.0 {
 		reasons = append(reasons, "Moderate volume support")
 	}
 
+	if volume.Imbalance >= flowImbalanceThreshold {
+		reasons = append(reasons, "Taker flow confirms breakout direction")
+	} else if volume.Imbalance <= -flowImbalanceThreshold {
+		reasons = append(reasons, "Taker flow opposes breakout direction")
+	}
+
 	if math.Abs(momentum) > bd.MomentumThreshold {
 		reasons = append(reasons, "Strong momentum indicator")
 	}
@@ -391,8 +676,8 @@ func (bd *BreakoutDetector) generateBreakoutReasons(breakoutType BreakoutType, s
 		reasons = append(reasons, "ATR indicates significant move")
 	}
 
-	if bd.consecutiveFailures > 0 {
-		reasons = append(reasons, "Recent false breakouts detected")
+	if reason := bd.historyReason(symbol); reason != "" {
+		reasons = append(reasons, reason)
 	}
 
 	return reasons
@@ -429,7 +714,7 @@ func (bd *BreakoutDetector) startBreakoutTracking(signal *BreakoutSignal) {
 // validateBreakout checks if a breakout is still valid
 func (bd *BreakoutDetector) validateBreakout(breakout *BreakoutEvent, currentPrice float64) bool {
 	timeSinceStart := time.Since(breakout.StartTime)
-	minDuration := time.Duration(bd.ConfirmationCandles) * 300 * time.Millisecond
+	minDuration := time.Duration(bd.ConfirmationCandles) * bd.candleAggregator.BaseInterval()
 
 	if timeSinceStart < minDuration {
 		// Not enough time passed yet
@@ -472,8 +757,10 @@ func (bd *BreakoutDetector) GetBreakoutStats() map[string]interface{} {
 		"true_breakouts":       bd.trueBreakoutCount,
 		"false_breakouts":      bd.falseBreakoutCount,
 		"success_rate":         successRate,
-		"consecutive_failures": bd.consecutiveFailures,
+		"success_rates":        bd.GetSuccessRates(),
 		"recent_events":        len(bd.breakoutHistory),
+		"confirmation_mode":    string(bd.confirmationMode),
+		"strength_mode":        string(bd.strengthMode),
 	}
 }
 
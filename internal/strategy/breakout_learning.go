@@ -0,0 +1,97 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BreakoutSuccess is the long-run breakout record of a symbol
+type BreakoutSuccess struct {
+	Symbol     string  `json:"symbol"`
+	Outcomes   int     `json:"outcomes"`
+	Real       int     `json:"real"`       // Breakouts that held
+	Rate       float64 `json:"rate"`       // Success rate shrunk toward 50% by the prior
+	Adjustment float64 `json:"adjustment"` // Added to the confidence of the symbol's breakouts
+}
+
+// breakoutRecord counts the outcomes of a symbol
+type breakoutRecord struct {
+	outcomes int
+	real     int
+}
+
+// RecordOutcome adds a resolved breakout to the symbol's record; restored history is fed through here too
+func (bd *BreakoutDetector) RecordOutcome(symbol string, wasReal bool) {
+	bd.learningMu.Lock()
+	defer bd.learningMu.Unlock()
+
+	record, ok := bd.records[symbol]
+	if !ok {
+		record = &breakoutRecord{}
+		bd.records[symbol] = record
+	}
+	record.outcomes++
+	if wasReal {
+		record.real++
+	}
+}
+
+// SuccessRate returns the symbol's breakout success rate, starting from 50% with the weight of SuccessPrior
+// outcomes so a few early failures do not condemn a symbol
+func (bd *BreakoutDetector) SuccessRate(symbol string) float64 {
+	bd.learningMu.Lock()
+	defer bd.learningMu.Unlock()
+	return bd.successRate(bd.records[symbol])
+}
+
+// successRate returns the shrunk success rate of a record
+func (bd *BreakoutDetector) successRate(record *breakoutRecord) float64 {
+	if record == nil {
+		return 0.5
+	}
+	return (float64(record.real) + 0.5*bd.successPrior) / (float64(record.outcomes) + bd.successPrior)
+}
+
+// historyAdjustment returns the confidence added to a symbol's breakouts: positive where breakouts have
+// mostly held, negative where they have mostly failed
+func (bd *BreakoutDetector) historyAdjustment(symbol string) float64 {
+	return (bd.SuccessRate(symbol) - 0.5) * bd.successWeight
+}
+
+// historyReason describes a success rate far enough from even to sway confidence
+func (bd *BreakoutDetector) historyReason(symbol string) string {
+	bd.learningMu.Lock()
+	record := bd.records[symbol]
+	rate := bd.successRate(record)
+	bd.learningMu.Unlock()
+
+	switch {
+	case record == nil:
+		return ""
+	case rate < 0.4:
+		return fmt.Sprintf("Breakouts of %s mostly failed (%d of %d held)", symbol, record.real, record.outcomes)
+	case rate > 0.6:
+		return fmt.Sprintf("Breakouts of %s mostly held (%d of %d)", symbol, record.real, record.outcomes)
+	}
+	return ""
+}
+
+// GetSuccessRates returns the breakout record of every symbol with outcomes
+func (bd *BreakoutDetector) GetSuccessRates() []BreakoutSuccess {
+	bd.learningMu.Lock()
+	defer bd.learningMu.Unlock()
+
+	rates := make([]BreakoutSuccess, 0, len(bd.records))
+	for symbol, record := range bd.records {
+		rate := bd.successRate(record)
+		rates = append(rates, BreakoutSuccess{
+			Symbol:     symbol,
+			Outcomes:   record.outcomes,
+			Real:       record.real,
+			Rate:       rate,
+			Adjustment: (rate - 0.5) * bd.successWeight,
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Symbol < rates[j].Symbol })
+	return rates
+}
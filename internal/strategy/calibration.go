@@ -0,0 +1,205 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCalibrationBins is the number of equal-width confidence bins in a calibration curve
+const DefaultCalibrationBins = 10
+
+// SignalOutcome joins the confidence of the signal that opened a trade with the trade's result
+type SignalOutcome struct {
+	TradeID    string    `json:"trade_id"`
+	SignalType string    `json:"signal_type"`
+	Confidence float64   `json:"confidence"`
+	PnL        float64   `json:"pnl"`
+	Won        bool      `json:"won"`
+	SignalTime time.Time `json:"signal_time"`
+	ClosedTime time.Time `json:"closed_time"`
+}
+
+// CalibrationBin compares predicted confidence with the realized win rate of trades in a confidence range
+type CalibrationBin struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Trades        int     `json:"trades"`
+	Wins          int     `json:"wins"`
+	AvgConfidence float64 `json:"avg_confidence"`
+	WinRate       float64 `json:"win_rate"`
+	Gap           float64 `json:"gap"` // Win rate minus average confidence; negative means overconfident
+}
+
+// CalibrationCurve is the calibration of one signal type
+type CalibrationCurve struct {
+	SignalType    string           `json:"signal_type"`
+	Trades        int              `json:"trades"`
+	WinRate       float64          `json:"win_rate"`
+	AvgConfidence float64          `json:"avg_confidence"`
+	BrierScore    float64          `json:"brier_score"` // Mean squared error of confidence as a win probability
+	Bins          []CalibrationBin `json:"bins"`        // Only bins with trades
+}
+
+// CalibrationReport holds a calibration curve per signal type and the outcomes it was built from
+type CalibrationReport struct {
+	Curves   []CalibrationCurve `json:"curves"`
+	Outcomes []SignalOutcome    `json:"outcomes"`
+	Pending  int                `json:"pending"` // Signals whose trade was still open
+}
+
+// BuildCalibrationReport groups outcomes by signal type and bins them by confidence
+func BuildCalibrationReport(outcomes []SignalOutcome, bins int) *CalibrationReport {
+	if bins <= 0 {
+		bins = DefaultCalibrationBins
+	}
+
+	byType := make(map[string][]SignalOutcome)
+	for _, outcome := range outcomes {
+		byType[outcome.SignalType] = append(byType[outcome.SignalType], outcome)
+	}
+	signalTypes := make([]string, 0, len(byType))
+	for signalType := range byType {
+		signalTypes = append(signalTypes, signalType)
+	}
+	sort.Strings(signalTypes)
+
+	report := &CalibrationReport{
+		Curves:   make([]CalibrationCurve, 0, len(signalTypes)),
+		Outcomes: append([]SignalOutcome(nil), outcomes...),
+	}
+	for _, signalType := range signalTypes {
+		report.Curves = append(report.Curves, buildCalibrationCurve(signalType, byType[signalType], bins))
+	}
+	return report
+}
+
+// buildCalibrationCurve computes the curve of one signal type
+func buildCalibrationCurve(signalType string, outcomes []SignalOutcome, bins int) CalibrationCurve {
+	curve := CalibrationCurve{SignalType: signalType, Trades: len(outcomes)}
+	binned := make([]CalibrationBin, bins)
+	confidenceSums := make([]float64, bins)
+	width := 1.0 / float64(bins)
+
+	wins := 0
+	for _, outcome := range outcomes {
+		confidence := math.Max(0, math.Min(1, outcome.Confidence))
+		index := int(confidence * float64(bins))
+		if index >= bins {
+			index = bins - 1 // Confidence 1.0 belongs to the last bin
+		}
+
+		binned[index].Trades++
+		confidenceSums[index] += confidence
+		curve.AvgConfidence += confidence
+
+		actual := 0.0
+		if outcome.Won {
+			binned[index].Wins++
+			wins++
+			actual = 1
+		}
+		curve.BrierScore += (confidence - actual) * (confidence - actual)
+	}
+
+	if len(outcomes) > 0 {
+		curve.WinRate = float64(wins) / float64(len(outcomes))
+		curve.AvgConfidence /= float64(len(outcomes))
+		curve.BrierScore /= float64(len(outcomes))
+	}
+
+	for i := range binned {
+		bin := binned[i]
+		if bin.Trades == 0 {
+			continue
+		}
+		bin.Lower = float64(i) * width
+		bin.Upper = float64(i+1) * width
+		bin.AvgConfidence = confidenceSums[i] / float64(bin.Trades)
+		bin.WinRate = float64(bin.Wins) / float64(bin.Trades)
+		bin.Gap = bin.WinRate - bin.AvgConfidence
+		curve.Bins = append(curve.Bins, bin)
+	}
+	return curve
+}
+
+// FormatText renders the calibration curves as a table per signal type
+func (r *CalibrationReport) FormatText() string {
+	var b strings.Builder
+
+	if len(r.Curves) == 0 {
+		fmt.Fprintf(&b, "  (no closed trades with a recorded signal)\n")
+	}
+	for _, curve := range r.Curves {
+		fmt.Fprintf(&b, "  %s: %d trades, win rate %.1f%%, avg confidence %.1f%%, Brier %.3f\n",
+			curve.SignalType, curve.Trades, curve.WinRate*100, curve.AvgConfidence*100, curve.BrierScore)
+		for _, bin := range curve.Bins {
+			fmt.Fprintf(&b, "    %.2f-%.2f  trades=%-4d confidence=%5.1f%%  win rate=%5.1f%%  gap=%+.1f%%\n",
+				bin.Lower, bin.Upper, bin.Trades, bin.AvgConfidence*100, bin.WinRate*100, bin.Gap*100)
+		}
+	}
+	if r.Pending > 0 {
+		fmt.Fprintf(&b, "  %d signal(s) still open\n", r.Pending)
+	}
+	return b.String()
+}
+
+// CalibrationTracker records signal confidences when trades open and joins them with trade results
+type CalibrationTracker struct {
+	pending  map[string]SignalOutcome // Trade ID -> signal awaiting its result
+	outcomes []SignalOutcome
+	mu       sync.Mutex
+}
+
+// NewCalibrationTracker creates an empty calibration tracker
+func NewCalibrationTracker() *CalibrationTracker {
+	return &CalibrationTracker{
+		pending: make(map[string]SignalOutcome),
+	}
+}
+
+// RecordSignal records the signal that opened a trade
+func (ct *CalibrationTracker) RecordSignal(tradeID, signalType string, confidence float64, signalTime time.Time) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.pending[tradeID] = SignalOutcome{
+		TradeID:    tradeID,
+		SignalType: signalType,
+		Confidence: confidence,
+		SignalTime: signalTime,
+	}
+}
+
+// RecordOutcome completes a trade with its realized PnL; trades without a recorded signal are ignored
+func (ct *CalibrationTracker) RecordOutcome(tradeID string, pnl float64, closedTime time.Time) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	outcome, exists := ct.pending[tradeID]
+	if !exists {
+		return false
+	}
+	delete(ct.pending, tradeID)
+
+	outcome.PnL = pnl
+	outcome.Won = pnl > 0
+	outcome.ClosedTime = closedTime
+	ct.outcomes = append(ct.outcomes, outcome)
+	return true
+}
+
+// Report builds the calibration report of all completed trades
+func (ct *CalibrationTracker) Report(bins int) *CalibrationReport {
+	ct.mu.Lock()
+	outcomes := append([]SignalOutcome(nil), ct.outcomes...)
+	pending := len(ct.pending)
+	ct.mu.Unlock()
+
+	report := BuildCalibrationReport(outcomes, bins)
+	report.Pending = pending
+	return report
+}
@@ -0,0 +1,173 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"sort"
+	"sync"
+)
+
+// Capital bucket names; a bucket is named after the strategy prefix of its client order IDs
+const (
+	BucketGrid        = "grid"
+	BucketBreakout    = "breakout"
+	BucketUnallocated = "unallocated" // Fills from orders no bucket claims
+)
+
+// CapitalAllocatorConfig holds configuration for virtual capital buckets
+type CapitalAllocatorConfig struct {
+	TotalCapital float64            `json:"total_capital"`
+	Allocations  map[string]float64 `json:"allocations"` // Bucket -> fraction of total capital
+}
+
+// CapitalBucket is a strategy's virtual share of the account with its own performance
+type CapitalBucket struct {
+	Name           string  `json:"name"`
+	Allocation     float64 `json:"allocation"`
+	InitialCapital float64 `json:"initial_capital"`
+	RealizedPnL    float64 `json:"realized_pnl"`
+	Fees           float64 `json:"fees"`
+	Volume         float64 `json:"volume"`
+	Fills          int64   `json:"fills"`
+	WinningFills   int64   `json:"winning_fills"`
+	LosingFills    int64   `json:"losing_fills"`
+}
+
+// Capital returns the bucket's current capital
+func (b CapitalBucket) Capital() float64 {
+	return b.InitialCapital + b.RealizedPnL - b.Fees
+}
+
+// CapitalAllocator splits account capital into buckets so strategies running side by side size
+// and report independently
+type CapitalAllocator struct {
+	config  CapitalAllocatorConfig
+	buckets map[string]*CapitalBucket
+	mu      sync.RWMutex
+}
+
+// NewCapitalAllocator creates a capital allocator; unallocated capital forms its own bucket
+func NewCapitalAllocator(config CapitalAllocatorConfig) *CapitalAllocator {
+	ca := &CapitalAllocator{
+		config:  config,
+		buckets: make(map[string]*CapitalBucket),
+	}
+
+	allocated := 0.0
+	for name, fraction := range config.Allocations {
+		ca.buckets[name] = &CapitalBucket{
+			Name:           name,
+			Allocation:     fraction,
+			InitialCapital: config.TotalCapital * fraction,
+		}
+		allocated += fraction
+	}
+	if _, exists := ca.buckets[BucketUnallocated]; !exists {
+		remaining := 1 - allocated
+		if remaining < 0 {
+			remaining = 0
+		}
+		ca.buckets[BucketUnallocated] = &CapitalBucket{
+			Name:           BucketUnallocated,
+			Allocation:     remaining,
+			InitialCapital: config.TotalCapital * remaining,
+		}
+	}
+	return ca
+}
+
+// BucketFor returns the bucket an order belongs to
+func (ca *CapitalAllocator) BucketFor(clientOrderID string) string {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	name := types.StrategyFromClientOrderID(clientOrderID)
+	if _, exists := ca.buckets[name]; exists {
+		return name
+	}
+	return BucketUnallocated
+}
+
+// RecordFill books a fill against the bucket of the placing strategy
+func (ca *CapitalAllocator) RecordFill(update types.OrderUpdate) {
+	if !update.IsFill() {
+		return
+	}
+	name := ca.BucketFor(update.ClientOrderID)
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	bucket := ca.buckets[name]
+	bucket.Fills++
+	bucket.Volume += update.GetFillNotional()
+	bucket.RealizedPnL += update.RealizedPnL
+	bucket.Fees += update.Fee
+	if update.RealizedPnL > 0 {
+		bucket.WinningFills++
+	} else if update.RealizedPnL < 0 {
+		bucket.LosingFills++
+	}
+}
+
+// Capital returns a bucket's current capital, or 0 for an unknown bucket
+func (ca *CapitalAllocator) Capital(name string) float64 {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	bucket, exists := ca.buckets[name]
+	if !exists {
+		return 0
+	}
+	return bucket.Capital()
+}
+
+// SizeFraction returns a bucket's share of total current capital, used to scale account-wide sizing
+func (ca *CapitalAllocator) SizeFraction(name string) float64 {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	bucket, exists := ca.buckets[name]
+	if !exists {
+		return 0
+	}
+	total := 0.0
+	for _, b := range ca.buckets {
+		total += b.Capital()
+	}
+	if total <= 0 || bucket.Capital() <= 0 {
+		return 0
+	}
+	return bucket.Capital() / total
+}
+
+// GetBuckets returns all buckets ordered by name
+func (ca *CapitalAllocator) GetBuckets() []CapitalBucket {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+
+	buckets := make([]CapitalBucket, 0, len(ca.buckets))
+	for _, bucket := range ca.buckets {
+		buckets = append(buckets, *bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+	return buckets
+}
+
+// GetAllocatorStats returns capital allocation statistics
+func (ca *CapitalAllocator) GetAllocatorStats() map[string]interface{} {
+	buckets := make(map[string]interface{})
+	for _, bucket := range ca.GetBuckets() {
+		buckets[bucket.Name] = map[string]interface{}{
+			"allocation":   bucket.Allocation,
+			"capital":      bucket.Capital(),
+			"realized_pnl": bucket.RealizedPnL,
+			"fees":         bucket.Fees,
+			"fills":        bucket.Fills,
+		}
+	}
+
+	return map[string]interface{}{
+		"total_capital": ca.config.TotalCapital,
+		"buckets":       buckets,
+	}
+}
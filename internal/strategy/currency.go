@@ -0,0 +1,188 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"strings"
+	"sync"
+)
+
+// CurrencyConfig holds the accounting currency portfolio risk and PnL are aggregated in
+type CurrencyConfig struct {
+	AccountingCurrency string                        `json:"accounting_currency"` // USDT
+	QuoteAsset         string                        `json:"quote_asset"`         // Preferred quote asset when splitting symbols
+	Rates              map[string]float64            `json:"rates"`               // Asset -> accounting currency per unit, used without a live price
+	Contracts          map[string]types.ContractSpec `json:"contracts"`           // Inverse contracts settle in their base asset
+}
+
+// PriceSource returns the latest price of a symbol, or 0 if it is unknown
+type PriceSource func(symbol string) float64
+
+// stableQuotes are the stablecoin quotes tried when looking up an asset's dollar price
+var stableQuotes = []string{"USDT", "USDC", "FDUSD", "BUSD"}
+
+// CurrencyConverter converts amounts in the quote or settlement asset of a symbol into the accounting
+// currency, so fills and exposure of USDT, USDC, BUSD and BTC-quoted symbols can be added up. Stablecoins
+// are taken at par with each other; other assets are priced from the latest market prices, falling back
+// to configured rates. Amounts whose asset has no rate are left unconverted and counted as missing.
+type CurrencyConverter struct {
+	config CurrencyConfig
+	prices PriceSource
+
+	// Statistics
+	conversions int64
+	missing     map[string]int64 // Asset -> conversions without a rate
+
+	mu sync.Mutex
+}
+
+// NewCurrencyConverter creates a currency converter pricing assets from prices (may be nil)
+func NewCurrencyConverter(config CurrencyConfig, prices PriceSource) *CurrencyConverter {
+	if config.AccountingCurrency == "" {
+		config.AccountingCurrency = "USDT" // default
+	}
+	config.AccountingCurrency = strings.ToUpper(config.AccountingCurrency)
+	rates := make(map[string]float64, len(config.Rates))
+	for asset, rate := range config.Rates {
+		rates[strings.ToUpper(asset)] = rate
+	}
+	config.Rates = rates
+
+	return &CurrencyConverter{
+		config:  config,
+		prices:  prices,
+		missing: make(map[string]int64),
+	}
+}
+
+// AccountingCurrency returns the currency amounts are converted into
+func (c *CurrencyConverter) AccountingCurrency() string {
+	return c.config.AccountingCurrency
+}
+
+// QuoteAsset returns the quote asset of a symbol
+func (c *CurrencyConverter) QuoteAsset(symbol string) string {
+	if quote := c.config.QuoteAsset; quote != "" && len(symbol) > len(quote) && strings.HasSuffix(symbol, quote) {
+		return quote
+	}
+	if quote := types.QuoteAsset(symbol); quote != "" {
+		return quote
+	}
+	return c.config.QuoteAsset
+}
+
+// SettlementAsset returns the asset a symbol's PnL, fees and margin are denominated in: the base asset of
+// inverse contracts, the quote asset otherwise
+func (c *CurrencyConverter) SettlementAsset(symbol string) string {
+	quote := c.QuoteAsset(symbol)
+	if c.config.Contracts[symbol].IsInverse() {
+		base, _ := types.SplitSymbol(symbol, quote)
+		return base
+	}
+	return quote
+}
+
+// Rate returns the value of one unit of asset in the accounting currency and whether a rate is known
+func (c *CurrencyConverter) Rate(asset string) (float64, bool) {
+	asset = strings.ToUpper(asset)
+	accounting := c.config.AccountingCurrency
+	if asset == "" || asset == accounting {
+		return 1, true
+	}
+	if types.IsStablecoin(asset) && types.IsStablecoin(accounting) {
+		return 1, true
+	}
+
+	if c.prices != nil {
+		if price := c.prices(asset + accounting); price > 0 {
+			return price, true
+		}
+		if price := c.prices(accounting + asset); price > 0 {
+			return 1 / price, true
+		}
+		// Any stablecoin market prices an asset in a dollar accounting currency, and vice versa
+		for _, stable := range stableQuotes {
+			if types.IsStablecoin(accounting) {
+				if price := c.prices(asset + stable); price > 0 {
+					return price, true
+				}
+			} else if types.IsStablecoin(asset) {
+				if price := c.prices(accounting + stable); price > 0 {
+					return 1 / price, true
+				}
+			}
+		}
+	}
+
+	if rate := c.config.Rates[asset]; rate > 0 {
+		return rate, true
+	}
+	return 0, false
+}
+
+// Convert converts an amount of asset into the accounting currency, leaving it unchanged without a rate
+func (c *CurrencyConverter) Convert(asset string, amount float64) float64 {
+	rate, ok := c.Rate(asset)
+
+	c.mu.Lock()
+	c.conversions++
+	if !ok {
+		c.missing[strings.ToUpper(asset)]++
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return amount
+	}
+	return amount * rate
+}
+
+// ToAccounting converts an amount in a symbol's settlement asset into the accounting currency
+func (c *CurrencyConverter) ToAccounting(symbol string, amount float64) float64 {
+	if amount == 0 {
+		return 0
+	}
+	return c.Convert(c.SettlementAsset(symbol), amount)
+}
+
+// ConvertFill returns a copy of an order update, and the expected price of its order, with PnL and fees
+// in the accounting currency and prices valued in it, so fill notional and slippage are too
+func (c *CurrencyConverter) ConvertFill(update types.OrderUpdate, expectedPrice float64) (types.OrderUpdate, float64) {
+	update.Fee = c.ToAccounting(update.Symbol, update.Fee)
+	update.RealizedPnL = c.ToAccounting(update.Symbol, update.RealizedPnL)
+
+	if quote := c.QuoteAsset(update.Symbol); quote != c.config.AccountingCurrency {
+		if rate, ok := c.Rate(quote); ok && rate != 1 {
+			update.LastFillPrice *= rate
+			update.AvgFillPrice *= rate
+			expectedPrice *= rate
+		}
+	}
+	return update, expectedPrice
+}
+
+// PriceInAccounting values a price of symbol, quoted in its quote asset, in the accounting currency
+func (c *CurrencyConverter) PriceInAccounting(symbol string, price float64) float64 {
+	if quote := c.QuoteAsset(symbol); quote != c.config.AccountingCurrency {
+		if rate, ok := c.Rate(quote); ok {
+			return price * rate
+		}
+	}
+	return price
+}
+
+// GetCurrencyStats returns conversion statistics
+func (c *CurrencyConverter) GetCurrencyStats() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	missing := make(map[string]int64, len(c.missing))
+	for asset, count := range c.missing {
+		missing[asset] = count
+	}
+	return map[string]interface{}{
+		"accounting_currency": c.config.AccountingCurrency,
+		"conversions":         c.conversions,
+		"missing_rates":       missing,
+		"static_rates":        len(c.config.Rates),
+	}
+}
@@ -0,0 +1,182 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"sync"
+	"time"
+)
+
+// dailyPnLDayFormat is the layout of the accounting day of a daily PnL
+const dailyPnLDayFormat = "2006-01-02"
+
+// DailyPnL is the profit and loss of one accounting day, checkpointed so the daily loss limit survives restarts
+type DailyPnL struct {
+	Day        string    `json:"day"`        // Date in the accounting timezone, 2006-01-02
+	Realized   float64   `json:"realized"`   // Realized PnL net of fees
+	Unrealized float64   `json:"unrealized"` // Unrealized PnL of open positions at the last update
+	Halted     bool      `json:"halted"`     // The loss limit was hit and entries stay blocked until the next day
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Total returns the realized and unrealized PnL of the day
+func (p DailyPnL) Total() float64 {
+	return p.Realized + p.Unrealized
+}
+
+// DailyLossBreach describes the daily loss limit being hit
+type DailyLossBreach struct {
+	Day   string  `json:"day"`
+	PnL   float64 `json:"pnl"`
+	Limit float64 `json:"limit"`
+}
+
+// DailyLossGuard enforces the maximum loss per accounting day over realized and unrealized PnL. Once the
+// limit is hit the day stays halted, including across restarts restored from a checkpoint.
+type DailyLossGuard struct {
+	maxLoss  float64        // Quote asset amount, 0 disables the limit
+	location *time.Location // Accounting timezone the day rolls over in
+	pnl      DailyPnL
+
+	restored bool
+	breaches int64
+
+	mu sync.RWMutex
+}
+
+// NewDailyLossGuard creates a daily loss guard for the maximum daily loss in the quote asset. Days roll
+// over at midnight in location, UTC when nil.
+func NewDailyLossGuard(maxLoss float64, location *time.Location) *DailyLossGuard {
+	if location == nil {
+		location = time.UTC // default
+	}
+	return &DailyLossGuard{
+		maxLoss:  maxLoss,
+		location: location,
+		pnl:      DailyPnL{Day: time.Now().In(location).Format(dailyPnLDayFormat)},
+	}
+}
+
+// day returns the accounting day of a time
+func (g *DailyLossGuard) day(t time.Time) string {
+	return t.In(g.location).Format(dailyPnLDayFormat)
+}
+
+// Restore continues from a checkpoint of the current accounting day; checkpoints of earlier days are ignored.
+// The checkpointed unrealized PnL is not carried over, since positions that survived the restart
+// report their own unrealized PnL on the next update.
+func (g *DailyLossGuard) Restore(checkpoint DailyPnL, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if checkpoint.Day != g.day(now) {
+		return false
+	}
+	g.pnl.Day = checkpoint.Day
+	g.pnl.Realized += checkpoint.Realized
+	g.pnl.Halted = g.pnl.Halted || checkpoint.Halted
+	g.restored = true
+	return true
+}
+
+// RecordFill adds the realized PnL of a fill net of its fee
+func (g *DailyLossGuard) RecordFill(update types.OrderUpdate) {
+	if !update.IsFill() {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	at := update.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	g.roll(at)
+	g.pnl.Realized += update.RealizedPnL - update.Fee
+	g.pnl.UpdatedAt = at
+}
+
+// Update records the unrealized PnL of open positions and returns the breach when the day's loss reaches
+// the limit; a day is breached at most once
+func (g *DailyLossGuard) Update(unrealized float64, now time.Time) *DailyLossBreach {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.roll(now)
+	g.pnl.Unrealized = unrealized
+	g.pnl.UpdatedAt = now
+	if g.maxLoss <= 0 || g.pnl.Halted || g.pnl.Total() > -g.maxLoss {
+		return nil
+	}
+
+	g.pnl.Halted = true
+	g.breaches++
+	return &DailyLossBreach{Day: g.pnl.Day, PnL: g.pnl.Total(), Limit: g.maxLoss}
+}
+
+// SetMaxLoss changes the maximum daily loss; 0 disables the limit. A day already halted stays halted.
+func (g *DailyLossGuard) SetMaxLoss(maxLoss float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.maxLoss = maxLoss
+}
+
+// roll starts a new day at the first update after midnight in the accounting timezone. Callers hold the lock.
+func (g *DailyLossGuard) roll(now time.Time) {
+	if day := g.day(now); day != g.pnl.Day {
+		g.pnl = DailyPnL{Day: day}
+	}
+}
+
+// IsHalted returns true while the daily loss limit of the current accounting day has been hit
+func (g *DailyLossGuard) IsHalted() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.pnl.Halted && g.pnl.Day == g.day(time.Now())
+}
+
+// NextRollover returns the start of the accounting day after now. Midnight is found on the calendar, so
+// days of 23 or 25 hours around daylight saving changes roll over at the right instant.
+func (g *DailyLossGuard) NextRollover(now time.Time) time.Time {
+	local := now.In(g.location)
+	return LocalTime(local.Year(), local.Month(), local.Day()+1, 0, 0, g.location)
+}
+
+// LocalTime returns the wall clock time of a calendar day in location. A time skipped by the clock moving
+// forward resolves to the same distance after the gap, as a clock that was not adjusted would show it.
+func LocalTime(year int, month time.Month, day, hour, minute int, location *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, 0, 0, location)
+	if t.Hour() == hour && t.Minute() == minute {
+		return t
+	}
+	// Read the wall time with the offset in force before the gap
+	_, offset := time.Date(year, month, day, hour, minute, 0, 0, time.UTC).Add(-24 * time.Hour).In(location).Zone()
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC).Add(-time.Duration(offset) * time.Second).In(location)
+}
+
+// Snapshot returns the current day's PnL for checkpointing
+func (g *DailyLossGuard) Snapshot() DailyPnL {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.pnl
+}
+
+// GetDailyLossStats returns daily loss guard statistics
+func (g *DailyLossGuard) GetDailyLossStats() map[string]interface{} {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return map[string]interface{}{
+		"day":            g.pnl.Day,
+		"timezone":       g.location.String(),
+		"next_rollover":  g.NextRollover(time.Now()),
+		"realized_pnl":   g.pnl.Realized,
+		"unrealized_pnl": g.pnl.Unrealized,
+		"daily_pnl":      g.pnl.Total(),
+		"max_daily_loss": g.maxLoss,
+		"halted":         g.pnl.Halted,
+		"restored":       g.restored,
+		"breaches":       g.breaches,
+		"updated_at":     g.pnl.UpdatedAt,
+	}
+}
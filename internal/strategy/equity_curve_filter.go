@@ -0,0 +1,132 @@
+package strategy
+
+import (
+	"sync"
+	"time"
+)
+
+// EquityCurveFilter switches trading off while account equity trades below its own moving average
+type EquityCurveFilter struct {
+	config EquityCurveConfig
+
+	// Rolling equity samples
+	samples []float64
+	sum     float64
+
+	// Filter state
+	tradingEnabled bool
+	lastEquity     float64
+	lastChange     time.Time
+
+	// Statistics
+	disableCount  int64
+	disabledSince time.Time
+	disabledTotal time.Duration
+
+	mu sync.RWMutex
+}
+
+// EquityCurveConfig holds configuration for the equity curve filter
+type EquityCurveConfig struct {
+	Enabled       bool    `json:"enabled"`        // Disabled filters never switch trading off
+	MAPeriod      int     `json:"ma_period"`      // Equity samples in the moving average (20)
+	DisableBuffer float64 `json:"disable_buffer"` // Equity must fall this far below the average to switch off (0.5%)
+}
+
+// NewEquityCurveFilter creates a new equity curve filter
+func NewEquityCurveFilter(config EquityCurveConfig) *EquityCurveFilter {
+	if config.MAPeriod == 0 {
+		config.MAPeriod = 20 // default
+	}
+	if config.DisableBuffer == 0 {
+		config.DisableBuffer = 0.005 // 0.5%
+	}
+
+	return &EquityCurveFilter{
+		config:         config,
+		samples:        make([]float64, 0, config.MAPeriod),
+		tradingEnabled: true,
+	}
+}
+
+// Update adds an equity sample and returns true if trading was switched on or off by it.
+// Trading turns off when equity drops below the average by the buffer and back on once it regains the average.
+func (f *EquityCurveFilter) Update(equity float64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.samples = append(f.samples, equity)
+	f.sum += equity
+	if len(f.samples) > f.config.MAPeriod {
+		f.sum -= f.samples[0]
+		f.samples = f.samples[1:]
+	}
+	f.lastEquity = equity
+
+	if !f.config.Enabled || len(f.samples) < f.config.MAPeriod {
+		return false
+	}
+
+	average := f.sum / float64(len(f.samples))
+	now := time.Now()
+
+	if f.tradingEnabled && equity < average*(1-f.config.DisableBuffer) {
+		f.tradingEnabled = false
+		f.lastChange = now
+		f.disabledSince = now
+		f.disableCount++
+		return true
+	}
+	if !f.tradingEnabled && equity >= average {
+		f.tradingEnabled = true
+		f.lastChange = now
+		f.disabledTotal += now.Sub(f.disabledSince)
+		return true
+	}
+	return false
+}
+
+// IsTradingEnabled returns false while equity is below its moving average
+func (f *EquityCurveFilter) IsTradingEnabled() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.tradingEnabled
+}
+
+// GetMovingAverage returns the equity moving average, or 0 before any samples
+func (f *EquityCurveFilter) GetMovingAverage() float64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.samples) == 0 {
+		return 0
+	}
+	return f.sum / float64(len(f.samples))
+}
+
+// GetEquityCurveStats returns equity curve filter statistics
+func (f *EquityCurveFilter) GetEquityCurveStats() map[string]interface{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	average := float64(0)
+	if len(f.samples) > 0 {
+		average = f.sum / float64(len(f.samples))
+	}
+	disabledTotal := f.disabledTotal
+	if !f.tradingEnabled {
+		disabledTotal += time.Since(f.disabledSince)
+	}
+
+	return map[string]interface{}{
+		"enabled":         f.config.Enabled,
+		"trading_enabled": f.tradingEnabled,
+		"equity":          f.lastEquity,
+		"moving_average":  average,
+		"samples":         len(f.samples),
+		"ma_period":       f.config.MAPeriod,
+		"disable_count":   f.disableCount,
+		"disabled_time":   disabledTotal,
+		"last_change":     f.lastChange,
+	}
+}
@@ -1,6 +1,8 @@
 package strategy
 
 import (
+	"aibot/internal/indicators"
+	"fmt"
 	"math"
 	"time"
 )
@@ -14,6 +16,7 @@ type FalseBreakoutDetector struct {
 	MinVolumeDecline     float64 `json:"min_volume_decline"`     // Minimum volume drop (50%)
 	MomentumReversalTime int     `json:"momentum_reversal_time"` // Time for momentum reversal (900ms)
 	MaxFakeoutFrequency   float64 `json:"max_fakeout_frequency"`  // Max fakeout frequency per hour
+	RequireVolumeData    bool    `json:"require_volume_data"`    // Suppress signals when no volume data is available
 
 	// State tracking
 	recentPriceChanges   []float64 `json:"recent_price_changes"`
@@ -25,6 +28,23 @@ type FalseBreakoutDetector struct {
 	// Detection thresholds
 	atrMultiplier        float64 `json:"atr_multiplier"`        // ATR for reversal detection
 	standardDevMultiplier float64 `json:"std_dev_multiplier"`   // Standard deviation threshold
+
+	// Optional external scoring model
+	scorer               FalseBreakoutScorer
+	scorerWeight         float64 // Weight of the model probability in the blended confidence
+	scorerCalls          int64
+	scorerErrors         int64
+
+	// Optional early warning from price/oscillator divergence
+	divergenceWarning    bool
+	divergenceSource     DivergenceSource
+	divergenceSignals    int64
+	lastDivergence       float64 // Strength of the divergence against the breakout at the last detection
+}
+
+// DivergenceSource reports divergences of price with oscillators, e.g. the technical analyzer
+type DivergenceSource interface {
+	GetDivergences(symbol string) []indicators.Divergence
 }
 
 // FalseBreakoutSignal represents a detected false breakout
@@ -39,6 +59,8 @@ type FalseBreakoutSignal struct {
 	CurrentPrice   float64           `json:"current_price"`
 	Reasons        []string          `json:"reasons"`
 	RecoveryAction string            `json:"recovery_action"`
+	RuleConfidence float64           `json:"rule_confidence"`             // Confidence before blending with the model
+	ModelProbability float64         `json:"model_probability,omitempty"` // External model probability, if scored
 }
 
 // FalseBreakoutType represents different types of false breakouts
@@ -49,6 +71,7 @@ const (
 	FalseBreakoutVolumeDrop    FalseBreakoutType = "volume_drop"       // Volume dries up
 	FalseBreakoutMomentumShift FalseBreakoutType = "momentum_shift"    // Momentum reverses
 	FalseBreakoutConsolidation FalseBreakoutType = "consolidation"     // Price consolidates
+	FalseBreakoutDivergence    FalseBreakoutType = "divergence"        // OBV or RSI does not confirm the new extreme
 )
 
 // ReversalType represents the type of reversal detected
@@ -69,6 +92,9 @@ type FalseBreakoutConfig struct {
 	MomentumReversalMs      int     `json:"momentum_reversal_ms"`      // 900ms
 	ATRMultiplier           float64 `json:"atr_multiplier"`            // 1.5x
 	StdDevMultiplier        float64 `json:"std_dev_multiplier"`         // 2.0x
+	RequireVolumeData       bool    `json:"require_volume_data"`        // Only signal when volume data is available
+	DivergenceWarning       bool    `json:"divergence_warning"`         // Warn when OBV or RSI diverges from the breakout
+	Scorer                  FalseBreakoutScorerConfig `json:"scorer"`       // Optional external model blended with the rules
 }
 
 // NewFalseBreakoutDetector creates a new false breakout detector
@@ -101,6 +127,8 @@ func NewFalseBreakoutDetector(config FalseBreakoutConfig) *FalseBreakoutDetector
 		ConfirmationWindow:   config.ConfirmationCandles,
 		MinVolumeDecline:     config.VolumeDeclineThreshold,
 		MomentumReversalTime: config.MomentumReversalMs,
+		RequireVolumeData:    config.RequireVolumeData,
+		divergenceWarning:    config.DivergenceWarning,
 		atrMultiplier:        atrMultiple,
 		standardDevMultiplier: config.StdDevMultiplier,
 		recentPriceChanges:   make([]float64, 0),
@@ -118,6 +146,11 @@ func (fb *FalseBreakoutDetector) DetectFalseBreakout(
 	timeSinceBreakout time.Duration,
 ) *FalseBreakoutSignal {
 
+	// Without volume data the signals cannot be validated
+	if fb.RequireVolumeData && averageVolume <= 0 {
+		return nil
+	}
+
 	// Update tracking data
 	fb.updateTrackingData(currentPrice, averageVolume, timeSinceBreakout)
 
@@ -144,14 +177,83 @@ func (fb *FalseBreakoutDetector) DetectFalseBreakout(
 		signals = append(signals, signal)
 	}
 
+	// Oscillator divergence detection
+	if signal := fb.detectDivergence(symbol, breakoutType); signal != nil {
+		signals = append(signals, signal)
+	}
+
 	// Combine signals if multiple patterns detected
 	if len(signals) > 0 {
-		return fb.combineSignals(signals, symbol, entryPrice, currentPrice, breakoutType)
+		signal := fb.combineSignals(signals, symbol, entryPrice, currentPrice, breakoutType)
+		signal.RuleConfidence = signal.Confidence
+		fb.applyScorer(signal, breakoutType, averageVolume, timeSinceBreakout)
+		return signal
 	}
 
 	return nil
 }
 
+// SetScorer attaches an external model whose probability is blended into signal confidence with the given weight
+func (fb *FalseBreakoutDetector) SetScorer(scorer FalseBreakoutScorer, weight float64) {
+	if weight <= 0 || weight > 1 {
+		weight = 0.5 // default
+	}
+	fb.scorer = scorer
+	fb.scorerWeight = weight
+}
+
+// SetDivergenceSource attaches the source of the price/oscillator divergences checked when divergence
+// warnings are enabled
+func (fb *FalseBreakoutDetector) SetDivergenceSource(source DivergenceSource) {
+	fb.divergenceSource = source
+}
+
+// applyScorer blends the model probability into the signal; scoring errors keep the rule-based confidence
+func (fb *FalseBreakoutDetector) applyScorer(signal *FalseBreakoutSignal, breakoutType BreakoutType, averageVolume float64, timeSinceBreakout time.Duration) {
+	if fb.scorer == nil {
+		return
+	}
+
+	features := fb.buildFeatures(signal, breakoutType, averageVolume, timeSinceBreakout)
+	fb.scorerCalls++
+	probability, err := fb.scorer.Score(features)
+	if err != nil {
+		fb.scorerErrors++
+		signal.Reasons = append(signal.Reasons, "Model scoring unavailable: "+err.Error())
+		return
+	}
+
+	signal.ModelProbability = probability
+	signal.Confidence = (1-fb.scorerWeight)*signal.RuleConfidence + fb.scorerWeight*probability
+}
+
+// buildFeatures extracts the engineered features for the external model
+func (fb *FalseBreakoutDetector) buildFeatures(signal *FalseBreakoutSignal, breakoutType BreakoutType, averageVolume float64, timeSinceBreakout time.Duration) FalseBreakoutFeatures {
+	reversal := 0.0
+	if signal.EntryPrice > 0 {
+		reversal = (signal.EntryPrice - signal.CurrentPrice) / signal.EntryPrice
+		if breakoutType == BreakoutTypeDown {
+			reversal = -reversal
+		}
+	}
+
+	volumeRatio := 0.0
+	if recentVolume := fb.calculateRecentAverageVolume(); recentVolume > 0 && averageVolume > 0 {
+		volumeRatio = averageVolume / recentVolume
+	}
+
+	return FalseBreakoutFeatures{
+		Symbol:            signal.Symbol,
+		BreakoutType:      breakoutType,
+		ReversalStrength:  reversal,
+		VolumeRatio:       volumeRatio,
+		Momentum:          fb.calculateRecentMomentum(),
+		TimeSinceBreakout: timeSinceBreakout.Seconds(),
+		Divergence:        fb.lastDivergence,
+		RuleConfidence:    signal.RuleConfidence,
+	}
+}
+
 // detectQuickReversal detects immediate price reversal patterns
 func (fb *FalseBreakoutDetector) detectQuickReversal(
 	symbol string,
@@ -211,16 +313,19 @@ func (fb *FalseBreakoutDetector) detectVolumeDrop(
 	timeSinceBreakout time.Duration,
 ) *FalseBreakoutSignal {
 
-	if len(fb.volumeHistory) < 5 {
+	if averageVolume <= 0 || len(fb.volumeHistory) < 5 {
 		return nil
 	}
 
-	// Calculate recent volume average
+	// Compare current volume against the recent baseline
 	recentVolumeAvg := fb.calculateRecentAverageVolume()
-	volumeDropRatio := (averageVolume - recentVolumeAvg) / averageVolume
+	if recentVolumeAvg <= 0 {
+		return nil
+	}
+	volumeDropRatio := (recentVolumeAvg - averageVolume) / recentVolumeAvg
 
 	// Check if volume has dropped significantly
-	if volumeDropRatio < fb.MinVolumeDecline {
+	if volumeDropRatio >= fb.MinVolumeDecline {
 		// Volume dropping suggests weakening breakout
 		confidence := min(1.0, volumeDropRatio*2) // Scale confidence
 
@@ -334,6 +439,60 @@ func (fb *FalseBreakoutDetector) detectConsolidation(
 	return nil
 }
 
+// detectDivergence warns when an oscillator fails to confirm the breakout: a bearish divergence (price higher
+// high, OBV or RSI lower high) against an upward breakout or a bullish one against a downward breakout
+func (fb *FalseBreakoutDetector) detectDivergence(symbol string, breakoutType BreakoutType) *FalseBreakoutSignal {
+	fb.lastDivergence = 0
+	if !fb.divergenceWarning || fb.divergenceSource == nil {
+		return nil
+	}
+
+	var kind string
+	switch breakoutType {
+	case BreakoutTypeUp:
+		kind = indicators.DivergenceBearish
+	case BreakoutTypeDown:
+		kind = indicators.DivergenceBullish
+	default:
+		return nil
+	}
+
+	reasons := make([]string, 0)
+	strongest := 0.0
+	for _, divergence := range fb.divergenceSource.GetDivergences(symbol) {
+		if divergence.Kind != kind {
+			continue
+		}
+		strongest = math.Max(strongest, divergence.Strength)
+		reasons = append(reasons, fmt.Sprintf("%s %s divergence: price %.4f -> %.4f while %s %.2f -> %.2f",
+			kind, divergence.Oscillator, divergence.PriorPrice, divergence.RecentPrice,
+			divergence.Oscillator, divergence.PriorOscillator, divergence.RecentOscillator))
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	fb.lastDivergence = strongest
+	fb.divergenceSignals++
+
+	// An early warning: moderate on its own, stronger when both oscillators disagree with price
+	confidence := 0.3 + 0.5*strongest
+	if len(reasons) > 1 {
+		confidence += 0.1
+	}
+
+	return &FalseBreakoutSignal{
+		SignalType:     FalseBreakoutDivergence,
+		Confidence:     min(1.0, confidence),
+		ReversalType:   ReversalTypeTakeProfit,
+		ReversalTarget: 0, // No specific target
+		Timestamp:      time.Now(),
+		Symbol:         symbol,
+		CurrentPrice:   0,
+		Reasons:        reasons,
+		RecoveryAction: fb.getRecoveryAction(ReversalTypeTakeProfit),
+	}
+}
+
 // updateTrackingData updates internal tracking data
 func (fb *FalseBreakoutDetector) updateTrackingData(currentPrice, averageVolume float64, timeSinceBreakout time.Duration) {
 	// Update price changes (keep last 20)
@@ -342,10 +501,12 @@ func (fb *FalseBreakoutDetector) updateTrackingData(currentPrice, averageVolume
 		fb.recentPriceChanges = fb.recentPriceChanges[1:]
 	}
 
-	// Update volume history
-	fb.volumeHistory = append(fb.volumeHistory, averageVolume)
-	if len(fb.volumeHistory) > 20 {
-		fb.volumeHistory = fb.volumeHistory[1:]
+	// Update volume history (skip missing volume data)
+	if averageVolume > 0 {
+		fb.volumeHistory = append(fb.volumeHistory, averageVolume)
+		if len(fb.volumeHistory) > 20 {
+			fb.volumeHistory = fb.volumeHistory[1:]
+		}
 	}
 
 	// Update fakeout count
@@ -470,6 +631,11 @@ func (fb *FalseBreakoutDetector) GetFalseBreakoutStats() map[string]interface{}
 	"last_breakout_time":  fb.lastBreakoutTime,
 		"price_history_size":  len(fb.recentPriceChanges),
 		"volume_history_size": len(fb.volumeHistory),
+		"scorer_enabled":      fb.scorer != nil,
+		"scorer_calls":        fb.scorerCalls,
+		"scorer_errors":       fb.scorerErrors,
+		"divergence_warning":  fb.divergenceWarning && fb.divergenceSource != nil,
+		"divergence_signals":  fb.divergenceSignals,
 	}
 }
 
@@ -0,0 +1,101 @@
+package strategy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Supported false breakout scorer types
+const (
+	ScorerTypeHTTP = "http"
+	ScorerTypeONNX = "onnx"
+)
+
+// FalseBreakoutFeatures are the engineered features passed to an external scoring model
+type FalseBreakoutFeatures struct {
+	Symbol            string       `json:"symbol"`
+	BreakoutType      BreakoutType `json:"breakout_type"`
+	ReversalStrength  float64      `json:"reversal_strength"`   // Move back against the breakout as a fraction of entry price
+	VolumeRatio       float64      `json:"volume_ratio"`        // Current vs recent average volume (0 if unknown)
+	Momentum          float64      `json:"momentum"`            // Average per-update rate of change over recent prices
+	TimeSinceBreakout float64      `json:"time_since_breakout"` // Seconds
+	Divergence        float64      `json:"divergence"`          // Strength of an OBV or RSI divergence against the breakout (0 if none)
+	RuleConfidence    float64      `json:"rule_confidence"`     // Confidence from the rule-based detector
+}
+
+// FalseBreakoutScorer returns the probability (0-1) that a breakout is false
+type FalseBreakoutScorer interface {
+	Score(features FalseBreakoutFeatures) (float64, error)
+}
+
+// FalseBreakoutScorerConfig holds configuration for the external scoring model
+type FalseBreakoutScorerConfig struct {
+	Type        string        `json:"type"`         // "" (disabled), "http", "onnx"
+	Endpoint    string        `json:"endpoint"`     // HTTP scoring endpoint
+	ModelPath   string        `json:"model_path"`   // ONNX model file
+	Timeout     time.Duration `json:"timeout"`      // Per-request timeout (200ms)
+	BlendWeight float64       `json:"blend_weight"` // Weight of the model probability vs rule confidence (0.5)
+}
+
+// NewFalseBreakoutScorer creates the scorer for the configured type
+func NewFalseBreakoutScorer(config FalseBreakoutScorerConfig) (FalseBreakoutScorer, error) {
+	if config.Timeout == 0 {
+		config.Timeout = 200 * time.Millisecond // default
+	}
+
+	switch config.Type {
+	case ScorerTypeHTTP:
+		if config.Endpoint == "" {
+			return nil, fmt.Errorf("http scorer requires an endpoint")
+		}
+		return &HTTPScorer{
+			endpoint: config.Endpoint,
+			client:   &http.Client{Timeout: config.Timeout},
+		}, nil
+	case ScorerTypeONNX:
+		return nil, fmt.Errorf("onnx scorer is not supported: no ONNX runtime is available in this build; serve %s over HTTP instead", config.ModelPath)
+	default:
+		return nil, fmt.Errorf("unsupported scorer type: %s", config.Type)
+	}
+}
+
+// HTTPScorer posts features as JSON and expects {"probability": 0.0-1.0} back
+type HTTPScorer struct {
+	endpoint string
+	client   *http.Client
+}
+
+// scoreResponse is the body returned by an HTTP scoring endpoint
+type scoreResponse struct {
+	Probability float64 `json:"probability"`
+}
+
+// Score sends the features to the endpoint and returns the model probability
+func (s *HTTPScorer) Score(features FalseBreakoutFeatures) (float64, error) {
+	body, err := json.Marshal(features)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode features: %w", err)
+	}
+
+	resp, err := s.client.Post(s.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("scoring request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("scoring endpoint returned %s", resp.Status)
+	}
+
+	var result scoreResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode score: %w", err)
+	}
+	if result.Probability < 0 || result.Probability > 1 {
+		return 0, fmt.Errorf("probability %.4f out of range", result.Probability)
+	}
+	return result.Probability, nil
+}
@@ -0,0 +1,243 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"math"
+	"sync"
+	"time"
+)
+
+// FeeGovernorConfig holds the fee budget and how hard grid turnover is throttled when it is exceeded
+type FeeGovernorConfig struct {
+	Enabled      bool          `json:"enabled"`       // Disabled governors never throttle
+	HourlyBudget float64       `json:"hourly_budget"` // Max fees over the last hour in the quote asset (0 = no limit)
+	DailyBudget  float64       `json:"daily_budget"`  // Max fees over the last 24h (0 = no limit)
+	MaxFeeRatio  float64       `json:"max_fee_ratio"` // Max fees as a fraction of 24h gross profit (0.5)
+	MinFills     int           `json:"min_fills"`     // Fills in 24h before the fee ratio is judged (10)
+	Cooldown     time.Duration `json:"cooldown"`      // Minimum time between throttle changes (15m)
+	MaxThrottle  int           `json:"max_throttle"`  // Highest throttle level (3)
+	SpacingStep  float64       `json:"spacing_step"`  // Extra grid spacing per throttle level (0.5 = +50%)
+	LevelStep    float64       `json:"level_step"`    // Fraction of grid levels removed per throttle level (0.2)
+}
+
+// GridThrottle scales the grid layout down to reduce turnover
+type GridThrottle struct {
+	Level             int     `json:"level"`
+	SpacingMultiplier float64 `json:"spacing_multiplier"`
+	LevelMultiplier   float64 `json:"level_multiplier"`
+}
+
+// Apply widens the spacing and removes levels around the grid center; the per-level size is scaled so
+// the grid keeps its total size
+func (t GridThrottle) Apply(result *GridCalculationResult, price float64) {
+	if t.Level == 0 || result.GridLevels <= 0 {
+		return
+	}
+
+	levels := int(math.Max(1, math.Round(float64(result.GridLevels)*t.LevelMultiplier)))
+	scale := t.SpacingMultiplier * float64(levels) / float64(result.GridLevels)
+	if result.SpacingMode.IsGeometric() && result.LowerBound > 0 {
+		// Scale the log range around the geometric mid so the levels keep a constant ratio
+		center := math.Sqrt(result.UpperBound * result.LowerBound)
+		factor := math.Pow(result.UpperBound/result.LowerBound, scale/2)
+		result.UpperBound = center * factor
+		result.LowerBound = center / factor
+	} else {
+		center := (result.UpperBound + result.LowerBound) / 2
+		halfRange := (result.UpperBound - result.LowerBound) / 2 * scale
+		result.UpperBound = center + halfRange
+		result.LowerBound = math.Max(center-halfRange, center*0.01)
+	}
+	result.PositionSize *= float64(result.GridLevels) / float64(levels)
+	result.GridLevels = levels
+	result.GridSpacing *= t.SpacingMultiplier
+	if price > 0 {
+		result.TotalRange = (result.UpperBound - result.LowerBound) / price
+	}
+}
+
+// feeEvent is the fee and gross PnL of one fill
+type feeEvent struct {
+	time time.Time
+	fee  float64
+	pnl  float64
+}
+
+// FeeGovernor tracks commissions against a fee budget and gross profit and raises a throttle level
+// while fees run ahead, since fee burn is the main way tight grids lose money
+type FeeGovernor struct {
+	config FeeGovernorConfig
+	events []feeEvent // Fills of the last 24h, oldest first
+
+	// Throttle state
+	level      int
+	lastChange time.Time
+	reason     string // Why the budget is exceeded, "" while within budget
+
+	// Statistics
+	totalFees   float64
+	throttleUps int64
+
+	mu sync.RWMutex
+}
+
+// NewFeeGovernor creates a new fee governor
+func NewFeeGovernor(config FeeGovernorConfig) *FeeGovernor {
+	if config.MaxFeeRatio == 0 {
+		config.MaxFeeRatio = 0.5 // default
+	}
+	if config.MinFills == 0 {
+		config.MinFills = 10 // default
+	}
+	if config.Cooldown == 0 {
+		config.Cooldown = 15 * time.Minute // default
+	}
+	if config.MaxThrottle == 0 {
+		config.MaxThrottle = 3 // default
+	}
+	if config.SpacingStep == 0 {
+		config.SpacingStep = 0.5 // default
+	}
+	if config.LevelStep == 0 {
+		config.LevelStep = 0.2 // default
+	}
+
+	return &FeeGovernor{
+		config: config,
+		events: make([]feeEvent, 0),
+	}
+}
+
+// RecordFill adds the fee and gross realized PnL of a fill
+func (g *FeeGovernor) RecordFill(update types.OrderUpdate) {
+	if !update.IsFill() {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	at := update.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	g.events = append(g.events, feeEvent{time: at, fee: update.Fee, pnl: update.RealizedPnL})
+	g.totalFees += update.Fee
+}
+
+// Evaluate checks the budget and moves the throttle one level up while it is exceeded or one level down
+// once it is met again, at most once per cooldown. Returns true if the throttle level changed.
+func (g *FeeGovernor) Evaluate(now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.prune(now)
+	if !g.config.Enabled {
+		return false
+	}
+
+	g.reason = g.overBudget(now)
+	if !g.lastChange.IsZero() && now.Sub(g.lastChange) < g.config.Cooldown {
+		return false
+	}
+
+	switch {
+	case g.reason != "" && g.level < g.config.MaxThrottle:
+		g.level++
+		g.throttleUps++
+	case g.reason == "" && g.level > 0:
+		g.level--
+	default:
+		return false
+	}
+	g.lastChange = now
+	return true
+}
+
+// overBudget returns why fees are over budget, or "" if they are within it
+func (g *FeeGovernor) overBudget(now time.Time) string {
+	hourlyFees, dailyFees, grossProfit := g.totals(now)
+
+	if g.config.HourlyBudget > 0 && hourlyFees > g.config.HourlyBudget {
+		return "hourly fee budget exceeded"
+	}
+	if g.config.DailyBudget > 0 && dailyFees > g.config.DailyBudget {
+		return "daily fee budget exceeded"
+	}
+	if len(g.events) >= g.config.MinFills && dailyFees > 0 && dailyFees > g.config.MaxFeeRatio*math.Max(grossProfit, 0) {
+		return "fees ahead of gross profit"
+	}
+	return ""
+}
+
+// totals returns the fees of the last hour and day and the gross profit of the last day
+func (g *FeeGovernor) totals(now time.Time) (float64, float64, float64) {
+	var hourlyFees, dailyFees, grossProfit float64
+	hourAgo := now.Add(-time.Hour)
+	for _, event := range g.events {
+		dailyFees += event.fee
+		grossProfit += event.pnl
+		if event.time.After(hourAgo) {
+			hourlyFees += event.fee
+		}
+	}
+	return hourlyFees, dailyFees, grossProfit
+}
+
+// prune drops fills older than 24h
+func (g *FeeGovernor) prune(now time.Time) {
+	cutoff := now.Add(-24 * time.Hour)
+	drop := 0
+	for drop < len(g.events) && !g.events[drop].time.After(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		g.events = append(g.events[:0], g.events[drop:]...)
+	}
+}
+
+// Throttle returns the current grid throttle
+func (g *FeeGovernor) Throttle() GridThrottle {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return GridThrottle{
+		Level:             g.level,
+		SpacingMultiplier: 1 + g.config.SpacingStep*float64(g.level),
+		LevelMultiplier:   math.Max(0.1, 1-g.config.LevelStep*float64(g.level)),
+	}
+}
+
+// GetReason returns why the fee budget is exceeded, or "" if it is met
+func (g *FeeGovernor) GetReason() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.reason
+}
+
+// GetFeeGovernorStats returns fee governor statistics
+func (g *FeeGovernor) GetFeeGovernorStats() map[string]interface{} {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	hourlyFees, dailyFees, grossProfit := g.totals(time.Now())
+	feeRatio := 0.0
+	if grossProfit > 0 {
+		feeRatio = dailyFees / grossProfit
+	}
+
+	return map[string]interface{}{
+		"enabled":        g.config.Enabled,
+		"throttle_level": g.level,
+		"max_throttle":   g.config.MaxThrottle,
+		"reason":         g.reason,
+		"hourly_fees":    hourlyFees,
+		"daily_fees":     dailyFees,
+		"gross_profit":   grossProfit,
+		"fee_ratio":      feeRatio,
+		"fills_24h":      len(g.events),
+		"total_fees":     g.totalFees,
+		"throttle_ups":   g.throttleUps,
+		"last_change":    g.lastChange,
+	}
+}
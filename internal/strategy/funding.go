@@ -0,0 +1,281 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// FundingConfig holds configuration for the funding and settlement calendar of futures contracts
+type FundingConfig struct {
+	Interval           time.Duration `json:"interval"`            // Funding period of perpetuals (8h)
+	Offset             time.Duration `json:"offset"`              // First funding of the UTC day (00:00)
+	EntryBlackout      time.Duration `json:"entry_blackout"`      // No new grid inventory this close to funding (30s)
+	SettlementBlackout time.Duration `json:"settlement_blackout"` // No new entries this close to contract settlement (1h)
+	ExpectedHolding    time.Duration `json:"expected_holding"`    // Holding period breakout carry cost is estimated over (4h)
+	MaxCarryFraction   float64       `json:"max_carry_fraction"`  // Skip breakouts whose carry exceeds this fraction of the target move (0.25)
+}
+
+// FundingSchedule is the funding and settlement calendar of one symbol
+type FundingSchedule struct {
+	Symbol      string    `json:"symbol"`
+	Rate        float64   `json:"rate"`                 // Funding rate per interval; longs pay shorts when positive
+	NextFunding time.Time `json:"next_funding"`         // Reported by the exchange (zero: derived from the interval)
+	Settlement  time.Time `json:"settlement,omitempty"` // Settlement of a dated contract (zero: perpetual)
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// FundingPayment is a funding exchange that has taken place at the last polled rate
+type FundingPayment struct {
+	Symbol string    `json:"symbol"`
+	Rate   float64   `json:"rate"`
+	Time   time.Time `json:"time"`
+}
+
+// FundingCalendar tracks upcoming funding times, funding rates and contract settlement per symbol, so
+// strategies can hold back new inventory just before funding and weigh the carry cost of a position
+type FundingCalendar struct {
+	config    FundingConfig
+	schedules map[string]*FundingSchedule
+	paid      map[string]time.Time // Last funding time reported as due per symbol
+
+	// Statistics
+	blackouts    int64
+	carrySkipped int64
+
+	mu sync.RWMutex
+}
+
+// NewFundingCalendar creates a funding calendar; settlement times of dated contracts come from their specs
+func NewFundingCalendar(config FundingConfig, contracts map[string]types.ContractSpec) *FundingCalendar {
+	if config.Interval == 0 {
+		config.Interval = 8 * time.Hour // default
+	}
+	if config.EntryBlackout == 0 {
+		config.EntryBlackout = 30 * time.Second // default
+	}
+	if config.SettlementBlackout == 0 {
+		config.SettlementBlackout = time.Hour // default
+	}
+	if config.ExpectedHolding == 0 {
+		config.ExpectedHolding = 4 * time.Hour // default
+	}
+	if config.MaxCarryFraction == 0 {
+		config.MaxCarryFraction = 0.25 // default
+	}
+
+	calendar := &FundingCalendar{
+		config:    config,
+		schedules: make(map[string]*FundingSchedule),
+		paid:      make(map[string]time.Time),
+	}
+	for symbol, contract := range contracts {
+		if !contract.Expiry.IsZero() {
+			calendar.schedule(symbol).Settlement = contract.Expiry
+		}
+	}
+	return calendar
+}
+
+// schedule returns the schedule of a symbol, creating it. Callers hold the lock.
+func (fc *FundingCalendar) schedule(symbol string) *FundingSchedule {
+	schedule, exists := fc.schedules[symbol]
+	if !exists {
+		schedule = &FundingSchedule{Symbol: symbol}
+		fc.schedules[symbol] = schedule
+	}
+	return schedule
+}
+
+// UpdateFunding records the funding rate and next funding time reported by the exchange
+func (fc *FundingCalendar) UpdateFunding(symbol string, rate float64, nextFunding time.Time) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	schedule := fc.schedule(symbol)
+	schedule.Rate = rate
+	schedule.NextFunding = nextFunding
+	schedule.UpdatedAt = time.Now()
+}
+
+// DueFunding returns the funding of a symbol once the funding time reported by the exchange has passed,
+// at the rate polled before it; each funding is returned once. Call it before UpdateFunding moves the
+// schedule on to the next funding.
+func (fc *FundingCalendar) DueFunding(symbol string, now time.Time) (FundingPayment, bool) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	schedule, exists := fc.schedules[symbol]
+	if !exists || !schedule.Settlement.IsZero() || schedule.NextFunding.IsZero() || now.Before(schedule.NextFunding) {
+		return FundingPayment{}, false
+	}
+	if !fc.paid[symbol].Before(schedule.NextFunding) {
+		return FundingPayment{}, false
+	}
+	fc.paid[symbol] = schedule.NextFunding
+	return FundingPayment{Symbol: symbol, Rate: schedule.Rate, Time: schedule.NextFunding}, true
+}
+
+// GetSchedule returns the funding schedule of a symbol with the next funding time filled in
+func (fc *FundingCalendar) GetSchedule(symbol string, now time.Time) FundingSchedule {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+
+	schedule := FundingSchedule{Symbol: symbol}
+	if known, exists := fc.schedules[symbol]; exists {
+		schedule = *known
+	}
+	schedule.NextFunding = fc.nextFunding(schedule, now)
+	return schedule
+}
+
+// nextFunding returns the first funding after now: the exchange's time while it is still ahead, else the
+// next slot of the configured interval. Dated contracts pay no funding.
+func (fc *FundingCalendar) nextFunding(schedule FundingSchedule, now time.Time) time.Time {
+	if !schedule.Settlement.IsZero() {
+		return time.Time{}
+	}
+	if schedule.NextFunding.After(now) {
+		return schedule.NextFunding
+	}
+	day := now.UTC().Truncate(24 * time.Hour)
+	elapsed := now.Sub(day.Add(fc.config.Offset))
+	slots := int64(math.Floor(float64(elapsed)/float64(fc.config.Interval))) + 1
+	return day.Add(fc.config.Offset + time.Duration(slots)*fc.config.Interval)
+}
+
+// TimeToFunding returns the time until the next funding of a symbol; false for dated contracts
+func (fc *FundingCalendar) TimeToFunding(symbol string, now time.Time) (time.Duration, bool) {
+	next := fc.GetSchedule(symbol, now).NextFunding
+	if next.IsZero() {
+		return 0, false
+	}
+	return next.Sub(now), true
+}
+
+// TimeToSettlement returns the time until a dated contract settles; false for perpetuals
+func (fc *FundingCalendar) TimeToSettlement(symbol string, now time.Time) (time.Duration, bool) {
+	settlement := fc.GetSchedule(symbol, now).Settlement
+	if settlement.IsZero() {
+		return 0, false
+	}
+	return settlement.Sub(now), true
+}
+
+// EntryBlackout returns why new grid inventory should not be opened now, or "" if it may; a nil calendar
+// (spot markets) never blocks entries
+func (fc *FundingCalendar) EntryBlackout(symbol string, now time.Time) string {
+	if fc == nil {
+		return ""
+	}
+	reason := fc.SettlementBlackout(symbol, now)
+	if reason != "" {
+		return reason
+	}
+	if untilFunding, perpetual := fc.TimeToFunding(symbol, now); perpetual && fc.config.EntryBlackout > 0 && untilFunding <= fc.config.EntryBlackout {
+		reason = fmt.Sprintf("funding of %s in %v", symbol, untilFunding.Round(time.Second))
+		fc.countBlackout()
+	}
+	return reason
+}
+
+// SettlementBlackout returns why no position should be opened in a dated contract close to its settlement,
+// or "" if it may
+func (fc *FundingCalendar) SettlementBlackout(symbol string, now time.Time) string {
+	if fc == nil {
+		return ""
+	}
+	untilSettlement, dated := fc.TimeToSettlement(symbol, now)
+	if !dated || untilSettlement > fc.config.SettlementBlackout {
+		return ""
+	}
+	fc.countBlackout()
+	if untilSettlement <= 0 {
+		return fmt.Sprintf("%s has settled", symbol)
+	}
+	return fmt.Sprintf("%s settles in %v", symbol, untilSettlement.Round(time.Second))
+}
+
+// countBlackout counts an entry held back by the calendar
+func (fc *FundingCalendar) countBlackout() {
+	fc.mu.Lock()
+	fc.blackouts++
+	fc.mu.Unlock()
+}
+
+// CarryCost returns the funding a position of the given notional pays over holding, positive when it pays
+// and negative when it receives; the last known rate is assumed for every funding in the period
+func (fc *FundingCalendar) CarryCost(symbol string, positionType types.PositionType, notional float64, holding time.Duration, now time.Time) float64 {
+	schedule := fc.GetSchedule(symbol, now)
+	if schedule.NextFunding.IsZero() || holding <= 0 {
+		return 0
+	}
+	end := now.Add(holding)
+	if schedule.NextFunding.After(end) {
+		return 0
+	}
+	fundings := 1 + int(end.Sub(schedule.NextFunding)/fc.config.Interval)
+
+	cost := schedule.Rate * notional * float64(fundings)
+	if positionType == types.PositionTypeShort {
+		return -cost
+	}
+	return cost
+}
+
+// CheckCarry estimates the carry of a breakout entry at price targeting target over the expected holding
+// period and returns why the entry should be skipped, or "" if the carry is acceptable
+func (fc *FundingCalendar) CheckCarry(symbol string, positionType types.PositionType, price, target float64, now time.Time) string {
+	if fc == nil {
+		return ""
+	}
+	move := math.Abs(target - price)
+	if price <= 0 || move == 0 {
+		return ""
+	}
+	carry := fc.CarryCost(symbol, positionType, price, fc.config.ExpectedHolding, now)
+	if carry <= fc.config.MaxCarryFraction*move {
+		return ""
+	}
+
+	fc.mu.Lock()
+	fc.carrySkipped++
+	fc.mu.Unlock()
+	return fmt.Sprintf("funding carry %.4f over %v exceeds %.0f%% of the %.4f target move",
+		carry, fc.config.ExpectedHolding, fc.config.MaxCarryFraction*100, move)
+}
+
+// GetFundingStats returns funding calendar statistics
+func (fc *FundingCalendar) GetFundingStats() map[string]interface{} {
+	now := time.Now()
+	fc.mu.RLock()
+	symbols := make([]string, 0, len(fc.schedules))
+	for symbol := range fc.schedules {
+		symbols = append(symbols, symbol)
+	}
+	stats := map[string]interface{}{
+		"interval":      fc.config.Interval.String(),
+		"blackouts":     fc.blackouts,
+		"carry_skipped": fc.carrySkipped,
+	}
+	fc.mu.RUnlock()
+
+	schedules := make(map[string]interface{}, len(symbols))
+	for _, symbol := range symbols {
+		schedule := fc.GetSchedule(symbol, now)
+		entry := map[string]interface{}{"rate": schedule.Rate}
+		if !schedule.NextFunding.IsZero() {
+			entry["next_funding"] = schedule.NextFunding
+			entry["time_to_funding"] = schedule.NextFunding.Sub(now).Round(time.Second).String()
+		}
+		if !schedule.Settlement.IsZero() {
+			entry["settlement"] = schedule.Settlement
+			entry["time_to_settlement"] = schedule.Settlement.Sub(now).Round(time.Second).String()
+		}
+		schedules[symbol] = entry
+	}
+	stats["schedules"] = schedules
+	return stats
+}
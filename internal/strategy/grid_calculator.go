@@ -23,11 +23,15 @@ type GridCalculator struct {
 	// Risk management
 	MaxRiskPerTrade   float64 `json:"max_risk_per_trade"`   // Maximum risk per trade (2.0%)
 	PositionSizeRatio float64 `json:"position_size_ratio"` // Position size as % of capital
+
+	// Level layout
+	SpacingMode       GridSpacingMode `json:"spacing_mode"` // Arithmetic or geometric (percent) spacing
 }
 
 // GridCalculationResult contains optimized grid parameters
 type GridCalculationResult struct {
 	GridSpacing      float64 `json:"grid_spacing"`
+	SpacingMode      GridSpacingMode `json:"spacing_mode"`
 	GridLevels       int     `json:"grid_levels"`
 	TotalRange       float64 `json:"total_range"`
 	UpperBound       float64 `json:"upper_bound"`
@@ -63,6 +67,7 @@ func NewGridCalculator() *GridCalculator {
 		MaxGridLevels:      30,
 		MaxRiskPerTrade:    0.02,   // 2.0%
 		PositionSizeRatio:  0.05,   // 5% of capital
+		SpacingMode:        GridSpacingArithmetic,
 	}
 }
 
@@ -101,6 +106,7 @@ func (gc *GridCalculator) CalculateOptimalGrid(
 
 	return &GridCalculationResult{
 		GridSpacing:        optimalSpacing,
+		SpacingMode:        gc.SpacingMode,
 		GridLevels:         optimalLevels,
 		TotalRange:         (upperBound - lowerBound) / currentPrice * 100,
 		UpperBound:         upperBound,
@@ -205,6 +211,13 @@ func (gc *GridCalculator) calculateGridBounds(currentPrice, spacing float64, lev
 	volatilityBuffer := volatility * 2 // 2x ATR as buffer
 	totalRange += volatilityBuffer / currentPrice
 
+	// Geometric grids step by a ratio, so the bounds are a factor away from price and stay positive
+	if gc.SpacingMode.IsGeometric() {
+		upperBound, lowerBound := GridBoundsAround(currentPrice, spacing, levels, gc.SpacingMode)
+		buffer := 1 + volatilityBuffer/currentPrice/2
+		return upperBound * buffer, lowerBound / buffer
+	}
+
 	// Calculate bounds centered around current price
 	halfRange := totalRange / 2
 	upperBound := currentPrice * (1 + halfRange)
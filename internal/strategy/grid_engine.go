@@ -0,0 +1,588 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gridOrderStrategy is the strategy name embedded in client order IDs generated for grid levels
+const gridOrderStrategy = "grid"
+
+// GridSpacingMode selects how grid levels are spaced between the bounds
+type GridSpacingMode string
+
+// Grid spacing modes
+const (
+	GridSpacingArithmetic GridSpacingMode = "arithmetic" // Equal price distance between levels
+	GridSpacingGeometric  GridSpacingMode = "geometric"  // Equal percentage distance, so levels scale with price
+)
+
+// IsGeometric returns true if levels are spaced by a constant ratio
+func (m GridSpacingMode) IsGeometric() bool {
+	return m == GridSpacingGeometric
+}
+
+// GridLevelPrices returns the levelCount+1 level prices from lowerBound to upperBound
+func GridLevelPrices(lowerBound, upperBound float64, levelCount int, mode GridSpacingMode) []float64 {
+	prices := make([]float64, levelCount+1)
+	if mode.IsGeometric() && lowerBound > 0 {
+		ratio := math.Pow(upperBound/lowerBound, 1/float64(levelCount))
+		for i := range prices {
+			prices[i] = lowerBound * math.Pow(ratio, float64(i))
+		}
+	} else {
+		step := (upperBound - lowerBound) / float64(levelCount)
+		for i := range prices {
+			prices[i] = lowerBound + step*float64(i)
+		}
+	}
+	prices[levelCount] = upperBound
+	return prices
+}
+
+// GridBoundsAround returns the bounds of a grid of levelCount levels centered on price with the given spacing,
+// a fraction of price; geometric grids step by a factor of 1+spacing per level
+func GridBoundsAround(price, spacing float64, levelCount int, mode GridSpacingMode) (float64, float64) {
+	if mode.IsGeometric() {
+		factor := math.Pow(1+spacing, float64(levelCount)/2)
+		return price * factor, price / factor
+	}
+	halfRange := spacing * float64(levelCount) / 2
+	return price * (1 + halfRange), price * (1 - halfRange)
+}
+
+// GridSpacingOf returns the spacing, as a fraction of price, of a grid between the bounds
+func GridSpacingOf(lowerBound, upperBound, price float64, levelCount int, mode GridSpacingMode) float64 {
+	if levelCount <= 0 || lowerBound <= 0 || price <= 0 {
+		return 0
+	}
+	if mode.IsGeometric() {
+		return math.Pow(upperBound/lowerBound, 1/float64(levelCount)) - 1
+	}
+	return (upperBound - lowerBound) / price / float64(levelCount)
+}
+
+// GridLevelStats is the fill history of the levels at one distance from the grid center, kept across
+// grid rebuilds so outer levels can be compared with inner ones
+type GridLevelStats struct {
+	Offset     int           `json:"offset"`     // Levels from the grid center (rounded down), negative below it
+	Price      float64       `json:"price"`      // Price of the level in the latest grid
+	Allocation float64       `json:"allocation"` // Quote value of the level's order in the latest grid
+	Fills      int64         `json:"fills"`      // Completed fills
+	BuyFills   int64         `json:"buy_fills"`
+	SellFills  int64         `json:"sell_fills"`
+	Volume     float64       `json:"volume"`     // Quote value filled
+	Profit     float64       `json:"profit"`     // Realized PnL net of fees of the level's fills
+	Refills    int64         `json:"refills"`    // Fills that followed an earlier fill of the same level
+	AvgRefill  time.Duration `json:"avg_refill"` // Average time from a fill to the level's next fill
+	LastFill   time.Time     `json:"last_fill"`
+}
+
+// ReturnOnAllocation returns the level's profit as a fraction of the capital its order ties up
+func (s GridLevelStats) ReturnOnAllocation() float64 {
+	if s.Allocation <= 0 {
+		return 0
+	}
+	return s.Profit / s.Allocation
+}
+
+// gridLevelTracker accumulates the statistics of one level offset
+type gridLevelTracker struct {
+	stats       GridLevelStats
+	refillTotal time.Duration
+}
+
+// GridEngine maintains grid levels and derives the orders needed to keep the grid populated
+type GridEngine struct {
+	config GridEngineConfig
+
+	// Grid state
+	symbol      string
+	levels      []*types.GridLevel
+	spacing     float64
+	quantity    float64
+	inventory   float64        // Net base inventory accumulated from grid fills
+	orderLevels map[string]int // Client order ID -> level index
+	orderSeq    int64          // Nonce for client order IDs, unique for the engine's lifetime
+	center      float64        // Mid of the grid, where inventory unloads are placed
+
+	// Inventory control
+	maxInventory   float64 // Resolved for the current symbol; 0 is unlimited
+	unloadClientID string  // Client order ID of the pending unload order
+	unloadOrderID  string
+	unloads        int64
+	skippedLevels  int64 // Level placements held back by the inventory limit
+	deferredLevels int64 // Level placements held back by the open order budget
+
+	// Statistics
+	totalFills   int64
+	buyFills     int64
+	sellFills    int64
+	realizedPnL  float64
+	lastFillTime time.Time
+	levelStats   map[int]*gridLevelTracker // Level offset from the center -> fill statistics
+	levelFills   map[int]time.Time         // Level index -> last completed fill in the current grid
+
+	mu sync.RWMutex
+}
+
+// GridEngineConfig holds configuration for the grid engine
+type GridEngineConfig struct {
+	MarketType  types.MarketType `json:"market_type"`  // "futures" (buy below, sell above) or "spot" (sells backed by inventory)
+	SpacingMode GridSpacingMode  `json:"spacing_mode"` // "arithmetic" or "geometric" level spacing (arithmetic)
+
+	// Inventory skew control
+	MaxInventory         float64            `json:"max_inventory"`           // Max net base inventory (0 = unlimited)
+	MaxInventoryBySymbol map[string]float64 `json:"max_inventory_by_symbol"` // Per-symbol overrides of MaxInventory
+	SkewSpacingFactor    float64            `json:"skew_spacing_factor"`     // Extra spacing on the heavy side at max inventory, in spacings (1.0)
+	UnloadThreshold      float64            `json:"unload_threshold"`        // Inventory fraction of max that triggers an unload at mid (0.8)
+	UnloadFraction       float64            `json:"unload_fraction"`         // Fraction of inventory unloaded (0.5)
+}
+
+// NewGridEngine creates a new grid engine
+func NewGridEngine(config GridEngineConfig) *GridEngine {
+	if config.MarketType == "" {
+		config.MarketType = types.MarketTypeFutures // default
+	}
+	if config.SpacingMode == "" {
+		config.SpacingMode = GridSpacingArithmetic // default
+	}
+	if config.SkewSpacingFactor == 0 {
+		config.SkewSpacingFactor = 1.0 // default
+	}
+	if config.UnloadThreshold == 0 {
+		config.UnloadThreshold = 0.8 // default
+	}
+	if config.UnloadFraction == 0 {
+		config.UnloadFraction = 0.5 // default
+	}
+
+	return &GridEngine{
+		config:      config,
+		levels:      make([]*types.GridLevel, 0),
+		orderLevels: make(map[string]int),
+		levelStats:  make(map[int]*gridLevelTracker),
+		levelFills:  make(map[int]time.Time),
+	}
+}
+
+// BuildGrid lays out levels between the bounds; levels below price buy and levels above sell.
+// On spot markets sell levels are only activated while the given base inventory covers them.
+func (ge *GridEngine) BuildGrid(symbol string, upperBound, lowerBound float64, levelCount int, quantity, currentPrice, inventory float64) error {
+	if levelCount <= 0 || upperBound <= lowerBound || quantity <= 0 || currentPrice <= 0 {
+		return fmt.Errorf("invalid grid parameters: levels=%d, bounds=%.4f-%.4f, quantity=%.6f",
+			levelCount, lowerBound, upperBound, quantity)
+	}
+
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	if len(ge.orderLevels) > 0 {
+		return fmt.Errorf("grid has %d outstanding orders; cancel them before rebuilding", len(ge.orderLevels))
+	}
+
+	prices := GridLevelPrices(lowerBound, upperBound, levelCount, ge.config.SpacingMode)
+	ge.symbol = symbol
+	ge.spacing = (upperBound - lowerBound) / float64(levelCount)
+	ge.center = (upperBound + lowerBound) / 2
+	if ge.config.SpacingMode.IsGeometric() {
+		// Levels get wider with price; the spacing and center are those of the geometric mid
+		ge.center = math.Sqrt(upperBound * lowerBound)
+		ge.spacing = ge.center * (prices[1]/prices[0] - 1)
+	}
+	ge.maxInventory = ge.config.MaxInventory
+	if limit, exists := ge.config.MaxInventoryBySymbol[symbol]; exists {
+		ge.maxInventory = limit
+	}
+	ge.quantity = quantity
+	ge.inventory = inventory
+	ge.levels = make([]*types.GridLevel, levelCount+1)
+	ge.levelFills = make(map[int]time.Time)
+
+	for i, price := range prices {
+		side := types.OrderSideBuy
+		if price > currentPrice {
+			side = types.OrderSideSell
+		}
+		level := types.NewGridLevel(fmt.Sprintf("%s-grid-%d", symbol, i), symbol, price, quantity, side)
+
+		// Leave the level closest to price empty so it isn't filled immediately
+		gap := prices[1] - prices[0]
+		if i > 0 {
+			gap = price - prices[i-1]
+		}
+		if math.Abs(price-currentPrice) < gap/2 {
+			level.Active = false
+		}
+		ge.levels[i] = level
+
+		tracker := ge.levelTracker(i)
+		tracker.stats.Price = price
+		tracker.stats.Allocation = price * quantity
+	}
+
+	if ge.config.MarketType.IsSpot() {
+		ge.limitSellsToInventory(currentPrice)
+	}
+
+	return nil
+}
+
+// limitSellsToInventory deactivates sell levels not covered by inventory, keeping those nearest to price
+func (ge *GridEngine) limitSellsToInventory(currentPrice float64) {
+	available := ge.inventory
+	for _, level := range ge.levels {
+		if level.Side != types.OrderSideSell || level.Price <= currentPrice || !level.Active {
+			continue
+		}
+		if available+types.DefaultQuantityEpsilon < level.Quantity {
+			level.Active = false
+			continue
+		}
+		available -= level.Quantity
+	}
+}
+
+// GetUnplacedLevels returns active levels that do not have an order yet. Levels on the side that adds
+// to accumulated inventory are pushed further from price, and held back once they would exceed the limit.
+func (ge *GridEngine) GetUnplacedLevels() []*types.GridLevel {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	committed := ge.committedInventory()
+	levels := make([]*types.GridLevel, 0)
+	for _, level := range ge.levels {
+		if level.Active && !level.Filled && level.OrderID == "" && !ge.hasPendingOrder(level.ID) {
+			copied := *level
+			if !ge.applySkew(&copied, committed) {
+				ge.skippedLevels++
+				continue
+			}
+			committed += signedQuantity(copied.Side, copied.Quantity)
+			levels = append(levels, &copied)
+		}
+	}
+	return levels
+}
+
+// PrioritizeLevels keeps the budget levels nearest to price, nearest first, when there are more levels than
+// orders may be opened; the rest wait until fills or cancellations free up room
+func (ge *GridEngine) PrioritizeLevels(levels []*types.GridLevel, price float64, budget int) []*types.GridLevel {
+	if budget >= len(levels) {
+		return levels
+	}
+	if budget < 0 {
+		budget = 0
+	}
+
+	prioritized := make([]*types.GridLevel, len(levels))
+	copy(prioritized, levels)
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		return math.Abs(prioritized[i].Price-price) < math.Abs(prioritized[j].Price-price)
+	})
+
+	ge.mu.Lock()
+	ge.deferredLevels += int64(len(levels) - budget)
+	ge.mu.Unlock()
+	return prioritized[:budget]
+}
+
+// AssignOrder links a new deterministic client order ID to a level before the order is placed;
+// the same ID must be reused when retrying the placement
+func (ge *GridEngine) AssignOrder(levelID string) (string, error) {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	index := ge.findLevel(levelID)
+	if index < 0 {
+		return "", fmt.Errorf("grid level %s not found", levelID)
+	}
+	ge.orderSeq++
+	clientOrderID := types.NewClientOrderID(gridOrderStrategy, index, ge.levels[index].Side, ge.orderSeq)
+	ge.orderLevels[clientOrderID] = index
+	return clientOrderID, nil
+}
+
+// ConfirmOrder records the exchange order ID once the order has been accepted
+func (ge *GridEngine) ConfirmOrder(clientOrderID, orderID string) {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	if clientOrderID == ge.unloadClientID {
+		ge.unloadOrderID = orderID
+		return
+	}
+
+	if index, exists := ge.orderLevels[clientOrderID]; exists {
+		ge.levels[index].OrderID = orderID
+	}
+}
+
+// ReleaseOrder unlinks an order that was rejected or cancelled and deactivates its level
+func (ge *GridEngine) ReleaseOrder(clientOrderID string) {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	if clientOrderID == ge.unloadClientID {
+		ge.unloadClientID, ge.unloadOrderID = "", ""
+		return
+	}
+
+	index, exists := ge.orderLevels[clientOrderID]
+	if !exists {
+		return
+	}
+	delete(ge.orderLevels, clientOrderID)
+	ge.levels[index].OrderID = ""
+	ge.levels[index].Active = false
+}
+
+// IsGridOrder returns true if the client order ID was generated by a grid engine, including released orders
+func IsGridOrder(clientOrderID string) bool {
+	return strings.HasPrefix(clientOrderID, gridOrderStrategy+"-")
+}
+
+// OrderTag returns the tag attributing a grid order to its level, e.g. "grid:level-3", or to the inventory
+// unload
+func (ge *GridEngine) OrderTag(clientOrderID string) string {
+	ge.mu.RLock()
+	defer ge.mu.RUnlock()
+
+	if index, exists := ge.orderLevels[clientOrderID]; exists {
+		return types.NewOrderTag(gridOrderStrategy, fmt.Sprintf("level-%d", index))
+	}
+	if clientOrderID != "" && clientOrderID == ge.unloadClientID {
+		return types.NewOrderTag(gridOrderStrategy, "unload")
+	}
+	return gridOrderStrategy
+}
+
+// OwnsOrder returns true if the client order ID belongs to a grid level
+func (ge *GridEngine) OwnsOrder(clientOrderID string) bool {
+	ge.mu.RLock()
+	defer ge.mu.RUnlock()
+
+	_, exists := ge.orderLevels[clientOrderID]
+	return exists || (clientOrderID != "" && clientOrderID == ge.unloadClientID)
+}
+
+// HandleFill applies a grid order fill and activates the counter level one spacing away.
+// Returns true if a new level is waiting to be placed.
+func (ge *GridEngine) HandleFill(update types.OrderUpdate) bool {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	if update.ClientOrderID != "" && update.ClientOrderID == ge.unloadClientID {
+		return ge.handleUnloadFill(update)
+	}
+
+	index, exists := ge.orderLevels[update.ClientOrderID]
+	if !exists || !update.IsFill() {
+		return false
+	}
+
+	// Track inventory on every partial fill
+	if update.Side == types.OrderSideBuy {
+		ge.inventory += update.LastFillQty
+	} else {
+		ge.inventory -= update.LastFillQty
+	}
+	ge.realizedPnL += update.RealizedPnL - update.Fee
+	tracker := ge.levelTracker(index)
+	tracker.stats.Volume += update.LastFillQty * update.LastFillPrice
+	tracker.stats.Profit += update.RealizedPnL - update.Fee
+
+	if update.Status != types.OrderStatusFilled {
+		return false
+	}
+
+	level := ge.levels[index]
+	level.MarkFilled(update.OrderID)
+	delete(ge.orderLevels, update.ClientOrderID)
+	ge.recordLevelFill(index, tracker, update)
+
+	ge.totalFills++
+	ge.lastFillTime = update.Timestamp
+	step := 1
+	counterSide := types.OrderSideSell
+	if update.Side == types.OrderSideBuy {
+		ge.buyFills++
+	} else {
+		ge.sellFills++
+		step = -1
+		counterSide = types.OrderSideBuy
+	}
+
+	// Re-arm the nearest free level on the opposite side (buy low, sell high)
+	counterIndex := index + step
+	for counterIndex >= 0 && counterIndex < len(ge.levels) && ge.isOccupied(ge.levels[counterIndex]) {
+		counterIndex += step
+	}
+	if counterIndex < 0 || counterIndex >= len(ge.levels) {
+		return false
+	}
+
+	counter := ge.levels[counterIndex]
+	counter.Side = counterSide
+	counter.Quantity = update.FilledQty
+	counter.Active = true
+	counter.Filled = false
+	counter.OrderID = ""
+	counter.FillTime = nil
+
+	return true
+}
+
+// Reset clears all levels and returns the exchange order IDs that must be cancelled
+func (ge *GridEngine) Reset() []string {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	orderIDs := make([]string, 0, len(ge.orderLevels)+1)
+	for _, index := range ge.orderLevels {
+		if orderID := ge.levels[index].OrderID; orderID != "" {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	if ge.unloadOrderID != "" {
+		orderIDs = append(orderIDs, ge.unloadOrderID)
+	}
+
+	ge.levels = make([]*types.GridLevel, 0)
+	ge.orderLevels = make(map[string]int)
+	ge.unloadClientID, ge.unloadOrderID = "", ""
+	return orderIDs
+}
+
+// levelTracker returns the statistics of a level index of the current grid; callers hold the lock
+func (ge *GridEngine) levelTracker(index int) *gridLevelTracker {
+	offset := index - (len(ge.levels)-1)/2
+	tracker, exists := ge.levelStats[offset]
+	if !exists {
+		tracker = &gridLevelTracker{stats: GridLevelStats{Offset: offset}}
+		ge.levelStats[offset] = tracker
+	}
+	return tracker
+}
+
+// recordLevelFill counts a completed level fill and the time since the level's previous fill; callers hold the lock
+func (ge *GridEngine) recordLevelFill(index int, tracker *gridLevelTracker, update types.OrderUpdate) {
+	at := update.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	tracker.stats.Fills++
+	if update.Side == types.OrderSideBuy {
+		tracker.stats.BuyFills++
+	} else {
+		tracker.stats.SellFills++
+	}
+	if previous, exists := ge.levelFills[index]; exists && at.After(previous) {
+		tracker.stats.Refills++
+		tracker.refillTotal += at.Sub(previous)
+	}
+	ge.levelFills[index] = at
+	tracker.stats.LastFill = at
+}
+
+// GetLevelStats returns the fill statistics of every level offset seen this session, lowest level first
+func (ge *GridEngine) GetLevelStats() []GridLevelStats {
+	ge.mu.RLock()
+	defer ge.mu.RUnlock()
+
+	stats := make([]GridLevelStats, 0, len(ge.levelStats))
+	for _, tracker := range ge.levelStats {
+		level := tracker.stats
+		if level.Refills > 0 {
+			level.AvgRefill = tracker.refillTotal / time.Duration(level.Refills)
+		}
+		stats = append(stats, level)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Offset < stats[j].Offset })
+	return stats
+}
+
+// GetInventory returns the net base inventory accumulated from grid fills
+func (ge *GridEngine) GetInventory() float64 {
+	ge.mu.RLock()
+	defer ge.mu.RUnlock()
+	return ge.inventory
+}
+
+// GetLevels returns a copy of the current grid levels
+func (ge *GridEngine) GetLevels() []types.GridLevel {
+	ge.mu.RLock()
+	defer ge.mu.RUnlock()
+
+	levels := make([]types.GridLevel, len(ge.levels))
+	for i, level := range ge.levels {
+		levels[i] = *level
+	}
+	return levels
+}
+
+// GetGridStats returns grid engine statistics
+func (ge *GridEngine) GetGridStats() map[string]interface{} {
+	ge.mu.RLock()
+	defer ge.mu.RUnlock()
+
+	activeLevels := 0
+	for _, level := range ge.levels {
+		if level.Active && !level.Filled {
+			activeLevels++
+		}
+	}
+
+	return map[string]interface{}{
+		"market_type":     ge.config.MarketType,
+		"symbol":          ge.symbol,
+		"levels":          len(ge.levels),
+		"active_levels":   activeLevels,
+		"open_orders":     len(ge.orderLevels),
+		"spacing":         ge.spacing,
+		"spacing_mode":    ge.config.SpacingMode,
+		"level_quantity":  ge.quantity,
+		"inventory":       ge.inventory,
+		"max_inventory":   ge.maxInventory,
+		"inventory_ratio": ge.inventoryRatio(),
+		"unloads":         ge.unloads,
+		"unload_pending":  ge.unloadClientID != "",
+		"skipped_levels":  ge.skippedLevels,
+		"deferred_levels": ge.deferredLevels,
+		"total_fills":     ge.totalFills,
+		"buy_fills":       ge.buyFills,
+		"sell_fills":      ge.sellFills,
+		"realized_pnl":    ge.realizedPnL,
+		"last_fill_time":  ge.lastFillTime,
+	}
+}
+
+// findLevel returns the index of a level by ID, or -1
+func (ge *GridEngine) findLevel(levelID string) int {
+	for i, level := range ge.levels {
+		if level.ID == levelID {
+			return i
+		}
+	}
+	return -1
+}
+
+// isOccupied returns true if the level already has a live order
+func (ge *GridEngine) isOccupied(level *types.GridLevel) bool {
+	return level.Active && !level.Filled && (level.OrderID != "" || ge.hasPendingOrder(level.ID))
+}
+
+// hasPendingOrder returns true if a client order is already linked to the level
+func (ge *GridEngine) hasPendingOrder(levelID string) bool {
+	for _, index := range ge.orderLevels {
+		if ge.levels[index].ID == levelID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"math"
+)
+
+// gridUnloadStrategy is the strategy name embedded in client order IDs of inventory unload orders
+const gridUnloadStrategy = gridOrderStrategy + "-unload"
+
+// NextUnloadOrder returns a limit order at the grid mid that unloads part of the inventory once it
+// reaches the unload threshold, or nil if no unload is needed or one is already pending
+func (ge *GridEngine) NextUnloadOrder() *types.Order {
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	if ge.maxInventory <= 0 || ge.unloadClientID != "" || ge.center <= 0 {
+		return nil
+	}
+	if math.Abs(ge.inventory) < ge.config.UnloadThreshold*ge.maxInventory {
+		return nil
+	}
+
+	side := types.OrderSideSell
+	if ge.inventory < 0 {
+		side = types.OrderSideBuy
+	}
+	quantity := math.Abs(ge.inventory) * ge.config.UnloadFraction
+
+	ge.orderSeq++
+	ge.unloadClientID = types.NewClientOrderID(gridUnloadStrategy, 0, side, ge.orderSeq)
+
+	order := types.NewLimitOrder("", ge.symbol, side, quantity, ge.center, "")
+	order.ClientOrderID = ge.unloadClientID
+	order.Tag = types.NewOrderTag(gridOrderStrategy, "unload")
+	return order
+}
+
+// handleUnloadFill applies a fill of the unload order and returns true once it is complete,
+// since the lower inventory may free levels held back by the limit; caller must hold ge.mu
+func (ge *GridEngine) handleUnloadFill(update types.OrderUpdate) bool {
+	if !update.IsFill() {
+		return false
+	}
+	ge.inventory += signedQuantity(update.Side, update.LastFillQty)
+	ge.realizedPnL += update.RealizedPnL - update.Fee
+
+	if update.Status != types.OrderStatusFilled {
+		return false
+	}
+	ge.unloads++
+	ge.unloadClientID, ge.unloadOrderID = "", ""
+	return true
+}
+
+// applySkew widens spacing for a level that adds to the heavy side and returns false if placing it
+// would push inventory past the limit; committed includes inventory and pending grid orders
+func (ge *GridEngine) applySkew(level *types.GridLevel, committed float64) bool {
+	if ge.maxInventory <= 0 {
+		return true
+	}
+
+	ratio := ge.inventoryRatio()
+	addsToHeavySide := (ratio > 0 && level.Side == types.OrderSideBuy) || (ratio < 0 && level.Side == types.OrderSideSell)
+	if !addsToHeavySide {
+		return true
+	}
+
+	if math.Abs(committed+signedQuantity(level.Side, level.Quantity)) > ge.maxInventory+types.DefaultQuantityEpsilon {
+		return false
+	}
+
+	shift := ge.spacing * ge.config.SkewSpacingFactor * math.Abs(ratio)
+	if level.Side == types.OrderSideBuy {
+		level.Price -= shift
+	} else {
+		level.Price += shift
+	}
+	return level.Price > 0
+}
+
+// committedInventory returns inventory plus the quantity of resting grid orders; caller must hold ge.mu
+func (ge *GridEngine) committedInventory() float64 {
+	committed := ge.inventory
+	for _, index := range ge.orderLevels {
+		level := ge.levels[index]
+		committed += signedQuantity(level.Side, level.Quantity)
+	}
+	return committed
+}
+
+// inventoryRatio returns inventory as a fraction of the limit, clamped to [-1, 1]; caller must hold ge.mu
+func (ge *GridEngine) inventoryRatio() float64 {
+	if ge.maxInventory <= 0 {
+		return 0
+	}
+	return math.Max(-1, math.Min(1, ge.inventory/ge.maxInventory))
+}
+
+// signedQuantity returns quantity as a positive inventory change for buys and negative for sells
+func signedQuantity(side types.OrderSide, quantity float64) float64 {
+	if side == types.OrderSideSell {
+		return -quantity
+	}
+	return quantity
+}
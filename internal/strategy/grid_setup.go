@@ -402,7 +402,7 @@ func (gs *GridSetup) GetRecommendedGridLevels(volatility float64) int {
 
 // ShouldSetupGrid determines if conditions are suitable for grid setup
 func (gs *GridSetup) ShouldSetupGrid(symbol string) (bool, string) {
-	candles := gs.aggregator.GetCandles(symbol, gs.config.AnalysisTimeframe, 50)
+	candles := gs.aggregator.GetCandles(symbol, gs.config.AnalysisTimeframe, gs.config.MinHistoryCandles)
 	if len(candles) < gs.config.MinHistoryCandles {
 		return false, "insufficient_data"
 	}
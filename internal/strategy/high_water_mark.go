@@ -0,0 +1,47 @@
+package strategy
+
+import (
+	"time"
+)
+
+// EquityHighWaterMark is the highest equity an account has reached, checkpointed so drawdown is measured
+// against the account's history rather than the peak of the current process
+type EquityHighWaterMark struct {
+	Account            string    `json:"account"`
+	Peak               float64   `json:"peak"`
+	PeakAt             time.Time `json:"peak_at"`
+	MaxDrawdownReached float64   `json:"max_drawdown_reached"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// HighWaterMark returns the equity peak drawdown is currently measured from
+func (rm *RiskManager) HighWaterMark() EquityHighWaterMark {
+	rm.deRiskMu.RLock()
+	defer rm.deRiskMu.RUnlock()
+
+	return EquityHighWaterMark{
+		Peak:               rm.equityPeak,
+		PeakAt:             rm.equityPeakAt,
+		MaxDrawdownReached: rm.MaxDrawdownReached,
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// RestoreHighWaterMark continues from a checkpointed high-water mark; a peak below the current one is
+// ignored, so the initial balance still bounds drawdown of a fresh account. Returns false if nothing changed.
+func (rm *RiskManager) RestoreHighWaterMark(hwm EquityHighWaterMark) bool {
+	rm.deRiskMu.Lock()
+	defer rm.deRiskMu.Unlock()
+
+	restored := false
+	if hwm.Peak > rm.equityPeak {
+		rm.equityPeak = hwm.Peak
+		rm.equityPeakAt = hwm.PeakAt
+		restored = true
+	}
+	if hwm.MaxDrawdownReached > rm.MaxDrawdownReached {
+		rm.MaxDrawdownReached = hwm.MaxDrawdownReached
+		restored = true
+	}
+	return restored
+}
@@ -0,0 +1,165 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LeverageTier caps leverage while realized volatility is at or above its threshold
+type LeverageTier struct {
+	Volatility float64 `json:"volatility"` // Daily realized volatility that activates the tier
+	Leverage   float64 `json:"leverage"`   // Leverage cap while the tier is active
+}
+
+// LeverageControlConfig holds the volatility tiers of the dynamic leverage controller
+type LeverageControlConfig struct {
+	Enabled       bool           `json:"enabled"`        // Disabled controllers always allow the default leverage
+	Window        int            `json:"window"`         // Candles in the rolling realized volatility (80)
+	Tiers         []LeverageTier `json:"tiers"`          // Volatility thresholds and their leverage caps (5% -> 3x, 8% -> 1x)
+	RestoreBuffer float64        `json:"restore_buffer"` // Volatility must fall this fraction below a threshold to leave its tier (0.1)
+}
+
+// LeverageChange describes an effective leverage change for the orchestrator to apply on the exchange
+type LeverageChange struct {
+	From       float64   `json:"from"`
+	To         float64   `json:"to"`
+	Volatility float64   `json:"volatility"`
+	Threshold  float64   `json:"threshold"` // Threshold of the tier entered or left
+	Reason     string    `json:"reason"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// LeverageController lowers leverage while realized volatility runs above configured thresholds and
+// restores it once volatility has normalized, with a buffer so it does not flap around a threshold
+type LeverageController struct {
+	config       LeverageControlConfig
+	baseLeverage float64
+
+	tier       int // 0 is the base leverage, i is Tiers[i-1]
+	volatility float64
+	changes    int64
+	lastChange time.Time
+
+	mu sync.RWMutex
+}
+
+// NewLeverageController creates a leverage controller around the default leverage
+func NewLeverageController(config LeverageControlConfig, baseLeverage float64) *LeverageController {
+	if config.Window == 0 {
+		config.Window = 80 // default
+	}
+	if len(config.Tiers) == 0 {
+		config.Tiers = []LeverageTier{{Volatility: 0.05, Leverage: 3}, {Volatility: 0.08, Leverage: 1}} // default
+	}
+	if config.RestoreBuffer == 0 {
+		config.RestoreBuffer = 0.1 // default
+	}
+
+	tiers := append([]LeverageTier(nil), config.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].Volatility < tiers[j].Volatility })
+	config.Tiers = tiers
+
+	return &LeverageController{
+		config:       config,
+		baseLeverage: baseLeverage,
+	}
+}
+
+// Window returns the number of candles the realized volatility should be measured over
+func (lc *LeverageController) Window() int {
+	return lc.config.Window
+}
+
+// Update records the latest realized volatility and returns the change when the effective leverage moves
+func (lc *LeverageController) Update(volatility float64) *LeverageChange {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.volatility = volatility
+	if !lc.config.Enabled || volatility <= 0 {
+		return nil
+	}
+
+	target := 0
+	for i, tier := range lc.config.Tiers {
+		if volatility >= tier.Volatility {
+			target = i + 1
+		}
+	}
+	// Leaving a tier needs volatility clearly below its threshold
+	if target < lc.tier && volatility >= lc.config.Tiers[lc.tier-1].Volatility*(1-lc.config.RestoreBuffer) {
+		return nil
+	}
+	if target == lc.tier {
+		return nil
+	}
+
+	from := lc.leverageAt(lc.tier)
+	change := &LeverageChange{
+		From:       from,
+		To:         lc.leverageAt(target),
+		Volatility: volatility,
+		Timestamp:  time.Now(),
+	}
+	if target > lc.tier {
+		change.Threshold = lc.config.Tiers[target-1].Volatility
+		change.Reason = fmt.Sprintf("realized volatility %.2f%% reached %.2f%%", volatility*100, change.Threshold*100)
+	} else {
+		change.Threshold = lc.config.Tiers[lc.tier-1].Volatility
+		change.Reason = fmt.Sprintf("realized volatility %.2f%% normalized below %.2f%%", volatility*100, change.Threshold*100)
+	}
+	lc.tier = target
+	if change.To == change.From {
+		return nil
+	}
+	lc.changes++
+	lc.lastChange = change.Timestamp
+	return change
+}
+
+// Revert undoes a change the exchange rejected so the next update retries it
+func (lc *LeverageController) Revert(change *LeverageChange) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for tier := 0; tier <= len(lc.config.Tiers); tier++ {
+		if lc.leverageAt(tier) == change.From {
+			lc.tier = tier
+			return
+		}
+	}
+}
+
+// Leverage returns the effective leverage
+func (lc *LeverageController) Leverage() float64 {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.leverageAt(lc.tier)
+}
+
+// leverageAt returns the leverage of a tier, never above the base leverage. Callers hold the lock.
+func (lc *LeverageController) leverageAt(tier int) float64 {
+	if tier == 0 {
+		return lc.baseLeverage
+	}
+	return math.Min(lc.baseLeverage, lc.config.Tiers[tier-1].Leverage)
+}
+
+// GetLeverageStats returns leverage controller statistics
+func (lc *LeverageController) GetLeverageStats() map[string]interface{} {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	return map[string]interface{}{
+		"enabled":            lc.config.Enabled,
+		"base_leverage":      lc.baseLeverage,
+		"effective_leverage": lc.leverageAt(lc.tier),
+		"tier":               lc.tier,
+		"volatility":         lc.volatility,
+		"changes":            lc.changes,
+		"last_change":        lc.lastChange,
+	}
+}
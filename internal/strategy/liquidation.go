@@ -0,0 +1,62 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"math"
+)
+
+// Margin modes used for liquidation estimates
+const (
+	MarginModeCross    = "cross"    // The whole account balance backs every position
+	MarginModeIsolated = "isolated" // Each position is backed only by its own margin
+)
+
+// LiquidationEstimate is the estimated liquidation price of an open position
+type LiquidationEstimate struct {
+	Symbol           string             `json:"symbol"`
+	PositionType     types.PositionType `json:"position_type"`
+	Size             float64            `json:"size"`
+	EntryPrice       float64            `json:"entry_price"`
+	MarkPrice        float64            `json:"mark_price"`
+	Leverage         float64            `json:"leverage"`
+	Collateral       float64            `json:"collateral"`        // Margin backing the position, in the margin currency
+	LiquidationPrice float64            `json:"liquidation_price"` // 0 if the position cannot be liquidated
+	Distance         float64            `json:"distance"`          // Adverse move from the mark price to liquidation, as a fraction
+	NearLiquidation  bool               `json:"near_liquidation"`  // Within the liquidation buffer
+}
+
+// EstimateLiquidation estimates where a position is liquidated. In cross margin the wallet balance
+// (equity without the position's unrealized PnL) backs it, assuming other positions stay flat; in
+// isolated margin only the margin set by its leverage does.
+func (rm *RiskManager) EstimateLiquidation(position *types.Position, equity float64) LiquidationEstimate {
+	estimate := LiquidationEstimate{
+		Symbol:       position.Symbol,
+		PositionType: position.Type,
+		Size:         position.Size,
+		EntryPrice:   position.EntryPrice,
+		MarkPrice:    position.MarkPrice,
+		Leverage:     position.Leverage,
+	}
+
+	contract := position.Contract()
+	if rm.MarginMode == MarginModeIsolated || equity <= 0 {
+		leverage := position.Leverage
+		if leverage <= 0 {
+			leverage = rm.DefaultLeverage
+		}
+		estimate.Collateral = contract.Notional(position.Size, position.EntryPrice) / leverage
+	} else {
+		estimate.Collateral = equity - position.UnrealizedPnL
+	}
+
+	estimate.LiquidationPrice = contract.LiquidationPrice(position.Type, position.Size, position.EntryPrice,
+		estimate.Collateral, rm.MaintenanceMarginRate)
+	if estimate.LiquidationPrice > 0 && position.MarkPrice > 0 {
+		estimate.Distance = math.Max(0, (position.MarkPrice-estimate.LiquidationPrice)/position.MarkPrice)
+		if position.Type == types.PositionTypeShort {
+			estimate.Distance = math.Max(0, (estimate.LiquidationPrice-position.MarkPrice)/position.MarkPrice)
+		}
+		estimate.NearLiquidation = estimate.Distance <= rm.LiquidationBuffer
+	}
+	return estimate
+}
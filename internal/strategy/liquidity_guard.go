@@ -0,0 +1,135 @@
+package strategy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LiquidityGuardConfig holds the order book thresholds below which orders are not placed
+type LiquidityGuardConfig struct {
+	MaxSpread     float64       `json:"max_spread"`     // Bid/ask spread as a fraction of the mid price (0 = no limit)
+	MinDepth      float64       `json:"min_depth"`      // Quote notional at both the best bid and the best ask (0 = no limit)
+	RetryInterval time.Duration `json:"retry_interval"` // How often deferred orders re-check the book (5s)
+}
+
+// LiquidityCheck is the result of checking the top of the book
+type LiquidityCheck struct {
+	Allowed   bool      `json:"allowed"`
+	Reason    string    `json:"reason,omitempty"` // Why placement is blocked, "" when allowed
+	Spread    float64   `json:"spread"`           // Fraction of the mid price
+	BidDepth  float64   `json:"bid_depth"`        // Quote notional at the best bid
+	AskDepth  float64   `json:"ask_depth"`        // Quote notional at the best ask
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// LiquidityGuard keeps orders out of thin markets, where wide spreads and a shallow book give bad fills
+type LiquidityGuard struct {
+	config LiquidityGuardConfig
+	last   map[string]LiquidityCheck // Last check per symbol
+
+	// Statistics
+	checks  int64
+	blocked int64
+	thinned int64 // Transitions from normal to thin liquidity
+
+	mu sync.RWMutex
+}
+
+// NewLiquidityGuard creates a liquidity guard
+func NewLiquidityGuard(config LiquidityGuardConfig) *LiquidityGuard {
+	if config.RetryInterval == 0 {
+		config.RetryInterval = 5 * time.Second // default
+	}
+
+	return &LiquidityGuard{
+		config: config,
+		last:   make(map[string]LiquidityCheck),
+	}
+}
+
+// Enabled returns true if any threshold is configured
+func (g *LiquidityGuard) Enabled() bool {
+	return g.config.MaxSpread > 0 || g.config.MinDepth > 0
+}
+
+// Evaluate checks the best bid and ask of a symbol against the thresholds and reports whether the
+// result changed between allowed and blocked. Depth is not judged when the quote carries no sizes.
+func (g *LiquidityGuard) Evaluate(symbol string, bid, ask, bidSize, askSize float64, now time.Time) (LiquidityCheck, bool) {
+	check := LiquidityCheck{
+		Allowed:   true,
+		BidDepth:  bid * bidSize,
+		AskDepth:  ask * askSize,
+		Timestamp: now,
+	}
+	if mid := (bid + ask) / 2; mid > 0 && ask >= bid {
+		check.Spread = (ask - bid) / mid
+	}
+
+	switch {
+	case bid <= 0 || ask <= 0 || ask < bid:
+		check.Allowed = false
+		check.Reason = fmt.Sprintf("no valid quote (bid %.8g, ask %.8g)", bid, ask)
+	case g.config.MaxSpread > 0 && check.Spread > g.config.MaxSpread:
+		check.Allowed = false
+		check.Reason = fmt.Sprintf("spread %.4f%% above %.4f%%", check.Spread*100, g.config.MaxSpread*100)
+	case g.config.MinDepth > 0 && (bidSize > 0 || askSize > 0) && (check.BidDepth < g.config.MinDepth || check.AskDepth < g.config.MinDepth):
+		check.Allowed = false
+		check.Reason = fmt.Sprintf("top of book %.2f/%.2f below %.2f", check.BidDepth, check.AskDepth, g.config.MinDepth)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	previous, seen := g.last[symbol]
+	g.last[symbol] = check
+	g.checks++
+	if !check.Allowed {
+		g.blocked++
+	}
+
+	changed := (seen && previous.Allowed != check.Allowed) || (!seen && !check.Allowed)
+	if changed && !check.Allowed {
+		g.thinned++
+	}
+	return check, changed
+}
+
+// IsThin returns true if the last check of a symbol blocked placement
+func (g *LiquidityGuard) IsThin(symbol string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	check, ok := g.last[symbol]
+	return ok && !check.Allowed
+}
+
+// RetryDue returns true if placement for a symbol was blocked and the book is due to be checked again
+func (g *LiquidityGuard) RetryDue(symbol string, now time.Time) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	check, ok := g.last[symbol]
+	return ok && !check.Allowed && now.Sub(check.Timestamp) >= g.config.RetryInterval
+}
+
+// GetLiquidityGuardStats returns liquidity guard statistics
+func (g *LiquidityGuard) GetLiquidityGuardStats() map[string]interface{} {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	last := make(map[string]LiquidityCheck, len(g.last))
+	for symbol, check := range g.last {
+		last[symbol] = check
+	}
+
+	return map[string]interface{}{
+		"enabled":    g.Enabled(),
+		"max_spread": g.config.MaxSpread,
+		"min_depth":  g.config.MinDepth,
+		"checks":     g.checks,
+		"blocked":    g.blocked,
+		"thinned":    g.thinned,
+		"last":       last,
+	}
+}
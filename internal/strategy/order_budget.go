@@ -0,0 +1,121 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"sync"
+)
+
+// OrderBudgetConfig holds the caps on simultaneously open orders; exchanges reject orders beyond theirs
+type OrderBudgetConfig struct {
+	MaxOpenOrders          int            `json:"max_open_orders"`            // Open orders across all symbols (0 = unlimited)
+	MaxOpenOrdersPerSymbol int            `json:"max_open_orders_per_symbol"` // Open orders per symbol (0 = unlimited)
+	MaxOpenOrdersBySymbol  map[string]int `json:"max_open_orders_by_symbol"`  // Per-symbol overrides of MaxOpenOrdersPerSymbol
+}
+
+// OrderBudget tracks how many more orders may rest on the exchange
+type OrderBudget struct {
+	config OrderBudgetConfig
+
+	// Statistics
+	checks    int64
+	exhausted int64 // Checks that found no room left
+	deferred  int64 // Orders held back for lack of room
+	lastOpen  map[string]int
+	lastTotal int
+
+	mu sync.Mutex
+}
+
+// NewOrderBudget creates an order budget
+func NewOrderBudget(config OrderBudgetConfig) *OrderBudget {
+	return &OrderBudget{
+		config:   config,
+		lastOpen: make(map[string]int),
+	}
+}
+
+// Enabled returns true if any cap is configured
+func (b *OrderBudget) Enabled() bool {
+	return b.config.MaxOpenOrders > 0 || b.config.MaxOpenOrdersPerSymbol > 0 || len(b.config.MaxOpenOrdersBySymbol) > 0
+}
+
+// SymbolLimit returns the open order cap of a symbol, 0 if unlimited
+func (b *OrderBudget) SymbolLimit(symbol string) int {
+	if limit, exists := b.config.MaxOpenOrdersBySymbol[symbol]; exists {
+		return limit
+	}
+	return b.config.MaxOpenOrdersPerSymbol
+}
+
+// Available returns how many more orders of a symbol may be opened given the orders already open across
+// all symbols; the second result is false if neither cap applies
+func (b *OrderBudget) Available(symbol string, open []*types.Order) (int, bool) {
+	total, forSymbol := 0, 0
+	for _, order := range open {
+		if !order.IsActive() {
+			continue
+		}
+		total++
+		if order.Symbol == symbol {
+			forSymbol++
+		}
+	}
+
+	available, limited := 0, false
+	if limit := b.SymbolLimit(symbol); limit > 0 {
+		available, limited = limit-forSymbol, true
+	}
+	if b.config.MaxOpenOrders > 0 {
+		if room := b.config.MaxOpenOrders - total; !limited || room < available {
+			available = room
+		}
+		limited = true
+	}
+	if available < 0 {
+		available = 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.checks++
+	b.lastOpen[symbol] = forSymbol
+	b.lastTotal = total
+	if limited && available == 0 {
+		b.exhausted++
+	}
+	return available, limited
+}
+
+// RecordDeferred counts orders that were not placed because the budget ran out
+func (b *OrderBudget) RecordDeferred(count int) {
+	if count <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.deferred += int64(count)
+}
+
+// GetOrderBudgetStats returns order budget statistics
+func (b *OrderBudget) GetOrderBudgetStats() map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	open := make(map[string]int, len(b.lastOpen))
+	for symbol, count := range b.lastOpen {
+		open[symbol] = count
+	}
+
+	return map[string]interface{}{
+		"enabled":                    b.Enabled(),
+		"max_open_orders":            b.config.MaxOpenOrders,
+		"max_open_orders_per_symbol": b.config.MaxOpenOrdersPerSymbol,
+		"open_orders":                b.lastTotal,
+		"open_orders_by_symbol":      open,
+		"checks":                     b.checks,
+		"exhausted":                  b.exhausted,
+		"deferred":                   b.deferred,
+	}
+}
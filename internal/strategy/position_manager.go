@@ -16,9 +16,11 @@ type PositionManager struct {
 	TakeProfitPercent   float64 `json:"take_profit_percent"`   // Take profit percentage
 	RiskPerPosition    float64 `json:"risk_per_position"`    // Risk per position (2%)
 	PartialCloseRatio   float64 `json:"partial_close_ratio"`   // Partial close ratio (50%)
+	HedgeMode           bool    `json:"hedge_mode"`            // Track long and short sides of a symbol independently
+	Contracts           map[string]types.ContractSpec `json:"contracts"` // Symbol -> contract; linear if not listed
 
 	// State tracking
-	positions          map[string]*PositionState `json:"positions"`          // Current positions by symbol
+	positions          map[string]*PositionState `json:"positions"`          // Current positions by symbol, or by symbol side in hedge mode
 	gridStrategies      map[string]*GridState    `json:"grid_strategies"`      // Active grid strategies
 	breakoutPositions   map[string]*BreakoutState `json:"breakout_positions"`   // Active breakout positions
 	positionHistory     []PositionEvent           `json:"position_history"`     // All position events
@@ -28,7 +30,11 @@ type PositionManager struct {
 	dailyLossLimit      float64                     `json:"daily_loss_limit"`
 	positionCounter     int64                       `json:"position_counter"`
 	TimeoutHours        int                         `json:"timeout_hours"`
+	AgedUnwind          AgedUnwindConfig            `json:"aged_unwind"`    // How positions past the timeout are unwound
 	TrailingStopPercent float64                     `json:"trailing_stop_percent"`
+	ExchangeExits       bool                        `json:"exchange_exits"`         // Stop losses and take profits rest on the exchange where supported
+	ExchangeTrailingStop float64                    `json:"exchange_trailing_stop"` // Exchange stop losses trail the price by this fraction (0 rests them at the stop)
+	unwinds             map[string]*agedUnwind      // Stepped unwinds in progress by position key
 
 	// Performance tracking
 	totalProfit         float64                     `json:"total_profit"`
@@ -81,6 +87,36 @@ type CloseTrigger struct {
 	Reason       string       `json:"reason"`       // Trigger reason
 	Executed     bool         `json:"executed"`     // Whether triggered
 	PositionSize float64      `json:"position_size"` // Size at trigger time
+	OrderID      string       `json:"order_id,omitempty"` // Exchange order executing the trigger; not fired locally while set
+}
+
+// ExchangeOrder returns a reduce-only order that lets the exchange execute this trigger for a position, or nil
+// for triggers without an exchange-native equivalent. Stop losses trigger on the mark price and become trailing
+// stops when trailingPercent is set; take profits rest as limit orders.
+func (t CloseTrigger) ExchangeOrder(position *types.Position, trailingPercent float64) *types.Order {
+	side := types.OrderSideSell
+	if position.Type == types.PositionTypeShort {
+		side = types.OrderSideBuy
+	}
+
+	var order *types.Order
+	switch t.Type {
+	case TriggerStopLoss:
+		if trailingPercent > 0 {
+			order = types.NewTrailingStopOrder("", position.Symbol, side, position.Size, trailingPercent, 0, position.Type)
+		} else {
+			order = types.NewOrder("", position.Symbol, side, types.OrderTypeStop, position.Size, 0, position.Type)
+			order.SetStopPrice(t.Price)
+		}
+		order.TriggerPrice = types.TriggerPriceMark
+	case TriggerTakeProfit:
+		order = types.NewLimitOrder("", position.Symbol, side, position.Size, t.Price, position.Type)
+	default:
+		return nil
+	}
+	order.SetReduceOnly(true)
+	order.Tag = types.NewOrderTag("breakout", "exit-"+string(t.Type))
+	return order
 }
 
 // TriggerType represents different types of close triggers
@@ -92,6 +128,9 @@ const (
 	TriggerGridBreach  TriggerType = "grid_breach"
 	TriggerTimeout    TriggerType = "timeout"
 	TriggerFalseBreakout TriggerType = "false_breakout"
+	TriggerOrderFill    TriggerType = "order_fill"
+	TriggerStability    TriggerType = "stability"
+	TriggerResync       TriggerType = "resync"
 )
 
 // PositionEvent represents a significant position event
@@ -118,7 +157,12 @@ type PositionManagerConfig struct {
 	PartialCloseRatio     float64 `json:"partial_close_ratio"`     // Partial close ratio (50%)
 	MaxDailyLoss         float64 `json:"max_daily_loss"`         // Maximum daily loss (5%)
 	TimeoutHours          int     `json:"timeout_hours"`          // Position timeout (24h)
+	AgedUnwind            AgedUnwindConfig `json:"aged_unwind"`     // Unwind policy of positions past the timeout (market)
 	TrailingStopPercent   float64 `json:"trailing_stop_percent"`   // Trailing stop % (1%)
+	ExchangeExits         bool    `json:"exchange_exits"`          // Leave stop losses and take profits to exchange orders where supported
+	ExchangeTrailingStop  float64 `json:"exchange_trailing_stop"`  // Exchange stop losses trail by this fraction (0 rests them at the stop)
+	HedgeMode             bool    `json:"hedge_mode"`              // Separate long and short positions per symbol
+	Contracts             map[string]types.ContractSpec `json:"contracts"` // Symbol -> contract; linear if not listed
 }
 
 // NewPositionManager creates a new position manager
@@ -151,6 +195,15 @@ func NewPositionManager(config PositionManagerConfig) *PositionManager {
 	if config.TrailingStopPercent == 0 {
 		config.TrailingStopPercent = 0.01 // 1%
 	}
+	if config.AgedUnwind.Policy == "" {
+		config.AgedUnwind.Policy = UnwindMarket // default
+	}
+	if config.AgedUnwind.StepFraction == 0 {
+		config.AgedUnwind.StepFraction = 0.25 // default
+	}
+	if config.AgedUnwind.StepInterval == 0 {
+		config.AgedUnwind.StepInterval = time.Hour // default
+	}
 
 	return &PositionManager{
 		MaxPositionSize:   config.MaxPositionSize,
@@ -161,7 +214,13 @@ func NewPositionManager(config PositionManagerConfig) *PositionManager {
 		PartialCloseRatio: config.PartialCloseRatio,
 		dailyLossLimit:    config.MaxDailyLoss,
 		TimeoutHours:      config.TimeoutHours,
+		AgedUnwind:        config.AgedUnwind,
+		unwinds:           make(map[string]*agedUnwind),
 		TrailingStopPercent: config.TrailingStopPercent,
+		ExchangeExits:     config.ExchangeExits,
+		ExchangeTrailingStop: config.ExchangeTrailingStop,
+		HedgeMode:         config.HedgeMode,
+		Contracts:         config.Contracts,
 		positions:        make(map[string]*PositionState),
 		gridStrategies:    make(map[string]*GridState),
 		breakoutPositions: make(map[string]*BreakoutState),
@@ -185,6 +244,7 @@ func (pm *PositionManager) OpenGridPosition(symbol string, positionType types.Po
 	// Create new position
 	positionID := fmt.Sprintf("%s_%d", symbol, pm.positionCounter)
 	position := types.NewPosition(positionID, symbol, positionType, quantity, price, 1.0) // Default 1x leverage
+	position.SetContract(pm.Contracts[symbol])
 
 	// Calculate stop loss and take profit
 	stopLossPrice := pm.calculateStopLoss(positionType, price)
@@ -203,9 +263,9 @@ func (pm *PositionManager) OpenGridPosition(symbol string, positionType types.Po
 	// Set up close triggers
 	state.CloseTriggers = pm.setupGridTriggers(positionType, price, stopLossPrice, takeProfitPrice)
 
-	pm.positions[symbol] = state
+	pm.positions[pm.PositionKey(symbol, positionType)] = state
 	pm.positionCounter++
-	pm.totalRiskExposure += (quantity * price) / 100 // Convert to account units
+	pm.totalRiskExposure += position.Contract().Notional(quantity, price) / 100 // Convert to account units
 
 	// Record event
 	pm.recordEvent("open", symbol, positionID, string(positionType), quantity, price, 0, "Grid position opened", "")
@@ -241,7 +301,7 @@ func (pm *PositionManager) OpenBreakoutPosition(symbol string, breakoutType Brea
 	}
 
 	// Mark as breakout position
-	if state, exists := pm.positions[symbol]; exists {
+	if state, exists := pm.positions[pm.PositionKey(symbol, positionType)]; exists {
 		state.Notes = append(state.Notes, "Breakout position (immediate 50%)")
 	}
 
@@ -259,7 +319,7 @@ func (pm *PositionManager) CompleteBreakoutPosition(symbol string, remainingQuan
 	}
 
 	// Update notes
-	if state, exists := pm.positions[symbol]; exists {
+	if state, exists := pm.lookup(symbol); exists {
 		state.Notes = append(state.Notes, "Breakout position completed (final 50%)")
 	}
 
@@ -268,7 +328,7 @@ func (pm *PositionManager) CompleteBreakoutPosition(symbol string, remainingQuan
 
 // AddToPosition adds to an existing position
 func (pm *PositionManager) AddToPosition(symbol string, quantity, price float64) (*types.OrderResult, error) {
-	state, exists := pm.positions[symbol]
+	state, exists := pm.lookup(symbol)
 	if !exists {
 		return nil, fmt.Errorf("no position found for symbol %s", symbol)
 	}
@@ -279,14 +339,12 @@ func (pm *PositionManager) AddToPosition(symbol string, quantity, price float64)
 	}
 
 	// Average entry price calculation
-	currentValue := state.Position.Size * state.Position.EntryPrice
-	additionalValue := quantity * price
-	newSize := state.Position.Size + quantity
-	newEntryPrice := (currentValue + additionalValue) / newSize
+	newEntryPrice := state.Position.Contract().AverageEntry(state.Position.Size, state.Position.EntryPrice, quantity, price)
 
 	// Update position
-	state.Position.Size = newSize
+	state.Position.Size += quantity
 	state.Position.EntryPrice = newEntryPrice
+	state.Position.UpdateMargin()
 	state.Position.UpdateMarkPrice(price)
 	state.LastUpdate = time.Now()
 
@@ -316,23 +374,25 @@ func (pm *PositionManager) AddToPosition(symbol string, quantity, price float64)
 
 // ClosePosition closes a position with specified parameters
 func (pm *PositionManager) ClosePosition(symbol string, quantity float64, price float64, reason string, triggerType TriggerType) (*types.OrderResult, error) {
-	state, exists := pm.positions[symbol]
+	state, exists := pm.lookup(symbol)
 	if !exists {
 		return nil, fmt.Errorf("no position found for symbol %s", symbol)
 	}
 
-	// Validate quantity
-	if quantity > state.Position.Size {
+	// Validate quantity; a close within rounding of the size closes all of it
+	if quantity > state.Position.Size || state.Position.Contract().Precision().QuantityEqual(quantity, state.Position.Size) {
 		quantity = state.Position.Size
 	}
 
 	// Calculate PnL for this close
-	entryValue := quantity * state.Position.EntryPrice
-	exitValue := quantity * price
-	pnl := exitValue - entryValue // Simplified PnL calculation
+	contract := state.Position.Contract()
+	entryValue := contract.Notional(quantity, state.Position.EntryPrice)
+	exitValue := contract.Notional(quantity, price)
+	pnl := contract.PnL(state.Position.Type, quantity, state.Position.EntryPrice, price)
 
 	// Update position
 	state.Position.Size -= quantity
+	state.Position.UpdateMargin()
 	state.Position.UpdateMarkPrice(price)
 	state.Position.RealizedPnL += pnl
 	state.LastUpdate = time.Now()
@@ -348,13 +408,15 @@ func (pm *PositionManager) ClosePosition(symbol string, quantity float64, price
 	})
 
 	// Close position if fully closed
-	if state.Position.Size <= 0.001 { // Threshold for rounding
+	if state.Position.IsFlat() {
+		state.Position.Size = 0
 		state.Position.Status = "closed"
 		now := time.Now()
 		state.Position.ExitTime = &now
 
 		// Remove from positions
-		delete(pm.positions, symbol)
+		delete(pm.positions, pm.PositionKey(state.Position.Symbol, state.Position.Type))
+		delete(pm.unwinds, pm.PositionKey(state.Position.Symbol, state.Position.Type))
 		pm.totalRiskExposure -= entryValue / 100
 
 		// Update performance stats
@@ -368,7 +430,7 @@ func (pm *PositionManager) ClosePosition(symbol string, quantity float64, price
 
 	// Record event
 	eventType := "partial_close"
-	if state.Position.Size <= 0.001 {
+	if state.Position.Status == "closed" {
 		eventType = "close"
 	}
 	pm.recordEvent(eventType, symbol, state.Position.ID, string(state.Position.Type), quantity, price, pnl, reason, string(triggerType))
@@ -391,9 +453,110 @@ func (pm *PositionManager) ClosePosition(symbol string, quantity float64, price
 	return result, nil
 }
 
-// ProcessCloseTriggers checks and processes position close triggers
+// ApplyFill updates tracked positions from an executor order update
+func (pm *PositionManager) ApplyFill(update types.OrderUpdate) (*types.OrderResult, error) {
+	if !update.IsFill() {
+		// Cancels and rejects don't change positions but are kept in the history
+		pm.recordEvent("order_"+string(update.Status), update.Symbol, update.OrderID, string(update.PositionType), update.Quantity, update.LastFillPrice, 0, update.Reason, "")
+		return nil, nil
+	}
+
+	if pm.HedgeMode {
+		return pm.applyHedgeFill(update)
+	}
+
+	state, exists := pm.positions[update.Symbol]
+
+	if update.IsOpening() {
+		if exists && state.Position.Type == update.PositionType {
+			return pm.AddToPosition(update.Symbol, update.LastFillQty, update.LastFillPrice)
+		}
+		if exists {
+			// Exchange netted the opposite position away before opening this one
+			if _, err := pm.ClosePosition(update.Symbol, state.Position.Size, update.LastFillPrice, "Netted by opposite fill", TriggerOrderFill); err != nil {
+				return nil, err
+			}
+		}
+		return pm.OpenGridPosition(update.Symbol, update.PositionType, update.LastFillQty, update.LastFillPrice)
+	}
+
+	if !exists || state.Position.Type != update.PositionType {
+		return nil, fmt.Errorf("no %s position for %s to reduce", update.PositionType, update.Symbol)
+	}
+
+	return pm.ClosePosition(update.Symbol, update.LastFillQty, update.LastFillPrice, "Order filled", TriggerOrderFill)
+}
+
+// applyHedgeFill books a fill against its own position side without netting the opposite side
+func (pm *PositionManager) applyHedgeFill(update types.OrderUpdate) (*types.OrderResult, error) {
+	key := types.PositionKey(update.Symbol, update.PositionType)
+	_, exists := pm.positions[key]
+
+	if update.IsOpening() {
+		if exists {
+			return pm.AddToPosition(key, update.LastFillQty, update.LastFillPrice)
+		}
+		return pm.OpenGridPosition(update.Symbol, update.PositionType, update.LastFillQty, update.LastFillPrice)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("no %s position for %s to reduce", update.PositionType, update.Symbol)
+	}
+
+	return pm.ClosePosition(key, update.LastFillQty, update.LastFillPrice, "Order filled", TriggerOrderFill)
+}
+
+// SyncPosition brings the tracked position of a side to the size reported by the executor, for when order
+// updates were missed; the difference is booked at price and in one-way mode a position on the other side is
+// closed first
+func (pm *PositionManager) SyncPosition(symbol string, positionType types.PositionType, size, price float64) (*types.OrderResult, error) {
+	key := pm.PositionKey(symbol, positionType)
+	state, exists := pm.positions[key]
+	if exists && state.Position.Type != positionType {
+		if _, err := pm.ClosePosition(key, state.Position.Size, price, "Resynced with executor", TriggerResync); err != nil {
+			return nil, err
+		}
+		state, exists = pm.positions[key]
+	}
+
+	if !exists {
+		if size <= 0 {
+			return nil, nil
+		}
+		return pm.OpenGridPosition(symbol, positionType, size, price)
+	}
+
+	tracked := state.Position.Size
+	switch {
+	case state.Position.Contract().Precision().QuantityEqual(size, tracked):
+		return nil, nil
+	case size > tracked:
+		return pm.AddToPosition(key, size-tracked, price)
+	default:
+		return pm.ClosePosition(key, tracked-size, price, "Resynced with executor", TriggerResync)
+	}
+}
+
+// ProcessCloseTriggers checks and processes position close triggers; in hedge mode both sides are checked
 func (pm *PositionManager) ProcessCloseTriggers(symbol string, currentPrice float64) ([]*types.OrderResult, error) {
-	state, exists := pm.positions[symbol]
+	if !pm.HedgeMode {
+		return pm.processCloseTriggers(symbol, currentPrice)
+	}
+
+	var results []*types.OrderResult
+	for _, positionType := range []types.PositionType{types.PositionTypeLong, types.PositionTypeShort} {
+		sideResults, err := pm.processCloseTriggers(types.PositionKey(symbol, positionType), currentPrice)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, sideResults...)
+	}
+	return results, nil
+}
+
+// processCloseTriggers checks the close triggers of a single position
+func (pm *PositionManager) processCloseTriggers(key string, currentPrice float64) ([]*types.OrderResult, error) {
+	state, exists := pm.positions[key]
 	if !exists {
 		return nil, nil
 	}
@@ -410,7 +573,7 @@ func (pm *PositionManager) ProcessCloseTriggers(symbol string, currentPrice floa
 	// Check each trigger
 	for i := range state.CloseTriggers {
 		trigger := &state.CloseTriggers[i]
-		if trigger.Executed {
+		if trigger.Executed || trigger.OrderID != "" {
 			continue
 		}
 
@@ -423,8 +586,6 @@ func (pm *PositionManager) ProcessCloseTriggers(symbol string, currentPrice floa
 			shouldTrigger = pm.shouldTriggerTakeProfit(state, currentPrice, trigger.Price)
 		case TriggerGridBreach:
 			shouldTrigger = pm.shouldTriggerGridBreach(state, currentPrice)
-		case TriggerTimeout:
-			shouldTrigger = time.Since(state.EntryTime) > time.Duration(pm.TimeoutHours)*time.Hour
 		case TriggerFalseBreakout:
 			shouldTrigger = pm.shouldTriggerFalseBreakout(state)
 		}
@@ -436,7 +597,7 @@ func (pm *PositionManager) ProcessCloseTriggers(symbol string, currentPrice floa
 				quantity = state.Position.Size
 			}
 
-			result, err := pm.ClosePosition(symbol, quantity, currentPrice, trigger.Reason, trigger.Type)
+			result, err := pm.ClosePosition(key, quantity, currentPrice, trigger.Reason, trigger.Type)
 			if err != nil {
 				return nil, err
 			}
@@ -452,7 +613,7 @@ func (pm *PositionManager) ProcessCloseTriggers(symbol string, currentPrice floa
 			}
 
 			// If position was closed, break early
-			if state.Position.Size <= 0.001 {
+			if state.Position.IsFlat() {
 				break
 			}
 		}
@@ -461,10 +622,139 @@ func (pm *PositionManager) ProcessCloseTriggers(symbol string, currentPrice floa
 	return results, nil
 }
 
-// GetPosition returns current position state
+// SetPrecision sets the quantity and price increments of a symbol, so open and future positions treat
+// less than half a step as closed
+func (pm *PositionManager) SetPrecision(symbol string, precision types.Precision) {
+	// The contract map may be shared with other components, so it is replaced rather than modified
+	contracts := make(map[string]types.ContractSpec, len(pm.Contracts)+1)
+	for key, spec := range pm.Contracts {
+		contracts[key] = spec
+	}
+	spec := contracts[symbol]
+	spec.StepSize = precision.StepSize
+	spec.TickSize = precision.TickSize
+	contracts[symbol] = spec
+	pm.Contracts = contracts
+
+	for _, state := range pm.positions {
+		if state.Position.Symbol == symbol {
+			state.Position.SetContract(spec)
+		}
+	}
+}
+
+// GetPositionTypeForBreakout returns the position direction taken on a breakout
+func (pm *PositionManager) GetPositionTypeForBreakout(breakoutType BreakoutType) types.PositionType {
+	return pm.getPositionTypeFromBreakout(breakoutType)
+}
+
+// GetStopAndTarget returns the stop loss and take profit prices for a new position
+func (pm *PositionManager) GetStopAndTarget(positionType types.PositionType, price float64) (float64, float64) {
+	return pm.calculateStopLoss(positionType, price), pm.calculateTakeProfit(positionType, price)
+}
+
+// SetStopAndTarget overrides the stop loss and take profit levels of an open position
+func (pm *PositionManager) SetStopAndTarget(symbol string, stopLoss, takeProfit float64) error {
+	state, exists := pm.lookup(symbol)
+	if !exists {
+		return fmt.Errorf("no position found for symbol %s", symbol)
+	}
+
+	state.StopLoss = stopLoss
+	state.TakeProfit = takeProfit
+	for i := range state.CloseTriggers {
+		switch state.CloseTriggers[i].Type {
+		case TriggerStopLoss:
+			state.CloseTriggers[i].Price = stopLoss
+		case TriggerTakeProfit:
+			state.CloseTriggers[i].Price = takeProfit
+		}
+	}
+	state.LastUpdate = time.Now()
+
+	return nil
+}
+
+// ExchangeExitOrders returns exchange orders for the stop loss and take profit triggers of an open position
+// that are not delegated yet, or nil when exchange exits are disabled; supported reports the order types the
+// executor executes natively, and triggers it cannot execute stay with the bot
+func (pm *PositionManager) ExchangeExitOrders(key string, supported func(types.OrderType) bool) map[TriggerType]*types.Order {
+	state, exists := pm.lookup(key)
+	if !pm.ExchangeExits || !exists {
+		return nil
+	}
+
+	orders := make(map[TriggerType]*types.Order)
+	for _, trigger := range state.CloseTriggers {
+		if trigger.Executed || trigger.OrderID != "" || trigger.Price <= 0 {
+			continue
+		}
+		order := trigger.ExchangeOrder(state.Position, pm.ExchangeTrailingStop)
+		if order != nil && supported(order.Type) {
+			orders[trigger.Type] = order
+		}
+	}
+	return orders
+}
+
+// DelegateTrigger leaves the pending triggers of a type to the exchange order orderID; "" takes them back
+func (pm *PositionManager) DelegateTrigger(key string, triggerType TriggerType, orderID string) {
+	state, exists := pm.lookup(key)
+	if !exists {
+		return
+	}
+	for i := range state.CloseTriggers {
+		if trigger := &state.CloseTriggers[i]; trigger.Type == triggerType && !trigger.Executed {
+			trigger.OrderID = orderID
+		}
+	}
+}
+
+// GetPosition returns current position state by symbol or, in hedge mode, by position key
 func (pm *PositionManager) GetPosition(symbol string) (*PositionState, bool) {
-	state, exists := pm.positions[symbol]
-	return state, exists
+	return pm.lookup(symbol)
+}
+
+// PositionKey returns the key a position is tracked under: the symbol, or the symbol side in hedge mode
+func (pm *PositionManager) PositionKey(symbol string, positionType types.PositionType) string {
+	if pm.HedgeMode {
+		return types.PositionKey(symbol, positionType)
+	}
+	return symbol
+}
+
+// GetHedgePosition returns the long and short sides tracked for a symbol
+func (pm *PositionManager) GetHedgePosition(symbol string) *types.HedgePosition {
+	hedge := &types.HedgePosition{Symbol: symbol}
+	for _, state := range pm.positions {
+		if state.Position.Symbol != symbol {
+			continue
+		}
+		if state.Position.Type == types.PositionTypeLong {
+			hedge.Long = state.Position
+		} else {
+			hedge.Short = state.Position
+		}
+	}
+	return hedge
+}
+
+// lookup finds a position by key; in hedge mode a bare symbol matches only if a single side is open
+func (pm *PositionManager) lookup(key string) (*PositionState, bool) {
+	if state, exists := pm.positions[key]; exists || !pm.HedgeMode {
+		return state, exists
+	}
+
+	long, hasLong := pm.positions[types.PositionKey(key, types.PositionTypeLong)]
+	short, hasShort := pm.positions[types.PositionKey(key, types.PositionTypeShort)]
+	switch {
+	case hasLong && !hasShort:
+		return long, true
+	case hasShort && !hasLong:
+		return short, true
+	default:
+		return nil, false
+	}
 }
 
 // GetAllPositions returns all active positions
@@ -508,6 +798,10 @@ func (pm *PositionManager) GetPositionStats() map[string]interface{} {
 		"total_risk_exposure": pm.totalRiskExposure,
 	"daily_loss_limit":   pm.dailyLossLimit,
 		"position_counter":   pm.positionCounter,
+		"hedge_mode":         pm.HedgeMode,
+		"timeout_hours":      pm.TimeoutHours,
+		"aged_unwind_policy": pm.AgedUnwind.Policy,
+		"aged_unwinds":       len(pm.unwinds),
 	}
 }
 
@@ -0,0 +1,110 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"math"
+	"testing"
+)
+
+// conditionalOrders reports the order types of an executor with native stops
+func conditionalOrders(orderType types.OrderType) bool {
+	return orderType != types.OrderTypeTrailingStop
+}
+
+func TestExchangeExitsTakeOverStopAndTarget(t *testing.T) {
+	pm := NewPositionManager(PositionManagerConfig{ExchangeExits: true})
+	if _, err := pm.OpenGridPosition("BTCUSDT", types.PositionTypeLong, 0.5, 50000); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.SetStopAndTarget("BTCUSDT", 49000, 52000); err != nil {
+		t.Fatal(err)
+	}
+
+	orders := pm.ExchangeExitOrders("BTCUSDT", conditionalOrders)
+	stop, target := orders[TriggerStopLoss], orders[TriggerTakeProfit]
+	if len(orders) != 2 || stop == nil || target == nil {
+		t.Fatalf("expected a stop loss and a take profit, got %+v", orders)
+	}
+	if stop.Type != types.OrderTypeStop || stop.StopPrice != 49000 || stop.Side != types.OrderSideSell || !stop.ReduceOnly || stop.Quantity != 0.5 {
+		t.Fatalf("unexpected stop loss order %+v", stop)
+	}
+	if target.Type != types.OrderTypeLimit || target.Price != 52000 || target.Side != types.OrderSideSell || !target.ReduceOnly {
+		t.Fatalf("unexpected take profit order %+v", target)
+	}
+
+	// Delegated triggers are left to the exchange
+	pm.DelegateTrigger("BTCUSDT", TriggerStopLoss, "stop-1")
+	pm.DelegateTrigger("BTCUSDT", TriggerTakeProfit, "target-1")
+	if remaining := pm.ExchangeExitOrders("BTCUSDT", conditionalOrders); len(remaining) != 0 {
+		t.Fatalf("expected no exits for delegated triggers, got %+v", remaining)
+	}
+	if results, err := pm.ProcessCloseTriggers("BTCUSDT", 48500); err != nil || len(results) != 0 {
+		t.Fatalf("expected the delegated stop loss not to fire locally, got %+v (%v)", results, err)
+	}
+
+	// A stop handed back fires locally again
+	pm.DelegateTrigger("BTCUSDT", TriggerStopLoss, "")
+	results, err := pm.ProcessCloseTriggers("BTCUSDT", 48500)
+	if err != nil || len(results) != 1 || results[0].Quantity != 0.5 {
+		t.Fatalf("expected the stop loss to close the position, got %+v (%v)", results, err)
+	}
+}
+
+func TestExchangeExitsSkipUnsupportedOrders(t *testing.T) {
+	pm := NewPositionManager(PositionManagerConfig{ExchangeExits: true, ExchangeTrailingStop: 0.01})
+	if _, err := pm.OpenGridPosition("BTCUSDT", types.PositionTypeShort, 0.5, 50000); err != nil {
+		t.Fatal(err)
+	}
+
+	// Trailing stops are not executed natively, so only the take profit goes to the exchange
+	orders := pm.ExchangeExitOrders("BTCUSDT", conditionalOrders)
+	if len(orders) != 1 || orders[TriggerTakeProfit] == nil || orders[TriggerTakeProfit].Side != types.OrderSideBuy {
+		t.Fatalf("expected only a buy take profit, got %+v", orders)
+	}
+
+	disabled := NewPositionManager(PositionManagerConfig{})
+	if _, err := disabled.OpenGridPosition("BTCUSDT", types.PositionTypeLong, 0.5, 50000); err != nil {
+		t.Fatal(err)
+	}
+	if orders := disabled.ExchangeExitOrders("BTCUSDT", conditionalOrders); orders != nil {
+		t.Fatalf("expected no exchange exits when disabled, got %+v", orders)
+	}
+}
+
+func TestSettleClosesAtExitFills(t *testing.T) {
+	pm := NewPositionManager(PositionManagerConfig{})
+	if _, err := pm.OpenGridPosition("BTCUSDT", types.PositionTypeLong, 0.5, 50000); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.SetStopAndTarget("BTCUSDT", 49000, 52000); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots := pm.SnapshotPositions("BTCUSDT")
+	results, err := pm.ProcessCloseTriggers("BTCUSDT", 48500)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("expected the stop loss to fire, got %+v (%v)", results, err)
+	}
+	pm.MarkBooked(snapshots)
+
+	// The exit filled 0.3 lower than the stop fired at, leaving 0.2 open on the exchange
+	if err := pm.SettleCloses(snapshots["BTCUSDT"], []ExitFill{{Quantity: 0.3, Price: 48400}}); err != nil {
+		t.Fatal(err)
+	}
+	state, open := pm.GetPosition("BTCUSDT")
+	if !open || !state.Position.Contract().Precision().QuantityEqual(state.Position.Size, 0.2) {
+		t.Fatalf("expected 0.2 left open, got %+v", state)
+	}
+	if pnl := state.Position.RealizedPnL; math.Abs(pnl-0.3*(48400-50000)) > 1e-6 {
+		t.Fatalf("expected the close booked at the fill, realized %.2f", pnl)
+	}
+
+	// The stop stays armed for the rest
+	results, err = pm.ProcessCloseTriggers("BTCUSDT", 48500)
+	if err != nil || len(results) != 1 || !state.Position.Contract().Precision().QuantityEqual(results[0].Quantity, 0.2) {
+		t.Fatalf("expected the stop loss to close the rest, got %+v (%v)", results, err)
+	}
+	if _, open := pm.GetPosition("BTCUSDT"); open {
+		t.Fatal("expected the position closed")
+	}
+}
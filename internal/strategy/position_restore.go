@@ -0,0 +1,181 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"time"
+)
+
+// PositionSnapshot is a copy of a tracked position taken before closes are booked on it, so the closes can
+// be undone if their exit orders never reach the executor
+type PositionSnapshot struct {
+	key      string
+	live     *PositionState
+	state    PositionState
+	position types.Position
+	triggers []CloseTrigger
+	unwind   *agedUnwind
+	taken    time.Time
+
+	// Position after the closes were booked; a restore is refused once it has moved on
+	bookedSize     float64
+	bookedTriggers int
+}
+
+// Key returns the position key of the snapshot
+func (s *PositionSnapshot) Key() string {
+	return s.key
+}
+
+// SnapshotPositions copies the tracked positions of a symbol by position key; in hedge mode both sides
+// are copied
+func (pm *PositionManager) SnapshotPositions(symbol string) map[string]*PositionSnapshot {
+	keys := []string{symbol}
+	if pm.HedgeMode {
+		keys = []string{types.PositionKey(symbol, types.PositionTypeLong), types.PositionKey(symbol, types.PositionTypeShort)}
+	}
+
+	snapshots := make(map[string]*PositionSnapshot, len(keys))
+	for _, key := range keys {
+		state, exists := pm.positions[key]
+		if !exists {
+			continue
+		}
+		snapshot := &PositionSnapshot{
+			key:      key,
+			live:     state,
+			state:    *state,
+			position: *state.Position,
+			triggers: append([]CloseTrigger(nil), state.CloseTriggers...),
+			taken:    time.Now(),
+		}
+		if progress, ok := pm.unwinds[key]; ok {
+			unwind := *progress
+			snapshot.unwind = &unwind
+		}
+		snapshots[key] = snapshot
+	}
+	return snapshots
+}
+
+// MarkBooked records the positions after closes were booked on them, so a later restore can tell whether
+// anything else has changed them since
+func (pm *PositionManager) MarkBooked(snapshots map[string]*PositionSnapshot) {
+	for _, snapshot := range snapshots {
+		snapshot.bookedSize = snapshot.live.Position.Size
+		snapshot.bookedTriggers = len(snapshot.live.CloseTriggers)
+	}
+}
+
+// RestorePosition undoes the closes booked on a position since its snapshot: size, triggers and unwind
+// progress are put back so the closes fire again on a later price, and the closes are taken out of the
+// position history and performance stats.
+func (pm *PositionManager) RestorePosition(snapshot *PositionSnapshot) error {
+	live := snapshot.live
+	current, open := pm.positions[snapshot.key]
+	closed := live.Position.Status == "closed"
+	if (open && current != live) || (!open && !closed) ||
+		live.Position.Size != snapshot.bookedSize || len(live.CloseTriggers) != snapshot.bookedTriggers {
+		return fmt.Errorf("%s position changed since its closes were booked", snapshot.key)
+	}
+
+	if closed {
+		// The last trigger record is the close that flattened the position
+		final := live.CloseTriggers[len(live.CloseTriggers)-1]
+		contract := live.Position.Contract()
+		pnl := contract.PnL(live.Position.Type, final.PositionSize, live.Position.EntryPrice, final.Price)
+		pm.totalRiskExposure += contract.Notional(final.PositionSize, live.Position.EntryPrice) / 100
+		if pnl > 0 {
+			pm.totalProfit -= pnl
+		} else {
+			pm.totalLoss -= math.Abs(pnl)
+		}
+	}
+
+	// Restore in place, so holders of the state see the position open again
+	position := live.Position
+	*live = snapshot.state
+	*position = snapshot.position
+	live.Position = position
+	live.CloseTriggers = append([]CloseTrigger(nil), snapshot.triggers...)
+	pm.positions[snapshot.key] = live
+
+	delete(pm.unwinds, snapshot.key)
+	if snapshot.unwind != nil {
+		unwind := *snapshot.unwind
+		pm.unwinds[snapshot.key] = &unwind
+	}
+
+	history := pm.positionHistory[:0]
+	for _, event := range pm.positionHistory {
+		booked := event.PositionID == live.Position.ID && !event.Timestamp.Before(snapshot.taken) &&
+			(event.EventType == "close" || event.EventType == "partial_close")
+		if !booked {
+			history = append(history, event)
+		}
+	}
+	pm.positionHistory = history
+	pm.updatePerformanceStats()
+	return nil
+}
+
+// ExitFill is the quantity and price an exit order closed at on the executor
+type ExitFill struct {
+	Quantity float64
+	Price    float64
+}
+
+// SettleCloses books the closes booked on a position since its snapshot again at their exit fills: fills[i]
+// replaces the quantity and price of the i-th booked close, and closes past the fills are booked as before.
+// A close that filled short of its booked quantity leaves the rest of the position open with its trigger
+// armed, so the rest fires again on a later price; a zero fill books nothing.
+func (pm *PositionManager) SettleCloses(snapshot *PositionSnapshot, fills []ExitFill) error {
+	live := snapshot.live
+	first := len(snapshot.triggers)
+	if len(live.CloseTriggers) < first+len(fills) {
+		return fmt.Errorf("%s position has %d exit fills for %d booked closes", snapshot.key, len(fills), len(live.CloseTriggers)-first)
+	}
+	booked := append([]CloseTrigger(nil), live.CloseTriggers[first:]...)
+	var unwind *agedUnwind
+	if progress, ok := pm.unwinds[snapshot.key]; ok {
+		copied := *progress
+		unwind = &copied
+	}
+	if err := pm.RestorePosition(snapshot); err != nil {
+		return err
+	}
+
+	precision := live.Position.Contract().Precision()
+	for i, record := range booked {
+		if _, open := pm.positions[snapshot.key]; !open {
+			break
+		}
+		quantity, price := record.PositionSize, record.Price
+		if i < len(fills) {
+			quantity, price = fills[i].Quantity, fills[i].Price
+		}
+		if quantity <= 0 {
+			continue
+		}
+		if _, err := pm.ClosePosition(snapshot.key, quantity, price, record.Reason, record.Type); err != nil {
+			return err
+		}
+		if i >= len(fills) || (quantity < record.PositionSize && !precision.QuantityEqual(quantity, record.PositionSize)) {
+			continue
+		}
+		// The trigger fired in full, as when it was booked
+		for j := range live.CloseTriggers[:first] {
+			trigger := &live.CloseTriggers[j]
+			if trigger.Type == record.Type && trigger.OrderID == "" {
+				trigger.Executed = true
+			}
+		}
+	}
+
+	if _, open := pm.positions[snapshot.key]; open && unwind != nil {
+		pm.unwinds[snapshot.key] = unwind
+	}
+	pm.MarkBooked(map[string]*PositionSnapshot{snapshot.key: snapshot})
+	return nil
+}
@@ -0,0 +1,226 @@
+package strategy
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "balanced"
+
+//go:embed profiles/*.json
+var builtinProfiles embed.FS
+
+// profileNamePattern restricts profile names to safe file names
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// StrategyProfile is a named set of grid setup, breakout and risk parameters. Indicator names, fees
+// from the fee schedule and the drawdown policy stay in the configuration.
+type StrategyProfile struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	GridSetup   GridSetupConfig `json:"grid_setup"`
+	Breakout    BreakoutConfig  `json:"breakout"`
+	Risk        RiskProfile     `json:"risk"`
+	Builtin     bool            `json:"-"` // Shipped with the bot rather than saved by the user
+}
+
+// RiskProfile holds the risk limits set by a profile
+type RiskProfile struct {
+	MaxPortfolioRisk     float64 `json:"max_portfolio_risk"`
+	MaxPositionRisk      float64 `json:"max_position_risk"`
+	MaxCorrelation       float64 `json:"max_correlation"`
+	MaxDrawdown          float64 `json:"max_drawdown"`
+	MinRiskRewardRatio   float64 `json:"min_risk_reward_ratio"`
+	DefaultLeverage      float64 `json:"default_leverage"`
+	MaxLeverage          float64 `json:"max_leverage"`
+	ConcentrationLimit   float64 `json:"concentration_limit"`
+	VolatilityMultiplier float64 `json:"volatility_multiplier"`
+}
+
+// Apply sets the profile's limits on a risk manager configuration
+func (p RiskProfile) Apply(config *RiskManagerConfig) {
+	config.MaxPortfolioRisk = p.MaxPortfolioRisk
+	config.MaxPositionRisk = p.MaxPositionRisk
+	config.MaxCorrelation = p.MaxCorrelation
+	config.MaxDrawdown = p.MaxDrawdown
+	config.MinRiskRewardRatio = p.MinRiskRewardRatio
+	config.DefaultLeverage = p.DefaultLeverage
+	config.MaxLeverage = p.MaxLeverage
+	config.ConcentrationLimit = p.ConcentrationLimit
+	config.VolatilityMultiplier = p.VolatilityMultiplier
+}
+
+// Validate checks that the profile's parameters are consistent
+func (p *StrategyProfile) Validate() error {
+	if !profileNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' and '_'", p.Name)
+	}
+
+	grid := p.GridSetup
+	if grid.MinGridLevels <= 0 || grid.MinGridLevels > grid.DefaultGridLevels || grid.DefaultGridLevels > grid.MaxGridLevels {
+		return fmt.Errorf("profile %s: grid levels must satisfy 0 < min <= default <= max", p.Name)
+	}
+	if grid.MinPriceRange <= 0 || grid.MinPriceRange >= grid.MaxPriceRange {
+		return fmt.Errorf("profile %s: grid price range must satisfy 0 < min < max", p.Name)
+	}
+	if grid.MinProfitPerLevel <= 2*grid.MakerFee {
+		return fmt.Errorf("profile %s: min profit per level must exceed the round-trip maker fee", p.Name)
+	}
+
+	breakout := p.Breakout
+	if breakout.ConfirmationPeriod <= 0 {
+		return fmt.Errorf("profile %s: breakout confirmation period must be positive", p.Name)
+	}
+	if breakout.ConfirmationMode != "" && !breakout.ConfirmationMode.IsValid() {
+		return fmt.Errorf("profile %s: unknown breakout confirmation mode %q", p.Name, breakout.ConfirmationMode)
+	}
+	if breakout.ConfirmationCloses < 0 {
+		return fmt.Errorf("profile %s: breakout confirmation closes cannot be negative", p.Name)
+	}
+	if breakout.StrengthMode != "" && !breakout.StrengthMode.IsValid() {
+		return fmt.Errorf("profile %s: unknown breakout strength mode %q", p.Name, breakout.StrengthMode)
+	}
+	if breakout.MinStrengthPercent < 0 || breakout.MinStrengthATR < 0 {
+		return fmt.Errorf("profile %s: breakout minimum strengths cannot be negative", p.Name)
+	}
+	if breakout.RSIOversold <= 0 || breakout.RSIOversold >= breakout.RSIOverbought || breakout.RSIOverbought >= 100 {
+		return fmt.Errorf("profile %s: RSI thresholds must satisfy 0 < oversold < overbought < 100", p.Name)
+	}
+
+	risk := p.Risk
+	for name, value := range map[string]float64{
+		"max portfolio risk":  risk.MaxPortfolioRisk,
+		"max position risk":   risk.MaxPositionRisk,
+		"max correlation":     risk.MaxCorrelation,
+		"max drawdown":        risk.MaxDrawdown,
+		"concentration limit": risk.ConcentrationLimit,
+	} {
+		if value <= 0 || value > 1 {
+			return fmt.Errorf("profile %s: %s must be between 0 and 1", p.Name, name)
+		}
+	}
+	if risk.MaxPositionRisk > risk.MaxPortfolioRisk {
+		return fmt.Errorf("profile %s: max position risk cannot exceed max portfolio risk", p.Name)
+	}
+	if risk.DefaultLeverage < 1 || risk.DefaultLeverage > risk.MaxLeverage {
+		return fmt.Errorf("profile %s: leverage must satisfy 1 <= default <= max", p.Name)
+	}
+	return nil
+}
+
+// LoadProfile returns a profile by name, looking in the custom profile directory before the built-in profiles
+func LoadProfile(name, dir string) (*StrategyProfile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+	if !profileNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid profile name %q", name)
+	}
+
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+		if err == nil {
+			return parseProfile(data, name, false)
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read profile %s: %w", name, err)
+		}
+	}
+
+	data, err := builtinProfiles.ReadFile("profiles/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	return parseProfile(data, name, true)
+}
+
+// parseProfile decodes and validates a profile; unknown fields are rejected so typos cannot silently
+// leave a parameter at its default
+func parseProfile(data []byte, name string, builtin bool) (*StrategyProfile, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var profile StrategyProfile
+	if err := decoder.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile %s: %w", name, err)
+	}
+	if profile.Name != name {
+		return nil, fmt.Errorf("profile file %s.json declares name %q", name, profile.Name)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	profile.Builtin = builtin
+	return &profile, nil
+}
+
+// ListProfiles returns the built-in and custom profiles ordered by name; a custom profile replaces a
+// built-in one of the same name
+func ListProfiles(dir string) ([]*StrategyProfile, error) {
+	names := make(map[string]bool)
+	entries, err := builtinProfiles.ReadDir("profiles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list built-in profiles: %w", err)
+	}
+	for _, entry := range entries {
+		names[trimJSON(entry.Name())] = true
+	}
+	if dir != "" {
+		custom, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list custom profiles: %w", err)
+		}
+		for _, path := range custom {
+			names[trimJSON(filepath.Base(path))] = true
+		}
+	}
+
+	profiles := make([]*StrategyProfile, 0, len(names))
+	for name := range names {
+		profile, err := LoadProfile(name, dir)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// SaveProfile validates a profile and writes it to the custom profile directory; built-in names are reserved
+func SaveProfile(profile *StrategyProfile, dir string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("no custom profile directory configured")
+	}
+	if err := profile.Validate(); err != nil {
+		return "", err
+	}
+	if _, err := builtinProfiles.ReadFile("profiles/" + profile.Name + ".json"); err == nil {
+		return "", fmt.Errorf("profile %s is built in; save the copy under another name", profile.Name)
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	path := filepath.Join(dir, profile.Name+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write profile: %w", err)
+	}
+	return path, nil
+}
+
+// trimJSON strips the .json extension from a profile file name
+func trimJSON(name string) string {
+	return name[:len(name)-len(filepath.Ext(name))]
+}
@@ -1,7 +1,10 @@
 package strategy
 
 import (
+	"aibot/internal/types"
+	"fmt"
 	"math"
+	"sync"
 	"time"
 )
 
@@ -29,6 +32,7 @@ type RiskManager struct {
 	MaxDrawdownReached    float64 `json:"max_drawdown_reached"`     // Maximum drawdown ever reached
 
 	// State tracking
+	portfolioMu           sync.Mutex // Guards the portfolio value, positions and margin usage synced by the risk worker
 	positions             []RiskPosition `json:"positions"`
 	riskMetrics           RiskMetrics    `json:"risk_metrics"`
 	marginCalls           int            `json:"margin_calls"`
@@ -38,6 +42,52 @@ type RiskManager struct {
 	VolatilityMultiplier  float64 `json:"volatility_multiplier"`    // Volatility risk multiplier
 	CorrelationPenalty    float64 `json:"correlation_penalty"`      // Penalty for correlated positions
 	ConcentrationLimit    float64 `json:"concentration_limit"`      // Max concentration in one asset (30%)
+
+	// Drawdown de-risking policy (thresholds are fractions of MaxDrawdown)
+	DeRiskReduceAt        float64       `json:"derisk_reduce_at"`        // Cut position sizes from here (0.5)
+	DeRiskReduceFactor    float64       `json:"derisk_reduce_factor"`    // Size multiplier while reduced (0.5)
+	DeRiskHaltAt          float64       `json:"derisk_halt_at"`          // Stop new entries from here (0.8)
+	DeRiskFlattenAt       float64       `json:"derisk_flatten_at"`       // Flatten and go idle from here (1.0)
+	DeRiskCooldown        time.Duration `json:"derisk_cooldown"`         // Idle period after flattening (1h)
+	equityPeak            float64   // High-water mark drawdown is measured from
+	equityPeakAt          time.Time
+	deRiskLevel           DeRiskLevel
+	cooldownUntil         time.Time
+	deRiskMu              sync.RWMutex
+
+	// Liquidation estimates
+	MaintenanceMarginRate float64 `json:"maintenance_margin_rate"` // Maintenance margin as a fraction of notional (0.004)
+	LiquidationBuffer     float64 `json:"liquidation_buffer"`      // Distance to liquidation that raises alerts (0.05)
+	MarginMode            string  `json:"margin_mode"`             // "cross" or "isolated"
+
+	// Volatility-driven leverage
+	leverageControl       *LeverageController
+
+	// Declarative order policy
+	policy                *RiskPolicy
+	policyPath            string
+	policyChecks          int64
+	policyViolations      map[string]int64 // Rule -> violations
+	recentViolations      []PolicyViolation
+	policyMu              sync.RWMutex
+}
+
+// DeRiskLevel is the stage of the drawdown de-risking policy currently in force
+type DeRiskLevel string
+
+const (
+	DeRiskNormal  DeRiskLevel = "normal"
+	DeRiskReduce  DeRiskLevel = "reduce_size"
+	DeRiskHalt    DeRiskLevel = "halt_entries"
+	DeRiskFlatten DeRiskLevel = "flatten"
+)
+
+// DeRiskAction describes a change of de-risking stage for the orchestrator to execute
+type DeRiskAction struct {
+	Level         DeRiskLevel `json:"level"`
+	Drawdown      float64     `json:"drawdown"`
+	Threshold     float64     `json:"threshold"` // Drawdown at which the stage starts
+	CooldownUntil time.Time   `json:"cooldown_until,omitempty"`
 }
 
 // RiskPosition represents a position from risk management perspective
@@ -126,6 +176,16 @@ type RiskManagerConfig struct {
 	MaxLeverage          float64 `json:"max_leverage"`           // 10x
 	ConcentrationLimit   float64 `json:"concentration_limit"`    // 30%
 	VolatilityMultiplier float64 `json:"volatility_multiplier"`  // 1.5
+	DeRiskReduceAt       float64       `json:"derisk_reduce_at"`       // 50% of max drawdown
+	DeRiskReduceFactor   float64       `json:"derisk_reduce_factor"`   // 0.5
+	DeRiskHaltAt         float64       `json:"derisk_halt_at"`         // 80% of max drawdown
+	DeRiskFlattenAt      float64       `json:"derisk_flatten_at"`      // 100% of max drawdown
+	DeRiskCooldown       time.Duration `json:"derisk_cooldown"`        // 1h
+	PolicyFile           string        `json:"policy_file"`            // Declarative order policy, YAML or JSON ("" disables it)
+	MaintenanceMarginRate float64      `json:"maintenance_margin_rate"` // 0.4% of notional
+	LiquidationBuffer    float64       `json:"liquidation_buffer"`     // Alert within 5% of the liquidation price
+	MarginMode           string        `json:"margin_mode"`            // "cross" or "isolated" (cross)
+	LeverageControl      LeverageControlConfig `json:"leverage_control"` // Lower leverage while realized volatility is high
 }
 
 // NewRiskManager creates a new risk manager
@@ -158,6 +218,30 @@ func NewRiskManager(config RiskManagerConfig, initialBalance float64) *RiskManag
 	if config.VolatilityMultiplier == 0 {
 		config.VolatilityMultiplier = 1.5
 	}
+	if config.DeRiskReduceAt == 0 {
+		config.DeRiskReduceAt = 0.5 // 50% of max drawdown
+	}
+	if config.DeRiskReduceFactor == 0 {
+		config.DeRiskReduceFactor = 0.5
+	}
+	if config.DeRiskHaltAt == 0 {
+		config.DeRiskHaltAt = 0.8 // 80% of max drawdown
+	}
+	if config.DeRiskFlattenAt == 0 {
+		config.DeRiskFlattenAt = 1.0 // 100% of max drawdown
+	}
+	if config.DeRiskCooldown == 0 {
+		config.DeRiskCooldown = 1 * time.Hour
+	}
+	if config.MaintenanceMarginRate == 0 {
+		config.MaintenanceMarginRate = 0.004 // 0.4%
+	}
+	if config.LiquidationBuffer == 0 {
+		config.LiquidationBuffer = 0.05 // 5%
+	}
+	if config.MarginMode == "" {
+		config.MarginMode = MarginModeCross
+	}
 
 	return &RiskManager{
 		MaxPortfolioRisk:     config.MaxPortfolioRisk,
@@ -169,6 +253,18 @@ func NewRiskManager(config RiskManagerConfig, initialBalance float64) *RiskManag
 		MaxLeverage:          config.MaxLeverage,
 		ConcentrationLimit:   config.ConcentrationLimit,
 		VolatilityMultiplier: config.VolatilityMultiplier,
+		DeRiskReduceAt:       config.DeRiskReduceAt,
+		DeRiskReduceFactor:   config.DeRiskReduceFactor,
+		DeRiskHaltAt:         config.DeRiskHaltAt,
+		DeRiskFlattenAt:      config.DeRiskFlattenAt,
+		DeRiskCooldown:       config.DeRiskCooldown,
+		MaintenanceMarginRate: config.MaintenanceMarginRate,
+		LiquidationBuffer:    config.LiquidationBuffer,
+		MarginMode:           config.MarginMode,
+		leverageControl:      NewLeverageController(config.LeverageControl, config.DefaultLeverage),
+		equityPeak:           initialBalance,
+		deRiskLevel:          DeRiskNormal,
+		policyViolations:     make(map[string]int64),
 		MinPositionSize:      0.001, // 0.001 BTC minimum
 		MaxPositionSize:      1.0,   // 1.0 BTC maximum
 		PortfolioValue:       initialBalance,
@@ -183,6 +279,9 @@ func NewRiskManager(config RiskManagerConfig, initialBalance float64) *RiskManag
 
 // CalculatePositionSize calculates optimal position size based on risk parameters
 func (rm *RiskManager) CalculatePositionSize(req PositionSizingRequest) *PositionSizingResult {
+	rm.portfolioMu.Lock()
+	defer rm.portfolioMu.Unlock()
+
 	// Validate basic requirements
 	if req.EntryPrice <= 0 || req.StopLoss <= 0 {
 		return &PositionSizingResult{
@@ -191,6 +290,15 @@ func (rm *RiskManager) CalculatePositionSize(req PositionSizingRequest) *Positio
 		}
 	}
 
+	// Drawdown policy may block or shrink new entries
+	sizeMultiplier := rm.GetSizeMultiplier()
+	if sizeMultiplier == 0 {
+		return &PositionSizingResult{
+			AcceptableRisk: false,
+			Reason:         "New entries halted by drawdown policy",
+		}
+	}
+
 	// Calculate risk/reward ratio
 	riskRewardRatio := rm.calculateRiskRewardRatio(req.EntryPrice, req.StopLoss, req.TakeProfit)
 	if riskRewardRatio < rm.MinRiskRewardRatio {
@@ -213,12 +321,12 @@ func (rm *RiskManager) CalculatePositionSize(req PositionSizingRequest) *Positio
 	// Calculate position size
 	basePositionSize := (riskAmount * confidenceMultiplier) / (volatilityAdjustedRisk * req.EntryPrice)
 
-	// Apply leverage
-	leverage := rm.DefaultLeverage
+	// Apply leverage, capped by the volatility controller
+	leverage := rm.GetEffectiveLeverage()
 	if req.Leverage > 0 {
-		leverage = math.Min(req.Leverage, rm.MaxLeverage)
+		leverage = math.Min(math.Min(req.Leverage, rm.MaxLeverage), leverage)
 	}
-	positionSize := basePositionSize * leverage
+	positionSize := basePositionSize * leverage * sizeMultiplier
 
 	// Check portfolio constraints
 	positionSize = rm.applyPortfolioConstraints(req.Symbol, positionSize, req.EntryPrice)
@@ -256,6 +364,9 @@ func (rm *RiskManager) CalculatePositionSize(req PositionSizingRequest) *Positio
 
 // UpdatePortfolio updates portfolio state after trade execution
 func (rm *RiskManager) UpdatePortfolio(trade TradeUpdate) {
+	rm.portfolioMu.Lock()
+	defer rm.portfolioMu.Unlock()
+
 	// Update portfolio value based on PnL
 	rm.PortfolioValue += trade.RealizedPnL
 
@@ -271,8 +382,155 @@ func (rm *RiskManager) UpdatePortfolio(trade TradeUpdate) {
 	rm.lastRiskAssessment = time.Now()
 }
 
+// UpdateEquity records account equity, updates drawdown and returns the de-risking action when the policy stage changes
+func (rm *RiskManager) UpdateEquity(equity float64) *DeRiskAction {
+	rm.deRiskMu.Lock()
+	defer rm.deRiskMu.Unlock()
+
+	now := time.Now()
+	if rm.deRiskLevel == DeRiskFlatten {
+		if now.Before(rm.cooldownUntil) {
+			return nil
+		}
+		// Cooldown over: measure drawdown afresh from current equity
+		rm.equityPeak = equity
+		rm.equityPeakAt = now
+	}
+
+	if equity > rm.equityPeak {
+		rm.equityPeak = equity
+		rm.equityPeakAt = now
+	}
+	drawdown := float64(0)
+	if rm.equityPeak > 0 {
+		drawdown = math.Max(0, (rm.equityPeak-equity)/rm.equityPeak)
+	}
+	rm.CurrentDrawdown = drawdown
+	if drawdown > rm.MaxDrawdownReached {
+		rm.MaxDrawdownReached = drawdown
+	}
+
+	level, threshold := rm.deRiskLevelFor(drawdown)
+	if level == rm.deRiskLevel {
+		return nil
+	}
+	rm.deRiskLevel = level
+
+	action := &DeRiskAction{Level: level, Drawdown: drawdown, Threshold: threshold}
+	if level == DeRiskFlatten {
+		rm.cooldownUntil = now.Add(rm.DeRiskCooldown)
+		action.CooldownUntil = rm.cooldownUntil
+	}
+	return action
+}
+
+// GetSizeMultiplier returns the position size multiplier imposed by the drawdown policy; 0 blocks new entries
+func (rm *RiskManager) GetSizeMultiplier() float64 {
+	rm.deRiskMu.RLock()
+	defer rm.deRiskMu.RUnlock()
+
+	switch rm.deRiskLevel {
+	case DeRiskReduce:
+		return rm.DeRiskReduceFactor
+	case DeRiskHalt, DeRiskFlatten:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// UpdateVolatility records the rolling realized volatility and returns the leverage change to apply on the
+// exchange when the effective leverage moves
+func (rm *RiskManager) UpdateVolatility(volatility float64) *LeverageChange {
+	return rm.leverageControl.Update(volatility)
+}
+
+// RevertLeverage undoes a leverage change the exchange rejected
+func (rm *RiskManager) RevertLeverage(change *LeverageChange) {
+	rm.leverageControl.Revert(change)
+}
+
+// GetEffectiveLeverage returns the default leverage lowered by the volatility controller
+func (rm *RiskManager) GetEffectiveLeverage() float64 {
+	return rm.leverageControl.Leverage()
+}
+
+// GetVolatilityWindow returns the number of candles the realized volatility is measured over
+func (rm *RiskManager) GetVolatilityWindow() int {
+	return rm.leverageControl.Window()
+}
+
+// RiskLimits holds the risk limits that can be changed while trading; zero fields keep their current value
+type RiskLimits struct {
+	MaxDrawdown     float64 `json:"max_drawdown,omitempty"`      // Fraction of peak equity
+	MaxPositionRisk float64 `json:"max_position_risk,omitempty"` // Fraction of portfolio value
+	MaxPositionSize float64 `json:"max_position_size,omitempty"` // Base asset quantity
+}
+
+// Validate checks that the limits are within range
+func (l RiskLimits) Validate() error {
+	if l.MaxDrawdown < 0 || l.MaxDrawdown > 1 {
+		return fmt.Errorf("max drawdown must be between 0 and 1, got %.4f", l.MaxDrawdown)
+	}
+	if l.MaxPositionRisk < 0 || l.MaxPositionRisk > 1 {
+		return fmt.Errorf("max position risk must be between 0 and 1, got %.4f", l.MaxPositionRisk)
+	}
+	if l.MaxPositionSize < 0 {
+		return fmt.Errorf("max position size cannot be negative, got %.4f", l.MaxPositionSize)
+	}
+	return nil
+}
+
+// UpdateLimits applies the non-zero risk limits; the drawdown policy stage follows at the next equity update
+func (rm *RiskManager) UpdateLimits(limits RiskLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	if limits.MaxPositionSize > 0 && limits.MaxPositionSize < rm.MinPositionSize {
+		return fmt.Errorf("max position size %.4f is below the min position size %.4f", limits.MaxPositionSize, rm.MinPositionSize)
+	}
+
+	rm.deRiskMu.Lock()
+	defer rm.deRiskMu.Unlock()
+
+	if limits.MaxDrawdown > 0 {
+		rm.MaxDrawdown = limits.MaxDrawdown
+	}
+	if limits.MaxPositionRisk > 0 {
+		rm.MaxPositionRisk = limits.MaxPositionRisk
+	}
+	if limits.MaxPositionSize > 0 {
+		rm.MaxPositionSize = limits.MaxPositionSize
+	}
+	return nil
+}
+
+// GetDeRiskLevel returns the current drawdown policy stage
+func (rm *RiskManager) GetDeRiskLevel() DeRiskLevel {
+	rm.deRiskMu.RLock()
+	defer rm.deRiskMu.RUnlock()
+	return rm.deRiskLevel
+}
+
+// deRiskLevelFor maps a drawdown to its policy stage and the drawdown at which that stage starts
+func (rm *RiskManager) deRiskLevelFor(drawdown float64) (DeRiskLevel, float64) {
+	switch {
+	case drawdown >= rm.MaxDrawdown*rm.DeRiskFlattenAt:
+		return DeRiskFlatten, rm.MaxDrawdown * rm.DeRiskFlattenAt
+	case drawdown >= rm.MaxDrawdown*rm.DeRiskHaltAt:
+		return DeRiskHalt, rm.MaxDrawdown * rm.DeRiskHaltAt
+	case drawdown >= rm.MaxDrawdown*rm.DeRiskReduceAt:
+		return DeRiskReduce, rm.MaxDrawdown * rm.DeRiskReduceAt
+	default:
+		return DeRiskNormal, 0
+	}
+}
+
 // AssessRisk performs comprehensive risk assessment
 func (rm *RiskManager) AssessRisk() *RiskAssessment {
+	rm.portfolioMu.Lock()
+	defer rm.portfolioMu.Unlock()
+
 	assessment := &RiskAssessment{
 		Timestamp: time.Now(),
 		RiskFactors: make([]string, 0),
@@ -410,6 +668,20 @@ func (rm *RiskManager) generatePositionReason(positionSize, riskPercentage float
 	return "Position sized within risk parameters"
 }
 
+// SyncPortfolio replaces the portfolio value and tracked positions, both in the accounting currency, and
+// recalculates margin usage and risk metrics across the positions
+func (rm *RiskManager) SyncPortfolio(portfolioValue float64, positions []RiskPosition) {
+	rm.portfolioMu.Lock()
+	defer rm.portfolioMu.Unlock()
+
+	if portfolioValue > 0 {
+		rm.PortfolioValue = portfolioValue
+	}
+	rm.positions = append(rm.positions[:0], positions...)
+	rm.updateMarginUsage()
+	rm.calculateRiskMetrics()
+}
+
 // updatePositions updates the risk position tracking
 func (rm *RiskManager) updatePositions(trade TradeUpdate) {
 	// Find existing position for the symbol
@@ -460,10 +732,12 @@ func (rm *RiskManager) updateMarginUsage() {
 	rm.UsedMargin = 0
 	rm.TotalExposure = 0
 
+	// Shorts carry a negative notional value but use margin and add exposure all the same
 	for _, pos := range rm.positions {
-		marginForPosition := pos.NotionalValue / rm.DefaultLeverage
+		notional := math.Abs(pos.NotionalValue)
+		marginForPosition := notional / rm.DefaultLeverage
 		rm.UsedMargin += marginForPosition
-		rm.TotalExposure += pos.NotionalValue
+		rm.TotalExposure += notional
 	}
 
 	rm.AvailableMargin = rm.PortfolioValue - rm.UsedMargin
@@ -587,19 +861,32 @@ func (rm *RiskManager) checkMarginCallRisk(assessment *RiskAssessment) {
 	}
 }
 
-// performStressTests performs stress tests on the portfolio
+// performStressTests runs the default stress scenarios against the tracked positions
 func (rm *RiskManager) performStressTests(assessment *RiskAssessment) {
-	// Market crash scenario (-20%)
-	crashLoss := rm.TotalExposure * 0.20
-	assessment.RiskMetrics["market_crash_20_percent"] = crashLoss / rm.PortfolioValue
-
-	// Volatility spike scenario (3x volatility increase)
-	volatilityLoss := rm.TotalExposure * rm.riskMetrics.PortfolioVolatility * 2
-	assessment.RiskMetrics["volatility_spike"] = volatilityLoss / rm.PortfolioValue
+	positions := make([]*types.Position, 0, len(rm.positions))
+	volatilities := make(map[string]float64, len(rm.positions))
+	for _, pos := range rm.positions {
+		if math.Abs(pos.PositionSize) < types.DefaultQuantityEpsilon {
+			continue
+		}
+		positionType := types.PositionTypeLong
+		if pos.PositionSize < 0 {
+			positionType = types.PositionTypeShort
+		}
+		price := pos.NotionalValue / pos.PositionSize
+		position := types.NewPosition(pos.Symbol, pos.Symbol, positionType, math.Abs(pos.PositionSize), price, rm.DefaultLeverage)
+		position.UpdateMarkPrice(price)
+		positions = append(positions, position)
+		volatilities[pos.Symbol] = pos.Volatility
+	}
 
-	// Correlation breakdown scenario
-	correlationLoss := rm.TotalExposure * 0.10 // 10% loss if correlations increase
-	assessment.RiskMetrics["correlation_breakdown"] = correlationLoss / rm.PortfolioValue
+	for _, result := range rm.StressTest(positions, rm.PortfolioValue, volatilities, DefaultStressScenarios()) {
+		assessment.RiskMetrics["stress_"+result.Scenario.Name] = result.LossPercent
+		rm.riskMetrics.StressTestResults[result.Scenario.Name] = result.LossPercent
+		if result.MarginCall {
+			assessment.RiskFactors = append(assessment.RiskFactors, "Margin call under stress scenario "+result.Scenario.Name)
+		}
+	}
 }
 
 // generateRecommendedActions generates recommended actions based on risk assessment
@@ -722,16 +1009,26 @@ type TradeUpdate struct {
 
 // GetRiskStats returns current risk management statistics
 func (rm *RiskManager) GetRiskStats() map[string]interface{} {
+	health := rm.AssessRisk().PortfolioHealth
+
+	rm.portfolioMu.Lock()
+	defer rm.portfolioMu.Unlock()
+
 	return map[string]interface{}{
 		"portfolio_value":       rm.PortfolioValue,
 		"available_margin":      rm.AvailableMargin,
 		"used_margin":           rm.UsedMargin,
 		"total_exposure":        rm.TotalExposure,
 		"current_drawdown":      rm.CurrentDrawdown,
+		"max_drawdown_reached":  rm.MaxDrawdownReached,
+		"derisk_level":          rm.GetDeRiskLevel(),
+		"equity_high_water_mark": rm.HighWaterMark().Peak,
 		"position_count":        len(rm.positions),
 		"margin_calls":          rm.marginCalls,
-		"portfolio_health":      rm.AssessRisk().PortfolioHealth,
+		"portfolio_health":      health,
 		"overall_risk_level":    rm.calculateOverallRisk(),
 		"risk_metrics":          rm.riskMetrics,
+		"policy":                rm.GetPolicyStats(),
+		"leverage":              rm.leverageControl.GetLeverageStats(),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,293 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy rules reported in violations
+const (
+	PolicyRuleBannedSymbol  = "banned_symbol"
+	PolicyRuleMaxLeverage   = "max_leverage"
+	PolicyRuleMaxNotional   = "max_notional"
+	PolicyRuleTradingWindow = "trading_window"
+)
+
+// maxRecentViolations bounds the violation history kept for reporting
+const maxRecentViolations = 100
+
+// policyDays maps the day names accepted in trading windows
+var policyDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// RiskPolicy is a declarative set of constraints every order that adds exposure must satisfy.
+// Zero limits are not enforced.
+type RiskPolicy struct {
+	MaxLeverage         float64            `json:"max_leverage" yaml:"max_leverage"`                     // Leverage limit for symbols without their own
+	MaxLeverageBySymbol map[string]float64 `json:"max_leverage_by_symbol" yaml:"max_leverage_by_symbol"` // Symbol -> leverage limit
+	MaxNotional         float64            `json:"max_notional" yaml:"max_notional"`                     // Largest single order notional for symbols without their own
+	MaxNotionalBySymbol map[string]float64 `json:"max_notional_by_symbol" yaml:"max_notional_by_symbol"` // Symbol -> order notional limit
+	BannedSymbols       []string           `json:"banned_symbols" yaml:"banned_symbols"`                 // Symbols that may never be traded
+	TradingWindows      []TradingWindow    `json:"trading_windows" yaml:"trading_windows"`               // New exposure only inside a window (any time if empty)
+}
+
+// TradingWindow is a daily UTC time range in which orders are allowed
+type TradingWindow struct {
+	Days  []string `json:"days" yaml:"days"`   // "mon" ... "sun" (every day if empty)
+	Start string   `json:"start" yaml:"start"` // "HH:MM" UTC, inclusive
+	End   string   `json:"end" yaml:"end"`     // "HH:MM" UTC, exclusive; not after start wraps past midnight (equal covers the whole day)
+}
+
+// OrderIntent describes an order before it is sent to the exchange
+type OrderIntent struct {
+	Symbol     string          `json:"symbol"`
+	Side       types.OrderSide `json:"side"`
+	Quantity   float64         `json:"quantity"`
+	Price      float64         `json:"price"`
+	Leverage   float64         `json:"leverage"`
+	ReduceOnly bool            `json:"reduce_only"`
+	Time       time.Time       `json:"time"`
+}
+
+// PolicyViolation is a rule an order intent failed
+type PolicyViolation struct {
+	Rule    string    `json:"rule"`
+	Symbol  string    `json:"symbol"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// LoadRiskPolicy reads and validates a policy file, YAML for .yaml and .yml files and JSON otherwise;
+// unknown fields are rejected so typos cannot silently disable a limit
+func LoadRiskPolicy(path string) (*RiskPolicy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risk policy: %w", err)
+	}
+
+	var policy RiskPolicy
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&policy)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&policy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse risk policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid risk policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// Validate checks limits and trading windows
+func (p *RiskPolicy) Validate() error {
+	if p.MaxLeverage < 0 || p.MaxNotional < 0 {
+		return fmt.Errorf("limits cannot be negative")
+	}
+	for symbol, limit := range p.MaxLeverageBySymbol {
+		if limit < 0 {
+			return fmt.Errorf("max leverage for %s cannot be negative", symbol)
+		}
+	}
+	for symbol, limit := range p.MaxNotionalBySymbol {
+		if limit < 0 {
+			return fmt.Errorf("max notional for %s cannot be negative", symbol)
+		}
+	}
+	for i, window := range p.TradingWindows {
+		if _, err := parseClock(window.Start); err != nil {
+			return fmt.Errorf("trading window %d start: %w", i, err)
+		}
+		if _, err := parseClock(window.End); err != nil {
+			return fmt.Errorf("trading window %d end: %w", i, err)
+		}
+		for _, day := range window.Days {
+			if _, exists := policyDays[strings.ToLower(day)]; !exists {
+				return fmt.Errorf("trading window %d: unknown day %q", i, day)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate returns every rule the intent violates; reduce-only orders always pass so positions can be closed
+func (p *RiskPolicy) Evaluate(intent OrderIntent) []PolicyViolation {
+	if intent.ReduceOnly {
+		return nil
+	}
+
+	var violations []PolicyViolation
+	add := func(rule, format string, args ...interface{}) {
+		violations = append(violations, PolicyViolation{
+			Rule:    rule,
+			Symbol:  intent.Symbol,
+			Message: fmt.Sprintf(format, args...),
+			Time:    intent.Time,
+		})
+	}
+
+	for _, banned := range p.BannedSymbols {
+		if strings.EqualFold(banned, intent.Symbol) {
+			add(PolicyRuleBannedSymbol, "%s is banned", intent.Symbol)
+			break
+		}
+	}
+
+	if limit := symbolLimit(p.MaxLeverageBySymbol, intent.Symbol, p.MaxLeverage); limit > 0 && intent.Leverage > limit {
+		add(PolicyRuleMaxLeverage, "leverage %.1fx exceeds %.1fx", intent.Leverage, limit)
+	}
+
+	notional := intent.Quantity * intent.Price
+	if limit := symbolLimit(p.MaxNotionalBySymbol, intent.Symbol, p.MaxNotional); limit > 0 && notional > limit {
+		add(PolicyRuleMaxNotional, "order notional %.2f exceeds %.2f", notional, limit)
+	}
+
+	if len(p.TradingWindows) > 0 && !p.inTradingWindow(intent.Time) {
+		add(PolicyRuleTradingWindow, "%s UTC is outside the trading windows", intent.Time.UTC().Format("Mon 15:04"))
+	}
+
+	return violations
+}
+
+// inTradingWindow returns true if t falls inside any trading window
+func (p *RiskPolicy) inTradingWindow(t time.Time) bool {
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+
+	for _, window := range p.TradingWindows {
+		start, _ := parseClock(window.Start)
+		end, _ := parseClock(window.End)
+
+		// A window wrapping past midnight belongs to the day it starts on
+		day := t.Weekday()
+		inside := minute >= start && minute < end
+		if end <= start {
+			inside = minute >= start || minute < end
+			if minute < end {
+				day = (day + 6) % 7
+			}
+		}
+		if inside && windowHasDay(window, day) {
+			return true
+		}
+	}
+	return false
+}
+
+// windowHasDay returns true if the window applies on a weekday
+func windowHasDay(window TradingWindow, day time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, name := range window.Days {
+		if policyDays[strings.ToLower(name)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses "HH:MM" into minutes after midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// symbolLimit returns the symbol's own limit, or the fallback
+func symbolLimit(limits map[string]float64, symbol string, fallback float64) float64 {
+	if limit, exists := limits[symbol]; exists {
+		return limit
+	}
+	return fallback
+}
+
+// LoadPolicy loads a policy file and enforces it on subsequent order intents
+func (rm *RiskManager) LoadPolicy(path string) error {
+	policy, err := LoadRiskPolicy(path)
+	if err != nil {
+		return err
+	}
+
+	rm.policyMu.Lock()
+	defer rm.policyMu.Unlock()
+	rm.policy = policy
+	rm.policyPath = path
+	return nil
+}
+
+// EvaluateOrder checks an order intent against the loaded policy and counts violations
+func (rm *RiskManager) EvaluateOrder(intent OrderIntent) []PolicyViolation {
+	rm.policyMu.Lock()
+	defer rm.policyMu.Unlock()
+
+	if rm.policy == nil {
+		return nil
+	}
+	if intent.Time.IsZero() {
+		intent.Time = time.Now()
+	}
+
+	rm.policyChecks++
+	violations := rm.policy.Evaluate(intent)
+	for _, violation := range violations {
+		rm.policyViolations[violation.Rule]++
+		rm.recentViolations = append(rm.recentViolations, violation)
+	}
+	if len(rm.recentViolations) > maxRecentViolations {
+		rm.recentViolations = rm.recentViolations[len(rm.recentViolations)-maxRecentViolations:]
+	}
+	return violations
+}
+
+// GetPolicyViolations returns the most recent policy violations, oldest first
+func (rm *RiskManager) GetPolicyViolations() []PolicyViolation {
+	rm.policyMu.RLock()
+	defer rm.policyMu.RUnlock()
+
+	return append([]PolicyViolation(nil), rm.recentViolations...)
+}
+
+// GetPolicyStats returns policy enforcement statistics
+func (rm *RiskManager) GetPolicyStats() map[string]interface{} {
+	rm.policyMu.RLock()
+	defer rm.policyMu.RUnlock()
+
+	violations := make(map[string]int64, len(rm.policyViolations))
+	total := int64(0)
+	for rule, count := range rm.policyViolations {
+		violations[rule] = count
+		total += count
+	}
+
+	return map[string]interface{}{
+		"enabled":            rm.policy != nil,
+		"path":               rm.policyPath,
+		"checks":             rm.policyChecks,
+		"violations":         total,
+		"violations_by_rule": violations,
+	}
+}
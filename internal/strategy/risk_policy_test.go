@@ -0,0 +1,39 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadRiskPolicyExamples(t *testing.T) {
+	yamlPolicy, err := LoadRiskPolicy(filepath.Join("..", "..", "policy.example.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load the YAML example: %v", err)
+	}
+	jsonPolicy, err := LoadRiskPolicy(filepath.Join("..", "..", "policy.example.json"))
+	if err != nil {
+		t.Fatalf("failed to load the JSON example: %v", err)
+	}
+
+	if !reflect.DeepEqual(yamlPolicy, jsonPolicy) {
+		t.Fatalf("YAML and JSON examples differ:\n%+v\n%+v", yamlPolicy, jsonPolicy)
+	}
+	if yamlPolicy.MaxLeverageBySymbol["ETHUSDT"] != 5 || yamlPolicy.MaxNotional != 5000 || len(yamlPolicy.TradingWindows) != 2 {
+		t.Fatalf("unexpected policy %+v", yamlPolicy)
+	}
+}
+
+func TestLoadRiskPolicyRejectsUnknownYAMLFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	if err := os.WriteFile(path, []byte("max_leverage: 5\nmax_notionl: 1000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadRiskPolicy(path)
+	if err == nil || !strings.Contains(err.Error(), "max_notionl") {
+		t.Fatalf("expected the misspelled field to be rejected, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"aibot/internal/indicators"
 	"aibot/internal/types"
 	"math"
+	"sync"
 	"time"
 )
 
@@ -33,6 +34,12 @@ type PriceStabilityDetector struct {
 	// Technical analysis
 	technicalAnalyzer     *indicators.TechnicalAnalyzer
 	candleAggregator      *data.CandleAggregator
+	volatilityIndicator   string // Named ATR used for volatility instead of candle-to-candle changes
+
+	// Adaptive thresholds
+	adaptive              AdaptiveStabilityConfig
+	histories             map[string]*adaptiveHistory // Symbol -> recent samples and calibrated thresholds
+	adaptiveMu            sync.Mutex
 
 	// Performance tracking
 	totalChecks           int     `json:"total_checks"`
@@ -53,6 +60,7 @@ type StabilityCheck struct {
 	OverallScore          float64   `json:"overall_score"`
 	Reason                string    `json:"reason"`
 	Symbol                string    `json:"symbol"`
+	Thresholds            StabilityThresholds `json:"thresholds"` // Limits the scores were measured against
 }
 
 // StabilitySignal represents a detected stability condition
@@ -79,6 +87,8 @@ type StabilityConfig struct {
 	MinStabilityPeriods  int     `json:"min_stability_periods"`  // 3 consecutive checks
 	PrimaryTimeframe     string  `json:"primary_timeframe"`      // "3s"
 	SecondaryTimeframe   string  `json:"secondary_timeframe"`    // "15s"
+	VolatilityIndicator  string  `json:"volatility_indicator"`   // Named ATR, e.g. "atr_30" ("" measures candle-to-candle changes)
+	Adaptive             AdaptiveStabilityConfig `json:"adaptive"` // Calibrate the thresholds from recent history
 }
 
 // NewPriceStabilityDetector creates a new price stability detector
@@ -90,7 +100,7 @@ func NewPriceStabilityDetector(
 
 	// Set defaults
 	if config.AnalysisWindow == 0 {
-		config.AnalysisWindow = 10 // 10 candles = 3s at 300ms intervals
+		config.AnalysisWindow = 10 // 10 candles = 3s at the default 300ms base interval
 	}
 	if config.VolatilityThreshold == 0 {
 		config.VolatilityThreshold = 0.005 // 0.5%
@@ -114,6 +124,29 @@ func NewPriceStabilityDetector(
 		config.SecondaryTimeframe = "15s"
 	}
 
+	if config.Adaptive.Lookback == 0 {
+		config.Adaptive.Lookback = 24 * time.Hour
+	}
+	if config.Adaptive.SampleInterval == 0 {
+		config.Adaptive.SampleInterval = time.Minute
+	}
+	if config.Adaptive.MinSamples == 0 {
+		config.Adaptive.MinSamples = 60
+	}
+	if config.Adaptive.VolatilityPercentile == 0 {
+		config.Adaptive.VolatilityPercentile = 0.3
+	}
+	if config.Adaptive.MomentumPercentile == 0 {
+		config.Adaptive.MomentumPercentile = 0.3
+	}
+	if config.Adaptive.ConformityPercentile == 0 {
+		config.Adaptive.ConformityPercentile = 0.3
+	}
+
+	if config.VolatilityIndicator != "" {
+		analyzer.RegisterIndicator(config.VolatilityIndicator)
+	}
+
 	return &PriceStabilityDetector{
 		StabilityWindow:      config.AnalysisWindow,
 		VolatilityThreshold:  config.VolatilityThreshold,
@@ -126,6 +159,9 @@ func NewPriceStabilityDetector(
 		stabilityChecks:      make([]StabilityCheck, 0),
 		technicalAnalyzer:    analyzer,
 		candleAggregator:     aggregator,
+		volatilityIndicator:  config.VolatilityIndicator,
+		adaptive:             config.Adaptive,
+		histories:            make(map[string]*adaptiveHistory),
 	}
 }
 
@@ -164,16 +200,21 @@ func (ps *PriceStabilityDetector) performStabilityCheck(
 ) StabilityCheck {
 
 	check := StabilityCheck{
-		Timestamp: time.Now(),
-		Symbol:    symbol,
+		Timestamp:  time.Now(),
+		Symbol:     symbol,
+		Thresholds: ps.Thresholds(symbol),
 	}
 
 	// 1. Volatility Analysis
-	volatilityScore, volatility := ps.analyzeVolatility(primaryCandles)
+	volatilityScore, volatility := ps.analyzeVolatility(primaryCandles, check.Thresholds.Volatility)
+	if atr, ok := ps.namedVolatility(symbol, currentPrice); ok {
+		volatility = atr
+		volatilityScore = math.Max(0, 1.0-(volatility/check.Thresholds.Volatility))
+	}
 	check.VolatilityScore = volatilityScore
 
 	// 2. Momentum Analysis
-	momentumScore, momentum := ps.analyzeMomentum(primaryCandles)
+	momentumScore, momentum := ps.analyzeMomentum(primaryCandles, check.Thresholds.Momentum)
 	check.MomentumScore = momentumScore
 
 	// 3. Range Contraction Analysis
@@ -181,7 +222,7 @@ func (ps *PriceStabilityDetector) performStabilityCheck(
 	check.RangeContractionScore = rangeContractionScore
 
 	// 4. Price Conformity Analysis
-	priceConformityScore := ps.analyzePriceConformity(primaryCandles)
+	priceConformityScore := ps.analyzePriceConformity(primaryCandles, check.Thresholds.Conformity)
 	check.PriceConformityScore = priceConformityScore
 
 	// 5. Trend Consistency Analysis (secondary timeframe)
@@ -207,42 +248,43 @@ func (ps *PriceStabilityDetector) performStabilityCheck(
 }
 
 // analyzeVolatility measures price volatility against threshold
-func (ps *PriceStabilityDetector) analyzeVolatility(candles []types.OHLCV) (float64, float64) {
+func (ps *PriceStabilityDetector) analyzeVolatility(candles []types.OHLCV, threshold float64) (float64, float64) {
 	if len(candles) < 2 {
 		return 0.0, 0.0
 	}
 
-	// Calculate price changes
-	priceChanges := make([]float64, len(candles)-1)
-	for i := 1; i < len(candles); i++ {
-		change := (candles[i].Close - candles[i-1].Close) / candles[i-1].Close
-		priceChanges[i-1] = math.Abs(change)
-	}
-
 	// Calculate average volatility
-	avgVolatility := calculateAverage(priceChanges)
+	avgVolatility := measureVolatility(candles)
 
 	// Calculate volatility score (lower volatility = higher score)
-	score := math.Max(0, 1.0-(avgVolatility/ps.VolatilityThreshold))
+	score := math.Max(0, 1.0-(avgVolatility/threshold))
 
 	return score, avgVolatility
 }
 
+// namedVolatility returns the configured named ATR as a fraction of price, or false if unset or warming up
+func (ps *PriceStabilityDetector) namedVolatility(symbol string, currentPrice float64) (float64, bool) {
+	if ps.volatilityIndicator == "" || currentPrice <= 0 {
+		return 0, false
+	}
+	atr, ok := ps.technicalAnalyzer.GetNamedIndicator(symbol, ps.volatilityIndicator)
+	if !ok {
+		return 0, false
+	}
+	return atr / currentPrice, true
+}
+
 // analyzeMomentum measures price momentum against threshold
-func (ps *PriceStabilityDetector) analyzeMomentum(candles []types.OHLCV) (float64, float64) {
+func (ps *PriceStabilityDetector) analyzeMomentum(candles []types.OHLCV, threshold float64) (float64, float64) {
 	if len(candles) < 3 {
 		return 0.0, 0.0
 	}
 
-	// Calculate momentum over different periods
-	shortMomentum := ps.calculateMomentum(candles, 3)   // Last 3 candles
-	longMomentum := ps.calculateMomentum(candles, len(candles)/2) // Half window
-
-	// Average momentum
-	avgMomentum := (math.Abs(shortMomentum) + math.Abs(longMomentum)) / 2
+	// Average momentum over the last 3 candles and half the window
+	avgMomentum := measureMomentum(candles)
 
 	// Momentum score (lower momentum = higher score)
-	score := math.Max(0, 1.0-(avgMomentum/ps.MomentumThreshold))
+	score := math.Max(0, 1.0-(avgMomentum/threshold))
 
 	return score, avgMomentum
 }
@@ -279,34 +321,13 @@ func (ps *PriceStabilityDetector) analyzeRangeContraction(candles []types.OHLCV)
 }
 
 // analyzePriceConformity measures how well prices conform to a stable pattern
-func (ps *PriceStabilityDetector) analyzePriceConformity(candles []types.OHLCV) float64 {
+func (ps *PriceStabilityDetector) analyzePriceConformity(candles []types.OHLCV, targetCV float64) float64 {
 	if len(candles) < 5 {
 		return 0.0
 	}
 
-	// Calculate linear regression of prices
-	prices := make([]float64, len(candles))
-	for i, candle := range candles {
-		prices[i] = candle.Close
-	}
-
-	// Calculate standard deviation from mean
-	mean := calculateAverage(prices)
-	sumSquares := 0.0
-	for _, price := range prices {
-		deviation := price - mean
-		sumSquares += deviation * deviation
-	}
-	stdDev := math.Sqrt(sumSquares / float64(len(prices)))
-
 	// Coefficient of variation (lower = more conforming)
-	if mean == 0 {
-		return 0.0
-	}
-	coefficientOfVariation := stdDev / mean
-
-	// Target coefficient of variation for stability
-	targetCV := 0.01 // 1% variation target
+	coefficientOfVariation := measureDispersion(candles)
 
 	// Score based on conformity (lower CV = higher score)
 	if coefficientOfVariation <= targetCV {
@@ -337,18 +358,6 @@ func (ps *PriceStabilityDetector) analyzeTrendConsistency(candles []types.OHLCV)
 	}
 }
 
-// calculateMomentum calculates price momentum over specified period
-func (ps *PriceStabilityDetector) calculateMomentum(candles []types.OHLCV, period int) float64 {
-	if len(candles) < period || period < 2 {
-		return 0.0
-	}
-
-	startPrice := candles[len(candles)-period].Close
-	endPrice := candles[len(candles)-1].Close
-
-	return (endPrice - startPrice) / startPrice
-}
-
 // calculatePriceRange calculates the price range of candles
 func (ps *PriceStabilityDetector) calculatePriceRange(candles []types.OHLCV) float64 {
 	if len(candles) == 0 {
@@ -573,6 +582,7 @@ func (ps *PriceStabilityDetector) GetStabilityStats() map[string]interface{} {
 		"is_currently_stable":    ps.isCurrentlyStable,
 		"avg_stability_duration": ps.avgStabilityDuration.String(),
 		"recent_checks":          len(ps.stabilityChecks),
+		"adaptive":               ps.GetAdaptiveStats(),
 	}
 }
 
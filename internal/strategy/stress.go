@@ -0,0 +1,135 @@
+package strategy
+
+import (
+	"aibot/internal/types"
+	"math"
+)
+
+// DefaultStressVolatility is the adverse move per unit of volatility shock for symbols without an estimate
+const DefaultStressVolatility = 0.02
+
+// StressScenario is a market shock applied to every open position
+type StressScenario struct {
+	Name            string  `json:"name"`
+	PriceShock      float64 `json:"price_shock"`      // Move of every price, e.g. -0.2 for a 20% drop
+	VolatilityShock float64 `json:"volatility_shock"` // Adverse move of each position, in multiples of its symbol's volatility
+	Correlation     float64 `json:"correlation"`      // Correlation of the adverse moves between positions (0-1)
+}
+
+// DefaultStressScenarios returns the standard shocks: price drops, a volatility spike and the same
+// spike with every position moving against the portfolio at once
+func DefaultStressScenarios() []StressScenario {
+	return []StressScenario{
+		{Name: "price_down_10", PriceShock: -0.10},
+		{Name: "price_down_20", PriceShock: -0.20},
+		{Name: "volatility_3x", VolatilityShock: 3},
+		{Name: "volatility_3x_correlated", VolatilityShock: 3, Correlation: 1},
+	}
+}
+
+// StressPositionResult is the effect of a scenario on one position
+type StressPositionResult struct {
+	Symbol           string             `json:"symbol"`
+	PositionType     types.PositionType `json:"position_type"`
+	MarkPrice        float64            `json:"mark_price"`
+	ShockedPrice     float64            `json:"shocked_price"`
+	PnL              float64            `json:"pnl"` // Change of unrealized PnL from the mark price
+	LiquidationPrice float64            `json:"liquidation_price"`
+	Liquidated       bool               `json:"liquidated"` // The shocked price is beyond the estimated liquidation price
+}
+
+// StressResult is the effect of a scenario on the portfolio
+type StressResult struct {
+	Scenario     StressScenario         `json:"scenario"`
+	PnL          float64                `json:"pnl"`          // Portfolio PnL; adverse moves are combined using the scenario correlation
+	LossPercent  float64                `json:"loss_percent"` // Loss as a fraction of equity (0 for gains)
+	EquityBefore float64                `json:"equity_before"`
+	EquityAfter  float64                `json:"equity_after"`
+	MarginBefore float64                `json:"margin_before"` // Maintenance margin at mark prices
+	MarginAfter  float64                `json:"margin_after"`  // Maintenance margin at shocked prices
+	MarginRatio  float64                `json:"margin_ratio"`  // Maintenance margin over equity after the shock (0 if equity is wiped out)
+	MarginCall   bool                   `json:"margin_call"`
+	Positions    []StressPositionResult `json:"positions"`
+}
+
+// StressTest runs scenarios against open positions. Volatilities are per-symbol adverse move fractions;
+// symbols without one use DefaultStressVolatility.
+func (rm *RiskManager) StressTest(positions []*types.Position, equity float64, volatilities map[string]float64,
+	scenarios []StressScenario) []StressResult {
+	results := make([]StressResult, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		results = append(results, rm.runStressScenario(positions, equity, volatilities, scenario))
+	}
+	return results
+}
+
+// runStressScenario applies one scenario. The price shock moves every position deterministically; the
+// adverse volatility moves are combined as sqrt((1-ρ)·ΣLᵢ² + ρ·(ΣLᵢ)²), so ρ=1 adds them up.
+func (rm *RiskManager) runStressScenario(positions []*types.Position, equity float64, volatilities map[string]float64,
+	scenario StressScenario) StressResult {
+	result := StressResult{
+		Scenario:     scenario,
+		EquityBefore: equity,
+		Positions:    make([]StressPositionResult, 0, len(positions)),
+	}
+	correlation := math.Max(0, math.Min(1, scenario.Correlation))
+
+	var sumLoss, sumSquaredLoss float64
+	for _, position := range positions {
+		contract := position.Contract()
+		mark := position.MarkPrice
+		if mark <= 0 {
+			mark = position.EntryPrice
+		}
+		shocked := mark * (1 + scenario.PriceShock)
+		pricePnL := contract.PnL(position.Type, position.Size, mark, shocked)
+
+		volatility, exists := volatilities[position.Symbol]
+		if !exists || volatility <= 0 {
+			volatility = DefaultStressVolatility
+		}
+		move := scenario.VolatilityShock * volatility
+		final := shocked * (1 - move)
+		if position.Type == types.PositionTypeShort {
+			final = shocked * (1 + move)
+		}
+		volatilityLoss := math.Max(0, -contract.PnL(position.Type, position.Size, shocked, final))
+		sumLoss += volatilityLoss
+		sumSquaredLoss += volatilityLoss * volatilityLoss
+
+		liquidation := rm.EstimateLiquidation(position, equity)
+		positionResult := StressPositionResult{
+			Symbol:           position.Symbol,
+			PositionType:     position.Type,
+			MarkPrice:        mark,
+			ShockedPrice:     final,
+			PnL:              pricePnL - volatilityLoss,
+			LiquidationPrice: liquidation.LiquidationPrice,
+		}
+		if liquidation.LiquidationPrice > 0 {
+			if position.Type == types.PositionTypeShort {
+				positionResult.Liquidated = final >= liquidation.LiquidationPrice
+			} else {
+				positionResult.Liquidated = final <= liquidation.LiquidationPrice
+			}
+		}
+		result.Positions = append(result.Positions, positionResult)
+
+		result.PnL += pricePnL
+		result.MarginBefore += contract.Notional(position.Size, mark) * rm.MaintenanceMarginRate
+		result.MarginAfter += contract.Notional(position.Size, final) * rm.MaintenanceMarginRate
+	}
+
+	result.PnL -= math.Sqrt(math.Max(0, (1-correlation)*sumSquaredLoss+correlation*sumLoss*sumLoss))
+	result.EquityAfter = equity + result.PnL
+	if equity > 0 && result.PnL < 0 {
+		result.LossPercent = -result.PnL / equity
+	}
+	if result.EquityAfter > 0 {
+		result.MarginRatio = result.MarginAfter / result.EquityAfter
+		result.MarginCall = result.MarginRatio >= 1
+	} else {
+		result.MarginCall = len(positions) > 0
+	}
+	return result
+}
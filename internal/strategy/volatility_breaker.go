@@ -0,0 +1,230 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// VolatilityBreakerConfig holds the thresholds of the volatility circuit breaker
+type VolatilityBreakerConfig struct {
+	Multiple          float64       `json:"multiple"`           // Trip when 1s realized volatility exceeds this multiple of its baseline (0 = disabled)
+	BaselineWindow    int           `json:"baseline_window"`    // 1s returns in the rolling baseline (300)
+	MinSamples        int           `json:"min_samples"`        // 1s returns needed before the breaker can trip (60)
+	MinMove           float64       `json:"min_move"`           // 1s returns smaller than this never trip the breaker (0.0005)
+	Cooldown          time.Duration `json:"cooldown"`           // Calm time before placement resumes, and again before spacing narrows (30s)
+	SpacingMultiplier float64       `json:"spacing_multiplier"` // Grid spacing widening while the breaker is not clear (2)
+}
+
+// BreakerLevel is the stage of the volatility circuit breaker
+type BreakerLevel string
+
+const (
+	BreakerClear      BreakerLevel = "clear"      // Normal trading
+	BreakerTripped    BreakerLevel = "tripped"    // New orders suspended, grid spacing widened
+	BreakerRecovering BreakerLevel = "recovering" // Orders placed again on the widened grid
+)
+
+// BreakerState is the volatility circuit breaker state of a symbol
+type BreakerState struct {
+	Symbol     string       `json:"symbol"`
+	Level      BreakerLevel `json:"level"`
+	Volatility float64      `json:"volatility"` // Absolute log return of the current second
+	Baseline   float64      `json:"baseline"`   // Root mean square of the 1s returns in the baseline window
+	Ratio      float64      `json:"ratio"`      // Volatility / baseline
+	Reason     string       `json:"reason,omitempty"`
+	TrippedAt  time.Time    `json:"tripped_at,omitempty"`
+	ChangedAt  time.Time    `json:"changed_at,omitempty"`
+}
+
+// breakerSymbol holds the 1s returns and stage of one symbol
+type breakerSymbol struct {
+	second    time.Time // Start of the second being built
+	prevClose float64   // Last price of the second before it
+	close     float64   // Last price seen in it
+	returns   []float64 // Squared 1s returns of the baseline, oldest first
+	sumSq     float64
+	lastSpike time.Time // Last time a return exceeded the threshold
+	state     BreakerState
+}
+
+// VolatilityBreaker suspends new orders and widens the grid while 1s realized volatility spikes far above
+// its rolling baseline, so resting orders are not run over in a flash move. Orders resume on the widened
+// grid after a calm cooldown; the normal spacing returns after a second one.
+type VolatilityBreaker struct {
+	config  VolatilityBreakerConfig
+	symbols map[string]*breakerSymbol
+
+	// Statistics
+	trips int64
+
+	mu sync.RWMutex
+}
+
+// NewVolatilityBreaker creates a volatility circuit breaker
+func NewVolatilityBreaker(config VolatilityBreakerConfig) *VolatilityBreaker {
+	if config.BaselineWindow == 0 {
+		config.BaselineWindow = 300 // default
+	}
+	if config.MinSamples == 0 {
+		config.MinSamples = 60 // default
+	}
+	if config.MinMove == 0 {
+		config.MinMove = 0.0005 // default
+	}
+	if config.Cooldown == 0 {
+		config.Cooldown = 30 * time.Second // default
+	}
+	if config.SpacingMultiplier == 0 {
+		config.SpacingMultiplier = 2 // default
+	}
+
+	return &VolatilityBreaker{
+		config:  config,
+		symbols: make(map[string]*breakerSymbol),
+	}
+}
+
+// Enabled returns true if a trip multiple is configured
+func (vb *VolatilityBreaker) Enabled() bool {
+	return vb.config.Multiple > 0
+}
+
+// Update feeds the price of a tick and returns the breaker state of its symbol and whether its level changed
+func (vb *VolatilityBreaker) Update(symbol string, price float64, timestamp time.Time) (BreakerState, bool) {
+	if !vb.Enabled() || price <= 0 {
+		return BreakerState{Symbol: symbol, Level: BreakerClear}, false
+	}
+	vb.mu.Lock()
+	defer vb.mu.Unlock()
+
+	data, exists := vb.symbols[symbol]
+	if !exists {
+		data = &breakerSymbol{state: BreakerState{Symbol: symbol, Level: BreakerClear}}
+		vb.symbols[symbol] = data
+	}
+
+	second := timestamp.Truncate(time.Second)
+	switch {
+	case data.second.IsZero():
+		data.second, data.close = second, price
+	case second.After(data.second):
+		// Close the finished second; seconds without ticks had no return
+		vb.closeSecond(data, data.returnOf(data.close))
+		gap := int(second.Sub(data.second)/time.Second) - 1
+		if gap > vb.config.BaselineWindow {
+			gap = vb.config.BaselineWindow
+		}
+		for ; gap > 0; gap-- {
+			vb.closeSecond(data, 0)
+		}
+		data.prevClose, data.second = data.close, second
+	}
+	data.close = price
+
+	// The return so far this second trips the breaker before the second is over
+	state := &data.state
+	state.Volatility = math.Abs(data.returnOf(price))
+	state.Baseline = data.baseline()
+	state.Ratio = 0
+	if state.Baseline > 0 {
+		state.Ratio = state.Volatility / state.Baseline
+	}
+	spike := len(data.returns) >= vb.config.MinSamples && state.Volatility >= vb.config.MinMove &&
+		state.Volatility > vb.config.Multiple*state.Baseline
+	if spike {
+		data.lastSpike = timestamp
+	}
+
+	level := state.Level
+	switch {
+	case spike && level != BreakerTripped:
+		level = BreakerTripped
+		state.TrippedAt = timestamp
+		state.Reason = fmt.Sprintf("1s volatility %.4f%% is %.1fx the %.4f%% baseline", state.Volatility*100, state.Ratio, state.Baseline*100)
+		vb.trips++
+	case level == BreakerTripped && timestamp.Sub(data.lastSpike) >= vb.config.Cooldown:
+		level = BreakerRecovering
+	case level == BreakerRecovering && timestamp.Sub(state.ChangedAt) >= vb.config.Cooldown:
+		level = BreakerClear
+		state.Reason = ""
+	}
+	if level == state.Level {
+		return *state, false
+	}
+	state.Level = level
+	state.ChangedAt = timestamp
+	return *state, true
+}
+
+// closeSecond adds a finished 1s return to the baseline; returns of a tripped second are left out so a
+// flash move does not inflate the baseline it is measured against. Caller must hold vb.mu.
+func (vb *VolatilityBreaker) closeSecond(data *breakerSymbol, ret float64) {
+	if data.state.Level == BreakerTripped {
+		return
+	}
+	data.returns = append(data.returns, ret*ret)
+	data.sumSq += ret * ret
+	if len(data.returns) > vb.config.BaselineWindow {
+		data.sumSq -= data.returns[0]
+		data.returns = data.returns[1:]
+	}
+}
+
+// returnOf returns the log return of price over the close of the previous second
+func (data *breakerSymbol) returnOf(price float64) float64 {
+	if data.prevClose <= 0 {
+		return 0
+	}
+	return math.Log(price / data.prevClose)
+}
+
+// baseline returns the root mean square of the baseline returns
+func (data *breakerSymbol) baseline() float64 {
+	if len(data.returns) == 0 {
+		return 0
+	}
+	return math.Sqrt(math.Max(0, data.sumSq) / float64(len(data.returns)))
+}
+
+// State returns the breaker state of a symbol
+func (vb *VolatilityBreaker) State(symbol string) BreakerState {
+	vb.mu.RLock()
+	defer vb.mu.RUnlock()
+
+	if data, exists := vb.symbols[symbol]; exists {
+		return data.state
+	}
+	return BreakerState{Symbol: symbol, Level: BreakerClear}
+}
+
+// IsTripped returns true while new orders on a symbol are suspended
+func (vb *VolatilityBreaker) IsTripped(symbol string) bool {
+	return vb.State(symbol).Level == BreakerTripped
+}
+
+// Throttle returns the grid widening in effect for a symbol
+func (vb *VolatilityBreaker) Throttle(symbol string) GridThrottle {
+	if vb.State(symbol).Level == BreakerClear {
+		return GridThrottle{}
+	}
+	return GridThrottle{Level: 1, SpacingMultiplier: vb.config.SpacingMultiplier, LevelMultiplier: 1}
+}
+
+// GetVolatilityBreakerStats returns circuit breaker statistics
+func (vb *VolatilityBreaker) GetVolatilityBreakerStats() map[string]interface{} {
+	vb.mu.RLock()
+	defer vb.mu.RUnlock()
+
+	states := make(map[string]BreakerState, len(vb.symbols))
+	for symbol, data := range vb.symbols {
+		states[symbol] = data.state
+	}
+	return map[string]interface{}{
+		"enabled":  vb.Enabled(),
+		"multiple": vb.config.Multiple,
+		"trips":    vb.trips,
+		"states":   states,
+	}
+}
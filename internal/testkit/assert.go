@@ -0,0 +1,83 @@
+package testkit
+
+import (
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// ExpectSignal fails the test unless a signal of the kind was emitted and returns the first one
+func ExpectSignal(t testing.TB, signals []Signal, kind string) Signal {
+	t.Helper()
+	return ExpectSignalWithin(t, signals, kind, 0, math.MaxInt)
+}
+
+// ExpectSignalWithin fails the test unless a signal of the kind was emitted by a tick in [from, to) and
+// returns the first one; scenario marks give the tick indices
+func ExpectSignalWithin(t testing.TB, signals []Signal, kind string, from, to int) Signal {
+	t.Helper()
+	for _, signal := range signals {
+		if signal.Kind == kind && signal.Tick >= from && signal.Tick < to {
+			return signal
+		}
+	}
+	t.Fatalf("expected a %s signal between ticks %d and %d, got %s", kind, from, to, describe(signals))
+	return Signal{}
+}
+
+// ExpectNoSignal fails the test if a signal of the kind was emitted
+func ExpectNoSignal(t testing.TB, signals []Signal, kind string) {
+	t.Helper()
+	for _, signal := range signals {
+		if signal.Kind == kind {
+			t.Fatalf("expected no %s signal, got one at tick %d (price %.4f)", kind, signal.Tick, signal.Price)
+		}
+	}
+}
+
+// ExpectBreakout fails the test unless a breakout of the type was detected and returns it
+func ExpectBreakout(t testing.TB, signals []Signal, breakoutType strategy.BreakoutType) *strategy.BreakoutSignal {
+	t.Helper()
+	for _, signal := range signals {
+		if signal.Kind == SignalBreakout && signal.Breakout.Type == breakoutType {
+			return signal.Breakout
+		}
+	}
+	t.Fatalf("expected a %s breakout, got %s", breakoutType, describe(signals))
+	return nil
+}
+
+// ExpectOrderCount fails the test unless there are exactly n orders
+func ExpectOrderCount(t testing.TB, orders []*types.Order, n int) {
+	t.Helper()
+	if len(orders) != n {
+		t.Fatalf("expected %d orders, got %d", n, len(orders))
+	}
+}
+
+// ExpectOrder fails the test unless an order of the side rests within tolerance of the price and returns it
+func ExpectOrder(t testing.TB, orders []*types.Order, side types.OrderSide, price, tolerance float64) *types.Order {
+	t.Helper()
+	for _, order := range orders {
+		if order.Side == side && math.Abs(order.Price-price) <= tolerance {
+			return order
+		}
+	}
+	t.Fatalf("expected a %s order at %.4f (±%.4f) among %d orders", side, price, tolerance, len(orders))
+	return nil
+}
+
+// describe summarizes signals for failure messages
+func describe(signals []Signal) string {
+	if len(signals) == 0 {
+		return "no signals"
+	}
+	kinds := make([]string, len(signals))
+	for i, signal := range signals {
+		kinds[i] = fmt.Sprintf("%s@%d", signal.Kind, signal.Tick)
+	}
+	return strings.Join(kinds, ", ")
+}
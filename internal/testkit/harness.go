@@ -0,0 +1,197 @@
+package testkit
+
+import (
+	"aibot/internal/data"
+	"aibot/internal/indicators"
+	"aibot/internal/strategy"
+	"aibot/internal/types"
+	"math"
+	"time"
+)
+
+// Signal kinds, named like the orchestrator's trading signals
+const (
+	SignalBreakout           = "breakout"
+	SignalFalseBreakout      = "false_breakout"
+	SignalStabilityConfirmed = "stability_confirmed"
+	SignalStabilityLost      = "stability_lost"
+)
+
+// Signal is a detector result emitted while a scenario was replayed
+type Signal struct {
+	Kind          string                        `json:"kind"`
+	Tick          int                           `json:"tick"` // Index of the tick that produced the signal
+	Time          time.Time                     `json:"time"`
+	Price         float64                       `json:"price"`
+	Breakout      *strategy.BreakoutSignal      `json:"breakout,omitempty"`
+	FalseBreakout *strategy.FalseBreakoutSignal `json:"false_breakout,omitempty"`
+	Stability     *strategy.StabilitySignal     `json:"stability,omitempty"`
+}
+
+// HarnessConfig holds the detector configuration a scenario is replayed through
+type HarnessConfig struct {
+	Symbol          string                       `json:"symbol"`          // BTCUSDT
+	BaseInterval    time.Duration                `json:"base_interval"`   // Aggregator feed interval (data.DefaultBaseInterval)
+	MinCandles      int                          `json:"min_candles"`     // Analyzer warm-up before detection starts (0 = indicator warm-up only)
+	VolumeLookback  int                          `json:"volume_lookback"` // 1s candles averaged for false breakout volume (20)
+	Stability       bool                         `json:"stability"`       // Also run the stability detector on every tick
+	Breakout        strategy.BreakoutConfig      `json:"breakout"`
+	FalseBreakout   strategy.FalseBreakoutConfig `json:"false_breakout"`
+	StabilityConfig strategy.StabilityConfig     `json:"stability_config"`
+}
+
+// breakoutEntry is the breakout a harness follows for false breakout detection
+type breakoutEntry struct {
+	breakoutType strategy.BreakoutType
+	price        float64
+	time         time.Time
+}
+
+// Harness wires the candle aggregator, the technical analyzer and the detectors like the orchestrator
+// does and collects the signals they emit
+type Harness struct {
+	config HarnessConfig
+
+	Aggregator    *data.CandleAggregator
+	Analyzer      *indicators.TechnicalAnalyzer
+	Breakout      *strategy.BreakoutDetector
+	FalseBreakout *strategy.FalseBreakoutDetector
+	Stability     *strategy.PriceStabilityDetector
+
+	bounds  strategy.GridBounds
+	entry   *breakoutEntry // Breakout being watched for a reversal, nil while the grid is active
+	stable  bool
+	ticks   int
+	signals []Signal
+}
+
+// NewHarness creates a harness
+func NewHarness(config HarnessConfig) *Harness {
+	if config.Symbol == "" {
+		config.Symbol = "BTCUSDT" // default
+	}
+	if config.VolumeLookback == 0 {
+		config.VolumeLookback = 20 // default
+	}
+
+	aggregator := data.NewCandleAggregator(data.AggregatorConfig{
+		BaseInterval: config.BaseInterval,
+		MaxHistory:   100,
+		Timeframes:   []data.CandleTimeframe{data.Timeframe1s, data.Timeframe3s, data.Timeframe15s},
+		Symbols:      []string{config.Symbol},
+	})
+	analyzer := indicators.NewTechnicalAnalyzer(indicators.AnalyzerConfig{
+		MaxHistoryCandles: 100,
+		MinCandles:        config.MinCandles,
+	})
+
+	falseBreakout := strategy.NewFalseBreakoutDetector(config.FalseBreakout)
+	falseBreakout.SetDivergenceSource(analyzer)
+
+	return &Harness{
+		config:        config,
+		Aggregator:    aggregator,
+		Analyzer:      analyzer,
+		Breakout:      strategy.NewBreakoutDetector(config.Breakout, aggregator, analyzer),
+		FalseBreakout: falseBreakout,
+		Stability:     strategy.NewPriceStabilityDetector(config.StabilityConfig, analyzer, aggregator),
+		signals:       make([]Signal, 0),
+	}
+}
+
+// SetGrid sets the grid bounds breakouts are detected against
+func (h *Harness) SetGrid(lower, upper float64) {
+	h.bounds = strategy.GridBounds{
+		UpperBound: upper,
+		LowerBound: lower,
+		Center:     (upper + lower) / 2,
+		Range:      upper - lower,
+	}
+}
+
+// Run replays every tick of a scenario and returns all signals emitted so far
+func (h *Harness) Run(scenario *Scenario) []Signal {
+	for _, tick := range scenario.Ticks() {
+		h.Step(tick)
+	}
+	return h.Signals()
+}
+
+// Step feeds one tick: candles and indicators are updated, then breakouts are detected while the grid is
+// active and false breakouts while a breakout is followed; a false breakout returns to the grid
+func (h *Harness) Step(tick types.Ticker) {
+	index := h.ticks
+	h.ticks++
+
+	h.Aggregator.AddTick(tick)
+	h.Analyzer.AddCandle(types.OHLCV{
+		Symbol:    tick.Symbol,
+		Timestamp: tick.Timestamp,
+		Open:      tick.Price,
+		High:      tick.Price,
+		Low:       tick.Price,
+		Close:     tick.Price,
+		Volume:    tick.Volume,
+	})
+	if tick.Symbol != h.config.Symbol || !h.Analyzer.IsReady(h.config.Symbol) {
+		return
+	}
+
+	if h.entry == nil && h.bounds.UpperBound > 0 {
+		if signal := h.Breakout.DetectBreakout(h.config.Symbol, h.bounds, tick.Price); signal != nil {
+			h.emit(Signal{Kind: SignalBreakout, Tick: index, Time: tick.Timestamp, Price: tick.Price, Breakout: signal})
+			h.entry = &breakoutEntry{breakoutType: signal.Type, price: tick.Price, time: tick.Timestamp}
+		}
+	} else if h.entry != nil {
+		atr, averageVolume := h.falseBreakoutInputs()
+		signal := h.FalseBreakout.DetectFalseBreakout(h.config.Symbol, h.entry.price, tick.Price, h.entry.breakoutType,
+			atr, averageVolume, tick.Timestamp.Sub(h.entry.time))
+		if signal != nil {
+			h.emit(Signal{Kind: SignalFalseBreakout, Tick: index, Time: tick.Timestamp, Price: tick.Price, FalseBreakout: signal})
+			h.entry = nil
+		}
+	}
+
+	if h.config.Stability {
+		h.Stability.Observe(h.config.Symbol, tick.Timestamp)
+		signal := h.Stability.AnalyzeStability(h.config.Symbol, tick.Price)
+		if signal.IsStable != h.stable {
+			h.stable = signal.IsStable
+			kind := SignalStabilityLost
+			if signal.IsStable {
+				kind = SignalStabilityConfirmed
+			}
+			h.emit(Signal{Kind: kind, Tick: index, Time: tick.Timestamp, Price: tick.Price, Stability: signal})
+		}
+	}
+}
+
+// Signals returns the signals emitted so far
+func (h *Harness) Signals() []Signal {
+	signals := make([]Signal, len(h.signals))
+	copy(signals, h.signals)
+	return signals
+}
+
+// emit records a signal
+func (h *Harness) emit(signal Signal) {
+	h.signals = append(h.signals, signal)
+}
+
+// falseBreakoutInputs returns the ATR and the recent average 1s volume, like the orchestrator
+func (h *Harness) falseBreakoutInputs() (float64, float64) {
+	atr := 0.0
+	if values := h.Analyzer.GetIndicatorValues(h.config.Symbol); values != nil && !math.IsNaN(values.ATR) {
+		atr = values.ATR
+	}
+
+	averageVolume := 0.0
+	candles := h.Aggregator.GetCandles(h.config.Symbol, data.Timeframe1s, h.config.VolumeLookback)
+	for _, candle := range candles {
+		averageVolume += candle.Volume
+	}
+	if len(candles) > 0 {
+		averageVolume /= float64(len(candles))
+	}
+	return atr, averageVolume
+}
@@ -0,0 +1,100 @@
+package testkit_test
+
+import (
+	"aibot/internal/strategy"
+	"aibot/internal/testkit"
+	"testing"
+)
+
+// rangeThenBreak scripts a quiet range around 50000 followed by a breakout move of change on heavy volume
+func rangeThenBreak(change float64) *testkit.Scenario {
+	return testkit.NewScenario(testkit.ScenarioConfig{}).
+		Range(200, 0.002).
+		Mark("breakout").
+		Volume(5).
+		Trend(30, change)
+}
+
+// gridHarness creates a harness with a grid just outside the scripted range
+func gridHarness(config testkit.HarnessConfig) *testkit.Harness {
+	harness := testkit.NewHarness(config)
+	harness.SetGrid(49800, 50200)
+	return harness
+}
+
+func TestBreakoutDetectorUp(t *testing.T) {
+	scenario := rangeThenBreak(0.02)
+	signals := gridHarness(testkit.HarnessConfig{}).Run(scenario)
+
+	testkit.ExpectSignalWithin(t, signals, testkit.SignalBreakout, scenario.MarkIndex("breakout"), len(scenario.Ticks()))
+	breakout := testkit.ExpectBreakout(t, signals, strategy.BreakoutTypeUp)
+	if breakout.Price <= 50200 {
+		t.Fatalf("expected the breakout above the upper bound, got %.2f", breakout.Price)
+	}
+}
+
+func TestBreakoutDetectorDown(t *testing.T) {
+	scenario := rangeThenBreak(-0.02)
+	signals := gridHarness(testkit.HarnessConfig{}).Run(scenario)
+
+	testkit.ExpectSignalWithin(t, signals, testkit.SignalBreakout, scenario.MarkIndex("breakout"), len(scenario.Ticks()))
+	breakout := testkit.ExpectBreakout(t, signals, strategy.BreakoutTypeDown)
+	if breakout.Price >= 49800 {
+		t.Fatalf("expected the breakout below the lower bound, got %.2f", breakout.Price)
+	}
+}
+
+func TestBreakoutDetectorIgnoresRange(t *testing.T) {
+	scenario := testkit.NewScenario(testkit.ScenarioConfig{}).Range(400, 0.002)
+	signals := gridHarness(testkit.HarnessConfig{}).Run(scenario)
+
+	testkit.ExpectNoSignal(t, signals, testkit.SignalBreakout)
+}
+
+func TestFalseBreakoutDetectorReversal(t *testing.T) {
+	// A sharp spike out of the range that falls straight back in on fading volume
+	scenario := testkit.NewScenario(testkit.ScenarioConfig{}).
+		Range(200, 0.002).
+		Volume(5).
+		Trend(10, 0.03).
+		Mark("reversal").
+		Volume(1).
+		Trend(10, -0.03).
+		Hold(20)
+	signals := gridHarness(testkit.HarnessConfig{}).Run(scenario)
+
+	testkit.ExpectBreakout(t, signals, strategy.BreakoutTypeUp)
+	for _, signal := range signals {
+		if signal.Kind == testkit.SignalFalseBreakout && signal.Tick >= scenario.MarkIndex("reversal") &&
+			signal.FalseBreakout.SignalType == strategy.FalseBreakoutQuickReversal {
+			return
+		}
+	}
+	t.Fatalf("expected a quick reversal after tick %d", scenario.MarkIndex("reversal"))
+}
+
+func TestFalseBreakoutDetectorFollowThrough(t *testing.T) {
+	// Price keeps running away from the range too fast to count as consolidation
+	scenario := testkit.NewScenario(testkit.ScenarioConfig{}).
+		Range(200, 0.002).
+		Volume(5).
+		Trend(60, 0.18)
+	signals := gridHarness(testkit.HarnessConfig{}).Run(scenario)
+
+	testkit.ExpectSignal(t, signals, testkit.SignalBreakout)
+	testkit.ExpectNoSignal(t, signals, testkit.SignalFalseBreakout)
+}
+
+func TestStabilityDetectorConfirmsAndLoses(t *testing.T) {
+	scenario := testkit.NewScenario(testkit.ScenarioConfig{}).
+		Range(300, 0.0005).
+		Mark("move").
+		Trend(60, 0.05)
+	signals := testkit.NewHarness(testkit.HarnessConfig{Stability: true}).Run(scenario)
+
+	confirmed := testkit.ExpectSignalWithin(t, signals, testkit.SignalStabilityConfirmed, 0, scenario.MarkIndex("move"))
+	lost := testkit.ExpectSignalWithin(t, signals, testkit.SignalStabilityLost, scenario.MarkIndex("move"), len(scenario.Ticks()))
+	if !confirmed.Stability.IsStable || lost.Stability.IsStable {
+		t.Fatalf("expected stable then unstable, got %v then %v", confirmed.Stability.IsStable, lost.Stability.IsStable)
+	}
+}
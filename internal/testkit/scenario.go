@@ -0,0 +1,206 @@
+// Package testkit scripts deterministic market scenarios and replays them through the strategy detectors,
+// so detector behavior can be asserted in tests without a feed or an exchange.
+package testkit
+
+import (
+	"aibot/internal/data"
+	"aibot/internal/types"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// rangeCycle is the number of ticks of one up-and-down swing in a range segment
+const rangeCycle = 20
+
+// ScenarioConfig holds the starting point of a scripted scenario
+type ScenarioConfig struct {
+	Symbol   string        `json:"symbol"`   // BTCUSDT
+	Start    time.Time     `json:"start"`    // Time of the first tick (2024-01-01 00:00 UTC)
+	Interval time.Duration `json:"interval"` // Time between ticks (data.DefaultBaseInterval)
+	Price    float64       `json:"price"`    // Starting price (50000)
+	Volume   float64       `json:"volume"`   // Volume per tick (1)
+	Noise    float64       `json:"noise"`    // Standard deviation of random price noise per tick, as a fraction of price (0)
+	Seed     int64         `json:"seed"`     // Noise seed, so the same script always yields the same ticks (1)
+}
+
+// Scenario is a scripted sequence of ticks built from market segments
+type Scenario struct {
+	config ScenarioConfig
+	ticks  []types.Ticker
+	marks  map[string]int
+	price  float64 // Price of the last tick, without noise
+	volume float64 // Volume of the following ticks
+	now    time.Time
+	rng    *rand.Rand
+}
+
+// NewScenario creates an empty scenario
+func NewScenario(config ScenarioConfig) *Scenario {
+	if config.Symbol == "" {
+		config.Symbol = "BTCUSDT" // default
+	}
+	if config.Start.IsZero() {
+		config.Start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // default
+	}
+	if config.Interval == 0 {
+		config.Interval = data.DefaultBaseInterval // default
+	}
+	if config.Price == 0 {
+		config.Price = 50000 // default
+	}
+	if config.Volume == 0 {
+		config.Volume = 1 // default
+	}
+	if config.Seed == 0 {
+		config.Seed = 1 // default
+	}
+
+	return &Scenario{
+		config: config,
+		ticks:  make([]types.Ticker, 0),
+		marks:  make(map[string]int),
+		price:  config.Price,
+		volume: config.Volume,
+		now:    config.Start,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// Hold keeps the price flat for a number of ticks
+func (s *Scenario) Hold(ticks int) *Scenario {
+	for i := 0; i < ticks; i++ {
+		s.emit(s.price)
+	}
+	return s
+}
+
+// Range oscillates around the current price for a number of ticks; width is the full swing as a fraction
+// of the price
+func (s *Scenario) Range(ticks int, width float64) *Scenario {
+	center := s.price
+	for i := 1; i <= ticks; i++ {
+		// Triangle wave starting at the center: up to +width/2, down to -width/2, back to the center
+		phase := float64(i%rangeCycle) / rangeCycle
+		s.emit(center * (1 + width/2*triangle(phase)))
+	}
+	return s
+}
+
+// Trend moves the price linearly by change (a fraction, negative for a downtrend) over a number of ticks
+func (s *Scenario) Trend(ticks int, change float64) *Scenario {
+	start := s.price
+	for i := 1; i <= ticks; i++ {
+		s.emit(start * (1 + change*float64(i)/float64(ticks)))
+	}
+	return s
+}
+
+// VReversal drops the price by depth (a fraction; negative for an inverted V) over the first half of the
+// ticks and recovers to the starting price over the second half
+func (s *Scenario) VReversal(ticks int, depth float64) *Scenario {
+	down := ticks / 2
+	start := s.price
+	s.Trend(down, -depth)
+	bottom := s.price
+	return s.Trend(ticks-down, start/bottom-1)
+}
+
+// Gap skips the feed for pause and resumes with the price moved by change, as after a disconnect or halt
+func (s *Scenario) Gap(change float64, pause time.Duration) *Scenario {
+	s.now = s.now.Add(pause)
+	s.emit(s.price * (1 + change))
+	return s
+}
+
+// Volume sets the volume per tick of the following segments
+func (s *Scenario) Volume(volume float64) *Scenario {
+	s.volume = volume
+	return s
+}
+
+// Mark names the index of the next tick, so assertions can refer to a segment
+func (s *Scenario) Mark(name string) *Scenario {
+	s.marks[name] = len(s.ticks)
+	return s
+}
+
+// MarkIndex returns the tick index of a mark, or -1 if the mark is unknown
+func (s *Scenario) MarkIndex(name string) int {
+	index, ok := s.marks[name]
+	if !ok {
+		return -1
+	}
+	return index
+}
+
+// Symbol returns the scenario's symbol
+func (s *Scenario) Symbol() string {
+	return s.config.Symbol
+}
+
+// Price returns the price the script has reached, without noise
+func (s *Scenario) Price() float64 {
+	return s.price
+}
+
+// Ticks returns the scripted ticks
+func (s *Scenario) Ticks() []types.Ticker {
+	ticks := make([]types.Ticker, len(s.ticks))
+	copy(ticks, s.ticks)
+	return ticks
+}
+
+// Candles aggregates the ticks into candles of the given interval, aligned to the scenario start
+func (s *Scenario) Candles(interval time.Duration) []types.OHLCV {
+	candles := make([]types.OHLCV, 0)
+	for _, tick := range s.ticks {
+		bucket := s.config.Start.Add(tick.Timestamp.Sub(s.config.Start) / interval * interval)
+		if n := len(candles); n > 0 && candles[n-1].Timestamp.Equal(bucket) {
+			candle := &candles[n-1]
+			candle.High = math.Max(candle.High, tick.Price)
+			candle.Low = math.Min(candle.Low, tick.Price)
+			candle.Close = tick.Price
+			candle.Volume += tick.Volume
+			continue
+		}
+		candles = append(candles, types.OHLCV{
+			Symbol:    tick.Symbol,
+			Timestamp: bucket,
+			Open:      tick.Price,
+			High:      tick.Price,
+			Low:       tick.Price,
+			Close:     tick.Price,
+			Volume:    tick.Volume,
+		})
+	}
+	return candles
+}
+
+// emit appends a tick at the given price plus noise and advances the clock
+func (s *Scenario) emit(price float64) {
+	s.price = price
+	if s.config.Noise > 0 {
+		price *= 1 + s.rng.NormFloat64()*s.config.Noise
+	}
+	s.ticks = append(s.ticks, types.Ticker{
+		Symbol:    s.config.Symbol,
+		Timestamp: s.now,
+		Price:     price,
+		Volume:    s.volume,
+	})
+	s.now = s.now.Add(s.config.Interval)
+}
+
+// triangle returns a triangle wave of period 1 that starts at 0, peaks at 1 at a quarter and bottoms at
+// -1 at three quarters
+func triangle(phase float64) float64 {
+	switch {
+	case phase < 0.25:
+		return 4 * phase
+	case phase < 0.75:
+		return 2 - 4*phase
+	default:
+		return 4*phase - 4
+	}
+}
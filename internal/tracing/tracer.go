@@ -0,0 +1,334 @@
+// Package tracing traces the tick-to-order pipeline with OpenTelemetry: a sampled tick starts a trace whose
+// spans follow it through the indicators, signal detection, risk checks and order submission, and finished
+// spans are sent to the configured exporter.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span exporters
+const (
+	ExporterNone   = "none"   // Latency statistics only
+	ExporterStdout = "stdout" // One JSON span per line in the output file, or on standard output
+	ExporterOTLP   = "otlp"   // OTLP over HTTP to a collector
+)
+
+// instrumentationName names the tracer in exported spans
+const instrumentationName = "aibot/internal/bot"
+
+// TracerConfig holds configuration for pipeline tracing
+type TracerConfig struct {
+	Enabled     bool   `json:"enabled"`
+	SampleEvery int    `json:"sample_every"` // Trace one in N root events, e.g. ticks (1)
+	Exporter    string `json:"exporter"`     // "stdout", "otlp" or "none" (stdout with an output file, none without)
+	OutputFile  string `json:"output_file"`  // File the stdout exporter appends to ("" writes to standard output)
+	Endpoint    string `json:"endpoint"`     // Collector host:port of the otlp exporter (OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4318)
+	Insecure    bool   `json:"insecure"`     // Send OTLP over plain HTTP
+	ServiceName string `json:"service_name"` // service.name of exported spans (aibot)
+}
+
+// Span is one timed step of a trace; spans of one tick share its trace ID, so an order can be followed
+// back to the tick that caused it. Methods of a nil span do nothing.
+type Span struct {
+	span trace.Span
+}
+
+// spanStats aggregates the latency of spans sharing a name
+type spanStats struct {
+	count  int64
+	errors int64
+	total  time.Duration
+	max    time.Duration
+}
+
+// Tracer creates spans on an OpenTelemetry tracer provider. A nil tracer, and spans outside a sampled
+// trace, are no-ops.
+type Tracer struct {
+	config   TracerConfig
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+	exporter *countingExporter // nil without an exporter
+	output   io.Closer         // File of the stdout exporter; nil on standard output
+	seen     atomic.Int64
+
+	// Statistics
+	stats  map[string]*spanStats
+	traces int64
+
+	mu sync.Mutex
+}
+
+// NewTracer creates a tracer; it returns nil when tracing is disabled
+func NewTracer(config TracerConfig) (*Tracer, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	if config.SampleEvery == 0 {
+		config.SampleEvery = 1 // default
+	}
+	if config.Exporter == "" {
+		config.Exporter = ExporterNone // default
+		if config.OutputFile != "" {
+			config.Exporter = ExporterStdout
+		}
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = "aibot" // default
+	}
+
+	t := &Tracer{
+		config: config,
+		stats:  make(map[string]*spanStats),
+	}
+	exporter, err := t.newExporter()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sampling happens when a trace starts, so every span the tracer creates is recorded
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", config.ServiceName))),
+		sdktrace.WithSpanProcessor(statsProcessor{t}),
+	}
+	if exporter != nil {
+		t.exporter = &countingExporter{SpanExporter: exporter}
+		options = append(options, sdktrace.WithBatcher(t.exporter))
+	}
+	t.provider = sdktrace.NewTracerProvider(options...)
+	t.tracer = t.provider.Tracer(instrumentationName)
+	return t, nil
+}
+
+// newExporter creates the configured span exporter; nil exports nothing
+func (t *Tracer) newExporter() (sdktrace.SpanExporter, error) {
+	switch t.config.Exporter {
+	case ExporterNone:
+		return nil, nil
+
+	case ExporterStdout:
+		var output io.Writer = os.Stdout
+		if t.config.OutputFile != "" {
+			if err := os.MkdirAll(filepath.Dir(t.config.OutputFile), 0755); err != nil {
+				return nil, fmt.Errorf("failed to create trace directory: %w", err)
+			}
+			file, err := os.OpenFile(t.config.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open trace file: %w", err)
+			}
+			output, t.output = file, file
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(output))
+
+	case ExporterOTLP:
+		var options []otlptracehttp.Option
+		if t.config.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(t.config.Endpoint))
+		}
+		if t.config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), options...)
+
+	default:
+		return nil, fmt.Errorf("unknown span exporter %q: use stdout, otlp or none", t.config.Exporter)
+	}
+}
+
+// StartTrace starts the root span of a new trace if this event is sampled
+func (t *Tracer) StartTrace(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	if (t.seen.Add(1)-1)%int64(t.config.SampleEvery) != 0 {
+		return ctx, nil
+	}
+
+	t.mu.Lock()
+	t.traces++
+	t.mu.Unlock()
+
+	ctx, span := t.tracer.Start(ctx, name, trace.WithNewRoot())
+	return ctx, &Span{span: span}
+}
+
+// Start starts a child of the span in ctx; without a sampled parent no span is created
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil || !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, nil
+	}
+
+	ctx, span := t.tracer.Start(ctx, name)
+	return ctx, &Span{span: span}
+}
+
+// TraceIDFromContext returns the trace ID of the active span, or ""
+func TraceIDFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// SetAttribute records a key/value on the span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	var kv attribute.KeyValue
+	switch v := value.(type) {
+	case string:
+		kv = attribute.String(key, v)
+	case bool:
+		kv = attribute.Bool(key, v)
+	case int:
+		kv = attribute.Int(key, v)
+	case int64:
+		kv = attribute.Int64(key, v)
+	case float64:
+		kv = attribute.Float64(key, v)
+	case time.Time:
+		kv = attribute.String(key, v.Format(time.RFC3339Nano))
+	case fmt.Stringer:
+		kv = attribute.String(key, v.String())
+	default:
+		kv = attribute.String(key, fmt.Sprint(v))
+	}
+	s.span.SetAttributes(kv)
+}
+
+// RecordError marks the span as failed
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End finishes the span; later calls are ignored
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
+
+// statsProcessor aggregates the latency of finished spans
+type statsProcessor struct {
+	tracer *Tracer
+}
+
+func (p statsProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p statsProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	t := p.tracer
+	duration := span.EndTime().Sub(span.StartTime())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, exists := t.stats[span.Name()]
+	if !exists {
+		stats = &spanStats{}
+		t.stats[span.Name()] = stats
+	}
+	stats.count++
+	stats.total += duration
+	if duration > stats.max {
+		stats.max = duration
+	}
+	if span.Status().Code == codes.Error {
+		stats.errors++
+	}
+}
+
+func (p statsProcessor) Shutdown(context.Context) error   { return nil }
+func (p statsProcessor) ForceFlush(context.Context) error { return nil }
+
+// countingExporter counts the spans its exporter sent and the batches it failed to send
+type countingExporter struct {
+	sdktrace.SpanExporter
+	exported atomic.Int64
+	failures atomic.Int64
+}
+
+func (e *countingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if err := e.SpanExporter.ExportSpans(ctx, spans); err != nil {
+		e.failures.Add(1)
+		return err
+	}
+	e.exported.Add(int64(len(spans)))
+	return nil
+}
+
+// Close flushes the spans waiting for export and shuts the exporter down
+func (t *Tracer) Close() error {
+	if t == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := t.provider.Shutdown(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.output != nil {
+		if closeErr := t.output.Close(); err == nil {
+			err = closeErr
+		}
+		t.output = nil
+	}
+	return err
+}
+
+// GetTracingStats returns per-span latency statistics
+func (t *Tracer) GetTracingStats() map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	spans := make(map[string]interface{}, len(t.stats))
+	for name, stats := range t.stats {
+		spans[name] = map[string]interface{}{
+			"count":       stats.count,
+			"errors":      stats.errors,
+			"avg_latency": stats.total / time.Duration(stats.count),
+			"max_latency": stats.max,
+		}
+	}
+
+	stats := map[string]interface{}{
+		"enabled":      true,
+		"exporter":     t.config.Exporter,
+		"sample_every": t.config.SampleEvery,
+		"traces":       t.traces,
+		"spans":        spans,
+	}
+	if t.exporter != nil {
+		stats["exported_spans"] = t.exporter.exported.Load()
+		stats["export_failures"] = t.exporter.failures.Load()
+	}
+	return stats
+}
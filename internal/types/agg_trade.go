@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+)
+
+// AggTrade is an aggregated trade: the fills of one taker order at a single price
+type AggTrade struct {
+	Symbol       string    `json:"symbol"`
+	Timestamp    time.Time `json:"timestamp"`
+	Price        float64   `json:"price"`
+	Quantity     float64   `json:"quantity"`
+	IsBuyerMaker bool      `json:"is_buyer_maker"` // The buyer's order was resting, so the taker sold
+	AggTradeID   int64     `json:"agg_trade_id"`   // Increases per symbol; 0 when the source has no IDs
+	FirstTradeID int64     `json:"first_trade_id"`
+	LastTradeID  int64     `json:"last_trade_id"`
+}
+
+// IsTakerBuy returns true if the trade was initiated by a buyer taking the ask
+func (t AggTrade) IsTakerBuy() bool {
+	return !t.IsBuyerMaker
+}
+
+// GetNotional returns price * quantity
+func (t AggTrade) GetNotional() float64 {
+	return t.Price * t.Quantity
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+// ContractType represents how a futures contract is margined and settled
+type ContractType string
+
+const (
+	ContractTypeLinear  ContractType = "linear"  // Quote-margined (USDT-M): quantity in base units, PnL in quote
+	ContractTypeInverse ContractType = "inverse" // Coin-margined (COIN-M): quantity in contracts worth a fixed quote amount, PnL in base
+)
+
+// ContractSpec describes the contract traded for a symbol
+type ContractSpec struct {
+	Type       ContractType `json:"type"`       // "linear" or "inverse" (linear)
+	Multiplier float64      `json:"multiplier"` // Quote value of one inverse contract (e.g. 100 USD), base units per linear unit (1)
+	Expiry     time.Time    `json:"expiry"`     // Settlement of a dated (delivery) contract; zero for perpetuals
+	StepSize   float64      `json:"step_size"`  // Quantity increment, 0 if unknown
+	TickSize   float64      `json:"tick_size"`  // Price increment, 0 if unknown
+}
+
+// IsInverse returns true for coin-margined contracts
+func (c ContractSpec) IsInverse() bool {
+	return c.Type == ContractTypeInverse
+}
+
+// Precision returns the quantity and price increments of the contract
+func (c ContractSpec) Precision() Precision {
+	return Precision{StepSize: c.StepSize, TickSize: c.TickSize}
+}
+
+// multiplier returns the contract multiplier, defaulting to 1
+func (c ContractSpec) multiplier() float64 {
+	if c.Multiplier <= 0 {
+		return 1
+	}
+	return c.Multiplier
+}
+
+// Notional returns the value of a quantity in the margin currency: quote for linear, base for inverse
+func (c ContractSpec) Notional(quantity, price float64) float64 {
+	if c.IsInverse() {
+		if price <= 0 {
+			return 0
+		}
+		return quantity * c.multiplier() / price
+	}
+	return quantity * price * c.multiplier()
+}
+
+// PnL returns the profit of moving a position from entry to exit price, in the margin currency
+func (c ContractSpec) PnL(positionType PositionType, quantity, entryPrice, exitPrice float64) float64 {
+	var pnl float64
+	if c.IsInverse() {
+		if entryPrice <= 0 || exitPrice <= 0 {
+			return 0
+		}
+		pnl = quantity * c.multiplier() * (1/entryPrice - 1/exitPrice)
+	} else {
+		pnl = (exitPrice - entryPrice) * quantity * c.multiplier()
+	}
+
+	if positionType == PositionTypeShort {
+		return -pnl
+	}
+	return pnl
+}
+
+// LiquidationPrice returns the price at which collateral plus the position's loss falls to the maintenance
+// margin, or 0 if the position cannot be liquidated
+func (c ContractSpec) LiquidationPrice(positionType PositionType, quantity, entryPrice, collateral, maintenanceRate float64) float64 {
+	if quantity <= 0 || entryPrice <= 0 {
+		return 0
+	}
+	value := quantity * c.multiplier()
+
+	var price float64
+	if c.IsInverse() {
+		// Collateral and maintenance margin are in base: value/price is the position's base notional
+		if positionType == PositionTypeShort {
+			denominator := value/entryPrice - collateral
+			if denominator <= 0 {
+				return 0
+			}
+			price = value * (1 - maintenanceRate) / denominator
+		} else {
+			price = value * (1 + maintenanceRate) / (collateral + value/entryPrice)
+		}
+	} else {
+		if positionType == PositionTypeShort {
+			price = (collateral + value*entryPrice) / (value * (1 + maintenanceRate))
+		} else {
+			price = (value*entryPrice - collateral) / (value * (1 - maintenanceRate))
+		}
+	}
+
+	if price <= 0 {
+		return 0
+	}
+	return price
+}
+
+// AverageEntry returns the entry price after adding to a position; inverse contracts average harmonically
+// so the combined position has the same base value as its parts
+func (c ContractSpec) AverageEntry(size, entryPrice, added, price float64) float64 {
+	total := size + added
+	if total <= 0 {
+		return price
+	}
+	if c.IsInverse() {
+		if entryPrice <= 0 || price <= 0 {
+			return math.Max(entryPrice, price)
+		}
+		return total / (size/entryPrice + added/price)
+	}
+	return (size*entryPrice + added*price) / total
+}
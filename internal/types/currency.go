@@ -0,0 +1,36 @@
+package types
+
+import (
+	"strings"
+)
+
+// KnownQuoteAssets lists the quote assets symbols are split by when no quote asset is configured
+var KnownQuoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "DAI", "USD", "EUR", "BTC", "ETH", "BNB"}
+
+// stablecoins are the assets pegged 1:1 to the US dollar
+var stablecoins = map[string]bool{
+	"USD":   true,
+	"USDT":  true,
+	"USDC":  true,
+	"BUSD":  true,
+	"TUSD":  true,
+	"FDUSD": true,
+	"DAI":   true,
+}
+
+// QuoteAsset returns the quote asset of a symbol such as ETHBTC by the longest known suffix, or "" if none matches
+func QuoteAsset(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	quote := ""
+	for _, asset := range KnownQuoteAssets {
+		if len(asset) > len(quote) && len(symbol) > len(asset) && strings.HasSuffix(symbol, asset) {
+			quote = asset
+		}
+	}
+	return quote
+}
+
+// IsStablecoin returns true for assets pegged 1:1 to the US dollar
+func IsStablecoin(asset string) bool {
+	return stablecoins[strings.ToUpper(asset)]
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"strings"
+)
+
+// MarketType represents the kind of market an account trades
+type MarketType string
+
+const (
+	MarketTypeFutures MarketType = "futures" // Leveraged perpetual futures with long and short positions
+	MarketTypeSpot    MarketType = "spot"    // Spot pairs: long-only inventory, no leverage
+)
+
+// IsSpot returns true for spot markets
+func (m MarketType) IsSpot() bool {
+	return m == MarketTypeSpot
+}
+
+// SplitSymbol splits a symbol such as BTCUSDT into base and quote assets
+func SplitSymbol(symbol, quoteAsset string) (string, string) {
+	if quoteAsset != "" && strings.HasSuffix(symbol, quoteAsset) && len(symbol) > len(quoteAsset) {
+		return strings.TrimSuffix(symbol, quoteAsset), quoteAsset
+	}
+	return symbol, quoteAsset
+}
@@ -6,13 +6,16 @@ import (
 
 // OHLCV represents Open, High, Low, Close, Volume data
 type OHLCV struct {
-	Symbol    string    `json:"symbol"`
-	Timestamp time.Time `json:"timestamp"`
-	Open      float64   `json:"open"`
-	High      float64   `json:"high"`
-	Low       float64   `json:"low"`
-	Close     float64   `json:"close"`
-	Volume    float64   `json:"volume"`
+	Symbol     string    `json:"symbol"`
+	Timestamp  time.Time `json:"timestamp"`
+	Open       float64   `json:"open"`
+	High       float64   `json:"high"`
+	Low        float64   `json:"low"`
+	Close      float64   `json:"close"`
+	Volume     float64   `json:"volume"`
+	BuyVolume  float64   `json:"buy_volume,omitempty"`  // Taker buy volume, from aggregated trades
+	SellVolume float64   `json:"sell_volume,omitempty"` // Taker sell volume, from aggregated trades
+	Synthetic  bool      `json:"synthetic,omitempty"`   // Carry-forward candle generated to fill a data gap
 }
 
 // NewOHLCV creates a new OHLCV instance
@@ -53,6 +56,24 @@ func (o OHLCV) GetOHLC4() float64 {
 	return (o.Open + o.High + o.Low + o.Close) / 4
 }
 
+// HasVolumeFlow returns true if the volume is split into taker buys and sells
+func (o OHLCV) HasVolumeFlow() bool {
+	return o.BuyVolume+o.SellVolume > 0
+}
+
+// GetVolumeDelta returns taker buy volume minus taker sell volume
+func (o OHLCV) GetVolumeDelta() float64 {
+	return o.BuyVolume - o.SellVolume
+}
+
+// GetFlowImbalance returns the volume delta as a fraction of the split volume (-1 all sells, +1 all buys)
+func (o OHLCV) GetFlowImbalance() float64 {
+	if !o.HasVolumeFlow() {
+		return 0
+	}
+	return o.GetVolumeDelta() / (o.BuyVolume + o.SellVolume)
+}
+
 // GetRange returns the price range (high - low)
 func (o OHLCV) GetRange() float64 {
 	return o.High - o.Low
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -20,8 +22,20 @@ const (
 	OrderTypeLimit  OrderType = "limit"
 	OrderTypeStop   OrderType = "stop"
 	OrderTypeStopLimit OrderType = "stop_limit"
+	OrderTypeTrailingStop OrderType = "trailing_stop"
 )
 
+// TriggerPriceType selects the price that triggers a conditional order
+type TriggerPriceType string
+
+const (
+	TriggerPriceLast TriggerPriceType = "last" // Last traded price (default)
+	TriggerPriceMark TriggerPriceType = "mark" // Mark price, which resists wicks on thin order books
+)
+
+// TimeInForcePostOnly is the time in force of post-only (good till crossing) orders
+const TimeInForcePostOnly = "GTX"
+
 // OrderStatus represents the status of an order
 type OrderStatus string
 
@@ -51,10 +65,15 @@ type Order struct {
 	UpdateTime    time.Time     `json:"update_time"`
 	FillTime      *time.Time    `json:"fill_time,omitempty"`
 	PositionType  PositionType  `json:"position_type"` // "long" or "short"
-	StopPrice     float64       `json:"stop_price,omitempty"` // For stop orders
-	TimeInForce   string        `json:"time_in_force"` // "GTC", "IOC", "FOK"
+	StopPrice     float64       `json:"stop_price,omitempty"` // For stop orders; trailing stops move it with the market
+	TriggerPrice  TriggerPriceType `json:"trigger_price,omitempty"` // Price compared with the stop price ("last" if empty)
+	CallbackRate  float64       `json:"callback_rate,omitempty"` // Trailing stop distance from the best price, as a fraction
+	ActivationPrice float64     `json:"activation_price,omitempty"` // Trailing starts once this price is reached (immediately if 0)
+	Triggered     bool          `json:"triggered,omitempty"` // A stop-limit order's stop was hit and its limit order is working
+	TimeInForce   string        `json:"time_in_force"` // "GTC", "IOC", "FOK", "GTX" (post-only)
 	ReduceOnly    bool          `json:"reduce_only"`
 	ClientOrderID string        `json:"client_order_id,omitempty"`
+	Tag           string        `json:"tag,omitempty"` // Component that created the order (see NewOrderTag)
 }
 
 // NewOrder creates a new order
@@ -90,6 +109,53 @@ func NewLimitOrder(id, symbol string, side OrderSide, quantity, price float64, p
 	return NewOrder(id, symbol, side, OrderTypeLimit, quantity, price, positionType)
 }
 
+// NewTrailingStopOrder creates a market order that triggers once the trigger price retraces callbackRate from
+// its best level since activation
+func NewTrailingStopOrder(id, symbol string, side OrderSide, quantity, callbackRate, activationPrice float64, positionType PositionType) *Order {
+	order := NewOrder(id, symbol, side, OrderTypeTrailingStop, quantity, 0, positionType)
+	order.CallbackRate = callbackRate
+	order.ActivationPrice = activationPrice
+	return order
+}
+
+// IsConditional returns true for orders that wait for a trigger price before executing
+func (o *Order) IsConditional() bool {
+	return o.Type == OrderTypeStop || o.Type == OrderTypeStopLimit || o.Type == OrderTypeTrailingStop
+}
+
+// StopReached returns true if a trigger price reaches the stop price: sell stops trigger at or below it,
+// buy stops at or above it. Trailing stops are never reached before they are activated.
+func (o *Order) StopReached(price float64) bool {
+	if o.StopPrice <= 0 {
+		return false
+	}
+	if o.IsBuy() {
+		return price >= o.StopPrice
+	}
+	return price <= o.StopPrice
+}
+
+// TrailStop moves a trailing stop's stop price behind the best trigger price seen since activation
+func (o *Order) TrailStop(price float64) {
+	if o.Type != OrderTypeTrailingStop || price <= 0 {
+		return
+	}
+	if o.StopPrice == 0 && o.ActivationPrice > 0 {
+		// Not active yet: sell stops activate at or above the activation price, buy stops at or below it
+		if (o.IsSell() && price < o.ActivationPrice) || (o.IsBuy() && price > o.ActivationPrice) {
+			return
+		}
+	}
+
+	if o.IsSell() {
+		if stop := price * (1 - o.CallbackRate); stop > o.StopPrice {
+			o.StopPrice = stop
+		}
+	} else if stop := price * (1 + o.CallbackRate); o.StopPrice == 0 || stop < o.StopPrice {
+		o.StopPrice = stop
+	}
+}
+
 // IsBuy returns true if this is a buy order
 func (o *Order) IsBuy() bool {
 	return o.Side == OrderSideBuy
@@ -171,11 +237,53 @@ func (o *Order) SetStopPrice(stopPrice float64) {
 	o.StopPrice = stopPrice
 }
 
+// NewClientOrderID builds a deterministic client order ID from the placing strategy, level, side and nonce.
+// Retrying a request with the same ID lets the executor recognise it instead of creating a duplicate order.
+func NewClientOrderID(strategy string, level int, side OrderSide, nonce int64) string {
+	return fmt.Sprintf("%s-%d-%s-%d", strategy, level, side, nonce)
+}
+
+// StrategyFromClientOrderID returns the strategy family that placed an order, e.g. "grid" for
+// "grid-3-buy-12" and "grid-unload-0-sell-13", or "" for IDs not built by NewClientOrderID
+func StrategyFromClientOrderID(clientOrderID string) string {
+	if separator := strings.Index(clientOrderID, "-"); separator > 0 {
+		return clientOrderID[:separator]
+	}
+	return ""
+}
+
+// NewOrderTag attributes an order to the component that created it and the part of that component, e.g.
+// "grid:level-3", "breakout:entry-tier-1" or "recovery:flatten"
+func NewOrderTag(component, detail string) string {
+	if detail == "" {
+		return component
+	}
+	return component + ":" + detail
+}
+
+// ComponentFromTag returns the component of an order tag, e.g. "grid" for "grid:level-3"
+func ComponentFromTag(tag string) string {
+	if separator := strings.Index(tag, ":"); separator >= 0 {
+		return tag[:separator]
+	}
+	return tag
+}
+
 // SetReduceOnly sets the reduce-only flag
 func (o *Order) SetReduceOnly(reduceOnly bool) {
 	o.ReduceOnly = reduceOnly
 }
 
+// SetPostOnly makes a limit order post-only: it is rejected instead of taking liquidity on arrival
+func (o *Order) SetPostOnly() {
+	o.TimeInForce = TimeInForcePostOnly
+}
+
+// IsPostOnly returns true if the order may only add liquidity
+func (o *Order) IsPostOnly() bool {
+	return o.TimeInForce == TimeInForcePostOnly
+}
+
 // GetEffectivePrice returns the effective price including fees
 func (o *Order) GetEffectivePrice() float64 {
 	if o.AvgFillPrice == 0 {
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"time"
+)
+
+// OrderUpdate represents an asynchronous order event pushed by an executor
+type OrderUpdate struct {
+	OrderID       string       `json:"order_id"`
+	ClientOrderID string       `json:"client_order_id,omitempty"`
+	Symbol        string       `json:"symbol"`
+	Side          OrderSide    `json:"side"`
+	Type          OrderType    `json:"type"`
+	PositionType  PositionType `json:"position_type"`
+	Status        OrderStatus  `json:"status"`        // "partial", "filled", "cancelled", "rejected"
+	Quantity      float64      `json:"quantity"`      // Original order quantity
+	LastFillQty   float64      `json:"last_fill_qty"` // Quantity filled by this event
+	LastFillPrice float64      `json:"last_fill_price"`
+	FilledQty     float64      `json:"filled_qty"` // Cumulative filled quantity
+	AvgFillPrice  float64      `json:"avg_fill_price"`
+	Fee           float64      `json:"fee"`          // Fee charged for this event
+	RealizedPnL   float64      `json:"realized_pnl"` // PnL realized by this event
+	ReduceOnly    bool         `json:"reduce_only"`
+	Reason        string       `json:"reason,omitempty"`
+	Tag           string       `json:"tag,omitempty"` // Tag of the order, attributing the event to the component that created it
+	Timestamp     time.Time    `json:"timestamp"`
+}
+
+// NewOrderUpdate creates an order update from the current state of an order
+func NewOrderUpdate(order *Order, lastFillQty, lastFillPrice, fee float64) OrderUpdate {
+	return OrderUpdate{
+		OrderID:       order.ID,
+		ClientOrderID: order.ClientOrderID,
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Type:          order.Type,
+		PositionType:  order.PositionType,
+		Status:        order.Status,
+		Quantity:      order.Quantity,
+		LastFillQty:   lastFillQty,
+		LastFillPrice: lastFillPrice,
+		FilledQty:     order.FilledQty,
+		AvgFillPrice:  order.AvgFillPrice,
+		Fee:           fee,
+		ReduceOnly:    order.ReduceOnly,
+		Tag:           order.Tag,
+		Timestamp:     order.UpdateTime,
+	}
+}
+
+// IsFill returns true if the update carries executed quantity
+func (u *OrderUpdate) IsFill() bool {
+	return u.LastFillQty > 0 && (u.Status == OrderStatusFilled || u.Status == OrderStatusPartial)
+}
+
+// IsOpening returns true if the fill increases exposure in its position direction
+func (u *OrderUpdate) IsOpening() bool {
+	if u.ReduceOnly {
+		return false
+	}
+	return (u.PositionType == PositionTypeLong && u.Side == OrderSideBuy) ||
+		(u.PositionType == PositionTypeShort && u.Side == OrderSideSell)
+}
+
+// GetFillNotional returns the notional value of the last fill
+func (u *OrderUpdate) GetFillNotional() float64 {
+	return u.LastFillQty * u.LastFillPrice
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math"
 	"time"
 )
 
@@ -28,6 +29,10 @@ type Position struct {
 	EntryTime    time.Time     `json:"entry_time"`
 	ExitTime     *time.Time    `json:"exit_time,omitempty"`
 	Status       string        `json:"status"` // "open", "closed", "partial"
+	ContractType ContractType  `json:"contract_type,omitempty"` // "" or "linear" for quote-margined contracts
+	ContractSize float64       `json:"contract_size,omitempty"` // Contract multiplier (see ContractSpec)
+	StepSize     float64       `json:"step_size,omitempty"`     // Quantity increment of the symbol, 0 if unknown
+	TickSize     float64       `json:"tick_size,omitempty"`     // Price increment of the symbol, 0 if unknown
 }
 
 // NewPosition creates a new position
@@ -48,6 +53,31 @@ func NewPosition(id, symbol string, posType PositionType, size, entryPrice, leve
 	}
 }
 
+// Contract returns the contract specification of the position
+func (p *Position) Contract() ContractSpec {
+	return ContractSpec{Type: p.ContractType, Multiplier: p.ContractSize, StepSize: p.StepSize, TickSize: p.TickSize}
+}
+
+// SetContract sets the contract specification and recalculates margin and PnL
+func (p *Position) SetContract(spec ContractSpec) {
+	p.ContractType = spec.Type
+	p.ContractSize = spec.Multiplier
+	p.StepSize = spec.StepSize
+	p.TickSize = spec.TickSize
+	p.UpdateMargin()
+	if p.MarkPrice > 0 {
+		p.calculateUnrealizedPnL()
+	}
+}
+
+// UpdateMargin recalculates the margin for the current size and entry price
+func (p *Position) UpdateMargin() {
+	if p.Leverage <= 0 {
+		return
+	}
+	p.Margin = p.Contract().Notional(p.Size, p.EntryPrice) / p.Leverage
+}
+
 // UpdateMarkPrice updates the mark price and recalculates unrealized PnL
 func (p *Position) UpdateMarkPrice(markPrice float64) {
 	p.MarkPrice = markPrice
@@ -56,13 +86,7 @@ func (p *Position) UpdateMarkPrice(markPrice float64) {
 
 // calculateUnrealizedPnL calculates the unrealized profit/loss
 func (p *Position) calculateUnrealizedPnL() {
-	priceDiff := p.MarkPrice - p.EntryPrice
-
-	if p.Type == PositionTypeLong {
-		p.UnrealizedPnL = priceDiff * p.Size
-	} else {
-		p.UnrealizedPnL = -priceDiff * p.Size
-	}
+	p.UnrealizedPnL = p.Contract().PnL(p.Type, p.Size, p.EntryPrice, p.MarkPrice)
 }
 
 // GetUnrealizedPnLPercentage returns unrealized PnL as percentage of margin
@@ -96,25 +120,26 @@ func (p *Position) PartialClose(closeSize float64, exitPrice float64, exitFee fl
 	}
 
 	// Calculate PnL for the closed portion
-	priceDiff := exitPrice - p.EntryPrice
-	var closedPnL float64
-	if p.Type == PositionTypeLong {
-		closedPnL = priceDiff * closeSize
-	} else {
-		closedPnL = -priceDiff * closeSize
-	}
+	closedPnL := p.Contract().PnL(p.Type, closeSize, p.EntryPrice, exitPrice)
 
 	// Update position
 	p.RealizedPnL += closedPnL
 	p.FeePaid += exitFee
 	p.Size -= closeSize
 
-	if p.Size <= 0.001 { // Threshold for rounding errors
+	if p.IsFlat() {
+		p.Size = 0
 		p.Status = "closed"
 		p.ExitTime = &[]time.Time{time.Now()}[0]
 	}
 }
 
+// IsFlat returns true if the size is below half a quantity step, i.e. only rounding residue that the
+// exchange does not hold
+func (p *Position) IsFlat() bool {
+	return p.Contract().Precision().IsZeroQuantity(p.Size)
+}
+
 // GetDuration returns how long the position has been open
 func (p *Position) GetDuration() time.Duration {
 	if p.ExitTime != nil {
@@ -134,4 +159,90 @@ func (p *Position) GetROI() float64 {
 		return 0
 	}
 	return (p.RealizedPnL / p.Margin) * 100
-}
\ No newline at end of file
+}
+// PositionKey returns the key of one side of a symbol's position in hedge mode
+func PositionKey(symbol string, positionType PositionType) string {
+	return symbol + ":" + string(positionType)
+}
+
+// HedgePosition groups the independently tracked long and short sides of a symbol
+type HedgePosition struct {
+	Symbol string    `json:"symbol"`
+	Long   *Position `json:"long,omitempty"`
+	Short  *Position `json:"short,omitempty"`
+}
+
+// NetSize returns long size minus short size
+func (h *HedgePosition) NetSize() float64 {
+	net := float64(0)
+	if h.Long != nil {
+		net += h.Long.Size
+	}
+	if h.Short != nil {
+		net -= h.Short.Size
+	}
+	return net
+}
+
+// GetUnrealizedPnL returns the combined unrealized PnL of both sides
+func (h *HedgePosition) GetUnrealizedPnL() float64 {
+	total := float64(0)
+	for _, side := range h.sides() {
+		total += side.UnrealizedPnL
+	}
+	return total
+}
+
+// GetRealizedPnL returns the combined realized PnL of both sides
+func (h *HedgePosition) GetRealizedPnL() float64 {
+	total := float64(0)
+	for _, side := range h.sides() {
+		total += side.RealizedPnL
+	}
+	return total
+}
+
+// GetMargin returns the combined margin locked by both sides
+func (h *HedgePosition) GetMargin() float64 {
+	total := float64(0)
+	for _, side := range h.sides() {
+		total += side.Margin
+	}
+	return total
+}
+
+// Net collapses both sides into a single net position, or nil if no side is open
+func (h *HedgePosition) Net() *Position {
+	sides := h.sides()
+	if len(sides) == 0 {
+		return nil
+	}
+
+	// Direction and entry follow the larger side
+	dominant := sides[0]
+	if len(sides) == 2 && h.Short.Size > h.Long.Size {
+		dominant = h.Short
+	}
+	net := *dominant
+	net.Size = math.Abs(h.NetSize())
+	net.UnrealizedPnL = h.GetUnrealizedPnL()
+	net.RealizedPnL = h.GetRealizedPnL()
+	net.Margin = h.GetMargin()
+	net.FeePaid = 0
+	for _, side := range sides {
+		net.FeePaid += side.FeePaid
+	}
+	return &net
+}
+
+// sides returns the open sides, long first
+func (h *HedgePosition) sides() []*Position {
+	sides := make([]*Position, 0, 2)
+	if h.Long != nil {
+		sides = append(sides, h.Long)
+	}
+	if h.Short != nil {
+		sides = append(sides, h.Short)
+	}
+	return sides
+}
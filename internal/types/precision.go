@@ -0,0 +1,69 @@
+package types
+
+import "math"
+
+const (
+	DefaultQuantityEpsilon = 1e-9 // Quantity rounding absorbed when a symbol's step size is unknown
+	DefaultPriceEpsilon    = 1e-9 // Price rounding absorbed when a symbol's tick size is unknown
+)
+
+// Precision holds the quantity and price increments a symbol trades in. Quantities and prices closer
+// than half an increment cannot differ on the exchange, so that is the tolerance they are compared with.
+type Precision struct {
+	StepSize float64 `json:"step_size"` // Quantity increment, 0 if unknown
+	TickSize float64 `json:"tick_size"` // Price increment, 0 if unknown
+}
+
+// QuantityEpsilon returns the tolerance for comparing quantities
+func (p Precision) QuantityEpsilon() float64 {
+	if p.StepSize > 0 {
+		return p.StepSize / 2
+	}
+	return DefaultQuantityEpsilon
+}
+
+// PriceEpsilon returns the tolerance for comparing prices
+func (p Precision) PriceEpsilon() float64 {
+	if p.TickSize > 0 {
+		return p.TickSize / 2
+	}
+	return DefaultPriceEpsilon
+}
+
+// IsZeroQuantity returns true if a quantity is rounding residue rather than a tradable amount
+func (p Precision) IsZeroQuantity(quantity float64) bool {
+	return math.Abs(quantity) < p.QuantityEpsilon()
+}
+
+// QuantityEqual returns true if two quantities are the same amount on the exchange
+func (p Precision) QuantityEqual(a, b float64) bool {
+	return NearlyEqual(a, b, p.QuantityEpsilon())
+}
+
+// PriceEqual returns true if two prices are the same price on the exchange
+func (p Precision) PriceEqual(a, b float64) bool {
+	return NearlyEqual(a, b, p.PriceEpsilon())
+}
+
+// RoundQuantity rounds a quantity to the nearest step; quantities are unchanged if the step is unknown
+func (p Precision) RoundQuantity(quantity float64) float64 {
+	return roundToIncrement(quantity, p.StepSize)
+}
+
+// RoundPrice rounds a price to the nearest tick; prices are unchanged if the tick is unknown
+func (p Precision) RoundPrice(price float64) float64 {
+	return roundToIncrement(price, p.TickSize)
+}
+
+// roundToIncrement rounds value to the nearest multiple of increment
+func roundToIncrement(value, increment float64) float64 {
+	if increment <= 0 {
+		return value
+	}
+	return math.Round(value/increment) * increment
+}
+
+// NearlyEqual returns true if a and b differ by less than epsilon
+func NearlyEqual(a, b, epsilon float64) bool {
+	return math.Abs(a-b) < epsilon
+}
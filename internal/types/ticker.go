@@ -9,6 +9,7 @@ type Ticker struct {
 	Symbol    string    `json:"symbol"`
 	Timestamp time.Time `json:"timestamp"`
 	Price     float64   `json:"price"`
+	MarkPrice float64   `json:"mark_price,omitempty"` // Exchange mark price for futures (Price when not reported)
 	Volume    float64   `json:"volume"`
 	Bid       float64   `json:"bid"`       // Best bid price
 	Ask       float64   `json:"ask"`       // Best ask price
@@ -30,6 +31,14 @@ func NewTicker(symbol string, timestamp time.Time, price, volume float64) *Ticke
 	}
 }
 
+// GetTriggerPrice returns the price that conditional orders with the given trigger type compare against
+func (t *Ticker) GetTriggerPrice(trigger TriggerPriceType) float64 {
+	if trigger == TriggerPriceMark && t.MarkPrice > 0 {
+		return t.MarkPrice
+	}
+	return t.Price
+}
+
 // GetSpread returns the bid-ask spread
 func (t *Ticker) GetSpread() float64 {
 	return t.Ask - t.Bid
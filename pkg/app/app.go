@@ -0,0 +1,266 @@
+package app
+
+import (
+	"aibot/internal/bot"
+	"aibot/internal/config"
+	"aibot/internal/logging"
+	"aibot/pkg/stream"
+	"aibot/pkg/trading"
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Config is the application configuration, aliased so programs embedding the bot can load and adjust it
+type Config = config.Config
+
+// LoadConfig loads and validates a configuration file, creating it with defaults if it does not exist
+func LoadConfig(path string) (*Config, error) {
+	return config.LoadConfig(path)
+}
+
+// DefaultConfig returns the default configuration
+func DefaultConfig() *Config {
+	return config.DefaultConfig()
+}
+
+// Dependencies are the components an application runs with; nil components are created from the configuration
+type Dependencies struct {
+	Logger          *logging.Logger
+	StreamProvider  stream.StreamProvider
+	TradingExecutor trading.TradingExecutor
+}
+
+// Application is one bot instance with its own configuration, logger, market data stream, executor
+// and orchestrator, so several instances can run in the same process
+type Application struct {
+	config          *Config
+	logger          *logging.Logger
+	ownLogger       bool // The logger was created for the application and is closed on shutdown
+	streamProvider  stream.StreamProvider
+	tradingExecutor trading.TradingExecutor
+	orchestrator    *bot.Orchestrator
+	checkpoints     *backtestCheckpointer // nil unless a replayed backtest is checkpointed
+
+	shutdownTimeout time.Duration
+}
+
+// New creates an application, creating the components missing from deps
+func New(cfg *Config, deps Dependencies) (*Application, error) {
+	return newApplication(cfg, deps, DefaultDataDir)
+}
+
+// newApplication creates an application keeping its journals and checkpoints under dataDir
+func newApplication(cfg *Config, deps Dependencies, dataDir string) (*Application, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration is required")
+	}
+
+	app := &Application{
+		config:          cfg,
+		logger:          deps.Logger,
+		streamProvider:  deps.StreamProvider,
+		tradingExecutor: deps.TradingExecutor,
+		shutdownTimeout: 5 * time.Second,
+	}
+	logging.RegisterSecrets(cfg.Secrets()...)
+	if app.logger == nil {
+		app.logger = logging.NewLogger(cfg.Logging)
+		app.ownLogger = true
+	}
+
+	app.logger.Info("Initializing application components")
+	if cfg.Screener.AutoSelect {
+		app.selectSymbols()
+	}
+
+	var err error
+	if app.streamProvider == nil {
+		app.streamProvider, err = NewStreamProvider(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stream provider: %w", err)
+		}
+	}
+	if app.tradingExecutor == nil {
+		app.tradingExecutor, err = NewTradingExecutor(cfg.Trading)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trading executor: %w", err)
+		}
+	}
+
+	botConfig, err := newBotConfig(cfg, dataDir)
+	if err != nil {
+		return nil, err
+	}
+	botConfig.LogLevels = app.logger.Levels()
+	app.orchestrator, err = bot.NewOrchestrator(botConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create orchestrator: %w", err)
+	}
+	if err := app.setupCheckpoints(botConfig, dataDir); err != nil {
+		return nil, fmt.Errorf("failed to set up backtest checkpoints: %w", err)
+	}
+
+	app.logger.Info("Components initialized successfully")
+	return app, nil
+}
+
+// setupCheckpoints checkpoints a backtest replayed into the simulation executor when a checkpoint
+// interval is configured
+func (app *Application) setupCheckpoints(botConfig *bot.BotConfig, dataDir string) error {
+	provider, replayed := app.streamProvider.(*stream.ReplayProvider)
+	executor, simulated := app.tradingExecutor.(*trading.SimulationExecutor)
+	if app.config.Backtest.CheckpointInterval <= 0 || !replayed || !simulated {
+		return nil
+	}
+
+	path := app.config.Backtest.CheckpointPath
+	if path == "" {
+		path = filepath.Join(dataDir, "journal", "backtest_checkpoint.json")
+	}
+	run := BacktestRun{
+		ConfigHash: botConfig.SessionConfig.Hash,
+		Symbols:    app.config.Backtest.Symbols,
+		StartTime:  app.config.Backtest.StartTime,
+		EndTime:    app.config.Backtest.EndTime,
+	}
+	checkpoints, err := newBacktestCheckpointer(path, run, provider, executor, orchestratorCheckpoint{app.orchestrator}, app.logger)
+	if err != nil {
+		return err
+	}
+	app.checkpoints = checkpoints
+	return nil
+}
+
+// selectSymbols replaces the traded symbols with the top symbols of the day's screen; the configured
+// symbols are kept if the screen fails or selects nothing
+func (app *Application) selectSymbols() {
+	report, err := NewScreener(app.config).SelectDaily(context.Background(), app.config.Screener.Directory, time.Now())
+	if err != nil {
+		app.logger.Warnf("Symbol screening failed, trading %s: %v", app.config.Trading.DefaultSymbol, err)
+		return
+	}
+	if len(report.Selected) == 0 {
+		app.logger.Warnf("No symbol passed the screener, trading %s", app.config.Trading.DefaultSymbol)
+		return
+	}
+
+	app.config.Trading.SupportedSymbols = report.Selected
+	app.config.Trading.DefaultSymbol = report.Selected[0]
+	app.logger.Infof("Screener selected %v for %s, trading %s", report.Selected,
+		report.GeneratedAt.Format("2006-01-02"), app.config.Trading.DefaultSymbol)
+}
+
+// Run creates an application from the configuration and runs it until ctx is cancelled
+func Run(ctx context.Context, cfg *Config) error {
+	app, err := New(cfg, Dependencies{})
+	if err != nil {
+		return err
+	}
+	return app.Run(ctx)
+}
+
+// Run starts trading and blocks until ctx is cancelled or the bot is stopped by a control command,
+// then shuts down gracefully
+func (app *Application) Run(ctx context.Context) error {
+	app.logger.Info("Starting trading bot")
+	// Connect before starting, so readiness probes see the executor's real state
+	if err := app.tradingExecutor.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect trading executor: %w", err)
+	}
+	if app.checkpoints != nil {
+		// A backtest stopped before its end carries on from its latest checkpoint
+		if _, err := app.checkpoints.resume(); err != nil {
+			return fmt.Errorf("failed to resume backtest: %w", err)
+		}
+		if err := app.checkpoints.arm(ctx); err != nil {
+			return fmt.Errorf("failed to arm backtest checkpoints: %w", err)
+		}
+	}
+	if err := app.orchestrator.Start(app.streamProvider, app.tradingExecutor); err != nil {
+		return fmt.Errorf("failed to start orchestrator: %w", err)
+	}
+	app.logger.Info("Trading bot started successfully")
+
+	var replayed <-chan struct{} // nil blocks, so live feeds run until cancelled
+	if provider, ok := app.streamProvider.(stream.FiniteProvider); ok {
+		replayed = provider.Done()
+	}
+
+	select {
+	case <-ctx.Done():
+		app.logger.Info("Context cancelled")
+	case <-app.orchestrator.Done():
+		app.logger.Info("Orchestrator stopped by control command")
+	case <-replayed:
+		app.orchestrator.WaitDataProcessed(ctx)
+		app.logger.Info("Market data replay finished")
+		app.finishCheckpoints()
+	}
+
+	return app.Shutdown()
+}
+
+// finishCheckpoints removes the checkpoint of a backtest replayed to its end
+func (app *Application) finishCheckpoints() {
+	provider, ok := app.streamProvider.(*stream.ReplayProvider)
+	if app.checkpoints == nil || !ok || !provider.Complete() {
+		return
+	}
+	if err := app.checkpoints.finish(); err != nil {
+		app.logger.Warnf("Failed to remove backtest checkpoint: %v", err)
+	}
+}
+
+// Shutdown stops the orchestrator, the stream provider and the trading executor, then closes the
+// application's own logger
+func (app *Application) Shutdown() error {
+	app.logger.Info("Starting graceful shutdown")
+	if app.ownLogger {
+		defer app.logger.Close()
+	}
+
+	shutdownErrors := make(chan error, 1)
+	go func() {
+		defer close(shutdownErrors)
+
+		app.logger.Info("Stopping orchestrator")
+		if err := app.orchestrator.Stop(); err != nil {
+			shutdownErrors <- fmt.Errorf("failed to stop orchestrator: %w", err)
+			return
+		}
+
+		app.logger.Info("Stopping stream provider")
+		if err := app.streamProvider.Stop(); err != nil {
+			shutdownErrors <- fmt.Errorf("failed to stop stream provider: %w", err)
+			return
+		}
+
+		app.logger.Info("Disconnecting trading executor")
+		if err := app.tradingExecutor.Disconnect(); err != nil {
+			shutdownErrors <- fmt.Errorf("failed to disconnect trading executor: %w", err)
+			return
+		}
+
+		app.logger.Info("Shutdown completed successfully")
+	}()
+
+	select {
+	case err := <-shutdownErrors:
+		return err
+	case <-time.After(app.shutdownTimeout):
+		app.logger.Warn("Shutdown timeout reached")
+		return fmt.Errorf("shutdown timeout")
+	}
+}
+
+// Orchestrator returns the bot orchestrator
+func (app *Application) Orchestrator() *bot.Orchestrator {
+	return app.orchestrator
+}
+
+// Config returns the application configuration
+func (app *Application) Config() *Config {
+	return app.config
+}
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"aibot/internal/bot"
+	"aibot/internal/journal"
+	"aibot/internal/logging"
+	"aibot/pkg/stream"
+	"aibot/pkg/trading"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// BacktestCheckpoint is the state of a backtest when a checkpoint was taken: how far the replay had got,
+// the simulated account and the strategy state
+type BacktestCheckpoint struct {
+	Run      BacktestRun             `json:"run"`
+	Progress stream.ReplayProgress   `json:"progress"`
+	Executor trading.SimulationState `json:"executor"`
+	Strategy json.RawMessage         `json:"strategy"`
+	SavedAt  time.Time               `json:"saved_at"`
+}
+
+// BacktestRun identifies a backtest; a checkpoint is only resumed by a run with the same identity
+type BacktestRun struct {
+	ConfigHash string    `json:"config_hash"`
+	Symbols    []string  `json:"symbols"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+// matches returns true if other is the same backtest
+func (r BacktestRun) matches(other BacktestRun) bool {
+	if r.ConfigHash != other.ConfigHash || !r.StartTime.Equal(other.StartTime) || !r.EndTime.Equal(other.EndTime) ||
+		len(r.Symbols) != len(other.Symbols) {
+		return false
+	}
+	for i, symbol := range r.Symbols {
+		if other.Symbols[i] != symbol {
+			return false
+		}
+	}
+	return true
+}
+
+// checkpointStrategy is the strategy side of a backtest checkpoint
+type checkpointStrategy interface {
+	// waitProcessed blocks until the events delivered up to progress have been processed, or ctx is done
+	waitProcessed(ctx context.Context, progress stream.ReplayProgress)
+	saveState() (json.RawMessage, error)
+	restoreState(state json.RawMessage) error
+}
+
+// orchestratorCheckpoint checkpoints the orchestrator's snapshot
+type orchestratorCheckpoint struct {
+	orchestrator *bot.Orchestrator
+}
+
+func (c orchestratorCheckpoint) waitProcessed(ctx context.Context, _ stream.ReplayProgress) {
+	c.orchestrator.WaitDataProcessed(ctx)
+}
+
+func (c orchestratorCheckpoint) saveState() (json.RawMessage, error) {
+	return json.Marshal(c.orchestrator.Snapshot())
+}
+
+func (c orchestratorCheckpoint) restoreState(state json.RawMessage) error {
+	var snapshot bot.OrchestratorSnapshot
+	if err := json.Unmarshal(state, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode orchestrator snapshot: %w", err)
+	}
+	return c.orchestrator.RestoreSnapshot(&snapshot)
+}
+
+// backtestCheckpointer saves checkpoints of a replayed backtest as the replay crosses the checkpoint
+// interval and resumes a stopped backtest from its latest checkpoint
+type backtestCheckpointer struct {
+	store    *journal.CheckpointStore
+	run      BacktestRun
+	provider *stream.ReplayProvider
+	executor *trading.SimulationExecutor
+	strategy checkpointStrategy
+	logger   *logging.Logger
+}
+
+// newBacktestCheckpointer creates a checkpointer keeping the latest checkpoint at path
+func newBacktestCheckpointer(path string, run BacktestRun, provider *stream.ReplayProvider, executor *trading.SimulationExecutor,
+	strategy checkpointStrategy, logger *logging.Logger) (*backtestCheckpointer, error) {
+	store, err := journal.NewCheckpointStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return &backtestCheckpointer{
+		store:    store,
+		run:      run,
+		provider: provider,
+		executor: executor,
+		strategy: strategy,
+		logger:   logger,
+	}, nil
+}
+
+// resume restores the executor and the strategy from a checkpoint of the same backtest and skips the
+// replay past the events it had processed; returns false if there is none. It must be called before the
+// replay and the strategy start.
+func (c *backtestCheckpointer) resume() (bool, error) {
+	var checkpoint BacktestCheckpoint
+	found, err := c.store.Load(&checkpoint)
+	if err != nil || !found {
+		return false, err
+	}
+	if !checkpoint.Run.matches(c.run) {
+		c.logger.Warnf("Ignoring backtest checkpoint %s of a different backtest", c.store.Path())
+		return false, nil
+	}
+
+	if err := c.strategy.restoreState(checkpoint.Strategy); err != nil {
+		return false, fmt.Errorf("failed to restore strategy state: %w", err)
+	}
+	c.executor.RestoreState(checkpoint.Executor)
+	if err := c.provider.Resume(checkpoint.Progress.Events); err != nil {
+		return false, err
+	}
+	c.logger.Infof("Resuming backtest after %d events at %s", checkpoint.Progress.Events,
+		checkpoint.Progress.Time.Format(time.RFC3339))
+	return true, nil
+}
+
+// arm saves a checkpoint each time the replay reaches one; ctx bounds the wait for the strategy
+func (c *backtestCheckpointer) arm(ctx context.Context) error {
+	return c.provider.SetCheckpoint(func(progress stream.ReplayProgress) {
+		if err := c.save(ctx, progress); err != nil {
+			c.logger.Warnf("Backtest checkpoint failed: %v", err)
+		}
+	})
+}
+
+// save writes a checkpoint once the strategy has processed the events up to progress
+func (c *backtestCheckpointer) save(ctx context.Context, progress stream.ReplayProgress) error {
+	c.strategy.waitProcessed(ctx, progress)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	state, err := c.strategy.saveState()
+	if err != nil {
+		return fmt.Errorf("failed to encode strategy state: %w", err)
+	}
+	return c.store.Save(BacktestCheckpoint{
+		Run:      c.run,
+		Progress: progress,
+		Executor: c.executor.SaveState(),
+		Strategy: state,
+		SavedAt:  time.Now(),
+	})
+}
+
+// finish removes the checkpoint of a completed backtest, so the next run starts from the beginning
+func (c *backtestCheckpointer) finish() error {
+	return c.store.Remove()
+}
@@ -0,0 +1,243 @@
+package app
+
+import (
+	"aibot/internal/logging"
+	"aibot/internal/types"
+	"aibot/pkg/stream"
+	"aibot/pkg/trading"
+	"context"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const checkpointSymbol = "BTCUSDT"
+
+var checkpointStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// waveCandles is a candle source of 1m candles on a slow wave, so trades win and lose
+type waveCandles int
+
+func (n waveCandles) LoadCandles(symbol string, start, end time.Time) ([]types.OHLCV, error) {
+	candles := make([]types.OHLCV, 0, int(n))
+	for i := 0; i < int(n); i++ {
+		price := 50000 + 800*math.Sin(float64(i)/45) + 150*math.Sin(float64(i)/7)
+		candles = append(candles, types.OHLCV{
+			Symbol:    symbol,
+			Timestamp: checkpointStart.Add(time.Duration(i) * time.Minute),
+			Open:      price,
+			High:      price * 1.001,
+			Low:       price * 0.999,
+			Close:     price,
+			Volume:    10,
+		})
+	}
+	return candles, nil
+}
+
+// replayStrategy trades the replayed closes on a fixed rule: it goes long after three rising closes,
+// closes after three falling ones and keeps a resting bid under the price while flat
+type replayStrategy struct {
+	executor *trading.SimulationExecutor
+
+	mu    sync.Mutex
+	state replayStrategyState
+}
+
+type replayStrategyState struct {
+	Events int     `json:"events"`
+	Last   float64 `json:"last"`
+	Rising int     `json:"rising"` // Consecutive rising closes, negative when falling
+	Bid    string  `json:"bid"`    // Resting bid order ID
+}
+
+func (s *replayStrategy) run(ctx context.Context, provider *stream.ReplayProvider, stopAt int, stop context.CancelFunc) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-provider.GetOHLCVChannel():
+		case ticker := <-provider.GetTickerChannel():
+			s.onTicker(ticker)
+			if s.events() == stopAt {
+				stop()
+				return
+			}
+		case <-provider.Done():
+			// Prices delivered before the replay finished are still traded
+			if len(provider.GetTickerChannel()) == 0 {
+				return
+			}
+		}
+	}
+}
+
+func (s *replayStrategy) onTicker(ticker types.Ticker) {
+	s.executor.UpdateTicker(ticker)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := &s.state
+	state.Events++
+	switch {
+	case state.Last == 0:
+	case ticker.Price > state.Last:
+		state.Rising = max(state.Rising, 0) + 1
+	default:
+		state.Rising = min(state.Rising, 0) - 1
+	}
+	state.Last = ticker.Price
+
+	position, _ := s.executor.GetPosition(ticker.Symbol)
+	long := position != nil && !position.IsFlat()
+	switch {
+	case !long && state.Rising >= 3:
+		s.cancelBid()
+		s.executor.OpenLong(ticker.Symbol, 0.05, 0)
+	case long && state.Rising <= -3:
+		s.executor.CloseLong(ticker.Symbol, position.Size, 0)
+	case !long && state.Bid == "":
+		if result, err := s.executor.OpenLong(ticker.Symbol, 0.02, ticker.Price*0.995); err == nil {
+			state.Bid = result.OrderID
+		}
+	}
+}
+
+// cancelBid pulls the resting bid; caller must hold s.mu
+func (s *replayStrategy) cancelBid() {
+	if s.state.Bid != "" {
+		s.executor.CancelOrder(s.state.Bid)
+		s.state.Bid = ""
+	}
+}
+
+func (s *replayStrategy) events() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.Events
+}
+
+func (s *replayStrategy) waitProcessed(ctx context.Context, progress stream.ReplayProgress) {
+	for s.events() < progress.Events && ctx.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *replayStrategy) saveState() (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.Marshal(s.state)
+}
+
+func (s *replayStrategy) restoreState(state json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.Unmarshal(state, &s.state)
+}
+
+// backtestResult is the account at the end of a backtest
+type backtestResult struct {
+	Balance       float64
+	UnrealizedPnL float64
+	Events        int
+	Resumed       bool
+}
+
+// runCheckpointedBacktest replays candles into a fresh executor, checkpointing to path every hour of event
+// time and resuming from a checkpoint left there; stopAt stops the replay after that many events (0 runs
+// it to the end)
+func runCheckpointedBacktest(t *testing.T, candles int, path string, stopAt int) backtestResult {
+	t.Helper()
+
+	provider, err := stream.NewReplayProvider(stream.ReplayConfig{
+		StreamConfig: stream.StreamConfig{
+			ProviderType: "replay",
+			Symbols:      []string{checkpointSymbol},
+			BufferSize:   1,
+		},
+		StartTime:          checkpointStart,
+		EndTime:            checkpointStart.Add(time.Duration(candles) * time.Minute),
+		Source:             waveCandles(candles),
+		CheckpointInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor := trading.NewSimulationExecutor(trading.SimulationConfig{
+		ExecutionConfig: trading.ExecutionConfig{InitialBalance: 10000},
+	})
+	strategy := &replayStrategy{executor: executor}
+	run := BacktestRun{ConfigHash: "test", Symbols: []string{checkpointSymbol}, StartTime: checkpointStart}
+	checkpoints, err := newBacktestCheckpointer(path, run, provider, executor, strategy, logging.NewComponentLogger("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := checkpoints.resume()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := checkpoints.arm(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := provider.Start(ctx, []string{checkpointSymbol}); err != nil {
+		t.Fatal(err)
+	}
+	strategy.run(ctx, provider, stopAt, cancel)
+	provider.Stop()
+	if provider.Complete() {
+		if err := checkpoints.finish(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result := backtestResult{Events: strategy.events(), Resumed: resumed}
+	result.Balance, _ = executor.GetBalance()
+	positions, _ := executor.GetAllPositions()
+	for _, position := range positions {
+		result.UnrealizedPnL += position.UnrealizedPnL
+	}
+	return result
+}
+
+func TestBacktestResumesFromCheckpoint(t *testing.T) {
+	const candles = 2000
+	dir := t.TempDir()
+
+	uninterrupted := runCheckpointedBacktest(t, candles, filepath.Join(dir, "uninterrupted.json"), 0)
+	if uninterrupted.Events != candles {
+		t.Fatalf("expected %d events, got %d", candles, uninterrupted.Events)
+	}
+	if uninterrupted.Balance == 10000 {
+		t.Fatalf("expected the strategy to trade")
+	}
+
+	path := filepath.Join(dir, "stopped.json")
+	stopped := runCheckpointedBacktest(t, candles, path, 1030)
+	if stopped.Resumed || stopped.Events != 1030 {
+		t.Fatalf("expected a fresh run stopped after 1030 events, got %+v", stopped)
+	}
+
+	resumed := runCheckpointedBacktest(t, candles, path, 0)
+	if !resumed.Resumed {
+		t.Fatalf("expected the run to resume from the checkpoint")
+	}
+	if resumed.Events != candles {
+		t.Fatalf("expected the resumed run to reach event %d, got %d", candles, resumed.Events)
+	}
+	if math.Abs(resumed.Balance-uninterrupted.Balance) > 1e-6 || math.Abs(resumed.UnrealizedPnL-uninterrupted.UnrealizedPnL) > 1e-6 {
+		t.Fatalf("resumed run ended at balance %.6f, unrealized %.6f; uninterrupted at %.6f, %.6f",
+			resumed.Balance, resumed.UnrealizedPnL, uninterrupted.Balance, uninterrupted.UnrealizedPnL)
+	}
+
+	again := runCheckpointedBacktest(t, candles, path, 0)
+	if again.Resumed {
+		t.Fatalf("expected a completed backtest to remove its checkpoint")
+	}
+}
@@ -0,0 +1,774 @@
+package app
+
+import (
+	"aibot/internal/bot"
+	"aibot/internal/config"
+	"aibot/internal/data"
+	"aibot/internal/execution"
+	"aibot/internal/indicators"
+	"aibot/internal/journal"
+	"aibot/internal/reconcile"
+	"aibot/internal/screener"
+	"aibot/internal/strategy"
+	"aibot/internal/tracing"
+	"aibot/internal/types"
+	"aibot/pkg/stream"
+	"aibot/pkg/trading"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// NewStreamProvider creates the market data stream provider for the configuration: a replay of the
+// backtest data, or the live feed
+func NewStreamProvider(fullConfig *config.Config) (stream.StreamProvider, error) {
+	cfg := fullConfig.Stream
+	factory := stream.NewStreamProviderFactory()
+	if cfg.ProviderType == "replay" {
+		return newReplayProvider(factory, fullConfig.Backtest, cfg.BufferSize)
+	}
+
+	// Create live config
+	liveConfig := stream.RealStreamConfig{
+		StreamConfig: stream.StreamConfig{
+			ProviderType: "live",
+			Symbols:      []string{"BTCUSDT"},
+		},
+		WSSURL:          "wss://api.binance.com/ws/btcusdt@ticker", // Default example
+		PingInterval:    20 * time.Second,
+		Timeout:         30 * time.Second,
+		Compression:     true,
+		RateLimitPerSec: 10,
+	}
+
+	primary, err := factory.CreateStreamProvider(liveConfig)
+	if err != nil || !cfg.Failover.Enabled {
+		return primary, err
+	}
+
+	// A second connection to the backup endpoint takes over when the primary stalls
+	backupConfig := liveConfig
+	backupConfig.WSSURL = cfg.Failover.BackupURL
+	backup, err := factory.CreateStreamProvider(backupConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup stream provider: %w", err)
+	}
+	return stream.NewFailoverProvider(stream.FailoverConfig{
+		StallTimeout:   cfg.Failover.StallTimeout,
+		RecoveryPeriod: cfg.Failover.RecoveryPeriod,
+		BufferSize:     cfg.BufferSize,
+	}, primary, backup)
+}
+
+// newReplayProvider creates a replay of the backtest period from the configured data source
+func newReplayProvider(factory *stream.StreamProviderFactory, cfg config.BacktestConfig, bufferSize int) (stream.StreamProvider, error) {
+	source, err := data.NewDataSource(data.DataSourceConfig{
+		Format:    cfg.DataFormat,
+		Directory: cfg.DataDirectory,
+		Timeframe: cfg.Timeframe,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return factory.CreateStreamProvider(stream.ReplayConfig{
+		StreamConfig: stream.StreamConfig{
+			ProviderType: "replay",
+			Symbols:      cfg.Symbols,
+			BufferSize:   bufferSize,
+		},
+		StartTime:          cfg.StartTime,
+		EndTime:            cfg.EndTime,
+		Source:             source,
+		CheckpointInterval: cfg.CheckpointInterval,
+	})
+}
+
+// NewTradingExecutor creates the simulated or live trading executor for the configuration
+func NewTradingExecutor(cfg config.TradingConfig) (trading.TradingExecutor, error) {
+	factory := trading.NewTradingExecutorFactory()
+
+	if cfg.ExecutionType == "simulation" {
+		return factory.CreateTradingExecutor(trading.SimulationConfig{
+			ExecutionConfig: trading.ExecutionConfig{
+				ProviderType:    "simulation",
+				MarketType:      types.MarketType(cfg.MarketType),
+				QuoteAsset:      cfg.QuoteAsset,
+				InitialBalance:  cfg.InitialBalance,
+				DefaultLeverage: cfg.DefaultLeverage,
+				MaxLeverage:     cfg.MaxLeverage,
+				Commission:      cfg.TakerFee,
+				Slippage:        cfg.Slippage,
+				EnableHedging:   cfg.EnableHedging,
+				Contracts:       convertContracts(cfg.Contracts),
+				FeeSchedule:     convertFeeSchedule(cfg.FeeSchedule),
+			},
+			Chaos: trading.ChaosConfig{
+				Enabled:            cfg.Chaos.Enabled,
+				Seed:               cfg.Chaos.Seed,
+				Latency:            cfg.Chaos.Latency,
+				LatencyJitter:      cfg.Chaos.LatencyJitter,
+				DisconnectRate:     cfg.Chaos.DisconnectRate,
+				DisconnectDuration: cfg.Chaos.DisconnectDuration,
+				BusyRate:           cfg.Chaos.BusyRate,
+				FillDelay:          cfg.Chaos.FillDelay,
+				ReorderRate:        cfg.Chaos.ReorderRate,
+				RateLimitRate:      cfg.Chaos.RateLimitRate,
+				RateLimitDuration:  cfg.Chaos.RateLimitDuration,
+			},
+			Margin: trading.MarginConfig{
+				MaintenanceMarginRate: cfg.Margin.MaintenanceMarginRate,
+				MarginCallRatio:       cfg.Margin.MarginCallRatio,
+			},
+		})
+	}
+
+	// Create live config
+	liveConfig := trading.LiveConfig{
+		ExecutionConfig: trading.ExecutionConfig{
+			ProviderType:    "live",
+			MarketType:      types.MarketType(cfg.MarketType),
+			QuoteAsset:      cfg.QuoteAsset,
+			APIKey:          config.GetEnv("TRADING_BOT_API_KEY", cfg.APIKey),
+			APISecret:       config.GetEnv("TRADING_BOT_API_SECRET", cfg.APISecret),
+			InitialBalance:  cfg.InitialBalance,
+			DefaultLeverage: cfg.DefaultLeverage,
+			MaxLeverage:     cfg.MaxLeverage,
+			Commission:      cfg.MakerFee + cfg.TakerFee,
+			EnableHedging:   cfg.EnableHedging,
+			Contracts:       convertContracts(cfg.Contracts),
+		},
+		WSSURL:          "wss://api.binance.com/ws/btcusdt@trade",
+		RESTURL:         "https://api.binance.com/api/v3",
+		Timeout:         30 * time.Second,
+		RateLimitPerSec: 10,
+		UseTestNet:      true,
+	}
+
+	return factory.CreateTradingExecutor(liveConfig)
+}
+
+// NewScreener creates the grid suitability screener, screening the supported symbols when no universe is configured
+func NewScreener(cfg *config.Config) *screener.Screener {
+	symbols := cfg.Screener.Symbols
+	if len(symbols) == 0 {
+		symbols = cfg.Trading.SupportedSymbols
+	}
+	return screener.NewScreener(screener.ScreenerConfig{
+		Symbols:        symbols,
+		Interval:       cfg.Screener.Interval,
+		Lookback:       cfg.Screener.Lookback,
+		MinVolatility:  cfg.Screener.MinVolatility,
+		MaxVolatility:  cfg.Screener.MaxVolatility,
+		MaxADX:         cfg.Screener.MaxADX,
+		MinQuoteVolume: cfg.Screener.MinQuoteVolume,
+		MaxSpread:      cfg.Screener.MaxSpread,
+		TopN:           cfg.Screener.TopN,
+	}, screener.NewRESTClient(cfg.Screener.RESTURL, cfg.Screener.Timeout))
+}
+
+// NewReconciler creates the trade history client and the reconciler that compares it with the trade journal
+func NewReconciler(cfg *config.Config) (*reconcile.HistoryClient, *reconcile.Reconciler) {
+	history := reconcile.NewHistoryClient(reconcile.HistoryConfig{
+		URL:        cfg.Reconcile.RESTURL,
+		MarketType: types.MarketType(cfg.Trading.MarketType),
+		APIKey:     config.GetEnv("TRADING_BOT_API_KEY", cfg.Trading.APIKey),
+		APISecret:  config.GetEnv("TRADING_BOT_API_SECRET", cfg.Trading.APISecret),
+		Timeout:    cfg.Reconcile.Timeout,
+	})
+	return history, reconcile.NewReconciler(reconcile.ReconcileConfig{
+		QuoteAsset:        cfg.Trading.QuoteAsset,
+		QuantityTolerance: cfg.Reconcile.QuantityTolerance,
+		FeeTolerance:      cfg.Reconcile.FeeTolerance,
+	})
+}
+
+// accountID names the trading account for state kept across sessions: the configured ID, or the market
+// type with a fingerprint of the API key so switching keys does not carry one account's history to another
+func accountID(cfg *config.Config) string {
+	if cfg.Trading.AccountID != "" {
+		return cfg.Trading.AccountID
+	}
+	apiKey := config.GetEnv("TRADING_BOT_API_KEY", cfg.Trading.APIKey)
+	if apiKey == "" {
+		return fmt.Sprintf("%s-%s", cfg.Trading.ExecutionType, cfg.Trading.MarketType)
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return fmt.Sprintf("%s-%s", cfg.Trading.MarketType, hex.EncodeToString(sum[:4]))
+}
+
+// accountingLocation returns the timezone the trading day rolls over in; Validate has already loaded it
+func accountingLocation(cfg *config.Config) *time.Location {
+	location, err := time.LoadLocation(cfg.Trading.AccountingTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// DefaultDataDir is the directory of a bot's journals, checkpoints and session results
+const DefaultDataDir = "./data"
+
+// NewBotConfig converts the application configuration to the orchestrator configuration, taking the
+// grid setup, breakout and risk parameters from the selected strategy profile
+func NewBotConfig(cfg *config.Config) (*bot.BotConfig, error) {
+	return newBotConfig(cfg, DefaultDataDir)
+}
+
+// newBotConfig converts the application configuration, keeping the bot's files under dataDir
+func newBotConfig(cfg *config.Config, dataDir string) (*bot.BotConfig, error) {
+	profile, err := strategy.LoadProfile(cfg.Strategy.Profile, cfg.Strategy.ProfileDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load strategy profile: %w", err)
+	}
+	journalDir := filepath.Join(dataDir, "journal")
+
+	botConfig := &bot.BotConfig{
+		InitialBalance:       cfg.Trading.InitialBalance,
+		MaxSymbols:           cfg.Trading.MaxSymbols,
+		DefaultSymbol:        cfg.Trading.DefaultSymbol,
+		MarketType:           types.MarketType(cfg.Trading.MarketType),
+		NamedIndicators:      cfg.Strategy.Technical.NamedIndicators,
+		MinHistoryCandles:    cfg.Strategy.Technical.MinHistoryCandles,
+		ConcurrentStrategies: cfg.Strategy.ConcurrentStrategies,
+		CapitalAllocation:    cfg.Strategy.CapitalAllocation,
+		GridSetupConfig:      profile.GridSetup,
+		GridEngineConfig: strategy.GridEngineConfig{
+			SpacingMode:          strategy.GridSpacingMode(cfg.Strategy.Grid.SpacingMode),
+			MaxInventory:         cfg.Strategy.Grid.MaxInventory,
+			MaxInventoryBySymbol: cfg.Strategy.Grid.MaxInventoryBySymbol,
+			SkewSpacingFactor:    cfg.Strategy.Grid.SkewSpacingFactor,
+			UnloadThreshold:      cfg.Strategy.Grid.UnloadThreshold,
+			UnloadFraction:       cfg.Strategy.Grid.UnloadFraction,
+		},
+		BreakoutConfig: profile.Breakout,
+		FalseBreakoutConfig: strategy.FalseBreakoutConfig{
+			PriceReversionThreshold: 0.005, // 0.5%
+			StrongReversalThreshold: 0.01,  // 1.0%
+			ConfirmationCandles:     3,
+			VolumeDeclineThreshold:  0.5, // 50%
+			MomentumReversalMs:      900,
+			StdDevMultiplier:        2.0,
+			RequireVolumeData:       cfg.Strategy.FalseBreakout.RequireVolumeData,
+			DivergenceWarning:       cfg.Strategy.FalseBreakout.DivergenceWarning,
+			Scorer: strategy.FalseBreakoutScorerConfig{
+				Type:        cfg.Strategy.FalseBreakout.ScorerType,
+				Endpoint:    cfg.Strategy.FalseBreakout.ScorerEndpoint,
+				ModelPath:   cfg.Strategy.FalseBreakout.ScorerModelPath,
+				Timeout:     cfg.Strategy.FalseBreakout.ScorerTimeout,
+				BlendWeight: cfg.Strategy.FalseBreakout.ScorerBlendWeight,
+			},
+		},
+		FalseBreakoutVolumeLookback: cfg.Strategy.FalseBreakout.VolumeLookbackCandles,
+		DivergenceConfig: indicators.DivergenceConfig{
+			Lookback:     cfg.Strategy.FalseBreakout.DivergenceLookback,
+			SwingCandles: cfg.Strategy.FalseBreakout.DivergenceSwingCandles,
+			MinGap:       cfg.Strategy.FalseBreakout.DivergenceMinGap,
+		},
+		EquityCurveConfig: strategy.EquityCurveConfig{
+			Enabled:       cfg.Strategy.EquityCurve.Enabled,
+			MAPeriod:      cfg.Strategy.EquityCurve.MAPeriod,
+			DisableBuffer: cfg.Strategy.EquityCurve.DisableBuffer,
+		},
+		FeeGovernorConfig: strategy.FeeGovernorConfig{
+			Enabled:      cfg.Strategy.FeeBudget.Enabled,
+			HourlyBudget: cfg.Strategy.FeeBudget.HourlyBudget,
+			DailyBudget:  cfg.Strategy.FeeBudget.DailyBudget,
+			MaxFeeRatio:  cfg.Strategy.FeeBudget.MaxFeeRatio,
+			MinFills:     cfg.Strategy.FeeBudget.MinFills,
+			Cooldown:     cfg.Strategy.FeeBudget.Cooldown,
+			MaxThrottle:  cfg.Strategy.FeeBudget.MaxThrottle,
+			SpacingStep:  cfg.Strategy.FeeBudget.SpacingStep,
+			LevelStep:    cfg.Strategy.FeeBudget.LevelStep,
+		},
+		LiquidityGuardConfig: strategy.LiquidityGuardConfig{
+			MaxSpread:     cfg.Risk.Liquidity.MaxSpread,
+			MinDepth:      cfg.Risk.Liquidity.MinDepth,
+			RetryInterval: cfg.Risk.Liquidity.RetryInterval,
+		},
+		VolatilityBreakerConfig: strategy.VolatilityBreakerConfig{
+			Multiple:          cfg.Risk.VolatilityBreaker.Multiple,
+			BaselineWindow:    cfg.Risk.VolatilityBreaker.BaselineWindow,
+			MinSamples:        cfg.Risk.VolatilityBreaker.MinSamples,
+			MinMove:           cfg.Risk.VolatilityBreaker.MinMove,
+			Cooldown:          cfg.Risk.VolatilityBreaker.Cooldown,
+			SpacingMultiplier: cfg.Risk.VolatilityBreaker.SpacingMultiplier,
+		},
+		OrderBudgetConfig: strategy.OrderBudgetConfig{
+			MaxOpenOrders:          cfg.Risk.OrderBudget.MaxOpenOrders,
+			MaxOpenOrdersPerSymbol: cfg.Risk.OrderBudget.MaxOpenOrdersPerSymbol,
+			MaxOpenOrdersBySymbol:  cfg.Risk.OrderBudget.MaxOpenOrdersBySymbol,
+		},
+		StabilityConfig: strategy.StabilityConfig{
+			AnalysisWindow:      10,
+			VolatilityThreshold: 0.005, // 0.5%
+			MomentumThreshold:   0.002, // 0.2%
+			PriceConformity:     0.8,   // 80%
+			RangeContraction:    0.7,   // 70%
+			MinStabilityPeriods: 3,
+			PrimaryTimeframe:    "3s",
+			SecondaryTimeframe:  "15s",
+			VolatilityIndicator: cfg.Strategy.Stability.VolatilityIndicator,
+			Adaptive: strategy.AdaptiveStabilityConfig{
+				Enabled:              cfg.Strategy.Stability.Adaptive.Enabled,
+				Lookback:             cfg.Strategy.Stability.Adaptive.Lookback,
+				SampleInterval:       cfg.Strategy.Stability.Adaptive.SampleInterval,
+				MinSamples:           cfg.Strategy.Stability.Adaptive.MinSamples,
+				VolatilityPercentile: cfg.Strategy.Stability.Adaptive.VolatilityPercentile,
+				MomentumPercentile:   cfg.Strategy.Stability.Adaptive.MomentumPercentile,
+				ConformityPercentile: cfg.Strategy.Stability.Adaptive.ConformityPercentile,
+			},
+		},
+		RiskManagerConfig: strategy.RiskManagerConfig{
+			DeRiskReduceAt:        cfg.Risk.DeRiskReduceAt,
+			DeRiskReduceFactor:    cfg.Risk.DeRiskReduceFactor,
+			DeRiskHaltAt:          cfg.Risk.DeRiskHaltAt,
+			DeRiskFlattenAt:       cfg.Risk.DeRiskFlattenAt,
+			DeRiskCooldown:        cfg.Risk.DeRiskCooldown,
+			PolicyFile:            cfg.Risk.PolicyFile,
+			MaintenanceMarginRate: cfg.Risk.MaintenanceMarginRate,
+			LiquidationBuffer:     cfg.Risk.LiquidationBuffer,
+			MarginMode:            cfg.Risk.MarginMode,
+			LeverageControl:       convertLeverageControl(cfg.Risk.LeverageControl),
+		},
+		JournalConfig: journal.JournalConfig{
+			Directory: journalDir,
+		},
+		IntentQueueConfig: journal.IntentQueueConfig{
+			Directory: journalDir,
+			MaxAge:    cfg.Trading.IntentMaxAge,
+		},
+		ExecutionConfig: convertExecution(cfg.Trading.Execution, cfg.Risk.AgedPositions),
+		PositionManagerConfig: strategy.PositionManagerConfig{
+			HedgeMode:    cfg.Trading.EnableHedging,
+			Contracts:    convertContracts(cfg.Trading.Contracts),
+			TimeoutHours: cfg.Risk.AgedPositions.TimeoutHours,
+			AgedUnwind: strategy.AgedUnwindConfig{
+				Policy:       cfg.Risk.AgedPositions.Policy,
+				StepFraction: cfg.Risk.AgedPositions.StepFraction,
+				StepInterval: cfg.Risk.AgedPositions.StepInterval,
+			},
+			ExchangeExits:        cfg.Risk.ExchangeExits.Enabled,
+			ExchangeTrailingStop: cfg.Risk.ExchangeExits.TrailingStop,
+		},
+		SessionReportDir: filepath.Join(dataDir, "sessions"),
+		PerformanceDBConfig: journal.PerformanceDBConfig{
+			Directory: dataDir,
+		},
+		BenchmarkConfig: journal.BenchmarkConfig{
+			SampleInterval: cfg.Backtest.BenchmarkInterval,
+			MaxPoints:      cfg.Backtest.BenchmarkPoints,
+			DCAInterval:    cfg.Backtest.DCAInterval,
+		},
+		SessionConfig: summarizeConfig(cfg, profile),
+		BreakoutHistoryConfig: journal.BreakoutHistoryConfig{
+			Directory: journalDir,
+		},
+		AccountSnapshotConfig: journal.SnapshotLogConfig{
+			Directory: journalDir,
+		},
+		AccountSnapshotInterval: cfg.Trading.AccountSnapshotInterval,
+		WebhookConfig: bot.WebhookConfig{
+			URL:        cfg.Webhook.URL,
+			Secret:     config.GetEnv("TRADING_BOT_WEBHOOK_SECRET", cfg.Webhook.Secret),
+			Events:     cfg.Webhook.Events,
+			Timeout:    cfg.Webhook.Timeout,
+			MaxRetries: cfg.Webhook.MaxRetries,
+			RetryDelay: cfg.Webhook.RetryDelay,
+			QueueSize:  cfg.Webhook.QueueSize,
+		},
+		GrafanaConfig: bot.GrafanaConfig{
+			URL:          cfg.Grafana.URL,
+			APIKey:       config.GetEnv("TRADING_BOT_GRAFANA_API_KEY", cfg.Grafana.APIKey),
+			DashboardUID: cfg.Grafana.DashboardUID,
+			Tags:         cfg.Grafana.Tags,
+			Events:       cfg.Grafana.Events,
+			Timeout:      cfg.Grafana.Timeout,
+			QueueSize:    cfg.Grafana.QueueSize,
+		},
+		EventLogConfig: bot.EventLogConfig{
+			Output:     cfg.Logging.Events.Output,
+			Topics:     cfg.Logging.Events.Topics,
+			BufferSize: cfg.Logging.Events.BufferSize,
+		},
+		NewsCalendarConfig: bot.NewsCalendarConfig{
+			URL:          cfg.News.URL,
+			PollInterval: cfg.News.PollInterval,
+			Timeout:      cfg.News.Timeout,
+			PauseBefore:  cfg.News.PauseBefore,
+			ResumeAfter:  cfg.News.ResumeAfter,
+			MinImpact:    cfg.News.MinImpact,
+			Currencies:   cfg.News.Currencies,
+		},
+		ExchangeStatusConfig: bot.ExchangeStatusConfig{
+			URL:          cfg.ExchangeStatus.URL,
+			MarketType:   types.MarketType(cfg.Trading.MarketType),
+			PollInterval: cfg.ExchangeStatus.PollInterval,
+			Timeout:      cfg.ExchangeStatus.Timeout,
+		},
+		FundingConfig: strategy.FundingConfig{
+			Interval:           cfg.Funding.Interval,
+			Offset:             cfg.Funding.Offset,
+			EntryBlackout:      cfg.Funding.EntryBlackout,
+			SettlementBlackout: cfg.Funding.SettlementBlackout,
+			ExpectedHolding:    cfg.Funding.ExpectedHolding,
+			MaxCarryFraction:   cfg.Funding.MaxCarryFraction,
+		},
+		FundingFeedConfig: bot.FundingFeedConfig{
+			URL:          cfg.Funding.URL,
+			PollInterval: cfg.Funding.PollInterval,
+			Timeout:      cfg.Funding.Timeout,
+		},
+		BasisConfig: strategy.BasisConfig{
+			MaxBasis:  cfg.Basis.MaxBasis,
+			BiasBasis: cfg.Basis.BiasBasis,
+			Smoothing: cfg.Basis.Smoothing,
+			MaxAge:    cfg.Basis.MaxAge,
+		},
+		SpotPriceFeedConfig: bot.SpotPriceFeedConfig{
+			URL:          cfg.Basis.URL,
+			Symbols:      cfg.Basis.Symbols,
+			PollInterval: cfg.Basis.PollInterval,
+			Timeout:      cfg.Basis.Timeout,
+		},
+		ProfitSweepEnabled: cfg.ProfitSweep.Enabled,
+		ProfitSweepConfig:  convertProfitSweep(cfg, journalDir),
+		AlertRulesConfig:   convertAlertRules(cfg.Alerts),
+		DailyReportEnabled: cfg.DailyReport.Enabled,
+		DailyReportConfig: bot.DailyReportConfig{
+			Time:       cfg.DailyReport.Time,
+			AttachCSV:  cfg.DailyReport.AttachCSV,
+			AlertLevel: cfg.DailyReport.AlertLevel,
+		},
+		EmailConfig: bot.EmailConfig{
+			SMTPHost: cfg.Email.SMTPHost,
+			SMTPPort: cfg.Email.SMTPPort,
+			Username: cfg.Email.Username,
+			Password: config.GetEnv("TRADING_BOT_SMTP_PASSWORD", cfg.Email.Password),
+			From:     cfg.Email.From,
+			To:       cfg.Email.To,
+			Timeout:  cfg.Email.Timeout,
+		},
+		SignalDedupConfig: bot.SignalDedupConfig{
+			Cooldowns: cfg.Strategy.SignalDedup.Cooldowns,
+		},
+		ModeWatchdogConfig:       convertModeWatchdog(cfg.Strategy.ModeWatchdog),
+		RecoveryEscalationConfig: convertRecoveryEscalation(cfg.Strategy.RecoveryEscalation),
+		HealthConfig: bot.HealthConfig{
+			CheckInterval: cfg.Health.CheckInterval,
+			MaxGoroutines: cfg.Health.MaxGoroutines,
+			MaxMemoryMB:   cfg.Health.MaxMemoryMB,
+			MaxBacklog:    cfg.Health.MaxBacklog,
+			MaxStreamLag:  cfg.Health.MaxStreamLag,
+			GracePeriod:   cfg.Health.GracePeriod,
+			DegradeToIdle: cfg.Health.DegradeToIdle,
+		},
+		TracingConfig: tracing.TracerConfig{
+			Enabled:     cfg.Tracing.Enabled,
+			SampleEvery: cfg.Tracing.SampleEvery,
+			Exporter:    cfg.Tracing.Exporter,
+			OutputFile:  cfg.Tracing.OutputFile,
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			ServiceName: cfg.Tracing.ServiceName,
+		},
+		ControlConfig: bot.ControlServerConfig{
+			Address:        cfg.Control.Address,
+			SocketPath:     cfg.Control.SocketPath,
+			SocketMode:     cfg.Control.SocketFileMode(),
+			CommandTimeout: cfg.Control.CommandTimeout,
+		},
+		HealthServerConfig: bot.HealthServerConfig{
+			Address:    cfg.Health.HTTPAddress,
+			MaxTickAge: cfg.Health.MaxTickAge,
+		},
+		GapConfig: data.GapConfig{
+			Policy:         cfg.Stream.GapPolicy,
+			StallTimeout:   cfg.Stream.GapStallTimeout,
+			MaxFill:        cfg.Stream.GapMaxFill,
+			RecoveryPeriod: cfg.Stream.GapRecoveryPeriod,
+		},
+		EventTimeConfig: data.EventTimeConfig{
+			Enabled:         cfg.Stream.EventTime,
+			AllowedLateness: cfg.Stream.AllowedLateness,
+		},
+		DataQueueConfig: bot.DataQueueConfig{
+			Policy:   cfg.Stream.Backpressure,
+			Capacity: cfg.Stream.QueueSize,
+		},
+		StreamConfig: stream.StreamConfig{
+			ProviderType: "live",
+			Symbols:      []string{cfg.Trading.DefaultSymbol},
+			AggTrades:    cfg.Stream.AggTrades,
+			StaleFilter: stream.StaleFilterConfig{
+				Tolerance:     cfg.Stream.StaleTolerance,
+				AlertInterval: cfg.Stream.StaleAlertInterval,
+			},
+		},
+		TradingConfig: trading.ExecutionConfig{
+			ProviderType:    "live",
+			InitialBalance:  cfg.Trading.InitialBalance,
+			DefaultLeverage: cfg.Trading.DefaultLeverage,
+			Commission:      cfg.Trading.MakerFee + cfg.Trading.TakerFee,
+			EnableHedging:   cfg.Trading.EnableHedging,
+			Contracts:       convertContracts(cfg.Trading.Contracts),
+		},
+		BaseInterval:          cfg.Stream.BaseInterval,
+		UpdateInterval:        1 * time.Second,
+		HealthCheckInterval:   30 * time.Second,
+		MaxDailyLoss:          cfg.Trading.MaxDailyLoss,
+		PnLCheckpointPath:     filepath.Join(journalDir, "daily_pnl.json"),
+		PnLCheckpointInterval: cfg.Trading.PnLCheckpointInterval,
+		AccountingLocation:    accountingLocation(cfg),
+		CurrencyConfig: strategy.CurrencyConfig{
+			AccountingCurrency: cfg.Trading.AccountingCurrency,
+			QuoteAsset:         cfg.Trading.QuoteAsset,
+			Rates:              cfg.Trading.QuoteRates,
+			Contracts:          convertContracts(cfg.Trading.Contracts),
+		},
+		RateSymbols:          cfg.Trading.RateSymbols,
+		AccountID:            accountID(cfg),
+		HighWaterMarkPath:    filepath.Join(journalDir, "equity_hwm.json"),
+		MaxConsecutiveLosses: cfg.Trading.MaxConsecutiveLosses,
+		OrderRetryAttempts:   cfg.Trading.RetryAttempts,
+		OrderRetryDelay:      cfg.Trading.RetryDelay,
+		TickLogSampleRate:    cfg.Logging.TickSampleRate,
+	}
+
+	botConfig.BreakoutConfig.RSIIndicator = cfg.Strategy.Breakout.RSIIndicator
+	botConfig.BreakoutConfig.ATRIndicator = cfg.Strategy.Breakout.ATRIndicator
+	botConfig.BreakoutConfig.VolumeIndicator = cfg.Strategy.Breakout.VolumeIndicator
+	if cfg.Strategy.Breakout.ConfirmationMode != "" {
+		botConfig.BreakoutConfig.ConfirmationMode = strategy.BreakoutConfirmationMode(cfg.Strategy.Breakout.ConfirmationMode)
+	}
+	if cfg.Strategy.Breakout.ConfirmationTimeframe != "" {
+		botConfig.BreakoutConfig.ConfirmationTimeframe = data.CandleTimeframe(cfg.Strategy.Breakout.ConfirmationTimeframe)
+	}
+	if cfg.Strategy.Breakout.ConfirmationCloses != 0 {
+		botConfig.BreakoutConfig.ConfirmationCloses = cfg.Strategy.Breakout.ConfirmationCloses
+	}
+	if cfg.Strategy.Breakout.StrengthMode != "" {
+		botConfig.BreakoutConfig.StrengthMode = strategy.BreakoutStrengthMode(cfg.Strategy.Breakout.StrengthMode)
+	}
+	if cfg.Strategy.Breakout.MinStrengthPercent != 0 {
+		botConfig.BreakoutConfig.MinStrengthPercent = cfg.Strategy.Breakout.MinStrengthPercent
+	}
+	if cfg.Strategy.Breakout.MinStrengthATR != 0 {
+		botConfig.BreakoutConfig.MinStrengthATR = cfg.Strategy.Breakout.MinStrengthATR
+	}
+	botConfig.BreakoutConfig.SuccessPrior = cfg.Strategy.Breakout.SuccessPrior
+	botConfig.BreakoutConfig.SuccessWeight = cfg.Strategy.Breakout.SuccessWeight
+	if cfg.Stream.ProviderType == "replay" {
+		botConfig.TradingStart = cfg.Backtest.TradingStart()
+	}
+	if cfg.Backtest.IntraCandlePath != "" {
+		botConfig.CandlePathConfig = data.CandlePathConfig{
+			Model: data.CandlePathModel(cfg.Backtest.IntraCandlePath),
+			Steps: cfg.Backtest.PathSteps,
+		}
+		if interval, err := time.ParseDuration(cfg.Backtest.Timeframe); err == nil {
+			botConfig.CandlePathConfig.Interval = interval
+		}
+	}
+	profile.Risk.Apply(&botConfig.RiskManagerConfig)
+
+	// Spot positions are fully funded, so risk sizing must not assume leverage
+	if botConfig.MarketType.IsSpot() {
+		botConfig.RiskManagerConfig.DefaultLeverage = 1.0
+		botConfig.RiskManagerConfig.MaxLeverage = 1.0
+	}
+
+	return botConfig, nil
+}
+
+// convertLeverageControl converts the configured leverage tiers to the risk manager configuration
+func convertLeverageControl(cfg config.LeverageControlConfig) strategy.LeverageControlConfig {
+	tiers := make([]strategy.LeverageTier, len(cfg.Tiers))
+	for i, tier := range cfg.Tiers {
+		tiers[i] = strategy.LeverageTier{Volatility: tier.Volatility, Leverage: tier.Leverage}
+	}
+	return strategy.LeverageControlConfig{
+		Enabled:       cfg.Enabled,
+		Window:        cfg.Window,
+		Tiers:         tiers,
+		RestoreBuffer: cfg.RestoreBuffer,
+	}
+}
+
+// convertRecoveryEscalation converts the recovery escalation steps; without a steps list the defaults apply
+func convertRecoveryEscalation(cfg config.RecoveryEscalationConfig) bot.RecoveryEscalationConfig {
+	escalation := bot.RecoveryEscalationConfig{}
+	if cfg.Steps == nil {
+		return escalation
+	}
+	escalation.Steps = make([]bot.RecoveryStep, 0, len(cfg.Steps))
+	for _, step := range cfg.Steps {
+		escalation.Steps = append(escalation.Steps, bot.RecoveryStep{
+			After:    step.After,
+			Action:   step.Action,
+			Fraction: step.Fraction,
+		})
+	}
+	return escalation
+}
+
+// convertModeWatchdog converts the per-mode time limits; without a modes section the watchdog defaults apply
+func convertModeWatchdog(cfg config.ModeWatchdogConfig) bot.ModeWatchdogConfig {
+	watchdog := bot.ModeWatchdogConfig{CheckInterval: cfg.CheckInterval}
+	if cfg.Modes == nil {
+		return watchdog
+	}
+	watchdog.Modes = make(map[bot.TradingMode]bot.ModeTimeout, len(cfg.Modes))
+	for mode, timeout := range cfg.Modes {
+		watchdog.Modes[bot.TradingMode(mode)] = bot.ModeTimeout{
+			MaxDuration: timeout.MaxDuration,
+			Inactivity:  timeout.Inactivity,
+			Fallback:    bot.TradingMode(timeout.Fallback),
+		}
+	}
+	return watchdog
+}
+
+// convertExecution converts the configured execution algorithms to the execution engine configuration;
+// the passive aged position policy chases its closes as a maker for the unwind window unless an algorithm
+// is configured for aged unwinds
+func convertExecution(cfg config.ExecutionAlgoConfig, aged config.AgedPositionConfig) execution.Config {
+	algos := make(map[execution.Intent]execution.Algo, len(cfg.Algos))
+	for intent, algo := range cfg.Algos {
+		algos[execution.Intent(intent)] = execution.Algo(algo)
+	}
+	if _, ok := algos[execution.IntentAgedUnwind]; !ok && aged.Policy == strategy.UnwindPassive {
+		algos[execution.IntentAgedUnwind] = execution.AlgoLimitChase
+	}
+	splitIntents := make([]execution.Intent, len(cfg.LargeOrder.Intents))
+	for i, intent := range cfg.LargeOrder.Intents {
+		splitIntents[i] = execution.Intent(intent)
+	}
+	return execution.Config{
+		Algos: algos,
+		LargeOrder: execution.LargeOrderConfig{
+			MinNotional: cfg.LargeOrder.MinNotional,
+			Algo:        execution.Algo(cfg.LargeOrder.Algo),
+			Intents:     splitIntents,
+		},
+		LimitChase: execution.LimitChaseConfig{
+			RepriceInterval:  cfg.LimitChase.RepriceInterval,
+			RepriceThreshold: cfg.LimitChase.RepriceThreshold,
+			MaxReprices:      cfg.LimitChase.MaxReprices,
+			Timeout:          cfg.LimitChase.Timeout,
+		},
+		TWAP: execution.TWAPConfig{
+			Duration: cfg.TWAP.Duration,
+			Slices:   cfg.TWAP.Slices,
+		},
+		Iceberg: execution.IcebergConfig{
+			DisplayFraction: cfg.Iceberg.DisplayFraction,
+			Duration:        cfg.Iceberg.Duration,
+		},
+		AgedUnwind: execution.LimitChaseConfig{
+			RepriceThreshold: cfg.LimitChase.RepriceThreshold,
+			Timeout:          aged.Window,
+		},
+	}
+}
+
+// convertFeeSchedule converts the configured fee schedule to the executor fee model configuration
+func convertFeeSchedule(schedule config.FeeScheduleConfig) trading.FeeScheduleConfig {
+	tiers := make([]trading.FeeTier, len(schedule.Tiers))
+	for i, tier := range schedule.Tiers {
+		tiers[i] = trading.FeeTier{
+			Name:      tier.Name,
+			MinVolume: tier.MinVolume,
+			MakerFee:  tier.MakerFee,
+			TakerFee:  tier.TakerFee,
+		}
+	}
+	return trading.FeeScheduleConfig{
+		Tiers:        tiers,
+		VolumeWindow: schedule.VolumeWindow,
+		BNBDiscount:  schedule.BNBDiscount,
+		DiscountRate: schedule.DiscountRate,
+	}
+}
+
+// convertAlertRules converts the user-defined alert rules
+func convertAlertRules(cfg config.AlertsConfig) bot.AlertRulesConfig {
+	rules := bot.AlertRulesConfig{CheckInterval: cfg.CheckInterval, Email: cfg.Email}
+	for _, rule := range cfg.Rules {
+		rules.Rules = append(rules.Rules, bot.AlertRule{
+			Name:      rule.Name,
+			Condition: rule.Condition,
+			Level:     rule.Level,
+			Message:   rule.Message,
+			Cooldown:  rule.Cooldown,
+		})
+	}
+	return rules
+}
+
+// convertProfitSweep converts the profit sweep settings; only live accounts transfer through the exchange,
+// simulated accounts sweep within the simulation executor
+func convertProfitSweep(cfg *config.Config, journalDir string) bot.ProfitSweepConfig {
+	sweep := bot.ProfitSweepConfig{
+		WorkingCapital: cfg.ProfitSweep.WorkingCapital,
+		Threshold:      cfg.ProfitSweep.Threshold,
+		Asset:          cfg.ProfitSweep.Asset,
+		TransferType:   cfg.ProfitSweep.TransferType,
+		CheckInterval:  cfg.ProfitSweep.CheckInterval,
+		URL:            cfg.ProfitSweep.URL,
+		Timeout:        cfg.ProfitSweep.Timeout,
+		StatePath:      filepath.Join(journalDir, "profit_sweeps.json"),
+	}
+	if cfg.Trading.ExecutionType == "live" {
+		sweep.APIKey = config.GetEnv("TRADING_BOT_API_KEY", cfg.Trading.APIKey)
+		sweep.APISecret = config.GetEnv("TRADING_BOT_API_SECRET", cfg.Trading.APISecret)
+	}
+	return sweep
+}
+
+// summarizeConfig identifies the effective strategy and risk parameters for the performance database:
+// the profile's parameters and the strategy and risk sections, hashed so sessions with the same
+// parameters are ranked together whatever the profile is called
+func summarizeConfig(cfg *config.Config, profile *strategy.StrategyProfile) journal.ConfigSummary {
+	strategyConfig := cfg.Strategy
+	strategyConfig.Profile, strategyConfig.ProfileDir = "", ""
+	parameters, err := json.Marshal(struct {
+		GridSetup   strategy.GridSetupConfig `json:"grid_setup"`
+		Breakout    strategy.BreakoutConfig  `json:"breakout"`
+		ProfileRisk strategy.RiskProfile     `json:"profile_risk"`
+		Strategy    config.StrategyConfig    `json:"strategy"`
+		Risk        config.RiskConfig        `json:"risk"`
+	}{profile.GridSetup, profile.Breakout, profile.Risk, strategyConfig, cfg.Risk})
+	if err != nil {
+		parameters = nil
+	}
+	sum := sha256.Sum256(parameters)
+
+	source := journal.SourceLive
+	if cfg.Trading.ExecutionType == "simulation" {
+		source = journal.SourceSimulation
+	}
+	return journal.ConfigSummary{
+		Hash:       hex.EncodeToString(sum[:6]),
+		Profile:    profile.Name,
+		Source:     source,
+		Parameters: parameters,
+	}
+}
+
+// convertContracts converts configured contracts to executor contract specifications
+func convertContracts(contracts map[string]config.ContractConfig) map[string]types.ContractSpec {
+	specs := make(map[string]types.ContractSpec, len(contracts))
+	for symbol, contract := range contracts {
+		specs[symbol] = types.ContractSpec{
+			Type:       types.ContractType(contract.Type),
+			Multiplier: contract.Multiplier,
+			Expiry:     contract.Expiry,
+			StepSize:   contract.StepSize,
+			TickSize:   contract.TickSize,
+		}
+	}
+	return specs
+}
@@ -0,0 +1,221 @@
+package app
+
+import (
+	"aibot/internal/config"
+	"aibot/internal/journal"
+	"aibot/internal/logging"
+	"aibot/internal/strategy"
+	"aibot/pkg/stream"
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// experimentProgressInterval is how often a running experiment logs its interim comparison
+const experimentProgressInterval = time.Hour
+
+// Experiment trades two parameter profiles side by side on the same symbol and market data. Each variant
+// is an application of its own whose simulated executor is the variant's virtual book, funded with the
+// variant's share of the initial balance; when the experiment ends their per-trade returns are compared.
+type Experiment struct {
+	config    *Config
+	logger    *logging.Logger
+	ownLogger bool // The logger was created for the experiment and is closed when it ends
+	broadcast *stream.Broadcast
+	variants  []*experimentVariant
+}
+
+// experimentVariant is one side of an experiment
+type experimentVariant struct {
+	config     config.ExperimentVariantConfig
+	configHash string
+	app        *Application
+}
+
+// NewExperiment creates the variants of the configured experiment. Market data comes from deps'
+// stream provider, or one created from the configuration, and is broadcast to both variants.
+func NewExperiment(cfg *Config, deps Dependencies) (*Experiment, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration is required")
+	}
+	if len(cfg.Experiment.Variants) != 2 {
+		return nil, fmt.Errorf("an experiment compares two variants, %d configured", len(cfg.Experiment.Variants))
+	}
+	if cfg.Trading.ExecutionType != "simulation" || deps.TradingExecutor != nil {
+		return nil, fmt.Errorf("experiment variants trade on simulated virtual books; set trading.execution_type to simulation")
+	}
+
+	settings := cfg.Experiment
+	if settings.Name == "" {
+		settings.Name = "experiment" // default
+	}
+	if settings.Directory == "" {
+		settings.Directory = "./data/experiments" // default
+	}
+	if settings.Confidence == 0 {
+		settings.Confidence = 0.95 // default
+	}
+	experimentCfg := *cfg
+	experimentCfg.Experiment = settings
+
+	logger := deps.Logger
+	ownLogger := logger == nil
+	if ownLogger {
+		logger = logging.NewLogger(cfg.Logging)
+	}
+	source := deps.StreamProvider
+	if source == nil {
+		var err error
+		source, err = NewStreamProvider(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stream provider: %w", err)
+		}
+	}
+	broadcast, err := stream.NewBroadcast(source, len(settings.Variants), cfg.Stream.BufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	experiment := &Experiment{
+		config:    &experimentCfg,
+		logger:    logger,
+		ownLogger: ownLogger,
+		broadcast: broadcast,
+	}
+	for i, variant := range settings.Variants {
+		variantCfg := *cfg
+		variantCfg.Strategy.Profile = variant.Profile
+		variantCfg.Trading.InitialBalance = cfg.Trading.InitialBalance * variant.CapitalShare
+		// Both variants must trade the configured symbol, and only one process may own the endpoints
+		variantCfg.Screener.AutoSelect = false
+		variantCfg.Control.Address = ""
+		variantCfg.Control.SocketPath = ""
+		variantCfg.Health.HTTPAddress = ""
+		dataDir := filepath.Join(settings.Directory, settings.Name, variant.Name)
+		if variantCfg.Logging.Events.Output != "" {
+			// Each variant records its own event stream
+			variantCfg.Logging.Events.Output = filepath.Join(dataDir, "events.jsonl")
+		}
+
+		profile, err := strategy.LoadProfile(variant.Profile, cfg.Strategy.ProfileDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load profile of variant %s: %w", variant.Name, err)
+		}
+		application, err := newApplication(&variantCfg, Dependencies{Logger: logger, StreamProvider: broadcast.Output(i)}, dataDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create variant %s: %w", variant.Name, err)
+		}
+		experiment.variants = append(experiment.variants, &experimentVariant{
+			config:     variant,
+			configHash: summarizeConfig(&variantCfg, profile).Hash,
+			app:        application,
+		})
+	}
+	return experiment, nil
+}
+
+// Run trades both variants until the experiment duration has passed, ctx is cancelled or a variant stops,
+// then shuts them down and returns the comparison of their results
+func (e *Experiment) Run(ctx context.Context) (*journal.ExperimentReport, error) {
+	if e.config.Experiment.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.config.Experiment.Duration)
+		defer cancel()
+	}
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	start := time.Now()
+	if err := e.broadcast.Start(ctx, []string{e.config.Trading.DefaultSymbol}); err != nil {
+		return nil, fmt.Errorf("failed to start market data stream: %w", err)
+	}
+	e.logger.Infof("Experiment %s started: %s (%s) vs %s (%s) on %s", e.config.Experiment.Name,
+		e.variants[0].config.Name, e.variants[0].config.Profile, e.variants[1].config.Name, e.variants[1].config.Profile,
+		e.config.Trading.DefaultSymbol)
+
+	errs := make(chan error, len(e.variants))
+	for _, variant := range e.variants {
+		go func(variant *experimentVariant) {
+			err := variant.app.Run(ctx)
+			if err != nil {
+				err = fmt.Errorf("variant %s: %w", variant.config.Name, err)
+			}
+			// The comparison only holds while both variants trade
+			stop()
+			errs <- err
+		}(variant)
+	}
+
+	progress := time.NewTicker(experimentProgressInterval)
+	defer progress.Stop()
+
+	var firstErr error
+	for pending := len(e.variants); pending > 0; {
+		select {
+		case err := <-errs:
+			pending--
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		case <-progress.C:
+			report := e.Compare(start, time.Now())
+			e.logger.Infof("Experiment %s after %s: %s", report.Name, time.Since(start).Round(time.Minute), report.Conclusion)
+		}
+	}
+	end := time.Now()
+	if err := e.broadcast.Stop(); err != nil {
+		e.logger.Warnf("Failed to stop market data stream: %v", err)
+	}
+
+	report := e.Compare(start, end)
+	if e.ownLogger {
+		e.logger.Close()
+	}
+	return &report, firstErr
+}
+
+// Compare compares the variants' results so far
+func (e *Experiment) Compare(start, end time.Time) journal.ExperimentReport {
+	report := journal.CompareVariants(e.variants[0].result(), e.variants[1].result(), e.config.Experiment.Confidence)
+	report.Name = e.config.Experiment.Name
+	report.Symbol = e.config.Trading.DefaultSymbol
+	report.Start = start
+	report.End = end
+	return report
+}
+
+// Directory returns the directory of the experiment's reports
+func (e *Experiment) Directory() string {
+	return e.config.Experiment.Directory
+}
+
+// result summarizes the variant's trading
+func (v *experimentVariant) result() journal.VariantResult {
+	orchestrator := v.app.Orchestrator()
+	performance := orchestrator.GetPerformance()
+	returns := orchestrator.GetTradeReturns()
+	initial := v.app.Config().Trading.InitialBalance
+
+	result := journal.VariantResult{
+		Name:            v.config.Name,
+		Profile:         v.config.Profile,
+		ConfigHash:      v.configHash,
+		InitialCapital:  initial,
+		FinalBalance:    initial,
+		MaxDrawdown:     performance.MaxDrawdown,
+		Trades:          performance.TotalTrades,
+		WinRate:         performance.WinRate,
+		Sharpe:          performance.SharpeRatio,
+		MeanTradeReturn: returns.Mean(),
+		TradeReturns:    returns,
+	}
+	if balance, err := v.app.tradingExecutor.GetBalance(); err == nil {
+		result.FinalBalance = balance
+	}
+	result.NetPnL = result.FinalBalance - initial
+	if initial > 0 {
+		result.Return = result.NetPnL / initial
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Broadcast shares one upstream feed between several consumers, e.g. the variants of an experiment, so
+// each consumer sees every event in the same order. A slow consumer holds back the others rather than
+// missing events, which would make the consumers' results incomparable.
+type Broadcast struct {
+	source  StreamProvider
+	outputs []*broadcastOutput
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+// broadcastOutput is the provider of one consumer; starting and stopping it is left to the broadcast
+type broadcastOutput struct {
+	broadcast *Broadcast
+	tickers   chan types.Ticker
+	candles   chan types.OHLCV
+}
+
+// NewBroadcast creates a broadcast of source to consumers outputs with channels of bufferSize (1000)
+func NewBroadcast(source StreamProvider, consumers, bufferSize int) (*Broadcast, error) {
+	if source == nil {
+		return nil, fmt.Errorf("broadcast requires a source provider")
+	}
+	if consumers < 1 {
+		return nil, fmt.Errorf("broadcast requires at least one consumer")
+	}
+	if bufferSize == 0 {
+		bufferSize = 1000 // default
+	}
+
+	b := &Broadcast{source: source}
+	for i := 0; i < consumers; i++ {
+		b.outputs = append(b.outputs, &broadcastOutput{
+			broadcast: b,
+			tickers:   make(chan types.Ticker, bufferSize),
+			candles:   make(chan types.OHLCV, bufferSize),
+		})
+	}
+	return b, nil
+}
+
+// Output returns the provider of consumer i
+func (b *Broadcast) Output(i int) StreamProvider {
+	return b.outputs[i]
+}
+
+// Start starts the source and copies its events to every output
+func (b *Broadcast) Start(ctx context.Context, symbols []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cancel != nil {
+		return fmt.Errorf("broadcast already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	if err := b.source.Start(ctx, symbols); err != nil {
+		cancel()
+		return err
+	}
+	b.cancel = cancel
+
+	b.wg.Add(1)
+	go b.pump(ctx)
+	return nil
+}
+
+// Stop stops copying events and stops the source
+func (b *Broadcast) Stop() error {
+	b.mu.Lock()
+	cancel := b.cancel
+	b.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	b.wg.Wait()
+	return b.source.Stop()
+}
+
+// pump copies source events to the outputs until ctx is cancelled
+func (b *Broadcast) pump(ctx context.Context) {
+	defer b.wg.Done()
+
+	tickers := b.source.GetTickerChannel()
+	candles := b.source.GetOHLCVChannel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case ticker, ok := <-tickers:
+			if !ok {
+				tickers = nil
+				continue
+			}
+			for _, output := range b.outputs {
+				select {
+				case output.tickers <- ticker:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+		case candle, ok := <-candles:
+			if !ok {
+				candles = nil
+				continue
+			}
+			for _, output := range b.outputs {
+				select {
+				case output.candles <- candle:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+// Start is a no-op; the broadcast starts the source
+func (o *broadcastOutput) Start(ctx context.Context, symbols []string) error {
+	return nil
+}
+
+// Stop is a no-op; the broadcast stops the source
+func (o *broadcastOutput) Stop() error {
+	return nil
+}
+
+// Subscribe subscribes the shared source, so every consumer receives the symbols
+func (o *broadcastOutput) Subscribe(symbols []string) error {
+	return o.broadcast.source.Subscribe(symbols)
+}
+
+// Unsubscribe is refused, since other consumers may still trade the symbols
+func (o *broadcastOutput) Unsubscribe(symbols []string) error {
+	return fmt.Errorf("cannot unsubscribe a shared broadcast stream")
+}
+
+// GetOHLCVChannel returns the consumer's candle channel
+func (o *broadcastOutput) GetOHLCVChannel() <-chan types.OHLCV {
+	return o.candles
+}
+
+// GetTickerChannel returns the consumer's ticker channel
+func (o *broadcastOutput) GetTickerChannel() <-chan types.Ticker {
+	return o.tickers
+}
+
+// IsConnected returns true while the source is connected
+func (o *broadcastOutput) IsConnected() bool {
+	return o.broadcast.source.IsConnected()
+}
+
+// GetSubscribedSymbols returns the symbols of the source
+func (o *broadcastOutput) GetSubscribedSymbols() []string {
+	return o.broadcast.source.GetSubscribedSymbols()
+}
+
+// GetLastError returns the last error of the source
+func (o *broadcastOutput) GetLastError() error {
+	return o.broadcast.source.GetLastError()
+}
@@ -56,6 +56,5 @@ func (f *StreamProviderFactory) createRealProvider(config RealStreamConfig) (Str
 
 // createReplayProvider creates a historical replay provider
 func (f *StreamProviderFactory) createReplayProvider(config ReplayConfig) (StreamProvider, error) {
-	// This would be implemented for historical data replay
-	return nil, fmt.Errorf("replay providers not implemented yet")
+	return NewReplayProvider(config)
 }
\ No newline at end of file
@@ -0,0 +1,435 @@
+package stream
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Feed names reported by the failover provider
+const (
+	FeedPrimary = "primary"
+	FeedBackup  = "backup"
+)
+
+// FailoverConfig holds configuration for a redundant dual-feed stream provider
+type FailoverConfig struct {
+	StallTimeout   time.Duration `json:"stall_timeout"`   // Silence of the active feed before switching to the other (2s)
+	RecoveryPeriod time.Duration `json:"recovery_period"` // Continuous primary data before switching back (30s)
+	CheckInterval  time.Duration `json:"check_interval"`  // Feed liveness check interval (500ms)
+	BufferSize     int           `json:"buffer_size"`     // Capacity of the merged channels (1000)
+}
+
+// FailoverSwitch records a change of the feed whose events are forwarded
+type FailoverSwitch struct {
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// feedState tracks the liveness of one upstream feed
+type feedState struct {
+	name        string
+	provider    StreamProvider
+	lastMessage time.Time
+	liveSince   time.Time // Start of the current run of messages without a stall
+	forwarded   int64
+	startErr    error
+}
+
+// FailoverProvider keeps a primary and a backup upstream feed streaming the same symbols and forwards the
+// events of one of them. When the active feed stalls or disconnects while the other is live it switches
+// over, and it returns to the primary once that has been live for the recovery period. Events already
+// forwarded are dropped, so the backup replaying recent data after a switch never reaches the consumer.
+type FailoverProvider struct {
+	config FailoverConfig
+	feeds  [2]*feedState
+	active int
+
+	tickers chan types.Ticker
+	candles chan types.OHLCV
+	trades  chan types.AggTrade
+
+	dedup       *StaleDataFilter
+	lastTradeID map[string]int64
+
+	// Statistics
+	duplicates    int64
+	switches      []FailoverSwitch // Most recent switches
+	totalSwitches int64
+	lastError     error
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+// NewFailoverProvider creates a failover provider over a primary and a backup feed
+func NewFailoverProvider(config FailoverConfig, primary, backup StreamProvider) (*FailoverProvider, error) {
+	if primary == nil || backup == nil {
+		return nil, fmt.Errorf("failover requires a primary and a backup provider")
+	}
+	if config.StallTimeout == 0 {
+		config.StallTimeout = 2 * time.Second // default
+	}
+	if config.RecoveryPeriod == 0 {
+		config.RecoveryPeriod = 30 * time.Second // default
+	}
+	if config.CheckInterval == 0 {
+		config.CheckInterval = 500 * time.Millisecond // default
+	}
+	if config.BufferSize == 0 {
+		config.BufferSize = 1000 // default
+	}
+
+	return &FailoverProvider{
+		config: config,
+		feeds: [2]*feedState{
+			{name: FeedPrimary, provider: primary},
+			{name: FeedBackup, provider: backup},
+		},
+		tickers:     make(chan types.Ticker, config.BufferSize),
+		candles:     make(chan types.OHLCV, config.BufferSize),
+		trades:      make(chan types.AggTrade, config.BufferSize),
+		dedup:       NewStaleDataFilter(StaleFilterConfig{}),
+		lastTradeID: make(map[string]int64),
+	}, nil
+}
+
+// Start starts both feeds; it fails only if neither can start
+func (p *FailoverProvider) Start(ctx context.Context, symbols []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	now := time.Now()
+	for _, feed := range p.feeds {
+		feed.startErr = feed.provider.Start(ctx, symbols)
+		if feed.startErr == nil {
+			feed.lastMessage, feed.liveSince = now, now // Grace period until the first event
+		}
+	}
+	if p.feeds[0].startErr != nil && p.feeds[1].startErr != nil {
+		cancel()
+		return fmt.Errorf("failed to start both feeds: primary: %v, backup: %w", p.feeds[0].startErr, p.feeds[1].startErr)
+	}
+	if p.feeds[0].startErr != nil {
+		p.switchTo(1, fmt.Sprintf("primary failed to start: %v", p.feeds[0].startErr), now)
+	}
+	p.cancel = cancel
+
+	for i, feed := range p.feeds {
+		if feed.startErr != nil {
+			continue
+		}
+		p.wg.Add(1)
+		go p.pump(ctx, i)
+	}
+	p.wg.Add(1)
+	go p.monitor(ctx)
+	return nil
+}
+
+// Stop stops both feeds and closes the merged channels
+func (p *FailoverProvider) Stop() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	var firstErr error
+	for _, feed := range p.feeds {
+		if feed.startErr != nil {
+			continue
+		}
+		if err := feed.provider.Stop(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to stop %s feed: %w", feed.name, err)
+		}
+	}
+
+	p.wg.Wait()
+	close(p.tickers)
+	close(p.candles)
+	close(p.trades)
+	return firstErr
+}
+
+// pump forwards the events of one feed while it is the active feed
+func (p *FailoverProvider) pump(ctx context.Context, index int) {
+	defer p.wg.Done()
+
+	provider := p.feeds[index].provider
+	tickers := provider.GetTickerChannel()
+	candles := provider.GetOHLCVChannel()
+	var trades <-chan types.AggTrade
+	if tradeProvider, ok := provider.(AggTradeProvider); ok {
+		trades = tradeProvider.GetAggTradeChannel()
+	}
+
+	for tickers != nil || candles != nil || trades != nil {
+		select {
+		case <-ctx.Done():
+			return
+
+		case ticker, ok := <-tickers:
+			if !ok {
+				tickers = nil
+				continue
+			}
+			if p.accept(index, func() bool { return p.dedup.CheckTicker(ticker) == VerdictAccept }) {
+				select {
+				case p.tickers <- ticker:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+		case candle, ok := <-candles:
+			if !ok {
+				candles = nil
+				continue
+			}
+			if p.accept(index, func() bool { return p.dedup.CheckCandle(candle) == VerdictAccept }) {
+				select {
+				case p.candles <- candle:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+		case trade, ok := <-trades:
+			if !ok {
+				trades = nil
+				continue
+			}
+			if p.accept(index, func() bool { return p.checkTrade(trade) }) {
+				select {
+				case p.trades <- trade:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+// accept records a message from a feed and returns true if it should be forwarded: it comes from the
+// active feed and was not forwarded before
+func (p *FailoverProvider) accept(index int, isNew func() bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	feed := p.feeds[index]
+	if now.Sub(feed.lastMessage) > p.config.StallTimeout {
+		feed.liveSince = now
+	}
+	feed.lastMessage = now
+
+	if index != p.active {
+		return false
+	}
+	if !isNew() {
+		p.duplicates++
+		return false
+	}
+	feed.forwarded++
+	return true
+}
+
+// checkTrade returns true for a trade newer than the last forwarded trade of its symbol; caller must hold p.mu
+func (p *FailoverProvider) checkTrade(trade types.AggTrade) bool {
+	if trade.AggTradeID == 0 {
+		return true // Without IDs the consumer's own checks apply
+	}
+	if trade.AggTradeID <= p.lastTradeID[trade.Symbol] {
+		return false
+	}
+	p.lastTradeID[trade.Symbol] = trade.AggTradeID
+	return true
+}
+
+// monitor switches feeds when the active one stalls and returns to the primary once it has recovered
+func (p *FailoverProvider) monitor(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.checkFeeds(now)
+		}
+	}
+}
+
+// checkFeeds applies the failover and recovery rules
+func (p *FailoverProvider) checkFeeds(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	other := 1 - p.active
+	if !p.isLive(p.active, now) && p.isLive(other, now) {
+		reason := fmt.Sprintf("%s feed stalled for %s", p.feeds[p.active].name, now.Sub(p.feeds[p.active].lastMessage).Round(time.Millisecond))
+		if !p.feeds[p.active].provider.IsConnected() {
+			reason = fmt.Sprintf("%s feed disconnected", p.feeds[p.active].name)
+		}
+		p.switchTo(other, reason, now)
+		return
+	}
+
+	if p.active != 0 && p.isLive(0, now) && now.Sub(p.feeds[0].liveSince) >= p.config.RecoveryPeriod {
+		p.switchTo(0, fmt.Sprintf("primary feed live for %s", p.config.RecoveryPeriod), now)
+	}
+}
+
+// isLive returns true if a feed is connected and delivered data within the stall timeout; caller must hold p.mu
+func (p *FailoverProvider) isLive(index int, now time.Time) bool {
+	feed := p.feeds[index]
+	return feed.startErr == nil && feed.provider.IsConnected() && now.Sub(feed.lastMessage) <= p.config.StallTimeout
+}
+
+// switchTo makes a feed the active one; caller must hold p.mu
+func (p *FailoverProvider) switchTo(index int, reason string, now time.Time) {
+	p.switches = append(p.switches, FailoverSwitch{
+		From:   p.feeds[p.active].name,
+		To:     p.feeds[index].name,
+		Reason: reason,
+		Time:   now,
+	})
+	if len(p.switches) > 20 {
+		p.switches = p.switches[1:]
+	}
+	p.totalSwitches++
+	p.lastError = fmt.Errorf("switched to %s feed: %s", p.feeds[index].name, reason)
+	p.active = index
+}
+
+// Subscribe adds symbols to both feeds
+func (p *FailoverProvider) Subscribe(symbols []string) error {
+	return p.forEachFeed(func(provider StreamProvider) error { return provider.Subscribe(symbols) })
+}
+
+// Unsubscribe removes symbols from both feeds
+func (p *FailoverProvider) Unsubscribe(symbols []string) error {
+	return p.forEachFeed(func(provider StreamProvider) error { return provider.Unsubscribe(symbols) })
+}
+
+// forEachFeed applies fn to every started feed and returns the first error; an error on the standby
+// feed is returned too, since it would leave the backup without the symbols
+func (p *FailoverProvider) forEachFeed(fn func(provider StreamProvider) error) error {
+	var firstErr error
+	for _, feed := range p.feeds {
+		if feed.startErr != nil {
+			continue
+		}
+		if err := fn(feed.provider); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s feed: %w", feed.name, err)
+		}
+	}
+	return firstErr
+}
+
+// GetOHLCVChannel returns the merged channel for OHLCV data
+func (p *FailoverProvider) GetOHLCVChannel() <-chan types.OHLCV {
+	return p.candles
+}
+
+// GetTickerChannel returns the merged channel for ticker data
+func (p *FailoverProvider) GetTickerChannel() <-chan types.Ticker {
+	return p.tickers
+}
+
+// GetAggTradeChannel returns the merged channel for aggregated trades; it stays empty unless the feeds
+// stream trades
+func (p *FailoverProvider) GetAggTradeChannel() <-chan types.AggTrade {
+	return p.trades
+}
+
+// IsConnected returns true if either feed is connected
+func (p *FailoverProvider) IsConnected() bool {
+	for _, feed := range p.feeds {
+		if feed.startErr == nil && feed.provider.IsConnected() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSubscribedSymbols returns the symbols subscribed on the active feed
+func (p *FailoverProvider) GetSubscribedSymbols() []string {
+	return p.feeds[p.ActiveIndex()].provider.GetSubscribedSymbols()
+}
+
+// GetLastError returns the active feed's last error, or the last failover
+func (p *FailoverProvider) GetLastError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.feeds[p.active].provider.GetLastError(); err != nil {
+		return err
+	}
+	return p.lastError
+}
+
+// ActiveIndex returns 0 while the primary feed is forwarded and 1 for the backup
+func (p *FailoverProvider) ActiveIndex() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active
+}
+
+// ActiveFeed returns the name of the forwarded feed
+func (p *FailoverProvider) ActiveFeed() string {
+	return p.feeds[p.ActiveIndex()].name
+}
+
+// SwitchesSince returns the feed switches after the first n, and the total number of switches so far
+func (p *FailoverProvider) SwitchesSince(n int64) ([]FailoverSwitch, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	missed := p.totalSwitches - n
+	if missed <= 0 {
+		return nil, p.totalSwitches
+	}
+	if missed > int64(len(p.switches)) {
+		missed = int64(len(p.switches)) // Older switches were trimmed
+	}
+	switches := make([]FailoverSwitch, missed)
+	copy(switches, p.switches[int64(len(p.switches))-missed:])
+	return switches, p.totalSwitches
+}
+
+// GetFailoverStats returns failover statistics
+func (p *FailoverProvider) GetFailoverStats() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	feeds := make(map[string]interface{}, len(p.feeds))
+	for i, feed := range p.feeds {
+		stats := map[string]interface{}{
+			"live":         p.isLive(i, now),
+			"last_message": feed.lastMessage,
+			"forwarded":    feed.forwarded,
+		}
+		if feed.startErr != nil {
+			stats["start_error"] = feed.startErr.Error()
+		}
+		feeds[feed.name] = stats
+	}
+
+	switches := make([]FailoverSwitch, len(p.switches))
+	copy(switches, p.switches)
+	return map[string]interface{}{
+		"active":         p.feeds[p.active].name,
+		"feeds":          feeds,
+		"duplicates":     p.duplicates,
+		"switches":       switches,
+		"total_switches": p.totalSwitches,
+	}
+}
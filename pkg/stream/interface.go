@@ -14,10 +14,10 @@ type StreamProvider interface {
 	// Stop stops the streaming provider
 	Stop() error
 
-	// Subscribe adds symbols to the subscription list
+	// Subscribe adds symbols to a running stream without reconnecting; subscribed symbols are ignored
 	Subscribe(symbols []string) error
 
-	// Unsubscribe removes symbols from the subscription list
+	// Unsubscribe removes symbols from a running stream without reconnecting; unknown symbols are ignored
 	Unsubscribe(symbols []string) error
 
 	// GetOHLCVChannel returns the channel for OHLCV data
@@ -36,6 +36,13 @@ type StreamProvider interface {
 	GetLastError() error
 }
 
+// AggTradeProvider is implemented by providers that can stream aggregated trades, which split candle volume
+// into taker buys and sells
+type AggTradeProvider interface {
+	// GetAggTradeChannel returns the channel for aggregated trades of the subscribed symbols
+	GetAggTradeChannel() <-chan types.AggTrade
+}
+
 // StreamConfig holds configuration for stream providers
 type StreamConfig struct {
 	ProviderType    string        `json:"provider_type"`    // "live", "replay"
@@ -47,6 +54,8 @@ type StreamConfig struct {
 	ReconnectDelay  time.Duration `json:"reconnect_delay"`
 	MaxRetries      int           `json:"max_retries"`
 	BufferSize      int           `json:"buffer_size"`
+	StaleFilter     StaleFilterConfig `json:"stale_filter"` // Per-symbol monotonic timestamp check
+	AggTrades       bool          `json:"agg_trades"`   // Consume aggregated trades when the provider supports them
 }
 
 
@@ -66,8 +75,10 @@ type ReplayConfig struct {
 	StartTime       time.Time     `json:"start_time"`
 	EndTime         time.Time     `json:"end_time"`
 	DataFiles       []string      `json:"data_files"`
-	PlaybackSpeed   float64       `json:"playback_speed"`   // 1.0 = normal speed
+	PlaybackSpeed   float64       `json:"playback_speed"`   // 1.0 = normal speed, 0 = as fast as consumed
+	Source          CandleSource  `json:"-"`                // Loads the replayed candles
 	PausePoints     []time.Time   `json:"pause_points"`     // Specific times to pause
+	CheckpointInterval time.Duration `json:"checkpoint_interval"` // Event time between checkpoints (0 disables them)
 }
 
 // StreamEvent represents various events from the stream
@@ -0,0 +1,261 @@
+package stream
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CandleSource loads historical candles of a symbol within [start, end] sorted by time; data.DataSource
+// implements it
+type CandleSource interface {
+	LoadCandles(symbol string, start, end time.Time) ([]types.OHLCV, error)
+}
+
+// FiniteProvider is implemented by providers whose data runs out, such as historical replays
+type FiniteProvider interface {
+	// Done is closed once every event has been delivered
+	Done() <-chan struct{}
+}
+
+// ReplayProgress is how far a replay has got: the number of candles delivered and the time of the last one
+type ReplayProgress struct {
+	Events int       `json:"events"`
+	Time   time.Time `json:"time"`
+}
+
+// ReplayProvider replays historical candles as market data: every candle is published on the candle
+// channel followed by its close as a ticker, in time order across symbols. Without a playback speed events
+// are delivered as fast as they are consumed; sends block, so a slow consumer never misses one.
+type ReplayProvider struct {
+	config  ReplayConfig
+	tickers chan types.Ticker
+	candles chan types.OHLCV
+	done    chan struct{}
+
+	symbols   *SubscriptionSet // Symbols loaded at start
+	connected bool
+	complete  bool // Every candle was delivered
+	lastError error
+
+	skip       int                  // Candles already processed by a resumed run
+	checkpoint func(ReplayProgress) // nil when no checkpoints are taken
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+}
+
+// NewReplayProvider creates a replay of the candles config.Source loads between StartTime and EndTime
+func NewReplayProvider(config ReplayConfig) (*ReplayProvider, error) {
+	if config.Source == nil {
+		return nil, fmt.Errorf("replay requires a candle source")
+	}
+	if config.PlaybackSpeed < 0 {
+		return nil, fmt.Errorf("replay playback speed cannot be negative")
+	}
+	if config.CheckpointInterval < 0 {
+		return nil, fmt.Errorf("replay checkpoint interval cannot be negative")
+	}
+	if config.BufferSize == 0 {
+		config.BufferSize = 1000 // default
+	}
+
+	return &ReplayProvider{
+		config:  config,
+		tickers: make(chan types.Ticker, config.BufferSize),
+		candles: make(chan types.OHLCV, config.BufferSize),
+		done:    make(chan struct{}),
+		symbols: NewSubscriptionSet(nil),
+	}, nil
+}
+
+// SetCheckpoint registers fn to be called each time the replay crosses a multiple of the checkpoint
+// interval in event time. The replay waits for fn, so every event up to the progress it is given has been
+// delivered and none after it. It must be called before Start.
+func (p *ReplayProvider) SetCheckpoint(fn func(ReplayProgress)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		return fmt.Errorf("replay already started")
+	}
+	p.checkpoint = fn
+	return nil
+}
+
+// Resume skips the first events candles, which a checkpoint recorded as processed, so a stopped replay
+// carries on where it was checkpointed. It must be called before Start.
+func (p *ReplayProvider) Resume(events int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		return fmt.Errorf("replay already started")
+	}
+	if events < 0 {
+		return fmt.Errorf("cannot resume at event %d", events)
+	}
+	p.skip = events
+	return nil
+}
+
+// Start loads the candles of symbols and starts replaying them
+func (p *ReplayProvider) Start(ctx context.Context, symbols []string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		return fmt.Errorf("replay already started")
+	}
+
+	candles := make([]types.OHLCV, 0)
+	for _, symbol := range symbols {
+		symbolCandles, err := p.config.Source.LoadCandles(symbol, p.config.StartTime, p.config.EndTime)
+		if err != nil {
+			p.lastError = err
+			return fmt.Errorf("failed to load %s candles: %w", symbol, err)
+		}
+		candles = append(candles, symbolCandles...)
+	}
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Timestamp.Before(candles[j].Timestamp)
+	})
+	if p.skip > len(candles) {
+		return fmt.Errorf("cannot resume at event %d of a %d candle replay", p.skip, len(candles))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+	p.symbols.Add(symbols)
+	p.connected = true
+
+	p.wg.Add(1)
+	go p.replay(ctx, candles, p.skip, p.checkpoint)
+	return nil
+}
+
+// replay delivers the candles from skip on and their closes, then closes done
+func (p *ReplayProvider) replay(ctx context.Context, candles []types.OHLCV, skip int, checkpoint func(ReplayProgress)) {
+	defer p.wg.Done()
+	defer func() {
+		p.mu.Lock()
+		p.connected = false
+		p.mu.Unlock()
+		close(p.done)
+	}()
+
+	for i := skip; i < len(candles); i++ {
+		candle := candles[i]
+		if p.config.PlaybackSpeed > 0 && i > skip {
+			wait := time.Duration(float64(candle.Timestamp.Sub(candles[i-1].Timestamp)) / p.config.PlaybackSpeed)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case p.candles <- candle:
+		case <-ctx.Done():
+			return
+		}
+		ticker := types.Ticker{Symbol: candle.Symbol, Timestamp: candle.Timestamp, Price: candle.Close, Volume: candle.Volume}
+		select {
+		case p.tickers <- ticker:
+		case <-ctx.Done():
+			return
+		}
+
+		if checkpoint != nil && p.checkpointDue(candles, i) {
+			checkpoint(ReplayProgress{Events: i + 1, Time: candle.Timestamp})
+		}
+	}
+
+	p.mu.Lock()
+	p.complete = true
+	p.mu.Unlock()
+}
+
+// checkpointDue returns true if the candle after index i starts a new checkpoint interval; the boundaries
+// are fixed in event time, so a resumed replay checkpoints where an uninterrupted one would
+func (p *ReplayProvider) checkpointDue(candles []types.OHLCV, i int) bool {
+	interval := p.config.CheckpointInterval
+	if interval <= 0 || i+1 >= len(candles) {
+		return false
+	}
+	return !candles[i+1].Timestamp.Truncate(interval).Equal(candles[i].Timestamp.Truncate(interval))
+}
+
+// Stop stops the replay
+func (p *ReplayProvider) Stop() error {
+	p.mu.Lock()
+	cancel := p.cancel
+	p.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+// Done is closed once every candle has been replayed or the replay was stopped
+func (p *ReplayProvider) Done() <-chan struct{} {
+	return p.done
+}
+
+// Complete returns true once every candle has been delivered, rather than the replay being stopped
+func (p *ReplayProvider) Complete() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.complete
+}
+
+// Subscribe is refused for symbols not loaded at start, since their history was not read
+func (p *ReplayProvider) Subscribe(symbols []string) error {
+	for _, symbol := range symbols {
+		if !p.symbols.Contains(symbol) {
+			return fmt.Errorf("cannot subscribe %s: replay symbols are loaded at start", symbol)
+		}
+	}
+	return nil
+}
+
+// Unsubscribe is a no-op; the replay keeps the timeline of every loaded symbol
+func (p *ReplayProvider) Unsubscribe(symbols []string) error {
+	return nil
+}
+
+// GetOHLCVChannel returns the channel for replayed candles
+func (p *ReplayProvider) GetOHLCVChannel() <-chan types.OHLCV {
+	return p.candles
+}
+
+// GetTickerChannel returns the channel for replayed closes
+func (p *ReplayProvider) GetTickerChannel() <-chan types.Ticker {
+	return p.tickers
+}
+
+// IsConnected returns true while candles are being replayed
+func (p *ReplayProvider) IsConnected() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.connected
+}
+
+// GetSubscribedSymbols returns the replayed symbols
+func (p *ReplayProvider) GetSubscribedSymbols() []string {
+	return p.symbols.Symbols()
+}
+
+// GetLastError returns the last candle loading error
+func (p *ReplayProvider) GetLastError() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.lastError
+}
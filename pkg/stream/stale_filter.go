@@ -0,0 +1,191 @@
+package stream
+
+import (
+	"aibot/internal/types"
+	"sync"
+	"time"
+)
+
+// Verdict is the result of checking an event against the last processed event for its symbol
+type Verdict string
+
+const (
+	VerdictAccept    Verdict = "accept"
+	VerdictStale     Verdict = "stale"     // Older than the last processed event
+	VerdictDuplicate Verdict = "duplicate" // Same timestamp and values as the last processed event
+)
+
+// StaleFilterConfig holds configuration for the stale data filter
+type StaleFilterConfig struct {
+	Tolerance     time.Duration `json:"tolerance"`      // Out-of-order events within this window are still accepted (0)
+	AlertInterval time.Duration `json:"alert_interval"` // Minimum time between stale data alerts (1m)
+}
+
+// StaleDataFilter enforces monotonic event timestamps per symbol so replayed or duplicated history
+// delivered after a reconnect never reaches the indicators
+type StaleDataFilter struct {
+	config  StaleFilterConfig
+	symbols map[string]*symbolSequence
+
+	// Rejections since the last alert
+	pendingStale     int64
+	pendingDuplicate int64
+	maxLag           time.Duration
+	lastAlert        time.Time
+
+	mu sync.Mutex
+}
+
+// symbolSequence tracks the last processed events for one symbol
+type symbolSequence struct {
+	lastTicker     types.Ticker
+	lastCandle     types.OHLCV
+	accepted       int64
+	stale          int64
+	duplicates     int64
+	lastRejectedAt time.Time
+}
+
+// StaleDataAlert summarizes rejections since the previous alert
+type StaleDataAlert struct {
+	Stale      int64         `json:"stale"`
+	Duplicates int64         `json:"duplicates"`
+	MaxLag     time.Duration `json:"max_lag"` // Largest distance behind the last processed event
+}
+
+// NewStaleDataFilter creates a new stale data filter
+func NewStaleDataFilter(config StaleFilterConfig) *StaleDataFilter {
+	if config.AlertInterval == 0 {
+		config.AlertInterval = time.Minute // default
+	}
+
+	return &StaleDataFilter{
+		config:  config,
+		symbols: make(map[string]*symbolSequence),
+	}
+}
+
+// CheckTicker returns whether a ticker may be processed and records it if accepted
+func (f *StaleDataFilter) CheckTicker(ticker types.Ticker) Verdict {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seq := f.sequence(ticker.Symbol)
+	last := seq.lastTicker
+
+	verdict := VerdictAccept
+	if !last.Timestamp.IsZero() {
+		switch {
+		case ticker.Timestamp.Equal(last.Timestamp) && ticker.Price == last.Price && ticker.Volume == last.Volume:
+			verdict = VerdictDuplicate
+		case ticker.Timestamp.Before(last.Timestamp.Add(-f.config.Tolerance)):
+			verdict = VerdictStale
+		}
+	}
+
+	f.record(seq, verdict, last.Timestamp.Sub(ticker.Timestamp))
+	if verdict == VerdictAccept && !ticker.Timestamp.Before(last.Timestamp) {
+		seq.lastTicker = ticker
+	}
+	return verdict
+}
+
+// CheckCandle returns whether a candle may be processed and records it if accepted
+func (f *StaleDataFilter) CheckCandle(candle types.OHLCV) Verdict {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	seq := f.sequence(candle.Symbol)
+	last := seq.lastCandle
+
+	verdict := VerdictAccept
+	if !last.Timestamp.IsZero() {
+		switch {
+		case candle.Timestamp.Equal(last.Timestamp) && candle.Close == last.Close && candle.Volume == last.Volume:
+			verdict = VerdictDuplicate
+		case candle.Timestamp.Before(last.Timestamp.Add(-f.config.Tolerance)):
+			verdict = VerdictStale
+		}
+	}
+
+	f.record(seq, verdict, last.Timestamp.Sub(candle.Timestamp))
+	if verdict == VerdictAccept && !candle.Timestamp.Before(last.Timestamp) {
+		seq.lastCandle = candle
+	}
+	return verdict
+}
+
+// TakeAlert returns a summary of rejections once per alert interval, or false if there is nothing to report
+func (f *StaleDataFilter) TakeAlert(now time.Time) (StaleDataAlert, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.pendingStale == 0 && f.pendingDuplicate == 0 {
+		return StaleDataAlert{}, false
+	}
+	if now.Sub(f.lastAlert) < f.config.AlertInterval {
+		return StaleDataAlert{}, false
+	}
+
+	alert := StaleDataAlert{
+		Stale:      f.pendingStale,
+		Duplicates: f.pendingDuplicate,
+		MaxLag:     f.maxLag,
+	}
+	f.pendingStale, f.pendingDuplicate, f.maxLag = 0, 0, 0
+	f.lastAlert = now
+	return alert, true
+}
+
+// sequence returns the sequence for a symbol, creating it if needed; caller must hold f.mu
+func (f *StaleDataFilter) sequence(symbol string) *symbolSequence {
+	seq, exists := f.symbols[symbol]
+	if !exists {
+		seq = &symbolSequence{}
+		f.symbols[symbol] = seq
+	}
+	return seq
+}
+
+// record updates counters for a verdict; caller must hold f.mu
+func (f *StaleDataFilter) record(seq *symbolSequence, verdict Verdict, lag time.Duration) {
+	switch verdict {
+	case VerdictAccept:
+		seq.accepted++
+		return
+	case VerdictStale:
+		seq.stale++
+		f.pendingStale++
+	case VerdictDuplicate:
+		seq.duplicates++
+		f.pendingDuplicate++
+	}
+
+	seq.lastRejectedAt = time.Now()
+	if lag > f.maxLag {
+		f.maxLag = lag
+	}
+}
+
+// GetFilterStats returns per-symbol accepted and rejected event counts
+func (f *StaleDataFilter) GetFilterStats() map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	symbols := make(map[string]interface{}, len(f.symbols))
+	for symbol, seq := range f.symbols {
+		symbols[symbol] = map[string]interface{}{
+			"accepted":         seq.accepted,
+			"stale":            seq.stale,
+			"duplicates":       seq.duplicates,
+			"last_ticker_time": seq.lastTicker.Timestamp,
+			"last_candle_time": seq.lastCandle.Timestamp,
+			"last_rejected_at": seq.lastRejectedAt,
+		}
+	}
+
+	return map[string]interface{}{
+		"tolerance": f.config.Tolerance,
+		"symbols":   symbols,
+	}
+}
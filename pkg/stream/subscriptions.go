@@ -0,0 +1,88 @@
+package stream
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SubscriptionSet tracks the symbols a provider streams so Subscribe and Unsubscribe can be applied to a
+// running connection as diffs: only symbols not yet subscribed are sent to the exchange on Subscribe, and
+// only subscribed ones on Unsubscribe, so repeated calls never force a reconnect
+type SubscriptionSet struct {
+	symbols map[string]bool
+	mu      sync.RWMutex
+}
+
+// NewSubscriptionSet creates a subscription set holding the given symbols
+func NewSubscriptionSet(symbols []string) *SubscriptionSet {
+	set := &SubscriptionSet{symbols: make(map[string]bool)}
+	set.Add(symbols)
+	return set
+}
+
+// normalizeSymbol returns the exchange form of a symbol
+func normalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// Add subscribes symbols and returns those that were not subscribed yet, in the given order
+func (s *SubscriptionSet) Add(symbols []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = normalizeSymbol(symbol)
+		if symbol == "" || s.symbols[symbol] {
+			continue
+		}
+		s.symbols[symbol] = true
+		added = append(added, symbol)
+	}
+	return added
+}
+
+// Remove unsubscribes symbols and returns those that were subscribed, in the given order
+func (s *SubscriptionSet) Remove(symbols []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = normalizeSymbol(symbol)
+		if !s.symbols[symbol] {
+			continue
+		}
+		delete(s.symbols, symbol)
+		removed = append(removed, symbol)
+	}
+	return removed
+}
+
+// Contains returns true if the symbol is subscribed
+func (s *SubscriptionSet) Contains(symbol string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.symbols[normalizeSymbol(symbol)]
+}
+
+// Symbols returns the subscribed symbols, sorted
+func (s *SubscriptionSet) Symbols() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	symbols := make([]string, 0, len(s.symbols))
+	for symbol := range s.symbols {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Len returns the number of subscribed symbols
+func (s *SubscriptionSet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.symbols)
+}
@@ -0,0 +1,206 @@
+package trading
+
+import (
+	"aibot/internal/types"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Transient errors injected by the simulation's chaos mode
+var (
+	ErrExchangeBusy = errors.New("exchange busy, try again later")
+	ErrDisconnected = errors.New("connection lost")
+)
+
+// ChaosConfig injects faults into the simulation executor to exercise error handling and reconciliation
+type ChaosConfig struct {
+	Enabled            bool          `json:"enabled"`
+	Seed               int64         `json:"seed"`                // Random seed; 0 uses the current time
+	Latency            time.Duration `json:"latency"`             // Added to every request
+	LatencyJitter      time.Duration `json:"latency_jitter"`      // Random extra latency up to this value
+	DisconnectRate     float64       `json:"disconnect_rate"`     // Probability a request drops the connection
+	DisconnectDuration time.Duration `json:"disconnect_duration"` // How long a dropped connection stays down (5s)
+	BusyRate           float64       `json:"busy_rate"`           // Probability a request fails with ErrExchangeBusy
+	FillDelay          time.Duration `json:"fill_delay"`          // Delay before order updates are published
+	ReorderRate        float64       `json:"reorder_rate"`        // Probability an update is held back behind the next one
+	RateLimitRate      float64       `json:"rate_limit_rate"`     // Probability a request is answered with a 429 and Retry-After
+	RateLimitDuration  time.Duration `json:"rate_limit_duration"` // Retry-After of an injected 429 (1m)
+}
+
+// chaosInjector decides which faults to inject; it has its own lock so delayed publishing never holds the executor lock
+type chaosInjector struct {
+	config ChaosConfig
+	rng    *rand.Rand
+
+	disconnectedUntil time.Time
+	rateLimit         *RateLimitError    // Injected ban; requests before its Until are refused with it
+	held              *types.OrderUpdate // Update waiting to be published after the next one
+
+	// Statistics
+	disconnects     int64
+	busyErrors      int64
+	rateLimits      int64
+	delayedUpdates  int64
+	reorderedEvents int64
+
+	mu sync.Mutex
+}
+
+// newChaosInjector creates a chaos injector, or nil if chaos mode is disabled
+func newChaosInjector(config ChaosConfig) *chaosInjector {
+	if !config.Enabled {
+		return nil
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano() // default
+	}
+	if config.DisconnectDuration == 0 {
+		config.DisconnectDuration = 5 * time.Second // default
+	}
+	if config.RateLimitDuration == 0 {
+		config.RateLimitDuration = time.Minute // default
+	}
+
+	return &chaosInjector{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// beforeRequest sleeps for the injected latency and returns an injected failure, if any
+func (c *chaosInjector) beforeRequest() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	latency := c.config.Latency
+	if c.config.LatencyJitter > 0 {
+		latency += time.Duration(c.rng.Int63n(int64(c.config.LatencyJitter)))
+	}
+	c.mu.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(c.disconnectedUntil) {
+		return ErrDisconnected
+	}
+	if c.rng.Float64() < c.config.DisconnectRate {
+		c.disconnects++
+		c.disconnectedUntil = now.Add(c.config.DisconnectDuration)
+		return ErrDisconnected
+	}
+	if c.rateLimit != nil && now.Before(c.rateLimit.Until) {
+		// Ignoring Retry-After turns a rate limit into an IP ban, as on Binance
+		c.rateLimit = c.rateLimitResponse(StatusIPBanned, now, "request sent during rate limit")
+		return c.rateLimit
+	}
+	if c.rng.Float64() < c.config.RateLimitRate {
+		c.rateLimits++
+		c.rateLimit = c.rateLimitResponse(StatusRateLimited, now, "too many requests")
+		return c.rateLimit
+	}
+	if c.rng.Float64() < c.config.BusyRate {
+		c.busyErrors++
+		return ErrExchangeBusy
+	}
+	return nil
+}
+
+// rateLimitResponse answers a request with a 418 or 429 carrying Retry-After, parsed as a live executor
+// parses the exchange's response
+func (c *chaosInjector) rateLimitResponse(statusCode int, now time.Time, message string) *RateLimitError {
+	header := http.Header{}
+	header.Set("Retry-After", strconv.Itoa(int(math.Ceil(c.config.RateLimitDuration.Seconds()))))
+	rateLimit := ParseRateLimitResponse(statusCode, header, now, c.config.RateLimitDuration)
+	rateLimit.Message = message
+	return rateLimit
+}
+
+// isDisconnected returns true while an injected disconnect is in effect
+func (c *chaosInjector) isDisconnected() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Now().Before(c.disconnectedUntil)
+}
+
+// publish sends an update to the feed, possibly delayed or swapped with the following update
+func (c *chaosInjector) publish(feed *FillFeed, update types.OrderUpdate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	batch := []types.OrderUpdate{update}
+	if c.held != nil {
+		// The held update is delivered after the one that arrived later
+		batch = append(batch, *c.held)
+		c.held = nil
+		c.reorderedEvents++
+	} else if c.rng.Float64() < c.config.ReorderRate {
+		held := update
+		c.held = &held
+		// Never hold an update forever if no other update follows
+		time.AfterFunc(c.config.FillDelay+time.Second, func() { c.flushHeld(feed) })
+		return
+	}
+
+	if c.config.FillDelay <= 0 {
+		for _, u := range batch {
+			feed.Publish(u)
+		}
+		return
+	}
+
+	c.delayedUpdates += int64(len(batch))
+	time.AfterFunc(c.config.FillDelay, func() {
+		for _, u := range batch {
+			feed.Publish(u)
+		}
+	})
+}
+
+// flushHeld publishes a held update that no later update has overtaken
+func (c *chaosInjector) flushHeld(feed *FillFeed) {
+	c.mu.Lock()
+	held := c.held
+	c.held = nil
+	c.mu.Unlock()
+
+	if held != nil {
+		feed.Publish(*held)
+	}
+}
+
+// stats returns chaos statistics
+func (c *chaosInjector) stats() map[string]interface{} {
+	if c == nil {
+		return map[string]interface{}{"enabled": false}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return map[string]interface{}{
+		"enabled":          true,
+		"seed":             c.config.Seed,
+		"disconnects":      c.disconnects,
+		"busy_errors":      c.busyErrors,
+		"rate_limits":      c.rateLimits,
+		"rate_limited":     c.rateLimit != nil && time.Now().Before(c.rateLimit.Until),
+		"delayed_updates":  c.delayedUpdates,
+		"reordered_events": c.reorderedEvents,
+		"disconnected":     time.Now().Before(c.disconnectedUntil),
+	}
+}
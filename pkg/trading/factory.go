@@ -22,6 +22,8 @@ func (f *TradingExecutorFactory) CreateTradingExecutor(config interface{}) (Trad
 		providerType = c.ProviderType
 	case LiveConfig:
 		providerType = c.ProviderType
+	case SimulationConfig:
+		providerType = c.ProviderType
 	default:
 		return nil, fmt.Errorf("unknown configuration type")
 	}
@@ -34,6 +36,15 @@ func (f *TradingExecutorFactory) CreateTradingExecutor(config interface{}) (Trad
 		}
 		return f.createLiveExecutor(liveConfig)
 
+	case "simulation":
+		switch c := config.(type) {
+		case SimulationConfig:
+			return NewSimulationExecutor(c), nil
+		case ExecutionConfig:
+			return NewSimulationExecutor(SimulationConfig{ExecutionConfig: c}), nil
+		}
+		return nil, fmt.Errorf("invalid configuration for simulation executor")
+
 	default:
 		return nil, fmt.Errorf("unsupported executor type: %s", providerType)
 	}
@@ -42,5 +53,10 @@ func (f *TradingExecutorFactory) CreateTradingExecutor(config interface{}) (Trad
 // createLiveExecutor creates a live trading executor
 func (f *TradingExecutorFactory) createLiveExecutor(config LiveConfig) (TradingExecutor, error) {
 	// This would be implemented for real exchanges like Binance, Bybit, etc.
+	// Live executors must translate exchange user-data events (fills, cancels,
+	// rejects) into types.OrderUpdate and publish them through a FillFeed. They must also check a
+	// RateLimitGuard before every REST request and record 418/429 responses parsed by
+	// ParseRateLimitResponse, so a ban is waited out instead of extended. Exchanges do not carry
+	// Order.Tag, so live executors keep it per client order ID and set it on the order's updates.
 	return nil, fmt.Errorf("live executors not implemented yet")
 }
\ No newline at end of file
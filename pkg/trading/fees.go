@@ -0,0 +1,134 @@
+package trading
+
+import (
+	"sort"
+	"time"
+)
+
+// FeeTier is one VIP level of a fee schedule
+type FeeTier struct {
+	Name      string  `json:"name"`
+	MinVolume float64 `json:"min_volume"` // Rolling volume required for the tier, in the margin currency
+	MakerFee  float64 `json:"maker_fee"`
+	TakerFee  float64 `json:"taker_fee"`
+}
+
+// FeeScheduleConfig holds a tiered maker/taker fee schedule
+type FeeScheduleConfig struct {
+	Tiers        []FeeTier     `json:"tiers"`         // Empty uses the flat commission rate
+	VolumeWindow time.Duration `json:"volume_window"` // Rolling volume window deciding the tier (30 days)
+	BNBDiscount  bool          `json:"bnb_discount"`  // Fees are paid in BNB at a discount
+	DiscountRate float64       `json:"discount_rate"` // Fraction taken off fees paid in BNB (0.1)
+}
+
+// FeeModel tracks rolling traded volume and returns the fee rate of the tier it qualifies for.
+// It is not safe for concurrent use; executors call it under their own lock.
+type FeeModel struct {
+	config  FeeScheduleConfig
+	daily   map[time.Time]float64 // UTC day -> traded volume
+	current int                   // Index of the tier in force
+
+	// Statistics
+	tierChanges int64
+	makerFills  int64
+	takerFills  int64
+}
+
+// NewFeeModel creates a fee model; tiers are ordered by minimum volume
+func NewFeeModel(config FeeScheduleConfig) *FeeModel {
+	if config.VolumeWindow == 0 {
+		config.VolumeWindow = 30 * 24 * time.Hour // default
+	}
+	if config.DiscountRate == 0 {
+		config.DiscountRate = 0.1 // default
+	}
+
+	tiers := append([]FeeTier(nil), config.Tiers...)
+	sort.SliceStable(tiers, func(i, j int) bool { return tiers[i].MinVolume < tiers[j].MinVolume })
+	config.Tiers = tiers
+
+	return &FeeModel{
+		config: config,
+		daily:  make(map[time.Time]float64),
+	}
+}
+
+// Rate returns the fee rate for a fill at time now
+func (m *FeeModel) Rate(maker bool, now time.Time) float64 {
+	if len(m.config.Tiers) == 0 {
+		return 0
+	}
+
+	tier := m.config.Tiers[m.tierIndex(now)]
+	rate := tier.TakerFee
+	if maker {
+		rate = tier.MakerFee
+	}
+	if m.config.BNBDiscount {
+		rate *= 1 - m.config.DiscountRate
+	}
+	return rate
+}
+
+// RecordFill adds a fill's volume to the rolling window
+func (m *FeeModel) RecordFill(notional float64, maker bool, now time.Time) {
+	m.daily[now.UTC().Truncate(24*time.Hour)] += notional
+	if maker {
+		m.makerFills++
+	} else {
+		m.takerFills++
+	}
+}
+
+// WindowVolume returns the traded volume inside the rolling window ending at now
+func (m *FeeModel) WindowVolume(now time.Time) float64 {
+	cutoff := now.UTC().Add(-m.config.VolumeWindow)
+	volume := 0.0
+	for day, dayVolume := range m.daily {
+		if day.Add(24 * time.Hour).After(cutoff) {
+			volume += dayVolume
+			continue
+		}
+		delete(m.daily, day)
+	}
+	return volume
+}
+
+// CurrentTier returns the tier in force at time now
+func (m *FeeModel) CurrentTier(now time.Time) FeeTier {
+	if len(m.config.Tiers) == 0 {
+		return FeeTier{}
+	}
+	return m.config.Tiers[m.tierIndex(now)]
+}
+
+// tierIndex returns the highest tier whose volume requirement is met and records tier changes
+func (m *FeeModel) tierIndex(now time.Time) int {
+	volume := m.WindowVolume(now)
+	index := 0
+	for i, tier := range m.config.Tiers {
+		if volume >= tier.MinVolume {
+			index = i
+		}
+	}
+	if index != m.current {
+		m.current = index
+		m.tierChanges++
+	}
+	return index
+}
+
+// GetFeeStats returns fee schedule statistics at time now
+func (m *FeeModel) GetFeeStats(now time.Time) map[string]interface{} {
+	tier := m.CurrentTier(now)
+	return map[string]interface{}{
+		"tier":          tier.Name,
+		"window_volume": m.WindowVolume(now),
+		"maker_rate":    m.Rate(true, now),
+		"taker_rate":    m.Rate(false, now),
+		"bnb_discount":  m.config.BNBDiscount,
+		"tier_changes":  m.tierChanges,
+		"maker_fills":   m.makerFills,
+		"taker_fills":   m.takerFills,
+	}
+}
@@ -0,0 +1,66 @@
+package trading
+
+import (
+	"aibot/internal/types"
+	"sync"
+	"sync/atomic"
+)
+
+// FillFeed publishes order updates to a buffered channel shared by all executor implementations
+type FillFeed struct {
+	ch      chan types.OrderUpdate
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+}
+
+// NewFillFeed creates a new fill feed with the given buffer size
+func NewFillFeed(bufferSize int) *FillFeed {
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+	return &FillFeed{
+		ch: make(chan types.OrderUpdate, bufferSize),
+	}
+}
+
+// Publish sends an update without blocking the executor, which may hold its own lock while the consumer
+// calls back into it; returns false if the update was dropped. Dropped updates are counted so the consumer
+// can resync positions from the executor.
+func (f *FillFeed) Publish(update types.OrderUpdate) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.closed {
+		return false
+	}
+
+	select {
+	case f.ch <- update:
+		return true
+	default:
+		f.dropped.Add(1)
+		return false
+	}
+}
+
+// Channel returns the receive side of the feed
+func (f *FillFeed) Channel() <-chan types.OrderUpdate {
+	return f.ch
+}
+
+// Dropped returns the number of updates dropped because the buffer was full
+func (f *FillFeed) Dropped() int64 {
+	return f.dropped.Load()
+}
+
+// Close closes the feed; subsequent publishes are ignored
+func (f *FillFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.closed {
+		f.closed = true
+		close(f.ch)
+	}
+}
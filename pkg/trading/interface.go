@@ -43,11 +43,56 @@ type TradingExecutor interface {
 	GetFeeRates() (*FeeRates, error)
 	GetLeverage(symbol string) (float64, error)
 	SetLeverage(symbol string, leverage float64) error
+
+	// Order events
+	GetFillChannel() <-chan types.OrderUpdate
+}
+
+// MarketDataReceiver is implemented by executors that need market data to simulate fills
+type MarketDataReceiver interface {
+	UpdateTicker(ticker types.Ticker)
+}
+
+// AccountInfoProvider is implemented by executors that can report API permissions and exchange trading rules
+type AccountInfoProvider interface {
+	GetAccountPermissions() (*AccountPermissions, error)
+	GetSymbolRules(symbol string) (*SymbolRules, error)
+}
+
+// SpotBalanceProvider is implemented by executors that hold per-asset spot balances
+type SpotBalanceProvider interface {
+	GetAssetBalances() (map[string]float64, error)
+}
+
+// WalletTransferProvider is implemented by executors that can move quote balance out of the trading wallet,
+// e.g. to sweep realized profit to a spot or funding wallet. Returns the transfer ID.
+type WalletTransferProvider interface {
+	TransferOut(asset string, amount float64) (string, error)
+}
+
+// ConditionalOrderProvider is implemented by executors that report which order types they execute natively,
+// so stops and trailing stops can be left to the exchange instead of being watched by the bot
+type ConditionalOrderProvider interface {
+	SupportsOrderType(orderType types.OrderType) bool
+}
+
+// FillDropReporter is implemented by executors whose order updates are dropped while the fill channel is
+// full; callers resync positions from the executor when the count grows
+type FillDropReporter interface {
+	DroppedFills() int64
+}
+
+// HedgePositionProvider is implemented by executors that can hold long and short positions on the same symbol
+type HedgePositionProvider interface {
+	IsHedgeMode() bool
+	GetHedgePosition(symbol string) (*types.HedgePosition, error)
 }
 
 // ExecutionConfig holds configuration for execution providers
 type ExecutionConfig struct {
-	ProviderType     string  `json:"provider_type"`     // "live"
+	ProviderType     string  `json:"provider_type"`     // "live", "simulation"
+	MarketType       types.MarketType `json:"market_type"` // "futures", "spot"
+	QuoteAsset       string  `json:"quote_asset"`       // Quote asset for spot balances (USDT)
 	Exchange         string  `json:"exchange"`          // "binance", "bybit", etc.
 	APIKey          string  `json:"api_key"`
 	APISecret       string  `json:"api_secret"`
@@ -59,6 +104,9 @@ type ExecutionConfig struct {
 	MaxOpenPositions int     `json:"max_open_positions"`
 	Commission      float64 `json:"commission"`         // Default commission rate
 	Slippage        float64 `json:"slippage"`          // Default slippage percentage
+	EnableHedging   bool    `json:"enable_hedging"`    // Track long and short positions per symbol independently
+	Contracts       map[string]types.ContractSpec `json:"contracts"` // Symbol -> contract; symbols not listed are linear
+	FeeSchedule     FeeScheduleConfig `json:"fee_schedule"` // Tiered maker/taker fees replacing Commission when tiers are set
 }
 
 
@@ -69,10 +117,27 @@ type LiveConfig struct {
 	RESTURL         string        `json:"rest_url"`
 	Timeout         time.Duration `json:"timeout"`
 	RateLimitPerSec int           `json:"rate_limit_per_sec"`
+	BanFallback     time.Duration `json:"ban_fallback"` // REST pause after a 418/429 without Retry-After (2m)
 	UseTestNet      bool          `json:"use_testnet"`
-	EnableHedging   bool          `json:"enable_hedging"`
 }
 
+// SimulationConfig holds specific configuration for simulated execution
+type SimulationConfig struct {
+	ExecutionConfig
+	FillBufferSize int `json:"fill_buffer_size"` // Buffered order updates before events are dropped (see FillDropReporter)
+	Chaos          ChaosConfig `json:"chaos"`      // Fault injection for exercising error handling
+	Margin         MarginConfig `json:"margin"`    // Maintenance margin, margin calls and liquidation on futures
+}
+
+// MarginConfig holds the margin model of simulated futures accounts (cross margin)
+type MarginConfig struct {
+	MaintenanceMarginRate float64 `json:"maintenance_margin_rate"` // Share of position notional kept as maintenance margin (0.4%)
+	MarginCallRatio       float64 `json:"margin_call_ratio"`       // Maintenance margin / equity at which a margin call is raised (0.8)
+}
+
+// OrderReasonLiquidation is the reason of order updates of positions closed by forced liquidation
+const OrderReasonLiquidation = "liquidation"
+
 
 // MarginInfo contains margin information
 type MarginInfo struct {
@@ -82,10 +147,31 @@ type MarginInfo struct {
 	FreeMargin         float64 `json:"free_margin"`
 	MarginLevel        float64 `json:"margin_level"`
 	MaintenanceMargin  float64 `json:"maintenance_margin"`
+	MarginRatio        float64 `json:"margin_ratio"` // Maintenance margin / equity; positions are liquidated at 1
+	MarginCall         bool    `json:"margin_call"`  // Margin ratio is at or above the margin call ratio
 	Leverage           float64 `json:"leverage"`
 	Currency           string  `json:"currency"`
 }
 
+// AccountPermissions describes what the configured API credentials are allowed to do
+type AccountPermissions struct {
+	CanTrade    bool `json:"can_trade"`
+	CanFutures  bool `json:"can_futures"`
+	CanWithdraw bool `json:"can_withdraw"`
+}
+
+// SymbolRules contains exchange trading rules for a symbol
+type SymbolRules struct {
+	Symbol      string  `json:"symbol"`
+	Status      string  `json:"status"`       // "TRADING" when the symbol accepts orders
+	MinQty      float64 `json:"min_qty"`
+	MaxQty      float64 `json:"max_qty"`      // 0 means no limit
+	StepSize    float64 `json:"step_size"`
+	TickSize    float64 `json:"tick_size"`
+	MinNotional float64 `json:"min_notional"`
+	MaxLeverage float64 `json:"max_leverage"`
+}
+
 // OrderBook represents the order book
 type OrderBook struct {
 	Symbol    string          `json:"symbol"`
@@ -113,6 +199,10 @@ type ExecutionStats struct {
 	TotalOrders       int64     `json:"total_orders"`
 	SuccessfulOrders  int64     `json:"successful_orders"`
 	FailedOrders      int64     `json:"failed_orders"`
+	DuplicateOrders   int64     `json:"duplicate_orders"` // Retries answered with an already accepted order
+	TriggeredOrders   int64     `json:"triggered_orders"` // Stop, stop-limit and trailing stop orders whose trigger was hit
+	MarginCalls       int64     `json:"margin_calls"`     // Times the margin ratio reached the margin call ratio
+	Liquidations      int64     `json:"liquidations"`     // Times equity fell to maintenance margin and positions were closed
 	TotalVolume       float64   `json:"total_volume"`
 	TotalFees         float64   `json:"total_fees"`
 	AvgLatency        time.Duration `json:"avg_latency"`
@@ -145,6 +235,8 @@ type Trade struct {
 	Quantity    float64       `json:"quantity"`
 	Price       float64       `json:"price"`
 	Fee         float64       `json:"fee"`
+	FeeAsset    string        `json:"fee_asset,omitempty"`
+	RealizedPnL float64       `json:"realized_pnl"`
 	Timestamp   time.Time     `json:"timestamp"`
 	OrderID     string        `json:"order_id"`
 	TradeID     string        `json:"trade_id"`
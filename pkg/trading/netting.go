@@ -0,0 +1,285 @@
+package trading
+
+import (
+	"aibot/internal/types"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// NettingExecutor lets several strategies trade one symbol on a one-way (netted) account. Each strategy
+// keeps a virtual position keyed by its client order ID prefix; reduce-only flags are translated against
+// the net exchange position and realized PnL on fills is recomputed per strategy.
+type NettingExecutor struct {
+	TradingExecutor
+
+	fills     *FillFeed
+	positions map[string]map[string]*VirtualPosition // Strategy -> symbol -> position
+	pending   map[string]*pendingOrder                // Client order ID -> market order not fully reported yet
+
+	// Statistics
+	translatedOrders int64 // Reduce-only orders sent without the flag because they add to the net position
+	rejectedOrders   int64 // Reduce-only orders larger than the strategy's own position
+	exchangePnL      float64
+
+	mu sync.Mutex
+}
+
+// VirtualPosition is a strategy's share of the netted exchange position
+type VirtualPosition struct {
+	Quantity    float64 `json:"quantity"` // Signed: positive long, negative short
+	AvgPrice    float64 `json:"avg_price"`
+	RealizedPnL float64 `json:"realized_pnl"`
+	Fills       int64   `json:"fills"`
+}
+
+// pendingOrder is a placed market order whose fills have not all been forwarded; it counts towards
+// positions so a reduce-only order sent right after an entry sees the entry
+type pendingOrder struct {
+	strategy  string
+	symbol    string
+	remaining float64 // Signed quantity still to be reported
+}
+
+// NewNettingExecutor wraps an executor and starts forwarding its fills
+func NewNettingExecutor(inner TradingExecutor) *NettingExecutor {
+	n := &NettingExecutor{
+		TradingExecutor: inner,
+		fills:           NewFillFeed(0),
+		positions:       make(map[string]map[string]*VirtualPosition),
+		pending:         make(map[string]*pendingOrder),
+	}
+	go n.forwardFills(inner.GetFillChannel())
+	return n
+}
+
+// PlaceOrder checks reduce-only orders against the placing strategy's virtual position and only keeps the
+// flag when the order also reduces the net exchange position
+func (n *NettingExecutor) PlaceOrder(order *types.Order) (*types.OrderResult, error) {
+	n.mu.Lock()
+	strategy := types.StrategyFromClientOrderID(order.ClientOrderID)
+	if !order.ReduceOnly {
+		n.trackPending(order, strategy)
+		n.mu.Unlock()
+		return n.placeTracked(order)
+	}
+
+	own := n.expectedQuantity(strategy, order.Symbol)
+	if !reduces(own, order.Side, order.Quantity) {
+		n.rejectedOrders++
+		n.mu.Unlock()
+		return nil, fmt.Errorf("reduce-only %s %.6f exceeds %s position %.6f on %s",
+			order.Side, order.Quantity, strategy, own, order.Symbol)
+	}
+
+	netted := order
+	if !reduces(n.netQuantity(order.Symbol), order.Side, order.Quantity) {
+		// Closing this strategy's position opens exposure on the netted account
+		copied := *order
+		copied.ReduceOnly = false
+		netted = &copied
+		n.translatedOrders++
+	}
+	n.trackPending(order, strategy)
+	n.mu.Unlock()
+
+	return n.placeTracked(netted)
+}
+
+// placeTracked places an order and forgets its pending quantity if the placement fails
+func (n *NettingExecutor) placeTracked(order *types.Order) (*types.OrderResult, error) {
+	result, err := n.TradingExecutor.PlaceOrder(order)
+	if err != nil {
+		n.mu.Lock()
+		delete(n.pending, order.ClientOrderID)
+		n.mu.Unlock()
+	}
+	return result, err
+}
+
+// trackPending records a market order until its fills are forwarded; caller must hold n.mu
+func (n *NettingExecutor) trackPending(order *types.Order, strategy string) {
+	if order.Type != types.OrderTypeMarket || order.ClientOrderID == "" {
+		return
+	}
+	n.pending[order.ClientOrderID] = &pendingOrder{
+		strategy:  strategy,
+		symbol:    order.Symbol,
+		remaining: signedQuantity(order.Side, order.Quantity),
+	}
+}
+
+// SupportsOrderType reports the order types of the wrapped executor; conditional orders are netted like
+// any other order once they fill
+func (n *NettingExecutor) SupportsOrderType(orderType types.OrderType) bool {
+	if provider, ok := n.TradingExecutor.(ConditionalOrderProvider); ok {
+		return provider.SupportsOrderType(orderType)
+	}
+	return orderType == types.OrderTypeMarket || orderType == types.OrderTypeLimit
+}
+
+// GetFillChannel returns the feed of fills with per-strategy realized PnL
+func (n *NettingExecutor) GetFillChannel() <-chan types.OrderUpdate {
+	return n.fills.Channel()
+}
+
+// DroppedFills returns the order updates dropped by the wrapped executor and by the forwarding feed
+func (n *NettingExecutor) DroppedFills() int64 {
+	dropped := n.fills.Dropped()
+	if reporter, ok := n.TradingExecutor.(FillDropReporter); ok {
+		dropped += reporter.DroppedFills()
+	}
+	return dropped
+}
+
+// forwardFills applies inner fills to the virtual positions and republishes them
+func (n *NettingExecutor) forwardFills(inner <-chan types.OrderUpdate) {
+	defer n.fills.Close()
+
+	for update := range inner {
+		n.mu.Lock()
+		if update.IsFill() {
+			n.applyFill(&update)
+		}
+		if pending, exists := n.pending[update.ClientOrderID]; exists {
+			pending.remaining -= signedQuantity(update.Side, update.LastFillQty)
+			if update.Status != types.OrderStatusPartial && update.Status != types.OrderStatusNew {
+				delete(n.pending, update.ClientOrderID)
+			}
+		}
+		n.mu.Unlock()
+		n.fills.Publish(update)
+	}
+}
+
+// applyFill updates the placing strategy's virtual position and replaces the exchange's realized PnL
+// (computed on the net position) with the PnL of the strategy's own position; caller must hold n.mu
+func (n *NettingExecutor) applyFill(update *types.OrderUpdate) {
+	position := n.position(types.StrategyFromClientOrderID(update.ClientOrderID), update.Symbol)
+	quantity := signedQuantity(update.Side, update.LastFillQty)
+
+	pnl := 0.0
+	switch {
+	case position.Quantity*quantity < 0:
+		closing := math.Min(math.Abs(quantity), math.Abs(position.Quantity))
+		direction := math.Copysign(1, position.Quantity)
+		pnl = closing * (update.LastFillPrice - position.AvgPrice) * direction
+		position.Quantity += quantity
+		if math.Abs(position.Quantity) < types.DefaultQuantityEpsilon {
+			position.Quantity, position.AvgPrice = 0, 0
+		} else if position.Quantity*direction < 0 {
+			// Flipped through zero; the remainder opened at the fill price
+			position.AvgPrice = update.LastFillPrice
+		}
+	default:
+		total := math.Abs(position.Quantity) + math.Abs(quantity)
+		if total > 0 {
+			position.AvgPrice = (position.AvgPrice*math.Abs(position.Quantity) + update.LastFillPrice*math.Abs(quantity)) / total
+		}
+		position.Quantity += quantity
+	}
+
+	position.RealizedPnL += pnl
+	position.Fills++
+	n.exchangePnL += update.RealizedPnL
+	update.RealizedPnL = pnl
+}
+
+// position returns a strategy's virtual position, creating it if needed; caller must hold n.mu
+func (n *NettingExecutor) position(strategy, symbol string) *VirtualPosition {
+	symbols, exists := n.positions[strategy]
+	if !exists {
+		symbols = make(map[string]*VirtualPosition)
+		n.positions[strategy] = symbols
+	}
+	position, exists := symbols[symbol]
+	if !exists {
+		position = &VirtualPosition{}
+		symbols[symbol] = position
+	}
+	return position
+}
+
+// expectedQuantity returns a strategy's position including pending market orders; caller must hold n.mu
+func (n *NettingExecutor) expectedQuantity(strategy, symbol string) float64 {
+	quantity := n.position(strategy, symbol).Quantity
+	for _, pending := range n.pending {
+		if pending.strategy == strategy && pending.symbol == symbol {
+			quantity += pending.remaining
+		}
+	}
+	return quantity
+}
+
+// netQuantity returns the sum of all strategies' positions in a symbol including pending market
+// orders; caller must hold n.mu
+func (n *NettingExecutor) netQuantity(symbol string) float64 {
+	net := 0.0
+	for _, symbols := range n.positions {
+		if position, exists := symbols[symbol]; exists {
+			net += position.Quantity
+		}
+	}
+	for _, pending := range n.pending {
+		if pending.symbol == symbol {
+			net += pending.remaining
+		}
+	}
+	return net
+}
+
+// signedQuantity returns quantity as positive for buys and negative for sells
+func signedQuantity(side types.OrderSide, quantity float64) float64 {
+	if side == types.OrderSideSell {
+		return -quantity
+	}
+	return quantity
+}
+
+// reduces returns true if an order of the given side and quantity only reduces a signed position
+func reduces(position float64, side types.OrderSide, quantity float64) bool {
+	if side == types.OrderSideSell {
+		return position >= quantity-types.DefaultQuantityEpsilon
+	}
+	return -position >= quantity-types.DefaultQuantityEpsilon
+}
+
+// GetVirtualPositions returns a copy of every strategy's virtual positions
+func (n *NettingExecutor) GetVirtualPositions() map[string]map[string]VirtualPosition {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	result := make(map[string]map[string]VirtualPosition, len(n.positions))
+	for strategy, symbols := range n.positions {
+		result[strategy] = make(map[string]VirtualPosition, len(symbols))
+		for symbol, position := range symbols {
+			result[strategy][symbol] = *position
+		}
+	}
+	return result
+}
+
+// GetNettingStats returns netting statistics
+func (n *NettingExecutor) GetNettingStats() map[string]interface{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	net := make(map[string]float64)
+	strategyPnL := 0.0
+	for _, symbols := range n.positions {
+		for symbol, position := range symbols {
+			net[symbol] += position.Quantity
+			strategyPnL += position.RealizedPnL
+		}
+	}
+
+	return map[string]interface{}{
+		"strategies":            len(n.positions),
+		"net_positions":         net,
+		"translated_orders":     n.translatedOrders,
+		"rejected_orders":       n.rejectedOrders,
+		"strategy_realized_pnl": strategyPnL,
+		"exchange_realized_pnl": n.exchangePnL,
+		"dropped_fills":         n.fills.Dropped(),
+	}
+}
@@ -0,0 +1,159 @@
+package trading
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exchange status codes of rate limit responses
+const (
+	StatusRateLimited = http.StatusTooManyRequests // 429: request weight or order limit exceeded
+	StatusIPBanned    = http.StatusTeapot          // 418: IP banned for continuing to send requests after 429s
+)
+
+// DefaultBanFallback is the REST pause after a 418/429 response without Retry-After
+const DefaultBanFallback = 2 * time.Minute
+
+// RateLimitError is returned while the exchange rate limits or bans the account's REST requests.
+// Any REST request before Until extends the ban, so callers must stop sending them until then.
+type RateLimitError struct {
+	StatusCode int           `json:"status_code"`
+	RetryAfter time.Duration `json:"retry_after"`
+	Until      time.Time     `json:"until"`
+	Message    string        `json:"message,omitempty"`
+}
+
+// Error implements error
+func (e *RateLimitError) Error() string {
+	kind := "rate limited"
+	if e.StatusCode == StatusIPBanned {
+		kind = "banned"
+	}
+	message := fmt.Sprintf("exchange %s (HTTP %d) until %s", kind, e.StatusCode, e.Until.Format(time.RFC3339))
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	return message
+}
+
+// IsBan returns true for an IP ban rather than a plain rate limit
+func (e *RateLimitError) IsBan() bool {
+	return e.StatusCode == StatusIPBanned
+}
+
+// AsRateLimitError returns the rate limit error wrapped in err, if any
+func AsRateLimitError(err error) (*RateLimitError, bool) {
+	var rateLimit *RateLimitError
+	if errors.As(err, &rateLimit) {
+		return rateLimit, true
+	}
+	return nil, false
+}
+
+// ParseRateLimitResponse returns a rate limit error for a 418 or 429 response, or nil for any other status.
+// Retry-After is read as seconds or an HTTP date; without it the ban lasts fallback.
+func ParseRateLimitResponse(statusCode int, header http.Header, now time.Time, fallback time.Duration) *RateLimitError {
+	if statusCode != StatusRateLimited && statusCode != StatusIPBanned {
+		return nil
+	}
+
+	retryAfter := fallback
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		} else if at, err := http.ParseTime(value); err == nil && at.After(now) {
+			retryAfter = at.Sub(now)
+		}
+	}
+	return &RateLimitError{
+		StatusCode: statusCode,
+		RetryAfter: retryAfter,
+		Until:      now.Add(retryAfter),
+	}
+}
+
+// RateLimitGuard remembers the ban window of the last rate limit response. Live executors check it before
+// every REST request and fail fast with the ban instead of sending requests that would extend it.
+type RateLimitGuard struct {
+	ban *RateLimitError // Latest ban; expired once Until has passed
+
+	// Statistics
+	bans    int64
+	blocked int64 // Requests refused locally during a ban
+
+	mu sync.Mutex
+}
+
+// NewRateLimitGuard creates a rate limit guard
+func NewRateLimitGuard() *RateLimitGuard {
+	return &RateLimitGuard{}
+}
+
+// Record remembers the ban carried by err, if any. It returns the ban and true when the ban starts a new
+// window or extends the current one.
+func (g *RateLimitGuard) Record(err error) (*RateLimitError, bool) {
+	rateLimit, ok := AsRateLimitError(err)
+	if !ok {
+		return nil, false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.ban != nil && !rateLimit.Until.After(g.ban.Until) {
+		return g.ban, false
+	}
+	if g.ban == nil || !time.Now().Before(g.ban.Until) {
+		g.bans++
+	}
+	g.ban = rateLimit
+	return rateLimit, true
+}
+
+// Check returns the active ban as an error, counting the refused request, or nil if requests may be sent
+func (g *RateLimitGuard) Check(now time.Time) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.ban == nil || !now.Before(g.ban.Until) {
+		return nil
+	}
+	g.blocked++
+	return g.ban
+}
+
+// Active returns the ban in effect at now, if any
+func (g *RateLimitGuard) Active(now time.Time) (*RateLimitError, bool) {
+	if g == nil {
+		return nil, false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.ban == nil || !now.Before(g.ban.Until) {
+		return nil, false
+	}
+	return g.ban, true
+}
+
+// GetRateLimitStats returns rate limit guard statistics
+func (g *RateLimitGuard) GetRateLimitStats() map[string]interface{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	stats := map[string]interface{}{
+		"banned":  g.ban != nil && time.Now().Before(g.ban.Until),
+		"bans":    g.bans,
+		"blocked": g.blocked,
+	}
+	if g.ban != nil {
+		stats["last_status"] = g.ban.StatusCode
+		stats["last_until"] = g.ban.Until
+	}
+	return stats
+}
@@ -0,0 +1,42 @@
+package trading
+
+import (
+	"aibot/internal/types"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidOrder is returned for orders that can never be accepted, so retrying them is pointless
+var ErrInvalidOrder = errors.New("invalid order")
+
+// PlaceOrderWithRetry places an order, retrying failed requests under the same client order ID.
+// Executors that track client order IDs answer a retry of an accepted order with the original order.
+func PlaceOrderWithRetry(executor TradingExecutor, order *types.Order, attempts int, delay time.Duration) (*types.OrderResult, error) {
+	if order.ClientOrderID == "" {
+		return nil, fmt.Errorf("%w: client order ID is required to retry safely", ErrInvalidOrder)
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var result *types.OrderResult
+		result, err = executor.PlaceOrder(order)
+
+		// Success, an exchange rejection or an invalid order is final
+		if err == nil || result != nil || errors.Is(err, ErrInvalidOrder) {
+			return result, err
+		}
+		// Retrying during a rate limit ban only extends it
+		if _, ok := AsRateLimitError(err); ok {
+			return nil, err
+		}
+		if attempt < attempts {
+			time.Sleep(delay)
+		}
+	}
+
+	return nil, fmt.Errorf("order %s failed after %d attempts: %w", order.ClientOrderID, attempts, err)
+}
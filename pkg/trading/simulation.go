@@ -0,0 +1,1235 @@
+package trading
+
+import (
+	"aibot/internal/types"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SimulationExecutor executes orders against the latest observed market prices
+type SimulationExecutor struct {
+	config SimulationConfig
+
+	// Account state
+	balance    float64
+	positions  map[string]*types.Position // Net position per symbol, or per symbol side in hedge mode
+	leverage   map[string]float64
+	marginCall bool // Margin ratio is at or above the margin call ratio
+
+	// Order state
+	openOrders   map[string]*types.Order
+	orderHistory []*types.Order
+	clientOrders map[string]*types.Order // Client order ID -> accepted order, for duplicate protection
+	orderCounter int64
+	transfers    int64
+
+	// Market state
+	tickers map[string]types.Ticker
+
+	// Events and statistics
+	fees      *FeeModel // nil when the flat commission rate applies
+	fills     *FillFeed
+	chaos     *chaosInjector // nil unless chaos mode is enabled
+	stats     ExecutionStats
+	connected bool
+	mu        sync.RWMutex
+}
+
+// NewSimulationExecutor creates a new simulated trading executor
+func NewSimulationExecutor(config SimulationConfig) *SimulationExecutor {
+	// Set defaults
+	if config.InitialBalance == 0 {
+		config.InitialBalance = 10000.0
+	}
+	if config.DefaultLeverage == 0 {
+		config.DefaultLeverage = 1.0
+	}
+	if config.MaxLeverage == 0 {
+		config.MaxLeverage = 10.0
+	}
+	if config.Commission == 0 {
+		config.Commission = 0.0004 // 0.04%
+	}
+	if config.FillBufferSize == 0 {
+		config.FillBufferSize = 256
+	}
+	if config.MarketType == "" {
+		config.MarketType = types.MarketTypeFutures
+	}
+	if config.QuoteAsset == "" {
+		config.QuoteAsset = "USDT"
+	}
+	if config.Margin.MaintenanceMarginRate == 0 {
+		config.Margin.MaintenanceMarginRate = 0.004 // 0.4%
+	}
+	if config.Margin.MarginCallRatio == 0 {
+		config.Margin.MarginCallRatio = 0.8
+	}
+	if config.MarketType.IsSpot() {
+		// Spot accounts trade without leverage
+		config.DefaultLeverage = 1.0
+		config.MaxLeverage = 1.0
+		config.EnableHedging = false // Spot holdings have no short side
+	}
+
+	var fees *FeeModel
+	if len(config.FeeSchedule.Tiers) > 0 {
+		fees = NewFeeModel(config.FeeSchedule)
+	}
+
+	return &SimulationExecutor{
+		config:       config,
+		fees:         fees,
+		balance:      config.InitialBalance,
+		positions:    make(map[string]*types.Position),
+		leverage:     make(map[string]float64),
+		openOrders:   make(map[string]*types.Order),
+		orderHistory: make([]*types.Order, 0),
+		clientOrders: make(map[string]*types.Order),
+		tickers:      make(map[string]types.Ticker),
+		fills:        NewFillFeed(config.FillBufferSize),
+		chaos:        newChaosInjector(config.Chaos),
+	}
+}
+
+// OpenLong opens or increases a long position; a zero price places a market order
+func (s *SimulationExecutor) OpenLong(symbol string, quantity float64, price float64) (*types.OrderResult, error) {
+	return s.PlaceOrder(s.newOrder(symbol, types.OrderSideBuy, quantity, price, types.PositionTypeLong, false))
+}
+
+// OpenShort opens or increases a short position; a zero price places a market order
+func (s *SimulationExecutor) OpenShort(symbol string, quantity float64, price float64) (*types.OrderResult, error) {
+	return s.PlaceOrder(s.newOrder(symbol, types.OrderSideSell, quantity, price, types.PositionTypeShort, false))
+}
+
+// CloseLong reduces a long position; a zero price places a market order
+func (s *SimulationExecutor) CloseLong(symbol string, quantity float64, price float64) (*types.OrderResult, error) {
+	return s.PlaceOrder(s.newOrder(symbol, types.OrderSideSell, quantity, price, types.PositionTypeLong, true))
+}
+
+// CloseShort reduces a short position; a zero price places a market order
+func (s *SimulationExecutor) CloseShort(symbol string, quantity float64, price float64) (*types.OrderResult, error) {
+	return s.PlaceOrder(s.newOrder(symbol, types.OrderSideBuy, quantity, price, types.PositionTypeShort, true))
+}
+
+// PlaceOrder places an order; marketable orders fill immediately, others rest until price crosses
+func (s *SimulationExecutor) PlaceOrder(order *types.Order) (*types.OrderResult, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A retried client order ID returns the order already accepted instead of placing it again
+	if existing, exists := s.clientOrders[order.ClientOrderID]; exists && order.ClientOrderID != "" {
+		s.stats.DuplicateOrders++
+		return s.toOrderResult(existing), nil
+	}
+
+	if order.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity %f", ErrInvalidOrder, order.Quantity)
+	}
+	if !s.SupportsOrderType(order.Type) {
+		return nil, fmt.Errorf("%w: unsupported order type for simulation: %s", ErrInvalidOrder, order.Type)
+	}
+	if err := validateConditionalOrder(order); err != nil {
+		return nil, err
+	}
+
+	s.orderCounter++
+	if order.ID == "" {
+		order.ID = fmt.Sprintf("sim-%d", s.orderCounter)
+	}
+	if order.PositionType == "" {
+		order.PositionType = s.resolvePositionType(order)
+	}
+	s.stats.TotalOrders++
+	s.stats.LastOrderTime = time.Now()
+
+	ticker, hasTicker := s.tickers[order.Symbol]
+	if order.Type == types.OrderTypeMarket && !hasTicker {
+		return s.rejectOrder(order, fmt.Sprintf("no market price available for %s", order.Symbol))
+	}
+
+	if order.ReduceOnly && !s.reducesPosition(order) {
+		return s.rejectOrder(order, fmt.Sprintf("reduce-only order would not reduce %s position", order.Symbol))
+	}
+
+	if s.config.MarketType.IsSpot() {
+		if order.PositionType == types.PositionTypeShort {
+			return s.rejectOrder(order, "short positions are not available on spot markets")
+		}
+		if reason := s.checkSpotBalance(order, ticker.Price); reason != "" {
+			return s.rejectOrder(order, reason)
+		}
+	} else if !order.ReduceOnly {
+		// Initial margin check for the exposure the order can add on top of resting orders
+		required := s.reservedMargin(order) - s.reservedMargin(nil)
+		if available := s.availableBalance(); required > available {
+			return s.rejectOrder(order, fmt.Sprintf("insufficient margin: required %.2f, available %.2f", required, available))
+		}
+	}
+
+	switch {
+	case order.IsConditional():
+		// Like exchanges, reject stops that would trigger on arrival instead of executing them
+		if hasTicker {
+			order.TrailStop(ticker.GetTriggerPrice(order.TriggerPrice))
+			if order.StopReached(ticker.GetTriggerPrice(order.TriggerPrice)) {
+				return s.rejectOrder(order, fmt.Sprintf("%s order would trigger immediately", order.Type))
+			}
+		}
+		order.Status = types.OrderStatusNew
+		s.openOrders[order.ID] = order
+	case order.Type == types.OrderTypeMarket:
+		s.fillOrder(order, s.applySlippage(order.Side, ticker.Price), order.Quantity, false)
+	case order.IsPostOnly() && hasTicker && s.crossesBook(order, ticker):
+		return s.rejectOrder(order, "post-only order would take liquidity")
+	case hasTicker && !order.IsPostOnly() && s.isMarketable(order, ticker.Price):
+		// Marketable limit order fills at the better of limit and market price
+		s.fillOrder(order, ticker.Price, order.Quantity, false)
+	default:
+		order.Status = types.OrderStatusNew
+		s.openOrders[order.ID] = order
+	}
+
+	if order.ClientOrderID != "" {
+		s.clientOrders[order.ClientOrderID] = order
+	}
+
+	return s.toOrderResult(order), nil
+}
+
+// CancelOrder cancels an open order
+func (s *SimulationExecutor) CancelOrder(orderID string) error {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, exists := s.openOrders[orderID]
+	if !exists {
+		return fmt.Errorf("open order not found: %s", orderID)
+	}
+
+	order.Cancel()
+	delete(s.openOrders, orderID)
+	s.archiveOrder(order)
+
+	update := types.NewOrderUpdate(order, 0, 0, 0)
+	update.Reason = "cancelled by request"
+	s.publish(update)
+
+	return nil
+}
+
+// GetOrder returns an order by ID
+func (s *SimulationExecutor) GetOrder(orderID string) (*types.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if order, exists := s.openOrders[orderID]; exists {
+		copy := *order
+		return &copy, nil
+	}
+	for i := len(s.orderHistory) - 1; i >= 0; i-- {
+		if s.orderHistory[i].ID == orderID {
+			copy := *s.orderHistory[i]
+			return &copy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("order not found: %s", orderID)
+}
+
+// GetOpenOrders returns open orders for a symbol, or all open orders if symbol is empty
+func (s *SimulationExecutor) GetOpenOrders(symbol string) ([]*types.Order, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	orders := make([]*types.Order, 0)
+	for _, order := range s.openOrders {
+		if symbol == "" || order.Symbol == symbol {
+			copy := *order
+			orders = append(orders, &copy)
+		}
+	}
+	return orders, nil
+}
+
+// GetOrderHistory returns the most recent completed orders for a symbol
+func (s *SimulationExecutor) GetOrderHistory(symbol string, limit int) ([]*types.Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	orders := make([]*types.Order, 0)
+	for i := len(s.orderHistory) - 1; i >= 0; i-- {
+		if limit > 0 && len(orders) >= limit {
+			break
+		}
+		if symbol == "" || s.orderHistory[i].Symbol == symbol {
+			copy := *s.orderHistory[i]
+			orders = append(orders, &copy)
+		}
+	}
+	return orders, nil
+}
+
+// GetPosition returns the current position for a symbol, or nil if flat; in hedge mode both sides are netted
+func (s *SimulationExecutor) GetPosition(symbol string) (*types.Position, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.config.EnableHedging {
+		return s.hedgePosition(symbol).Net(), nil
+	}
+
+	position, exists := s.positions[symbol]
+	if !exists {
+		return nil, nil
+	}
+	copy := *position
+	return &copy, nil
+}
+
+// GetHedgePosition returns the long and short sides of a symbol
+func (s *SimulationExecutor) GetHedgePosition(symbol string) (*types.HedgePosition, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hedgePosition(symbol), nil
+}
+
+// IsHedgeMode returns true if long and short positions are tracked independently
+func (s *SimulationExecutor) IsHedgeMode() bool {
+	return s.config.EnableHedging
+}
+
+// GetAllPositions returns all open positions; in hedge mode each side is a separate position
+func (s *SimulationExecutor) GetAllPositions() ([]*types.Position, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	positions := make([]*types.Position, 0, len(s.positions))
+	for _, position := range s.positions {
+		copy := *position
+		positions = append(positions, &copy)
+	}
+	return positions, nil
+}
+
+// GetBalance returns the wallet balance (initial balance plus realized PnL minus fees)
+func (s *SimulationExecutor) GetBalance() (float64, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.balance, nil
+}
+
+// GetAvailableBalance returns equity not locked as position margin or reserved by resting orders
+func (s *SimulationExecutor) GetAvailableBalance() (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.availableBalance(), nil
+}
+
+// GetMarginInfo returns margin information for the simulated account
+func (s *SimulationExecutor) GetMarginInfo() (*MarginInfo, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	usedMargin := s.usedMargin()
+	equity := s.balance + s.unrealizedPnL()
+
+	marginLevel := float64(0)
+	if usedMargin > 0 {
+		marginLevel = equity / usedMargin
+	}
+
+	return &MarginInfo{
+		TotalBalance:      equity,
+		AvailableBalance:  s.availableBalance(),
+		UsedMargin:        usedMargin,
+		FreeMargin:        equity - usedMargin,
+		MarginLevel:       marginLevel,
+		MaintenanceMargin: s.maintenanceMargin(),
+		MarginRatio:       s.marginRatio(),
+		MarginCall:        s.marginCall,
+		Leverage:          s.config.DefaultLeverage,
+		Currency:          s.config.QuoteAsset,
+	}, nil
+}
+
+// GetTicker returns the last ticker observed for a symbol
+func (s *SimulationExecutor) GetTicker(symbol string) (*types.Ticker, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ticker, exists := s.tickers[symbol]
+	if !exists {
+		return nil, fmt.Errorf("no ticker available for %s", symbol)
+	}
+	return &ticker, nil
+}
+
+// GetOrderBook returns a synthetic top-of-book built from the last ticker
+func (s *SimulationExecutor) GetOrderBook(symbol string, depth int) (*OrderBook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ticker, exists := s.tickers[symbol]
+	if !exists {
+		return nil, fmt.Errorf("no ticker available for %s", symbol)
+	}
+
+	bid, ask := ticker.Bid, ticker.Ask
+	if bid == 0 || ask == 0 {
+		bid, ask = ticker.Price, ticker.Price
+	}
+
+	return &OrderBook{
+		Symbol:    symbol,
+		Bids:      []PriceLevel{{Price: bid, Quantity: ticker.BidSize, Orders: 1}},
+		Asks:      []PriceLevel{{Price: ask, Quantity: ticker.AskSize, Orders: 1}},
+		Timestamp: ticker.Timestamp,
+	}, nil
+}
+
+// IsConnected returns true if the executor is connected
+func (s *SimulationExecutor) IsConnected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.connected && !s.chaos.isDisconnected()
+}
+
+// Connect marks the simulated executor as connected
+func (s *SimulationExecutor) Connect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connected = true
+	return nil
+}
+
+// Disconnect disconnects the executor and closes the fill channel
+func (s *SimulationExecutor) Disconnect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connected = false
+	s.fills.Close()
+	return nil
+}
+
+// GetFeeRates returns the simulated fee rates
+func (s *SimulationExecutor) GetFeeRates() (*FeeRates, error) {
+	return &FeeRates{
+		MakerFee: s.config.Commission,
+		TakerFee: s.config.Commission,
+	}, nil
+}
+
+// GetLeverage returns the leverage for a symbol
+func (s *SimulationExecutor) GetLeverage(symbol string) (float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.getLeverage(symbol), nil
+}
+
+// SetLeverage sets the leverage for a symbol
+func (s *SimulationExecutor) SetLeverage(symbol string, leverage float64) error {
+	if s.config.MarketType.IsSpot() && leverage != 1 {
+		return fmt.Errorf("leverage is not supported on spot markets")
+	}
+	if leverage <= 0 || leverage > s.config.MaxLeverage {
+		return fmt.Errorf("leverage %.1f outside allowed range (0, %.1f]", leverage, s.config.MaxLeverage)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leverage[symbol] = leverage
+	return nil
+}
+
+// GetAccountPermissions returns the simulated account permissions
+func (s *SimulationExecutor) GetAccountPermissions() (*AccountPermissions, error) {
+	return &AccountPermissions{
+		CanTrade:   true,
+		CanFutures: !s.config.MarketType.IsSpot(),
+	}, nil
+}
+
+// TransferOut moves quote balance out of the simulated wallet; only balance not used as margin and not
+// backing unrealized losses can leave
+func (s *SimulationExecutor) TransferOut(asset string, amount float64) (string, error) {
+	if err := s.chaos.beforeRequest(); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("transfer amount must be positive, got %.8f", amount)
+	}
+	if asset != "" && asset != s.config.QuoteAsset {
+		return "", fmt.Errorf("cannot transfer %s: the simulated wallet holds %s", asset, s.config.QuoteAsset)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transferable := math.Min(s.balance-s.usedMargin(), s.availableBalance())
+	if amount > transferable {
+		return "", fmt.Errorf("insufficient %s balance to transfer: requested %.2f, transferable %.2f", s.config.QuoteAsset, amount, transferable)
+	}
+	s.balance -= amount
+	s.transfers++
+	return fmt.Sprintf("sim-transfer-%d", s.transfers), nil
+}
+
+// GetAssetBalances returns free quote and base inventory per asset on spot markets
+func (s *SimulationExecutor) GetAssetBalances() (map[string]float64, error) {
+	if !s.config.MarketType.IsSpot() {
+		return nil, fmt.Errorf("asset balances are only available on spot markets")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	balances := map[string]float64{
+		s.config.QuoteAsset: s.balance - s.usedMargin(),
+	}
+	for _, position := range s.positions {
+		base, _ := types.SplitSymbol(position.Symbol, s.config.QuoteAsset)
+		balances[base] += position.Size
+	}
+	return balances, nil
+}
+
+// GetSymbolRules returns trading rules for a symbol; the simulation applies no exchange filters
+func (s *SimulationExecutor) GetSymbolRules(symbol string) (*SymbolRules, error) {
+	contract := s.contract(symbol)
+	return &SymbolRules{
+		Symbol:      symbol,
+		Status:      "TRADING",
+		StepSize:    contract.StepSize,
+		TickSize:    contract.TickSize,
+		MaxLeverage: s.config.MaxLeverage,
+	}, nil
+}
+
+// GetFillChannel returns the channel of asynchronous order updates
+func (s *SimulationExecutor) GetFillChannel() <-chan types.OrderUpdate {
+	return s.fills.Channel()
+}
+
+// UpdateTicker records a new market price, marks positions, fills crossed limit orders and checks the
+// account's maintenance margin
+func (s *SimulationExecutor) UpdateTicker(ticker types.Ticker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tickers[ticker.Symbol] = ticker
+
+	for _, position := range s.positions {
+		if position.Symbol == ticker.Symbol {
+			position.UpdateMarkPrice(ticker.Price)
+		}
+	}
+
+	for id, order := range s.openOrders {
+		if order.Symbol != ticker.Symbol {
+			continue
+		}
+		if order.IsConditional() && !order.Triggered {
+			s.processConditionalOrder(order, ticker)
+			continue
+		}
+		if !s.isMarketable(order, ticker.Price) {
+			continue
+		}
+		if order.ReduceOnly && !s.reducesPosition(order) {
+			// A stop or another exit reached earlier on the path already closed the position
+			s.expireReduceOnly(order)
+			continue
+		}
+		delete(s.openOrders, id)
+		s.fillOrder(order, order.Price, order.GetRemainingQty(), true)
+	}
+
+	s.checkMaintenanceMargin()
+}
+
+// checkMaintenanceMargin raises a margin call when maintenance margin reaches the margin call ratio of
+// equity and liquidates the account when equity no longer covers it; caller must hold s.mu
+func (s *SimulationExecutor) checkMaintenanceMargin() {
+	if s.config.MarketType.IsSpot() || len(s.positions) == 0 {
+		s.marginCall = false
+		return
+	}
+
+	ratio := s.marginRatio()
+	switch {
+	case ratio >= 1:
+		s.liquidate()
+	case ratio >= s.config.Margin.MarginCallRatio:
+		if !s.marginCall {
+			s.marginCall = true
+			s.stats.MarginCalls++
+		}
+	default:
+		s.marginCall = false
+	}
+}
+
+// liquidate cancels all open orders and closes every position at the mark price with slippage, as an
+// exchange does with a cross margin account whose equity fell to its maintenance margin. A balance left
+// negative is absorbed like an exchange insurance fund would. Caller must hold s.mu.
+func (s *SimulationExecutor) liquidate() {
+	s.stats.Liquidations++
+	s.marginCall = false
+
+	for id, order := range s.openOrders {
+		order.Cancel()
+		delete(s.openOrders, id)
+		s.archiveOrder(order)
+
+		update := types.NewOrderUpdate(order, 0, 0, 0)
+		update.Reason = "cancelled by liquidation"
+		s.publish(update)
+	}
+
+	keys := make([]string, 0, len(s.positions))
+	for key := range s.positions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		position := s.positions[key]
+		side := types.OrderSideSell
+		if position.Type == types.PositionTypeShort {
+			side = types.OrderSideBuy
+		}
+		s.orderCounter++
+		order := types.NewMarketOrder(fmt.Sprintf("sim-liq-%d", s.orderCounter), position.Symbol, side, position.Size, position.Type)
+		order.SetReduceOnly(true)
+		order.Tag = types.NewOrderTag("exchange", OrderReasonLiquidation)
+		s.stats.TotalOrders++
+
+		update := s.settleFill(order, s.applySlippage(side, position.MarkPrice), position.Size, false)
+		update.Reason = OrderReasonLiquidation
+		s.publish(update)
+	}
+
+	if s.balance < 0 {
+		s.balance = 0
+	}
+}
+
+// SupportsOrderType returns true for the order types the simulation can execute
+func (s *SimulationExecutor) SupportsOrderType(orderType types.OrderType) bool {
+	switch orderType {
+	case types.OrderTypeMarket, types.OrderTypeLimit, types.OrderTypeStop, types.OrderTypeStopLimit, types.OrderTypeTrailingStop:
+		return true
+	}
+	return false
+}
+
+// processConditionalOrder trails and triggers a resting conditional order; stop and trailing stop orders
+// execute as market orders, stop-limit orders leave a working limit order. Reduce-only orders whose
+// position is gone expire when triggered.
+func (s *SimulationExecutor) processConditionalOrder(order *types.Order, ticker types.Ticker) {
+	triggerPrice := ticker.GetTriggerPrice(order.TriggerPrice)
+	order.TrailStop(triggerPrice)
+	if !order.StopReached(triggerPrice) {
+		return
+	}
+
+	order.Triggered = true
+	order.UpdateTime = time.Now()
+	s.stats.TriggeredOrders++
+
+	if order.ReduceOnly && !s.reducesPosition(order) {
+		s.expireReduceOnly(order)
+		return
+	}
+
+	if order.Type == types.OrderTypeStopLimit {
+		if s.isMarketable(order, ticker.Price) {
+			delete(s.openOrders, order.ID)
+			s.fillOrder(order, ticker.Price, order.GetRemainingQty(), false)
+		}
+		return
+	}
+	delete(s.openOrders, order.ID)
+	s.fillOrder(order, s.applySlippage(order.Side, ticker.Price), order.GetRemainingQty(), false)
+}
+
+// expireReduceOnly cancels a reduce-only order whose position is gone when it would execute
+func (s *SimulationExecutor) expireReduceOnly(order *types.Order) {
+	order.Cancel()
+	delete(s.openOrders, order.ID)
+	s.archiveOrder(order)
+
+	update := types.NewOrderUpdate(order, 0, 0, 0)
+	update.Reason = fmt.Sprintf("%s order expired: no position to reduce", order.Type)
+	s.publish(update)
+}
+
+// validateConditionalOrder checks the trigger settings of stop, stop-limit and trailing stop orders
+func validateConditionalOrder(order *types.Order) error {
+	switch order.TriggerPrice {
+	case "", types.TriggerPriceLast, types.TriggerPriceMark:
+	default:
+		return fmt.Errorf("%w: unknown trigger price type %s", ErrInvalidOrder, order.TriggerPrice)
+	}
+
+	switch order.Type {
+	case types.OrderTypeStop:
+		if order.StopPrice <= 0 {
+			return fmt.Errorf("%w: stop order without stop price", ErrInvalidOrder)
+		}
+	case types.OrderTypeStopLimit:
+		if order.StopPrice <= 0 || order.Price <= 0 {
+			return fmt.Errorf("%w: stop-limit order needs a stop price and a limit price", ErrInvalidOrder)
+		}
+	case types.OrderTypeTrailingStop:
+		if order.CallbackRate <= 0 || order.CallbackRate > 0.1 {
+			return fmt.Errorf("%w: trailing stop callback rate must be between 0 and 10%%: %f", ErrInvalidOrder, order.CallbackRate)
+		}
+	}
+	return nil
+}
+
+// GetExecutionStats returns execution statistics for the session
+func (s *SimulationExecutor) GetExecutionStats() ExecutionStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := s.stats
+	stats.OpenPositions = len(s.positions)
+	stats.UnrealizedPnL = s.unrealizedPnL()
+	return stats
+}
+
+// GetChaosStats returns statistics about injected faults
+func (s *SimulationExecutor) GetChaosStats() map[string]interface{} {
+	return s.chaos.stats()
+}
+
+// DroppedFills returns the number of order updates dropped because the fill channel was full
+func (s *SimulationExecutor) DroppedFills() int64 {
+	return s.fills.Dropped()
+}
+
+// publish sends an order update, through the chaos injector when enabled
+func (s *SimulationExecutor) publish(update types.OrderUpdate) {
+	if s.chaos != nil {
+		s.chaos.publish(s.fills, update)
+		return
+	}
+	s.fills.Publish(update)
+}
+
+// fillOrder fills an order, updates the account and publishes the resulting update; resting orders
+// filled later are makers, everything that fills on arrival is a taker
+func (s *SimulationExecutor) fillOrder(order *types.Order, price, quantity float64, maker bool) {
+	s.publish(s.settleFill(order, price, quantity, maker))
+}
+
+// settleFill fills an order, updates the account and returns the resulting update
+func (s *SimulationExecutor) settleFill(order *types.Order, price, quantity float64, maker bool) types.OrderUpdate {
+	notional := s.contract(order.Symbol).Notional(quantity, price)
+	fee := notional * s.config.Commission
+	if s.fees != nil {
+		now := s.marketTime(order.Symbol)
+		fee = notional * s.fees.Rate(maker, now)
+		s.fees.RecordFill(notional, maker, now)
+	}
+	order.Fill(quantity, price, fee)
+
+	realizedPnL := s.applyFill(order, quantity, price)
+	s.balance += realizedPnL - fee
+
+	s.stats.SuccessfulOrders++
+	s.stats.TotalVolume += notional
+	s.stats.TotalFees += fee
+	s.stats.RealizedPnL += realizedPnL
+
+	if order.IsFilled() {
+		s.archiveOrder(order)
+	}
+
+	update := types.NewOrderUpdate(order, quantity, price, fee)
+	update.RealizedPnL = realizedPnL
+	return update
+}
+
+// applyFill nets a fill into the symbol position and returns the realized PnL
+func (s *SimulationExecutor) applyFill(order *types.Order, quantity, price float64) float64 {
+	if s.config.EnableHedging {
+		return s.applyHedgeFill(order, quantity, price)
+	}
+
+	position := s.positions[order.Symbol]
+
+	// Signed quantities: positive is long, negative is short
+	delta := quantity
+	if order.IsSell() {
+		delta = -quantity
+	}
+	current := float64(0)
+	if position != nil {
+		current = position.Size
+		if position.Type == types.PositionTypeShort {
+			current = -current
+		}
+	}
+
+	// Increasing or opening exposure
+	precision := s.contract(order.Symbol).Precision()
+	if precision.IsZeroQuantity(current) || (current > 0) == (delta > 0) {
+		if order.ReduceOnly {
+			return 0
+		}
+		if position == nil {
+			positionType := types.PositionTypeLong
+			if delta < 0 {
+				positionType = types.PositionTypeShort
+			}
+			position = s.newPosition(order.ID, order.Symbol, positionType, quantity, price)
+			s.positions[order.Symbol] = position
+		} else {
+			position.EntryPrice = position.Contract().AverageEntry(position.Size, position.EntryPrice, quantity, price)
+			position.Size += quantity
+			position.UpdateMargin()
+		}
+		position.UpdateMarkPrice(price)
+		return 0
+	}
+
+	// Reducing exposure
+	closeQty := math.Min(quantity, position.Size)
+	realizedPnL := position.Contract().PnL(position.Type, closeQty, position.EntryPrice, price)
+
+	position.PartialClose(closeQty, price, 0)
+	position.UpdateMargin()
+	position.UpdateMarkPrice(price)
+	if position.Status == "closed" {
+		delete(s.positions, order.Symbol)
+	}
+
+	// Flip into the opposite direction with any remaining quantity
+	if remaining := quantity - closeQty; remaining >= precision.QuantityEpsilon() && !order.ReduceOnly {
+		positionType := types.PositionTypeLong
+		if delta < 0 {
+			positionType = types.PositionTypeShort
+		}
+		flipped := s.newPosition(order.ID, order.Symbol, positionType, remaining, price)
+		flipped.UpdateMarkPrice(price)
+		s.positions[order.Symbol] = flipped
+	}
+
+	return realizedPnL
+}
+
+// applyHedgeFill applies a fill to the order's position side only and returns the realized PnL
+func (s *SimulationExecutor) applyHedgeFill(order *types.Order, quantity, price float64) float64 {
+	key := types.PositionKey(order.Symbol, order.PositionType)
+	position := s.positions[key]
+	opening := (order.PositionType == types.PositionTypeLong) == order.IsBuy()
+
+	if opening {
+		if order.ReduceOnly {
+			return 0
+		}
+		if position == nil {
+			position = s.newPosition(order.ID, order.Symbol, order.PositionType, quantity, price)
+			s.positions[key] = position
+		} else {
+			position.EntryPrice = position.Contract().AverageEntry(position.Size, position.EntryPrice, quantity, price)
+			position.Size += quantity
+			position.UpdateMargin()
+		}
+		position.UpdateMarkPrice(price)
+		return 0
+	}
+
+	if position == nil {
+		return 0
+	}
+
+	// Closing one side never flips into the other
+	closeQty := math.Min(quantity, position.Size)
+	realizedPnL := position.Contract().PnL(position.Type, closeQty, position.EntryPrice, price)
+
+	position.PartialClose(closeQty, price, 0)
+	position.UpdateMargin()
+	position.UpdateMarkPrice(price)
+	if position.Status == "closed" {
+		delete(s.positions, key)
+	}
+
+	return realizedPnL
+}
+
+// marketTime returns the time of the latest ticker for a symbol so replayed data ages the fee volume
+// window in market time; caller must hold s.mu
+func (s *SimulationExecutor) marketTime(symbol string) time.Time {
+	if ticker, exists := s.tickers[symbol]; exists && !ticker.Timestamp.IsZero() {
+		return ticker.Timestamp
+	}
+	return time.Now()
+}
+
+// GetFeeStats returns fee schedule statistics, or nil when the flat commission rate applies
+func (s *SimulationExecutor) GetFeeStats() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fees == nil {
+		return nil
+	}
+	now := time.Time{}
+	for _, ticker := range s.tickers {
+		if ticker.Timestamp.After(now) {
+			now = ticker.Timestamp
+		}
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return s.fees.GetFeeStats(now)
+}
+
+// contract returns the contract specification of a symbol
+func (s *SimulationExecutor) contract(symbol string) types.ContractSpec {
+	return s.config.Contracts[symbol]
+}
+
+// newPosition opens a position at the symbol's leverage and contract; caller must hold s.mu
+func (s *SimulationExecutor) newPosition(id, symbol string, positionType types.PositionType, quantity, price float64) *types.Position {
+	position := types.NewPosition(id, symbol, positionType, quantity, price, s.getLeverage(symbol))
+	position.SetContract(s.contract(symbol))
+	return position
+}
+
+// hedgePosition collects both sides of a symbol; caller must hold s.mu
+func (s *SimulationExecutor) hedgePosition(symbol string) *types.HedgePosition {
+	hedge := &types.HedgePosition{Symbol: symbol}
+	if long, exists := s.positions[types.PositionKey(symbol, types.PositionTypeLong)]; exists {
+		copy := *long
+		hedge.Long = &copy
+	}
+	if short, exists := s.positions[types.PositionKey(symbol, types.PositionTypeShort)]; exists {
+		copy := *short
+		hedge.Short = &copy
+	}
+	return hedge
+}
+
+// rejectOrder records a rejected order and publishes the rejection
+func (s *SimulationExecutor) rejectOrder(order *types.Order, reason string) (*types.OrderResult, error) {
+	order.Status = types.OrderStatusRejected
+	order.UpdateTime = time.Now()
+	s.stats.FailedOrders++
+	s.archiveOrder(order)
+
+	update := types.NewOrderUpdate(order, 0, 0, 0)
+	update.Reason = reason
+	s.publish(update)
+
+	return s.toOrderResult(order), fmt.Errorf("order rejected: %s", reason)
+}
+
+// archiveOrder moves an order into the bounded order history
+func (s *SimulationExecutor) archiveOrder(order *types.Order) {
+	s.orderHistory = append(s.orderHistory, order)
+
+	// Keep only last 1000 orders
+	if len(s.orderHistory) > 1000 {
+		expired := s.orderHistory[0]
+		if s.clientOrders[expired.ClientOrderID] == expired {
+			delete(s.clientOrders, expired.ClientOrderID)
+		}
+		s.orderHistory = s.orderHistory[1:]
+	}
+}
+
+// newOrder builds a market or limit order for the position helpers
+func (s *SimulationExecutor) newOrder(symbol string, side types.OrderSide, quantity, price float64, positionType types.PositionType, reduceOnly bool) *types.Order {
+	var order *types.Order
+	if price > 0 {
+		order = types.NewLimitOrder("", symbol, side, quantity, price, positionType)
+	} else {
+		order = types.NewMarketOrder("", symbol, side, quantity, positionType)
+	}
+	order.SetReduceOnly(reduceOnly)
+	return order
+}
+
+// resolvePositionType infers the position an order affects when the caller did not set it
+func (s *SimulationExecutor) resolvePositionType(order *types.Order) types.PositionType {
+	if s.config.EnableHedging {
+		// Each side is explicit in hedge mode; only reduce-only orders close the opposite side
+		if order.IsBuy() != order.ReduceOnly {
+			return types.PositionTypeLong
+		}
+		return types.PositionTypeShort
+	}
+	if position, exists := s.positions[order.Symbol]; exists {
+		closesLong := position.Type == types.PositionTypeLong && order.IsSell()
+		closesShort := position.Type == types.PositionTypeShort && order.IsBuy()
+		if closesLong || closesShort {
+			return position.Type
+		}
+	}
+	if order.IsBuy() {
+		return types.PositionTypeLong
+	}
+	return types.PositionTypeShort
+}
+
+// reducesPosition returns true if the order is on the opposite side of the open position
+func (s *SimulationExecutor) reducesPosition(order *types.Order) bool {
+	key := order.Symbol
+	if s.config.EnableHedging {
+		key = types.PositionKey(order.Symbol, order.PositionType)
+	}
+	position, exists := s.positions[key]
+	if !exists {
+		return false
+	}
+	return (position.Type == types.PositionTypeLong && order.IsSell()) ||
+		(position.Type == types.PositionTypeShort && order.IsBuy())
+}
+
+// isMarketable returns true if a limit order would execute at the given price
+func (s *SimulationExecutor) isMarketable(order *types.Order, price float64) bool {
+	if order.IsBuy() {
+		return price <= order.Price
+	}
+	return price >= order.Price
+}
+
+// crossesBook returns true if a limit order would trade against the opposite side of the book on arrival
+func (s *SimulationExecutor) crossesBook(order *types.Order, ticker types.Ticker) bool {
+	if order.IsBuy() && ticker.Ask > 0 {
+		return order.Price >= ticker.Ask
+	}
+	if order.IsSell() && ticker.Bid > 0 {
+		return order.Price <= ticker.Bid
+	}
+	return s.isMarketable(order, ticker.Price)
+}
+
+// applySlippage moves the execution price against the taker
+func (s *SimulationExecutor) applySlippage(side types.OrderSide, price float64) float64 {
+	if side == types.OrderSideBuy {
+		return price * (1 + s.config.Slippage)
+	}
+	return price * (1 - s.config.Slippage)
+}
+
+func (s *SimulationExecutor) getLeverage(symbol string) float64 {
+	if leverage, exists := s.leverage[symbol]; exists {
+		return leverage
+	}
+	return s.config.DefaultLeverage
+}
+
+func (s *SimulationExecutor) usedMargin() float64 {
+	total := float64(0)
+	for _, position := range s.positions {
+		total += position.Margin
+	}
+	return total
+}
+
+func (s *SimulationExecutor) unrealizedPnL() float64 {
+	total := float64(0)
+	for _, position := range s.positions {
+		total += position.UnrealizedPnL
+	}
+	return total
+}
+
+func (s *SimulationExecutor) availableBalance() float64 {
+	if s.config.MarketType.IsSpot() {
+		// Unrealized gains are not spendable quote on spot
+		return s.balance - s.usedMargin() - s.reservedQuote()
+	}
+	return s.balance + s.unrealizedPnL() - s.usedMargin() - s.reservedMargin(nil)
+}
+
+// maintenanceMargin returns the margin the open positions must keep at their mark price before they are
+// liquidated
+func (s *SimulationExecutor) maintenanceMargin() float64 {
+	total := float64(0)
+	for _, position := range s.positions {
+		price := position.MarkPrice
+		if price == 0 {
+			price = position.EntryPrice
+		}
+		total += s.contract(position.Symbol).Notional(position.Size, price) * s.config.Margin.MaintenanceMarginRate
+	}
+	return total
+}
+
+// marginRatio returns maintenance margin as a share of equity; at 1 or above the account is liquidated
+func (s *SimulationExecutor) marginRatio() float64 {
+	maintenance := s.maintenanceMargin()
+	if maintenance == 0 {
+		return 0
+	}
+	equity := s.balance + s.unrealizedPnL()
+	if equity <= 0 {
+		return math.Inf(1)
+	}
+	return maintenance / equity
+}
+
+// reservedMargin returns the initial margin of resting futures orders, plus extra if not nil, for the
+// exposure they can add: quantity that only closes the open position needs none. Older orders claim the
+// closing quantity first; a market order claims it before resting orders since it executes at once.
+func (s *SimulationExecutor) reservedMargin(extra *types.Order) float64 {
+	if s.config.MarketType.IsSpot() {
+		return 0
+	}
+
+	orders := make([]*types.Order, 0, len(s.openOrders)+1)
+	for _, order := range s.openOrders {
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreateTime.Equal(orders[j].CreateTime) {
+			return orders[i].CreateTime.Before(orders[j].CreateTime)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+	if extra != nil && extra.Type == types.OrderTypeMarket {
+		orders = append([]*types.Order{extra}, orders...)
+	} else if extra != nil {
+		orders = append(orders, extra)
+	}
+
+	offsets := make(map[string]float64) // Position key -> closing quantity not yet claimed by an order
+	total := float64(0)
+	for _, order := range orders {
+		if order.ReduceOnly {
+			continue
+		}
+		quantity := order.GetRemainingQty()
+		if s.reducesPosition(order) {
+			key := order.Symbol
+			if s.config.EnableHedging {
+				key = types.PositionKey(order.Symbol, order.PositionType)
+			}
+			offset, claimed := offsets[key]
+			if !claimed {
+				offset = s.positions[key].Size
+			}
+			closing := math.Min(quantity, offset)
+			offsets[key] = offset - closing
+			quantity -= closing
+		}
+		if quantity <= 0 {
+			continue
+		}
+
+		price := order.Price
+		if price == 0 {
+			price = order.StopPrice
+		}
+		if price == 0 {
+			price = s.tickers[order.Symbol].Price
+		}
+		total += s.contract(order.Symbol).Notional(quantity, price) / s.getLeverage(order.Symbol)
+	}
+	return total
+}
+
+// checkSpotBalance validates a spot order against free base and quote balances; returns a reject reason
+func (s *SimulationExecutor) checkSpotBalance(order *types.Order, marketPrice float64) string {
+	if order.IsSell() {
+		base, _ := types.SplitSymbol(order.Symbol, s.config.QuoteAsset)
+		held := float64(0)
+		if position, exists := s.positions[order.Symbol]; exists && position.Type == types.PositionTypeLong {
+			held = position.Size
+		}
+		if free := held - s.reservedBase(order.Symbol); order.Quantity > free+s.contract(order.Symbol).Precision().QuantityEpsilon() {
+			return fmt.Sprintf("insufficient %s balance: required %.6f, available %.6f", base, order.Quantity, free)
+		}
+		return ""
+	}
+
+	refPrice := order.Price
+	if refPrice == 0 {
+		refPrice = s.applySlippage(order.Side, marketPrice)
+	}
+	required := order.Quantity * refPrice * (1 + s.config.Commission)
+	if available := s.availableBalance(); required > available {
+		return fmt.Sprintf("insufficient %s balance: required %.2f, available %.2f", s.config.QuoteAsset, required, available)
+	}
+	return ""
+}
+
+// reservedQuote returns quote locked by resting spot buy orders
+func (s *SimulationExecutor) reservedQuote() float64 {
+	total := float64(0)
+	for _, order := range s.openOrders {
+		if order.IsBuy() {
+			total += order.GetRemainingQty() * order.Price * (1 + s.config.Commission)
+		}
+	}
+	return total
+}
+
+// reservedBase returns base inventory locked by resting spot sell orders
+func (s *SimulationExecutor) reservedBase(symbol string) float64 {
+	total := float64(0)
+	for _, order := range s.openOrders {
+		if order.Symbol == symbol && order.IsSell() {
+			total += order.GetRemainingQty()
+		}
+	}
+	return total
+}
+
+func (s *SimulationExecutor) toOrderResult(order *types.Order) *types.OrderResult {
+	return &types.OrderResult{
+		OrderID:      order.ID,
+		Symbol:       order.Symbol,
+		Side:         string(order.Side),
+		PositionType: string(order.PositionType),
+		Quantity:     order.Quantity,
+		Price:        order.Price,
+		FilledQty:    order.FilledQty,
+		FilledPrice:  order.AvgFillPrice,
+		Fee:          order.Fee,
+		Timestamp:    order.CreateTime,
+		Status:       string(order.Status),
+		ExecutedTime: order.UpdateTime,
+		Commission:   order.Fee,
+	}
+}
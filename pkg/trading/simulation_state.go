@@ -0,0 +1,126 @@
+package trading
+
+import (
+	"aibot/internal/types"
+	"time"
+)
+
+// SimulationState is the account, order book and market state of a simulation executor, saved in backtest
+// checkpoints so a stopped backtest resumes with the same account
+type SimulationState struct {
+	Balance      float64                    `json:"balance"`
+	Positions    map[string]*types.Position `json:"positions"`
+	Leverage     map[string]float64         `json:"leverage"`
+	MarginCall   bool                       `json:"margin_call"`
+	OpenOrders   []*types.Order             `json:"open_orders"`
+	OrderHistory []*types.Order             `json:"order_history"`
+	OrderCounter int64                      `json:"order_counter"`
+	Transfers    int64                      `json:"transfers"`
+	Tickers      map[string]types.Ticker    `json:"tickers"`
+	Stats        ExecutionStats             `json:"stats"`
+	FeeVolume    map[time.Time]float64      `json:"fee_volume,omitempty"` // Traded volume per UTC day of the fee schedule
+	FeeTier      int                        `json:"fee_tier,omitempty"`
+}
+
+// SaveState copies the executor's state
+func (s *SimulationExecutor) SaveState() SimulationState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state := SimulationState{
+		Balance:      s.balance,
+		Positions:    make(map[string]*types.Position, len(s.positions)),
+		Leverage:     make(map[string]float64, len(s.leverage)),
+		MarginCall:   s.marginCall,
+		OpenOrders:   make([]*types.Order, 0, len(s.openOrders)),
+		OrderHistory: make([]*types.Order, len(s.orderHistory)),
+		OrderCounter: s.orderCounter,
+		Transfers:    s.transfers,
+		Tickers:      make(map[string]types.Ticker, len(s.tickers)),
+		Stats:        s.stats,
+	}
+	for key, position := range s.positions {
+		copied := *position
+		state.Positions[key] = &copied
+	}
+	for symbol, leverage := range s.leverage {
+		state.Leverage[symbol] = leverage
+	}
+	for _, order := range s.openOrders {
+		copied := *order
+		state.OpenOrders = append(state.OpenOrders, &copied)
+	}
+	for i, order := range s.orderHistory {
+		copied := *order
+		state.OrderHistory[i] = &copied
+	}
+	for symbol, ticker := range s.tickers {
+		state.Tickers[symbol] = ticker
+	}
+	if s.fees != nil {
+		state.FeeVolume = make(map[time.Time]float64, len(s.fees.daily))
+		for day, volume := range s.fees.daily {
+			state.FeeVolume[day] = volume
+		}
+		state.FeeTier = s.fees.current
+	}
+	return state
+}
+
+// RestoreState replaces the executor's state with a saved one; resting orders keep matching against later
+// prices and client order IDs stay deduplicated
+func (s *SimulationExecutor) RestoreState(state SimulationState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balance = state.Balance
+	s.marginCall = state.MarginCall
+	s.orderCounter = state.OrderCounter
+	s.transfers = state.Transfers
+	s.stats = state.Stats
+
+	s.positions = make(map[string]*types.Position, len(state.Positions))
+	for key, position := range state.Positions {
+		s.positions[key] = position
+	}
+	s.leverage = make(map[string]float64, len(state.Leverage))
+	for symbol, leverage := range state.Leverage {
+		s.leverage[symbol] = leverage
+	}
+	s.tickers = make(map[string]types.Ticker, len(state.Tickers))
+	for symbol, ticker := range state.Tickers {
+		s.tickers[symbol] = ticker
+	}
+
+	s.openOrders = make(map[string]*types.Order, len(state.OpenOrders))
+	s.clientOrders = make(map[string]*types.Order)
+	for _, order := range state.OpenOrders {
+		s.openOrders[order.ID] = order
+	}
+	s.orderHistory = make([]*types.Order, len(state.OrderHistory))
+	for i, order := range state.OrderHistory {
+		// A partially filled order is both archived and open; keep it one order
+		if open, exists := s.openOrders[order.ID]; exists {
+			order = open
+		}
+		s.orderHistory[i] = order
+	}
+	for _, order := range s.orderHistory {
+		if order.ClientOrderID != "" {
+			s.clientOrders[order.ClientOrderID] = order
+		}
+	}
+	for _, order := range s.openOrders {
+		if order.ClientOrderID != "" {
+			s.clientOrders[order.ClientOrderID] = order
+		}
+	}
+
+	if s.fees != nil {
+		s.fees.daily = make(map[time.Time]float64, len(state.FeeVolume))
+		for day, volume := range state.FeeVolume {
+			s.fees.daily[day] = volume
+		}
+		s.fees.current = state.FeeTier
+	}
+}